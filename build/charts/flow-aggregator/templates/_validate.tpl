@@ -1,7 +1,12 @@
 {{- define "validateReplicas" -}}
   {{- if eq .Values.mode "Aggregate"}}
-    {{- if gt (int .Values.replicas) 1 }}
-      {{- fail "Flow-aggregator can only have at most 1 replica in 'Aggregate' mode." }}
+    {{- if and (gt (int .Values.replicas) 1) (not .Values.sharding.enable) }}
+      {{- fail "Flow-aggregator can only have at most 1 replica in 'Aggregate' mode, unless sharding.enable is true." }}
+    {{- end }}
+  {{- end }}
+  {{- if and (gt (int .Values.replicas) 1) (or .Values.clickHouse.spool.enable .Values.s3Uploader.spool.enable) }}
+    {{- if or .Values.spool.existingClaim .Values.spool.persistentVolumeClaim.create }}
+      {{- fail "A PersistentVolumeClaim cannot be used for the spools when flow-aggregator has more than 1 replica." }}
     {{- end }}
   {{- end }}
 {{- end }}
@@ -39,6 +39,7 @@ import (
 	"antrea.io/antrea/v2/pkg/agent/cniserver"
 	"antrea.io/antrea/v2/pkg/agent/cniserver/ipam"
 	"antrea.io/antrea/v2/pkg/agent/config"
+	"antrea.io/antrea/v2/pkg/agent/connectivityprobe"
 	"antrea.io/antrea/v2/pkg/agent/controller/bgp"
 	"antrea.io/antrea/v2/pkg/agent/controller/egress"
 	"antrea.io/antrea/v2/pkg/agent/controller/ipseccertificate"
@@ -82,6 +83,7 @@ import (
 	ofconfig "antrea.io/antrea/v2/pkg/ovs/openflow"
 	"antrea.io/antrea/v2/pkg/ovs/ovsconfig"
 	"antrea.io/antrea/v2/pkg/ovs/ovsctl"
+	antreaquerier "antrea.io/antrea/v2/pkg/querier"
 	"antrea.io/antrea/v2/pkg/signals"
 	"antrea.io/antrea/v2/pkg/util/channel"
 	"antrea.io/antrea/v2/pkg/util/k8s"
@@ -123,6 +125,7 @@ func run(o *Options) error {
 	crdInformerFactory := crdinformers.NewSharedInformerFactoryWithOptions(crdClient, informerDefaultResync, crdinformers.WithTransform(k8s.NewTrimmer()))
 	traceflowInformer := crdInformerFactory.Crd().V1beta1().Traceflows()
 	packetCaptureInformer := crdInformerFactory.Crd().V1alpha1().PacketCaptures()
+	connectivityProbeInformer := crdInformerFactory.Crd().V1alpha1().ConnectivityProbes()
 	egressInformer := crdInformerFactory.Crd().V1beta1().Egresses()
 	externalIPPoolInformer := crdInformerFactory.Crd().V1beta1().ExternalIPPools()
 	trafficControlInformer := crdInformerFactory.Crd().V1alpha2().TrafficControls()
@@ -522,7 +525,12 @@ func run(o *Options) error {
 	}
 	var l7Reconciler *l7engine.Reconciler
 	if l7NetworkPolicyEnabled {
-		l7Reconciler = l7engine.NewReconciler(ofClient)
+		_, l7EngineType := config.GetL7EngineTypeFromStr(o.config.L7NetworkPolicy.Engine)
+		l7Engine, err := l7engine.NewEngine(l7EngineType)
+		if err != nil {
+			return fmt.Errorf("error creating L7 engine: %v", err)
+		}
+		l7Reconciler = l7engine.NewReconciler(ofClient, l7Engine)
 	}
 	networkPolicyController, err := networkpolicy.NewNetworkPolicyController(
 		antreaClientProvider,
@@ -707,16 +715,41 @@ func run(o *Options) error {
 	}
 
 	var packetCaptureController *packetcapture.Controller
+	var policyRuleDropChannel *channel.SubscribableChannel
 	if features.DefaultFeatureGate.Enabled(features.PacketCapture) {
+		// The packets dropped by Antrea-native policy rules can trigger RingBuffer captures.
+		policyRuleDropChannel = channel.NewSubscribableChannel("PolicyRuleDrop", 100)
 		packetCaptureController, err = packetcapture.NewPacketCaptureController(
 			k8sClient,
 			crdClient,
 			packetCaptureInformer,
 			ifaceStore,
+			nodeConfig,
+			networkConfig,
+			networkPolicyController,
+			enableFlowExporter,
+			policyRuleDropChannel,
 		)
 		if err != nil {
 			return fmt.Errorf("error when creating PacketCapture controller: %v", err)
 		}
+		networkPolicyController.SetPolicyRuleDropNotifier(policyRuleDropChannel, packetCaptureController.HasPolicyRuleTriggers)
+	}
+
+	var connectivityProbeController *connectivityprobe.Controller
+	if features.DefaultFeatureGate.Enabled(features.ConnectivityProbe) {
+		connectivityProbeController, err = connectivityprobe.NewConnectivityProbeController(
+			k8sClient,
+			connectivityProbeInformer,
+			localPodInformer.Get(),
+			namespaceInformer,
+			serviceInformer,
+			ofClient,
+			ifaceStore,
+		)
+		if err != nil {
+			return fmt.Errorf("error when creating ConnectivityProbe controller: %v", err)
+		}
 	}
 
 	if err := antreaClientProvider.RunOnce(); err != nil {
@@ -737,6 +770,7 @@ func run(o *Options) error {
 			PollInterval:            o.pollInterval,
 			ConnectUplinkToBridge:   connectUplinkToBridge,
 			ProtocolFilter:          o.config.FlowExporter.ProtocolFilter,
+			EnableSharding:          o.config.FlowExporter.EnableSharding,
 		}
 		flowExporter, err = flowexporter.NewFlowExporter(
 			podStore,
@@ -819,8 +853,12 @@ func run(o *Options) error {
 			egressInformer,
 			bgpPolicyInformer,
 			endpointSliceInformer,
+			localPodInformer.Get(),
+			namespaceInformer,
 			o.enableEgress,
 			k8sClient,
+			routeClient,
+			serviceCIDRProvider,
 			nodeConfig,
 			networkConfig)
 		if err != nil {
@@ -841,6 +879,15 @@ func run(o *Options) error {
 		go tcController.Run(stopCh)
 	}
 
+	// The informers used to redact support bundles must be initialized before the informers are started.
+	var supportBundlePodInformer cache.SharedIndexInformer
+	var supportBundleServiceInformer coreinformers.ServiceInformer
+	if features.DefaultFeatureGate.Enabled(features.SupportBundleCollection) && o.nodeType == config.K8sNode {
+		supportBundlePodInformer = localPodInformer.Get()
+		supportBundleServiceInformer = serviceInformer
+		serviceInformer.Informer()
+	}
+
 	//  Start the localPodInformer
 	if localPodInformer.Evaluated() {
 		go localPodInformer.Get().Run(stopCh)
@@ -849,6 +896,7 @@ func run(o *Options) error {
 	var nodeLatencyMonitor *monitortool.NodeLatencyMonitor
 	if features.DefaultFeatureGate.Enabled(features.NodeLatencyMonitor) && o.nodeType == config.K8sNode {
 		nodeLatencyMonitor = monitortool.NewNodeLatencyMonitor(
+			k8sClient,
 			antreaClientProvider,
 			nodeInformer,
 			nodeLatencyMonitorInformer,
@@ -874,9 +922,14 @@ func run(o *Options) error {
 	}
 
 	if features.DefaultFeatureGate.Enabled(features.PacketCapture) {
+		go policyRuleDropChannel.Run(stopCh)
 		go packetCaptureController.Run(stopCh)
 	}
 
+	if features.DefaultFeatureGate.Enabled(features.ConnectivityProbe) {
+		go connectivityProbeController.Run(stopCh)
+	}
+
 	if o.enableAntreaProxy {
 		go proxyServer.Run(ctx)
 
@@ -896,6 +949,9 @@ func run(o *Options) error {
 	}
 
 	go networkPolicyController.Run(stopCh)
+	if l7NetworkPolicyEnabled {
+		go l7Reconciler.Run(stopCh)
+	}
 	if o.enableEgress {
 		go egressController.Run(stopCh)
 	}
@@ -986,6 +1042,14 @@ func run(o *Options) error {
 		go statsCollector.Run(stopCh)
 	}
 
+	var nodeLatencyMonitorQuerier antreaquerier.AgentNodeLatencyMonitorQuerier
+	if nodeLatencyMonitor != nil {
+		nodeLatencyMonitorQuerier = nodeLatencyMonitor
+	}
+	var connectivityProbeQuerier antreaquerier.AgentConnectivityProbeQuerier
+	if connectivityProbeController != nil {
+		connectivityProbeQuerier = connectivityProbeController
+	}
 	agentQuerier := querier.NewAgentQuerier(
 		nodeConfig,
 		networkConfig,
@@ -1000,6 +1064,8 @@ func run(o *Options) error {
 		memberlistCluster,
 		nodeInformer.Lister(),
 		bgpController,
+		nodeLatencyMonitorQuerier,
+		connectivityProbeQuerier,
 	)
 
 	if features.DefaultFeatureGate.Enabled(features.SupportBundleCollection) {
@@ -1010,7 +1076,8 @@ func run(o *Options) error {
 			nodeType = controlplane.SupportBundleCollectionNodeTypeExternalNode
 		}
 		supportBundleController := support.NewSupportBundleController(nodeConfig.Name, nodeType, nodeNamespace, antreaClientProvider,
-			ovsctl.NewClient(o.config.OVSBridge), agentQuerier, networkPolicyController, v4Enabled, v6Enabled)
+			ovsctl.NewClient(o.config.OVSBridge), agentQuerier, networkPolicyController, supportBundlePodInformer, supportBundleServiceInformer,
+			v4Enabled, v6Enabled)
 		go supportBundleController.Run(stopCh)
 	}
 
@@ -1031,6 +1098,7 @@ func run(o *Options) error {
 		mcastController,
 		externalIPController,
 		bgpController,
+		packetCaptureController,
 		secureServing,
 		authentication,
 		authorization,
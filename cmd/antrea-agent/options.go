@@ -153,6 +153,10 @@ func (o *Options) validate(args []string) error {
 		return err
 	}
 
+	if err := o.validateL7NetworkPolicyConfig(); err != nil {
+		return err
+	}
+
 	if config.ExternalNode.String() == o.config.NodeType && !features.DefaultFeatureGate.Enabled(features.ExternalNode) {
 		return fmt.Errorf("nodeType %s requires feature gate ExternalNode to be enabled", o.config.NodeType)
 	}
@@ -215,6 +219,9 @@ func (o *Options) setDefaults() {
 		o.config.PacketInRate = defaultPacketInRate
 	}
 	o.setAuditLoggingDefaultOptions()
+	if o.config.L7NetworkPolicy.Engine == "" {
+		o.config.L7NetworkPolicy.Engine = config.L7EngineSuricata.String()
+	}
 }
 
 func (o *Options) validateTLSOptions() error {
@@ -304,6 +311,11 @@ func (o *Options) validateFlowExporterConfig() error {
 			if err != nil {
 				return err
 			}
+			if o.config.FlowExporter.EnableSharding {
+				if ns, _ := k8s.SplitNamespacedName(host); ns == "" {
+					return fmt.Errorf("flowExporter.flowCollectorAddr must reference a Service when flowExporter.enableSharding is true")
+				}
+			}
 			o.flowCollectorAddr = net.JoinHostPort(host, port)
 			o.flowCollectorProto = proto
 		}
@@ -860,3 +872,10 @@ func (o *Options) validateHostNetworkModeOptions() error {
 	}
 	return nil
 }
+
+func (o *Options) validateL7NetworkPolicyConfig() error {
+	if ok, _ := config.GetL7EngineTypeFromStr(o.config.L7NetworkPolicy.Engine); !ok {
+		return fmt.Errorf("L7NetworkPolicy engine %q is unknown", o.config.L7NetworkPolicy.Engine)
+	}
+	return nil
+}
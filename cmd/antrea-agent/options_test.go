@@ -489,3 +489,40 @@ func TestOptionsValidateHostNetworkMode(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionsValidateL7NetworkPolicyConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		engine      string
+		expectedErr string
+	}{
+		{
+			name:   "suricata",
+			engine: "suricata",
+		},
+		{
+			name:   "builtin",
+			engine: "builtin",
+		},
+		{
+			name:        "invalid",
+			engine:      "snort",
+			expectedErr: "L7NetworkPolicy engine \"snort\" is unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				L7NetworkPolicy: agentconfig.L7NetworkPolicyConfig{Engine: tt.engine},
+			}}
+
+			err := o.validateL7NetworkPolicyConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -49,7 +49,9 @@ import (
 	crdinformers "antrea.io/antrea/v2/pkg/client/informers/externalversions"
 	crdv1a2informers "antrea.io/antrea/v2/pkg/client/informers/externalversions/crd/v1alpha2"
 	"antrea.io/antrea/v2/pkg/clusteridentity"
+	"antrea.io/antrea/v2/pkg/controller/bgppolicy"
 	"antrea.io/antrea/v2/pkg/controller/certificatesigningrequest"
+	"antrea.io/antrea/v2/pkg/controller/connectivityprobe"
 	"antrea.io/antrea/v2/pkg/controller/egress"
 	egressstore "antrea.io/antrea/v2/pkg/controller/egress/store"
 	"antrea.io/antrea/v2/pkg/controller/externalippool"
@@ -60,6 +62,7 @@ import (
 	"antrea.io/antrea/v2/pkg/controller/metrics"
 	"antrea.io/antrea/v2/pkg/controller/networkpolicy"
 	"antrea.io/antrea/v2/pkg/controller/networkpolicy/store"
+	"antrea.io/antrea/v2/pkg/controller/packetcapture"
 	"antrea.io/antrea/v2/pkg/controller/querier"
 	"antrea.io/antrea/v2/pkg/controller/serviceexternalip"
 	"antrea.io/antrea/v2/pkg/controller/stats"
@@ -207,11 +210,30 @@ func run(o *Options) error {
 		externalNodeController = externalnode.NewExternalNodeController(crdClient, externalNodeInformer, eeInformer)
 	}
 
+	// BGPPolicy is implemented by antrea-agents, which report the status of the effective BGPPolicy in their
+	// AntreaAgentInfos. The controller always aggregates it into the BGPPolicy status.
+	bgpPolicyStatusController := bgppolicy.NewStatusController(crdClient,
+		crdInformerFactory.Crd().V1alpha1().BGPPolicies(),
+		crdInformerFactory.Crd().V1beta1().AntreaAgentInfos(),
+		nodeInformer)
+
+	// Likewise, ConnectivityProbes are sent by antrea-agents, which report their results in their AntreaAgentInfos.
+	connectivityProbeStatusController := connectivityprobe.NewStatusController(crdClient,
+		crdInformerFactory.Crd().V1alpha1().ConnectivityProbes(),
+		crdInformerFactory.Crd().V1beta1().AntreaAgentInfos(),
+		nodeInformer)
+
+	var packetCaptureController *packetcapture.Controller
+	if features.DefaultFeatureGate.Enabled(features.PacketCapture) {
+		packetCaptureController = packetcapture.NewController(crdClient, crdInformerFactory.Crd().V1alpha1().PacketCaptures(), podInformer, nodeInformer)
+	}
+
 	var bundleCollectionController *supportbundlecollection.Controller
 	bundleCollectionStore := supportbundlecollectionstore.NewSupportBundleCollectionStore()
 	if features.DefaultFeatureGate.Enabled(features.SupportBundleCollection) {
 		bundleCollectionInformer := crdInformerFactory.Crd().V1alpha1().SupportBundleCollections()
-		bundleCollectionController = supportbundlecollection.NewSupportBundleCollectionController(client, crdClient, bundleCollectionInformer, nodeInformer, externalNodeInformer, bundleCollectionStore)
+		bundleCollectionController = supportbundlecollection.NewSupportBundleCollectionController(client, crdClient, bundleCollectionInformer, nodeInformer, externalNodeInformer,
+			podInformer, serviceInformer, networkPolicyInformer, acnpInformer, annpInformer, bundleCollectionStore)
 	}
 
 	var networkPolicyStatusController *networkpolicy.StatusController
@@ -421,6 +443,14 @@ func run(o *Options) error {
 		go externalNodeController.Run(stopCh)
 	}
 
+	go bgpPolicyStatusController.Run(stopCh)
+
+	go connectivityProbeStatusController.Run(stopCh)
+
+	if features.DefaultFeatureGate.Enabled(features.PacketCapture) {
+		go packetCaptureController.Run(stopCh)
+	}
+
 	if features.DefaultFeatureGate.Enabled(features.SupportBundleCollection) {
 		go bundleCollectionController.Run(stopCh)
 	}
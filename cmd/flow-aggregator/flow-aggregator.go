@@ -27,6 +27,7 @@ import (
 
 	aggregator "antrea.io/antrea/v2/pkg/flowaggregator"
 	"antrea.io/antrea/v2/pkg/flowaggregator/apiserver"
+	"antrea.io/antrea/v2/pkg/flowaggregator/metrics"
 	"antrea.io/antrea/v2/pkg/log"
 	"antrea.io/antrea/v2/pkg/signals"
 	"antrea.io/antrea/v2/pkg/util/cipher"
@@ -51,6 +52,8 @@ func run(configFile string) error {
 
 	log.StartLogFileNumberMonitor(stopCh)
 
+	metrics.InitializePrometheusMetrics()
+
 	k8sClient, err := createK8sClient()
 	if err != nil {
 		return fmt.Errorf("error when creating K8s client: %w", err)
@@ -72,7 +72,7 @@ MOCKGEN_TARGETS=(
   "pkg/agent/memberlist Memberlist ."
   "pkg/agent/multicast RouteInterface testing"
   "pkg/agent/types McastNetworkPolicyController,CNIDeleteChecker testing"
-  "pkg/agent/monitortool PacketListener testing"
+  "pkg/agent/monitortool PacketListener,TCPProber testing"
   "pkg/agent/nodeportlocal/portcache LocalPortOpener testing"
   "pkg/agent/nodeportlocal/rules PodPortRules testing"
   "pkg/agent/openflow Client testing"
@@ -98,7 +98,7 @@ MOCKGEN_TARGETS=(
   "pkg/ovs/ovsconfig OVSBridgeClient testing"
   "pkg/ovs/ovsctl OVSCtlClient testing"
   "pkg/ovs/ovsctl OVSOfctlRunner,OVSAppctlRunner ."
-  "pkg/querier AgentNetworkPolicyInfoQuerier,AgentMulticastInfoQuerier,EgressQuerier,AgentBGPPolicyInfoQuerier testing"
+  "pkg/querier AgentNetworkPolicyInfoQuerier,AgentMulticastInfoQuerier,EgressQuerier,AgentBGPPolicyInfoQuerier,AgentPacketCaptureQuerier,AgentNodeLatencyMonitorQuerier,AgentConnectivityProbeQuerier testing"
   "pkg/flowaggregator/intermediate AggregationProcess testing"
   "pkg/flowaggregator/querier FlowAggregatorQuerier testing"
   "pkg/flowaggregator/s3uploader S3UploaderAPI testing"
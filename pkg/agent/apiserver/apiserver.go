@@ -46,6 +46,7 @@ import (
 	"antrea.io/antrea/v2/pkg/agent/apiserver/handlers/networkpolicy"
 	"antrea.io/antrea/v2/pkg/agent/apiserver/handlers/ovsflows"
 	"antrea.io/antrea/v2/pkg/agent/apiserver/handlers/ovstracing"
+	"antrea.io/antrea/v2/pkg/agent/apiserver/handlers/packetcapture"
 	"antrea.io/antrea/v2/pkg/agent/apiserver/handlers/podinterface"
 	"antrea.io/antrea/v2/pkg/agent/apiserver/handlers/serviceexternalip"
 	agentquerier "antrea.io/antrea/v2/pkg/agent/querier"
@@ -89,7 +90,7 @@ func (s *agentAPIServer) GetCertData() []byte {
 	return cert
 }
 
-func installHandlers(aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, mq querier.AgentMulticastInfoQuerier, seipq querier.ServiceExternalIPStatusQuerier, s *genericapiserver.GenericAPIServer, bgpq querier.AgentBGPPolicyInfoQuerier) {
+func installHandlers(aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, mq querier.AgentMulticastInfoQuerier, seipq querier.ServiceExternalIPStatusQuerier, s *genericapiserver.GenericAPIServer, bgpq querier.AgentBGPPolicyInfoQuerier, pcq querier.AgentPacketCaptureQuerier) {
 	s.Handler.NonGoRestfulMux.HandleFunc("/loglevel", loglevel.HandleFunc())
 	s.Handler.NonGoRestfulMux.HandleFunc("/podmulticaststats", multicast.HandleFunc(mq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/featuregates", featuregates.HandleFunc())
@@ -106,6 +107,7 @@ func installHandlers(aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolic
 	s.Handler.NonGoRestfulMux.HandleFunc("/bgppeers", bgppeer.HandleFunc(bgpq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/bgproutes", bgproute.HandleFunc(bgpq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/fqdncache", fqdncache.HandleFunc(npq))
+	s.Handler.NonGoRestfulMux.HandleFunc("/packetcaptures", packetcapture.HandleFunc(pcq))
 }
 
 func installAPIGroup(s *genericapiserver.GenericAPIServer, aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, v4Enabled, v6Enabled bool) error {
@@ -124,6 +126,7 @@ func New(aq agentquerier.AgentQuerier,
 	mq querier.AgentMulticastInfoQuerier,
 	seipq querier.ServiceExternalIPStatusQuerier,
 	bgpq querier.AgentBGPPolicyInfoQuerier,
+	pcq querier.AgentPacketCaptureQuerier,
 	secureServing *genericoptions.SecureServingOptionsWithLoopback,
 	authentication *genericoptions.DelegatingAuthenticationOptions,
 	authorization *genericoptions.DelegatingAuthorizationOptions,
@@ -144,7 +147,7 @@ func New(aq agentquerier.AgentQuerier,
 	if err := installAPIGroup(s, aq, npq, v4Enabled, v6Enabled); err != nil {
 		return nil, err
 	}
-	installHandlers(aq, npq, mq, seipq, s, bgpq)
+	installHandlers(aq, npq, mq, seipq, s, bgpq, pcq)
 	return &agentAPIServer{GenericAPIServer: s}, nil
 }
 
@@ -196,6 +199,15 @@ func newConfig(aq agentquerier.AgentQuerier,
 		return fmt.Errorf("some watchers may not be connected")
 	})
 	serverConfig.ReadyzChecks = append(serverConfig.ReadyzChecks, watcherCheck)
+	// Add readiness probe to check the status of the L7 engine, which is only started when there are L7 NetworkPolicy
+	// rules.
+	l7EngineCheck := healthz.NamedCheck("l7engine", func(_ *http.Request) error {
+		if err := npq.GetL7EngineHealth(); err != nil {
+			return fmt.Errorf("L7 engine is not healthy: %w", err)
+		}
+		return nil
+	})
+	serverConfig.ReadyzChecks = append(serverConfig.ReadyzChecks, l7EngineCheck)
 	// Add liveness probe to check the connection with OFSwitch.
 	// This helps automatic recovery if some issues cause OFSwitch reconnection to not work properly, e.g. issue #4092.
 	ovsConnCheck := healthz.NamedCheck("ovs", func(_ *http.Request) error {
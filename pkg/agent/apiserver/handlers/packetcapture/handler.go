@@ -0,0 +1,59 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"antrea.io/antrea/v2/pkg/agent/packetcapture"
+	"antrea.io/antrea/v2/pkg/querier"
+)
+
+// HandleFunc returns the function which can handle the requests to download the packets file of a PacketCapture,
+// which is used by the packetcapture command when no file server is configured.
+func HandleFunc(pcq querier.AgentPacketCaptureQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if pcq == nil || reflect.ValueOf(pcq).IsNil() {
+			// The error message must match the "FOO is not enabled" pattern to pass antctl e2e tests.
+			http.Error(w, "packetcapture is not enabled", http.StatusServiceUnavailable)
+			return
+		}
+
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			http.Error(w, "name must be provided", http.StatusBadRequest)
+			return
+		}
+		file, err := pcq.GetPacketCaptureFile(name)
+		if err != nil {
+			if errors.Is(err, packetcapture.ErrPacketCaptureFileNotFound) {
+				http.Error(w, fmt.Sprintf("packets file of PacketCapture %s not found on the Node", name), http.StatusNotFound)
+			} else {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+		defer file.Close()
+
+		fileName := name + ".pcapng"
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+		http.ServeContent(w, r, fileName, time.Time{}, file)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"antrea.io/antrea/v2/pkg/agent/packetcapture"
+	queriertest "antrea.io/antrea/v2/pkg/querier/testing"
+)
+
+type testFile struct {
+	*strings.Reader
+	closed bool
+}
+
+func (f *testFile) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestPacketCaptureQuery(t *testing.T) {
+	tests := []struct {
+		name             string
+		url              string
+		file             *testFile
+		err              error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "get packets file",
+			url:              "?name=pc1",
+			file:             &testFile{Reader: strings.NewReader("packets")},
+			expectedStatus:   http.StatusOK,
+			expectedResponse: "packets",
+		},
+		{
+			name:             "packets file not found",
+			url:              "?name=pc1",
+			err:              packetcapture.ErrPacketCaptureFileNotFound,
+			expectedStatus:   http.StatusNotFound,
+			expectedResponse: "packets file of PacketCapture pc1 not found on the Node\n",
+		},
+		{
+			name:             "failed to open packets file",
+			url:              "?name=pc1",
+			err:              errors.New("permission denied"),
+			expectedStatus:   http.StatusInternalServerError,
+			expectedResponse: "permission denied\n",
+		},
+		{
+			name:             "missing name",
+			url:              "",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: "name must be provided\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			q := queriertest.NewMockAgentPacketCaptureQuerier(ctrl)
+			if tt.file != nil {
+				q.EXPECT().GetPacketCaptureFile("pc1").Return(tt.file, nil)
+			} else if tt.err != nil {
+				q.EXPECT().GetPacketCaptureFile("pc1").Return(nil, tt.err)
+			}
+			handler := HandleFunc(q)
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			body, err := io.ReadAll(recorder.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResponse, string(body))
+			if tt.file != nil {
+				assert.Equal(t, `attachment; filename="pc1.pcapng"`, recorder.Header().Get("Content-Disposition"))
+				assert.True(t, tt.file.closed)
+			}
+		})
+	}
+}
+
+func TestPacketCaptureNotEnabled(t *testing.T) {
+	handler := HandleFunc(nil)
+	req, err := http.NewRequest(http.MethodGet, "?name=pc1", nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "packetcapture is not enabled\n", recorder.Body.String())
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// State is the state of a BFD session, as defined in RFC 5880.
+type State uint8
+
+const (
+	StateAdminDown State = iota
+	StateDown
+	StateInit
+	StateUp
+)
+
+func (s State) String() string {
+	switch s {
+	case StateAdminDown:
+		return "AdminDown"
+	case StateDown:
+		return "Down"
+	case StateInit:
+		return "Init"
+	case StateUp:
+		return "Up"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(s))
+	}
+}
+
+// Diagnostic is the reason for the last change of the state of a BFD session, as defined in RFC 5880.
+type Diagnostic uint8
+
+const (
+	DiagnosticNone Diagnostic = iota
+	DiagnosticControlDetectionTimeExpired
+	DiagnosticEchoFunctionFailed
+	DiagnosticNeighborSignaledSessionDown
+	DiagnosticForwardingPlaneReset
+	DiagnosticPathDown
+	DiagnosticConcatenatedPathDown
+	DiagnosticAdministrativelyDown
+	DiagnosticReverseConcatenatedPathDown
+)
+
+func (d Diagnostic) String() string {
+	switch d {
+	case DiagnosticNone:
+		return "None"
+	case DiagnosticControlDetectionTimeExpired:
+		return "ControlDetectionTimeExpired"
+	case DiagnosticEchoFunctionFailed:
+		return "EchoFunctionFailed"
+	case DiagnosticNeighborSignaledSessionDown:
+		return "NeighborSignaledSessionDown"
+	case DiagnosticForwardingPlaneReset:
+		return "ForwardingPlaneReset"
+	case DiagnosticPathDown:
+		return "PathDown"
+	case DiagnosticConcatenatedPathDown:
+		return "ConcatenatedPathDown"
+	case DiagnosticAdministrativelyDown:
+		return "AdministrativelyDown"
+	case DiagnosticReverseConcatenatedPathDown:
+		return "ReverseConcatenatedPathDown"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(d))
+	}
+}
+
+const (
+	protocolVersion = 1
+	// controlPacketLength is the length of the mandatory section of a BFD Control packet.
+	controlPacketLength = 24
+
+	flagPoll          = 0x20
+	flagFinal         = 0x10
+	flagAuthPresent   = 0x04
+	flagMultipoint    = 0x01
+	diagnosticMask    = 0x1f
+	stateShift        = 6
+	versionShift      = 5
+	intervalPrecision = time.Microsecond
+)
+
+// controlPacket is a BFD Control packet, as defined in RFC 5880 section 4.1. The Control Plane Independent, Demand and
+// Multipoint bits are never set, and authentication is not supported.
+type controlPacket struct {
+	diagnostic                Diagnostic
+	state                     State
+	poll                      bool
+	final                     bool
+	detectMultiplier          uint8
+	myDiscriminator           uint32
+	yourDiscriminator         uint32
+	desiredMinTxInterval      time.Duration
+	requiredMinRxInterval     time.Duration
+	requiredMinEchoRxInterval time.Duration
+}
+
+func (p *controlPacket) marshal() []byte {
+	b := make([]byte, controlPacketLength)
+	b[0] = protocolVersion<<versionShift | uint8(p.diagnostic)&diagnosticMask
+	b[1] = uint8(p.state) << stateShift
+	if p.poll {
+		b[1] |= flagPoll
+	}
+	if p.final {
+		b[1] |= flagFinal
+	}
+	b[2] = p.detectMultiplier
+	b[3] = controlPacketLength
+	binary.BigEndian.PutUint32(b[4:8], p.myDiscriminator)
+	binary.BigEndian.PutUint32(b[8:12], p.yourDiscriminator)
+	binary.BigEndian.PutUint32(b[12:16], uint32(p.desiredMinTxInterval/intervalPrecision))
+	binary.BigEndian.PutUint32(b[16:20], uint32(p.requiredMinRxInterval/intervalPrecision))
+	binary.BigEndian.PutUint32(b[20:24], uint32(p.requiredMinEchoRxInterval/intervalPrecision))
+	return b
+}
+
+// parseControlPacket parses a BFD Control packet, and validates it according to RFC 5880 section 6.8.6.
+func parseControlPacket(b []byte) (*controlPacket, error) {
+	if len(b) < controlPacketLength {
+		return nil, fmt.Errorf("packet is too short: %d bytes", len(b))
+	}
+	if version := b[0] >> versionShift; version != protocolVersion {
+		return nil, fmt.Errorf("unsupported version %d", version)
+	}
+	if length := int(b[3]); length < controlPacketLength || length > len(b) {
+		return nil, fmt.Errorf("invalid length %d", length)
+	}
+	if b[1]&flagAuthPresent != 0 {
+		return nil, fmt.Errorf("authentication is not supported")
+	}
+	if b[1]&flagMultipoint != 0 {
+		return nil, fmt.Errorf("multipoint bit is set")
+	}
+	p := &controlPacket{
+		diagnostic:                Diagnostic(b[0] & diagnosticMask),
+		state:                     State(b[1] >> stateShift),
+		poll:                      b[1]&flagPoll != 0,
+		final:                     b[1]&flagFinal != 0,
+		detectMultiplier:          b[2],
+		myDiscriminator:           binary.BigEndian.Uint32(b[4:8]),
+		yourDiscriminator:         binary.BigEndian.Uint32(b[8:12]),
+		desiredMinTxInterval:      time.Duration(binary.BigEndian.Uint32(b[12:16])) * intervalPrecision,
+		requiredMinRxInterval:     time.Duration(binary.BigEndian.Uint32(b[16:20])) * intervalPrecision,
+		requiredMinEchoRxInterval: time.Duration(binary.BigEndian.Uint32(b[20:24])) * intervalPrecision,
+	}
+	if p.detectMultiplier == 0 {
+		return nil, fmt.Errorf("detect multiplier is zero")
+	}
+	if p.myDiscriminator == 0 {
+		return nil, fmt.Errorf("my discriminator is zero")
+	}
+	if p.yourDiscriminator == 0 && p.state != StateDown && p.state != StateAdminDown {
+		return nil, fmt.Errorf("your discriminator is zero in state %s", p.state)
+	}
+	return p, nil
+}
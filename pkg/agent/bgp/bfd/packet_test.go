@@ -0,0 +1,113 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlPacketMarshalAndParse(t *testing.T) {
+	p := &controlPacket{
+		diagnostic:            DiagnosticControlDetectionTimeExpired,
+		state:                 StateUp,
+		poll:                  true,
+		detectMultiplier:      3,
+		myDiscriminator:       0x12345678,
+		yourDiscriminator:     0x9abcdef0,
+		desiredMinTxInterval:  300 * time.Millisecond,
+		requiredMinRxInterval: time.Second,
+	}
+	b := p.marshal()
+	assert.Equal(t, []byte{
+		0x21, 0xe0, 0x03, 0x18,
+		0x12, 0x34, 0x56, 0x78,
+		0x9a, 0xbc, 0xde, 0xf0,
+		0x00, 0x04, 0x93, 0xe0,
+		0x00, 0x0f, 0x42, 0x40,
+		0x00, 0x00, 0x00, 0x00,
+	}, b)
+
+	parsed, err := parseControlPacket(b)
+	require.NoError(t, err)
+	assert.Equal(t, p, parsed)
+}
+
+func TestParseControlPacketErrors(t *testing.T) {
+	validPacket := func() []byte {
+		p := &controlPacket{
+			state:             StateInit,
+			detectMultiplier:  3,
+			myDiscriminator:   1,
+			yourDiscriminator: 2,
+		}
+		return p.marshal()
+	}
+	testCases := []struct {
+		name          string
+		mutate        func(b []byte) []byte
+		expectedError string
+	}{
+		{
+			name:          "too short",
+			mutate:        func(b []byte) []byte { return b[:20] },
+			expectedError: "packet is too short: 20 bytes",
+		},
+		{
+			name:          "unsupported version",
+			mutate:        func(b []byte) []byte { b[0] = 0x40; return b },
+			expectedError: "unsupported version 2",
+		},
+		{
+			name:          "invalid length",
+			mutate:        func(b []byte) []byte { b[3] = 26; return b },
+			expectedError: "invalid length 26",
+		},
+		{
+			name:          "authentication",
+			mutate:        func(b []byte) []byte { b[1] |= flagAuthPresent; return b },
+			expectedError: "authentication is not supported",
+		},
+		{
+			name:          "multipoint",
+			mutate:        func(b []byte) []byte { b[1] |= flagMultipoint; return b },
+			expectedError: "multipoint bit is set",
+		},
+		{
+			name:          "zero detect multiplier",
+			mutate:        func(b []byte) []byte { b[2] = 0; return b },
+			expectedError: "detect multiplier is zero",
+		},
+		{
+			name:          "zero my discriminator",
+			mutate:        func(b []byte) []byte { b[7] = 0; return b },
+			expectedError: "my discriminator is zero",
+		},
+		{
+			name:          "zero your discriminator",
+			mutate:        func(b []byte) []byte { b[11] = 0; return b },
+			expectedError: "your discriminator is zero in state Init",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseControlPacket(tt.mutate(validPacket()))
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+	"golang.org/x/time/rate"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultPort is the destination UDP port of single-hop BFD Control packets (RFC 5881).
+	DefaultPort = 3784
+
+	// The source port of BFD Control packets must be in the range 49152 through 65535 (RFC 5881 section 4).
+	minSourcePort         = 49152
+	maxSourcePort         = 65535
+	maxSourcePortAttempts = 100
+
+	// BFD Control packets are sent with a TTL (or Hop Limit) of 255, and the received ones with a different value are
+	// discarded, to make sure that they are sent by a directly connected peer (RFC 5881 section 5).
+	ttl = 255
+
+	maxPacketSize = 512
+
+	// The delay before reading again from a socket after a read error, which is doubled after each consecutive error.
+	minReadErrorDelay = 10 * time.Millisecond
+	maxReadErrorDelay = time.Second
+)
+
+// Server runs single-hop BFD sessions in Asynchronous mode, as specified in RFC 5880 and RFC 5881. The Echo function,
+// the Demand mode and authentication are not supported.
+type Server struct {
+	listenAddresses []string
+	port            int
+	// handler is called when the state of a session changes. It must not block.
+	handler func(StateChange)
+
+	mutex    sync.RWMutex
+	sessions map[netip.Addr]*session
+	// sessionsByDiscriminator stores the sessions keyed by their local discriminators.
+	sessionsByDiscriminator map[uint32]*session
+	// listeners stores the sockets receiving BFD Control packets, keyed by network ("udp4" or "udp6"). They are
+	// created when the first session of the IP family is added.
+	listeners map[string][]net.PacketConn
+}
+
+// NewServer creates a BFD server which receives BFD Control packets on the given addresses and port. If no address of
+// an IP family is given, the packets of the IP family are received on all addresses.
+func NewServer(listenAddresses []string, port int, handler func(StateChange)) *Server {
+	return &Server{
+		listenAddresses:         listenAddresses,
+		port:                    port,
+		handler:                 handler,
+		sessions:                make(map[netip.Addr]*session),
+		sessionsByDiscriminator: make(map[uint32]*session),
+		listeners:               make(map[string][]net.PacketConn),
+	}
+}
+
+// AddSession starts a BFD session with the given peer. If localAddress is empty, the source address of BFD Control
+// packets is determined based on the routing decision.
+func (s *Server) AddSession(peerAddress, localAddress string, config SessionConfig) error {
+	peerAddr, err := netip.ParseAddr(peerAddress)
+	if err != nil {
+		return fmt.Errorf("invalid peer address %s: %w", peerAddress, err)
+	}
+	peerAddr = peerAddr.Unmap()
+	var localAddr netip.Addr
+	if localAddress != "" {
+		if localAddr, err = netip.ParseAddr(localAddress); err != nil {
+			return fmt.Errorf("invalid local address %s: %w", localAddress, err)
+		}
+		localAddr = localAddr.Unmap()
+	}
+	if config.Multiplier == 0 {
+		return fmt.Errorf("detection time multiplier must not be zero")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.sessions[peerAddr]; exists {
+		return fmt.Errorf("BFD session with peer %s already exists", peerAddress)
+	}
+	network := "udp4"
+	if peerAddr.Is6() {
+		network = "udp6"
+	}
+	if err := s.ensureListeners(network); err != nil {
+		return err
+	}
+	conn, err := listenSourcePort(network, localAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create socket for BFD session with peer %s: %w", peerAddress, err)
+	}
+	sess := newSession(peerAddress, peerAddr, s.port, config, s.newDiscriminator(), conn, s.handler)
+	s.sessions[peerAddr] = sess
+	s.sessionsByDiscriminator[sess.localDiscriminator] = sess
+	go sess.run()
+	klog.V(2).InfoS("Added BFD session", "peer", peerAddress, "config", config)
+	return nil
+}
+
+// RemoveSession stops the BFD session with the given peer. The peer is notified that the session is disabled
+// administratively.
+func (s *Server) RemoveSession(peerAddress string) {
+	peerAddr, err := netip.ParseAddr(peerAddress)
+	if err != nil {
+		return
+	}
+	s.mutex.Lock()
+	sess, exists := s.sessions[peerAddr.Unmap()]
+	if exists {
+		delete(s.sessions, sess.peerAddress)
+		delete(s.sessionsByDiscriminator, sess.localDiscriminator)
+	}
+	s.mutex.Unlock()
+	if exists {
+		sess.stop(true)
+		klog.V(2).InfoS("Removed BFD session", "peer", peerAddress)
+	}
+}
+
+// GetSessionState returns the state of the BFD session with the given peer, and whether the session exists.
+func (s *Server) GetSessionState(peerAddress string) (State, bool) {
+	peerAddr, err := netip.ParseAddr(peerAddress)
+	if err != nil {
+		return StateAdminDown, false
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	sess, exists := s.sessions[peerAddr.Unmap()]
+	if !exists {
+		return StateAdminDown, false
+	}
+	return sess.getState(), true
+}
+
+// Stop stops all the BFD sessions and closes the listening sockets. Unlike RemoveSession, the peers are not notified,
+// and they will detect that the sessions are down after the detection time.
+func (s *Server) Stop() {
+	s.mutex.Lock()
+	sessions := s.sessions
+	listeners := s.listeners
+	s.sessions = make(map[netip.Addr]*session)
+	s.sessionsByDiscriminator = make(map[uint32]*session)
+	s.listeners = make(map[string][]net.PacketConn)
+	s.mutex.Unlock()
+	for _, sess := range sessions {
+		sess.stop(false)
+	}
+	for _, conns := range listeners {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+}
+
+func (s *Server) newDiscriminator() uint32 {
+	for {
+		// #nosec G404: random number generator not used for security purposes.
+		discriminator := rand.Uint32()
+		if _, exists := s.sessionsByDiscriminator[discriminator]; discriminator != 0 && !exists {
+			return discriminator
+		}
+	}
+}
+
+// ensureListeners creates the sockets receiving BFD Control packets of the given network if they don't exist.
+func (s *Server) ensureListeners(network string) error {
+	if _, exists := s.listeners[network]; exists {
+		return nil
+	}
+	var addresses []string
+	for _, address := range s.listenAddresses {
+		if addr, err := netip.ParseAddr(address); err == nil && addr.Unmap().Is6() == (network == "udp6") {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		addresses = []string{""}
+	}
+	var conns []net.PacketConn
+	for _, address := range addresses {
+		conn, read, err := listen(network, net.JoinHostPort(address, strconv.Itoa(s.port)))
+		if err != nil {
+			for _, c := range conns {
+				c.Close()
+			}
+			return fmt.Errorf("failed to listen for BFD Control packets on %s: %w", address, err)
+		}
+		conns = append(conns, conn)
+		go s.receive(conn, read)
+	}
+	s.listeners[network] = conns
+	return nil
+}
+
+// readFunc reads a packet from a socket, and returns the number of bytes read, the TTL (or Hop Limit) of the packet
+// and the source address.
+type readFunc func(b []byte) (int, int, net.Addr, error)
+
+// listen creates a socket receiving BFD Control packets, and returns it with the function reading packets from it.
+func listen(network, address string) (net.PacketConn, readFunc, error) {
+	conn, err := net.ListenPacket(network, address)
+	if err != nil {
+		return nil, nil, err
+	}
+	var read readFunc
+	if network == "udp4" {
+		pc := ipv4.NewPacketConn(conn)
+		if err := pc.SetControlMessage(ipv4.FlagTTL, true); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		read = func(b []byte) (int, int, net.Addr, error) {
+			n, cm, src, err := pc.ReadFrom(b)
+			if err != nil || cm == nil {
+				return n, 0, src, err
+			}
+			return n, cm.TTL, src, nil
+		}
+	} else {
+		pc := ipv6.NewPacketConn(conn)
+		if err := pc.SetControlMessage(ipv6.FlagHopLimit, true); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		read = func(b []byte) (int, int, net.Addr, error) {
+			n, cm, src, err := pc.ReadFrom(b)
+			if err != nil || cm == nil {
+				return n, 0, src, err
+			}
+			return n, cm.HopLimit, src, nil
+		}
+	}
+	return conn, read, nil
+}
+
+// listenSourcePort creates a socket sending BFD Control packets, bound to a random source port in the range required
+// by RFC 5881.
+func listenSourcePort(network string, localAddr netip.Addr) (net.PacketConn, error) {
+	for range maxSourcePortAttempts {
+		// #nosec G404: random number generator not used for security purposes.
+		port := minSourcePort + rand.IntN(maxSourcePort-minSourcePort+1)
+		udpAddr := &net.UDPAddr{Port: port}
+		if localAddr.IsValid() {
+			udpAddr = net.UDPAddrFromAddrPort(netip.AddrPortFrom(localAddr, uint16(port)))
+		}
+		conn, err := net.ListenUDP(network, udpAddr)
+		if err != nil {
+			if errors.Is(err, syscall.EADDRINUSE) {
+				continue
+			}
+			return nil, err
+		}
+		if network == "udp4" {
+			err = ipv4.NewConn(conn).SetTTL(ttl)
+		} else {
+			err = ipv6.NewConn(conn).SetHopLimit(ttl)
+		}
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return conn, nil
+	}
+	return nil, fmt.Errorf("no source port available")
+}
+
+func (s *Server) receive(conn net.PacketConn, read readFunc) {
+	b := make([]byte, maxPacketSize)
+	// A read error may be persistent, so it is logged at most once per minute, and reading is retried with a backoff.
+	logRateLimiter := rate.NewLimiter(rate.Every(time.Minute), 1)
+	var readErrorDelay time.Duration
+	for {
+		n, packetTTL, src, err := read(b)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			readErrorDelay = min(max(2*readErrorDelay, minReadErrorDelay), maxReadErrorDelay)
+			if logRateLimiter.Allow() {
+				klog.ErrorS(err, "Failed to read BFD Control packet", "address", conn.LocalAddr(), "retryDelay", readErrorDelay)
+			}
+			time.Sleep(readErrorDelay)
+			continue
+		}
+		readErrorDelay = 0
+		udpAddr, ok := src.(*net.UDPAddr)
+		if !ok {
+			continue
+		}
+		srcAddr := udpAddr.AddrPort().Addr().Unmap()
+		if packetTTL != ttl {
+			klog.V(4).InfoS("Discarded BFD Control packet with invalid TTL", "source", srcAddr, "ttl", packetTTL)
+			continue
+		}
+		p, err := parseControlPacket(b[:n])
+		if err != nil {
+			klog.V(4).InfoS("Discarded invalid BFD Control packet", "source", srcAddr, "err", err)
+			continue
+		}
+		s.dispatch(srcAddr, p)
+	}
+}
+
+// dispatch delivers a received BFD Control packet to its session, which is selected by the Your Discriminator field
+// of the packet, or by the source address if the field is zero (RFC 5880 section 6.3).
+func (s *Server) dispatch(srcAddr netip.Addr, p *controlPacket) {
+	s.mutex.RLock()
+	var sess *session
+	if p.yourDiscriminator != 0 {
+		sess = s.sessionsByDiscriminator[p.yourDiscriminator]
+	} else {
+		sess = s.sessions[srcAddr]
+	}
+	s.mutex.RUnlock()
+	if sess == nil || sess.peerAddress != srcAddr {
+		klog.V(4).InfoS("Discarded BFD Control packet of unknown session", "source", srcAddr, "yourDiscriminator", p.yourDiscriminator)
+		return
+	}
+	sess.deliver(p)
+}
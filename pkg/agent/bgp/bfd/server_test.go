@@ -0,0 +1,135 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getFreeUDPPort(t *testing.T) int {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func TestServer(t *testing.T) {
+	port := getFreeUDPPort(t)
+	address1 := "127.0.0.1"
+	address2 := "127.0.0.2"
+	changes1 := make(chan StateChange, 100)
+	changes2 := make(chan StateChange, 100)
+	server1 := NewServer([]string{address1}, port, func(change StateChange) { changes1 <- change })
+	server2 := NewServer([]string{address2}, port, func(change StateChange) { changes2 <- change })
+	defer server1.Stop()
+	defer server2.Stop()
+
+	waitForState := func(changes chan StateChange, state State) StateChange {
+		t.Helper()
+		timer := time.NewTimer(10 * time.Second)
+		defer timer.Stop()
+		for {
+			select {
+			case change := <-changes:
+				if change.NewState == state {
+					return change
+				}
+			case <-timer.C:
+				require.FailNow(t, "Timed out waiting for BFD session state", "state", state)
+			}
+		}
+	}
+
+	require.NoError(t, server1.AddSession(address2, address1, testSessionConfig))
+	require.NoError(t, server2.AddSession(address1, address2, testSessionConfig))
+	assert.EqualError(t, server1.AddSession(address2, address1, testSessionConfig), "BFD session with peer 127.0.0.2 already exists")
+	waitForState(changes1, StateUp)
+	waitForState(changes2, StateUp)
+	state, exists := server1.GetSessionState(address2)
+	assert.True(t, exists)
+	assert.Equal(t, StateUp, state)
+
+	// The session is disabled administratively when it is removed, which is not a failure.
+	server2.RemoveSession(address1)
+	_, exists = server2.GetSessionState(address1)
+	assert.False(t, exists)
+	change := waitForState(changes1, StateDown)
+	assert.True(t, change.PeerAdminDown)
+	assert.False(t, change.IsFailure())
+
+	require.NoError(t, server2.AddSession(address1, address2, testSessionConfig))
+	waitForState(changes1, StateUp)
+	waitForState(changes2, StateUp)
+
+	// The peers are not notified when server2 is stopped, which is the same as a failure of the path.
+	server2.Stop()
+	start := time.Now()
+	change = waitForState(changes1, StateDown)
+	assert.Equal(t, DiagnosticControlDetectionTimeExpired, change.Diagnostic)
+	assert.True(t, change.IsFailure())
+	// The failure should be detected after the detection time (3 * 50ms).
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestServerReceiveReadError(t *testing.T) {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	synctest.Test(t, func(t *testing.T) {
+		var reads atomic.Int32
+		var closed atomic.Bool
+		read := func(b []byte) (int, int, net.Addr, error) {
+			reads.Add(1)
+			if closed.Load() {
+				return 0, 0, nil, net.ErrClosed
+			}
+			return 0, 0, nil, errors.New("read error")
+		}
+		server := NewServer(nil, DefaultPort, func(StateChange) {})
+		done := make(chan struct{})
+		go func() {
+			server.receive(conn, read)
+			close(done)
+		}()
+
+		// After consecutive errors, reading is retried after 10ms, 20ms, 40ms, etc., up to 1s.
+		time.Sleep(time.Second)
+		synctest.Wait()
+		assert.Equal(t, int32(7), reads.Load())
+		time.Sleep(10 * time.Second)
+		synctest.Wait()
+		assert.Equal(t, int32(17), reads.Load())
+
+		// Receiving stops once the socket is closed.
+		closed.Store(true)
+		time.Sleep(time.Second)
+		synctest.Wait()
+		select {
+		case <-done:
+		default:
+			assert.Fail(t, "Receiving did not stop after the socket was closed")
+		}
+		assert.Equal(t, int32(18), reads.Load())
+	})
+}
@@ -0,0 +1,290 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"math/rand/v2"
+	"net"
+	"net/netip"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// slowTxInterval is the minimum interval between the BFD Control packets sent when a session is not up, as
+	// required by RFC 5880 section 6.8.3.
+	slowTxInterval = time.Second
+	// rxQueueSize is the number of received BFD Control packets which can be queued for a session.
+	rxQueueSize = 16
+)
+
+// SessionConfig contains the configuration of a BFD session.
+type SessionConfig struct {
+	// MinTxInterval is the desired minimum interval between the BFD Control packets sent to the peer.
+	MinTxInterval time.Duration
+	// MinRxInterval is the required minimum interval between the BFD Control packets received from the peer.
+	MinRxInterval time.Duration
+	// Multiplier is the detection time multiplier.
+	Multiplier uint8
+}
+
+// StateChange describes a change of the state of a BFD session.
+type StateChange struct {
+	PeerAddress string
+	OldState    State
+	NewState    State
+	Diagnostic  Diagnostic
+	// PeerAdminDown is true if the session went down because the peer disabled it administratively.
+	PeerAdminDown bool
+}
+
+// IsFailure returns whether the change indicates a failure of the path to the peer. As specified in RFC 5882 section
+// 3.2, a session disabled administratively by the peer is not considered as a failure.
+func (c StateChange) IsFailure() bool {
+	return c.OldState == StateUp && c.NewState == StateDown && !c.PeerAdminDown
+}
+
+// session is a BFD session in Asynchronous mode. All the state of the session, except state, is only accessed by the
+// goroutine running the session.
+type session struct {
+	// address is the address of the peer as it is given when the session is added.
+	address            string
+	peerAddress        netip.Addr
+	peerUDPAddr        *net.UDPAddr
+	config             SessionConfig
+	localDiscriminator uint32
+	// conn is the socket used to send BFD Control packets to the peer.
+	conn    net.PacketConn
+	handler func(StateChange)
+
+	state                      atomic.Uint32
+	localDiagnostic            Diagnostic
+	remoteDiscriminator        uint32
+	remoteMinRxInterval        time.Duration
+	remoteDesiredMinTxInterval time.Duration
+	remoteDetectMultiplier     uint8
+	// pollActive is true when a Poll Sequence is in progress.
+	pollActive bool
+
+	rxCh   chan *controlPacket
+	stopCh chan struct{}
+	doneCh chan struct{}
+	// notifyPeerOnStop indicates whether the peer is notified that the session is disabled administratively when the
+	// session is stopped.
+	notifyPeerOnStop bool
+}
+
+func newSession(address string, peerAddress netip.Addr, port int, config SessionConfig, localDiscriminator uint32, conn net.PacketConn, handler func(StateChange)) *session {
+	s := &session{
+		address:            address,
+		peerAddress:        peerAddress,
+		peerUDPAddr:        net.UDPAddrFromAddrPort(netip.AddrPortFrom(peerAddress, uint16(port))),
+		config:             config,
+		localDiscriminator: localDiscriminator,
+		conn:               conn,
+		handler:            handler,
+		// RFC 5880 section 6.8.1 requires bfd.RemoteMinRxInterval to be initialized to 1.
+		remoteMinRxInterval: time.Microsecond,
+		rxCh:                make(chan *controlPacket, rxQueueSize),
+		stopCh:              make(chan struct{}),
+		doneCh:              make(chan struct{}),
+	}
+	s.state.Store(uint32(StateDown))
+	return s
+}
+
+func (s *session) getState() State {
+	return State(s.state.Load())
+}
+
+// run runs the session until stop is called.
+func (s *session) run() {
+	defer close(s.doneCh)
+	txTimer := time.NewTimer(0)
+	defer txTimer.Stop()
+	detectTimer := time.NewTimer(0)
+	detectTimer.Stop()
+	defer detectTimer.Stop()
+
+	resetTxTimer := func() {
+		if interval := s.txInterval(); interval > 0 {
+			txTimer.Reset(interval)
+		} else {
+			txTimer.Stop()
+		}
+	}
+	for {
+		select {
+		case <-s.stopCh:
+			if s.notifyPeerOnStop {
+				s.localDiagnostic = DiagnosticAdministrativelyDown
+				s.state.Store(uint32(StateAdminDown))
+				s.send(false)
+			}
+			return
+		case <-txTimer.C:
+			s.send(false)
+			resetTxTimer()
+		case <-detectTimer.C:
+			if state := s.getState(); state != StateInit && state != StateUp {
+				continue
+			}
+			s.remoteDiscriminator = 0
+			s.setState(StateDown, DiagnosticControlDetectionTimeExpired, false)
+			s.send(false)
+			resetTxTimer()
+		case p := <-s.rxCh:
+			stateChanged := s.receive(p)
+			detectTimer.Reset(s.detectionTime())
+			if p.poll {
+				s.send(true)
+			}
+			// Notify the peer of the new state immediately, instead of waiting for the next periodic transmission.
+			if stateChanged {
+				s.send(false)
+			}
+			if stateChanged || p.final {
+				resetTxTimer()
+			}
+		}
+	}
+}
+
+// stop stops the session. If notifyPeer is true, the session is disabled administratively, and the peer is notified
+// of it, so that the peer doesn't consider it as a failure.
+func (s *session) stop(notifyPeer bool) {
+	s.notifyPeerOnStop = notifyPeer
+	close(s.stopCh)
+	<-s.doneCh
+	s.conn.Close()
+}
+
+// deliver queues a BFD Control packet received from the peer. The packet is dropped if the queue is full.
+func (s *session) deliver(p *controlPacket) {
+	select {
+	case s.rxCh <- p:
+	default:
+		klog.V(4).InfoS("Dropped BFD Control packet as the queue is full", "peer", s.peerAddress)
+	}
+}
+
+// receive processes a BFD Control packet received from the peer, as specified in RFC 5880 section 6.8.6. It returns
+// whether the state of the session has changed.
+func (s *session) receive(p *controlPacket) bool {
+	s.remoteDiscriminator = p.myDiscriminator
+	s.remoteMinRxInterval = p.requiredMinRxInterval
+	s.remoteDesiredMinTxInterval = p.desiredMinTxInterval
+	s.remoteDetectMultiplier = p.detectMultiplier
+	if p.final {
+		s.pollActive = false
+	}
+
+	state := s.getState()
+	if p.state == StateAdminDown {
+		if state != StateDown {
+			s.setState(StateDown, DiagnosticNeighborSignaledSessionDown, true)
+			return true
+		}
+		return false
+	}
+	switch state {
+	case StateDown:
+		if p.state == StateDown {
+			s.setState(StateInit, DiagnosticNone, false)
+			return true
+		} else if p.state == StateInit {
+			s.setState(StateUp, DiagnosticNone, false)
+			return true
+		}
+	case StateInit:
+		if p.state == StateInit || p.state == StateUp {
+			s.setState(StateUp, DiagnosticNone, false)
+			return true
+		}
+	case StateUp:
+		if p.state == StateDown {
+			s.setState(StateDown, DiagnosticNeighborSignaledSessionDown, false)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *session) setState(state State, diagnostic Diagnostic, peerAdminDown bool) {
+	oldState := s.getState()
+	s.state.Store(uint32(state))
+	s.localDiagnostic = diagnostic
+	// The desired minimum TX interval changes from the slow interval to the configured one when the session comes up,
+	// which must be signaled to the peer with a Poll Sequence.
+	s.pollActive = state == StateUp && s.config.MinTxInterval < slowTxInterval
+	klog.V(2).InfoS("BFD session state changed", "peer", s.peerAddress, "oldState", oldState, "newState", state, "diagnostic", diagnostic)
+	s.handler(StateChange{
+		PeerAddress:   s.address,
+		OldState:      oldState,
+		NewState:      state,
+		Diagnostic:    diagnostic,
+		PeerAdminDown: peerAdminDown,
+	})
+}
+
+func (s *session) send(final bool) {
+	p := &controlPacket{
+		diagnostic:            s.localDiagnostic,
+		state:                 s.getState(),
+		poll:                  s.pollActive && !final,
+		final:                 final,
+		detectMultiplier:      s.config.Multiplier,
+		myDiscriminator:       s.localDiscriminator,
+		yourDiscriminator:     s.remoteDiscriminator,
+		desiredMinTxInterval:  s.desiredMinTxInterval(),
+		requiredMinRxInterval: s.config.MinRxInterval,
+	}
+	if _, err := s.conn.WriteTo(p.marshal(), s.peerUDPAddr); err != nil {
+		klog.V(4).InfoS("Failed to send BFD Control packet", "peer", s.peerAddress, "err", err)
+	}
+}
+
+func (s *session) desiredMinTxInterval() time.Duration {
+	if s.getState() != StateUp {
+		return max(s.config.MinTxInterval, slowTxInterval)
+	}
+	return s.config.MinTxInterval
+}
+
+// txInterval returns the interval until the next periodic transmission, with the jitter specified in RFC 5880
+// section 6.8.7 applied. It returns 0 if the peer doesn't want to receive periodic BFD Control packets.
+func (s *session) txInterval() time.Duration {
+	if s.remoteMinRxInterval == 0 {
+		return 0
+	}
+	interval := max(s.desiredMinTxInterval(), s.remoteMinRxInterval)
+	maxJitter := interval / 4
+	if s.config.Multiplier == 1 {
+		maxJitter = interval / 10
+	}
+	if maxJitter <= 0 {
+		return interval
+	}
+	// #nosec G404: random number generator not used for security purposes.
+	return interval - rand.N(maxJitter)
+}
+
+// detectionTime returns the time after which the session is considered down if no BFD Control packet is received,
+// as specified in RFC 5880 section 6.8.4.
+func (s *session) detectionTime() time.Duration {
+	return time.Duration(s.remoteDetectMultiplier) * max(s.config.MinRxInterval, s.remoteDesiredMinTxInterval)
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSessionConfig = SessionConfig{
+	MinTxInterval: 50 * time.Millisecond,
+	MinRxInterval: 50 * time.Millisecond,
+	Multiplier:    3,
+}
+
+func TestSessionReceive(t *testing.T) {
+	testCases := []struct {
+		name                string
+		state               State
+		remoteState         State
+		expectedState       State
+		expectedStateChange *StateChange
+		expectedPollActive  bool
+		expectedDiagnostic  Diagnostic
+	}{
+		{
+			name:          "Down to Init",
+			state:         StateDown,
+			remoteState:   StateDown,
+			expectedState: StateInit,
+			expectedStateChange: &StateChange{
+				PeerAddress: "192.168.77.1",
+				OldState:    StateDown,
+				NewState:    StateInit,
+			},
+		},
+		{
+			name:          "Down to Up",
+			state:         StateDown,
+			remoteState:   StateInit,
+			expectedState: StateUp,
+			expectedStateChange: &StateChange{
+				PeerAddress: "192.168.77.1",
+				OldState:    StateDown,
+				NewState:    StateUp,
+			},
+			expectedPollActive: true,
+		},
+		{
+			name:          "Down ignores Up",
+			state:         StateDown,
+			remoteState:   StateUp,
+			expectedState: StateDown,
+		},
+		{
+			name:          "Init to Up",
+			state:         StateInit,
+			remoteState:   StateUp,
+			expectedState: StateUp,
+			expectedStateChange: &StateChange{
+				PeerAddress: "192.168.77.1",
+				OldState:    StateInit,
+				NewState:    StateUp,
+			},
+			expectedPollActive: true,
+		},
+		{
+			name:          "Up to Down",
+			state:         StateUp,
+			remoteState:   StateDown,
+			expectedState: StateDown,
+			expectedStateChange: &StateChange{
+				PeerAddress: "192.168.77.1",
+				OldState:    StateUp,
+				NewState:    StateDown,
+				Diagnostic:  DiagnosticNeighborSignaledSessionDown,
+			},
+			expectedDiagnostic: DiagnosticNeighborSignaledSessionDown,
+		},
+		{
+			name:          "Up to Down by peer AdminDown",
+			state:         StateUp,
+			remoteState:   StateAdminDown,
+			expectedState: StateDown,
+			expectedStateChange: &StateChange{
+				PeerAddress:   "192.168.77.1",
+				OldState:      StateUp,
+				NewState:      StateDown,
+				Diagnostic:    DiagnosticNeighborSignaledSessionDown,
+				PeerAdminDown: true,
+			},
+			expectedDiagnostic: DiagnosticNeighborSignaledSessionDown,
+		},
+		{
+			name:          "Up stays Up",
+			state:         StateUp,
+			remoteState:   StateUp,
+			expectedState: StateUp,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var stateChange *StateChange
+			s := newSession("192.168.77.1", netip.MustParseAddr("192.168.77.1"), DefaultPort, testSessionConfig, 1, nil, func(change StateChange) {
+				stateChange = &change
+			})
+			s.state.Store(uint32(tt.state))
+			changed := s.receive(&controlPacket{
+				state:                 tt.remoteState,
+				detectMultiplier:      5,
+				myDiscriminator:       2,
+				yourDiscriminator:     1,
+				desiredMinTxInterval:  time.Second,
+				requiredMinRxInterval: 100 * time.Millisecond,
+			})
+			assert.Equal(t, tt.expectedStateChange != nil, changed)
+			assert.Equal(t, tt.expectedStateChange, stateChange)
+			assert.Equal(t, tt.expectedState, s.getState())
+			assert.Equal(t, tt.expectedPollActive, s.pollActive)
+			assert.Equal(t, tt.expectedDiagnostic, s.localDiagnostic)
+			assert.Equal(t, uint32(2), s.remoteDiscriminator)
+			assert.Equal(t, 100*time.Millisecond, s.remoteMinRxInterval)
+			assert.Equal(t, 5*time.Second, s.detectionTime())
+		})
+	}
+}
+
+func TestSessionTxInterval(t *testing.T) {
+	s := newSession("192.168.77.1", netip.MustParseAddr("192.168.77.1"), DefaultPort, testSessionConfig, 1, nil, nil)
+	// The slow interval is used when the session is not up.
+	interval := s.txInterval()
+	assert.GreaterOrEqual(t, interval, 750*time.Millisecond)
+	assert.LessOrEqual(t, interval, time.Second)
+
+	s.state.Store(uint32(StateUp))
+	s.remoteMinRxInterval = 200 * time.Millisecond
+	interval = s.txInterval()
+	assert.GreaterOrEqual(t, interval, 150*time.Millisecond)
+	assert.LessOrEqual(t, interval, 200*time.Millisecond)
+
+	// The peer doesn't want to receive periodic BFD Control packets.
+	s.remoteMinRxInterval = 0
+	assert.Zero(t, s.txInterval())
+}
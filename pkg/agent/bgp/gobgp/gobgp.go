@@ -17,25 +17,75 @@ package gobgp
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/netip"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	gobgpapi "github.com/osrg/gobgp/v3/api"
 	"github.com/osrg/gobgp/v3/pkg/server"
 	"google.golang.org/protobuf/types/known/anypb"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/net"
 
 	"antrea.io/antrea/v2/pkg/agent/bgp"
+	"antrea.io/antrea/v2/pkg/agent/bgp/bfd"
 )
 
 const (
 	ipv4AllZero = "0.0.0.0"
 	ipv6AllZero = "::"
+
+	// globalRIBName is the name used by goBGP to refer to the global RIB in policy assignments.
+	globalRIBName = "global"
+
+	// resetPeerTimeout is the timeout of resetting a BGP session after the BFD session with the peer goes down.
+	resetPeerTimeout = 10 * time.Second
 )
 
+// peerRoutePolicies contains the import and export route policies of a BGP peer.
+type peerRoutePolicies struct {
+	importPolicy *bgp.RoutePolicy
+	exportPolicy *bgp.RoutePolicy
+}
+
+// bfdSession contains the configuration of the BFD session with a BGP peer.
+type bfdSession struct {
+	config       bgp.BFDConfig
+	localAddress string
+}
+
+// installedPolicy contains the goBGP policy and the defined sets referenced by it, which are assigned to the global
+// RIB for a direction.
+type installedPolicy struct {
+	name        string
+	definedSets []*gobgpapi.DefinedSet
+}
+
 type Server struct {
 	server       *server.BgpServer
 	globalConfig *gobgpapi.Global
+	// routePolicies stores the route policies of BGP peers, keyed by peer address. As goBGP only supports per-peer
+	// policies for route server clients, the route policies of all peers are merged into the import and export
+	// policies of the global RIB, in which the statements for a peer are conditioned on the peer address.
+	routePolicies map[string]peerRoutePolicies
+	// installedPolicies stores the goBGP policies assigned to the global RIB, keyed by policy direction.
+	installedPolicies map[gobgpapi.PolicyDirection]*installedPolicy
+	// policyGeneration is used to generate unique names for goBGP policies, so that a new policy can be installed
+	// before the previous one is removed.
+	policyGeneration uint64
+	// localRoutes stores the prefixes of the routes originated by the local BGP server.
+	localRoutes sets.Set[string]
+	// stopCtx is canceled when the BGP server is stopped, to terminate the watches of received routes.
+	stopCtx    context.Context
+	stopCancel context.CancelFunc
+	// bfdServer runs the BFD sessions with the BGP peers for which BFD is enabled.
+	bfdServer *bfd.Server
+	// bfdSessions stores the BFD sessions with BGP peers, keyed by peer address.
+	bfdSessions map[string]bfdSession
 }
 
 func NewGoBGPServer(globalConfig *bgp.GlobalConfig) *Server {
@@ -47,7 +97,17 @@ func NewGoBGPServer(globalConfig *bgp.GlobalConfig) *Server {
 			ListenPort:      globalConfig.ListenPort,
 			ListenAddresses: globalConfig.ListenAddresses,
 		},
+		routePolicies:     make(map[string]peerRoutePolicies),
+		installedPolicies: make(map[gobgpapi.PolicyDirection]*installedPolicy),
+		localRoutes:       sets.New[string](),
+		bfdSessions:       make(map[string]bfdSession),
+	}
+	s.stopCtx, s.stopCancel = context.WithCancel(context.Background())
+	bfdPort := bfd.DefaultPort
+	if globalConfig.BFDListenPort != 0 {
+		bfdPort = int(globalConfig.BFDListenPort)
 	}
+	s.bfdServer = bfd.NewServer(globalConfig.ListenAddresses, bfdPort, s.handleBFDStateChange)
 	if globalConfig.Confederation != nil {
 		s.globalConfig.Confederation = &gobgpapi.Confederation{
 			Enabled:      true,
@@ -67,6 +127,8 @@ func (s *Server) Start(ctx context.Context) error {
 }
 
 func (s *Server) Stop(ctx context.Context) error {
+	s.stopCancel()
+	s.bfdServer.Stop()
 	if err := s.server.StopBgp(ctx, &gobgpapi.StopBgpRequest{}); err != nil {
 		return err
 	}
@@ -78,8 +140,17 @@ func (s *Server) AddPeer(ctx context.Context, peerConf bgp.PeerConfig) error {
 	if err != nil {
 		return err
 	}
+	// Apply the route policies of the peer before adding it, so that the routes exchanged with the peer are
+	// filtered as soon as the session is established.
+	if err := s.setPeerRoutePolicies(ctx, peerConf.Address, getPeerRoutePolicies(peerConf), false); err != nil {
+		return err
+	}
+	if err := s.syncPeerBFDSession(peerConf); err != nil {
+		return err
+	}
 	request := &gobgpapi.AddPeerRequest{Peer: peer}
 	if err := s.server.AddPeer(ctx, request); err != nil {
+		s.removePeerBFDSession(peerConf.Address)
 		return err
 	}
 	return nil
@@ -94,14 +165,226 @@ func (s *Server) UpdatePeer(ctx context.Context, peerConf bgp.PeerConfig) error
 	if _, err := s.server.UpdatePeer(ctx, request); err != nil {
 		return err
 	}
+	if err := s.setPeerRoutePolicies(ctx, peerConf.Address, getPeerRoutePolicies(peerConf), true); err != nil {
+		return err
+	}
+	if err := s.syncPeerBFDSession(peerConf); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (s *Server) RemovePeer(ctx context.Context, peerConf bgp.PeerConfig) error {
+	s.removePeerBFDSession(peerConf.Address)
 	request := &gobgpapi.DeletePeerRequest{Address: peerConf.Address}
 	if err := s.server.DeletePeer(ctx, request); err != nil {
 		return err
 	}
+	if err := s.setPeerRoutePolicies(ctx, peerConf.Address, peerRoutePolicies{}, false); err != nil {
+		return err
+	}
+	return nil
+}
+
+// syncPeerBFDSession starts, restarts or stops the BFD session with a BGP peer according to its BFD configuration.
+func (s *Server) syncPeerBFDSession(peerConf bgp.PeerConfig) error {
+	var curSession *bfdSession
+	if peerConf.BFDConfig != nil {
+		curSession = &bfdSession{config: *peerConf.BFDConfig, localAddress: peerConf.LocalAddress}
+	}
+	if prevSession, exists := s.bfdSessions[peerConf.Address]; exists {
+		if curSession != nil && *curSession == prevSession {
+			return nil
+		}
+		s.removePeerBFDSession(peerConf.Address)
+	}
+	if curSession == nil {
+		return nil
+	}
+	sessionConfig := bfd.SessionConfig{
+		MinTxInterval: curSession.config.MinTxInterval,
+		MinRxInterval: curSession.config.MinRxInterval,
+		Multiplier:    curSession.config.Multiplier,
+	}
+	if err := s.bfdServer.AddSession(peerConf.Address, curSession.localAddress, sessionConfig); err != nil {
+		return fmt.Errorf("failed to start BFD session with peer %s: %w", peerConf.Address, err)
+	}
+	s.bfdSessions[peerConf.Address] = *curSession
+	return nil
+}
+
+func (s *Server) removePeerBFDSession(address string) {
+	if _, exists := s.bfdSessions[address]; exists {
+		s.bfdServer.RemoveSession(address)
+		delete(s.bfdSessions, address)
+	}
+}
+
+// handleBFDStateChange resets the BGP session with a BGP peer when the BFD session with it fails, so that the routes
+// exchanged with the peer are withdrawn without waiting for the hold timer to expire.
+func (s *Server) handleBFDStateChange(change bfd.StateChange) {
+	if !change.IsFailure() {
+		return
+	}
+	klog.InfoS("BFD session with BGP peer is down, resetting BGP session", "peer", change.PeerAddress, "diagnostic", change.Diagnostic)
+	go func() {
+		ctx, cancel := context.WithTimeout(s.stopCtx, resetPeerTimeout)
+		defer cancel()
+		request := &gobgpapi.ResetPeerRequest{Address: change.PeerAddress, Communication: "BFD session down"}
+		if err := s.server.ResetPeer(ctx, request); err != nil {
+			klog.ErrorS(err, "Failed to reset BGP session after BFD session went down", "peer", change.PeerAddress)
+		}
+	}()
+}
+
+func getPeerRoutePolicies(peerConf bgp.PeerConfig) peerRoutePolicies {
+	return peerRoutePolicies{
+		importPolicy: peerConf.ImportRoutePolicy,
+		exportPolicy: peerConf.ExportRoutePolicy,
+	}
+}
+
+// setPeerRoutePolicies updates the route policies of a BGP peer, and re-installs the global policies of the
+// directions in which the route policies of the peer have changed. When softReset is true, the routes exchanged with
+// the peer are re-evaluated against the new policies without resetting the session.
+func (s *Server) setPeerRoutePolicies(ctx context.Context, address string, policies peerRoutePolicies, softReset bool) error {
+	prevPolicies := s.routePolicies[address]
+	if policies.importPolicy == nil && policies.exportPolicy == nil {
+		delete(s.routePolicies, address)
+	} else {
+		s.routePolicies[address] = policies
+	}
+	restore := func() {
+		if prevPolicies.importPolicy == nil && prevPolicies.exportPolicy == nil {
+			delete(s.routePolicies, address)
+		} else {
+			s.routePolicies[address] = prevPolicies
+		}
+	}
+
+	if !reflect.DeepEqual(prevPolicies.importPolicy, policies.importPolicy) {
+		if err := s.syncGlobalPolicy(ctx, gobgpapi.PolicyDirection_IMPORT); err != nil {
+			restore()
+			return fmt.Errorf("failed to install import policy for peer %s: %w", address, err)
+		}
+		if softReset {
+			if err := s.server.ResetPeer(ctx, &gobgpapi.ResetPeerRequest{Address: address, Soft: true, Direction: gobgpapi.ResetPeerRequest_IN}); err != nil {
+				return err
+			}
+		}
+	}
+	if !reflect.DeepEqual(prevPolicies.exportPolicy, policies.exportPolicy) {
+		var prevAdvertisedRoutes []bgp.Route
+		if softReset {
+			var err error
+			if prevAdvertisedRoutes, err = s.GetRoutes(ctx, bgp.RouteAdvertised, address); err != nil {
+				restore()
+				return err
+			}
+		}
+		if err := s.syncGlobalPolicy(ctx, gobgpapi.PolicyDirection_EXPORT); err != nil {
+			restore()
+			return fmt.Errorf("failed to install export policy for peer %s: %w", address, err)
+		}
+		if softReset {
+			if err := s.softResetPeerOut(ctx, address, prevAdvertisedRoutes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// softResetPeerOut re-advertises the routes to a BGP peer after its export policy is changed. As goBGP doesn't keep
+// track of the routes sent to peers, a soft reset only advertises the routes accepted by the new policy, without
+// withdrawing the routes which were accepted by the previous policy but are rejected by the new one. These local
+// routes are withdrawn and advertised again to get them withdrawn from the peer.
+func (s *Server) softResetPeerOut(ctx context.Context, address string, prevAdvertisedRoutes []bgp.Route) error {
+	if err := s.server.ResetPeer(ctx, &gobgpapi.ResetPeerRequest{Address: address, Soft: true, Direction: gobgpapi.ResetPeerRequest_OUT}); err != nil {
+		return err
+	}
+	if len(prevAdvertisedRoutes) == 0 {
+		return nil
+	}
+	advertisedRoutes, err := s.GetRoutes(ctx, bgp.RouteAdvertised, address)
+	if err != nil {
+		return err
+	}
+	routesToReadvertise := sets.New(prevAdvertisedRoutes...).Difference(sets.New(advertisedRoutes...))
+	for route := range routesToReadvertise {
+		if !s.localRoutes.Has(route.Prefix) {
+			continue
+		}
+		path := convertRouteToGoBGPPath(&route)
+		if err := s.server.DeletePath(ctx, &gobgpapi.DeletePathRequest{Path: path}); err != nil {
+			return err
+		}
+		if _, err := s.server.AddPath(ctx, &gobgpapi.AddPathRequest{Path: path}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncGlobalPolicy installs a goBGP policy generated from the route policies of all BGP peers for the given direction,
+// assigns it to the global RIB in place of the previous one, and then removes the previous one.
+func (s *Server) syncGlobalPolicy(ctx context.Context, direction gobgpapi.PolicyDirection) error {
+	s.policyGeneration++
+	policyName := fmt.Sprintf("antrea-%s-%d", strings.ToLower(direction.String()), s.policyGeneration)
+
+	var statements []*gobgpapi.Statement
+	var definedSets []*gobgpapi.DefinedSet
+	for _, address := range slices.Sorted(maps.Keys(s.routePolicies)) {
+		routePolicy := s.routePolicies[address].importPolicy
+		if direction == gobgpapi.PolicyDirection_EXPORT {
+			routePolicy = s.routePolicies[address].exportPolicy
+		}
+		if routePolicy == nil {
+			continue
+		}
+		peerStatements, peerDefinedSets := convertRoutePolicyToGoBGPStatements(policyName, address, routePolicy)
+		statements = append(statements, peerStatements...)
+		definedSets = append(definedSets, peerDefinedSets...)
+	}
+
+	var newPolicy *installedPolicy
+	var policies []*gobgpapi.Policy
+	if len(statements) > 0 {
+		for _, definedSet := range definedSets {
+			if err := s.server.AddDefinedSet(ctx, &gobgpapi.AddDefinedSetRequest{DefinedSet: definedSet}); err != nil {
+				return err
+			}
+		}
+		if err := s.server.AddPolicy(ctx, &gobgpapi.AddPolicyRequest{Policy: &gobgpapi.Policy{Name: policyName, Statements: statements}}); err != nil {
+			return err
+		}
+		newPolicy = &installedPolicy{name: policyName, definedSets: definedSets}
+		policies = append(policies, &gobgpapi.Policy{Name: policyName})
+	}
+	request := &gobgpapi.SetPolicyAssignmentRequest{
+		Assignment: &gobgpapi.PolicyAssignment{
+			Name:          globalRIBName,
+			Direction:     direction,
+			Policies:      policies,
+			DefaultAction: gobgpapi.RouteAction_ACCEPT,
+		},
+	}
+	if err := s.server.SetPolicyAssignment(ctx, request); err != nil {
+		return err
+	}
+
+	// The previous policy is no longer assigned, remove it along with its statements and defined sets.
+	if prevPolicy := s.installedPolicies[direction]; prevPolicy != nil {
+		if err := s.server.DeletePolicy(ctx, &gobgpapi.DeletePolicyRequest{Policy: &gobgpapi.Policy{Name: prevPolicy.name}, All: true}); err != nil {
+			return err
+		}
+		for _, definedSet := range prevPolicy.definedSets {
+			if err := s.server.DeleteDefinedSet(ctx, &gobgpapi.DeleteDefinedSetRequest{DefinedSet: definedSet, All: true}); err != nil {
+				return err
+			}
+		}
+	}
+	s.installedPolicies[direction] = newPolicy
 	return nil
 }
 
@@ -110,6 +393,9 @@ func (s *Server) GetPeers(ctx context.Context) ([]bgp.PeerStatus, error) {
 	fn := func(peer *gobgpapi.Peer) {
 		peerStatus := convertGoBGPPeerToPeerStatus(peer)
 		if peerStatus != nil {
+			if bfdState, exists := s.bfdServer.GetSessionState(peerStatus.Address); exists {
+				peerStatus.BFDSessionState = bfdState.String()
+			}
 			peerStatuses = append(peerStatuses, *peerStatus)
 		}
 	}
@@ -126,6 +412,7 @@ func (s *Server) AdvertiseRoutes(ctx context.Context, routes []bgp.Route) error
 		if _, err := s.server.AddPath(ctx, request); err != nil {
 			return err
 		}
+		s.localRoutes.Insert(routes[i].Prefix)
 	}
 	return nil
 }
@@ -136,6 +423,7 @@ func (s *Server) WithdrawRoutes(ctx context.Context, routes []bgp.Route) error {
 		if err := s.server.DeletePath(ctx, request); err != nil {
 			return err
 		}
+		s.localRoutes.Delete(routes[i].Prefix)
 	}
 	return nil
 }
@@ -162,6 +450,57 @@ func (s *Server) GetRoutes(ctx context.Context, routeType bgp.RouteType, peerAdd
 	return routes, nil
 }
 
+func (s *Server) GetReceivedRoutes(ctx context.Context) ([]bgp.ReceivedRoute, error) {
+	var routes []bgp.ReceivedRoute
+	fn := func(destination *gobgpapi.Destination) {
+		for _, path := range destination.GetPaths() {
+			if !path.GetBest() {
+				continue
+			}
+			if route := convertGoBGPPathToReceivedRoute(destination.GetPrefix(), path); route != nil {
+				routes = append(routes, *route)
+			}
+		}
+	}
+	for _, isIPv6 := range []bool{false, true} {
+		request := &gobgpapi.ListPathRequest{
+			TableType: gobgpapi.TableType_GLOBAL,
+			Family:    &gobgpapi.Family{Afi: convertToGoBGPFamilyAfi(isIPv6), Safi: gobgpapi.Family_SAFI_UNICAST},
+		}
+		if err := s.server.ListPath(ctx, request, fn); err != nil {
+			return nil, err
+		}
+	}
+	return routes, nil
+}
+
+func (s *Server) WatchReceivedRoutes(ctx context.Context, handler func()) error {
+	watchCtx, cancel := context.WithCancel(ctx)
+	// Terminate the watch when the BGP server is stopped.
+	context.AfterFunc(s.stopCtx, cancel)
+	request := &gobgpapi.WatchEventRequest{
+		Table: &gobgpapi.WatchEventRequest_Table{
+			Filters: []*gobgpapi.WatchEventRequest_Table_Filter{
+				{Type: gobgpapi.WatchEventRequest_Table_Filter_BEST},
+			},
+		},
+	}
+	fn := func(response *gobgpapi.WatchEventResponse) {
+		for _, path := range response.GetTable().GetPaths() {
+			// Ignore the changes of the routes originated locally.
+			if isReceivedGoBGPPath(path) {
+				handler()
+				return
+			}
+		}
+	}
+	if err := s.server.WatchEvent(watchCtx, request, fn); err != nil {
+		cancel()
+		return err
+	}
+	return nil
+}
+
 func convertGoBGPPeerToPeerStatus(peer *gobgpapi.Peer) *bgp.PeerStatus {
 	if peer == nil {
 		return nil
@@ -187,11 +526,18 @@ func convertGoBGPPeerToPeerStatus(peer *gobgpapi.Peer) *bgp.PeerStatus {
 		if peerStatus.SessionState == bgp.SessionEstablished {
 			if timers := peer.GetTimers(); timers != nil {
 				if timerState := timers.GetState(); timerState != nil {
-					peerStatus.UptimeSeconds = int(time.Since(timerState.GetUptime().AsTime()).Seconds())
+					peerStatus.EstablishedTime = timerState.GetUptime().AsTime()
+					peerStatus.UptimeSeconds = int(time.Since(peerStatus.EstablishedTime).Seconds())
 				}
 			}
 		}
 	}
+	for _, afiSafi := range peer.GetAfiSafis() {
+		if state := afiSafi.GetState(); state != nil {
+			peerStatus.AdvertisedRoutes += int(state.GetAdvertised())
+			peerStatus.ReceivedRoutes += int(state.GetReceived())
+		}
+	}
 	return peerStatus
 }
 
@@ -203,6 +549,47 @@ func convertGoBGPDestinationToRoute(destination *gobgpapi.Destination) *bgp.Rout
 	return route
 }
 
+// isReceivedGoBGPPath returns whether the goBGP path is received from a BGP peer. goBGP doesn't set the neighbor
+// address of a path originated locally.
+func isReceivedGoBGPPath(path *gobgpapi.Path) bool {
+	return path != nil && isValidIPString(path.GetNeighborIp())
+}
+
+func convertGoBGPPathToReceivedRoute(prefix string, path *gobgpapi.Path) *bgp.ReceivedRoute {
+	if !isReceivedGoBGPPath(path) || path.GetIsWithdraw() {
+		return nil
+	}
+	nextHop := getGoBGPPathNextHop(path)
+	if nextHop == "" {
+		return nil
+	}
+	return &bgp.ReceivedRoute{
+		Prefix:      prefix,
+		NextHop:     nextHop,
+		PeerAddress: path.GetNeighborIp(),
+	}
+}
+
+// getGoBGPPathNextHop returns the next hop of the goBGP path, which is carried by the NEXT_HOP attribute for IPv4
+// routes and the MP_REACH_NLRI attribute for IPv6 routes.
+func getGoBGPPathNextHop(path *gobgpapi.Path) string {
+	for _, pattr := range path.GetPattrs() {
+		attr, err := pattr.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+		switch a := attr.(type) {
+		case *gobgpapi.NextHopAttribute:
+			return a.GetNextHop()
+		case *gobgpapi.MpReachNLRIAttribute:
+			if len(a.GetNextHops()) > 0 {
+				return a.GetNextHops()[0]
+			}
+		}
+	}
+	return ""
+}
+
 func convertRouteTypeToGoBGPTableType(routeType bgp.RouteType) gobgpapi.TableType {
 	if routeType == bgp.RouteAdvertised {
 		return gobgpapi.TableType_ADJ_OUT
@@ -244,6 +631,115 @@ func convertRouteToGoBGPPath(route *bgp.Route) *gobgpapi.Path {
 	}
 }
 
+// convertRoutePolicyToGoBGPStatements converts the route policy of a BGP peer to goBGP policy statements, which are
+// conditioned on the peer address, and the defined sets referenced by them.
+func convertRoutePolicyToGoBGPStatements(policyName, peerAddress string, routePolicy *bgp.RoutePolicy) ([]*gobgpapi.Statement, []*gobgpapi.DefinedSet) {
+	isIPv6 := net.IsIPv6String(peerAddress)
+	neighborPrefix := peerAddress + "/32"
+	if isIPv6 {
+		neighborPrefix = peerAddress + "/128"
+	}
+	namePrefix := fmt.Sprintf("%s-%s", policyName, peerAddress)
+	neighborSet := &gobgpapi.DefinedSet{
+		DefinedType: gobgpapi.DefinedType_NEIGHBOR,
+		Name:        namePrefix,
+		List:        []string{neighborPrefix},
+	}
+	definedSets := []*gobgpapi.DefinedSet{neighborSet}
+	var statements []*gobgpapi.Statement
+	for i := range routePolicy.Rules {
+		rule := &routePolicy.Rules[i]
+		conditions := &gobgpapi.Conditions{
+			NeighborSet: &gobgpapi.MatchSet{Type: gobgpapi.MatchSet_ANY, Name: neighborSet.Name},
+		}
+		if len(rule.Prefixes) > 0 {
+			prefixes := convertPrefixMatchesToGoBGPPrefixes(rule.Prefixes, isIPv6)
+			// Only the routes of the address family of the peer address are exchanged with the peer, a rule which
+			// matches no prefix of that family can be skipped.
+			if len(prefixes) == 0 {
+				continue
+			}
+			prefixSet := &gobgpapi.DefinedSet{
+				DefinedType: gobgpapi.DefinedType_PREFIX,
+				Name:        fmt.Sprintf("%s-%d", namePrefix, i),
+				Prefixes:    prefixes,
+			}
+			definedSets = append(definedSets, prefixSet)
+			conditions.PrefixSet = &gobgpapi.MatchSet{Type: gobgpapi.MatchSet_ANY, Name: prefixSet.Name}
+		}
+		statements = append(statements, &gobgpapi.Statement{
+			Name:       fmt.Sprintf("%s-%d", namePrefix, i),
+			Conditions: conditions,
+			Actions:    convertRoutePolicyRuleToGoBGPActions(rule),
+		})
+	}
+	if routePolicy.DefaultAction == bgp.RouteActionReject {
+		statements = append(statements, &gobgpapi.Statement{
+			Name: namePrefix + "-default",
+			Conditions: &gobgpapi.Conditions{
+				NeighborSet: &gobgpapi.MatchSet{Type: gobgpapi.MatchSet_ANY, Name: neighborSet.Name},
+			},
+			Actions: &gobgpapi.Actions{RouteAction: gobgpapi.RouteAction_REJECT},
+		})
+	}
+	if len(statements) == 0 {
+		return nil, nil
+	}
+	return statements, definedSets
+}
+
+func convertPrefixMatchesToGoBGPPrefixes(prefixMatches []bgp.PrefixMatch, isIPv6 bool) []*gobgpapi.Prefix {
+	var prefixes []*gobgpapi.Prefix
+	for _, prefixMatch := range prefixMatches {
+		prefix, err := netip.ParsePrefix(prefixMatch.Prefix)
+		if err != nil || prefix.Addr().Is6() != isIPv6 {
+			continue
+		}
+		prefix = prefix.Masked()
+		maskLengthMax := uint32(prefix.Bits())
+		if prefixMatch.OrLonger {
+			maskLengthMax = uint32(prefix.Addr().BitLen())
+		}
+		prefixes = append(prefixes, &gobgpapi.Prefix{
+			IpPrefix:      prefix.String(),
+			MaskLengthMin: uint32(prefix.Bits()),
+			MaskLengthMax: maskLengthMax,
+		})
+	}
+	return prefixes
+}
+
+func convertRoutePolicyRuleToGoBGPActions(rule *bgp.RoutePolicyRule) *gobgpapi.Actions {
+	if rule.Action == bgp.RouteActionReject {
+		return &gobgpapi.Actions{RouteAction: gobgpapi.RouteAction_REJECT}
+	}
+	actions := &gobgpapi.Actions{RouteAction: gobgpapi.RouteAction_ACCEPT}
+	if attributes := rule.Attributes; attributes != nil {
+		if len(attributes.Communities) > 0 {
+			actions.Community = &gobgpapi.CommunityAction{
+				Type:        gobgpapi.CommunityAction_ADD,
+				Communities: attributes.Communities,
+			}
+		}
+		if len(attributes.LargeCommunities) > 0 {
+			actions.LargeCommunity = &gobgpapi.CommunityAction{
+				Type:        gobgpapi.CommunityAction_ADD,
+				Communities: attributes.LargeCommunities,
+			}
+		}
+		if attributes.LocalPreference != nil {
+			actions.LocalPref = &gobgpapi.LocalPrefAction{Value: *attributes.LocalPreference}
+		}
+		if attributes.MED != nil {
+			actions.Med = &gobgpapi.MedAction{
+				Type:  gobgpapi.MedAction_REPLACE,
+				Value: int64(*attributes.MED),
+			}
+		}
+	}
+	return actions
+}
+
 func convertToGoBGPFamilyAfi(isIPv6 bool) gobgpapi.Family_Afi {
 	if isIPv6 {
 		return gobgpapi.Family_AFI_IP6
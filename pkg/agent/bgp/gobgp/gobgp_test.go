@@ -15,11 +15,15 @@
 package gobgp
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	gobgpapi "github.com/osrg/gobgp/v3/api"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"k8s.io/utils/ptr"
 
@@ -28,6 +32,7 @@ import (
 )
 
 func TestConvertGoBGPPeerToPeerStatus(t *testing.T) {
+	establishedTime := time.Unix(time.Now().Unix()-3600, 0)
 	tests := []struct {
 		name     string
 		peer     *gobgpapi.Peer
@@ -59,9 +64,13 @@ func TestConvertGoBGPPeerToPeerStatus(t *testing.T) {
 				},
 				Timers: &gobgpapi.Timers{
 					State: &gobgpapi.TimersState{
-						Uptime: &timestamppb.Timestamp{Seconds: time.Now().Unix() - 3600},
+						Uptime: timestamppb.New(establishedTime),
 					},
 				},
+				AfiSafis: []*gobgpapi.AfiSafi{
+					{State: &gobgpapi.AfiSafiState{Received: 2, Accepted: 1, Advertised: 3}},
+					{State: &gobgpapi.AfiSafiState{Received: 1, Accepted: 1, Advertised: 0}},
+				},
 			},
 			expected: &bgp.PeerStatus{
 				Address:                    "192.168.1.1",
@@ -71,6 +80,9 @@ func TestConvertGoBGPPeerToPeerStatus(t *testing.T) {
 				GracefulRestartTimeSeconds: 120,
 				SessionState:               bgp.SessionEstablished,
 				UptimeSeconds:              3600,
+				EstablishedTime:            establishedTime.UTC(),
+				AdvertisedRoutes:           3,
+				ReceivedRoutes:             3,
 			},
 		},
 		{
@@ -135,6 +147,60 @@ func TestConvertGoBGPDestinationToRoute(t *testing.T) {
 	}
 }
 
+func TestConvertGoBGPPathToReceivedRoute(t *testing.T) {
+	newPath := func(neighborIP string, isWithdraw bool, attr proto.Message) *gobgpapi.Path {
+		a, _ := anypb.New(attr)
+		return &gobgpapi.Path{NeighborIp: neighborIP, IsWithdraw: isWithdraw, Pattrs: []*anypb.Any{a}}
+	}
+	tests := []struct {
+		name     string
+		prefix   string
+		path     *gobgpapi.Path
+		expected *bgp.ReceivedRoute
+	}{
+		{
+			name:   "IPv4 route",
+			prefix: "10.10.0.0/24",
+			path:   newPath("192.168.77.100", false, &gobgpapi.NextHopAttribute{NextHop: "192.168.77.100"}),
+			expected: &bgp.ReceivedRoute{
+				Prefix:      "10.10.0.0/24",
+				NextHop:     "192.168.77.100",
+				PeerAddress: "192.168.77.100",
+			},
+		},
+		{
+			name:   "IPv6 route",
+			prefix: "fec0:10:10::/64",
+			path:   newPath("fec0::192:168:77:100", false, &gobgpapi.MpReachNLRIAttribute{NextHops: []string{"fec0::192:168:77:101", "fe80::1"}}),
+			expected: &bgp.ReceivedRoute{
+				Prefix:      "fec0:10:10::/64",
+				NextHop:     "fec0::192:168:77:101",
+				PeerAddress: "fec0::192:168:77:100",
+			},
+		},
+		{
+			name:   "route originated locally",
+			prefix: "10.10.0.0/24",
+			path:   newPath("<nil>", false, &gobgpapi.NextHopAttribute{NextHop: "0.0.0.0"}),
+		},
+		{
+			name:   "withdrawn route",
+			prefix: "10.10.0.0/24",
+			path:   newPath("192.168.77.100", true, &gobgpapi.NextHopAttribute{NextHop: "192.168.77.100"}),
+		},
+		{
+			name:   "route without next hop",
+			prefix: "10.10.0.0/24",
+			path:   newPath("192.168.77.100", false, &gobgpapi.OriginAttribute{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, convertGoBGPPathToReceivedRoute(tt.prefix, tt.path))
+		})
+	}
+}
+
 func TestConvertRouteTypeToGoBGPTableType(t *testing.T) {
 	tableType := convertRouteTypeToGoBGPTableType(bgp.RouteAdvertised)
 	assert.Equal(t, gobgpapi.TableType_ADJ_OUT, tableType)
@@ -186,6 +252,143 @@ func TestConvertPeerConfigToGoBGPPeer(t *testing.T) {
 
 }
 
+func TestConvertRoutePolicyToGoBGPStatements(t *testing.T) {
+	routePolicy := &bgp.RoutePolicy{
+		Rules: []bgp.RoutePolicyRule{
+			{
+				Prefixes: []bgp.PrefixMatch{
+					{Prefix: "10.96.0.10/32"},
+					{Prefix: "10.10.0.0/16", OrLonger: true},
+					{Prefix: "fec0::10/128"},
+				},
+				Attributes: &bgp.PathAttributes{
+					Communities:      []string{"65000:100", "no-export"},
+					LargeCommunities: []string{"65000:1:2"},
+					LocalPreference:  ptr.To[uint32](200),
+					MED:              ptr.To[uint32](10),
+				},
+			},
+			{
+				// The rule is skipped for an IPv4 peer as it has no IPv4 prefix.
+				Prefixes: []bgp.PrefixMatch{{Prefix: "fec0::/64", OrLonger: true}},
+				Action:   bgp.RouteActionReject,
+			},
+			{
+				Action: bgp.RouteActionReject,
+			},
+		},
+		DefaultAction: bgp.RouteActionReject,
+	}
+	neighborMatch := &gobgpapi.MatchSet{Type: gobgpapi.MatchSet_ANY, Name: "export-1-192.168.0.1"}
+	expectedStatements := []*gobgpapi.Statement{
+		{
+			Name: "export-1-192.168.0.1-0",
+			Conditions: &gobgpapi.Conditions{
+				NeighborSet: neighborMatch,
+				PrefixSet:   &gobgpapi.MatchSet{Type: gobgpapi.MatchSet_ANY, Name: "export-1-192.168.0.1-0"},
+			},
+			Actions: &gobgpapi.Actions{
+				RouteAction:    gobgpapi.RouteAction_ACCEPT,
+				Community:      &gobgpapi.CommunityAction{Type: gobgpapi.CommunityAction_ADD, Communities: []string{"65000:100", "no-export"}},
+				LargeCommunity: &gobgpapi.CommunityAction{Type: gobgpapi.CommunityAction_ADD, Communities: []string{"65000:1:2"}},
+				LocalPref:      &gobgpapi.LocalPrefAction{Value: 200},
+				Med:            &gobgpapi.MedAction{Type: gobgpapi.MedAction_REPLACE, Value: 10},
+			},
+		},
+		{
+			Name:       "export-1-192.168.0.1-2",
+			Conditions: &gobgpapi.Conditions{NeighborSet: neighborMatch},
+			Actions:    &gobgpapi.Actions{RouteAction: gobgpapi.RouteAction_REJECT},
+		},
+		{
+			Name:       "export-1-192.168.0.1-default",
+			Conditions: &gobgpapi.Conditions{NeighborSet: neighborMatch},
+			Actions:    &gobgpapi.Actions{RouteAction: gobgpapi.RouteAction_REJECT},
+		},
+	}
+	expectedDefinedSets := []*gobgpapi.DefinedSet{
+		{
+			DefinedType: gobgpapi.DefinedType_NEIGHBOR,
+			Name:        "export-1-192.168.0.1",
+			List:        []string{"192.168.0.1/32"},
+		},
+		{
+			DefinedType: gobgpapi.DefinedType_PREFIX,
+			Name:        "export-1-192.168.0.1-0",
+			Prefixes: []*gobgpapi.Prefix{
+				{IpPrefix: "10.96.0.10/32", MaskLengthMin: 32, MaskLengthMax: 32},
+				{IpPrefix: "10.10.0.0/16", MaskLengthMin: 16, MaskLengthMax: 32},
+			},
+		},
+	}
+
+	statements, definedSets := convertRoutePolicyToGoBGPStatements("export-1", "192.168.0.1", routePolicy)
+	assert.Equal(t, expectedStatements, statements)
+	assert.Equal(t, expectedDefinedSets, definedSets)
+
+	statements, definedSets = convertRoutePolicyToGoBGPStatements("export-1", "192.168.0.1", &bgp.RoutePolicy{})
+	assert.Empty(t, statements)
+	assert.Empty(t, definedSets)
+}
+
+func TestSetPeerRoutePolicies(t *testing.T) {
+	ctx := context.Background()
+	s := NewGoBGPServer(&bgp.GlobalConfig{ASN: 65000, RouterID: "127.0.0.1", ListenPort: -1})
+	require.NoError(t, s.Start(ctx))
+	defer s.Stop(ctx)
+
+	listPolicyNames := func(direction gobgpapi.PolicyDirection) []string {
+		var names []string
+		request := &gobgpapi.ListPolicyAssignmentRequest{Name: globalRIBName, Direction: direction}
+		require.NoError(t, s.server.ListPolicyAssignment(ctx, request, func(assignment *gobgpapi.PolicyAssignment) {
+			for _, policy := range assignment.Policies {
+				names = append(names, policy.Name)
+			}
+		}))
+		return names
+	}
+	countDefinedSets := func() int {
+		var count int
+		for _, definedType := range []gobgpapi.DefinedType{gobgpapi.DefinedType_NEIGHBOR, gobgpapi.DefinedType_PREFIX} {
+			require.NoError(t, s.server.ListDefinedSet(ctx, &gobgpapi.ListDefinedSetRequest{DefinedType: definedType}, func(*gobgpapi.DefinedSet) {
+				count++
+			}))
+		}
+		return count
+	}
+
+	peerConfig := bgp.PeerConfig{
+		BGPPeer: &v1alpha1.BGPPeer{Address: "192.168.0.1", ASN: 65001},
+		ImportRoutePolicy: &bgp.RoutePolicy{
+			Rules:         []bgp.RoutePolicyRule{{Prefixes: []bgp.PrefixMatch{{Prefix: "10.0.0.0/8", OrLonger: true}}}},
+			DefaultAction: bgp.RouteActionReject,
+		},
+		ExportRoutePolicy: &bgp.RoutePolicy{
+			Rules: []bgp.RoutePolicyRule{{Prefixes: []bgp.PrefixMatch{{Prefix: "10.96.0.10/32"}}, Action: bgp.RouteActionReject}},
+		},
+	}
+	require.NoError(t, s.AddPeer(ctx, peerConfig))
+	assert.Equal(t, []string{"antrea-import-1"}, listPolicyNames(gobgpapi.PolicyDirection_IMPORT))
+	assert.Equal(t, []string{"antrea-export-2"}, listPolicyNames(gobgpapi.PolicyDirection_EXPORT))
+	assert.Equal(t, 4, countDefinedSets())
+
+	// Only the export policy is re-installed when only the export route policy is changed.
+	peerConfig.ExportRoutePolicy = &bgp.RoutePolicy{
+		Rules: []bgp.RoutePolicyRule{{Prefixes: []bgp.PrefixMatch{{Prefix: "10.96.0.11/32"}}, Action: bgp.RouteActionReject}},
+	}
+	require.NoError(t, s.UpdatePeer(ctx, peerConfig))
+	assert.Equal(t, []string{"antrea-import-1"}, listPolicyNames(gobgpapi.PolicyDirection_IMPORT))
+	assert.Equal(t, []string{"antrea-export-3"}, listPolicyNames(gobgpapi.PolicyDirection_EXPORT))
+	assert.Equal(t, 4, countDefinedSets())
+
+	// The policies and defined sets are removed along with the peer.
+	require.NoError(t, s.RemovePeer(ctx, peerConfig))
+	assert.Empty(t, listPolicyNames(gobgpapi.PolicyDirection_IMPORT))
+	assert.Empty(t, listPolicyNames(gobgpapi.PolicyDirection_EXPORT))
+	assert.Equal(t, 0, countDefinedSets())
+	assert.Empty(t, s.routePolicies)
+}
+
 func TestConvertGoBGPSessionStateToSessionState(t *testing.T) {
 	tests := []struct {
 		input    gobgpapi.PeerState_SessionState
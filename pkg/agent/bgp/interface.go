@@ -16,6 +16,7 @@ package bgp
 
 import (
 	"context"
+	"time"
 
 	"antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
 )
@@ -30,10 +31,10 @@ type Interface interface {
 	// Stop terminates the BGP process.
 	Stop(ctx context.Context) error
 
-	// AddPeer adds a new BGP peer.
+	// AddPeer adds a new BGP peer, and applies its route policies.
 	AddPeer(ctx context.Context, peerConf PeerConfig) error
 
-	// UpdatePeer updates an existing BGP peer.
+	// UpdatePeer updates an existing BGP peer, including its route policies.
 	UpdatePeer(ctx context.Context, peerConf PeerConfig) error
 
 	// RemovePeer removes a specified BGP peer.
@@ -42,7 +43,8 @@ type Interface interface {
 	// GetPeers retrieves the current status of all BGP peers.
 	GetPeers(ctx context.Context) ([]PeerStatus, error)
 
-	// AdvertiseRoutes announces the specified routes to all BGP peers.
+	// AdvertiseRoutes announces the specified routes to all BGP peers, subject to the export route policies of the
+	// peers, which may filter the routes or set path attributes on them.
 	AdvertiseRoutes(ctx context.Context, routes []Route) error
 
 	// WithdrawRoutes withdraws the specified routes from all BGP peers.
@@ -50,6 +52,14 @@ type Interface interface {
 
 	// GetRoutes retrieves the advertised / received routes to / from the given peer.
 	GetRoutes(ctx context.Context, routeType RouteType, peerAddress string) ([]Route, error)
+
+	// GetReceivedRoutes retrieves the best routes received from all BGP peers, which are accepted by the import route
+	// policies of the peers. Routes originated locally are not included.
+	GetReceivedRoutes(ctx context.Context) ([]ReceivedRoute, error)
+
+	// WatchReceivedRoutes calls handler whenever the best routes received from BGP peers change, until ctx is
+	// canceled or the BGP process is stopped. handler should not block.
+	WatchReceivedRoutes(ctx context.Context, handler func()) error
 }
 
 type Confederation struct {
@@ -66,6 +76,9 @@ type GlobalConfig struct {
 	// server will bind to all addresses (INADDR_ANY).
 	ListenAddresses []string
 	Confederation   *Confederation
+	// BFDListenPort is the port on which the BFD sessions with BGP peers are run. It is not exposed in the BGPPolicy
+	// API but is used in integration tests. If omitted, the well-known port of single-hop BFD (3784) is used.
+	BFDListenPort int32
 }
 
 type SessionState string
@@ -111,6 +124,67 @@ type PeerConfig struct {
 	// required to establish a secure BGP connection. If the peer requires password-based authentication, this value
 	// must be set to the appropriate password. Leaving this field empty will disable password authentication.
 	Password string
+	// ImportRoutePolicy is the policy applied to the routes received from the BGP peer. It is derived from the
+	// ImportPolicy of the BGPPeer, and nil means that all routes are accepted.
+	ImportRoutePolicy *RoutePolicy
+	// ExportRoutePolicy is the policy applied to the routes advertised to the BGP peer. It is derived from the
+	// ExportPolicy of the BGPPeer, with the route types resolved to the prefixes of the routes, and nil means that
+	// all routes are advertised.
+	ExportRoutePolicy *RoutePolicy
+	// BFDConfig is the configuration of the BFD session with the BGP peer. It is derived from the BFD of the BGPPeer,
+	// with the default values applied, and nil means that BFD is disabled.
+	BFDConfig *BFDConfig
+}
+
+// BFDConfig contains the configuration of a BFD session with a BGP peer. When the BFD session goes down, the BGP
+// session with the peer is reset.
+type BFDConfig struct {
+	// MinTxInterval is the desired minimum interval between the BFD Control packets sent to the peer.
+	MinTxInterval time.Duration
+	// MinRxInterval is the required minimum interval between the BFD Control packets received from the peer.
+	MinRxInterval time.Duration
+	// Multiplier is the detection time multiplier.
+	Multiplier uint8
+}
+
+type RouteAction int
+
+const (
+	RouteActionAccept RouteAction = iota // default
+	RouteActionReject
+)
+
+// PrefixMatch matches the routes of a prefix.
+type PrefixMatch struct {
+	// Prefix is a CIDR string, e.g., "192.168.0.0/24".
+	Prefix string
+	// OrLonger specifies whether the routes with longer prefixes within Prefix are matched as well.
+	OrLonger bool
+}
+
+// PathAttributes contains the BGP path attributes set on routes by a RoutePolicy.
+type PathAttributes struct {
+	// Communities are in the format "<ASN>:<value>" or well-known community names like "no-export".
+	Communities []string
+	// LargeCommunities are in the format "<global administrator>:<local data part 1>:<local data part 2>".
+	LargeCommunities []string
+	LocalPreference  *uint32
+	MED              *uint32
+}
+
+// RoutePolicyRule is a rule of a RoutePolicy.
+type RoutePolicyRule struct {
+	// Prefixes is the list of prefixes matched by the rule. If empty, the rule matches all routes.
+	Prefixes   []PrefixMatch
+	Action     RouteAction
+	Attributes *PathAttributes
+}
+
+// RoutePolicy is an ordered list of rules applied to the routes advertised to or received from a BGP peer. A route
+// is handled by the first rule it matches, and DefaultAction is taken if it matches no rule.
+type RoutePolicy struct {
+	Rules         []RoutePolicyRule
+	DefaultAction RouteAction
 }
 
 // PeerStatus contains the status information for a BGP peer. More attributes related to status might be added later.
@@ -122,6 +196,14 @@ type PeerStatus struct {
 	GracefulRestartTimeSeconds int32
 	SessionState               SessionState
 	UptimeSeconds              int
+	// EstablishedTime is the time when the BGP session was established. It is zero if the session is not established.
+	EstablishedTime time.Time
+	// AdvertisedRoutes is the number of routes advertised to the peer.
+	AdvertisedRoutes int
+	// ReceivedRoutes is the number of routes received from the peer.
+	ReceivedRoutes int
+	// BFDSessionState is the state of the BFD session with the peer. It is empty if BFD is disabled for the peer.
+	BFDSessionState string
 }
 
 // Route represents a BGP route. Currently only prefix (e.g., "192.168.0.0/24") is needed. More attributes might be
@@ -129,3 +211,10 @@ type PeerStatus struct {
 type Route struct {
 	Prefix string
 }
+
+// ReceivedRoute represents a best route received from a BGP peer.
+type ReceivedRoute struct {
+	Prefix      string
+	NextHop     string
+	PeerAddress string
+}
@@ -99,6 +99,21 @@ func (mr *MockInterfaceMockRecorder) GetPeers(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeers", reflect.TypeOf((*MockInterface)(nil).GetPeers), ctx)
 }
 
+// GetReceivedRoutes mocks base method.
+func (m *MockInterface) GetReceivedRoutes(ctx context.Context) ([]bgp.ReceivedRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedRoutes", ctx)
+	ret0, _ := ret[0].([]bgp.ReceivedRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedRoutes indicates an expected call of GetReceivedRoutes.
+func (mr *MockInterfaceMockRecorder) GetReceivedRoutes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedRoutes", reflect.TypeOf((*MockInterface)(nil).GetReceivedRoutes), ctx)
+}
+
 // GetRoutes mocks base method.
 func (m *MockInterface) GetRoutes(ctx context.Context, routeType bgp.RouteType, peerAddress string) ([]bgp.Route, error) {
 	m.ctrl.T.Helper()
@@ -170,6 +185,20 @@ func (mr *MockInterfaceMockRecorder) UpdatePeer(ctx, peerConf any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePeer", reflect.TypeOf((*MockInterface)(nil).UpdatePeer), ctx, peerConf)
 }
 
+// WatchReceivedRoutes mocks base method.
+func (m *MockInterface) WatchReceivedRoutes(ctx context.Context, handler func()) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchReceivedRoutes", ctx, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchReceivedRoutes indicates an expected call of WatchReceivedRoutes.
+func (mr *MockInterfaceMockRecorder) WatchReceivedRoutes(ctx, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchReceivedRoutes", reflect.TypeOf((*MockInterface)(nil).WatchReceivedRoutes), ctx, handler)
+}
+
 // WithdrawRoutes mocks base method.
 func (m *MockInterface) WithdrawRoutes(ctx context.Context, routes []bgp.Route) error {
 	m.ctrl.T.Helper()
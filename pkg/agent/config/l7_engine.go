@@ -0,0 +1,52 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "strings"
+
+// L7EngineType is the type of the engine enforcing layer 7 NetworkPolicy rules.
+type L7EngineType int
+
+const (
+	L7EngineSuricata L7EngineType = iota
+	L7EngineBuiltin
+	L7EngineInvalid = -1
+)
+
+var (
+	l7EngineTypeStrs = [...]string{
+		"suricata",
+		"builtin",
+	}
+)
+
+// GetL7EngineTypeFromStr returns true and L7EngineType corresponding to input string.
+// Otherwise, false and undefined value is returned
+func GetL7EngineTypeFromStr(str string) (bool, L7EngineType) {
+	for idx, ts := range l7EngineTypeStrs {
+		if strings.EqualFold(ts, str) {
+			return true, L7EngineType(idx)
+		}
+	}
+	return false, L7EngineInvalid
+}
+
+// String returns value in string.
+func (t L7EngineType) String() string {
+	if t == L7EngineInvalid {
+		return "invalid"
+	}
+	return l7EngineTypeStrs[t]
+}
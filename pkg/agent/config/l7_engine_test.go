@@ -0,0 +1,69 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetL7EngineTypeFromStr(t *testing.T) {
+	tests := []struct {
+		name         string
+		str          string
+		expectedOK   bool
+		expectedType L7EngineType
+	}{
+		{
+			name:         "suricata",
+			str:          "suricata",
+			expectedOK:   true,
+			expectedType: L7EngineSuricata,
+		},
+		{
+			name:         "builtin",
+			str:          "builtin",
+			expectedOK:   true,
+			expectedType: L7EngineBuiltin,
+		},
+		{
+			name:         "uppercase builtin",
+			str:          "Builtin",
+			expectedOK:   true,
+			expectedType: L7EngineBuiltin,
+		},
+		{
+			name:       "invalid",
+			str:        "snort",
+			expectedOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOK, gotType := GetL7EngineTypeFromStr(tt.str)
+			assert.Equal(t, tt.expectedOK, gotOK)
+			if tt.expectedOK {
+				assert.Equal(t, tt.expectedType, gotType)
+			}
+		})
+	}
+}
+
+func TestL7EngineTypeString(t *testing.T) {
+	assert.Equal(t, "suricata", L7EngineSuricata.String())
+	assert.Equal(t, "builtin", L7EngineBuiltin.String())
+	assert.Equal(t, "invalid", L7EngineType(L7EngineInvalid).String())
+}
@@ -0,0 +1,331 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectivityprobe
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/v2/pkg/agent/interfacestore"
+	"antrea.io/antrea/v2/pkg/agent/metrics"
+	"antrea.io/antrea/v2/pkg/agent/openflow"
+	"antrea.io/antrea/v2/pkg/agent/packetcapture/capture"
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	crdinformers "antrea.io/antrea/v2/pkg/client/informers/externalversions/crd/v1alpha1"
+	crdlisters "antrea.io/antrea/v2/pkg/client/listers/crd/v1alpha1"
+)
+
+const (
+	controllerName = "AntreaAgentConnectivityProbeController"
+	// Set resyncPeriod to 0 to disable resyncing.
+	resyncPeriod time.Duration = 0
+	// How long to wait before retrying the processing of a ConnectivityProbe.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing ConnectivityProbe events.
+	defaultWorkers = 2
+
+	defaultIntervalSeconds = 60
+	defaultTimeoutSeconds  = 5
+	defaultMaxPodsPerNode  = 1
+)
+
+// packetCapturer captures the packets received on a network device which match the given filter, until ctx is done.
+type packetCapturer interface {
+	Capture(ctx context.Context, device string, snapLen int, srcIP, dstIP net.IP, packet *crdv1alpha1.Packet, serviceDestinations []capture.ServiceDestination, direction crdv1alpha1.CaptureDirection) (chan gopacket.Packet, error)
+}
+
+// probeRunner periodically sends the probes of a ConnectivityProbe from the selected local Pods.
+type probeRunner struct {
+	name string
+	// generation is the generation of the ConnectivityProbe when the runner was started. The runner is restarted
+	// when the spec of the ConnectivityProbe is updated.
+	generation int64
+	spec       crdv1alpha1.ConnectivityProbeSpec
+	cancel     context.CancelFunc
+	done       chan struct{}
+	// podLister gets the destination Pod, if the destination of the ConnectivityProbe is a Pod.
+	podLister corelisters.PodLister
+
+	// stats is protected by the mutex of the Controller, as it's reported in AntreaAgentInfo.
+	stats probeStats
+	// icmpSeq is only accessed by the goroutine of the runner.
+	icmpSeq uint16
+}
+
+type Controller struct {
+	kubeClient                clientset.Interface
+	connectivityProbeInformer crdinformers.ConnectivityProbeInformer
+	connectivityProbeLister   crdlisters.ConnectivityProbeLister
+	connectivityProbeSynced   cache.InformerSynced
+	podLister                 corelisters.PodLister
+	podListerSynced           cache.InformerSynced
+	namespaceLister           corelisters.NamespaceLister
+	namespaceListerSynced     cache.InformerSynced
+	serviceLister             corelisters.ServiceLister
+	serviceListerSynced       cache.InformerSynced
+	ofClient                  openflow.Client
+	interfaceStore            interfacestore.InterfaceStore
+	queue                     workqueue.TypedRateLimitingInterface[string]
+	captureInterface          packetCapturer
+	// lookupIP resolves the FQDN destinations.
+	lookupIP func(ctx context.Context, network, host string) ([]net.IP, error)
+	mutex    sync.Mutex
+	// A name-runner mapping for all the ConnectivityProbes.
+	runners map[string]*probeRunner
+}
+
+// NewConnectivityProbeController creates a controller which sends the probes of the ConnectivityProbes from the Pods
+// running on the local Node. podInformer must only watch the local Pods.
+func NewConnectivityProbeController(
+	kubeClient clientset.Interface,
+	connectivityProbeInformer crdinformers.ConnectivityProbeInformer,
+	podInformer cache.SharedIndexInformer,
+	namespaceInformer coreinformers.NamespaceInformer,
+	serviceInformer coreinformers.ServiceInformer,
+	ofClient openflow.Client,
+	interfaceStore interfacestore.InterfaceStore,
+) (*Controller, error) {
+	c := &Controller{
+		kubeClient:                kubeClient,
+		connectivityProbeInformer: connectivityProbeInformer,
+		connectivityProbeLister:   connectivityProbeInformer.Lister(),
+		connectivityProbeSynced:   connectivityProbeInformer.Informer().HasSynced,
+		podLister:                 corelisters.NewPodLister(podInformer.GetIndexer()),
+		podListerSynced:           podInformer.HasSynced,
+		namespaceLister:           namespaceInformer.Lister(),
+		namespaceListerSynced:     namespaceInformer.Informer().HasSynced,
+		serviceLister:             serviceInformer.Lister(),
+		serviceListerSynced:       serviceInformer.Informer().HasSynced,
+		ofClient:                  ofClient,
+		interfaceStore:            interfaceStore,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[string](minRetryDelay, maxRetryDelay),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "connectivityprobe"},
+		),
+		lookupIP: net.DefaultResolver.LookupIP,
+		runners:  make(map[string]*probeRunner),
+	}
+
+	connectivityProbeInformer.Informer().AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addConnectivityProbe,
+		UpdateFunc: c.updateConnectivityProbe,
+		DeleteFunc: c.deleteConnectivityProbe,
+	}, resyncPeriod)
+
+	capture, err := capture.NewPcapCapture()
+	if err != nil {
+		return nil, err
+	}
+	c.captureInterface = capture
+	return c, nil
+}
+
+// Run will create defaultWorkers workers (go routines) which will process the ConnectivityProbe events from the
+// workqueue.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting controller", "name", controllerName)
+	defer klog.InfoS("Shutting down controller", "name", controllerName)
+
+	cacheSynced := []cache.InformerSynced{c.connectivityProbeSynced, c.podListerSynced, c.namespaceListerSynced, c.serviceListerSynced}
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, cacheSynced...) {
+		return
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+
+	c.mutex.Lock()
+	runners := c.runners
+	c.runners = make(map[string]*probeRunner)
+	c.mutex.Unlock()
+	for _, r := range runners {
+		r.stop()
+	}
+}
+
+func (c *Controller) addConnectivityProbe(obj interface{}) {
+	cp := obj.(*crdv1alpha1.ConnectivityProbe)
+	klog.V(2).InfoS("Processing ConnectivityProbe ADD event", "name", cp.Name)
+	c.queue.Add(cp.Name)
+}
+
+func (c *Controller) updateConnectivityProbe(_, obj interface{}) {
+	cp := obj.(*crdv1alpha1.ConnectivityProbe)
+	klog.V(2).InfoS("Processing ConnectivityProbe UPDATE event", "name", cp.Name)
+	c.queue.Add(cp.Name)
+}
+
+func (c *Controller) deleteConnectivityProbe(obj interface{}) {
+	cp, ok := obj.(*crdv1alpha1.ConnectivityProbe)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Received unexpected object", "object", obj)
+			return
+		}
+		cp, ok = deletedState.Obj.(*crdv1alpha1.ConnectivityProbe)
+		if !ok {
+			klog.ErrorS(nil, "DeletedFinalStateUnknown contains non-ConnectivityProbe object", "object", deletedState.Obj)
+			return
+		}
+	}
+	klog.V(2).InfoS("Processing ConnectivityProbe DELETE event", "name", cp.Name)
+	c.queue.Add(cp.Name)
+}
+
+func (c *Controller) worker() {
+	for c.processConnectivityProbeItem() {
+	}
+}
+
+func (c *Controller) processConnectivityProbeItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncConnectivityProbe(key); err != nil {
+		klog.ErrorS(err, "Error syncing ConnectivityProbe", "name", key)
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+// syncConnectivityProbe starts the runner of the ConnectivityProbe, restarts it if the spec of the ConnectivityProbe
+// has been updated, or stops it if the ConnectivityProbe has been deleted.
+func (c *Controller) syncConnectivityProbe(name string) error {
+	cp, err := c.connectivityProbeLister.Get(name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		c.stopRunner(name)
+		metrics.ConnectivityProbeSentCount.DeleteLabelValues(name)
+		metrics.ConnectivityProbeSucceededCount.DeleteLabelValues(name)
+		metrics.ConnectivityProbeLatency.DeleteLabelValues(name)
+		return nil
+	}
+
+	c.mutex.Lock()
+	r, exists := c.runners[name]
+	c.mutex.Unlock()
+	if exists && r.generation == cp.Generation {
+		return nil
+	}
+	if exists {
+		klog.V(2).InfoS("Restarting ConnectivityProbe as its spec has been updated", "name", name)
+		c.stopRunner(name)
+	}
+	c.startRunner(cp)
+	return nil
+}
+
+func (c *Controller) startRunner(cp *crdv1alpha1.ConnectivityProbe) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &probeRunner{
+		name:       cp.Name,
+		generation: cp.Generation,
+		spec:       *cp.Spec.DeepCopy(),
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	setDefaults(&r.spec)
+	var podInformer cache.SharedIndexInformer
+	if dstPod := r.spec.Destination.Pod; dstPod != nil {
+		// Watch the destination Pod only, rather than getting it from the K8s API in every round of probes.
+		podInformer = coreinformers.NewFilteredPodInformer(c.kubeClient, dstPod.Namespace, resyncPeriod, cache.Indexers{}, func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", dstPod.Name).String()
+		})
+		r.podLister = corelisters.NewPodLister(podInformer.GetIndexer())
+	}
+	c.mutex.Lock()
+	c.runners[cp.Name] = r
+	c.mutex.Unlock()
+
+	interval := time.Duration(r.spec.IntervalSeconds) * time.Second
+	klog.InfoS("Starting ConnectivityProbe", "name", cp.Name, "interval", interval)
+	go func() {
+		defer close(r.done)
+		if podInformer != nil {
+			go podInformer.Run(ctx.Done())
+			if !cache.WaitForNamedCacheSync(controllerName, ctx.Done(), podInformer.HasSynced) {
+				return
+			}
+		}
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			c.probeRound(ctx, r)
+		}, interval)
+	}()
+}
+
+// stopRunner stops the runner of the ConnectivityProbe if any, and waits for the ongoing round of probes to be
+// interrupted.
+func (c *Controller) stopRunner(name string) {
+	c.mutex.Lock()
+	r, exists := c.runners[name]
+	delete(c.runners, name)
+	c.mutex.Unlock()
+	if !exists {
+		return
+	}
+	klog.InfoS("Stopping ConnectivityProbe", "name", name)
+	r.stop()
+}
+
+func (r *probeRunner) stop() {
+	r.cancel()
+	<-r.done
+}
+
+func setDefaults(spec *crdv1alpha1.ConnectivityProbeSpec) {
+	if spec.Protocol == "" {
+		spec.Protocol = crdv1alpha1.ConnectivityProbeProtocolICMP
+	}
+	if spec.IntervalSeconds <= 0 {
+		spec.IntervalSeconds = defaultIntervalSeconds
+	}
+	if spec.TimeoutSeconds <= 0 {
+		spec.TimeoutSeconds = defaultTimeoutSeconds
+	}
+	if spec.TimeoutSeconds >= spec.IntervalSeconds {
+		spec.TimeoutSeconds = spec.IntervalSeconds
+	}
+	if spec.Source.MaxPodsPerNode == nil {
+		maxPods := int32(defaultMaxPodsPerNode)
+		spec.Source.MaxPodsPerNode = &maxPods
+	}
+}
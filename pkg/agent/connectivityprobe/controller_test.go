@@ -0,0 +1,693 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectivityprobe
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"antrea.io/libOpenflow/protocol"
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/component-base/metrics/testutil"
+
+	"antrea.io/antrea/v2/pkg/agent/interfacestore"
+	"antrea.io/antrea/v2/pkg/agent/metrics"
+	oftest "antrea.io/antrea/v2/pkg/agent/openflow/testing"
+	"antrea.io/antrea/v2/pkg/agent/packetcapture/capture"
+	"antrea.io/antrea/v2/pkg/agent/util"
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	crdv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+	fakeversioned "antrea.io/antrea/v2/pkg/client/clientset/versioned/fake"
+	crdinformers "antrea.io/antrea/v2/pkg/client/informers/externalversions"
+	binding "antrea.io/antrea/v2/pkg/ovs/openflow"
+)
+
+const (
+	pod1IPv4 = "10.10.0.2"
+	pod1IPv6 = "fd00::2"
+	pod2IPv4 = "10.10.0.3"
+	pod3IPv4 = "10.10.0.4"
+	dstIPv4  = "10.10.1.2"
+	dstIPv6  = "fd00:1::2"
+	svcIPv4  = "10.96.0.10"
+
+	ofPortPod1 = 3
+	ofPortPod2 = 4
+)
+
+var (
+	pod1MAC, _ = net.ParseMAC("aa:bb:cc:dd:ee:01")
+	pod2MAC, _ = net.ParseMAC("aa:bb:cc:dd:ee:02")
+	pod3MAC, _ = net.ParseMAC("aa:bb:cc:dd:ee:03")
+
+	ns1 = v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"probe": "true"}},
+	}
+	ns2 = v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns2"},
+	}
+	pod1 = v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1", Labels: map[string]string{"app": "client"}},
+		Spec:       v1.PodSpec{NodeName: "node1"},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	pod2 = v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "ns1", Labels: map[string]string{"app": "client"}},
+		Spec:       v1.PodSpec{NodeName: "node1"},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	pod3 = v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod3", Namespace: "ns2", Labels: map[string]string{"app": "client"}},
+		Spec:       v1.PodSpec{NodeName: "node1"},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	pendingPod = v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending-pod", Namespace: "ns1", Labels: map[string]string{"app": "client"}},
+		Spec:       v1.PodSpec{NodeName: "node1"},
+		Status:     v1.PodStatus{Phase: v1.PodPending},
+	}
+	dstPod = v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "server", Namespace: "ns2"},
+		Spec:       v1.PodSpec{NodeName: "node2"},
+		Status: v1.PodStatus{
+			Phase:  v1.PodRunning,
+			PodIPs: []v1.PodIP{{IP: dstIPv4}, {IP: dstIPv6}},
+		},
+	}
+	service1 = v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc1", Namespace: "ns2"},
+		Spec: v1.ServiceSpec{
+			ClusterIP:  svcIPv4,
+			ClusterIPs: []string{svcIPv4},
+			Ports:      []v1.ServicePort{{Port: 80, Protocol: v1.ProtocolTCP}},
+		},
+	}
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+// testCapture delivers the replies of the probes sent by the mock OpenFlow client.
+type testCapture struct {
+	mutex   sync.Mutex
+	packets map[string]chan gopacket.Packet
+}
+
+func (p *testCapture) Capture(ctx context.Context, device string, snapLen int, srcIP, dstIP net.IP, packet *crdv1alpha1.Packet, serviceDestinations []capture.ServiceDestination, direction crdv1alpha1.CaptureDirection) (chan gopacket.Packet, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	ch := make(chan gopacket.Packet, 1)
+	p.packets[srcIP.String()] = ch
+	return ch, nil
+}
+
+func (p *testCapture) reply(packet gopacket.Packet, srcIP net.IP) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.packets[srcIP.String()] <- packet
+}
+
+type fakeController struct {
+	*Controller
+	mockOFClient       *oftest.MockClient
+	crdClient          *fakeversioned.Clientset
+	crdInformerFactory crdinformers.SharedInformerFactory
+	informerFactory    informers.SharedInformerFactory
+	capture            *testCapture
+}
+
+func newFakeController(t *testing.T, crdObjects ...runtime.Object) *fakeController {
+	ctrl := gomock.NewController(t)
+	kubeClient := fake.NewSimpleClientset(&ns1, &ns2, &pod1, &pod2, &pod3, &pendingPod, &dstPod, &service1)
+	crdClient := fakeversioned.NewSimpleClientset(crdObjects...)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	informerFactory := informers.NewSharedInformerFactory(kubeClient, 0)
+	mockOFClient := oftest.NewMockClient(ctrl)
+
+	ifaceStore := interfacestore.NewInterfaceStore()
+	addPodInterface(ifaceStore, &pod1, []string{pod1IPv4, pod1IPv6}, pod1MAC, ofPortPod1)
+	addPodInterface(ifaceStore, &pod2, []string{pod2IPv4}, pod2MAC, ofPortPod2)
+	addPodInterface(ifaceStore, &pod3, []string{pod3IPv4}, pod3MAC, 5)
+
+	c, err := NewConnectivityProbeController(
+		kubeClient,
+		crdInformerFactory.Crd().V1alpha1().ConnectivityProbes(),
+		informerFactory.Core().V1().Pods().Informer(),
+		informerFactory.Core().V1().Namespaces(),
+		informerFactory.Core().V1().Services(),
+		mockOFClient,
+		ifaceStore,
+	)
+	require.NoError(t, err)
+	capture := &testCapture{packets: make(map[string]chan gopacket.Packet)}
+	c.captureInterface = capture
+	c.lookupIP = func(ctx context.Context, network, host string) ([]net.IP, error) {
+		if host == "server.example.com" {
+			return []net.IP{net.ParseIP(dstIPv6), net.ParseIP(dstIPv4)}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	c.queue = workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[string](time.Millisecond*50, time.Millisecond*200),
+		workqueue.TypedRateLimitingQueueConfig[string]{Name: "connectivityprobe"},
+	)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	crdInformerFactory.Start(stopCh)
+	informerFactory.Start(stopCh)
+	crdInformerFactory.WaitForCacheSync(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+	return &fakeController{
+		Controller:         c,
+		mockOFClient:       mockOFClient,
+		crdClient:          crdClient,
+		crdInformerFactory: crdInformerFactory,
+		informerFactory:    informerFactory,
+		capture:            capture,
+	}
+}
+
+func addPodInterface(ifaceStore interfacestore.InterfaceStore, pod *v1.Pod, podIPs []string, mac net.HardwareAddr, ofPort int32) {
+	containerID := pod.Namespace + "/" + pod.Name
+	var ifIPs []net.IP
+	for _, ip := range podIPs {
+		ifIPs = append(ifIPs, net.ParseIP(ip))
+	}
+	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		Type:                     interfacestore.ContainerInterface,
+		IPs:                      ifIPs,
+		MAC:                      mac,
+		InterfaceName:            util.GenerateContainerInterfaceName(pod.Name, pod.Namespace, containerID),
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: pod.Name, PodNamespace: pod.Namespace, ContainerID: containerID},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: ofPort},
+	})
+}
+
+// craftReply returns a packet sent by the destination of the probe to the source Pod, with the given transport
+// layers.
+func craftReply(t *testing.T, probe *binding.Packet, transport ...gopacket.SerializableLayer) gopacket.Packet {
+	var ipProtocol layers.IPProtocol
+	switch transport[0].(type) {
+	case *layers.TCP:
+		ipProtocol = layers.IPProtocolTCP
+	case *layers.UDP:
+		ipProtocol = layers.IPProtocolUDP
+	case *layers.ICMPv6:
+		ipProtocol = layers.IPProtocolICMPv6
+	default:
+		ipProtocol = layers.IPProtocolICMPv4
+	}
+	ethernet := &layers.Ethernet{SrcMAC: pod2MAC, DstMAC: probe.SourceMAC, EthernetType: layers.EthernetTypeIPv4}
+	var ip gopacket.SerializableLayer = &layers.IPv4{Version: 4, IHL: 5, TTL: 64, Protocol: ipProtocol, SrcIP: probe.DestinationIP, DstIP: probe.SourceIP}
+	if probe.IsIPv6 {
+		ethernet.EthernetType = layers.EthernetTypeIPv6
+		ip = &layers.IPv6{Version: 6, HopLimit: 64, NextHeader: ipProtocol, SrcIP: probe.DestinationIP, DstIP: probe.SourceIP}
+	}
+	buffer := gopacket.NewSerializeBuffer()
+	require.NoError(t, gopacket.SerializeLayers(buffer, gopacket.SerializeOptions{FixLengths: true}, append([]gopacket.SerializableLayer{ethernet, ip}, transport...)...))
+	return gopacket.NewPacket(buffer.Bytes(), layers.LayerTypeEthernet, gopacket.Default)
+}
+
+func TestGetSourcePods(t *testing.T) {
+	c := newFakeController(t)
+	tests := []struct {
+		name         string
+		source       crdv1alpha1.ConnectivityProbeSource
+		expectedPods []string
+	}{
+		{
+			name: "namespace selector",
+			source: crdv1alpha1.ConnectivityProbeSource{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"probe": "true"}},
+				MaxPodsPerNode:    int32Ptr(0),
+			},
+			expectedPods: []string{"ns1/pod1", "ns1/pod2"},
+		},
+		{
+			name: "pod selector",
+			source: crdv1alpha1.ConnectivityProbeSource{
+				PodSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}},
+				MaxPodsPerNode: int32Ptr(0),
+			},
+			expectedPods: []string{"ns1/pod1", "ns1/pod2", "ns2/pod3"},
+		},
+		{
+			name: "max pods per node",
+			source: crdv1alpha1.ConnectivityProbeSource{
+				PodSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}},
+				MaxPodsPerNode: int32Ptr(2),
+			},
+			expectedPods: []string{"ns1/pod1", "ns1/pod2"},
+		},
+		{
+			name: "no matching pod",
+			source: crdv1alpha1.ConnectivityProbeSource{
+				PodSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "server"}},
+				MaxPodsPerNode: int32Ptr(1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods, err := c.getSourcePods(&tt.source)
+			require.NoError(t, err)
+			var podNames []string
+			for _, pod := range pods {
+				podNames = append(podNames, pod.String())
+			}
+			assert.Equal(t, tt.expectedPods, podNames)
+		})
+	}
+}
+
+func TestResolveDestination(t *testing.T) {
+	c := newFakeController(t)
+	tests := []struct {
+		name          string
+		destination   crdv1alpha1.ConnectivityProbeDestination
+		expectedIPs   []net.IP
+		expectedError string
+	}{
+		{
+			name:        "pod",
+			destination: crdv1alpha1.ConnectivityProbeDestination{Pod: &crdv1alpha1.PodReference{Namespace: "ns2", Name: "server"}},
+			expectedIPs: []net.IP{net.ParseIP(dstIPv4), net.ParseIP(dstIPv6)},
+		},
+		{
+			name:          "missing pod",
+			destination:   crdv1alpha1.ConnectivityProbeDestination{Pod: &crdv1alpha1.PodReference{Namespace: "ns2", Name: "missing"}},
+			expectedError: "failed to get the destination Pod",
+		},
+		{
+			name:        "service",
+			destination: crdv1alpha1.ConnectivityProbeDestination{Service: &crdv1alpha1.ServiceReference{Namespace: "ns2", Name: "svc1"}},
+			expectedIPs: []net.IP{net.ParseIP(svcIPv4)},
+		},
+		{
+			name:        "fqdn",
+			destination: crdv1alpha1.ConnectivityProbeDestination{FQDN: "server.example.com"},
+			expectedIPs: []net.IP{net.ParseIP(dstIPv6), net.ParseIP(dstIPv4)},
+		},
+		{
+			name:          "unknown fqdn",
+			destination:   crdv1alpha1.ConnectivityProbeDestination{FQDN: "unknown.example.com"},
+			expectedError: "failed to resolve the destination FQDN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ips, err := c.resolveDestination(context.Background(), &tt.destination, c.informerFactory.Core().V1().Pods().Lister())
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedIPs, ips)
+		})
+	}
+}
+
+func TestNewProbePacket(t *testing.T) {
+	intf := &interfacestore.InterfaceConfig{MAC: pod1MAC}
+	srcIP, dstIP := net.ParseIP(pod1IPv4).To4(), net.ParseIP(dstIPv4).To4()
+	tcpPacket := newProbePacket(&crdv1alpha1.ConnectivityProbeSpec{Protocol: crdv1alpha1.ConnectivityProbeProtocolTCP, Port: 80}, intf, srcIP, dstIP, 0)
+	assert.Equal(t, uint8(protocol.Type_TCP), tcpPacket.IPProto)
+	assert.Equal(t, uint16(80), tcpPacket.DestinationPort)
+	assert.Equal(t, tcpSyn, tcpPacket.TCPFlags)
+	assert.GreaterOrEqual(t, tcpPacket.SourcePort, uint16(minSourcePort))
+	assert.LessOrEqual(t, tcpPacket.SourcePort, uint16(maxSourcePort))
+	assert.Equal(t, pod1MAC, tcpPacket.SourceMAC)
+
+	udpPacket := newProbePacket(&crdv1alpha1.ConnectivityProbeSpec{Protocol: crdv1alpha1.ConnectivityProbeProtocolUDP, Port: 53}, intf, srcIP, dstIP, 0)
+	assert.Equal(t, uint8(protocol.Type_UDP), udpPacket.IPProto)
+	assert.Equal(t, uint16(53), udpPacket.DestinationPort)
+
+	icmpPacket := newProbePacket(&crdv1alpha1.ConnectivityProbeSpec{Protocol: crdv1alpha1.ConnectivityProbeProtocolICMP}, intf, srcIP, dstIP, 7)
+	assert.Equal(t, uint8(protocol.Type_ICMP), icmpPacket.IPProto)
+	assert.Equal(t, icmpEchoRequestType, icmpPacket.ICMPType)
+	assert.Equal(t, uint16(7), icmpPacket.ICMPEchoSeq)
+	assert.False(t, icmpPacket.IsIPv6)
+
+	icmpv6Packet := newProbePacket(&crdv1alpha1.ConnectivityProbeSpec{Protocol: crdv1alpha1.ConnectivityProbeProtocolICMP}, intf, net.ParseIP(pod1IPv6), net.ParseIP(dstIPv6), 7)
+	assert.Equal(t, uint8(protocol.Type_IPv6ICMP), icmpv6Packet.IPProto)
+	assert.Equal(t, icmpv6EchoRequestType, icmpv6Packet.ICMPType)
+	assert.True(t, icmpv6Packet.IsIPv6)
+}
+
+func TestMatchReply(t *testing.T) {
+	srcIP, dstIP := net.ParseIP(pod1IPv4).To4(), net.ParseIP(dstIPv4).To4()
+	tcpProbe := &binding.Packet{SourceMAC: pod1MAC, SourceIP: srcIP, DestinationIP: dstIP, IPProto: protocol.Type_TCP, SourcePort: 40000, DestinationPort: 80}
+	udpProbe := &binding.Packet{SourceMAC: pod1MAC, SourceIP: srcIP, DestinationIP: dstIP, IPProto: protocol.Type_UDP, SourcePort: 40000, DestinationPort: 53}
+	icmpProbe := &binding.Packet{SourceMAC: pod1MAC, SourceIP: srcIP, DestinationIP: dstIP, IPProto: protocol.Type_ICMP, ICMPEchoID: 100, ICMPEchoSeq: 1}
+	icmpv6Probe := &binding.Packet{IsIPv6: true, SourceMAC: pod1MAC, SourceIP: net.ParseIP(pod1IPv6), DestinationIP: net.ParseIP(dstIPv6), IPProto: protocol.Type_IPv6ICMP, ICMPEchoID: 100, ICMPEchoSeq: 1}
+	tests := []struct {
+		name            string
+		probe           *binding.Packet
+		reply           []gopacket.SerializableLayer
+		expectedMatched bool
+		expectedError   string
+	}{
+		{
+			name:            "TCP SYN-ACK",
+			probe:           tcpProbe,
+			reply:           []gopacket.SerializableLayer{&layers.TCP{SrcPort: 80, DstPort: 40000, SYN: true, ACK: true}},
+			expectedMatched: true,
+		},
+		{
+			name:            "TCP RST",
+			probe:           tcpProbe,
+			reply:           []gopacket.SerializableLayer{&layers.TCP{SrcPort: 80, DstPort: 40000, RST: true, ACK: true}},
+			expectedMatched: true,
+			expectedError:   "connection refused",
+		},
+		{
+			name:  "TCP other connection",
+			probe: tcpProbe,
+			reply: []gopacket.SerializableLayer{&layers.TCP{SrcPort: 80, DstPort: 40001, SYN: true, ACK: true}},
+		},
+		{
+			name:            "UDP reply",
+			probe:           udpProbe,
+			reply:           []gopacket.SerializableLayer{&layers.UDP{SrcPort: 53, DstPort: 40000}},
+			expectedMatched: true,
+		},
+		{
+			name:  "UDP other port",
+			probe: udpProbe,
+			reply: []gopacket.SerializableLayer{&layers.UDP{SrcPort: 54, DstPort: 40000}},
+		},
+		{
+			name:            "ICMP echo reply",
+			probe:           icmpProbe,
+			reply:           []gopacket.SerializableLayer{&layers.ICMPv4{TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoReply, 0), Id: 100, Seq: 1}},
+			expectedMatched: true,
+		},
+		{
+			name:  "ICMP echo reply for another probe",
+			probe: icmpProbe,
+			reply: []gopacket.SerializableLayer{&layers.ICMPv4{TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoReply, 0), Id: 101, Seq: 1}},
+		},
+		{
+			name:            "ICMP destination unreachable",
+			probe:           udpProbe,
+			reply:           []gopacket.SerializableLayer{&layers.ICMPv4{TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable, layers.ICMPv4CodePort)}},
+			expectedMatched: true,
+			expectedError:   "destination unreachable (ICMP code 3)",
+		},
+		{
+			name:  "ICMPv6 echo reply",
+			probe: icmpv6Probe,
+			reply: []gopacket.SerializableLayer{
+				&layers.ICMPv6{TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypeEchoReply, 0)},
+				&layers.ICMPv6Echo{Identifier: 100, SeqNumber: 1},
+			},
+			expectedMatched: true,
+		},
+		{
+			name:            "ICMPv6 destination unreachable",
+			probe:           icmpv6Probe,
+			reply:           []gopacket.SerializableLayer{&layers.ICMPv6{TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypeDestinationUnreachable, layers.ICMPv6CodeAdminProhibited)}},
+			expectedMatched: true,
+			expectedError:   "destination unreachable (ICMPv6 code 1)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, err := matchReply(tt.probe, craftReply(t, tt.probe, tt.reply...))
+			assert.Equal(t, tt.expectedMatched, matched)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProbeRound(t *testing.T) {
+	metrics.InitializeConnectivityProbeMetrics()
+
+	tests := []struct {
+		name string
+		spec crdv1alpha1.ConnectivityProbeSpec
+		// reply returns the layers of the reply to the probe, or nil if the probe is not answered.
+		reply                 func(probe *binding.Packet) []gopacket.SerializableLayer
+		expectedProbes        int
+		expectedSucceeded     int64
+		expectedFailureSubstr string
+	}{
+		{
+			name: "TCP to Service",
+			spec: crdv1alpha1.ConnectivityProbeSpec{
+				Source:      crdv1alpha1.ConnectivityProbeSource{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"probe": "true"}}, MaxPodsPerNode: int32Ptr(0)},
+				Destination: crdv1alpha1.ConnectivityProbeDestination{Service: &crdv1alpha1.ServiceReference{Namespace: "ns2", Name: "svc1"}},
+				Protocol:    crdv1alpha1.ConnectivityProbeProtocolTCP,
+				Port:        80,
+			},
+			reply: func(probe *binding.Packet) []gopacket.SerializableLayer {
+				assert.Equal(t, svcIPv4, probe.DestinationIP.String())
+				return []gopacket.SerializableLayer{&layers.TCP{SrcPort: layers.TCPPort(probe.DestinationPort), DstPort: layers.TCPPort(probe.SourcePort), SYN: true, ACK: true}}
+			},
+			expectedProbes:    2,
+			expectedSucceeded: 2,
+		},
+		{
+			name: "ICMP to Pod",
+			spec: crdv1alpha1.ConnectivityProbeSpec{
+				Source:      crdv1alpha1.ConnectivityProbeSource{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}}},
+				Destination: crdv1alpha1.ConnectivityProbeDestination{Pod: &crdv1alpha1.PodReference{Namespace: "ns2", Name: "server"}},
+			},
+			reply: func(probe *binding.Packet) []gopacket.SerializableLayer {
+				return []gopacket.SerializableLayer{&layers.ICMPv4{TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoReply, 0), Id: probe.ICMPEchoID, Seq: probe.ICMPEchoSeq}}
+			},
+			expectedProbes:    1,
+			expectedSucceeded: 1,
+		},
+		{
+			name: "TCP connection refused",
+			spec: crdv1alpha1.ConnectivityProbeSpec{
+				Source:      crdv1alpha1.ConnectivityProbeSource{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}}},
+				Destination: crdv1alpha1.ConnectivityProbeDestination{FQDN: "server.example.com"},
+				Protocol:    crdv1alpha1.ConnectivityProbeProtocolTCP,
+				Port:        443,
+			},
+			reply: func(probe *binding.Packet) []gopacket.SerializableLayer {
+				// pod1 has an IPv6 address, the first resolved IP is used.
+				assert.Equal(t, dstIPv6, probe.DestinationIP.String())
+				return []gopacket.SerializableLayer{&layers.TCP{SrcPort: layers.TCPPort(probe.DestinationPort), DstPort: layers.TCPPort(probe.SourcePort), RST: true, ACK: true}}
+			},
+			expectedProbes:        1,
+			expectedFailureSubstr: "Pod ns1/pod1: connection refused",
+		},
+		{
+			name: "UDP without reply",
+			spec: crdv1alpha1.ConnectivityProbeSpec{
+				Source:         crdv1alpha1.ConnectivityProbeSource{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}}},
+				Destination:    crdv1alpha1.ConnectivityProbeDestination{Pod: &crdv1alpha1.PodReference{Namespace: "ns2", Name: "server"}},
+				Protocol:       crdv1alpha1.ConnectivityProbeProtocolUDP,
+				Port:           53,
+				TimeoutSeconds: 1,
+			},
+			reply:                 func(probe *binding.Packet) []gopacket.SerializableLayer { return nil },
+			expectedProbes:        1,
+			expectedFailureSubstr: "Pod ns1/pod1: no reply received within 1s",
+		},
+		{
+			name: "unknown destination",
+			spec: crdv1alpha1.ConnectivityProbeSpec{
+				Source:      crdv1alpha1.ConnectivityProbeSource{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}}},
+				Destination: crdv1alpha1.ConnectivityProbeDestination{FQDN: "unknown.example.com"},
+			},
+			expectedFailureSubstr: "failed to resolve the destination FQDN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := &crdv1alpha1.ConnectivityProbe{
+				ObjectMeta: metav1.ObjectMeta{Name: "probe-" + tt.name},
+				Spec:       tt.spec,
+			}
+			c := newFakeController(t, cp)
+			metrics.ConnectivityProbeSentCount.DeleteLabelValues(cp.Name)
+			metrics.ConnectivityProbeSucceededCount.DeleteLabelValues(cp.Name)
+			if tt.expectedProbes > 0 {
+				c.mockOFClient.EXPECT().SendTraceflowPacket(uint8(0), gomock.Any(), gomock.Any(), int32(-1)).Times(tt.expectedProbes).DoAndReturn(
+					func(dataplaneTag uint8, packet *binding.Packet, inPort uint32, outPort int32) error {
+						if reply := tt.reply(packet); reply != nil {
+							c.capture.reply(craftReply(t, packet, reply...), packet.SourceIP)
+						}
+						return nil
+					})
+			}
+			r := &probeRunner{name: cp.Name, spec: *cp.Spec.DeepCopy(), podLister: c.informerFactory.Core().V1().Pods().Lister()}
+			setDefaults(&r.spec)
+			c.runners[cp.Name] = r
+			c.probeRound(context.Background(), r)
+
+			expectedSent := int64(tt.expectedProbes)
+			if expectedSent == 0 {
+				expectedSent = 1
+			}
+			assert.Equal(t, expectedSent, r.stats.sent)
+			assert.Equal(t, tt.expectedSucceeded, r.stats.succeeded)
+			assert.Contains(t, r.stats.lastFailureMessage, tt.expectedFailureSubstr)
+
+			infos := c.GetConnectivityProbeInfos()
+			require.Len(t, infos, 1)
+			assert.Equal(t, cp.Name, infos[0].Name)
+			assert.Equal(t, expectedSent, infos[0].ProbesSent)
+			assert.Equal(t, tt.expectedSucceeded, infos[0].ProbesSucceeded)
+			assert.Contains(t, infos[0].LastFailureMessage, tt.expectedFailureSubstr)
+
+			sent, err := testutil.GetCounterMetricValue(metrics.ConnectivityProbeSentCount.WithLabelValues(cp.Name))
+			require.NoError(t, err)
+			assert.Equal(t, float64(expectedSent), sent)
+			succeeded, err := testutil.GetCounterMetricValue(metrics.ConnectivityProbeSucceededCount.WithLabelValues(cp.Name))
+			require.NoError(t, err)
+			assert.Equal(t, float64(tt.expectedSucceeded), succeeded)
+		})
+	}
+}
+
+func TestProbeRoundWithoutSourcePods(t *testing.T) {
+	cp := &crdv1alpha1.ConnectivityProbe{
+		ObjectMeta: metav1.ObjectMeta{Name: "probe"},
+		Spec: crdv1alpha1.ConnectivityProbeSpec{
+			Source:      crdv1alpha1.ConnectivityProbeSource{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "server"}}},
+			Destination: crdv1alpha1.ConnectivityProbeDestination{Pod: &crdv1alpha1.PodReference{Namespace: "ns2", Name: "server"}},
+		},
+	}
+	c := newFakeController(t, cp)
+	r := &probeRunner{name: cp.Name, spec: *cp.Spec.DeepCopy(), stats: probeStats{sent: 2}}
+	setDefaults(&r.spec)
+	c.runners[cp.Name] = r
+	c.probeRound(context.Background(), r)
+
+	// The local Node no longer sends probes, its results are no longer reported.
+	assert.Equal(t, probeStats{}, r.stats)
+	assert.Empty(t, c.GetConnectivityProbeInfos())
+}
+
+func TestGetConnectivityProbeInfos(t *testing.T) {
+	lastProbeTime := time.Now().Truncate(time.Second)
+	c := newFakeController(t)
+	c.runners["probe-b"] = &probeRunner{name: "probe-b", stats: probeStats{
+		sent:               4,
+		succeeded:          2,
+		totalLatency:       3 * time.Millisecond,
+		lastProbeTime:      lastProbeTime,
+		lastFailureMessage: "Pod ns1/pod1: connection refused",
+	}}
+	c.runners["probe-a"] = &probeRunner{name: "probe-a", stats: probeStats{
+		sent:          1,
+		lastProbeTime: lastProbeTime,
+	}}
+	c.runners["probe-c"] = &probeRunner{name: "probe-c"}
+
+	assert.Equal(t, []crdv1beta1.ConnectivityProbeInfo{
+		{
+			Name:          "probe-a",
+			ProbesSent:    1,
+			LastProbeTime: metav1.NewTime(lastProbeTime),
+		},
+		{
+			Name:                      "probe-b",
+			ProbesSent:                4,
+			ProbesSucceeded:           2,
+			AverageLatencyNanoseconds: 1500000,
+			LastProbeTime:             metav1.NewTime(lastProbeTime),
+			LastFailureMessage:        "Pod ns1/pod1: connection refused",
+		},
+	}, c.GetConnectivityProbeInfos())
+}
+
+func TestSyncConnectivityProbe(t *testing.T) {
+	cp := &crdv1alpha1.ConnectivityProbe{
+		ObjectMeta: metav1.ObjectMeta{Name: "probe", Generation: 1},
+		Spec: crdv1alpha1.ConnectivityProbeSpec{
+			// No local Pod is selected, so that no probe is sent.
+			Source:      crdv1alpha1.ConnectivityProbeSource{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "server"}}},
+			Destination: crdv1alpha1.ConnectivityProbeDestination{FQDN: "server.example.com"},
+		},
+	}
+	c := newFakeController(t, cp)
+
+	getRunner := func() *probeRunner {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		return c.runners[cp.Name]
+	}
+
+	require.NoError(t, c.syncConnectivityProbe(cp.Name))
+	r := getRunner()
+	require.NotNil(t, r)
+	assert.Equal(t, int32(defaultIntervalSeconds), r.spec.IntervalSeconds)
+	assert.Equal(t, int32(defaultTimeoutSeconds), r.spec.TimeoutSeconds)
+	assert.Equal(t, crdv1alpha1.ConnectivityProbeProtocolICMP, r.spec.Protocol)
+	assert.Equal(t, int32(defaultMaxPodsPerNode), *r.spec.Source.MaxPodsPerNode)
+
+	// The runner is kept as long as the spec is not updated.
+	require.NoError(t, c.syncConnectivityProbe(cp.Name))
+	assert.Same(t, r, getRunner())
+
+	updatedCP := cp.DeepCopy()
+	updatedCP.Generation = 2
+	updatedCP.Spec.IntervalSeconds = 10
+	_, err := c.crdClient.CrdV1alpha1().ConnectivityProbes().Update(context.Background(), updatedCP, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		cp, err := c.connectivityProbeLister.Get(cp.Name)
+		return err == nil && cp.Generation == 2
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, c.syncConnectivityProbe(cp.Name))
+	newRunner := getRunner()
+	require.NotNil(t, newRunner)
+	assert.NotSame(t, r, newRunner)
+	assert.Equal(t, int32(10), newRunner.spec.IntervalSeconds)
+	select {
+	case <-r.done:
+	default:
+		t.Fatal("The previous runner should have been stopped")
+	}
+
+	require.NoError(t, c.crdClient.CrdV1alpha1().ConnectivityProbes().Delete(context.Background(), cp.Name, metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		_, err := c.connectivityProbeLister.Get(cp.Name)
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, c.syncConnectivityProbe(cp.Name))
+	assert.Nil(t, getRunner())
+	select {
+	case <-newRunner.done:
+	default:
+		t.Fatal("The runner should have been stopped")
+	}
+}
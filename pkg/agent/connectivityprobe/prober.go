@@ -0,0 +1,381 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectivityprobe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"antrea.io/libOpenflow/protocol"
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/v2/pkg/agent/interfacestore"
+	"antrea.io/antrea/v2/pkg/agent/metrics"
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	binding "antrea.io/antrea/v2/pkg/ovs/openflow"
+)
+
+const (
+	// ICMP Echo Request type.
+	icmpEchoRequestType   uint8 = 8
+	icmpv6EchoRequestType uint8 = 128
+
+	tcpSyn uint8 = 0b000010
+
+	defaultTTL uint8 = 64
+
+	// The replies are only parsed up to their transport header.
+	snapLen = 128
+
+	// The source ports of the TCP and UDP probes are picked in the ephemeral port range of Linux.
+	minSourcePort = 32768
+	maxSourcePort = 60999
+)
+
+// probeStats are the statistics of the probes sent from the local Node, since the runner was started.
+type probeStats struct {
+	sent               int64
+	succeeded          int64
+	totalLatency       time.Duration
+	lastProbeTime      time.Time
+	lastFailureMessage string
+}
+
+type probeResult struct {
+	latency time.Duration
+	err     error
+}
+
+// sourcePod is a local Pod from which probes are sent.
+type sourcePod struct {
+	namespace string
+	name      string
+	intf      *interfacestore.InterfaceConfig
+}
+
+func (p *sourcePod) String() string {
+	return p.namespace + "/" + p.name
+}
+
+// probeRound sends one probe from each selected local Pod, and records the results in the statistics of the runner,
+// which are reported in AntreaAgentInfo, and in the metrics.
+func (c *Controller) probeRound(ctx context.Context, r *probeRunner) {
+	pods, err := c.getSourcePods(&r.spec.Source)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the source Pods of ConnectivityProbe", "name", r.name)
+		return
+	}
+	if len(pods) == 0 {
+		// The local Node no longer sends probes, stop reporting its results.
+		c.mutex.Lock()
+		r.stats = probeStats{}
+		c.mutex.Unlock()
+		return
+	}
+
+	results := make([]probeResult, len(pods))
+	dstIPs, err := c.resolveDestination(ctx, &r.spec.Destination, r.podLister)
+	if err != nil {
+		for i := range results {
+			results[i].err = err
+		}
+	} else {
+		var wg sync.WaitGroup
+		for i, pod := range pods {
+			seq := r.icmpSeq
+			r.icmpSeq++
+			wg.Go(func() {
+				results[i] = c.probe(ctx, &r.spec, pod, dstIPs, seq)
+			})
+		}
+		wg.Wait()
+	}
+	if ctx.Err() != nil {
+		// The runner has been stopped, the results are not relevant.
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	r.stats.lastProbeTime = time.Now()
+	for i, result := range results {
+		r.stats.sent++
+		metrics.ConnectivityProbeSentCount.WithLabelValues(r.name).Inc()
+		if result.err != nil {
+			r.stats.lastFailureMessage = fmt.Sprintf("Pod %s: %v", pods[i], result.err)
+			klog.V(2).InfoS("ConnectivityProbe failed", "name", r.name, "pod", pods[i], "err", result.err)
+			continue
+		}
+		r.stats.succeeded++
+		r.stats.totalLatency += result.latency
+		metrics.ConnectivityProbeSucceededCount.WithLabelValues(r.name).Inc()
+		metrics.ConnectivityProbeLatency.WithLabelValues(r.name).Observe(result.latency.Seconds())
+	}
+}
+
+// getSourcePods returns the local Pods selected by the source of a ConnectivityProbe, sorted by Namespace and name,
+// and truncated to MaxPodsPerNode.
+func (c *Controller) getSourcePods(source *crdv1alpha1.ConnectivityProbeSource) ([]*sourcePod, error) {
+	namespaceSelector := labels.Everything()
+	if source.NamespaceSelector != nil {
+		var err error
+		if namespaceSelector, err = metav1.LabelSelectorAsSelector(source.NamespaceSelector); err != nil {
+			return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
+		}
+	}
+	podSelector := labels.Everything()
+	if source.PodSelector != nil {
+		var err error
+		if podSelector, err = metav1.LabelSelectorAsSelector(source.PodSelector); err != nil {
+			return nil, fmt.Errorf("invalid podSelector: %w", err)
+		}
+	}
+	namespaces, err := c.namespaceLister.List(namespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	var pods []*sourcePod
+	for _, namespace := range namespaces {
+		nsPods, err := c.podLister.Pods(namespace.Name).List(podSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range nsPods {
+			if pod.Spec.HostNetwork || pod.Status.Phase != v1.PodRunning {
+				continue
+			}
+			interfaces := c.interfaceStore.GetContainerInterfacesByPod(pod.Name, pod.Namespace)
+			if len(interfaces) == 0 {
+				continue
+			}
+			pods = append(pods, &sourcePod{namespace: pod.Namespace, name: pod.Name, intf: interfaces[0]})
+		}
+	}
+	slices.SortFunc(pods, func(a, b *sourcePod) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	if maxPods := int(*source.MaxPodsPerNode); maxPods > 0 && len(pods) > maxPods {
+		pods = pods[:maxPods]
+	}
+	return pods, nil
+}
+
+// resolveDestination returns the IPs to which the probes are sent. Each source Pod uses the first IP of the same
+// family as one of its own IPs. podLister gets the destination Pod, if the destination is a Pod.
+func (c *Controller) resolveDestination(ctx context.Context, destination *crdv1alpha1.ConnectivityProbeDestination, podLister corelisters.PodLister) ([]net.IP, error) {
+	switch {
+	case destination.Pod != nil:
+		pod, err := podLister.Pods(destination.Pod.Namespace).Get(destination.Pod.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the destination Pod: %w", err)
+		}
+		var ips []net.IP
+		for _, podIP := range pod.Status.PodIPs {
+			if ip := net.ParseIP(podIP.IP); ip != nil {
+				ips = append(ips, ip)
+			}
+		}
+		if len(ips) == 0 {
+			return nil, errors.New("destination Pod does not have an IP address")
+		}
+		return ips, nil
+	case destination.Service != nil:
+		service, err := c.serviceLister.Services(destination.Service.Namespace).Get(destination.Service.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the destination Service: %w", err)
+		}
+		var ips []net.IP
+		for _, clusterIP := range service.Spec.ClusterIPs {
+			if ip := net.ParseIP(clusterIP); ip != nil {
+				ips = append(ips, ip)
+			}
+		}
+		if len(ips) == 0 {
+			return nil, errors.New("destination Service does not have a ClusterIP")
+		}
+		return ips, nil
+	case destination.FQDN != "":
+		ips, err := c.lookupIP(ctx, "ip", destination.FQDN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the destination FQDN: %w", err)
+		}
+		return ips, nil
+	}
+	return nil, errors.New("destination is not specified")
+}
+
+// selectIPs returns the first destination IP of the same family as one of the IPs of the source Pod, and the source
+// Pod IP of this family.
+func selectIPs(intf *interfacestore.InterfaceConfig, dstIPs []net.IP) (net.IP, net.IP) {
+	for _, dstIP := range dstIPs {
+		if dstIPv4 := dstIP.To4(); dstIPv4 != nil {
+			if srcIP := intf.GetIPv4Addr(); srcIP != nil {
+				return srcIP, dstIPv4
+			}
+		} else if srcIP := intf.GetIPv6Addr(); srcIP != nil {
+			return srcIP, dstIP
+		}
+	}
+	return nil, nil
+}
+
+// probe sends a probe from the source Pod, by injecting a packet into the OVS pipeline as if it were sent by the Pod,
+// and waits for the reply on the interface of the Pod.
+func (c *Controller) probe(ctx context.Context, spec *crdv1alpha1.ConnectivityProbeSpec, pod *sourcePod, dstIPs []net.IP, icmpSeq uint16) probeResult {
+	srcIP, dstIP := selectIPs(pod.intf, dstIPs)
+	if srcIP == nil {
+		return probeResult{err: errors.New("the Pod does not have an IP address of the family of the destination")}
+	}
+	timeout := time.Duration(spec.TimeoutSeconds) * time.Second
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ipFamily := v1.IPv4Protocol
+	if dstIP.To4() == nil {
+		ipFamily = v1.IPv6Protocol
+	}
+	// Capture all the packets from the destination to the source Pod, including ICMP errors.
+	packets, err := c.captureInterface.Capture(ctx, pod.intf.InterfaceName, snapLen, srcIP, dstIP, &crdv1alpha1.Packet{IPFamily: ipFamily}, nil, crdv1alpha1.CaptureDirectionDestinationToSource)
+	if err != nil {
+		return probeResult{err: fmt.Errorf("failed to capture the replies: %w", err)}
+	}
+
+	packet := newProbePacket(spec, pod.intf, srcIP, dstIP, icmpSeq)
+	// The packet is sent to the destination Pod directly if it is a local Pod, otherwise to the gateway.
+	if dstIntf, ok := c.interfaceStore.GetInterfaceByIP(dstIP.String()); ok && dstIntf.Type == interfacestore.ContainerInterface {
+		packet.DestinationMAC = dstIntf.MAC
+	}
+	start := time.Now()
+	if err := c.ofClient.SendTraceflowPacket(0, packet, uint32(pod.intf.OFPort), -1); err != nil {
+		return probeResult{err: fmt.Errorf("failed to send the probe: %w", err)}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return probeResult{err: fmt.Errorf("no reply received within %v", timeout)}
+		case p, ok := <-packets:
+			if !ok {
+				return probeResult{err: fmt.Errorf("no reply received within %v", timeout)}
+			}
+			matched, err := matchReply(packet, p)
+			if !matched {
+				continue
+			}
+			if err != nil {
+				return probeResult{err: err}
+			}
+			latency := time.Since(start)
+			if ts := p.Metadata().Timestamp; ts.After(start) {
+				latency = ts.Sub(start)
+			}
+			return probeResult{latency: latency}
+		}
+	}
+}
+
+// newProbePacket returns the packet of a probe. The source port of TCP and UDP probes, and the identifier of ICMP
+// probes, are picked randomly so that the replies of concurrent probes can be told apart.
+func newProbePacket(spec *crdv1alpha1.ConnectivityProbeSpec, intf *interfacestore.InterfaceConfig, srcIP, dstIP net.IP, icmpSeq uint16) *binding.Packet {
+	packet := &binding.Packet{
+		IsIPv6:        dstIP.To4() == nil,
+		SourceMAC:     intf.MAC,
+		SourceIP:      srcIP,
+		DestinationIP: dstIP,
+		TTL:           defaultTTL,
+	}
+	// #nosec G404: random number generator not used for security purposes.
+	srcPort := uint16(minSourcePort + rand.IntN(maxSourcePort-minSourcePort+1))
+	switch spec.Protocol {
+	case crdv1alpha1.ConnectivityProbeProtocolTCP:
+		packet.IPProto = protocol.Type_TCP
+		packet.SourcePort = srcPort
+		packet.DestinationPort = uint16(spec.Port)
+		packet.TCPFlags = tcpSyn
+	case crdv1alpha1.ConnectivityProbeProtocolUDP:
+		packet.IPProto = protocol.Type_UDP
+		packet.SourcePort = srcPort
+		packet.DestinationPort = uint16(spec.Port)
+	default:
+		if packet.IsIPv6 {
+			packet.IPProto = protocol.Type_IPv6ICMP
+			packet.ICMPType = icmpv6EchoRequestType
+		} else {
+			packet.IPProto = protocol.Type_ICMP
+			packet.ICMPType = icmpEchoRequestType
+		}
+		// #nosec G404: random number generator not used for security purposes.
+		packet.ICMPEchoID = uint16(rand.Uint32())
+		packet.ICMPEchoSeq = icmpSeq
+	}
+	return packet
+}
+
+// matchReply returns whether a packet received from the destination is a reply to the probe, and if so, an error if
+// the reply indicates that the probe failed. ICMP destination unreachable errors fail any probe.
+func matchReply(probe *binding.Packet, reply gopacket.Packet) (bool, error) {
+	if layer := reply.Layer(layers.LayerTypeICMPv4); layer != nil {
+		icmp := layer.(*layers.ICMPv4)
+		switch icmp.TypeCode.Type() {
+		case layers.ICMPv4TypeEchoReply:
+			return probe.IPProto == protocol.Type_ICMP && icmp.Id == probe.ICMPEchoID && icmp.Seq == probe.ICMPEchoSeq, nil
+		case layers.ICMPv4TypeDestinationUnreachable:
+			return true, fmt.Errorf("destination unreachable (ICMP code %d)", icmp.TypeCode.Code())
+		}
+		return false, nil
+	}
+	if layer := reply.Layer(layers.LayerTypeICMPv6); layer != nil {
+		icmp := layer.(*layers.ICMPv6)
+		switch icmp.TypeCode.Type() {
+		case layers.ICMPv6TypeEchoReply:
+			echoLayer := reply.Layer(layers.LayerTypeICMPv6Echo)
+			if probe.IPProto != protocol.Type_IPv6ICMP || echoLayer == nil {
+				return false, nil
+			}
+			echo := echoLayer.(*layers.ICMPv6Echo)
+			return echo.Identifier == probe.ICMPEchoID && echo.SeqNumber == probe.ICMPEchoSeq, nil
+		case layers.ICMPv6TypeDestinationUnreachable:
+			return true, fmt.Errorf("destination unreachable (ICMPv6 code %d)", icmp.TypeCode.Code())
+		}
+		return false, nil
+	}
+	if layer := reply.Layer(layers.LayerTypeTCP); layer != nil {
+		tcp := layer.(*layers.TCP)
+		if probe.IPProto != protocol.Type_TCP || uint16(tcp.SrcPort) != probe.DestinationPort || uint16(tcp.DstPort) != probe.SourcePort {
+			return false, nil
+		}
+		if tcp.RST {
+			return true, errors.New("connection refused")
+		}
+		return tcp.SYN && tcp.ACK, nil
+	}
+	if layer := reply.Layer(layers.LayerTypeUDP); layer != nil {
+		udp := layer.(*layers.UDP)
+		return probe.IPProto == protocol.Type_UDP && uint16(udp.SrcPort) == probe.DestinationPort && uint16(udp.DstPort) == probe.SourcePort, nil
+	}
+	return false, nil
+}
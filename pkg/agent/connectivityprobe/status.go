@@ -0,0 +1,57 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectivityprobe
+
+import (
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crdv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+)
+
+func newProbeInfo(name string, stats *probeStats) crdv1beta1.ConnectivityProbeInfo {
+	info := crdv1beta1.ConnectivityProbeInfo{
+		Name:               name,
+		ProbesSent:         stats.sent,
+		ProbesSucceeded:    stats.succeeded,
+		LastProbeTime:      metav1.NewTime(stats.lastProbeTime),
+		LastFailureMessage: stats.lastFailureMessage,
+	}
+	if stats.succeeded > 0 {
+		info.AverageLatencyNanoseconds = stats.totalLatency.Nanoseconds() / stats.succeeded
+	}
+	return info
+}
+
+// GetConnectivityProbeInfos returns the results of the ConnectivityProbes sent from the local Node, sorted by name.
+// They are reported in the AntreaAgentInfo of the Node, and aggregated into the ConnectivityProbe status by
+// antrea-controller. The ConnectivityProbes which have not sent any probe from the local Node are not included.
+func (c *Controller) GetConnectivityProbeInfos() []crdv1beta1.ConnectivityProbeInfo {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var infos []crdv1beta1.ConnectivityProbeInfo
+	for name, r := range c.runners {
+		if r.stats.sent == 0 {
+			continue
+		}
+		infos = append(infos, newProbeInfo(name, &r.stats))
+	}
+	slices.SortFunc(infos, func(a, b crdv1beta1.ConnectivityProbeInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return infos
+}
@@ -21,8 +21,13 @@ import (
 	"fmt"
 	"hash/fnv"
 	"net"
+	"net/netip"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -43,11 +48,13 @@ import (
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 	"k8s.io/utils/ptr"
-	"k8s.io/utils/strings/slices"
+	utilslices "k8s.io/utils/strings/slices"
 
 	"antrea.io/antrea/v2/pkg/agent/bgp"
 	"antrea.io/antrea/v2/pkg/agent/bgp/gobgp"
 	"antrea.io/antrea/v2/pkg/agent/config"
+	"antrea.io/antrea/v2/pkg/agent/route"
+	"antrea.io/antrea/v2/pkg/agent/servicecidr"
 	"antrea.io/antrea/v2/pkg/agent/types"
 	"antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
 	"antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
@@ -56,6 +63,8 @@ import (
 	crdlistersv1a1 "antrea.io/antrea/v2/pkg/client/listers/crd/v1alpha1"
 	crdlistersv1b1 "antrea.io/antrea/v2/pkg/client/listers/crd/v1beta1"
 	"antrea.io/antrea/v2/pkg/util/env"
+	"antrea.io/antrea/v2/pkg/util/k8s"
+	"antrea.io/antrea/v2/pkg/util/runtime"
 )
 
 const (
@@ -72,6 +81,13 @@ const (
 	ipv6Suffix = "/128"
 )
 
+// The default BFD configuration of BGP peers, which is applied when the fields of BGPPeerBFD are not set.
+const (
+	defaultBFDMinTxIntervalMilliseconds = 300
+	defaultBFDMinRxIntervalMilliseconds = 300
+	defaultBFDMultiplier                = 3
+)
+
 const dummyKey = "dummyKey"
 
 var (
@@ -86,6 +102,7 @@ const (
 	ServiceExternalIP     AdvertisedRouteType = "ServiceExternalIP"
 	ServiceClusterIP      AdvertisedRouteType = "ServiceClusterIP"
 	NodeIPAMPodCIDR       AdvertisedRouteType = "NodeIPAMPodCIDR"
+	PodIP                 AdvertisedRouteType = "PodIP"
 )
 
 type RouteMetadata struct {
@@ -116,6 +133,9 @@ type bgpPolicyState struct {
 	// peerConfigs is a map that stores configurations of BGP peers. The map keys are the concatenated strings of BGP
 	// peer IP address and ASN (e.g., "192.168.77.100-65000", "2001::1-65000").
 	peerConfigs map[string]bgp.PeerConfig
+	// cancelReceivedRoutesWatch stops watching the routes received by the local BGP server. It is nil if the routes
+	// received from BGP peers are not installed.
+	cancelReceivedRoutesWatch context.CancelFunc
 }
 
 type BGPPolicyInfo struct {
@@ -148,10 +168,30 @@ type Controller struct {
 	endpointSliceLister       discoverylisters.EndpointSliceLister
 	endpointSliceListerSynced cache.InformerSynced
 
+	// podInformer only watches the Pods running on the current Node.
+	podInformer     cache.SharedIndexInformer
+	podLister       corelisters.PodLister
+	podListerSynced cache.InformerSynced
+
+	namespaceInformer     cache.SharedIndexInformer
+	namespaceLister       corelisters.NamespaceLister
+	namespaceListerSynced cache.InformerSynced
+
 	secretInformer cache.SharedIndexInformer
 
 	bgpPolicyState      *bgpPolicyState
 	bgpPolicyStateMutex sync.RWMutex
+	// installedReceivedRoutes stores the routes received from BGP peers and installed into the host routing table,
+	// keyed by prefix, with next hops as values. It is kept across BGP server restarts and protected by
+	// bgpPolicyStateMutex.
+	installedReceivedRoutes map[string]string
+	routeClient             route.Interface
+	serviceCIDRProvider     servicecidr.Interface
+	// peerMetricLabels stores the label values of the BGP peer metrics which have been set. It is only accessed by
+	// syncBGPPolicyStatus.
+	peerMetricLabels sets.Set[peerMetricKey]
+	// bgpPolicyStatus stores the status of the effective BGPPolicy collected by syncBGPPolicyStatus.
+	bgpPolicyStatus atomic.Pointer[v1beta1.BGPPolicyInfo]
 
 	k8sClient             kubernetes.Interface
 	bgpPeerPasswords      map[string]string
@@ -163,6 +203,9 @@ type Controller struct {
 	podIPv4CIDR  string
 	podIPv6CIDR  string
 	nodeIPv4Addr string
+	// transportSubnets stores the subnets of the Node transport interface.
+	transportSubnets []netip.Prefix
+	noEncapMode      bool
 
 	egressEnabled bool
 
@@ -176,8 +219,12 @@ func NewBGPPolicyController(nodeInformer coreinformers.NodeInformer,
 	egressInformer crdinformersv1b1.EgressInformer,
 	bgpPolicyInformer crdinformersv1a1.BGPPolicyInformer,
 	endpointSliceInformer discoveryinformers.EndpointSliceInformer,
+	podInformer cache.SharedIndexInformer,
+	namespaceInformer coreinformers.NamespaceInformer,
 	egressEnabled bool,
 	k8sClient kubernetes.Interface,
+	routeClient route.Interface,
+	serviceCIDRProvider servicecidr.Interface,
 	nodeConfig *config.NodeConfig,
 	networkConfig *config.NetworkConfig) (*Controller, error) {
 	c := &Controller{
@@ -193,7 +240,16 @@ func NewBGPPolicyController(nodeInformer coreinformers.NodeInformer,
 		endpointSliceInformer:     endpointSliceInformer.Informer(),
 		endpointSliceLister:       endpointSliceInformer.Lister(),
 		endpointSliceListerSynced: endpointSliceInformer.Informer().HasSynced,
+		podInformer:               podInformer,
+		podLister:                 corelisters.NewPodLister(podInformer.GetIndexer()),
+		podListerSynced:           podInformer.HasSynced,
+		namespaceInformer:         namespaceInformer.Informer(),
+		namespaceLister:           namespaceInformer.Lister(),
+		namespaceListerSynced:     namespaceInformer.Informer().HasSynced,
 		k8sClient:                 k8sClient,
+		routeClient:               routeClient,
+		serviceCIDRProvider:       serviceCIDRProvider,
+		installedReceivedRoutes:   make(map[string]string),
 		bgpPeerPasswords:          make(map[string]string),
 		nodeName:                  nodeConfig.Name,
 		enabledIPv4:               networkConfig.IPv4Enabled,
@@ -201,6 +257,7 @@ func NewBGPPolicyController(nodeInformer coreinformers.NodeInformer,
 		podIPv4CIDR:               nodeConfig.PodIPv4CIDR.String(),
 		podIPv6CIDR:               nodeConfig.PodIPv6CIDR.String(),
 		nodeIPv4Addr:              nodeConfig.NodeIPv4Addr.IP.String(),
+		noEncapMode:               networkConfig.TrafficEncapMode == config.TrafficEncapModeNoEncap,
 		egressEnabled:             egressEnabled,
 		newBGPServerFn: func(globalConfig *bgp.GlobalConfig) bgp.Interface {
 			return gobgp.NewGoBGPServer(globalConfig)
@@ -236,6 +293,22 @@ func NewBGPPolicyController(nodeInformer coreinformers.NodeInformer,
 		},
 		resyncPeriod,
 	)
+	c.podInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addPod,
+			UpdateFunc: c.updatePod,
+			DeleteFunc: c.deletePod,
+		},
+		resyncPeriod,
+	)
+	c.namespaceInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    nil,
+			UpdateFunc: c.updateNamespace,
+			DeleteFunc: nil,
+		},
+		resyncPeriod,
+	)
 	if c.egressEnabled {
 		c.egressInformer = egressInformer.Informer()
 		c.egressLister = egressInformer.Lister()
@@ -253,10 +326,23 @@ func NewBGPPolicyController(nodeInformer coreinformers.NodeInformer,
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.addNode,
 			UpdateFunc: c.updateNode,
-			DeleteFunc: nil,
+			DeleteFunc: c.deleteNode,
 		},
 		resyncPeriod,
 	)
+	for _, transportAddr := range []*net.IPNet{nodeConfig.NodeTransportIPv4Addr, nodeConfig.NodeTransportIPv6Addr} {
+		if transportAddr == nil {
+			continue
+		}
+		if subnet, err := netip.ParsePrefix(transportAddr.String()); err == nil {
+			c.transportSubnets = append(c.transportSubnets, subnet.Masked())
+		}
+	}
+	// The received routes overlapping with the Service CIDRs are ignored, so they must be resynced when the Service
+	// CIDRs change.
+	serviceCIDRProvider.AddEventHandler(func(_ []*net.IPNet) {
+		c.queue.Add(dummyKey)
+	})
 
 	c.secretInformer = coreinformers.NewFilteredSecretInformer(k8sClient,
 		env.GetAntreaNamespace(),
@@ -287,6 +373,8 @@ func (c *Controller) Run(ctx context.Context) {
 		c.serviceListerSynced,
 		c.bgpPolicyListerSynced,
 		c.endpointSliceListerSynced,
+		c.podListerSynced,
+		c.namespaceListerSynced,
 		c.secretInformer.HasSynced,
 	}
 	if c.egressEnabled {
@@ -296,8 +384,19 @@ func (c *Controller) Run(ctx context.Context) {
 		return
 	}
 
+	// The routes received from BGP peers and installed before the agent restarted are removed by the first sync,
+	// unless they are still received.
+	// Installing received routes is only supported on Linux Nodes.
+	if !runtime.IsWindowsPlatform() {
+		if err := c.restoreReceivedRoutes(); err != nil {
+			klog.ErrorS(err, "Failed to restore the routes received from BGP peers")
+		}
+	}
+
 	go wait.Until(c.worker, time.Second, ctx.Done())
 
+	go wait.UntilWithContext(ctx, c.syncBGPPolicyStatus, statusSyncInterval)
+
 	<-ctx.Done()
 }
 
@@ -372,15 +471,21 @@ func (c *Controller) syncBGPPolicy(ctx context.Context) error {
 
 	// When the effective BGPPolicy is nil, it means that there is no available BGPPolicy.
 	if effectivePolicy == nil {
-		// If the BGPPolicy state is nil, just return.
+		// If the BGPPolicy state is nil, just uninstall the received routes restored after the agent restarted, if
+		// any, and return.
 		if c.bgpPolicyState == nil {
-			return nil
+			return c.uninstallReceivedRoutes()
 		}
 
-		// If the BGPPolicy state is not nil, stop the BGP server and reset the state to nil, then return.
+		// If the BGPPolicy state is not nil, stop the BGP server, uninstall the routes received from BGP peers, and
+		// reset the state to nil, then return.
+		c.stopWatchingReceivedRoutes()
 		if err := c.bgpPolicyState.bgpServer.Stop(ctx); err != nil {
 			return err
 		}
+		if err := c.uninstallReceivedRoutes(); err != nil {
+			return err
+		}
 		c.bgpPolicyState = nil
 		return nil
 	}
@@ -410,7 +515,9 @@ func (c *Controller) syncBGPPolicy(ctx context.Context) error {
 
 	if needUpdateBGPServer {
 		if c.bgpPolicyState != nil {
-			// Stop the current BGP server.
+			// Stop the current BGP server. The installed routes received from BGP peers are kept and will be
+			// reconciled with the routes received by the new BGP server.
+			c.stopWatchingReceivedRoutes()
 			if err := c.bgpPolicyState.bgpServer.Stop(ctx); err != nil {
 				return fmt.Errorf("failed to stop current BGP server: %w", err)
 			}
@@ -453,21 +560,34 @@ func (c *Controller) syncBGPPolicy(ctx context.Context) error {
 		c.bgpPolicyState.bgpPolicyName = bgpPolicyName
 	}
 
-	// Reconcile BGP peers.
-	if err := c.reconcileBGPPeers(ctx, effectivePolicy.Spec.BGPPeers); err != nil {
+	// Get the routes to be advertised, which are required to resolve the route policies of BGP peers.
+	curRoutes := c.getRoutes(effectivePolicy.Spec.Advertisements)
+
+	// Reconcile BGP peers. The route policies of BGP peers are reconciled along with the peers before the
+	// advertisements, so that new routes are never advertised to a BGP peer whose export policy rejects them.
+	if err := c.reconcileBGPPeers(ctx, effectivePolicy.Spec.BGPPeers, curRoutes); err != nil {
 		return err
 	}
 
 	// Reconcile BGP advertisements.
-	if err := c.reconcileBGPAdvertisements(ctx, effectivePolicy.Spec.Advertisements); err != nil {
+	if err := c.reconcileBGPAdvertisements(ctx, curRoutes); err != nil {
+		return err
+	}
+
+	// Reconcile the routes received from BGP peers. Installing them is only supported on Linux Nodes.
+	receivedRoutes := effectivePolicy.Spec.ReceivedRoutes
+	if runtime.IsWindowsPlatform() {
+		receivedRoutes = nil
+	}
+	if err := c.reconcileReceivedRoutes(ctx, receivedRoutes); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c *Controller) reconcileBGPPeers(ctx context.Context, bgpPeers []v1alpha1.BGPPeer) error {
-	curPeerConfigs := c.getPeerConfigs(bgpPeers)
+func (c *Controller) reconcileBGPPeers(ctx context.Context, bgpPeers []v1alpha1.BGPPeer, curRoutes map[bgp.Route]RouteMetadata) error {
+	curPeerConfigs := c.getPeerConfigs(bgpPeers, curRoutes)
 	prePeerConfigs := c.bgpPolicyState.peerConfigs
 	prePeerKeys := sets.KeySet(prePeerConfigs)
 	curPeerKeys := sets.KeySet(curPeerConfigs)
@@ -509,8 +629,7 @@ func (c *Controller) reconcileBGPPeers(ctx context.Context, bgpPeers []v1alpha1.
 	return nil
 }
 
-func (c *Controller) reconcileBGPAdvertisements(ctx context.Context, bgpAdvertisements v1alpha1.Advertisements) error {
-	curRoutes := c.getRoutes(bgpAdvertisements)
+func (c *Controller) reconcileBGPAdvertisements(ctx context.Context, curRoutes map[bgp.Route]RouteMetadata) error {
 	preRoutes := c.bgpPolicyState.routes
 	currRoutesKeys := sets.KeySet(curRoutes)
 	preRoutesKeys := sets.KeySet(preRoutes)
@@ -538,6 +657,219 @@ func (c *Controller) reconcileBGPAdvertisements(ctx context.Context, bgpAdvertis
 	return nil
 }
 
+func (c *Controller) reconcileReceivedRoutes(ctx context.Context, receivedRoutes *v1alpha1.ReceivedRoutes) error {
+	if receivedRoutes == nil {
+		c.stopWatchingReceivedRoutes()
+		return c.uninstallReceivedRoutes()
+	}
+
+	bgpServer := c.bgpPolicyState.bgpServer
+	// Watch the routes received by the BGP server, to trigger a sync when the routes are updated or withdrawn. The
+	// watch is not bound to ctx, which is canceled when the sync is done.
+	if c.bgpPolicyState.cancelReceivedRoutesWatch == nil {
+		watchCtx, cancel := context.WithCancel(context.Background())
+		if err := bgpServer.WatchReceivedRoutes(watchCtx, func() { c.queue.Add(dummyKey) }); err != nil {
+			cancel()
+			return fmt.Errorf("failed to watch received routes: %w", err)
+		}
+		c.bgpPolicyState.cancelReceivedRoutesWatch = cancel
+	}
+
+	routes, err := bgpServer.GetReceivedRoutes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get received routes: %w", err)
+	}
+	curRoutes, err := c.getInstallableReceivedRoutes(routes, receivedRoutes.AllowedPrefixes)
+	if err != nil {
+		return err
+	}
+
+	for prefix, nextHop := range curRoutes {
+		if preNextHop, exists := c.installedReceivedRoutes[prefix]; exists && preNextHop == nextHop {
+			continue
+		}
+		_, dst, _ := net.ParseCIDR(prefix)
+		if err := c.routeClient.AddBGPRoute(dst, net.ParseIP(nextHop)); err != nil {
+			return err
+		}
+		klog.V(2).InfoS("Installed route received from BGP peer", "prefix", prefix, "nextHop", nextHop)
+		c.installedReceivedRoutes[prefix] = nextHop
+	}
+	for prefix := range c.installedReceivedRoutes {
+		if _, exists := curRoutes[prefix]; exists {
+			continue
+		}
+		if err := c.deleteReceivedRoute(prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getInstallableReceivedRoutes returns the received routes which can be installed into the host routing table, keyed
+// by prefix, with next hops as values. A route is installable if its prefix is within the allowed prefixes, its IP
+// family is enabled, and it doesn't overlap with the CIDRs whose routes are managed by Antrea or the host network (see
+// getReservedCIDRs and getRemotePodCIDRs).
+func (c *Controller) getInstallableReceivedRoutes(routes []bgp.ReceivedRoute, allowedPrefixes []string) (map[string]string, error) {
+	var allowedCIDRs []netip.Prefix
+	for _, prefix := range allowedPrefixes {
+		if cidr, err := netip.ParsePrefix(prefix); err == nil {
+			allowedCIDRs = append(allowedCIDRs, cidr.Masked())
+		}
+	}
+	reservedCIDRs, err := c.getReservedCIDRs()
+	if err != nil {
+		return nil, err
+	}
+	remotePodCIDRs := c.getRemotePodCIDRs()
+
+	installableRoutes := make(map[string]string)
+	for _, route := range routes {
+		prefix, err := netip.ParsePrefix(route.Prefix)
+		if err != nil {
+			continue
+		}
+		nextHop, err := netip.ParseAddr(route.NextHop)
+		if err != nil || nextHop.Is4() != prefix.Addr().Is4() || nextHop.IsUnspecified() {
+			continue
+		}
+		if prefix.Addr().Is4() && !c.enabledIPv4 || prefix.Addr().Is6() && !c.enabledIPv6 {
+			continue
+		}
+		if !prefixWithinCIDRs(route.Prefix, allowedCIDRs) {
+			continue
+		}
+		prefix = prefix.Masked()
+		// Never override the routes of the local Pod CIDRs, the Service CIDRs and the transport subnets.
+		if slices.ContainsFunc(reservedCIDRs, prefix.Overlaps) {
+			klog.V(2).InfoS("Ignored received route overlapping with reserved CIDR", "prefix", route.Prefix, "peer", route.PeerAddress)
+			continue
+		}
+		if overlapsRemotePodCIDRs(prefix, remotePodCIDRs) {
+			klog.V(2).InfoS("Ignored received route overlapping with Pod CIDR of other Node", "prefix", route.Prefix, "peer", route.PeerAddress)
+			continue
+		}
+		installableRoutes[prefix.String()] = nextHop.String()
+	}
+	return installableRoutes, nil
+}
+
+// getReservedCIDRs returns the CIDRs which received routes must never overlap with: the Pod CIDRs of the local Node,
+// the Service CIDRs, and the subnets of the Node transport interface.
+func (c *Controller) getReservedCIDRs() ([]netip.Prefix, error) {
+	var reservedCIDRs []netip.Prefix
+	for _, podCIDR := range []string{c.podIPv4CIDR, c.podIPv6CIDR} {
+		if cidr, err := netip.ParsePrefix(podCIDR); err == nil {
+			reservedCIDRs = append(reservedCIDRs, cidr)
+		}
+	}
+	serviceCIDRs, err := c.serviceCIDRProvider.GetServiceCIDRs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Service CIDRs: %w", err)
+	}
+	for _, serviceCIDR := range serviceCIDRs {
+		if cidr, err := netip.ParsePrefix(serviceCIDR.String()); err == nil {
+			reservedCIDRs = append(reservedCIDRs, cidr.Masked())
+		}
+	}
+	return append(reservedCIDRs, c.transportSubnets...), nil
+}
+
+// getRemotePodCIDRs returns the Pod CIDRs of the other Nodes, with a bool value indicating whether a received route
+// whose prefix is exactly the Pod CIDR can be installed. This is only the case in noEncap mode for the Nodes outside
+// the transport subnets, as Antrea doesn't install routes for their Pod CIDRs and relies on the underlay network.
+func (c *Controller) getRemotePodCIDRs() map[netip.Prefix]bool {
+	remotePodCIDRs := make(map[netip.Prefix]bool)
+	nodes, _ := c.nodeLister.List(labels.Everything())
+	for _, node := range nodes {
+		if node.Name == c.nodeName {
+			continue
+		}
+		routedByUnderlay := c.noEncapMode && !c.inTransportSubnets(node)
+		for _, podCIDR := range node.Spec.PodCIDRs {
+			if cidr, err := netip.ParsePrefix(podCIDR); err == nil {
+				remotePodCIDRs[cidr.Masked()] = routedByUnderlay
+			}
+		}
+	}
+	return remotePodCIDRs
+}
+
+// inTransportSubnets returns whether any transport address of the Node is within the transport subnets of the local
+// Node.
+func (c *Controller) inTransportSubnets(node *corev1.Node) bool {
+	nodeAddrs, err := k8s.GetNodeTransportAddrs(node)
+	if err != nil {
+		return false
+	}
+	for _, nodeIP := range []net.IP{nodeAddrs.IPv4, nodeAddrs.IPv6} {
+		if nodeIP == nil {
+			continue
+		}
+		addr, ok := netip.AddrFromSlice(nodeIP)
+		if !ok {
+			continue
+		}
+		addr = addr.Unmap()
+		if slices.ContainsFunc(c.transportSubnets, func(subnet netip.Prefix) bool { return subnet.Contains(addr) }) {
+			return true
+		}
+	}
+	return false
+}
+
+func overlapsRemotePodCIDRs(prefix netip.Prefix, remotePodCIDRs map[netip.Prefix]bool) bool {
+	for podCIDR, installable := range remotePodCIDRs {
+		if prefix.Overlaps(podCIDR) && !(installable && prefix == podCIDR) {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreReceivedRoutes restores the routes received from BGP peers and installed before the agent restarted, so that
+// they are reconciled by the next sync. Only the routes installed by Antrea are restored, the routes installed by other
+// BGP daemons are never taken over.
+func (c *Controller) restoreReceivedRoutes() error {
+	dstCIDRs, err := c.routeClient.RestoreBGPRoutes()
+	if err != nil {
+		return err
+	}
+	c.bgpPolicyStateMutex.Lock()
+	defer c.bgpPolicyStateMutex.Unlock()
+	for _, dstCIDR := range dstCIDRs {
+		// The next hop is unknown, so the route is always replaced if it is still received.
+		c.installedReceivedRoutes[dstCIDR.String()] = ""
+	}
+	return nil
+}
+
+func (c *Controller) deleteReceivedRoute(prefix string) error {
+	_, dst, _ := net.ParseCIDR(prefix)
+	if err := c.routeClient.DeleteBGPRoute(dst); err != nil {
+		return err
+	}
+	klog.V(2).InfoS("Uninstalled route received from BGP peer", "prefix", prefix)
+	delete(c.installedReceivedRoutes, prefix)
+	return nil
+}
+
+func (c *Controller) uninstallReceivedRoutes() error {
+	for prefix := range c.installedReceivedRoutes {
+		if err := c.deleteReceivedRoute(prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Controller) stopWatchingReceivedRoutes() {
+	if c.bgpPolicyState.cancelReceivedRoutesWatch != nil {
+		c.bgpPolicyState.cancelReceivedRoutesWatch()
+		c.bgpPolicyState.cancelReceivedRoutesWatch = nil
+	}
+}
+
 func hashNodeNameToIP(s string) string {
 	h := fnv.New32a() // Create a new FNV hash
 	h.Write([]byte(s))
@@ -608,10 +940,10 @@ func (c *Controller) getRoutes(advertisements v1alpha1.Advertisements) map[bgp.R
 		c.addServiceRoutes(advertisements.Service, allRoutes)
 	}
 	if c.egressEnabled && advertisements.Egress != nil {
-		c.addEgressRoutes(allRoutes)
+		c.addEgressRoutes(advertisements.Egress, allRoutes)
 	}
 	if advertisements.Pod != nil {
-		c.addPodRoutes(allRoutes)
+		c.addPodRoutes(advertisements.Pod, allRoutes)
 	}
 
 	return allRoutes
@@ -622,6 +954,9 @@ func (c *Controller) addServiceRoutes(advertisement *v1alpha1.ServiceAdvertiseme
 	services, _ := c.serviceLister.List(labels.Everything())
 
 	for _, svc := range services {
+		if !c.matchesServiceSelectors(svc, advertisement.Selectors) {
+			continue
+		}
 		svcRef := svc.Namespace + "/" + svc.Name
 		internalLocal := svc.Spec.InternalTrafficPolicy != nil && *svc.Spec.InternalTrafficPolicy == corev1.ServiceInternalTrafficPolicyLocal
 		externalLocal := svc.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyLocal
@@ -666,10 +1001,10 @@ func (c *Controller) addServiceRoutes(advertisement *v1alpha1.ServiceAdvertiseme
 	}
 }
 
-func (c *Controller) addEgressRoutes(allRoutes map[bgp.Route]RouteMetadata) {
+func (c *Controller) addEgressRoutes(advertisement *v1alpha1.EgressAdvertisement, allRoutes map[bgp.Route]RouteMetadata) {
 	egresses, _ := c.egressLister.List(labels.Everything())
 	for _, eg := range egresses {
-		if eg.Status.EgressNode != c.nodeName {
+		if eg.Status.EgressNode != c.nodeName || !matchesEgressSelectors(eg, advertisement.Selectors) {
 			continue
 		}
 		ip := eg.Status.EgressIP
@@ -681,13 +1016,100 @@ func (c *Controller) addEgressRoutes(allRoutes map[bgp.Route]RouteMetadata) {
 	}
 }
 
-func (c *Controller) addPodRoutes(allRoutes map[bgp.Route]RouteMetadata) {
-	if c.enabledIPv4 {
-		addRoutes(allRoutes, c.podIPv4CIDR, "", NodeIPAMPodCIDR)
+func (c *Controller) addPodRoutes(advertisement *v1alpha1.PodAdvertisement, allRoutes map[bgp.Route]RouteMetadata) {
+	// Without selectors, the NodeIPAM Pod CIDR of the Node is advertised.
+	if len(advertisement.Selectors) == 0 {
+		if c.enabledIPv4 {
+			addRoutes(allRoutes, c.podIPv4CIDR, "", NodeIPAMPodCIDR)
+		}
+		if c.enabledIPv6 {
+			addRoutes(allRoutes, c.podIPv6CIDR, "", NodeIPAMPodCIDR)
+		}
+		return
+	}
+
+	pods, _ := c.podLister.List(labels.Everything())
+	for _, pod := range pods {
+		if !isAdvertisablePod(pod, c.nodeName) || !c.matchesPodSelectors(pod, advertisement.Selectors) {
+			continue
+		}
+		podRef := pod.Namespace + "/" + pod.Name
+		for _, podIP := range pod.Status.PodIPs {
+			if c.enabledIPv4 && utilnet.IsIPv4String(podIP.IP) {
+				addRoutes(allRoutes, podIP.IP+ipv4Suffix, podRef, PodIP)
+			} else if c.enabledIPv6 && utilnet.IsIPv6String(podIP.IP) {
+				addRoutes(allRoutes, podIP.IP+ipv6Suffix, podRef, PodIP)
+			}
+		}
+	}
+}
+
+// isAdvertisablePod returns whether the IPs of a Pod can be advertised by the given Node. The IPs of hostNetwork Pods
+// are the Node's IPs, and the IPs of terminated Pods may have been reused by other Pods.
+func isAdvertisablePod(pod *corev1.Pod, nodeName string) bool {
+	return pod.Spec.NodeName == nodeName &&
+		!pod.Spec.HostNetwork &&
+		pod.Status.Phase != corev1.PodSucceeded &&
+		pod.Status.Phase != corev1.PodFailed
+}
+
+// matchesLabelSelector returns whether the given labels match a label selector. A nil label selector matches all
+// labels.
+func matchesLabelSelector(objLabels map[string]string, labelSelector *metav1.LabelSelector) bool {
+	if labelSelector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(objLabels))
+}
+
+func (c *Controller) matchesNamespaceSelector(namespace string, namespaceSelector *metav1.LabelSelector) bool {
+	if namespaceSelector == nil {
+		return true
+	}
+	ns, _ := c.namespaceLister.Get(namespace)
+	if ns == nil {
+		return false
+	}
+	return matchesLabelSelector(ns.Labels, namespaceSelector)
+}
+
+func (c *Controller) matchesServiceSelectors(svc *corev1.Service, selectors []v1alpha1.ServiceAdvertisementSelector) bool {
+	if len(selectors) == 0 {
+		return true
+	}
+	for i := range selectors {
+		if matchesLabelSelector(svc.Labels, selectors[i].ServiceSelector) &&
+			c.matchesNamespaceSelector(svc.Namespace, selectors[i].NamespaceSelector) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Controller) matchesPodSelectors(pod *corev1.Pod, selectors []v1alpha1.PodAdvertisementSelector) bool {
+	for i := range selectors {
+		if matchesLabelSelector(pod.Labels, selectors[i].PodSelector) &&
+			c.matchesNamespaceSelector(pod.Namespace, selectors[i].NamespaceSelector) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesEgressSelectors(eg *v1beta1.Egress, selectors []v1alpha1.EgressAdvertisementSelector) bool {
+	if len(selectors) == 0 {
+		return true
 	}
-	if c.enabledIPv6 {
-		addRoutes(allRoutes, c.podIPv6CIDR, "", NodeIPAMPodCIDR)
+	for i := range selectors {
+		if matchesLabelSelector(eg.Labels, selectors[i].EgressSelector) {
+			return true
+		}
 	}
+	return false
 }
 
 func addRoutes(allRoutes map[bgp.Route]RouteMetadata, prefix, k8sObjRef string, routeType AdvertisedRouteType) {
@@ -710,7 +1132,7 @@ func (c *Controller) hasLocalEndpoints(svc *corev1.Service) bool {
 	return false
 }
 
-func (c *Controller) getPeerConfigs(peers []v1alpha1.BGPPeer) map[string]bgp.PeerConfig {
+func (c *Controller) getPeerConfigs(peers []v1alpha1.BGPPeer, routes map[bgp.Route]RouteMetadata) map[string]bgp.PeerConfig {
 	c.bgpPeerPasswordsMutex.RLock()
 	defer c.bgpPeerPasswordsMutex.RUnlock()
 
@@ -728,12 +1150,154 @@ func (c *Controller) getPeerConfigs(peers []v1alpha1.BGPPeer) map[string]bgp.Pee
 			peerConfigs[peerKey] = bgp.PeerConfig{
 				BGPPeer:  &peers[i],
 				Password: password,
+				// The routes received from BGP peers are not of any advertised route type.
+				ImportRoutePolicy: getRoutePolicy(peers[i].ImportPolicy, nil),
+				ExportRoutePolicy: getRoutePolicy(peers[i].ExportPolicy, routes),
+				BFDConfig:         getBFDConfig(peers[i].BFD),
 			}
 		}
 	}
 	return peerConfigs
 }
 
+// getBFDConfig converts the BFD configuration of a BGPPeer to the BFD configuration of the BGP server, with the
+// default values applied.
+func getBFDConfig(peerBFD *v1alpha1.BGPPeerBFD) *bgp.BFDConfig {
+	if peerBFD == nil {
+		return nil
+	}
+	minTxIntervalMilliseconds := ptr.Deref(peerBFD.MinTxIntervalMilliseconds, defaultBFDMinTxIntervalMilliseconds)
+	minRxIntervalMilliseconds := ptr.Deref(peerBFD.MinRxIntervalMilliseconds, defaultBFDMinRxIntervalMilliseconds)
+	return &bgp.BFDConfig{
+		MinTxInterval: time.Duration(minTxIntervalMilliseconds) * time.Millisecond,
+		MinRxInterval: time.Duration(minRxIntervalMilliseconds) * time.Millisecond,
+		Multiplier:    uint8(ptr.Deref(peerBFD.Multiplier, defaultBFDMultiplier)),
+	}
+}
+
+// getRoutePolicy converts a BGPRoutePolicy to a route policy of the BGP server, in which the route types selected by
+// the rules are resolved to the prefixes of the given routes of these types. The rules matching no route are omitted.
+func getRoutePolicy(policy *v1alpha1.BGPRoutePolicy, routes map[bgp.Route]RouteMetadata) *bgp.RoutePolicy {
+	if policy == nil {
+		return nil
+	}
+	routePolicy := &bgp.RoutePolicy{
+		DefaultAction: getRouteAction(policy.DefaultAction),
+	}
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		prefixes, matchesAny := getRouteMatchPrefixes(&rule.Match, routes)
+		if !matchesAny {
+			continue
+		}
+		routePolicy.Rules = append(routePolicy.Rules, bgp.RoutePolicyRule{
+			Prefixes:   prefixes,
+			Action:     getRouteAction(rule.Action),
+			Attributes: getPathAttributes(rule.Attributes),
+		})
+	}
+	return routePolicy
+}
+
+// getRouteMatchPrefixes returns the prefixes matched by a BGPRouteMatch, and whether it may match any route. An empty
+// slice of prefixes along with true means that all routes are matched.
+func getRouteMatchPrefixes(match *v1alpha1.BGPRouteMatch, routes map[bgp.Route]RouteMetadata) ([]bgp.PrefixMatch, bool) {
+	var cidrs []netip.Prefix
+	for _, prefix := range match.Prefixes {
+		// The prefixes have been validated when the BGPPolicy is created.
+		if cidr, err := netip.ParsePrefix(prefix); err == nil {
+			cidrs = append(cidrs, cidr.Masked())
+		}
+	}
+	if len(match.RouteTypes) == 0 {
+		if len(match.Prefixes) == 0 {
+			return nil, true
+		}
+		var prefixes []bgp.PrefixMatch
+		for _, cidr := range cidrs {
+			prefixes = append(prefixes, bgp.PrefixMatch{Prefix: cidr.String(), OrLonger: true})
+		}
+		return prefixes, len(prefixes) > 0
+	}
+
+	routeTypes := sets.New[AdvertisedRouteType]()
+	for _, routeType := range match.RouteTypes {
+		routeTypes.Insert(AdvertisedRouteType(routeType))
+	}
+	var prefixes []bgp.PrefixMatch
+	for route, routeMetadata := range routes {
+		if !routeTypes.Has(routeMetadata.Type) {
+			continue
+		}
+		if len(match.Prefixes) > 0 && !prefixWithinCIDRs(route.Prefix, cidrs) {
+			continue
+		}
+		prefixes = append(prefixes, bgp.PrefixMatch{Prefix: route.Prefix})
+	}
+	// Sort the prefixes to get a stable route policy, which is compared with the applied one to detect changes.
+	slices.SortFunc(prefixes, func(a, b bgp.PrefixMatch) int {
+		return strings.Compare(a.Prefix, b.Prefix)
+	})
+	return prefixes, len(prefixes) > 0
+}
+
+func prefixWithinCIDRs(prefixStr string, cidrs []netip.Prefix) bool {
+	prefix, err := netip.ParsePrefix(prefixStr)
+	if err != nil {
+		return false
+	}
+	for _, cidr := range cidrs {
+		if cidr.Bits() <= prefix.Bits() && cidr.Contains(prefix.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+func getRouteAction(action v1alpha1.BGPRouteAction) bgp.RouteAction {
+	if action == v1alpha1.BGPRouteActionReject {
+		return bgp.RouteActionReject
+	}
+	return bgp.RouteActionAccept
+}
+
+func getPathAttributes(attributes *v1alpha1.BGPPathAttributes) *bgp.PathAttributes {
+	if attributes == nil {
+		return nil
+	}
+	// The communities are validated by the CRD schema, but BGPPolicies created with an earlier version of the schema
+	// may have communities out of range, which would prevent the whole route policy from being applied.
+	pathAttributes := &bgp.PathAttributes{
+		Communities:      filterValidCommunities(attributes.Communities, 16),
+		LargeCommunities: filterValidCommunities(attributes.LargeCommunities, 32),
+	}
+	if attributes.LocalPreference != nil {
+		pathAttributes.LocalPreference = ptr.To(uint32(*attributes.LocalPreference))
+	}
+	if attributes.MED != nil {
+		pathAttributes.MED = ptr.To(uint32(*attributes.MED))
+	}
+	return pathAttributes
+}
+
+// filterValidCommunities returns the communities whose numeric parts are all unsigned integers of the given bit size.
+// Well-known community names are kept.
+func filterValidCommunities(communities []string, bitSize int) []string {
+	var validCommunities []string
+	for _, community := range communities {
+		parts := strings.Split(community, ":")
+		if len(parts) > 1 && slices.ContainsFunc(parts, func(part string) bool {
+			_, err := strconv.ParseUint(part, 10, bitSize)
+			return err != nil
+		}) {
+			klog.ErrorS(nil, "Ignoring BGP community out of range", "community", community)
+			continue
+		}
+		validCommunities = append(validCommunities, community)
+	}
+	return validCommunities
+}
+
 func generateBGPPeerKey(address string, asn int32) string {
 	return fmt.Sprintf("%s-%d", address, asn)
 }
@@ -803,7 +1367,10 @@ func matchesNode(node *corev1.Node, bgpPolicy *v1alpha1.BGPPolicy) bool {
 	return nodeSelector.Matches(labels.Set(node.Labels))
 }
 
-func matchesService(svc *corev1.Service, bgpPolicy *v1alpha1.BGPPolicy) bool {
+func (c *Controller) matchesService(svc *corev1.Service, bgpPolicy *v1alpha1.BGPPolicy) bool {
+	if !c.matchesServiceSelectors(svc, bgpPolicy.Spec.Advertisements.Service.Selectors) {
+		return false
+	}
 	ipTypeMap := sets.New(bgpPolicy.Spec.Advertisements.Service.IPTypes...)
 	if ipTypeMap.Has(v1alpha1.ServiceIPTypeClusterIP) && len(svc.Spec.ClusterIPs) != 0 ||
 		ipTypeMap.Has(v1alpha1.ServiceIPTypeExternalIP) && len(svc.Spec.ExternalIPs) != 0 ||
@@ -819,7 +1386,7 @@ func (c *Controller) hasAffectedPolicyByService(svc *corev1.Service) bool {
 		if policy.Spec.Advertisements.Service == nil || !c.matchesCurrentNode(policy) {
 			continue
 		}
-		if matchesService(svc, policy) {
+		if c.matchesService(svc, policy) {
 			return true
 		}
 	}
@@ -838,11 +1405,12 @@ func (c *Controller) updateService(oldObj, obj interface{}) {
 	oldSvc := oldObj.(*corev1.Service)
 	svc := obj.(*corev1.Service)
 
-	if slices.Equal(oldSvc.Spec.ClusterIPs, svc.Spec.ClusterIPs) &&
-		slices.Equal(oldSvc.Spec.ExternalIPs, svc.Spec.ExternalIPs) &&
-		slices.Equal(getIngressIPs(oldSvc), getIngressIPs(svc)) &&
+	if utilslices.Equal(oldSvc.Spec.ClusterIPs, svc.Spec.ClusterIPs) &&
+		utilslices.Equal(oldSvc.Spec.ExternalIPs, svc.Spec.ExternalIPs) &&
+		utilslices.Equal(getIngressIPs(oldSvc), getIngressIPs(svc)) &&
 		oldSvc.Spec.ExternalTrafficPolicy == svc.Spec.ExternalTrafficPolicy &&
-		ptr.Equal(oldSvc.Spec.InternalTrafficPolicy, svc.Spec.InternalTrafficPolicy) {
+		ptr.Equal(oldSvc.Spec.InternalTrafficPolicy, svc.Spec.InternalTrafficPolicy) &&
+		reflect.DeepEqual(oldSvc.Labels, svc.Labels) {
 		return
 	}
 	if c.hasAffectedPolicyByService(oldSvc) || c.hasAffectedPolicyByService(svc) {
@@ -943,13 +1511,13 @@ func (c *Controller) deleteEndpointSlice(obj interface{}) {
 	}
 }
 
-func (c *Controller) hasAffectedPolicyByEgress() bool {
+func (c *Controller) hasAffectedPolicyByEgress(eg *v1beta1.Egress) bool {
 	allPolicies, _ := c.bgpPolicyLister.List(labels.Everything())
 	for _, policy := range allPolicies {
 		if !c.matchesCurrentNode(policy) {
 			continue
 		}
-		if policy.Spec.Advertisements.Egress != nil {
+		if policy.Spec.Advertisements.Egress != nil && matchesEgressSelectors(eg, policy.Spec.Advertisements.Egress.Selectors) {
 			return true
 		}
 	}
@@ -961,7 +1529,7 @@ func (c *Controller) addEgress(obj interface{}) {
 	if eg.Status.EgressNode != c.nodeName {
 		return
 	}
-	if c.hasAffectedPolicyByEgress() {
+	if c.hasAffectedPolicyByEgress(eg) {
 		klog.V(2).InfoS("Processing Egress ADD event", "Egress", klog.KObj(eg))
 		c.queue.Add(dummyKey)
 	}
@@ -973,10 +1541,11 @@ func (c *Controller) updateEgress(oldObj, obj interface{}) {
 	if oldEg.Status.EgressNode != c.nodeName && eg.Status.EgressNode != c.nodeName {
 		return
 	}
-	if oldEg.Status.EgressIP == eg.Status.EgressIP && oldEg.Status.EgressNode == eg.Status.EgressNode {
+	if oldEg.Status.EgressIP == eg.Status.EgressIP && oldEg.Status.EgressNode == eg.Status.EgressNode &&
+		reflect.DeepEqual(oldEg.Labels, eg.Labels) {
 		return
 	}
-	if c.hasAffectedPolicyByEgress() {
+	if c.hasAffectedPolicyByEgress(oldEg) || c.hasAffectedPolicyByEgress(eg) {
 		klog.V(2).InfoS("Processing Egress UPDATE event", "Egress", klog.KObj(eg))
 		c.queue.Add(dummyKey)
 	}
@@ -999,12 +1568,109 @@ func (c *Controller) deleteEgress(obj interface{}) {
 	if eg.Status.EgressNode != c.nodeName {
 		return
 	}
-	if c.hasAffectedPolicyByEgress() {
+	if c.hasAffectedPolicyByEgress(eg) {
 		klog.V(2).InfoS("Processing Egress DELETE event", "Egress", klog.KObj(eg))
 		c.queue.Add(dummyKey)
 	}
 }
 
+func (c *Controller) hasAffectedPolicyByPod(pod *corev1.Pod) bool {
+	if !isAdvertisablePod(pod, c.nodeName) || len(pod.Status.PodIPs) == 0 {
+		return false
+	}
+	allPolicies, _ := c.bgpPolicyLister.List(labels.Everything())
+	for _, policy := range allPolicies {
+		if !c.matchesCurrentNode(policy) {
+			continue
+		}
+		// The IPs of individual Pods are advertised only when Pod selectors are specified.
+		if policy.Spec.Advertisements.Pod != nil && c.matchesPodSelectors(pod, policy.Spec.Advertisements.Pod.Selectors) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Controller) addPod(obj interface{}) {
+	pod := obj.(*corev1.Pod)
+	if c.hasAffectedPolicyByPod(pod) {
+		klog.V(2).InfoS("Processing Pod ADD event", "Pod", klog.KObj(pod))
+		c.queue.Add(dummyKey)
+	}
+}
+
+func (c *Controller) updatePod(oldObj, obj interface{}) {
+	oldPod := oldObj.(*corev1.Pod)
+	pod := obj.(*corev1.Pod)
+	if reflect.DeepEqual(oldPod.Status.PodIPs, pod.Status.PodIPs) &&
+		oldPod.Status.Phase == pod.Status.Phase &&
+		reflect.DeepEqual(oldPod.Labels, pod.Labels) {
+		return
+	}
+	if c.hasAffectedPolicyByPod(oldPod) || c.hasAffectedPolicyByPod(pod) {
+		klog.V(2).InfoS("Processing Pod UPDATE event", "Pod", klog.KObj(pod))
+		c.queue.Add(dummyKey)
+	}
+}
+
+func (c *Controller) deletePod(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Received unexpected object", "obj", obj)
+			return
+		}
+		pod, ok = deletedState.Obj.(*corev1.Pod)
+		if !ok {
+			klog.ErrorS(nil, "DeletedFinalStateUnknown contains non-Pod object", "key", deletedState.Key, "obj", deletedState.Obj)
+			return
+		}
+	}
+	if c.hasAffectedPolicyByPod(pod) {
+		klog.V(2).InfoS("Processing Pod DELETE event", "Pod", klog.KObj(pod))
+		c.queue.Add(dummyKey)
+	}
+}
+
+// hasAffectedPolicyByNamespace returns whether the effective BGPPolicy may select Services or Pods by the labels of
+// Namespaces.
+func (c *Controller) hasAffectedPolicyByNamespace() bool {
+	allPolicies, _ := c.bgpPolicyLister.List(labels.Everything())
+	for _, policy := range allPolicies {
+		if !c.matchesCurrentNode(policy) {
+			continue
+		}
+		if service := policy.Spec.Advertisements.Service; service != nil {
+			for i := range service.Selectors {
+				if service.Selectors[i].NamespaceSelector != nil {
+					return true
+				}
+			}
+		}
+		if pod := policy.Spec.Advertisements.Pod; pod != nil {
+			for i := range pod.Selectors {
+				if pod.Selectors[i].NamespaceSelector != nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (c *Controller) updateNamespace(oldObj, obj interface{}) {
+	oldNamespace := oldObj.(*corev1.Namespace)
+	namespace := obj.(*corev1.Namespace)
+	if reflect.DeepEqual(oldNamespace.Labels, namespace.Labels) {
+		return
+	}
+	if c.hasAffectedPolicyByNamespace() {
+		klog.V(2).InfoS("Processing Namespace UPDATE event", "Namespace", klog.KObj(namespace))
+		c.queue.Add(dummyKey)
+	}
+}
+
 func (c *Controller) hasAffectedPolicyByNode(node *corev1.Node) bool {
 	allPolicies, _ := c.bgpPolicyLister.List(labels.Everything())
 	for _, policy := range allPolicies {
@@ -1015,9 +1681,23 @@ func (c *Controller) hasAffectedPolicyByNode(node *corev1.Node) bool {
 	return false
 }
 
+// hasReceivedRoutesAffectedByNode returns whether the installation of received routes is enabled by the effective
+// BGPPolicy, in which case the received routes must be resynced when the Pod CIDRs of the other Nodes change.
+func (c *Controller) hasReceivedRoutesAffectedByNode(node *corev1.Node) bool {
+	if len(node.Spec.PodCIDRs) == 0 {
+		return false
+	}
+	effectivePolicy := c.getEffectiveBGPPolicy()
+	return effectivePolicy != nil && effectivePolicy.Spec.ReceivedRoutes != nil
+}
+
 func (c *Controller) addNode(obj interface{}) {
 	node := obj.(*corev1.Node)
 	if node.GetName() != c.nodeName {
+		if c.hasReceivedRoutesAffectedByNode(node) {
+			klog.V(2).InfoS("Processing Node ADD event", "Node", klog.KObj(node))
+			c.queue.Add(dummyKey)
+		}
 		return
 	}
 	if c.hasAffectedPolicyByNode(node) {
@@ -1030,6 +1710,13 @@ func (c *Controller) updateNode(oldObj, obj interface{}) {
 	oldNode := oldObj.(*corev1.Node)
 	node := obj.(*corev1.Node)
 	if node.GetName() != c.nodeName {
+		if (!reflect.DeepEqual(node.Spec.PodCIDRs, oldNode.Spec.PodCIDRs) ||
+			!reflect.DeepEqual(node.Status.Addresses, oldNode.Status.Addresses) ||
+			!reflect.DeepEqual(node.GetAnnotations(), oldNode.GetAnnotations())) &&
+			(c.hasReceivedRoutesAffectedByNode(oldNode) || c.hasReceivedRoutesAffectedByNode(node)) {
+			klog.V(2).InfoS("Processing Node UPDATE event", "Node", klog.KObj(node))
+			c.queue.Add(dummyKey)
+		}
 		return
 	}
 	if reflect.DeepEqual(node.GetLabels(), oldNode.GetLabels()) &&
@@ -1042,6 +1729,26 @@ func (c *Controller) updateNode(oldObj, obj interface{}) {
 	}
 }
 
+func (c *Controller) deleteNode(obj interface{}) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Received unexpected object", "obj", obj)
+			return
+		}
+		node, ok = deletedState.Obj.(*corev1.Node)
+		if !ok {
+			klog.ErrorS(nil, "DeletedFinalStateUnknown contains non-Node object", "key", deletedState.Key, "obj", deletedState.Obj)
+			return
+		}
+	}
+	if node.GetName() != c.nodeName && c.hasReceivedRoutesAffectedByNode(node) {
+		klog.V(2).InfoS("Processing Node DELETE event", "Node", klog.KObj(node))
+		c.queue.Add(dummyKey)
+	}
+}
+
 func (c *Controller) addSecret(obj interface{}) {
 	secret := obj.(*corev1.Secret)
 	klog.V(2).InfoS("Processing Secret ADD event", "Secret", klog.KObj(secret))
@@ -17,6 +17,7 @@ package bgp
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
 	"testing"
 	"testing/synctest"
@@ -42,6 +43,8 @@ import (
 	"antrea.io/antrea/v2/pkg/agent/bgp"
 	bgptest "antrea.io/antrea/v2/pkg/agent/bgp/testing"
 	"antrea.io/antrea/v2/pkg/agent/config"
+	routetest "antrea.io/antrea/v2/pkg/agent/route/testing"
+	servicecidrtest "antrea.io/antrea/v2/pkg/agent/servicecidr/testing"
 	"antrea.io/antrea/v2/pkg/agent/types"
 	"antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
 	crdv1b1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
@@ -61,12 +64,14 @@ var (
 	podIPv6CIDR      = ip.MustParseCIDR("fec0:10:10::/64")
 	podIPv6CIDRRoute = bgp.Route{Prefix: podIPv6CIDR.String()}
 	nodeIPv4Addr     = ip.MustParseCIDR("192.168.77.100/24")
+	serviceIPv4CIDR  = ip.MustParseCIDR("10.96.0.0/16")
 
 	testNodeConfig = &config.NodeConfig{
-		PodIPv4CIDR:  podIPv4CIDR,
-		PodIPv6CIDR:  podIPv6CIDR,
-		NodeIPv4Addr: nodeIPv4Addr,
-		Name:         localNodeName,
+		PodIPv4CIDR:           podIPv4CIDR,
+		PodIPv6CIDR:           podIPv6CIDR,
+		NodeIPv4Addr:          nodeIPv4Addr,
+		NodeTransportIPv4Addr: nodeIPv4Addr,
+		Name:                  localNodeName,
 	}
 
 	peer1ASN          = int32(65531)
@@ -226,6 +231,7 @@ type fakeController struct {
 	*Controller
 	mockController     *gomock.Controller
 	mockBGPServer      *bgptest.MockInterface
+	mockRouteClient    *routetest.MockInterface
 	crdClient          *fakeversioned.Clientset
 	crdInformerFactory crdinformers.SharedInformerFactory
 	client             *fake.Clientset
@@ -242,6 +248,10 @@ func (c *fakeController) startInformers(stopCh chan struct{}) {
 func newFakeController(t *testing.T, objects []runtime.Object, crdObjects []runtime.Object, ipv4Enabled, ipv6Enabled bool) *fakeController {
 	ctrl := gomock.NewController(t)
 	mockBGPServer := bgptest.NewMockInterface(ctrl)
+	mockRouteClient := routetest.NewMockInterface(ctrl)
+	mockServiceCIDRProvider := servicecidrtest.NewMockInterface(ctrl)
+	mockServiceCIDRProvider.EXPECT().AddEventHandler(gomock.Any())
+	mockServiceCIDRProvider.EXPECT().GetServiceCIDRs().Return([]*net.IPNet{serviceIPv4CIDR}, nil).AnyTimes()
 
 	client := fake.NewSimpleClientset(objects...)
 	crdClient := fakeversioned.NewSimpleClientset(crdObjects...)
@@ -254,14 +264,20 @@ func newFakeController(t *testing.T, objects []runtime.Object, crdObjects []runt
 	egressInformer := crdInformerFactory.Crd().V1beta1().Egresses()
 	endpointSliceInformer := informerFactory.Discovery().V1().EndpointSlices()
 	bgpPolicyInformer := crdInformerFactory.Crd().V1alpha1().BGPPolicies()
+	podInformer := informerFactory.Core().V1().Pods()
+	namespaceInformer := informerFactory.Core().V1().Namespaces()
 
 	bgpController, _ := NewBGPPolicyController(nodeInformer,
 		serviceInformer,
 		egressInformer,
 		bgpPolicyInformer,
 		endpointSliceInformer,
+		podInformer.Informer(),
+		namespaceInformer,
 		true,
 		client,
+		mockRouteClient,
+		mockServiceCIDRProvider,
 		testNodeConfig,
 		&config.NetworkConfig{
 			IPv4Enabled: ipv4Enabled,
@@ -276,6 +292,7 @@ func newFakeController(t *testing.T, objects []runtime.Object, crdObjects []runt
 		Controller:         bgpController,
 		mockController:     ctrl,
 		mockBGPServer:      mockBGPServer,
+		mockRouteClient:    mockRouteClient,
 		crdClient:          crdClient,
 		crdInformerFactory: crdInformerFactory,
 		client:             client,
@@ -1858,6 +1875,460 @@ func TestEgressLifecycle(t *testing.T) {
 	doneDummyEvent(t, c)
 }
 
+func TestPodLifecycle(t *testing.T) {
+	policy := generateBGPPolicy(bgpPolicyName1,
+		creationTimestamp,
+		nodeLabels1,
+		179,
+		65000,
+		false,
+		false,
+		false,
+		false,
+		true,
+		[]v1alpha1.BGPPeer{ipv4Peer1},
+		nil)
+	policy.Spec.Advertisements.Pod.Selectors = []v1alpha1.PodAdvertisementSelector{
+		{
+			PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+		},
+	}
+	namespace := generateNamespace("ns1", map[string]string{"tenant": "a"})
+	c := newFakeController(t, []runtime.Object{node, namespace}, []runtime.Object{policy}, true, false)
+	mockBGPServer := c.mockBGPServer
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	ctx := context.Background()
+	c.startInformers(stopCh)
+
+	// Fake the passwords of BGP peers.
+	c.bgpPeerPasswords = bgpPeerPasswords
+
+	// Wait for the dummy event triggered by BGPPolicy add events.
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().Start(gomock.Any())
+	mockBGPServer.EXPECT().AddPeer(gomock.Any(), ipv4Peer1Config)
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	// Done with the dummy event.
+	doneDummyEvent(t, c)
+
+	// Create a Pod.
+	pod := generatePod("pod1", "ns1", map[string]string{"app": "web"}, "10.20.0.5", localNodeName)
+	_, err := c.client.CoreV1().Pods("ns1").Create(context.TODO(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().AdvertiseRoutes(gomock.Any(), gomock.InAnyOrder([]bgp.Route{{Prefix: "10.20.0.5/32"}}))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+
+	// Update the labels of the Namespace of the Pod.
+	updatedNamespace := generateNamespace("ns1", map[string]string{"tenant": "b"})
+	_, err = c.client.CoreV1().Namespaces().Update(context.TODO(), updatedNamespace, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().WithdrawRoutes(gomock.Any(), gomock.InAnyOrder([]bgp.Route{{Prefix: "10.20.0.5/32"}}))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+
+	// Restore the labels of the Namespace of the Pod.
+	_, err = c.client.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().AdvertiseRoutes(gomock.Any(), gomock.InAnyOrder([]bgp.Route{{Prefix: "10.20.0.5/32"}}))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+
+	// Update the labels of the Pod.
+	updatedPod := generatePod("pod1", "ns1", map[string]string{"app": "db"}, "10.20.0.5", localNodeName)
+	_, err = c.client.CoreV1().Pods("ns1").Update(context.TODO(), updatedPod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().WithdrawRoutes(gomock.Any(), gomock.InAnyOrder([]bgp.Route{{Prefix: "10.20.0.5/32"}}))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+
+	// Restore the labels of the Pod.
+	_, err = c.client.CoreV1().Pods("ns1").Update(context.TODO(), pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().AdvertiseRoutes(gomock.Any(), gomock.InAnyOrder([]bgp.Route{{Prefix: "10.20.0.5/32"}}))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+
+	// Delete the Pod.
+	err = c.client.CoreV1().Pods("ns1").Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().WithdrawRoutes(gomock.Any(), gomock.InAnyOrder([]bgp.Route{{Prefix: "10.20.0.5/32"}}))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+}
+
+func TestReceivedRoutes(t *testing.T) {
+	policy := generateBGPPolicy(bgpPolicyName1,
+		creationTimestamp,
+		nodeLabels1,
+		179,
+		65000,
+		false,
+		false,
+		false,
+		false,
+		false,
+		[]v1alpha1.BGPPeer{ipv4Peer1},
+		nil)
+	policy.Spec.ReceivedRoutes = &v1alpha1.ReceivedRoutes{AllowedPrefixes: []string{"10.0.0.0/8", "fec0::/16"}}
+	remoteNode := generateNode("remote", nil, nil)
+	remoteNode.Spec.PodCIDRs = []string{"10.10.1.0/24"}
+	c := newFakeController(t, []runtime.Object{node, remoteNode}, []runtime.Object{policy}, true, false)
+	mockBGPServer := c.mockBGPServer
+	mockRouteClient := c.mockRouteClient
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	ctx := context.Background()
+	c.startInformers(stopCh)
+
+	// Fake the passwords of BGP peers.
+	c.bgpPeerPasswords = bgpPeerPasswords
+
+	var watchCtx context.Context
+	var watchHandler func()
+	// Wait for the dummy event triggered by BGPPolicy add events.
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().Start(gomock.Any())
+	mockBGPServer.EXPECT().AddPeer(gomock.Any(), ipv4Peer1Config)
+	mockBGPServer.EXPECT().WatchReceivedRoutes(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func()) error {
+		watchCtx = ctx
+		watchHandler = handler
+		return nil
+	})
+	mockBGPServer.EXPECT().GetReceivedRoutes(gomock.Any()).Return([]bgp.ReceivedRoute{
+		{Prefix: "10.20.1.0/24", NextHop: ipv4Peer1Addr, PeerAddress: ipv4Peer1Addr},
+		// Not within the allowed prefixes.
+		{Prefix: "172.16.0.0/16", NextHop: ipv4Peer1Addr, PeerAddress: ipv4Peer1Addr},
+		// Overlapping with the local Pod CIDR.
+		{Prefix: "10.10.0.0/16", NextHop: ipv4Peer1Addr, PeerAddress: ipv4Peer1Addr},
+		// Overlapping with the Service CIDR.
+		{Prefix: "10.96.1.0/24", NextHop: ipv4Peer1Addr, PeerAddress: ipv4Peer1Addr},
+		// Overlapping with the Pod CIDR of another Node, which is routed by Antrea in encap mode.
+		{Prefix: "10.10.1.0/24", NextHop: ipv4Peer1Addr, PeerAddress: ipv4Peer1Addr},
+		// IPv6 is not enabled.
+		{Prefix: "fec0:20::/64", NextHop: ipv6Peer1Addr, PeerAddress: ipv6Peer1Addr},
+	}, nil)
+	mockRouteClient.EXPECT().AddBGPRoute(ip.MustParseCIDR("10.20.1.0/24"), net.ParseIP(ipv4Peer1Addr))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	// Done with the dummy event.
+	doneDummyEvent(t, c)
+	assert.Equal(t, map[string]string{"10.20.1.0/24": ipv4Peer1Addr}, c.installedReceivedRoutes)
+
+	// The next hop of a received route is updated and a new route is received.
+	watchHandler()
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().GetReceivedRoutes(gomock.Any()).Return([]bgp.ReceivedRoute{
+		{Prefix: "10.20.1.0/24", NextHop: "192.168.77.252", PeerAddress: ipv4Peer1Addr},
+		{Prefix: "10.20.2.0/24", NextHop: ipv4Peer1Addr, PeerAddress: ipv4Peer1Addr},
+	}, nil)
+	mockRouteClient.EXPECT().AddBGPRoute(ip.MustParseCIDR("10.20.1.0/24"), net.ParseIP("192.168.77.252"))
+	mockRouteClient.EXPECT().AddBGPRoute(ip.MustParseCIDR("10.20.2.0/24"), net.ParseIP(ipv4Peer1Addr))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+	assert.Equal(t, map[string]string{"10.20.1.0/24": "192.168.77.252", "10.20.2.0/24": ipv4Peer1Addr}, c.installedReceivedRoutes)
+
+	// A received route is withdrawn.
+	watchHandler()
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().GetReceivedRoutes(gomock.Any()).Return([]bgp.ReceivedRoute{
+		{Prefix: "10.20.2.0/24", NextHop: ipv4Peer1Addr, PeerAddress: ipv4Peer1Addr},
+	}, nil)
+	mockRouteClient.EXPECT().DeleteBGPRoute(ip.MustParseCIDR("10.20.1.0/24"))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+	assert.Equal(t, map[string]string{"10.20.2.0/24": ipv4Peer1Addr}, c.installedReceivedRoutes)
+
+	// Stop installing received routes.
+	updatedPolicy := policy.DeepCopy()
+	updatedPolicy.Spec.ReceivedRoutes = nil
+	updatedPolicy.Generation += 1
+	_, err := c.crdClient.CrdV1alpha1().BGPPolicies().Update(context.TODO(), updatedPolicy, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	waitAndGetDummyEvent(t, c)
+	mockRouteClient.EXPECT().DeleteBGPRoute(ip.MustParseCIDR("10.20.2.0/24"))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+	assert.Empty(t, c.installedReceivedRoutes)
+	assert.Error(t, watchCtx.Err())
+
+	// Install received routes again, and then delete the BGPPolicy.
+	updatedPolicy = policy.DeepCopy()
+	updatedPolicy.Generation += 2
+	_, err = c.crdClient.CrdV1alpha1().BGPPolicies().Update(context.TODO(), updatedPolicy, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().WatchReceivedRoutes(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func()) error {
+		watchCtx = ctx
+		return nil
+	})
+	mockBGPServer.EXPECT().GetReceivedRoutes(gomock.Any()).Return([]bgp.ReceivedRoute{
+		{Prefix: "10.20.1.0/24", NextHop: ipv4Peer1Addr, PeerAddress: ipv4Peer1Addr},
+	}, nil)
+	mockRouteClient.EXPECT().AddBGPRoute(ip.MustParseCIDR("10.20.1.0/24"), net.ParseIP(ipv4Peer1Addr))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+
+	require.NoError(t, c.crdClient.CrdV1alpha1().BGPPolicies().Delete(context.TODO(), policy.Name, metav1.DeleteOptions{}))
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().Stop(gomock.Any())
+	mockRouteClient.EXPECT().DeleteBGPRoute(ip.MustParseCIDR("10.20.1.0/24"))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+	assert.Empty(t, c.installedReceivedRoutes)
+	assert.Error(t, watchCtx.Err())
+}
+
+func TestGetInstallableReceivedRoutes(t *testing.T) {
+	sameSubnetNode := generateNode("node-a", nil, nil)
+	sameSubnetNode.Spec.PodCIDRs = []string{"10.10.1.0/24"}
+	sameSubnetNode.Status.Addresses = []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "192.168.77.101"}}
+	otherSubnetNode := generateNode("node-b", nil, nil)
+	otherSubnetNode.Spec.PodCIDRs = []string{"10.10.2.0/24"}
+	otherSubnetNode.Status.Addresses = []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "192.168.78.101"}}
+	allowedPrefixes := []string{"0.0.0.0/0"}
+
+	testCases := []struct {
+		name           string
+		noEncapMode    bool
+		receivedRoutes []bgp.ReceivedRoute
+		expectedRoutes map[string]string
+	}{
+		{
+			name: "reserved CIDRs",
+			receivedRoutes: []bgp.ReceivedRoute{
+				{Prefix: "10.10.0.128/25", NextHop: ipv4Peer1Addr},
+				{Prefix: "10.96.0.0/12", NextHop: ipv4Peer1Addr},
+				{Prefix: "192.168.77.0/28", NextHop: ipv4Peer1Addr},
+				{Prefix: "0.0.0.0/0", NextHop: ipv4Peer1Addr},
+				{Prefix: "172.16.0.0/16", NextHop: ipv4Peer1Addr},
+			},
+			expectedRoutes: map[string]string{"172.16.0.0/16": ipv4Peer1Addr},
+		},
+		{
+			name: "Pod CIDRs of other Nodes in encap mode",
+			receivedRoutes: []bgp.ReceivedRoute{
+				{Prefix: "10.10.1.0/24", NextHop: ipv4Peer1Addr},
+				{Prefix: "10.10.2.0/24", NextHop: ipv4Peer1Addr},
+			},
+			expectedRoutes: map[string]string{},
+		},
+		{
+			name:        "Pod CIDRs of other Nodes in noEncap mode",
+			noEncapMode: true,
+			receivedRoutes: []bgp.ReceivedRoute{
+				// Routed by Antrea as the Node is in the same subnet.
+				{Prefix: "10.10.1.0/24", NextHop: ipv4Peer1Addr},
+				{Prefix: "10.10.2.0/24", NextHop: ipv4Peer1Addr},
+				// Overlapping with Pod CIDRs without matching one exactly.
+				{Prefix: "10.10.2.0/25", NextHop: ipv4Peer1Addr},
+				{Prefix: "10.10.0.0/16", NextHop: ipv4Peer1Addr},
+			},
+			expectedRoutes: map[string]string{"10.10.2.0/24": ipv4Peer1Addr},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeController(t, []runtime.Object{node, sameSubnetNode, otherSubnetNode}, nil, true, false)
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			c.startInformers(stopCh)
+			c.noEncapMode = tt.noEncapMode
+
+			routes, err := c.getInstallableReceivedRoutes(tt.receivedRoutes, allowedPrefixes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRoutes, routes)
+		})
+	}
+}
+
+func TestRestoreReceivedRoutes(t *testing.T) {
+	policy := generateBGPPolicy(bgpPolicyName1,
+		creationTimestamp,
+		nodeLabels1,
+		179,
+		65000,
+		false,
+		false,
+		false,
+		false,
+		false,
+		[]v1alpha1.BGPPeer{ipv4Peer1},
+		nil)
+	policy.Spec.ReceivedRoutes = &v1alpha1.ReceivedRoutes{AllowedPrefixes: []string{"10.0.0.0/8"}}
+	c := newFakeController(t, []runtime.Object{node}, []runtime.Object{policy}, true, false)
+	mockBGPServer := c.mockBGPServer
+	mockRouteClient := c.mockRouteClient
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	ctx := context.Background()
+	c.startInformers(stopCh)
+	c.bgpPeerPasswords = bgpPeerPasswords
+
+	// Routes installed before the agent restarted.
+	mockRouteClient.EXPECT().RestoreBGPRoutes().Return([]*net.IPNet{ip.MustParseCIDR("10.20.1.0/24"), ip.MustParseCIDR("10.20.2.0/24")}, nil)
+	require.NoError(t, c.restoreReceivedRoutes())
+
+	waitAndGetDummyEvent(t, c)
+	mockBGPServer.EXPECT().Start(gomock.Any())
+	mockBGPServer.EXPECT().AddPeer(gomock.Any(), ipv4Peer1Config)
+	mockBGPServer.EXPECT().WatchReceivedRoutes(gomock.Any(), gomock.Any())
+	mockBGPServer.EXPECT().GetReceivedRoutes(gomock.Any()).Return([]bgp.ReceivedRoute{
+		{Prefix: "10.20.1.0/24", NextHop: ipv4Peer1Addr, PeerAddress: ipv4Peer1Addr},
+	}, nil)
+	// The route still received is replaced, and the stale one is deleted.
+	mockRouteClient.EXPECT().AddBGPRoute(ip.MustParseCIDR("10.20.1.0/24"), net.ParseIP(ipv4Peer1Addr))
+	mockRouteClient.EXPECT().DeleteBGPRoute(ip.MustParseCIDR("10.20.2.0/24"))
+	require.NoError(t, c.syncBGPPolicy(ctx))
+	doneDummyEvent(t, c)
+	assert.Equal(t, map[string]string{"10.20.1.0/24": ipv4Peer1Addr}, c.installedReceivedRoutes)
+}
+
+func TestGetRoutesWithSelectors(t *testing.T) {
+	tenantNamespace := generateNamespace("tenant-a", map[string]string{"tenant": "a"})
+	defaultNamespace := generateNamespace(namespaceDefault, nil)
+
+	publicLoadBalancer := generateService("public", corev1.ServiceTypeLoadBalancer, "10.96.10.20", "", "192.168.77.160", false, false)
+	publicLoadBalancer.Namespace = tenantNamespace.Name
+	publicLoadBalancer.Labels = map[string]string{"public": "true"}
+	privateLoadBalancer := generateService("private", corev1.ServiceTypeLoadBalancer, "10.96.10.21", "", "192.168.77.161", false, false)
+	privateLoadBalancer.Namespace = tenantNamespace.Name
+	defaultLoadBalancer := generateService("public", corev1.ServiceTypeLoadBalancer, "10.96.10.22", "", "192.168.77.162", false, false)
+	defaultLoadBalancer.Labels = map[string]string{"public": "true"}
+
+	selectedEgress := generateEgress("eg1", "192.168.77.200", localNodeName)
+	selectedEgress.Labels = map[string]string{"bgp": "true"}
+	unselectedEgress := generateEgress("eg2", "192.168.77.201", localNodeName)
+
+	webPod := generatePod("web", tenantNamespace.Name, map[string]string{"app": "web"}, "10.20.0.5", localNodeName)
+	webPod.Status.PodIPs = append(webPod.Status.PodIPs, corev1.PodIP{IP: "fd00:10:20::5"})
+	hostNetworkPod := generatePod("host-network", tenantNamespace.Name, map[string]string{"app": "web"}, nodeIPv4Addr.IP.String(), localNodeName)
+	hostNetworkPod.Spec.HostNetwork = true
+	completedPod := generatePod("completed", tenantNamespace.Name, map[string]string{"app": "web"}, "10.20.0.6", localNodeName)
+	completedPod.Status.Phase = corev1.PodSucceeded
+	dbPod := generatePod("db", tenantNamespace.Name, map[string]string{"app": "db"}, "10.20.0.7", localNodeName)
+
+	objects := []runtime.Object{
+		tenantNamespace,
+		defaultNamespace,
+		publicLoadBalancer,
+		privateLoadBalancer,
+		defaultLoadBalancer,
+		webPod,
+		hostNetworkPod,
+		completedPod,
+		dbPod,
+	}
+	crdObjects := []runtime.Object{
+		selectedEgress,
+		unselectedEgress,
+	}
+
+	testCases := []struct {
+		name           string
+		advertisements v1alpha1.Advertisements
+		expectedRoutes map[bgp.Route]RouteMetadata
+	}{
+		{
+			name: "Service selectors",
+			advertisements: v1alpha1.Advertisements{
+				Service: &v1alpha1.ServiceAdvertisement{
+					IPTypes: []v1alpha1.ServiceIPType{v1alpha1.ServiceIPTypeLoadBalancerIP},
+					Selectors: []v1alpha1.ServiceAdvertisementSelector{
+						{
+							ServiceSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"public": "true"}},
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+						},
+					},
+				},
+			},
+			expectedRoutes: map[bgp.Route]RouteMetadata{
+				{Prefix: "192.168.77.160/32"}: {Type: ServiceLoadBalancerIP, K8sObjRef: "tenant-a/public"},
+			},
+		},
+		{
+			name: "Service selectors selecting all Services in a Namespace",
+			advertisements: v1alpha1.Advertisements{
+				Service: &v1alpha1.ServiceAdvertisement{
+					IPTypes: []v1alpha1.ServiceIPType{v1alpha1.ServiceIPTypeLoadBalancerIP},
+					Selectors: []v1alpha1.ServiceAdvertisementSelector{
+						{
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+						},
+					},
+				},
+			},
+			expectedRoutes: map[bgp.Route]RouteMetadata{
+				{Prefix: "192.168.77.160/32"}: {Type: ServiceLoadBalancerIP, K8sObjRef: "tenant-a/public"},
+				{Prefix: "192.168.77.161/32"}: {Type: ServiceLoadBalancerIP, K8sObjRef: "tenant-a/private"},
+			},
+		},
+		{
+			name: "Egress selectors",
+			advertisements: v1alpha1.Advertisements{
+				Egress: &v1alpha1.EgressAdvertisement{
+					Selectors: []v1alpha1.EgressAdvertisementSelector{
+						{EgressSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"bgp": "true"}}},
+					},
+				},
+			},
+			expectedRoutes: map[bgp.Route]RouteMetadata{
+				{Prefix: "192.168.77.200/32"}: {Type: EgressIP, K8sObjRef: "eg1"},
+			},
+		},
+		{
+			name: "Pod selectors",
+			advertisements: v1alpha1.Advertisements{
+				Pod: &v1alpha1.PodAdvertisement{
+					Selectors: []v1alpha1.PodAdvertisementSelector{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+					},
+				},
+			},
+			expectedRoutes: map[bgp.Route]RouteMetadata{
+				{Prefix: "10.20.0.5/32"}:      {Type: PodIP, K8sObjRef: "tenant-a/web"},
+				{Prefix: "fd00:10:20::5/128"}: {Type: PodIP, K8sObjRef: "tenant-a/web"},
+			},
+		},
+		{
+			name: "Pod without selectors",
+			advertisements: v1alpha1.Advertisements{
+				Pod: &v1alpha1.PodAdvertisement{},
+			},
+			expectedRoutes: map[bgp.Route]RouteMetadata{
+				podIPv4CIDRRoute: {Type: NodeIPAMPodCIDR},
+				podIPv6CIDRRoute: {Type: NodeIPAMPodCIDR},
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeController(t, objects, crdObjects, true, true)
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			c.startInformers(stopCh)
+
+			assert.Equal(t, tt.expectedRoutes, c.getRoutes(tt.advertisements))
+		})
+	}
+}
+
 func TestBGPPasswordUpdate(t *testing.T) {
 	policy := generateBGPPolicy(bgpPolicyName1,
 		creationTimestamp,
@@ -2337,6 +2808,35 @@ func generateNode(name string, labels, annotations map[string]string) *corev1.No
 	}
 }
 
+func generateNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			UID:    "test-uid",
+			Labels: labels,
+		},
+	}
+}
+
+func generatePod(name, namespace string, labels map[string]string, ip string, nodeName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       "test-uid",
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+		},
+		Status: corev1.PodStatus{
+			Phase:  corev1.PodRunning,
+			PodIP:  ip,
+			PodIPs: []corev1.PodIP{{IP: ip}},
+		},
+	}
+}
+
 func generateEndpointSlice(svcName string,
 	suffix string,
 	isLocal bool,
@@ -2600,9 +3100,182 @@ func TestGetBGPRoutes(t *testing.T) {
 	}
 }
 
+func TestGetRoutePolicy(t *testing.T) {
+	testCases := []struct {
+		name           string
+		policy         *v1alpha1.BGPRoutePolicy
+		routes         map[bgp.Route]RouteMetadata
+		expectedPolicy *bgp.RoutePolicy
+	}{
+		{
+			name:   "no policy",
+			routes: allRoutes,
+		},
+		{
+			name: "route types and prefixes",
+			policy: &v1alpha1.BGPRoutePolicy{
+				Rules: []v1alpha1.BGPRoutePolicyRule{
+					{
+						Match: v1alpha1.BGPRouteMatch{
+							RouteTypes: []v1alpha1.BGPRouteType{v1alpha1.BGPRouteTypeServiceLoadBalancerIP},
+						},
+						Attributes: &v1alpha1.BGPPathAttributes{
+							Communities:      []string{"65000:100", "no-export"},
+							LargeCommunities: []string{"65000:1:2"},
+							LocalPreference:  ptr.To[int64](200),
+							MED:              ptr.To[int64](10),
+						},
+					},
+					{
+						Match: v1alpha1.BGPRouteMatch{
+							RouteTypes: []v1alpha1.BGPRouteType{v1alpha1.BGPRouteTypeServiceClusterIP, v1alpha1.BGPRouteTypeServiceExternalIP},
+							Prefixes:   []string{"10.96.0.0/16"},
+						},
+						Action: v1alpha1.BGPRouteActionReject,
+					},
+					{
+						Match: v1alpha1.BGPRouteMatch{
+							Prefixes: []string{"10.10.0.1/16", "fec0:10:10::/48"},
+						},
+					},
+					{
+						// No route of the route type is within the prefixes, the rule is omitted.
+						Match: v1alpha1.BGPRouteMatch{
+							RouteTypes: []v1alpha1.BGPRouteType{v1alpha1.BGPRouteTypeEgressIP},
+							Prefixes:   []string{"10.0.0.0/8"},
+						},
+						Action: v1alpha1.BGPRouteActionReject,
+					},
+				},
+				DefaultAction: v1alpha1.BGPRouteActionReject,
+			},
+			routes: allRoutes,
+			expectedPolicy: &bgp.RoutePolicy{
+				Rules: []bgp.RoutePolicyRule{
+					{
+						Prefixes: []bgp.PrefixMatch{{Prefix: loadBalancerIPv4Route.Prefix}, {Prefix: loadBalancerIPv6Route.Prefix}},
+						Action:   bgp.RouteActionAccept,
+						Attributes: &bgp.PathAttributes{
+							Communities:      []string{"65000:100", "no-export"},
+							LargeCommunities: []string{"65000:1:2"},
+							LocalPreference:  ptr.To[uint32](200),
+							MED:              ptr.To[uint32](10),
+						},
+					},
+					{
+						Prefixes: []bgp.PrefixMatch{{Prefix: clusterIPv4Route1.Prefix}, {Prefix: clusterIPv4Route2.Prefix}},
+						Action:   bgp.RouteActionReject,
+					},
+					{
+						Prefixes: []bgp.PrefixMatch{{Prefix: "10.10.0.0/16", OrLonger: true}, {Prefix: "fec0:10:10::/48", OrLonger: true}},
+						Action:   bgp.RouteActionAccept,
+					},
+				},
+				DefaultAction: bgp.RouteActionReject,
+			},
+		},
+		{
+			name: "import policy",
+			policy: &v1alpha1.BGPRoutePolicy{
+				Rules: []v1alpha1.BGPRoutePolicyRule{
+					{
+						// Route types never match routes received from BGP peers.
+						Match: v1alpha1.BGPRouteMatch{
+							RouteTypes: []v1alpha1.BGPRouteType{v1alpha1.BGPRouteTypeNodeIPAMPodCIDR},
+						},
+					},
+					{
+						Match:      v1alpha1.BGPRouteMatch{},
+						Attributes: &v1alpha1.BGPPathAttributes{LocalPreference: ptr.To[int64](50)},
+					},
+				},
+			},
+			expectedPolicy: &bgp.RoutePolicy{
+				Rules: []bgp.RoutePolicyRule{
+					{
+						Action:     bgp.RouteActionAccept,
+						Attributes: &bgp.PathAttributes{LocalPreference: ptr.To[uint32](50)},
+					},
+				},
+				DefaultAction: bgp.RouteActionAccept,
+			},
+		},
+		{
+			name: "communities out of range",
+			policy: &v1alpha1.BGPRoutePolicy{
+				Rules: []v1alpha1.BGPRoutePolicyRule{
+					{
+						Match: v1alpha1.BGPRouteMatch{},
+						Attributes: &v1alpha1.BGPPathAttributes{
+							Communities:      []string{"0:0", "65535:65535", "65536:1", "1:65536", "99999:99999", "no-peer"},
+							LargeCommunities: []string{"4294967295:0:4294967295", "4294967296:0:1", "1:2:9999999999"},
+						},
+					},
+				},
+			},
+			expectedPolicy: &bgp.RoutePolicy{
+				Rules: []bgp.RoutePolicyRule{
+					{
+						Action: bgp.RouteActionAccept,
+						Attributes: &bgp.PathAttributes{
+							Communities:      []string{"0:0", "65535:65535", "no-peer"},
+							LargeCommunities: []string{"4294967295:0:4294967295"},
+						},
+					},
+				},
+				DefaultAction: bgp.RouteActionAccept,
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedPolicy, getRoutePolicy(tt.policy, tt.routes))
+		})
+	}
+}
+
 // TestDeleteHandlerTombstone verifies that all delete event handlers correctly handle
 // cache.DeletedFinalStateUnknown (tombstone) objects, which are delivered by the informer
 // when a watch reconnects and the original DELETE event was missed.
+func TestGetBFDConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		peerBFD  *v1alpha1.BGPPeerBFD
+		expected *bgp.BFDConfig
+	}{
+		{
+			name: "BFD disabled",
+		},
+		{
+			name:    "default values",
+			peerBFD: &v1alpha1.BGPPeerBFD{},
+			expected: &bgp.BFDConfig{
+				MinTxInterval: 300 * time.Millisecond,
+				MinRxInterval: 300 * time.Millisecond,
+				Multiplier:    3,
+			},
+		},
+		{
+			name: "custom values",
+			peerBFD: &v1alpha1.BGPPeerBFD{
+				MinTxIntervalMilliseconds: ptr.To[int32](50),
+				MinRxIntervalMilliseconds: ptr.To[int32](100),
+				Multiplier:                ptr.To[int32](5),
+			},
+			expected: &bgp.BFDConfig{
+				MinTxInterval: 50 * time.Millisecond,
+				MinRxInterval: 100 * time.Millisecond,
+				Multiplier:    5,
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getBFDConfig(tt.peerBFD))
+		})
+	}
+}
+
 func TestDeleteHandlerTombstone(t *testing.T) {
 	// A BGPPolicy that matches the local node (nodeLabels1) and advertises various IPs.
 	policy := generateBGPPolicy(
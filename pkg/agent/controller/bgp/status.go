@@ -0,0 +1,135 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/v2/pkg/agent/bgp"
+	"antrea.io/antrea/v2/pkg/agent/metrics"
+	"antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+)
+
+// statusSyncInterval is the interval at which the status of BGP peers is collected, to update the BGP metrics and the
+// status reported in AntreaAgentInfo.
+const statusSyncInterval = 30 * time.Second
+
+// peerMetricKey is the label values of the metrics of a BGP peer.
+type peerMetricKey struct {
+	address string
+	asn     string
+}
+
+// syncBGPPolicyStatus collects the status of the BGP peers of the local BGP server, updates the BGP metrics, and stores
+// the status of the effective BGPPolicy, which is reported in AntreaAgentInfo and aggregated into the BGPPolicy status
+// by the Antrea Controller.
+func (c *Controller) syncBGPPolicyStatus(ctx context.Context) {
+	var bgpServer bgp.Interface
+	var bgpPolicyName, routerID string
+	c.bgpPolicyStateMutex.RLock()
+	if c.bgpPolicyState != nil {
+		bgpServer = c.bgpPolicyState.bgpServer
+		bgpPolicyName = c.bgpPolicyState.bgpPolicyName
+		routerID = c.bgpPolicyState.routerID
+	}
+	c.bgpPolicyStateMutex.RUnlock()
+
+	var peers []bgp.PeerStatus
+	if bgpServer != nil {
+		var err error
+		if peers, err = bgpServer.GetPeers(ctx); err != nil {
+			klog.ErrorS(err, "Failed to get the status of BGP peers")
+			return
+		}
+	}
+	c.updateBGPPeerMetrics(peers)
+
+	var bgpPolicyStatus *v1beta1.BGPPolicyInfo
+	if bgpServer != nil {
+		bgpPolicyStatus = &v1beta1.BGPPolicyInfo{
+			Name:     bgpPolicyName,
+			RouterID: routerID,
+			Peers:    getBGPPeerStatuses(peers),
+		}
+	}
+	c.bgpPolicyStatus.Store(bgpPolicyStatus)
+}
+
+// GetBGPPolicyStatus returns the status of the BGPPolicy effective on the Node collected by the last status sync, or
+// nil if no BGPPolicy is effective on the Node.
+func (c *Controller) GetBGPPolicyStatus() *v1beta1.BGPPolicyInfo {
+	return c.bgpPolicyStatus.Load().DeepCopy()
+}
+
+func getBGPPeerStatuses(peers []bgp.PeerStatus) []v1beta1.BGPPeerInfo {
+	var peerStatuses []v1beta1.BGPPeerInfo
+	for _, peer := range peers {
+		peerStatus := v1beta1.BGPPeerInfo{
+			Address:          peer.Address,
+			ASN:              peer.ASN,
+			SessionState:     string(peer.SessionState),
+			AdvertisedRoutes: int32(peer.AdvertisedRoutes),
+			ReceivedRoutes:   int32(peer.ReceivedRoutes),
+			BFDSessionState:  peer.BFDSessionState,
+		}
+		if peer.SessionState == bgp.SessionEstablished && !peer.EstablishedTime.IsZero() {
+			// The time is serialized with a precision of seconds.
+			peerStatus.EstablishedTime = &metav1.Time{Time: peer.EstablishedTime.Truncate(time.Second)}
+		}
+		peerStatuses = append(peerStatuses, peerStatus)
+	}
+	slices.SortFunc(peerStatuses, func(a, b v1beta1.BGPPeerInfo) int {
+		if n := strings.Compare(a.Address, b.Address); n != 0 {
+			return n
+		}
+		return int(a.ASN - b.ASN)
+	})
+	return peerStatuses
+}
+
+// updateBGPPeerMetrics updates the metrics of the given BGP peers, and deletes the metrics of the BGP peers which no
+// longer exist.
+func (c *Controller) updateBGPPeerMetrics(peers []bgp.PeerStatus) {
+	curPeerMetricLabels := sets.New[peerMetricKey]()
+	for _, peer := range peers {
+		key := peerMetricKey{address: peer.Address, asn: strconv.Itoa(int(peer.ASN))}
+		curPeerMetricLabels.Insert(key)
+		established := 0.0
+		uptime := 0.0
+		if peer.SessionState == bgp.SessionEstablished {
+			established = 1
+			uptime = float64(peer.UptimeSeconds)
+		}
+		metrics.BGPPeerSessionEstablished.WithLabelValues(key.address, key.asn).Set(established)
+		metrics.BGPPeerSessionUptime.WithLabelValues(key.address, key.asn).Set(uptime)
+		metrics.BGPPeerAdvertisedRouteCount.WithLabelValues(key.address, key.asn).Set(float64(peer.AdvertisedRoutes))
+		metrics.BGPPeerReceivedRouteCount.WithLabelValues(key.address, key.asn).Set(float64(peer.ReceivedRoutes))
+	}
+	for key := range c.peerMetricLabels.Difference(curPeerMetricLabels) {
+		metrics.BGPPeerSessionEstablished.DeleteLabelValues(key.address, key.asn)
+		metrics.BGPPeerSessionUptime.DeleteLabelValues(key.address, key.asn)
+		metrics.BGPPeerAdvertisedRouteCount.DeleteLabelValues(key.address, key.asn)
+		metrics.BGPPeerReceivedRouteCount.DeleteLabelValues(key.address, key.asn)
+	}
+	c.peerMetricLabels = curPeerMetricLabels
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/component-base/metrics/testutil"
+
+	"antrea.io/antrea/v2/pkg/agent/bgp"
+	"antrea.io/antrea/v2/pkg/agent/metrics"
+	"antrea.io/antrea/v2/pkg/agent/types"
+	"antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+)
+
+func TestSyncBGPPolicyStatus(t *testing.T) {
+	metrics.InitializeBGPMetrics()
+
+	establishedTime := time.Now().Add(-time.Hour)
+	ipv4Peer1EstablishedStatus := bgp.PeerStatus{
+		Address:          ipv4Peer1Addr,
+		ASN:              peer1ASN,
+		SessionState:     bgp.SessionEstablished,
+		UptimeSeconds:    3600,
+		EstablishedTime:  establishedTime,
+		AdvertisedRoutes: 3,
+		ReceivedRoutes:   2,
+		BFDSessionState:  "Up",
+	}
+	expectedIPv4Peer1Status := v1beta1.BGPPeerInfo{
+		Address:          ipv4Peer1Addr,
+		ASN:              peer1ASN,
+		SessionState:     string(bgp.SessionEstablished),
+		EstablishedTime:  &metav1.Time{Time: establishedTime.Truncate(time.Second)},
+		AdvertisedRoutes: 3,
+		ReceivedRoutes:   2,
+		BFDSessionState:  "Up",
+	}
+	expectedIPv4Peer2Status := v1beta1.BGPPeerInfo{
+		Address:      ipv4Peer2Addr,
+		ASN:          peer2ASN,
+		SessionState: string(bgp.SessionActive),
+	}
+
+	testCases := []struct {
+		name            string
+		effectivePolicy string
+		peers           []bgp.PeerStatus
+		expectedStatus  *v1beta1.BGPPolicyInfo
+	}{
+		{
+			name:            "effective BGPPolicy",
+			effectivePolicy: bgpPolicyName1,
+			peers:           []bgp.PeerStatus{ipv4Peer2Status, ipv4Peer1EstablishedStatus},
+			expectedStatus: &v1beta1.BGPPolicyInfo{
+				Name:     bgpPolicyName1,
+				RouterID: nodeAnnotations1[types.NodeBGPRouterIDAnnotationKey],
+				Peers:    []v1beta1.BGPPeerInfo{expectedIPv4Peer1Status, expectedIPv4Peer2Status},
+			},
+		},
+		{
+			name: "no effective BGPPolicy",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeController(t, []runtime.Object{node}, nil, true, false)
+			// The status collected by the previous sync is overridden.
+			c.bgpPolicyStatus.Store(&v1beta1.BGPPolicyInfo{Name: bgpPolicyName2})
+
+			if tt.effectivePolicy != "" {
+				c.bgpPolicyState = generateBGPPolicyState(tt.effectivePolicy,
+					179,
+					65000,
+					nodeAnnotations1[types.NodeBGPRouterIDAnnotationKey],
+					nil,
+					nil,
+					nil,
+				)
+				c.bgpPolicyState.bgpServer = c.mockBGPServer
+				c.mockBGPServer.EXPECT().GetPeers(gomock.Any()).Return(tt.peers, nil)
+			}
+
+			c.syncBGPPolicyStatus(context.Background())
+			assert.Equal(t, tt.expectedStatus, c.GetBGPPolicyStatus())
+
+			for _, peer := range tt.peers {
+				asn := strconv.Itoa(int(peer.ASN))
+				established, err := testutil.GetGaugeMetricValue(metrics.BGPPeerSessionEstablished.WithLabelValues(peer.Address, asn))
+				require.NoError(t, err)
+				receivedRoutes, err := testutil.GetGaugeMetricValue(metrics.BGPPeerReceivedRouteCount.WithLabelValues(peer.Address, asn))
+				require.NoError(t, err)
+				if peer.SessionState == bgp.SessionEstablished {
+					assert.Equal(t, float64(1), established)
+				} else {
+					assert.Equal(t, float64(0), established)
+				}
+				assert.Equal(t, float64(peer.ReceivedRoutes), receivedRoutes)
+			}
+			// Reset the metrics of the peers.
+			c.updateBGPPeerMetrics(nil)
+		})
+	}
+}
@@ -75,6 +75,7 @@ type logInfo struct {
 	destPort     string // destination port of the traffic logged
 	pktLength    string // packet length of packetin
 	protocolStr  string // protocol of the traffic logged
+	l7Info       string // layer 7 information of the traffic rejected by the L7 engine, empty for other traffic
 }
 
 // logDedupRecord will be used as 1 sec buffer for log deduplication.
@@ -132,7 +133,7 @@ func (l *AuditLogger) updateLogKey(logMsg string, bufferLength time.Duration) bo
 }
 
 func buildLogMsg(ob *logInfo) string {
-	return strings.Join([]string{
+	items := []string{
 		ob.tableName,
 		ob.npRef,
 		ob.ruleName,
@@ -147,7 +148,11 @@ func buildLogMsg(ob *logInfo) string {
 		ob.protocolStr,
 		ob.pktLength,
 		ob.logLabel,
-	}, " ")
+	}
+	if ob.l7Info != "" {
+		items = append(items, ob.l7Info)
+	}
+	return strings.Join(items, " ")
 }
 
 // LogDedupPacket logs information in ob based on disposition and duplication conditions.
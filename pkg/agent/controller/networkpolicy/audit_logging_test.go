@@ -51,6 +51,7 @@ const (
 var (
 	actionAllow    = openflow.DispositionToString[openflow.DispositionAllow]
 	actionDrop     = openflow.DispositionToString[openflow.DispositionDrop]
+	actionReject   = openflow.DispositionToString[openflow.DispositionRej]
 	actionRedirect = "Redirect"
 	testANNPRef    = &v1beta2.NetworkPolicyReference{
 		Type:      v1beta2.AntreaNetworkPolicy,
@@ -254,6 +254,15 @@ func (c *ruleCache) getNetworkPolicy(uid string) *v1beta.NetworkPolicy {
 	return policy
 }
 
+// getRule returns the rule with the provided ID.
+func (c *ruleCache) getRule(ruleID string) (*rule, bool) {
+	obj, exists, _ := c.rules.GetByKey(ruleID)
+	if !exists {
+		return nil, false
+	}
+	return obj.(*rule), true
+}
+
 func (c *ruleCache) getAppliedNetworkPolicies(pod, namespace string, npFilter *querier.NetworkPolicyQueryFilter) []v1beta.NetworkPolicy {
 	var groups []string
 	memberPod := &v1beta.GroupMember{Pod: &v1beta.PodReference{Name: pod, Namespace: namespace}}
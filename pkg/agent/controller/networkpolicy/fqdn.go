@@ -135,6 +135,9 @@ type fqdnController struct {
 	ruleSyncTracker *ruleSyncTracker
 	// FQDN names this controller is tracking, with their corresponding dnsMeta.
 	dnsEntryCache map[string]dnsMeta
+	// ipToFQDNs is a reversed index of dnsEntryCache. It stores the FQDNs which resolved to each IP address.
+	// It's protected by fqdnSelectorMutex, like dnsEntryCache.
+	ipToFQDNs map[string]sets.Set[string]
 	// FQDN names that needs to be re-queried after their respective TTLs.
 	dnsQueryQueue workqueue.TypedRateLimitingInterface[string]
 	// idAllocator provides interfaces to allocateForRule and release uint32 id.
@@ -175,6 +178,7 @@ func newFQDNController(client openflow.Client, allocator *idAllocator, dnsServer
 			},
 		),
 		dnsEntryCache:          map[string]dnsMeta{},
+		ipToFQDNs:              map[string]sets.Set[string]{},
 		fqdnRuleToSelectedPods: map[string]sets.Set[int32]{},
 		fqdnToSelectorItem:     map[string]sets.Set[fqdnSelectorItem]{},
 		selectorItemToFQDN:     map[fqdnSelectorItem]sets.Set[string]{},
@@ -272,6 +276,57 @@ func (f *fqdnController) getIPsForFQDNSelectors(fqdns []string) []net.IP {
 	return matchedIPs
 }
 
+// setDNSEntry sets the dnsMeta of a FQDN in dnsEntryCache and updates ipToFQDNs accordingly.
+// fqdnSelectorMutex must have been acquired by the caller.
+func (f *fqdnController) setDNSEntry(fqdn string, meta dnsMeta) {
+	f.deleteDNSEntry(fqdn)
+	f.dnsEntryCache[fqdn] = meta
+	for ipStr := range meta.responseIPs {
+		fqdns, ok := f.ipToFQDNs[ipStr]
+		if !ok {
+			f.ipToFQDNs[ipStr] = sets.New[string](fqdn)
+		} else {
+			fqdns.Insert(fqdn)
+		}
+	}
+}
+
+// deleteDNSEntry deletes a FQDN from dnsEntryCache and ipToFQDNs.
+// fqdnSelectorMutex must have been acquired by the caller.
+func (f *fqdnController) deleteDNSEntry(fqdn string) {
+	for ipStr := range f.dnsEntryCache[fqdn].responseIPs {
+		if fqdns, ok := f.ipToFQDNs[ipStr]; ok {
+			fqdns.Delete(fqdn)
+			if len(fqdns) == 0 {
+				delete(f.ipToFQDNs, ipStr)
+			}
+		}
+	}
+	delete(f.dnsEntryCache, fqdn)
+}
+
+// getFQDNForIP returns the FQDN which resolved to the given IP address. If multiple FQDNs
+// resolved to the IP address, the one with the latest expiration time is returned.
+func (f *fqdnController) getFQDNForIP(ipStr string) string {
+	var fqdn string
+	var expirationTime time.Time
+	f.fqdnSelectorMutex.Lock()
+	defer f.fqdnSelectorMutex.Unlock()
+	for name := range f.ipToFQDNs[ipStr] {
+		ipWithExpiration, ok := f.dnsEntryCache[name].responseIPs[ipStr]
+		if !ok {
+			continue
+		}
+		// Ties are broken using the name, so that the result is deterministic.
+		if fqdn == "" || ipWithExpiration.expirationTime.After(expirationTime) ||
+			(ipWithExpiration.expirationTime.Equal(expirationTime) && name < fqdn) {
+			fqdn = name
+			expirationTime = ipWithExpiration.expirationTime
+		}
+	}
+	return fqdn
+}
+
 // addFQDNRule adds a new FQDN rule to fqdnSelectorItem mapping, as well as the OFAddresses of
 // Pods selected by the FQDN rule.
 func (f *fqdnController) addFQDNRule(ruleID string, fqdns []string, podOFAddrs sets.Set[int32]) error {
@@ -381,7 +436,7 @@ func (f *fqdnController) cleanupFQDNSelectorItem(fs fqdnSelectorItem) {
 				// that selects this FQDN. Hence this FQDN no longer needs to be
 				// tracked by the fqdnController.
 				delete(f.fqdnToSelectorItem, fqdn)
-				delete(f.dnsEntryCache, fqdn)
+				f.deleteDNSEntry(fqdn)
 			}
 		}
 	}
@@ -501,9 +556,9 @@ func (f *fqdnController) onDNSResponse(
 
 	// ipWithExpirationMap remains empty and timeToRequery is nil only when FQDN doesn't match any selector.
 	if len(ipWithExpirationMap) > 0 {
-		f.dnsEntryCache[fqdn] = dnsMeta{
+		f.setDNSEntry(fqdn, dnsMeta{
 			responseIPs: ipWithExpirationMap,
-		}
+		})
 		f.dnsQueryQueue.AddAfter(fqdn, timeToRequery.Sub(currentTime))
 	}
 
@@ -0,0 +1,156 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/v2/pkg/agent/controller/networkpolicy/l7engine"
+	"antrea.io/antrea/v2/pkg/agent/flowexporter/connection"
+	flowexporterutils "antrea.io/antrea/v2/pkg/agent/flowexporter/utils"
+	"antrea.io/antrea/v2/pkg/agent/interfacestore"
+	"antrea.io/antrea/v2/pkg/agent/metrics"
+	"antrea.io/antrea/v2/pkg/agent/openflow"
+	v1beta "antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+	"antrea.io/antrea/v2/pkg/util/ip"
+)
+
+// l7EngineTableName is used in place of the OVS table name in the audit logs of the traffic rejected by the L7 engine.
+const l7EngineTableName = "L7Engine"
+
+// handleL7Event surfaces the traffic rejected by the L7 engine through the audit logs, the metrics and the flow
+// exporter, as the traffic is only redirected to the L7 engine by OVS and never reaches the packetIn handlers.
+func (c *Controller) handleL7Event(event *l7engine.Event) {
+	if event.Action != l7engine.EventActionBlocked {
+		return
+	}
+	r, exists := c.ruleCache.getRule(event.RuleID)
+	if !exists {
+		klog.V(2).InfoS("Ignored L7 engine event of unknown rule", "ruleID", event.RuleID, "policy", event.PolicyName)
+		return
+	}
+	direction := "Ingress"
+	if r.Direction == v1beta.DirectionOut {
+		direction = "Egress"
+	}
+	metrics.L7NetworkPolicyRejectedRequestCount.WithLabelValues(direction, event.AppProtocol).Inc()
+
+	if c.auditLogger != nil && r.EnableLogging {
+		c.auditLogger.LogDedupPacket(c.buildL7LogInfo(event, r, direction))
+	}
+	if c.denyConnNotifier != nil {
+		denyConn := buildL7DenyConnection(event, r)
+		c.denyConnNotifier.Notify(denyConn)
+	}
+}
+
+func (c *Controller) buildL7LogInfo(event *l7engine.Event, r *rule, direction string) *logInfo {
+	ob := &logInfo{
+		tableName:   l7EngineTableName,
+		npRef:       event.PolicyName,
+		ruleName:    r.Name,
+		direction:   direction,
+		logLabel:    r.LogLabel,
+		disposition: openflow.DispositionToString[openflow.DispositionRej],
+		srcIP:       event.SrcIP.String(),
+		srcPort:     strconv.FormatUint(uint64(event.SrcPort), 10),
+		destIP:      event.DestIP.String(),
+		destPort:    strconv.FormatUint(uint64(event.DestPort), 10),
+		protocolStr: ip.IPProtocolNumberToString(event.Protocol, "UnknownProtocol"),
+		l7Info:      getL7EventInfo(event),
+	}
+	localIP := ob.destIP
+	if direction == "Egress" {
+		localIP = ob.srcIP
+	}
+	if iface, ok := c.ifaceStore.GetInterfaceByIP(localIP); ok && iface.Type == interfacestore.ContainerInterface {
+		ob.appliedToRef = fmt.Sprintf("%s/%s", iface.ContainerInterfaceConfig.PodNamespace, iface.ContainerInterfaceConfig.PodName)
+	}
+	// The OpenFlow priority and the packet length are not available for the traffic rejected by the L7 engine.
+	fillLogInfoPlaceholders([]*string{&ob.ruleName, &ob.logLabel, &ob.ofPriority, &ob.appliedToRef, &ob.pktLength})
+	return ob
+}
+
+// getL7EventInfo returns the layer 7 information of an event as a single token, so that it can be appended to the
+// space-separated audit log message.
+func getL7EventInfo(event *l7engine.Event) string {
+	if event.AppProtocol == "" {
+		return nullPlaceholder
+	}
+	var detail string
+	switch {
+	case event.HTTP != nil:
+		detail = event.HTTP.Method + ":" + event.HTTP.Hostname + event.HTTP.URL
+	case event.TLSSNI != "":
+		detail = event.TLSSNI
+	case event.DNSQuery != "":
+		detail = event.DNSQuery
+	}
+	if detail == "" {
+		return event.AppProtocol
+	}
+	return event.AppProtocol + ":" + detail
+}
+
+// buildL7DenyConnection returns the deny connection of the traffic rejected by the L7 engine. As the rule isn't
+// identified by an OpenFlow rule ID, the NetworkPolicy metadata is filled in from the rule directly.
+func buildL7DenyConnection(event *l7engine.Event, r *rule) *connection.Connection {
+	tuple := connection.Tuple{
+		SourceAddress:      event.SrcIP,
+		DestinationAddress: event.DestIP,
+		SourcePort:         event.SrcPort,
+		DestinationPort:    event.DestPort,
+		Protocol:           event.Protocol,
+	}
+	disposition := openflow.DispositionToString[openflow.DispositionRej]
+	denyConn := &connection.Connection{
+		FlowKey:                    tuple,
+		OriginalDestinationAddress: tuple.DestinationAddress,
+		OriginalDestinationPort:    tuple.DestinationPort,
+		StartTime:                  event.Timestamp,
+		Disposition:                disposition,
+		AppProtocolName:            event.AppProtocol,
+	}
+	if event.HTTP != nil {
+		if httpVals, err := json.Marshal(event.HTTP); err == nil {
+			denyConn.HttpVals = string(httpVals)
+		}
+	}
+	if r.SourceRef == nil {
+		return denyConn
+	}
+	policyType := flowexporterutils.PolicyTypeToUint8(r.SourceRef.Type)
+	ruleAction := flowexporterutils.RuleActionToUint8(disposition)
+	if r.Direction == v1beta.DirectionIn {
+		denyConn.IngressNetworkPolicyName = r.SourceRef.Name
+		denyConn.IngressNetworkPolicyNamespace = r.SourceRef.Namespace
+		denyConn.IngressNetworkPolicyUID = string(r.SourceRef.UID)
+		denyConn.IngressNetworkPolicyType = policyType
+		denyConn.IngressNetworkPolicyRuleName = r.Name
+		denyConn.IngressNetworkPolicyRuleAction = ruleAction
+	} else {
+		denyConn.EgressNetworkPolicyName = r.SourceRef.Name
+		denyConn.EgressNetworkPolicyNamespace = r.SourceRef.Namespace
+		denyConn.EgressNetworkPolicyUID = string(r.SourceRef.UID)
+		denyConn.EgressNetworkPolicyType = policyType
+		denyConn.EgressNetworkPolicyRuleName = r.Name
+		denyConn.EgressNetworkPolicyRuleAction = ruleAction
+	}
+	return denyConn
+}
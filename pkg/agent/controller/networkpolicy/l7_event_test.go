@@ -0,0 +1,217 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"antrea.io/antrea/v2/pkg/agent/controller/networkpolicy/l7engine"
+	"antrea.io/antrea/v2/pkg/agent/flowexporter/connection"
+	flowexporterutils "antrea.io/antrea/v2/pkg/agent/flowexporter/utils"
+	"antrea.io/antrea/v2/pkg/agent/interfacestore"
+	"antrea.io/antrea/v2/pkg/agent/util"
+	"antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+	"antrea.io/antrea/v2/pkg/util/channel"
+)
+
+func TestGetL7EventInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    *l7engine.Event
+		expected string
+	}{
+		{
+			name: "http",
+			event: &l7engine.Event{
+				AppProtocol: "http",
+				HTTP:        &l7engine.HTTPEventInfo{Hostname: "10.10.1.6", URL: "/admin", Method: "GET"},
+			},
+			expected: "http:GET:10.10.1.6/admin",
+		},
+		{
+			name:     "tls",
+			event:    &l7engine.Event{AppProtocol: "tls", TLSSNI: "www.example.com"},
+			expected: "tls:www.example.com",
+		},
+		{
+			name:     "dns",
+			event:    &l7engine.Event{AppProtocol: "dns", DNSQuery: "example.com"},
+			expected: "dns:example.com",
+		},
+		{
+			name:     "without details",
+			event:    &l7engine.Event{AppProtocol: "http2"},
+			expected: "http2",
+		},
+		{
+			name:     "unknown protocol",
+			event:    &l7engine.Event{},
+			expected: nullPlaceholder,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getL7EventInfo(tt.event))
+		})
+	}
+}
+
+func TestHandleL7Event(t *testing.T) {
+	srcIP := netip.MustParseAddr("10.10.1.5")
+	destIP := netip.MustParseAddr("10.10.1.6")
+	event := &l7engine.Event{
+		Timestamp:   time.Now(),
+		RuleID:      "rule1",
+		PolicyName:  testANNPRef.ToString(),
+		Action:      l7engine.EventActionBlocked,
+		SrcIP:       srcIP,
+		SrcPort:     34286,
+		DestIP:      destIP,
+		DestPort:    8080,
+		Protocol:    6,
+		AppProtocol: "http",
+		HTTP:        &l7engine.HTTPEventInfo{Hostname: "10.10.1.6", URL: "/admin", Method: "GET"},
+	}
+	key := connection.Tuple{
+		SourceAddress:      srcIP,
+		DestinationAddress: destIP,
+		SourcePort:         34286,
+		DestinationPort:    8080,
+		Protocol:           6,
+	}
+
+	tests := []struct {
+		name         string
+		rule         *rule
+		event        *l7engine.Event
+		expectedLog  string
+		expectedConn *connection.Connection
+	}{
+		{
+			name: "ingress rule with logging",
+			rule: &rule{
+				ID:            "rule1",
+				Direction:     v1beta2.DirectionIn,
+				Name:          "test-rule",
+				SourceRef:     testANNPRef,
+				EnableLogging: true,
+				LogLabel:      "test-label",
+			},
+			event:       event,
+			expectedLog: "L7Engine AntreaNetworkPolicy:default/test test-rule Ingress Reject <nil> default/destPod 10.10.1.5 34286 10.10.1.6 8080 TCP <nil> test-label http:GET:10.10.1.6/admin",
+			expectedConn: &connection.Connection{
+				FlowKey:                        key,
+				OriginalDestinationAddress:     destIP,
+				OriginalDestinationPort:        8080,
+				StartTime:                      event.Timestamp,
+				Disposition:                    "Reject",
+				AppProtocolName:                "http",
+				HttpVals:                       `{"hostname":"10.10.1.6","url":"/admin","http_method":"GET"}`,
+				IngressNetworkPolicyName:       "test",
+				IngressNetworkPolicyNamespace:  "default",
+				IngressNetworkPolicyType:       flowexporterutils.PolicyTypeAntreaNetworkPolicy,
+				IngressNetworkPolicyRuleName:   "test-rule",
+				IngressNetworkPolicyRuleAction: flowexporterutils.NetworkPolicyRuleActionReject,
+			},
+		},
+		{
+			name: "egress rule without logging",
+			rule: &rule{
+				ID:        "rule1",
+				Direction: v1beta2.DirectionOut,
+				Name:      "test-rule",
+				SourceRef: testANNPRef,
+			},
+			event: event,
+			expectedConn: &connection.Connection{
+				FlowKey:                       key,
+				OriginalDestinationAddress:    destIP,
+				OriginalDestinationPort:       8080,
+				StartTime:                     event.Timestamp,
+				Disposition:                   "Reject",
+				AppProtocolName:               "http",
+				HttpVals:                      `{"hostname":"10.10.1.6","url":"/admin","http_method":"GET"}`,
+				EgressNetworkPolicyName:       "test",
+				EgressNetworkPolicyNamespace:  "default",
+				EgressNetworkPolicyType:       flowexporterutils.PolicyTypeAntreaNetworkPolicy,
+				EgressNetworkPolicyRuleName:   "test-rule",
+				EgressNetworkPolicyRuleAction: flowexporterutils.NetworkPolicyRuleActionReject,
+			},
+		},
+		{
+			name: "unknown rule",
+			rule: &rule{
+				ID:        "rule2",
+				Direction: v1beta2.DirectionIn,
+				SourceRef: testANNPRef,
+			},
+			event: event,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, _, _ := newTestController()
+			controller.ruleCache.rules.Add(tt.rule)
+			ifaceStore := interfacestore.NewInterfaceStore()
+			ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+				InterfaceName:            util.GenerateContainerInterfaceName("destPod", "default", "c2"),
+				IPs:                      []net.IP{destIP.AsSlice()},
+				ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "destPod", PodNamespace: "default", ContainerID: "c2"},
+				OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 2},
+			})
+			controller.ifaceStore = ifaceStore
+			auditLogger, mockLogger := newTestAuditLogger(testBufferLength, clocktesting.NewFakeClock(time.Now()))
+			controller.auditLogger = auditLogger
+
+			updateReceivedCh := make(chan *connection.Connection, 1)
+			connUpdateChannel := channel.NewSubscribableChannel("conn update channel", 100)
+			connUpdateChannel.Subscribe(func(connInterface any) {
+				updateReceivedCh <- connInterface.(*connection.Connection)
+			})
+			go connUpdateChannel.Run(t.Context().Done())
+			controller.denyConnNotifier = connUpdateChannel
+
+			controller.handleL7Event(tt.event)
+
+			if tt.expectedLog != "" {
+				assert.Contains(t, auditLogger.logDeduplication.logMap, tt.expectedLog)
+			} else {
+				assert.Empty(t, auditLogger.logDeduplication.logMap)
+			}
+			assert.Empty(t, mockLogger.logged)
+			if tt.expectedConn != nil {
+				select {
+				case conn := <-updateReceivedCh:
+					assert.Equal(t, tt.expectedConn, conn)
+				case <-time.After(time.Second):
+					require.Fail(t, "connection update channel did not receive the expected connection")
+				}
+			} else {
+				select {
+				case conn := <-updateReceivedCh:
+					assert.Fail(t, "unexpected connection update", "connection", conn)
+				case <-time.After(100 * time.Millisecond):
+				}
+			}
+		})
+	}
+}
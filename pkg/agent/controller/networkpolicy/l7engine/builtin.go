@@ -0,0 +1,644 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l7engine
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	"golang.org/x/crypto/cryptobyte"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	v1beta "antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+	"antrea.io/antrea/v2/pkg/util/ip"
+)
+
+const (
+	// builtinMaxRequestSize is the maximum size of the data buffered for a connection before a verdict is made. It is
+	// large enough for the HTTP request headers of common clients and a TLS record carrying the ClientHello.
+	builtinMaxRequestSize = 32 * 1024
+	// builtinMaxHTTPMethodLength is the maximum length of the method of an HTTP request.
+	builtinMaxHTTPMethodLength = 16
+
+	builtinFlowIdleTimeout = 2 * time.Minute
+	builtinFlowGCInterval  = 30 * time.Second
+	builtinReadRetryDelay  = time.Second
+
+	tlsRecordTypeHandshake      = 0x16
+	tlsRecordHeaderLength       = 5
+	tlsHandshakeTypeClientHello = 0x01
+	tlsClientHelloRandomLength  = 32
+	tlsExtensionServerName      = 0x0000
+	tlsServerNameTypeHostName   = 0x00
+)
+
+// packetIO reads the packets redirected to the builtin engine by OVS, and sends the packets back to OVS.
+type packetIO interface {
+	// ReadPacket returns an Ethernet frame without the VLAN tag, and the VLAN ID it was received with.
+	ReadPacket() ([]byte, uint16, error)
+	// WritePacket sends an Ethernet frame tagged with the VLAN ID.
+	WritePacket(data []byte, vlanID uint16) error
+	Close() error
+}
+
+type builtinVerdict int
+
+const (
+	builtinVerdictPending builtinVerdict = iota
+	builtinVerdictPass
+	builtinVerdictReject
+)
+
+// builtinRule is an L7 NetworkPolicy rule compiled by the builtin engine. Like the Suricata rules generated by
+// suricataEngine, it allows the connections whose first request matches any of the HTTP or TLS matchers, and rejects
+// the other connections.
+type builtinRule struct {
+	policyName string
+	http       []*httpMatcher
+	// tlsSNIs are the SNI patterns of the TLS protocols. An empty pattern matches any SNI.
+	tlsSNIs []string
+}
+
+type httpMatcher struct {
+	*v1beta.HTTPProtocol
+	pathRegex *regexp.Regexp
+}
+
+// builtinFlowKey identifies a TCP connection redirected for a rule. The source is the client of the connection.
+type builtinFlowKey struct {
+	vlanID            uint16
+	srcIP, destIP     netip.Addr
+	srcPort, destPort uint16
+}
+
+func (k builtinFlowKey) reverse() builtinFlowKey {
+	return builtinFlowKey{vlanID: k.vlanID, srcIP: k.destIP, destIP: k.srcIP, srcPort: k.destPort, destPort: k.srcPort}
+}
+
+// builtinFlow is the state of a TCP connection tracked by the builtin engine. The packets sent by the client are
+// held until the first request of the connection has been received and a verdict has been made on it, while the
+// packets sent by the server are forwarded unless the connection is rejected.
+type builtinFlow struct {
+	key      builtinFlowKey
+	verdict  builtinVerdict
+	lastSeen time.Time
+
+	// nextSeq is the sequence number of the next byte expected from the client.
+	nextSeq uint32
+	// bufferSeq is the sequence number of the first byte in buffer.
+	bufferSeq uint32
+	buffer    []byte
+	held      [][]byte
+	// clientAck is the last acknowledgment number sent by the client, i.e. the sequence number of the next byte
+	// expected from the server.
+	clientAck uint32
+	// clientMAC and serverMAC are the MAC addresses of the last frame sent by the client.
+	clientMAC, serverMAC net.HardwareAddr
+}
+
+// builtinInspection is the result of the inspection of the first request of a connection.
+type builtinInspection struct {
+	verdict     builtinVerdict
+	appProtocol string
+	http        *HTTPEventInfo
+	tlsSNI      string
+}
+
+// builtinEngine is a lightweight L7 engine running in antrea-agent. It supports HTTP and TLS SNI matching of TCP
+// connections, and drops the other traffic redirected to it.
+type builtinEngine struct {
+	// Declared as a member variable for testing.
+	newPacketIOFn func() (packetIO, error)
+	clock         clock.Clock
+
+	rulesMutex sync.RWMutex
+	rules      map[uint16]*builtinRule
+
+	mutex       sync.RWMutex
+	packetIO    packetIO
+	handleAlert AlertHandler
+	stopped     bool
+	// readErr is the last error when reading packets, and is reset after a packet is read successfully.
+	readErr error
+
+	// flows and lastGC are only accessed by the goroutine processing the packets.
+	flows  map[builtinFlowKey]*builtinFlow
+	lastGC time.Time
+}
+
+func newBuiltinEngine() *builtinEngine {
+	return &builtinEngine{
+		newPacketIOFn: newPacketIO,
+		clock:         clock.RealClock{},
+		rules:         make(map[uint16]*builtinRule),
+		flows:         make(map[builtinFlowKey]*builtinFlow),
+	}
+}
+
+func (e *builtinEngine) Start() error {
+	pio, err := e.newPacketIOFn()
+	if err != nil {
+		return fmt.Errorf("failed to open the L7 redirect ports for the builtin L7 engine: %w", err)
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.packetIO = pio
+	go e.processPackets(pio)
+	klog.InfoS("Started builtin L7 engine successfully")
+	return nil
+}
+
+func (e *builtinEngine) AddRule(ruleID, policyName string, vlanID uint32, l7Protocols []v1beta.L7Protocol) error {
+	rule := &builtinRule{policyName: policyName}
+	for _, protocol := range l7Protocols {
+		switch {
+		case protocol.HTTP != nil:
+			matcher := &httpMatcher{HTTPProtocol: protocol.HTTP}
+			if protocol.HTTP.PathRegex != "" {
+				// Like in the Suricata rules, the pattern must match the whole path.
+				pathRegex, err := regexp.Compile("^(?:" + protocol.HTTP.PathRegex + ")$")
+				if err != nil {
+					return fmt.Errorf("invalid pathRegex %s in L7 rule %s of %s: %w", protocol.HTTP.PathRegex, ruleID, policyName, err)
+				}
+				matcher.pathRegex = pathRegex
+			}
+			rule.http = append(rule.http, matcher)
+		case protocol.TLS != nil:
+			rule.tlsSNIs = append(rule.tlsSNIs, protocol.TLS.SNI)
+		default:
+			return fmt.Errorf("L7 rule %s of %s has protocols which are not supported by the builtin L7 engine, only HTTP and TLS are supported", ruleID, policyName)
+		}
+	}
+	e.rulesMutex.Lock()
+	defer e.rulesMutex.Unlock()
+	e.rules[uint16(vlanID)] = rule
+	return nil
+}
+
+func (e *builtinEngine) DeleteRule(ruleID string, vlanID uint32) error {
+	e.rulesMutex.Lock()
+	defer e.rulesMutex.Unlock()
+	delete(e.rules, uint16(vlanID))
+	return nil
+}
+
+// Health returns the last error when reading the packets redirected to the engine.
+func (e *builtinEngine) Health() error {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.readErr
+}
+
+// Run passes the alerts generated for the rejected connections to handleAlert until stopCh is closed, then stops
+// processing the packets.
+func (e *builtinEngine) Run(stopCh <-chan struct{}, handleAlert AlertHandler) {
+	e.mutex.Lock()
+	e.handleAlert = handleAlert
+	e.mutex.Unlock()
+
+	<-stopCh
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.stopped = true
+	if e.packetIO != nil {
+		e.packetIO.Close()
+	}
+}
+
+func (e *builtinEngine) getRule(vlanID uint16) *builtinRule {
+	e.rulesMutex.RLock()
+	defer e.rulesMutex.RUnlock()
+	return e.rules[vlanID]
+}
+
+func (e *builtinEngine) processPackets(pio packetIO) {
+	for {
+		data, vlanID, err := pio.ReadPacket()
+		e.mutex.Lock()
+		stopped := e.stopped
+		e.readErr = err
+		e.mutex.Unlock()
+		if stopped {
+			return
+		}
+		if err != nil {
+			klog.ErrorS(err, "Failed to read packet redirected to builtin L7 engine")
+			time.Sleep(builtinReadRetryDelay)
+			continue
+		}
+		e.processPacket(pio, data, vlanID)
+	}
+}
+
+func (e *builtinEngine) processPacket(pio packetIO, data []byte, vlanID uint16) {
+	now := e.clock.Now()
+	if now.Sub(e.lastGC) >= builtinFlowGCInterval {
+		e.gcFlows(now)
+	}
+
+	rule := e.getRule(vlanID)
+	if rule == nil {
+		// The rule has been deleted, or the packet is not redirected by OVS.
+		return
+	}
+	packet := gopacket.NewPacket(data, layers.LayerTypeEthernet, gopacket.DecodeOptions{Lazy: true, NoCopy: true})
+	eth, _ := packet.LinkLayer().(*layers.Ethernet)
+	tcp, _ := packet.TransportLayer().(*layers.TCP)
+	if eth == nil || tcp == nil {
+		// Only TCP is supported.
+		return
+	}
+	var srcIP, destIP netip.Addr
+	switch l := packet.NetworkLayer().(type) {
+	case *layers.IPv4:
+		srcIP, _ = netip.AddrFromSlice(l.SrcIP.To4())
+		destIP, _ = netip.AddrFromSlice(l.DstIP.To4())
+	case *layers.IPv6:
+		srcIP, _ = netip.AddrFromSlice(l.SrcIP)
+		destIP, _ = netip.AddrFromSlice(l.DstIP)
+	default:
+		return
+	}
+
+	key := builtinFlowKey{vlanID: vlanID, srcIP: srcIP, destIP: destIP, srcPort: uint16(tcp.SrcPort), destPort: uint16(tcp.DstPort)}
+	fromClient := true
+	flow, ok := e.flows[key]
+	if !ok {
+		flow, ok = e.flows[key.reverse()]
+		fromClient = false
+	}
+	if !ok {
+		// The client of a connection is the sender of the SYN, or the sender of the first payload if the handshake
+		// has not been seen, e.g. when the connection was established before antrea-agent restarted.
+		if !(tcp.SYN && !tcp.ACK) && len(tcp.Payload) == 0 {
+			pio.WritePacket(data, vlanID)
+			return
+		}
+		flow = &builtinFlow{key: key, nextSeq: tcp.Seq}
+		e.flows[key] = flow
+		fromClient = true
+	}
+	flow.lastSeen = now
+
+	if tcp.RST {
+		if flow.verdict != builtinVerdictReject {
+			pio.WritePacket(data, vlanID)
+		}
+		delete(e.flows, flow.key)
+		return
+	}
+	switch {
+	case flow.verdict == builtinVerdictReject:
+		return
+	case !fromClient || flow.verdict == builtinVerdictPass:
+		pio.WritePacket(data, vlanID)
+		return
+	case tcp.SYN:
+		flow.nextSeq = tcp.Seq + 1
+		pio.WritePacket(data, vlanID)
+		return
+	case len(tcp.Payload) == 0 && (!tcp.FIN || len(flow.held) == 0):
+		// The FIN must be held if there is held data before it.
+		pio.WritePacket(data, vlanID)
+		return
+	}
+	if tcp.Seq != flow.nextSeq {
+		// The retransmitted segments are dropped as the original segments are held, and the out-of-order segments
+		// are dropped and will be retransmitted by the client.
+		return
+	}
+	if len(flow.buffer) == 0 {
+		flow.bufferSeq = tcp.Seq
+	}
+	flow.nextSeq += uint32(len(tcp.Payload))
+	if tcp.FIN {
+		flow.nextSeq++
+	}
+	if tcp.ACK {
+		flow.clientAck = tcp.Ack
+	}
+	flow.clientMAC, flow.serverMAC = eth.SrcMAC, eth.DstMAC
+	flow.buffer = append(flow.buffer, tcp.Payload...)
+	flow.held = append(flow.held, data)
+
+	inspection := rule.inspect(flow.buffer, tcp.FIN)
+	switch inspection.verdict {
+	case builtinVerdictPending:
+		return
+	case builtinVerdictPass:
+		for _, heldData := range flow.held {
+			pio.WritePacket(heldData, vlanID)
+		}
+	case builtinVerdictReject:
+		e.rejectFlow(pio, flow, rule, inspection, now)
+	}
+	flow.verdict = inspection.verdict
+	flow.buffer, flow.held = nil, nil
+}
+
+// rejectFlow resets the connection in both directions, and generates an alert for it.
+func (e *builtinEngine) rejectFlow(pio packetIO, flow *builtinFlow, rule *builtinRule, inspection *builtinInspection, now time.Time) {
+	key := flow.key
+	// The held data has not been sent to the server, so the RST sent to the server starts from the first byte of it.
+	if data, err := buildTCPReset(flow.clientMAC, flow.serverMAC, key.srcIP, key.destIP, key.srcPort, key.destPort, flow.bufferSeq, 0); err == nil {
+		pio.WritePacket(data, key.vlanID)
+	} else {
+		klog.ErrorS(err, "Failed to build TCP RST packet", "source", key.srcIP, "destination", key.destIP)
+	}
+	if data, err := buildTCPReset(flow.serverMAC, flow.clientMAC, key.destIP, key.srcIP, key.destPort, key.srcPort, flow.clientAck, flow.nextSeq); err == nil {
+		pio.WritePacket(data, key.vlanID)
+	} else {
+		klog.ErrorS(err, "Failed to build TCP RST packet", "source", key.destIP, "destination", key.srcIP)
+	}
+
+	e.mutex.RLock()
+	handleAlert := e.handleAlert
+	e.mutex.RUnlock()
+	if handleAlert == nil {
+		return
+	}
+	handleAlert(uint32(key.vlanID), &Event{
+		Timestamp:   now,
+		Action:      EventActionBlocked,
+		Signature:   fmt.Sprintf("Reject by %s", rule.policyName),
+		SrcIP:       key.srcIP,
+		SrcPort:     key.srcPort,
+		DestIP:      key.destIP,
+		DestPort:    key.destPort,
+		Protocol:    ip.TCPProtocol,
+		AppProtocol: inspection.appProtocol,
+		HTTP:        inspection.http,
+		TLSSNI:      inspection.tlsSNI,
+	})
+}
+
+func (e *builtinEngine) gcFlows(now time.Time) {
+	for key, flow := range e.flows {
+		if now.Sub(flow.lastSeen) >= builtinFlowIdleTimeout {
+			delete(e.flows, key)
+		}
+	}
+	e.lastGC = now
+}
+
+// buildTCPReset builds a TCP RST packet. The ACK flag is set if ack is not 0.
+func buildTCPReset(srcMAC, dstMAC net.HardwareAddr, srcIP, destIP netip.Addr, srcPort, dstPort uint16, seq, ack uint32) ([]byte, error) {
+	eth := &layers.Ethernet{SrcMAC: srcMAC, DstMAC: dstMAC}
+	tcp := &layers.TCP{
+		SrcPort: layers.TCPPort(srcPort),
+		DstPort: layers.TCPPort(dstPort),
+		Seq:     seq,
+		Ack:     ack,
+		RST:     true,
+		ACK:     ack != 0,
+	}
+	var network gopacket.NetworkLayer
+	if srcIP.Is4() {
+		eth.EthernetType = layers.EthernetTypeIPv4
+		network = &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolTCP, SrcIP: srcIP.AsSlice(), DstIP: destIP.AsSlice()}
+	} else {
+		eth.EthernetType = layers.EthernetTypeIPv6
+		network = &layers.IPv6{Version: 6, HopLimit: 64, NextHeader: layers.IPProtocolTCP, SrcIP: srcIP.AsSlice(), DstIP: destIP.AsSlice()}
+	}
+	if err := tcp.SetNetworkLayerForChecksum(network); err != nil {
+		return nil, err
+	}
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, eth, network.(gopacket.SerializableLayer), tcp); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// inspect makes a verdict on the data sent by the client of a connection. final indicates whether the client will
+// not send more data.
+func (r *builtinRule) inspect(data []byte, final bool) *builtinInspection {
+	inspection := &builtinInspection{verdict: builtinVerdictReject}
+	needMore := !final && len(data) <= builtinMaxRequestSize
+	switch {
+	case data[0] == tlsRecordTypeHandshake:
+		inspection.appProtocol = protocolTLS
+		sni, complete, err := parseTLSClientHelloSNI(data)
+		if !complete {
+			if needMore {
+				inspection.verdict = builtinVerdictPending
+			}
+			return inspection
+		}
+		if err != nil {
+			klog.V(4).InfoS("Failed to parse TLS ClientHello", "err", err)
+			return inspection
+		}
+		inspection.tlsSNI = sni
+		for _, pattern := range r.tlsSNIs {
+			if pattern == "" || matchContent(sni, pattern) {
+				inspection.verdict = builtinVerdictPass
+				break
+			}
+		}
+	default:
+		isHTTP, complete := isHTTPRequestPrefix(data)
+		if !isHTTP {
+			return inspection
+		}
+		inspection.appProtocol = protocolHTTP
+		end := bytes.Index(data, []byte("\r\n\r\n"))
+		if !complete || end < 0 {
+			if needMore {
+				inspection.verdict = builtinVerdictPending
+			}
+			return inspection
+		}
+		req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(data[:end+4])))
+		if err != nil {
+			klog.V(4).InfoS("Failed to parse HTTP request", "err", err)
+			return inspection
+		}
+		host := strings.ToLower(req.Host)
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		inspection.http = &HTTPEventInfo{Hostname: host, URL: req.RequestURI, Method: req.Method, Protocol: req.Proto}
+		for _, matcher := range r.http {
+			if matcher.match(req, host) {
+				inspection.verdict = builtinVerdictPass
+				break
+			}
+		}
+	}
+	return inspection
+}
+
+// isHTTPRequestPrefix returns whether data can be the beginning of an HTTP/1.x request, and whether data is long
+// enough to tell it.
+func isHTTPRequestPrefix(data []byte) (bool, bool) {
+	for i, c := range data {
+		if c == ' ' {
+			return i > 0, true
+		}
+		if c < 'A' || c > 'Z' || i >= builtinMaxHTTPMethodLength {
+			return false, true
+		}
+	}
+	return true, false
+}
+
+// match returns whether an HTTP request matches all the fields of the HTTPProtocol. host is the lowercase hostname of
+// the request without the port. Like the Suricata rules, Path is matched against the request URI, which includes the
+// query, while PathRegex is matched against the path only.
+func (m *httpMatcher) match(req *http.Request, host string) bool {
+	if m.Method != "" && req.Method != m.Method {
+		return false
+	}
+	if m.Host != "" && !matchContent(host, m.Host) {
+		return false
+	}
+	if m.Path != "" && !matchContent(req.RequestURI, m.Path) {
+		return false
+	}
+	if m.pathRegex != nil {
+		path, _, _ := strings.Cut(req.RequestURI, "?")
+		if !m.pathRegex.MatchString(path) {
+			return false
+		}
+	}
+	for _, header := range m.Headers {
+		if !matchHeader(req.Header.Values(header.Name), header.Value) {
+			return false
+		}
+	}
+	for _, param := range m.QueryParams {
+		if !matchQueryParam(req.URL.RawQuery, param.Name, param.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchHeader(values []string, pattern string) bool {
+	for _, value := range values {
+		if pattern == "" || matchContent(value, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchQueryParam matches the query parameters without decoding them, consistent with the Suricata rules.
+func matchQueryParam(rawQuery, name, pattern string) bool {
+	for _, param := range strings.Split(rawQuery, "&") {
+		paramName, value, _ := strings.Cut(param, "=")
+		if paramName == name && (pattern == "" || matchContent(value, pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchContent matches value with the pattern using the same wildcard semantics as convertContent.
+func matchContent(value, pattern string) bool {
+	prefixWildcard := strings.HasPrefix(pattern, "*")
+	if prefixWildcard {
+		pattern = pattern[1:]
+	}
+	suffixWildcard := strings.HasSuffix(pattern, "*")
+	if suffixWildcard {
+		pattern = pattern[:len(pattern)-1]
+	}
+	switch {
+	case prefixWildcard && suffixWildcard:
+		return strings.Contains(value, pattern)
+	case prefixWildcard:
+		return strings.HasSuffix(value, pattern)
+	case suffixWildcard:
+		return strings.HasPrefix(value, pattern)
+	default:
+		return value == pattern
+	}
+}
+
+// parseTLSClientHelloSNI returns the SNI of the TLS ClientHello at the beginning of data, and whether the first TLS
+// record has been received completely. The ClientHello is expected to be in the first TLS record.
+func parseTLSClientHelloSNI(data []byte) (string, bool, error) {
+	if len(data) < tlsRecordHeaderLength {
+		return "", false, nil
+	}
+	input := cryptobyte.String(data[1:])
+	var version uint16
+	var record cryptobyte.String
+	if !input.ReadUint16(&version) || !input.ReadUint16LengthPrefixed(&record) {
+		return "", false, nil
+	}
+	var handshakeType uint8
+	var clientHello cryptobyte.String
+	if !record.ReadUint8(&handshakeType) || handshakeType != tlsHandshakeTypeClientHello {
+		return "", true, fmt.Errorf("not a TLS ClientHello")
+	}
+	if !record.ReadUint24LengthPrefixed(&clientHello) {
+		return "", true, fmt.Errorf("TLS ClientHello is not in a single record")
+	}
+	var sessionID, cipherSuites, compressionMethods, extensions cryptobyte.String
+	if !clientHello.Skip(2+tlsClientHelloRandomLength) ||
+		!clientHello.ReadUint8LengthPrefixed(&sessionID) ||
+		!clientHello.ReadUint16LengthPrefixed(&cipherSuites) ||
+		!clientHello.ReadUint8LengthPrefixed(&compressionMethods) {
+		return "", true, fmt.Errorf("malformed TLS ClientHello")
+	}
+	if clientHello.Empty() {
+		// No extensions.
+		return "", true, nil
+	}
+	if !clientHello.ReadUint16LengthPrefixed(&extensions) {
+		return "", true, fmt.Errorf("malformed TLS ClientHello extensions")
+	}
+	for !extensions.Empty() {
+		var extensionType uint16
+		var extension cryptobyte.String
+		if !extensions.ReadUint16(&extensionType) || !extensions.ReadUint16LengthPrefixed(&extension) {
+			return "", true, fmt.Errorf("malformed TLS ClientHello extensions")
+		}
+		if extensionType != tlsExtensionServerName {
+			continue
+		}
+		var serverNames cryptobyte.String
+		if !extension.ReadUint16LengthPrefixed(&serverNames) {
+			return "", true, fmt.Errorf("malformed TLS server name extension")
+		}
+		for !serverNames.Empty() {
+			var nameType uint8
+			var name cryptobyte.String
+			if !serverNames.ReadUint8(&nameType) || !serverNames.ReadUint16LengthPrefixed(&name) {
+				return "", true, fmt.Errorf("malformed TLS server name extension")
+			}
+			if nameType == tlsServerNameTypeHostName {
+				return string(name), true, nil
+			}
+		}
+	}
+	return "", true, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l7engine
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/gopacket/gopacket/layers"
+	"github.com/gopacket/gopacket/pcapgo"
+	"golang.org/x/sys/unix"
+
+	"antrea.io/antrea/v2/pkg/agent/config"
+)
+
+const (
+	// maxFrameSize is large enough for the frames of the maximum MTU supported by the L7 redirect ports.
+	maxFrameSize = 65536
+
+	dot1QHeaderLength = 4
+	vlanIDMask        = 0x0fff
+)
+
+// afPacketIO reads the packets from config.L7RedirectTargetPortName and sends the packets to
+// config.L7RedirectReturnPortName with AF_PACKET sockets.
+type afPacketIO struct {
+	readHandle *pcapgo.EthernetHandle
+	writeFD    int
+	writeAddr  *unix.SockaddrLinklayer
+}
+
+func newPacketIO() (packetIO, error) {
+	readHandle, err := pcapgo.NewEthernetHandle(config.L7RedirectTargetPortName)
+	if err != nil {
+		return nil, err
+	}
+	// The packets redirected by OVS are not destined to the MAC address of the port.
+	if err := readHandle.SetPromiscuous(true); err != nil {
+		readHandle.Close()
+		return nil, err
+	}
+	if err := readHandle.SetCaptureLength(maxFrameSize); err != nil {
+		readHandle.Close()
+		return nil, err
+	}
+	returnPort, err := net.InterfaceByName(config.L7RedirectReturnPortName)
+	if err != nil {
+		readHandle.Close()
+		return nil, err
+	}
+	// The protocol is 0 so that the socket only sends packets.
+	writeFD, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		readHandle.Close()
+		return nil, fmt.Errorf("failed to open packet socket: %w", err)
+	}
+	return &afPacketIO{
+		readHandle: readHandle,
+		writeFD:    writeFD,
+		writeAddr:  &unix.SockaddrLinklayer{Ifindex: returnPort.Index},
+	}, nil
+}
+
+func (p *afPacketIO) ReadPacket() ([]byte, uint16, error) {
+	data, ci, err := p.readHandle.ReadPacketData()
+	if err != nil {
+		return nil, 0, err
+	}
+	// The VLAN tag is usually stripped by the kernel and provided in the auxiliary data.
+	if len(ci.AncillaryData) > 0 {
+		if tci, ok := ci.AncillaryData[0].(int); ok {
+			return data, uint16(tci) & vlanIDMask, nil
+		}
+	}
+	if len(data) >= 12+dot1QHeaderLength && binary.BigEndian.Uint16(data[12:14]) == uint16(layers.EthernetTypeDot1Q) {
+		vlanID := binary.BigEndian.Uint16(data[14:16]) & vlanIDMask
+		return append(data[:12], data[12+dot1QHeaderLength:]...), vlanID, nil
+	}
+	return data, 0, nil
+}
+
+func (p *afPacketIO) WritePacket(data []byte, vlanID uint16) error {
+	if len(data) < 12 {
+		return fmt.Errorf("invalid Ethernet frame")
+	}
+	frame := make([]byte, len(data)+dot1QHeaderLength)
+	copy(frame, data[:12])
+	binary.BigEndian.PutUint16(frame[12:14], uint16(layers.EthernetTypeDot1Q))
+	binary.BigEndian.PutUint16(frame[14:16], vlanID&vlanIDMask)
+	copy(frame[16:], data[12:])
+	return unix.Sendto(p.writeFD, frame, 0, p.writeAddr)
+}
+
+func (p *afPacketIO) Close() error {
+	unix.Close(p.writeFD)
+	return p.readHandle.Close()
+}
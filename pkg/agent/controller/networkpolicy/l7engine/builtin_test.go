@@ -0,0 +1,501 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l7engine
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	v1beta "antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+)
+
+var (
+	testClientMAC, _ = net.ParseMAC("aa:bb:cc:dd:ee:01")
+	testServerMAC, _ = net.ParseMAC("aa:bb:cc:dd:ee:02")
+	testClientIP     = netip.MustParseAddr("10.10.1.5")
+	testServerIP     = netip.MustParseAddr("10.10.1.6")
+)
+
+const (
+	testClientPort = 34286
+	testServerPort = 8080
+	testVLANID     = 1
+)
+
+type fakePacket struct {
+	data   []byte
+	vlanID uint16
+}
+
+type fakePacketIO struct {
+	mutex   sync.Mutex
+	packets chan fakePacket
+	written []fakePacket
+	closed  bool
+}
+
+func newFakePacketIO() *fakePacketIO {
+	return &fakePacketIO{packets: make(chan fakePacket, 10)}
+}
+
+func (f *fakePacketIO) ReadPacket() ([]byte, uint16, error) {
+	p, ok := <-f.packets
+	if !ok {
+		return nil, 0, io.EOF
+	}
+	return p.data, p.vlanID, nil
+}
+
+func (f *fakePacketIO) WritePacket(data []byte, vlanID uint16) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.written = append(f.written, fakePacket{data: data, vlanID: vlanID})
+	return nil
+}
+
+func (f *fakePacketIO) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if !f.closed {
+		f.closed = true
+		close(f.packets)
+	}
+	return nil
+}
+
+func (f *fakePacketIO) getWritten() []fakePacket {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	written := f.written
+	f.written = nil
+	return written
+}
+
+// newTestTCPPacket returns an Ethernet frame of a TCP packet of the test connection. fromClient indicates whether the
+// packet is sent by the client.
+func newTestTCPPacket(t *testing.T, fromClient bool, tcp *layers.TCP, payload string) []byte {
+	eth := &layers.Ethernet{SrcMAC: testClientMAC, DstMAC: testServerMAC, EthernetType: layers.EthernetTypeIPv4}
+	ipv4 := &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolTCP, SrcIP: testClientIP.AsSlice(), DstIP: testServerIP.AsSlice()}
+	tcp.SrcPort, tcp.DstPort = testClientPort, testServerPort
+	if !fromClient {
+		eth.SrcMAC, eth.DstMAC = eth.DstMAC, eth.SrcMAC
+		ipv4.SrcIP, ipv4.DstIP = ipv4.DstIP, ipv4.SrcIP
+		tcp.SrcPort, tcp.DstPort = tcp.DstPort, tcp.SrcPort
+	}
+	require.NoError(t, tcp.SetNetworkLayerForChecksum(ipv4))
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, eth, ipv4, tcp, gopacket.Payload(payload)))
+	return buf.Bytes()
+}
+
+func decodeTestTCPPacket(t *testing.T, data []byte) (*layers.IPv4, *layers.TCP) {
+	packet := gopacket.NewPacket(data, layers.LayerTypeEthernet, gopacket.Default)
+	ipv4, ok := packet.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+	require.True(t, ok)
+	tcp, ok := packet.Layer(layers.LayerTypeTCP).(*layers.TCP)
+	require.True(t, ok)
+	return ipv4, tcp
+}
+
+// generateTLSClientHello returns the first TLS record sent by a TLS client, which carries the ClientHello.
+func generateTLSClientHello(t *testing.T, serverName string) []byte {
+	clientConn, serverConn := net.Pipe()
+	defer serverConn.Close()
+	go func() {
+		defer clientConn.Close()
+		tls.Client(clientConn, &tls.Config{ServerName: serverName, InsecureSkipVerify: true}).Handshake()
+	}()
+	header := make([]byte, tlsRecordHeaderLength)
+	_, err := io.ReadFull(serverConn, header)
+	require.NoError(t, err)
+	record := make([]byte, int(header[3])<<8|int(header[4]))
+	_, err = io.ReadFull(serverConn, record)
+	require.NoError(t, err)
+	return append(header, record...)
+}
+
+func TestMatchContent(t *testing.T) {
+	tests := []struct {
+		value    string
+		pattern  string
+		expected bool
+	}{
+		{value: "/index.html", pattern: "/index.html", expected: true},
+		{value: "/index.html?a=1", pattern: "/index.html", expected: false},
+		{value: "/public/index.html", pattern: "/public/*", expected: true},
+		{value: "/private/index.html", pattern: "/public/*", expected: false},
+		{value: "www.foo.com", pattern: "*.foo.com", expected: true},
+		{value: "www.bar.com", pattern: "*.foo.com", expected: false},
+		{value: "/api/v2/pods", pattern: "*/v2/*", expected: true},
+		{value: "/api/v1/pods", pattern: "*/v2/*", expected: false},
+		{value: "anything", pattern: "*", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s-%s", tt.value, tt.pattern), func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchContent(tt.value, tt.pattern))
+		})
+	}
+}
+
+func TestBuiltinRuleInspectHTTP(t *testing.T) {
+	tests := []struct {
+		name            string
+		http            *v1beta.HTTPProtocol
+		request         string
+		final           bool
+		expectedVerdict builtinVerdict
+		expectedHTTP    *HTTPEventInfo
+	}{
+		{
+			name:            "any request",
+			http:            &v1beta.HTTPProtocol{},
+			request:         "GET /admin HTTP/1.1\r\nHost: 10.10.1.6:8080\r\n\r\n",
+			expectedVerdict: builtinVerdictPass,
+			expectedHTTP:    &HTTPEventInfo{Hostname: "10.10.1.6", URL: "/admin", Method: "GET", Protocol: "HTTP/1.1"},
+		},
+		{
+			name:            "matched host, method and path",
+			http:            &v1beta.HTTPProtocol{Host: "*.foo.com", Method: "GET", Path: "/public/*"},
+			request:         "GET /public/index.html HTTP/1.1\r\nHost: WWW.foo.com\r\n\r\n",
+			expectedVerdict: builtinVerdictPass,
+			expectedHTTP:    &HTTPEventInfo{Hostname: "www.foo.com", URL: "/public/index.html", Method: "GET", Protocol: "HTTP/1.1"},
+		},
+		{
+			name:            "unmatched method",
+			http:            &v1beta.HTTPProtocol{Host: "*.foo.com", Method: "GET", Path: "/public/*"},
+			request:         "POST /public/index.html HTTP/1.1\r\nHost: www.foo.com\r\n\r\n",
+			expectedVerdict: builtinVerdictReject,
+			expectedHTTP:    &HTTPEventInfo{Hostname: "www.foo.com", URL: "/public/index.html", Method: "POST", Protocol: "HTTP/1.1"},
+		},
+		{
+			name:            "matched path regex",
+			http:            &v1beta.HTTPProtocol{PathRegex: "^/api/v[0-9]+/users$"},
+			request:         "GET /api/v1/users HTTP/1.1\r\nHost: foo\r\n\r\n",
+			expectedVerdict: builtinVerdictPass,
+			expectedHTTP:    &HTTPEventInfo{Hostname: "foo", URL: "/api/v1/users", Method: "GET", Protocol: "HTTP/1.1"},
+		},
+		{
+			name:            "unmatched path regex",
+			http:            &v1beta.HTTPProtocol{PathRegex: "^/api/v[0-9]+/users$"},
+			request:         "GET /api/v1/users/admin HTTP/1.1\r\nHost: foo\r\n\r\n",
+			expectedVerdict: builtinVerdictReject,
+			expectedHTTP:    &HTTPEventInfo{Hostname: "foo", URL: "/api/v1/users/admin", Method: "GET", Protocol: "HTTP/1.1"},
+		},
+		{
+			name:            "matched path regex with query",
+			http:            &v1beta.HTTPProtocol{PathRegex: "^/api/v[0-9]+/users$"},
+			request:         "GET /api/v1/users?limit=10 HTTP/1.1\r\nHost: foo\r\n\r\n",
+			expectedVerdict: builtinVerdictPass,
+			expectedHTTP:    &HTTPEventInfo{Hostname: "foo", URL: "/api/v1/users?limit=10", Method: "GET", Protocol: "HTTP/1.1"},
+		},
+		{
+			name:            "unanchored path regex matches the whole path",
+			http:            &v1beta.HTTPProtocol{PathRegex: "/public"},
+			request:         "GET /private?redirect=/public HTTP/1.1\r\nHost: foo\r\n\r\n",
+			expectedVerdict: builtinVerdictReject,
+			expectedHTTP:    &HTTPEventInfo{Hostname: "foo", URL: "/private?redirect=/public", Method: "GET", Protocol: "HTTP/1.1"},
+		},
+		{
+			name: "matched headers and query parameters",
+			http: &v1beta.HTTPProtocol{
+				Headers:     []v1beta.HTTPHeaderMatch{{Name: "x-api-version", Value: "v2*"}, {Name: "Authorization"}},
+				QueryParams: []v1beta.HTTPQueryParamMatch{{Name: "debug"}, {Name: "user", Value: "*admin"}},
+			},
+			request:         "GET /?user=sysadmin&debug HTTP/1.1\r\nHost: foo\r\nX-API-Version: v2.1\r\nAuthorization: Bearer token\r\n\r\n",
+			expectedVerdict: builtinVerdictPass,
+			expectedHTTP:    &HTTPEventInfo{Hostname: "foo", URL: "/?user=sysadmin&debug", Method: "GET", Protocol: "HTTP/1.1"},
+		},
+		{
+			name: "unmatched header",
+			http: &v1beta.HTTPProtocol{
+				Headers: []v1beta.HTTPHeaderMatch{{Name: "X-API-Version", Value: "v2*"}},
+			},
+			request:         "GET / HTTP/1.1\r\nHost: foo\r\nX-API-Version: v1\r\n\r\n",
+			expectedVerdict: builtinVerdictReject,
+			expectedHTTP:    &HTTPEventInfo{Hostname: "foo", URL: "/", Method: "GET", Protocol: "HTTP/1.1"},
+		},
+		{
+			name: "unmatched query parameter",
+			http: &v1beta.HTTPProtocol{
+				QueryParams: []v1beta.HTTPQueryParamMatch{{Name: "user", Value: "admin"}},
+			},
+			request:         "GET /?username=admin HTTP/1.1\r\nHost: foo\r\n\r\n",
+			expectedVerdict: builtinVerdictReject,
+			expectedHTTP:    &HTTPEventInfo{Hostname: "foo", URL: "/?username=admin", Method: "GET", Protocol: "HTTP/1.1"},
+		},
+		{
+			name:            "incomplete request",
+			http:            &v1beta.HTTPProtocol{},
+			request:         "GET /admin HTTP/1.1\r\nHost: foo\r\n",
+			expectedVerdict: builtinVerdictPending,
+		},
+		{
+			name:            "incomplete method",
+			http:            &v1beta.HTTPProtocol{},
+			request:         "GE",
+			expectedVerdict: builtinVerdictPending,
+		},
+		{
+			name:            "incomplete request closed by client",
+			http:            &v1beta.HTTPProtocol{},
+			request:         "GET /admin HTTP/1.1\r\nHost: foo\r\n",
+			final:           true,
+			expectedVerdict: builtinVerdictReject,
+		},
+		{
+			name:            "not HTTP",
+			http:            &v1beta.HTTPProtocol{},
+			request:         "SSH-2.0-OpenSSH_9.6\r\n",
+			expectedVerdict: builtinVerdictReject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newBuiltinEngine()
+			require.NoError(t, e.AddRule("rule1", "AntreaNetworkPolicy:default/test-l7", testVLANID, []v1beta.L7Protocol{{HTTP: tt.http}}))
+			inspection := e.getRule(testVLANID).inspect([]byte(tt.request), tt.final)
+			assert.Equal(t, tt.expectedVerdict, inspection.verdict)
+			assert.Equal(t, tt.expectedHTTP, inspection.http)
+		})
+	}
+}
+
+func TestBuiltinRuleInspectTLS(t *testing.T) {
+	clientHello := generateTLSClientHello(t, "www.foo.com")
+	sni, complete, err := parseTLSClientHelloSNI(clientHello)
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, "www.foo.com", sni)
+
+	tests := []struct {
+		name            string
+		sni             string
+		data            []byte
+		expectedVerdict builtinVerdict
+		expectedSNI     string
+	}{
+		{
+			name:            "any SNI",
+			data:            clientHello,
+			expectedVerdict: builtinVerdictPass,
+			expectedSNI:     "www.foo.com",
+		},
+		{
+			name:            "matched SNI",
+			sni:             "*.foo.com",
+			data:            clientHello,
+			expectedVerdict: builtinVerdictPass,
+			expectedSNI:     "www.foo.com",
+		},
+		{
+			name:            "unmatched SNI",
+			sni:             "www.bar.com",
+			data:            clientHello,
+			expectedVerdict: builtinVerdictReject,
+			expectedSNI:     "www.foo.com",
+		},
+		{
+			name:            "incomplete ClientHello",
+			data:            clientHello[:len(clientHello)-1],
+			expectedVerdict: builtinVerdictPending,
+		},
+		{
+			name:            "not ClientHello",
+			data:            []byte{tlsRecordTypeHandshake, 0x03, 0x03, 0x00, 0x04, 0x02, 0x00, 0x00, 0x00},
+			expectedVerdict: builtinVerdictReject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newBuiltinEngine()
+			require.NoError(t, e.AddRule("rule1", "AntreaNetworkPolicy:default/test-l7", testVLANID, []v1beta.L7Protocol{{TLS: &v1beta.TLSProtocol{SNI: tt.sni}}}))
+			inspection := e.getRule(testVLANID).inspect(tt.data, false)
+			assert.Equal(t, tt.expectedVerdict, inspection.verdict)
+			assert.Equal(t, protocolTLS, inspection.appProtocol)
+			assert.Equal(t, tt.expectedSNI, inspection.tlsSNI)
+		})
+	}
+}
+
+func TestBuiltinEngineAddRule(t *testing.T) {
+	e := newBuiltinEngine()
+	err := e.AddRule("rule1", "AntreaNetworkPolicy:default/test-l7", testVLANID, []v1beta.L7Protocol{{DNS: &v1beta.DNSProtocol{QueryName: "*.foo.com"}}})
+	assert.EqualError(t, err, "L7 rule rule1 of AntreaNetworkPolicy:default/test-l7 has protocols which are not supported by the builtin L7 engine, only HTTP and TLS are supported")
+	assert.Nil(t, e.getRule(testVLANID))
+
+	require.NoError(t, e.AddRule("rule1", "AntreaNetworkPolicy:default/test-l7", testVLANID, []v1beta.L7Protocol{{HTTP: &v1beta.HTTPProtocol{Path: "/api/*"}}}))
+	assert.NotNil(t, e.getRule(testVLANID))
+	require.NoError(t, e.DeleteRule("rule1", testVLANID))
+	assert.Nil(t, e.getRule(testVLANID))
+}
+
+func TestBuiltinEngineProcessPacket(t *testing.T) {
+	const clientISN, serverISN = 1000, 5000
+	syn := &layers.TCP{Seq: clientISN, SYN: true, Window: 1024}
+	synAck := &layers.TCP{Seq: serverISN, Ack: clientISN + 1, SYN: true, ACK: true, Window: 1024}
+	ack := &layers.TCP{Seq: clientISN + 1, Ack: serverISN + 1, ACK: true, Window: 1024}
+
+	tests := []struct {
+		name          string
+		request       []string
+		expectedAlert *Event
+	}{
+		{
+			name:    "allowed request",
+			request: []string{"GET /public/index.html HTTP/1.1\r\n", "Host: 10.10.1.6\r\n\r\n"},
+		},
+		{
+			name:    "rejected request",
+			request: []string{"GET /admin HTTP/1.1\r\n", "Host: 10.10.1.6\r\n\r\n"},
+			expectedAlert: &Event{
+				Action:      EventActionBlocked,
+				Signature:   "Reject by AntreaNetworkPolicy:default/test-l7",
+				SrcIP:       testClientIP,
+				SrcPort:     testClientPort,
+				DestIP:      testServerIP,
+				DestPort:    testServerPort,
+				Protocol:    6,
+				AppProtocol: protocolHTTP,
+				HTTP:        &HTTPEventInfo{Hostname: "10.10.1.6", URL: "/admin", Method: "GET", Protocol: "HTTP/1.1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakeClock(time.Now())
+			pio := newFakePacketIO()
+			e := newBuiltinEngine()
+			e.clock = fakeClock
+			var alertVLANIDs []uint32
+			var alerts []*Event
+			e.handleAlert = func(vlanID uint32, event *Event) {
+				alertVLANIDs = append(alertVLANIDs, vlanID)
+				alerts = append(alerts, event)
+			}
+			require.NoError(t, e.AddRule("rule1", "AntreaNetworkPolicy:default/test-l7", testVLANID, []v1beta.L7Protocol{{HTTP: &v1beta.HTTPProtocol{Path: "/public/*"}}}))
+
+			// The packets of other VLANs are dropped.
+			e.processPacket(pio, newTestTCPPacket(t, true, syn, ""), testVLANID+1)
+			assert.Empty(t, pio.getWritten())
+
+			// The handshake is forwarded.
+			for _, p := range [][]byte{
+				newTestTCPPacket(t, true, syn, ""),
+				newTestTCPPacket(t, false, synAck, ""),
+				newTestTCPPacket(t, true, ack, ""),
+			} {
+				e.processPacket(pio, p, testVLANID)
+				assert.Equal(t, []fakePacket{{data: p, vlanID: testVLANID}}, pio.getWritten())
+			}
+
+			// The segments of the request are held until the request is complete.
+			seq := uint32(clientISN + 1)
+			var requestPackets []fakePacket
+			for i, segment := range tt.request {
+				p := newTestTCPPacket(t, true, &layers.TCP{Seq: seq, Ack: serverISN + 1, ACK: true, PSH: true, Window: 1024}, segment)
+				requestPackets = append(requestPackets, fakePacket{data: p, vlanID: testVLANID})
+				e.processPacket(pio, p, testVLANID)
+				if i == 0 {
+					assert.Empty(t, pio.getWritten())
+					// The retransmission of a held segment is dropped.
+					e.processPacket(pio, p, testVLANID)
+					assert.Empty(t, pio.getWritten())
+				}
+				seq += uint32(len(segment))
+			}
+			serverPacket := newTestTCPPacket(t, false, &layers.TCP{Seq: serverISN + 1, Ack: seq, ACK: true, Window: 1024}, "")
+
+			written := pio.getWritten()
+			if tt.expectedAlert == nil {
+				assert.Equal(t, requestPackets, written)
+				assert.Empty(t, alerts)
+				// The connection is allowed.
+				e.processPacket(pio, serverPacket, testVLANID)
+				assert.Len(t, pio.getWritten(), 1)
+				return
+			}
+
+			// The connection is reset in both directions.
+			require.Len(t, written, 2)
+			ipv4, tcp := decodeTestTCPPacket(t, written[0].data)
+			assert.Equal(t, testServerIP.AsSlice(), []byte(ipv4.DstIP.To4()))
+			assert.Equal(t, layers.TCPPort(testServerPort), tcp.DstPort)
+			assert.True(t, tcp.RST)
+			assert.Equal(t, uint32(clientISN+1), tcp.Seq)
+			ipv4, tcp = decodeTestTCPPacket(t, written[1].data)
+			assert.Equal(t, testClientIP.AsSlice(), []byte(ipv4.DstIP.To4()))
+			assert.Equal(t, layers.TCPPort(testClientPort), tcp.DstPort)
+			assert.True(t, tcp.RST)
+			assert.True(t, tcp.ACK)
+			assert.Equal(t, uint32(serverISN+1), tcp.Seq)
+			assert.Equal(t, seq, tcp.Ack)
+
+			tt.expectedAlert.Timestamp = fakeClock.Now()
+			assert.Equal(t, []uint32{testVLANID}, alertVLANIDs)
+			assert.Equal(t, []*Event{tt.expectedAlert}, alerts)
+
+			// The packets of the rejected connection are dropped.
+			e.processPacket(pio, serverPacket, testVLANID)
+			assert.Empty(t, pio.getWritten())
+
+			// The connection is forgotten after it is idle.
+			fakeClock.Step(builtinFlowIdleTimeout)
+			e.processPacket(pio, serverPacket, testVLANID)
+			assert.Empty(t, e.flows)
+		})
+	}
+}
+
+func TestBuiltinEngineRun(t *testing.T) {
+	pio := newFakePacketIO()
+	e := newBuiltinEngine()
+	e.newPacketIOFn = func() (packetIO, error) {
+		return pio, nil
+	}
+	require.NoError(t, e.AddRule("rule1", "AntreaNetworkPolicy:default/test-l7", testVLANID, []v1beta.L7Protocol{{HTTP: &v1beta.HTTPProtocol{}}}))
+	stopCh := make(chan struct{})
+	runDone := make(chan struct{})
+	go func() {
+		defer close(runDone)
+		e.Run(stopCh, func(vlanID uint32, event *Event) {})
+	}()
+	require.NoError(t, e.Start())
+
+	syn := newTestTCPPacket(t, true, &layers.TCP{Seq: 1000, SYN: true, Window: 1024}, "")
+	pio.packets <- fakePacket{data: syn, vlanID: testVLANID}
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		pio.mutex.Lock()
+		defer pio.mutex.Unlock()
+		assert.Equal(c, []fakePacket{{data: syn, vlanID: testVLANID}}, pio.written)
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.NoError(t, e.Health())
+
+	close(stopCh)
+	<-runDone
+	assert.True(t, pio.closed)
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l7engine
+
+import (
+	"fmt"
+
+	"antrea.io/antrea/v2/pkg/agent/config"
+	v1beta "antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+)
+
+// Engine enforces L7 NetworkPolicy rules on the traffic redirected to it by OVS. The traffic is received from
+// config.L7RedirectTargetPortName with the VLAN ID allocated to the rule, and the allowed traffic is sent back to
+// OVS through config.L7RedirectReturnPortName with the same VLAN ID.
+type Engine interface {
+	// Start starts the engine. It is called once before the first rule is added.
+	Start() error
+	// AddRule adds or updates the rule the VLAN ID is allocated to.
+	AddRule(ruleID, policyName string, vlanID uint32, l7Protocols []v1beta.L7Protocol) error
+	// DeleteRule deletes the rule the VLAN ID is allocated to.
+	DeleteRule(ruleID string, vlanID uint32) error
+	// Health returns an error if the engine is started but is not working properly.
+	Health() error
+	// Run passes the alerts generated by the engine to handleAlert until stopCh is closed.
+	Run(stopCh <-chan struct{}, handleAlert AlertHandler)
+}
+
+// AlertHandler is called for every alert generated by an Engine. vlanID is the VLAN ID of the traffic which triggers
+// the alert. The RuleID and PolicyName of the Event are not set by the Engine.
+type AlertHandler func(vlanID uint32, event *Event)
+
+// NewEngine returns an Engine of the provided type.
+func NewEngine(engineType config.L7EngineType) (Engine, error) {
+	switch engineType {
+	case config.L7EngineSuricata:
+		return newSuricataEngine(), nil
+	case config.L7EngineBuiltin:
+		return newBuiltinEngine(), nil
+	}
+	return nil, fmt.Errorf("unsupported L7 engine type %s", engineType)
+}
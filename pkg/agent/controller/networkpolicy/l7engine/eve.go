@@ -0,0 +1,247 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l7engine
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/v2/pkg/util/ip"
+)
+
+const (
+	eveLogFilePattern  = "eve-*.json"
+	eveLogPollInterval = time.Second
+	eveTimestampLayout = "2006-01-02T15:04:05.999999-0700"
+
+	eveEventTypeAlert = "alert"
+
+	// EventActionBlocked is the action of an Event generated for traffic rejected by the L7 engine.
+	EventActionBlocked = "blocked"
+)
+
+// Event is a verdict of the L7 engine on the traffic redirected to it for a layer 7 NetworkPolicy rule.
+type Event struct {
+	Timestamp time.Time
+	// RuleID and PolicyName are the ones provided when the rule was added to the Reconciler. They are set by the
+	// Reconciler.
+	RuleID     string
+	PolicyName string
+	// Action is the action taken by the L7 engine, e.g. EventActionBlocked.
+	Action    string
+	Signature string
+	SrcIP     netip.Addr
+	SrcPort   uint16
+	DestIP    netip.Addr
+	DestPort  uint16
+	// Protocol is the IP protocol number of the traffic.
+	Protocol uint8
+	// AppProtocol is the application layer protocol detected by the L7 engine, e.g. "http", "tls", "dns".
+	AppProtocol string
+	// HTTP, TLSSNI and DNSQuery are set according to AppProtocol when the L7 engine has parsed them.
+	HTTP     *HTTPEventInfo
+	TLSSNI   string
+	DNSQuery string
+}
+
+// HTTPEventInfo is the HTTP request metadata of an Event.
+type HTTPEventInfo struct {
+	Hostname string `json:"hostname,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Method   string `json:"http_method,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// EventHandler is called for every Event. It is supposed to execute quickly and not perform blocking operations.
+type EventHandler func(event *Event)
+
+// eveRecord is the subset of a record of the Suricata EVE JSON log consumed by Antrea.
+type eveRecord struct {
+	Timestamp string `json:"timestamp"`
+	EventType string `json:"event_type"`
+	// TenantID is the VLAN ID allocated to the L7 NetworkPolicy rule.
+	TenantID uint32         `json:"tenant_id"`
+	SrcIP    string         `json:"src_ip"`
+	SrcPort  uint16         `json:"src_port"`
+	DestIP   string         `json:"dest_ip"`
+	DestPort uint16         `json:"dest_port"`
+	Proto    string         `json:"proto"`
+	AppProto string         `json:"app_proto"`
+	Alert    *eveAlert      `json:"alert"`
+	HTTP     *HTTPEventInfo `json:"http"`
+	TLS      *struct {
+		SNI string `json:"sni"`
+	} `json:"tls"`
+	DNS *struct {
+		Query []struct {
+			RRName string `json:"rrname"`
+		} `json:"query"`
+	} `json:"dns"`
+}
+
+type eveAlert struct {
+	Action    string `json:"action"`
+	Signature string `json:"signature"`
+}
+
+var eveProtocols = map[string]uint8{
+	"TCP":  ip.TCPProtocol,
+	"UDP":  ip.UDPProtocol,
+	"SCTP": ip.SCTPProtocol,
+}
+
+// toEvent converts an alert record to an Event.
+func (r *eveRecord) toEvent() *Event {
+	event := &Event{
+		Action:      r.Alert.Action,
+		Signature:   r.Alert.Signature,
+		SrcPort:     r.SrcPort,
+		DestPort:    r.DestPort,
+		Protocol:    eveProtocols[r.Proto],
+		AppProtocol: r.AppProto,
+		HTTP:        r.HTTP,
+	}
+	if t, err := time.Parse(eveTimestampLayout, r.Timestamp); err == nil {
+		event.Timestamp = t
+	} else {
+		event.Timestamp = time.Now()
+	}
+	event.SrcIP, _ = netip.ParseAddr(r.SrcIP)
+	event.DestIP, _ = netip.ParseAddr(r.DestIP)
+	if r.TLS != nil {
+		event.TLSSNI = r.TLS.SNI
+	}
+	if r.DNS != nil && len(r.DNS.Query) > 0 {
+		event.DNSQuery = r.DNS.Query[0].RRName
+	}
+	return event
+}
+
+// eveLogWatcher tails the EVE JSON log files written by Suricata in dir, and passes the alert records to
+// handleRecord. Suricata rotates the log file daily, and the name of a log file contains its date, so the latest
+// log file is the last one in lexical order.
+type eveLogWatcher struct {
+	dir          string
+	handleRecord func(record *eveRecord)
+	// file is the log file being read, and offset is the position in file where the next read starts.
+	file   string
+	offset int64
+}
+
+func newEVELogWatcher(dir string, handleRecord func(record *eveRecord)) *eveLogWatcher {
+	return &eveLogWatcher{
+		dir:          dir,
+		handleRecord: handleRecord,
+	}
+}
+
+func (w *eveLogWatcher) run(stopCh <-chan struct{}) {
+	// Skip the records which already exist, as they may have been handled before the agent restarted.
+	if file := w.latestFile(); file != "" {
+		if info, err := defaultFS.Stat(file); err == nil {
+			w.file, w.offset = file, info.Size()
+		}
+	}
+	klog.InfoS("Starting L7 engine EVE log watcher", "directory", w.dir)
+	wait.Until(w.poll, eveLogPollInterval, stopCh)
+}
+
+func (w *eveLogWatcher) latestFile() string {
+	files, err := afero.Glob(defaultFS, filepath.Join(w.dir, eveLogFilePattern))
+	if err != nil || len(files) == 0 {
+		return ""
+	}
+	sort.Strings(files)
+	return files[len(files)-1]
+}
+
+func (w *eveLogWatcher) poll() {
+	latest := w.latestFile()
+	if latest == "" {
+		return
+	}
+	// Finish reading the current file before switching to a newer one, as Suricata may have written to it before
+	// rotating.
+	if w.file != "" {
+		w.readFile()
+	}
+	if latest != w.file {
+		w.file, w.offset = latest, 0
+		w.readFile()
+	}
+}
+
+// readFile reads the complete lines written to w.file after w.offset, and advances w.offset accordingly.
+func (w *eveLogWatcher) readFile() {
+	f, err := defaultFS.Open(w.file)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			klog.ErrorS(err, "Failed to open EVE log file", "file", w.file)
+		}
+		return
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.Size() < w.offset {
+		// The file has been truncated.
+		w.offset = 0
+	}
+	if _, err := f.Seek(w.offset, io.SeekStart); err != nil {
+		klog.ErrorS(err, "Failed to seek EVE log file", "file", w.file, "offset", w.offset)
+		return
+	}
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// A line without the trailing newline is still being written and will be read in the next poll.
+			if err != io.EOF {
+				klog.ErrorS(err, "Failed to read EVE log file", "file", w.file)
+			}
+			return
+		}
+		w.offset += int64(len(line))
+		w.handleLine(line)
+	}
+}
+
+func (w *eveLogWatcher) handleLine(line []byte) {
+	// Checking the event type before decoding avoids decoding the records of other types, some of which, like
+	// "packet", can be large.
+	if !bytes.Contains(line, []byte(`"event_type":"`+eveEventTypeAlert+`"`)) {
+		return
+	}
+	record := &eveRecord{}
+	if err := json.Unmarshal(line, record); err != nil {
+		klog.ErrorS(err, "Failed to decode EVE log record", "record", strings.TrimSpace(string(line)))
+		return
+	}
+	if record.EventType != eveEventTypeAlert || record.Alert == nil {
+		return
+	}
+	w.handleRecord(record)
+}
@@ -0,0 +1,132 @@
+//go:build !windows
+
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l7engine
+
+import (
+	"net/netip"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEVELogDir = "/var/log/antrea/networkpolicy/l7engine"
+
+	testHTTPAlert = `{"timestamp":"2024-08-26T22:34:14.245972+0000","flow_id":716769614173690,"in_iface":"antrea-l7-tap0","event_type":"alert","vlan":[1],"src_ip":"10.10.1.5","src_port":34286,"dest_ip":"10.10.1.6","dest_port":8080,"proto":"TCP","pkt_src":"wire/pcap","tenant_id":1,"alert":{"action":"blocked","gid":1,"signature_id":1,"rev":0,"signature":"Reject by AntreaClusterNetworkPolicy:test-l7-ingress","category":"","severity":3,"tenant_id":1},"http":{"hostname":"10.10.1.6","http_port":8080,"url":"/admin","http_user_agent":"curl/7.74.0","http_method":"GET","protocol":"HTTP/1.1","length":0},"app_proto":"http","direction":"to_server"}` + "\n"
+	testDNSAlert  = `{"timestamp":"2024-08-26T22:35:01.000000+0000","event_type":"alert","src_ip":"10.10.1.5","src_port":53012,"dest_ip":"10.96.0.10","dest_port":53,"proto":"UDP","tenant_id":2,"alert":{"action":"blocked","signature":"Reject by AntreaNetworkPolicy:default/test-dns"},"dns":{"query":[{"type":"query","id":4660,"rrname":"example.com","rrtype":"A","tx_id":0}]},"app_proto":"dns"}` + "\n"
+	testHTTPEvent = `{"timestamp":"2024-08-26T22:34:14.245972+0000","event_type":"http","src_ip":"10.10.1.5","src_port":34286,"dest_ip":"10.10.1.6","dest_port":8080,"proto":"TCP","tenant_id":1,"http":{"hostname":"10.10.1.6","url":"/public"}}` + "\n"
+)
+
+func TestEVERecordToEvent(t *testing.T) {
+	defaultFS = afero.NewMemMapFs()
+	defer func() {
+		defaultFS = afero.NewOsFs()
+	}()
+
+	var records []*eveRecord
+	w := newEVELogWatcher(testEVELogDir, func(record *eveRecord) {
+		records = append(records, record)
+	})
+	w.handleLine([]byte(testHTTPAlert))
+	w.handleLine([]byte(testHTTPEvent))
+	w.handleLine([]byte(testDNSAlert))
+	w.handleLine([]byte(`{"event_type":"alert",`))
+	require.Len(t, records, 2)
+
+	assert.Equal(t, uint32(1), records[0].TenantID)
+	event := records[0].toEvent()
+	assert.True(t, event.Timestamp.Equal(time.Date(2024, 8, 26, 22, 34, 14, 245972000, time.UTC)))
+	event.Timestamp = time.Time{}
+	assert.Equal(t, &Event{
+		Action:      EventActionBlocked,
+		Signature:   "Reject by AntreaClusterNetworkPolicy:test-l7-ingress",
+		SrcIP:       netip.MustParseAddr("10.10.1.5"),
+		SrcPort:     34286,
+		DestIP:      netip.MustParseAddr("10.10.1.6"),
+		DestPort:    8080,
+		Protocol:    6,
+		AppProtocol: "http",
+		HTTP: &HTTPEventInfo{
+			Hostname: "10.10.1.6",
+			URL:      "/admin",
+			Method:   "GET",
+			Protocol: "HTTP/1.1",
+		},
+	}, event)
+
+	assert.Equal(t, uint32(2), records[1].TenantID)
+	event = records[1].toEvent()
+	assert.Equal(t, uint8(17), event.Protocol)
+	assert.Equal(t, "dns", event.AppProtocol)
+	assert.Equal(t, "example.com", event.DNSQuery)
+	assert.Nil(t, event.HTTP)
+}
+
+func TestEVELogWatcher(t *testing.T) {
+	defaultFS = afero.NewMemMapFs()
+	defer func() {
+		defaultFS = afero.NewOsFs()
+	}()
+	require.NoError(t, defaultFS.MkdirAll(testEVELogDir, 0755))
+	appendLog := func(name, data string) {
+		f, err := defaultFS.OpenFile(testEVELogDir+"/"+name, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		defer f.Close()
+		_, err = f.WriteString(data)
+		require.NoError(t, err)
+	}
+
+	var tenants []uint32
+	w := newEVELogWatcher(testEVELogDir, func(record *eveRecord) {
+		tenants = append(tenants, record.TenantID)
+	})
+	stopCh := make(chan struct{})
+	close(stopCh)
+
+	// The records written before the watcher starts are skipped.
+	appendLog("eve-2024-08-26.json", testHTTPAlert)
+	w.run(stopCh)
+	w.poll()
+	assert.Empty(t, tenants)
+
+	// A partially written record is handled once it is complete.
+	appendLog("eve-2024-08-26.json", testHTTPEvent+testDNSAlert[:20])
+	w.poll()
+	assert.Empty(t, tenants)
+	appendLog("eve-2024-08-26.json", testDNSAlert[20:])
+	w.poll()
+	assert.Equal(t, []uint32{2}, tenants)
+
+	// The remaining records of the rotated file are handled before the ones of the new file.
+	appendLog("eve-2024-08-26.json", testHTTPAlert)
+	appendLog("eve-2024-08-27.json", testDNSAlert)
+	w.poll()
+	assert.Equal(t, []uint32{2, 1, 2}, tenants)
+	assert.Equal(t, testEVELogDir+"/eve-2024-08-27.json", w.file)
+
+	// The file is read from the beginning after it is truncated.
+	require.NoError(t, afero.WriteFile(defaultFS, testEVELogDir+"/eve-2024-08-27.json", nil, 0644))
+	w.poll()
+	assert.Zero(t, w.offset)
+	appendLog("eve-2024-08-27.json", testHTTPAlert)
+	w.poll()
+	assert.Equal(t, []uint32{2, 1, 2, 1}, tenants)
+}
@@ -22,226 +22,130 @@ import (
 	"sync/atomic"
 	"testing"
 
-	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
-	"k8s.io/apimachinery/pkg/util/sets"
 
 	oftesting "antrea.io/antrea/v2/pkg/agent/openflow/testing"
 	v1beta "antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
 )
 
-type fakeSuricata struct {
-	calledScCommands      sets.Set[string]
-	startSuricataFnCalled bool
+type fakeEngine struct {
+	startCalled  int
+	startErr     error
+	healthCalled int
+	healthErr    error
+	rules        map[uint32]string
+	handleAlert  AlertHandler
 }
 
-func newFakeSuricata() *fakeSuricata {
-	return &fakeSuricata{
-		calledScCommands:      sets.New[string](),
-		startSuricataFnCalled: false,
-	}
+func newFakeEngine() *fakeEngine {
+	return &fakeEngine{rules: make(map[uint32]string)}
 }
 
-func (f *fakeSuricata) suricataScFunc(scCmd string) (*scCmdRet, error) {
-	f.calledScCommands.Insert(scCmd)
-	return &scCmdRet{Return: scCmdOK}, nil
+func (f *fakeEngine) Start() error {
+	f.startCalled++
+	return f.startErr
 }
 
-func (f *fakeSuricata) startSuricataFn() {
-	f.startSuricataFnCalled = true
-	defaultFS.Create(suricataCommandSocket)
+func (f *fakeEngine) AddRule(ruleID, policyName string, vlanID uint32, l7Protocols []v1beta.L7Protocol) error {
+	f.rules[vlanID] = ruleID
+	return nil
 }
 
-func TestConvertProtocolHTTP(t *testing.T) {
-	testCases := []struct {
-		name     string
-		http     *v1beta.HTTPProtocol
-		expected string
-	}{
-		{
-			name:     "without host,method,path",
-			http:     &v1beta.HTTPProtocol{},
-			expected: "",
-		},
-		{
-			name: "with host,method,exact path",
-			http: &v1beta.HTTPProtocol{
-				Host:   "www.google.com",
-				Method: "GET",
-				Path:   "/index.html",
-			},
-			expected: `http.uri; content:"/index.html"; startswith; endswith; http.method; content:"GET"; http.host; content:"www.google.com"; startswith; endswith;`,
-		},
-		{
-			name: "with host suffix, path prefix",
-			http: &v1beta.HTTPProtocol{
-				Host: "*.foo.com",
-				Path: "/api/v2/*",
-			},
-			expected: `http.uri; content:"/api/v2/"; startswith; http.host; content:".foo.com"; endswith;`,
-		},
-		{
-			name: "with host pattern",
-			http: &v1beta.HTTPProtocol{
-				Host: "*.foo.*",
-			},
-			expected: `http.host; content:".foo.";`,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.expected, convertProtocolHTTP(tc.http))
-		})
-	}
+func (f *fakeEngine) DeleteRule(ruleID string, vlanID uint32) error {
+	delete(f.rules, vlanID)
+	return nil
 }
 
-func TestConvertProtocolTLS(t *testing.T) {
-	testCases := []struct {
-		name     string
-		tls      *v1beta.TLSProtocol
-		expected string
-	}{
-		{
-			name:     "without SNI",
-			tls:      &v1beta.TLSProtocol{},
-			expected: "",
-		},
-		{
-			name: "with SNI",
-			tls: &v1beta.TLSProtocol{
-				SNI: "google.com",
-			},
-			expected: `tls.sni; content:"google.com"; startswith; endswith;`,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.expected, convertProtocolTLS(tc.tls))
-		})
-	}
+func (f *fakeEngine) Health() error {
+	f.healthCalled++
+	return f.healthErr
 }
 
-func TestStartSuricata(t *testing.T) {
-	defaultFS = afero.NewMemMapFs()
-	defer func() {
-		defaultFS = afero.NewOsFs()
-	}()
-
-	_, err := defaultFS.Create(defaultSuricataConfigPath)
-	assert.NoError(t, err)
-
-	fe := NewReconciler(nil)
-	fs := newFakeSuricata()
-	fe.suricataScFn = fs.suricataScFunc
-	fe.startSuricataFn = fs.startSuricataFn
-
-	fe.startSuricata()
-
-	ok, err := afero.FileContainsBytes(defaultFS, antreaSuricataConfigPath, []byte(suricataAntreaConfigData))
-	assert.NoError(t, err)
-	assert.True(t, ok)
-
-	ok, err = afero.FileContainsBytes(defaultFS, defaultSuricataConfigPath, []byte("include: /etc/suricata/antrea.yaml"))
-	assert.NoError(t, err)
-	assert.True(t, ok)
+func (f *fakeEngine) Run(stopCh <-chan struct{}, handleAlert AlertHandler) {
+	f.handleAlert = handleAlert
 }
 
-func TestRuleLifecycle(t *testing.T) {
-	ruleID := "123456"
-	vlanID := uint32(1)
-	policyName := "AntreaNetworkPolicy:test-l7"
-
-	testCases := []struct {
-		name                 string
-		l7Protocols          []v1beta.L7Protocol
-		updatedL7Protocols   []v1beta.L7Protocol
-		expectedRules        string
-		expectedUpdatedRules string
-	}{
-		{
-			name: "protocol HTTP",
-			l7Protocols: []v1beta.L7Protocol{
-				{
-					HTTP: &v1beta.HTTPProtocol{
-						Host:   "www.google.com",
-						Method: "GET",
-						Path:   "/index.html",
-					},
-				},
-			},
-			updatedL7Protocols: []v1beta.L7Protocol{
-				{
-					HTTP: &v1beta.HTTPProtocol{},
-				},
-			},
-			expectedRules:        `pass http any any -> any any (msg: "Allow http by AntreaNetworkPolicy:test-l7"; http.uri; content:"/index.html"; startswith; endswith; http.method; content:"GET"; http.host; content:"www.google.com"; startswith; endswith; sid: 2;)`,
-			expectedUpdatedRules: `pass http any any -> any any (msg: "Allow http by AntreaNetworkPolicy:test-l7"; sid: 2;)`,
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			defaultFS = afero.NewMemMapFs()
-			defer func() {
-				defaultFS = afero.NewOsFs()
-			}()
-
-			_, err := defaultFS.Create(defaultSuricataConfigPath)
-			assert.NoError(t, err)
-
-			ctrl := gomock.NewController(t)
-			mockOfClient := oftesting.NewMockClient(ctrl)
-			fe := NewReconciler(mockOfClient)
-			fs := newFakeSuricata()
-			fe.suricataScFn = fs.suricataScFunc
-			fe.startSuricataFn = fs.startSuricataFn
-
-			mockOfClient.EXPECT().InstallL7NetworkPolicyFlows().Times(1)
-
-			// Test add a L7 NetworkPolicy.
-			assert.NoError(t, fe.AddRule(ruleID, policyName, vlanID, tc.l7Protocols))
-
-			rulesPath := generateTenantRulesPath(vlanID)
-			ok, err := afero.FileContainsBytes(defaultFS, rulesPath, []byte(tc.expectedRules))
-			assert.NoError(t, err)
-			assert.True(t, ok)
-
-			configPath := generateTenantConfigPath(vlanID)
-			ok, err = afero.FileContainsBytes(defaultFS, configPath, []byte(rulesPath))
-			assert.NoError(t, err)
-			assert.True(t, ok)
-
-			expectedScCommands := sets.New[string]("register-tenant 1 /etc/suricata/antrea-tenant-1.yaml", "register-tenant-handler 1 vlan 1")
-			assert.True(t, fs.startSuricataFnCalled)
-			assert.Equal(t, expectedScCommands, fs.calledScCommands)
-
-			// Update the added L7 NetworkPolicy.
-			assert.NoError(t, fe.AddRule(ruleID, policyName, vlanID, tc.updatedL7Protocols))
-			expectedScCommands.Insert("reload-tenant 1 /etc/suricata/antrea-tenant-1.yaml")
-			assert.Equal(t, expectedScCommands, fs.calledScCommands)
-
-			// Delete the L7 NetworkPolicy.
-			assert.NoError(t, fe.DeleteRule(ruleID, vlanID))
-			expectedScCommands.Insert("unregister-tenant-handler 1 vlan 1", "unregister-tenant 1")
-			assert.Equal(t, expectedScCommands, fs.calledScCommands)
+func TestReconcilerRuleLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOfClient := oftesting.NewMockClient(ctrl)
+	fe := newFakeEngine()
+	fe.startErr = fmt.Errorf("error")
+	r := NewReconciler(mockOfClient, fe)
+	l7Protocols := []v1beta.L7Protocol{{HTTP: &v1beta.HTTPProtocol{Path: "/api/*"}}}
+
+	// The rule is not added if the engine fails to start.
+	assert.Error(t, r.AddRule("rule1", "AntreaNetworkPolicy:default/test-l7", 1, l7Protocols))
+	assert.Empty(t, fe.rules)
+	assert.Empty(t, r.tenantRules)
+
+	fe.startErr = nil
+	mockOfClient.EXPECT().InstallL7NetworkPolicyFlows().Times(1)
+	require.NoError(t, r.AddRule("rule1", "AntreaNetworkPolicy:default/test-l7", 1, l7Protocols))
+	require.NoError(t, r.AddRule("rule2", "AntreaClusterNetworkPolicy:test-l7", 2, l7Protocols))
+	assert.Equal(t, 2, fe.startCalled)
+	assert.Equal(t, map[uint32]string{1: "rule1", 2: "rule2"}, fe.rules)
+	assert.Equal(t, map[uint32]ruleInfo{
+		1: {ruleID: "rule1", policyName: "AntreaNetworkPolicy:default/test-l7"},
+		2: {ruleID: "rule2", policyName: "AntreaClusterNetworkPolicy:test-l7"},
+	}, r.tenantRules)
+
+	require.NoError(t, r.DeleteRule("rule1", 1))
+	assert.Equal(t, map[uint32]string{2: "rule2"}, fe.rules)
+	assert.Equal(t, map[uint32]ruleInfo{
+		2: {ruleID: "rule2", policyName: "AntreaClusterNetworkPolicy:test-l7"},
+	}, r.tenantRules)
+}
 
-			exists, err := afero.Exists(defaultFS, rulesPath)
-			assert.NoError(t, err)
-			assert.False(t, exists)
+func TestReconcilerHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOfClient := oftesting.NewMockClient(ctrl)
+	fe := newFakeEngine()
+	fe.healthErr = fmt.Errorf("engine is down")
+	r := NewReconciler(mockOfClient, fe)
+
+	// The health of the engine is not checked before it is started.
+	r.checkHealth()
+	assert.NoError(t, r.Health())
+	assert.Zero(t, fe.healthCalled)
+
+	mockOfClient.EXPECT().InstallL7NetworkPolicyFlows().Times(1)
+	require.NoError(t, r.AddRule("rule1", "AntreaNetworkPolicy:default/test-l7", 1, nil))
+	r.checkHealth()
+	assert.EqualError(t, r.Health(), "engine is down")
+	// Health returns the result of the last check, without checking the engine again.
+	assert.EqualError(t, r.Health(), "engine is down")
+	assert.Equal(t, 1, fe.healthCalled)
+
+	fe.healthErr = nil
+	r.checkHealth()
+	assert.NoError(t, r.Health())
+}
 
-			exists, err = afero.Exists(defaultFS, configPath)
-			assert.NoError(t, err)
-			assert.False(t, exists)
-		})
-	}
+func TestReconcilerHandleAlert(t *testing.T) {
+	fe := newFakeEngine()
+	r := NewReconciler(nil, fe)
+	r.tenantRules[1] = ruleInfo{ruleID: "rule1", policyName: "AntreaClusterNetworkPolicy:test-l7-ingress"}
+	var events []*Event
+	r.RegisterEventHandler(func(event *Event) {
+		events = append(events, event)
+	})
+	r.Run(make(chan struct{}))
+
+	fe.handleAlert(1, &Event{Action: EventActionBlocked, AppProtocol: "http", HTTP: &HTTPEventInfo{URL: "/admin"}})
+	// The rule of the VLAN ID is unknown.
+	fe.handleAlert(2, &Event{Action: EventActionBlocked, AppProtocol: "dns"})
+	require.Len(t, events, 1)
+	assert.Equal(t, "rule1", events[0].RuleID)
+	assert.Equal(t, "AntreaClusterNetworkPolicy:test-l7-ingress", events[0].PolicyName)
+	assert.Equal(t, "/admin", events[0].HTTP.URL)
 }
 
 func TestInitializeL7FlowsOnce(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockOfClient := oftesting.NewMockClient(ctrl)
-	fe := NewReconciler(mockOfClient)
+	fe := NewReconciler(mockOfClient, newFakeEngine())
 
 	mockOfClient.EXPECT().InstallL7NetworkPolicyFlows().Return(fmt.Errorf("error"))
 	mockOfClient.EXPECT().InstallL7NetworkPolicyFlows().Return(nil)
@@ -0,0 +1,435 @@
+//go:build !windows
+
+// Copyright 2022 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l7engine
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	oftesting "antrea.io/antrea/v2/pkg/agent/openflow/testing"
+	v1beta "antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+)
+
+type fakeSuricata struct {
+	calledScCommands      sets.Set[string]
+	startSuricataFnCalled bool
+}
+
+func newFakeSuricata() *fakeSuricata {
+	return &fakeSuricata{
+		calledScCommands:      sets.New[string](),
+		startSuricataFnCalled: false,
+	}
+}
+
+func (f *fakeSuricata) suricataScFunc(scCmd string) (*scCmdRet, error) {
+	f.calledScCommands.Insert(scCmd)
+	return &scCmdRet{Return: scCmdOK}, nil
+}
+
+func (f *fakeSuricata) failedSuricataScFunc(scCmd string) (*scCmdRet, error) {
+	f.calledScCommands.Insert(scCmd)
+	return &scCmdRet{Return: "NOK", Message: "Unable to connect to socket"}, nil
+}
+
+func (f *fakeSuricata) startSuricataFn() {
+	f.startSuricataFnCalled = true
+	defaultFS.Create(suricataCommandSocket)
+}
+
+func TestConvertProtocolHTTP(t *testing.T) {
+	testCases := []struct {
+		name     string
+		http     *v1beta.HTTPProtocol
+		expected string
+	}{
+		{
+			name:     "without host,method,path",
+			http:     &v1beta.HTTPProtocol{},
+			expected: "",
+		},
+		{
+			name: "with host,method,exact path",
+			http: &v1beta.HTTPProtocol{
+				Host:   "www.google.com",
+				Method: "GET",
+				Path:   "/index.html",
+			},
+			expected: `http.uri; content:"/index.html"; startswith; endswith; http.method; content:"GET"; http.host; content:"www.google.com"; startswith; endswith;`,
+		},
+		{
+			name: "with host suffix, path prefix",
+			http: &v1beta.HTTPProtocol{
+				Host: "*.foo.com",
+				Path: "/api/v2/*",
+			},
+			expected: `http.uri; content:"/api/v2/"; startswith; http.host; content:".foo.com"; endswith;`,
+		},
+		{
+			name: "with host pattern",
+			http: &v1beta.HTTPProtocol{
+				Host: "*.foo.*",
+			},
+			expected: `http.host; content:".foo.";`,
+		},
+		{
+			name: "with method, path regex",
+			http: &v1beta.HTTPProtocol{
+				Method:    "GET",
+				PathRegex: "^/api/v[0-9]+/users/[^/]+$",
+			},
+			expected: `http.method; content:"GET"; http.uri; pcre:"/^(?:^\/api\/v[0-9]+\/users\/[^\/]+(?=\?|$))(?:\?|$)/";`,
+		},
+		{
+			name: "with headers",
+			http: &v1beta.HTTPProtocol{
+				Headers: []v1beta.HTTPHeaderMatch{
+					{Name: "X-Tenant", Value: "foo"},
+					{Name: "User-Agent", Value: "curl/*"},
+					{Name: "Authorization"},
+				},
+			},
+			expected: `http.header; pcre:"/^(?i:X-Tenant): foo\r$/m"; http.header; pcre:"/^(?i:User-Agent): curl\/[^\r\n]*\r$/m"; http.header; pcre:"/^(?i:Authorization):/m";`,
+		},
+		{
+			name: "with path prefix, query parameters",
+			http: &v1beta.HTTPProtocol{
+				Path: "/search*",
+				QueryParams: []v1beta.HTTPQueryParamMatch{
+					{Name: "q", Value: "a.b"},
+					{Name: "debug"},
+					{Name: "id", Value: "*"},
+				},
+			},
+			expected: `http.uri; content:"/search"; startswith; http.uri; pcre:"/[?&]q=a\.b(&|$)/"; http.uri; pcre:"/[?&]debug(=|&|$)/"; http.uri; pcre:"/[?&]id=[^&]*(&|$)/";`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, convertProtocolHTTP(tc.http))
+		})
+	}
+}
+
+func TestConvertPCREPathEnd(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: "/admin", expected: "/admin"},
+		{pattern: "^/admin$", expected: `^/admin(?=\?|$)`},
+		{pattern: "^/a$|^/b/[^/]+$", expected: `^/a(?=\?|$)|^/b/[^/]+(?=\?|$)`},
+		{pattern: `^/price\$[$]`, expected: `^/price\$[$]`},
+		{pattern: `^/[]$]$`, expected: `^/[]$](?=\?|$)`},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, convertPCREPathEnd(tc.pattern), "pattern %s", tc.pattern)
+	}
+}
+
+func TestEscapePCRE(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: "^/api/", expected: `^\/api\/`},
+		{pattern: `^\/api\/`, expected: `^\/api\/`},
+		{pattern: `a"b;c`, expected: `a\"b\;c`},
+		{pattern: `a\\/b`, expected: `a\\\/b`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			assert.Equal(t, tc.expected, escapePCRE(tc.pattern))
+		})
+	}
+}
+
+func TestConvertProtocolTLS(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tls      *v1beta.TLSProtocol
+		expected string
+	}{
+		{
+			name:     "without SNI",
+			tls:      &v1beta.TLSProtocol{},
+			expected: "",
+		},
+		{
+			name: "with SNI",
+			tls: &v1beta.TLSProtocol{
+				SNI: "google.com",
+			},
+			expected: `tls.sni; content:"google.com"; startswith; endswith;`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, convertProtocolTLS(tc.tls))
+		})
+	}
+}
+
+func TestConvertProtocolGRPC(t *testing.T) {
+	testCases := []struct {
+		name     string
+		grpc     *v1beta.GRPCProtocol
+		expected string
+	}{
+		{
+			name:     "without service,method",
+			grpc:     &v1beta.GRPCProtocol{},
+			expected: `http.request_header; content:"content-type: application/grpc"; startswith;`,
+		},
+		{
+			name: "with service,method",
+			grpc: &v1beta.GRPCProtocol{
+				Service: "helloworld.Greeter",
+				Method:  "SayHello",
+			},
+			expected: `http.request_header; content:"content-type: application/grpc"; startswith; http.uri; content:"/helloworld.Greeter/SayHello"; startswith; endswith;`,
+		},
+		{
+			name: "with service",
+			grpc: &v1beta.GRPCProtocol{
+				Service: "helloworld.Greeter",
+			},
+			expected: `http.request_header; content:"content-type: application/grpc"; startswith; http.uri; content:"/helloworld.Greeter/"; startswith;`,
+		},
+		{
+			name: "with method",
+			grpc: &v1beta.GRPCProtocol{
+				Method: "SayHello",
+			},
+			expected: `http.request_header; content:"content-type: application/grpc"; startswith; http.uri; content:"/SayHello"; endswith;`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, convertProtocolGRPC(tc.grpc))
+		})
+	}
+}
+
+func TestConvertProtocolDNS(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dns      *v1beta.DNSProtocol
+		expected string
+	}{
+		{
+			name:     "without query name,type",
+			dns:      &v1beta.DNSProtocol{},
+			expected: "",
+		},
+		{
+			name: "with exact query name",
+			dns: &v1beta.DNSProtocol{
+				QueryName: "www.google.com",
+			},
+			expected: `dns.query; content:"www.google.com"; startswith; endswith; nocase;`,
+		},
+		{
+			name: "with query name suffix, query type",
+			dns: &v1beta.DNSProtocol{
+				QueryName: "*.foo.com",
+				QueryType: "AAAA",
+			},
+			expected: `dns.query; content:".foo.com"; endswith; nocase; pkt_data; pcre:"/^(?:[\s\S]{2})?[\s\S]{12}[^\x00]+\x00\x00\x1c/";`,
+		},
+		{
+			name: "with query type ANY",
+			dns: &v1beta.DNSProtocol{
+				QueryType: "ANY",
+			},
+			expected: `pkt_data; pcre:"/^(?:[\s\S]{2})?[\s\S]{12}[^\x00]+\x00\x00\xff/";`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, convertProtocolDNS(tc.dns))
+		})
+	}
+}
+
+func TestStartSuricata(t *testing.T) {
+	defaultFS = afero.NewMemMapFs()
+	defer func() {
+		defaultFS = afero.NewOsFs()
+	}()
+
+	_, err := defaultFS.Create(defaultSuricataConfigPath)
+	assert.NoError(t, err)
+
+	se := newSuricataEngine()
+	fs := newFakeSuricata()
+	se.suricataScFn = fs.suricataScFunc
+	se.startSuricataFn = fs.startSuricataFn
+
+	assert.NoError(t, se.Start())
+	assert.True(t, fs.startSuricataFnCalled)
+
+	ok, err := afero.FileContainsBytes(defaultFS, antreaSuricataConfigPath, []byte(suricataAntreaConfigData))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = afero.FileContainsBytes(defaultFS, defaultSuricataConfigPath, []byte("include: /etc/suricata/antrea.yaml"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestRuleLifecycle(t *testing.T) {
+	ruleID := "123456"
+	vlanID := uint32(1)
+	policyName := "AntreaNetworkPolicy:test-l7"
+
+	testCases := []struct {
+		name                 string
+		l7Protocols          []v1beta.L7Protocol
+		updatedL7Protocols   []v1beta.L7Protocol
+		expectedRules        string
+		expectedUpdatedRules string
+	}{
+		{
+			name: "protocol HTTP",
+			l7Protocols: []v1beta.L7Protocol{
+				{
+					HTTP: &v1beta.HTTPProtocol{
+						Host:   "www.google.com",
+						Method: "GET",
+						Path:   "/index.html",
+					},
+				},
+			},
+			updatedL7Protocols: []v1beta.L7Protocol{
+				{
+					HTTP: &v1beta.HTTPProtocol{},
+				},
+			},
+			expectedRules:        `pass http any any -> any any (msg: "Allow http by AntreaNetworkPolicy:test-l7"; http.uri; content:"/index.html"; startswith; endswith; http.method; content:"GET"; http.host; content:"www.google.com"; startswith; endswith; sid: 2;)`,
+			expectedUpdatedRules: `pass http any any -> any any (msg: "Allow http by AntreaNetworkPolicy:test-l7"; sid: 2;)`,
+		},
+		{
+			name: "protocol gRPC",
+			l7Protocols: []v1beta.L7Protocol{
+				{
+					GRPC: &v1beta.GRPCProtocol{
+						Service: "helloworld.Greeter",
+						Method:  "SayHello",
+					},
+				},
+			},
+			updatedL7Protocols: []v1beta.L7Protocol{
+				{
+					GRPC: &v1beta.GRPCProtocol{},
+				},
+			},
+			expectedRules:        `pass http2 any any -> any any (msg: "Allow http2 by AntreaNetworkPolicy:test-l7"; http.request_header; content:"content-type: application/grpc"; startswith; http.uri; content:"/helloworld.Greeter/SayHello"; startswith; endswith; sid: 2;)`,
+			expectedUpdatedRules: `pass http2 any any -> any any (msg: "Allow http2 by AntreaNetworkPolicy:test-l7"; http.request_header; content:"content-type: application/grpc"; startswith; sid: 2;)`,
+		},
+		{
+			name: "protocol DNS",
+			l7Protocols: []v1beta.L7Protocol{
+				{
+					DNS: &v1beta.DNSProtocol{
+						QueryName: "*.google.com",
+					},
+				},
+			},
+			updatedL7Protocols: []v1beta.L7Protocol{
+				{
+					DNS: &v1beta.DNSProtocol{},
+				},
+			},
+			expectedRules: `reject dns any any -> any any (msg: "Reject by AntreaNetworkPolicy:test-l7"; flow: to_server; sid: 2;)
+pass dns any any -> any any (msg: "Allow dns by AntreaNetworkPolicy:test-l7"; dns.query; content:".google.com"; endswith; nocase; sid: 3;)`,
+			expectedUpdatedRules: `pass dns any any -> any any (msg: "Allow dns by AntreaNetworkPolicy:test-l7"; sid: 3;)`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defaultFS = afero.NewMemMapFs()
+			defer func() {
+				defaultFS = afero.NewOsFs()
+			}()
+
+			_, err := defaultFS.Create(defaultSuricataConfigPath)
+			assert.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			mockOfClient := oftesting.NewMockClient(ctrl)
+			se := newSuricataEngine()
+			fs := newFakeSuricata()
+			se.suricataScFn = fs.suricataScFunc
+			se.startSuricataFn = fs.startSuricataFn
+			fe := NewReconciler(mockOfClient, se)
+
+			mockOfClient.EXPECT().InstallL7NetworkPolicyFlows().Times(1)
+
+			// Test add a L7 NetworkPolicy.
+			assert.NoError(t, fe.AddRule(ruleID, policyName, vlanID, tc.l7Protocols))
+
+			rulesPath := generateTenantRulesPath(vlanID)
+			ok, err := afero.FileContainsBytes(defaultFS, rulesPath, []byte(tc.expectedRules))
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			configPath := generateTenantConfigPath(vlanID)
+			ok, err = afero.FileContainsBytes(defaultFS, configPath, []byte(rulesPath))
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			expectedScCommands := sets.New[string]("register-tenant 1 /etc/suricata/antrea-tenant-1.yaml", "register-tenant-handler 1 vlan 1")
+			assert.True(t, fs.startSuricataFnCalled)
+			assert.Equal(t, expectedScCommands, fs.calledScCommands)
+
+			// Update the added L7 NetworkPolicy.
+			assert.NoError(t, fe.AddRule(ruleID, policyName, vlanID, tc.updatedL7Protocols))
+			expectedScCommands.Insert("reload-tenant 1 /etc/suricata/antrea-tenant-1.yaml")
+			assert.Equal(t, expectedScCommands, fs.calledScCommands)
+
+			// Delete the L7 NetworkPolicy.
+			assert.NoError(t, fe.DeleteRule(ruleID, vlanID))
+			expectedScCommands.Insert("unregister-tenant-handler 1 vlan 1", "unregister-tenant 1")
+			assert.Equal(t, expectedScCommands, fs.calledScCommands)
+
+			exists, err := afero.Exists(defaultFS, rulesPath)
+			assert.NoError(t, err)
+			assert.False(t, exists)
+
+			exists, err = afero.Exists(defaultFS, configPath)
+			assert.NoError(t, err)
+			assert.False(t, exists)
+		})
+	}
+}
+
+func TestSuricataEngineHealth(t *testing.T) {
+	se := newSuricataEngine()
+	fs := newFakeSuricata()
+	se.suricataScFn = fs.suricataScFunc
+	assert.NoError(t, se.Health())
+	assert.Equal(t, sets.New[string]("version"), fs.calledScCommands)
+
+	se.suricataScFn = fs.failedSuricataScFunc
+	assert.EqualError(t, se.Health(), "unexpected response from Suricata: Unable to connect to socket")
+}
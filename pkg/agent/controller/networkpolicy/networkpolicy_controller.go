@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/netip"
 	"reflect"
 	"sync"
 	"time"
@@ -74,6 +75,7 @@ const (
 type L7RuleReconciler interface {
 	AddRule(ruleID, policyName string, vlanID uint32, l7Protocols []v1beta2.L7Protocol) error
 	DeleteRule(ruleID string, vlanID uint32) error
+	Health() error
 }
 
 var emptyWatch = watch.NewEmptyWatch()
@@ -135,7 +137,7 @@ type Controller struct {
 	// NetworkPolicy rules with the actual state of iptables entries.
 	nodeReconciler Reconciler
 	// l7RuleReconciler provides interfaces to reconcile the desired state of
-	// NetworkPolicy rules which have L7 rules with the actual state of Suricata rules.
+	// NetworkPolicy rules which have L7 rules with the actual state of L7 engine rules.
 	l7RuleReconciler L7RuleReconciler
 	// l7VlanIDAllocator allocates a VLAN ID for every L7 rule.
 	l7VlanIDAllocator *l7VlanIDAllocator
@@ -158,15 +160,23 @@ type Controller struct {
 	nodeConfig       *config.NodeConfig
 	podNetworkWait   *utilwait.Group
 
+	// policyRuleDropNotifier is used to send the packets dropped or rejected by Antrea-native policy rules to the
+	// PacketCapture controller.
+	policyRuleDropNotifier channel.Notifier
+	// policyRuleDropWanted returns whether the PacketCapture controller is currently waiting for such packets, so
+	// that packet-in messages are only parsed for policyRuleDropNotifier when needed.
+	policyRuleDropWanted func() bool
+
 	// The fileStores store runtime.Objects in files and use them as the fallback data source when agent can't connect
 	// to antrea-controller on startup.
 	networkPolicyStore  *fileStore
 	appliedToGroupStore *fileStore
 	addressGroupStore   *fileStore
 
-	logPacketAction           packetInAction
-	rejectRequestAction       packetInAction
-	storeDenyConnectionAction packetInAction
+	logPacketAction            packetInAction
+	rejectRequestAction        packetInAction
+	storeDenyConnectionAction  packetInAction
+	notifyPolicyRuleDropAction packetInAction
 }
 
 // NewNetworkPolicyController returns a new *Controller.
@@ -223,6 +233,9 @@ func NewNetworkPolicyController(antreaClientGetter client.AntreaClientProvider,
 	if l7NetworkPolicyEnabled {
 		c.l7RuleReconciler = l7Reconciler
 		c.l7VlanIDAllocator = newL7VlanIDAllocator()
+		if l7Reconciler != nil {
+			l7Reconciler.RegisterEventHandler(c.handleL7Event)
+		}
 	}
 
 	var err error
@@ -534,6 +547,7 @@ func NewNetworkPolicyController(antreaClientGetter client.AntreaClientProvider,
 	c.logPacketAction = c.logPacket
 	c.rejectRequestAction = c.rejectRequest
 	c.storeDenyConnectionAction = c.storeDenyConnection
+	c.notifyPolicyRuleDropAction = c.notifyPolicyRuleDrop
 	return c, nil
 }
 
@@ -552,6 +566,16 @@ func (c *Controller) GetFQDNCache(fqdnFilter *querier.FQDNCacheFilter) []types.D
 	return cacheEntryList
 }
 
+// GetFQDNForIP returns the FQDN which resolved to the given IP address. If multiple FQDNs
+// resolved to the IP address, the one with the latest expiration time is returned. An empty
+// string is returned if FQDN rules are not supported, i.e. AntreaPolicy is disabled.
+func (c *Controller) GetFQDNForIP(ip netip.Addr) string {
+	if c.fqdnController == nil {
+		return ""
+	}
+	return c.fqdnController.getFQDNForIP(ip.Unmap().String())
+}
+
 func (c *Controller) GetNetworkPolicyNum() int {
 	return c.ruleCache.GetNetworkPolicyNum()
 }
@@ -609,10 +633,23 @@ func (c *Controller) GetControllerConnectionStatus() bool {
 	return c.addressGroupWatcher.isConnected() && c.appliedToGroupWatcher.isConnected() && c.networkPolicyWatcher.isConnected()
 }
 
+// GetL7EngineHealth returns an error if the L7 engine enforcing the L7 NetworkPolicy rules is not working properly.
+func (c *Controller) GetL7EngineHealth() error {
+	if c.l7RuleReconciler == nil {
+		return nil
+	}
+	return c.l7RuleReconciler.Health()
+}
+
 func (c *Controller) SetDenyStoreNotifier(notifier channel.Notifier) {
 	c.denyConnNotifier = notifier
 }
 
+func (c *Controller) SetPolicyRuleDropNotifier(notifier channel.Notifier, wanted func() bool) {
+	c.policyRuleDropNotifier = notifier
+	c.policyRuleDropWanted = wanted
+}
+
 // Run begins watching and processing Antrea AddressGroups, AppliedToGroups
 // and NetworkPolicies, and spawns workers that reconciles NetworkPolicy rules.
 // Run will not return until stopCh is closed.
@@ -18,6 +18,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net"
+	"net/netip"
 	"os"
 	"regexp"
 	"strings"
@@ -33,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/watch"
 	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/component-base/metrics/legacyregistry"
@@ -79,7 +81,8 @@ func newTestController() (*Controller, *fake.Clientset, *mockReconciler) {
 	groupIDAllocator := openflow.NewGroupAllocator()
 	groupCounters := []proxytypes.GroupCounter{proxytypes.NewGroupCounter(groupIDAllocator, ch2)}
 	fs := afero.NewMemMapFs()
-	l7reconciler := l7engine.NewReconciler(nil)
+	l7Engine, _ := l7engine.NewEngine(config.L7EngineSuricata)
+	l7reconciler := l7engine.NewReconciler(nil, l7Engine)
 	controller, _ := NewNetworkPolicyController(&antreaClientGetter{clientset},
 		nil,
 		nil,
@@ -965,3 +968,59 @@ func TestGetFqdnCache(t *testing.T) {
 	returnedList = controller.GetFQDNCache(&querier.FQDNCacheFilter{DomainRegex: pattern})
 	assert.ElementsMatch(t, []agenttypes.DnsCacheEntry{expectedEntryList[3]}, returnedList)
 }
+
+func TestGetFQDNForIP(t *testing.T) {
+	// fqdnController is nil when AntreaPolicy is disabled.
+	assert.Empty(t, (&Controller{}).GetFQDNForIP(netip.MustParseAddr("10.0.0.1")))
+
+	controller, _, _ := newTestController()
+	assert.Empty(t, controller.GetFQDNForIP(netip.MustParseAddr("10.0.0.1")))
+	expirationTime := time.Now().Add(1 * time.Hour)
+
+	f := controller.fqdnController
+	f.setDNSEntry("example.com", dnsMeta{
+		responseIPs: map[string]ipWithExpiration{
+			"10.0.0.1": {ip: net.ParseIP("10.0.0.1"), expirationTime: expirationTime},
+			"10.0.0.2": {ip: net.ParseIP("10.0.0.2"), expirationTime: expirationTime},
+			"fd00::1":  {ip: net.ParseIP("fd00::1"), expirationTime: expirationTime},
+		},
+	})
+	f.setDNSEntry("www.example.com", dnsMeta{
+		responseIPs: map[string]ipWithExpiration{
+			"10.0.0.2": {ip: net.ParseIP("10.0.0.2"), expirationTime: expirationTime.Add(time.Minute)},
+		},
+	})
+	f.setDNSEntry("a.example.com", dnsMeta{
+		responseIPs: map[string]ipWithExpiration{
+			"10.0.0.1": {ip: net.ParseIP("10.0.0.1"), expirationTime: expirationTime},
+		},
+	})
+
+	for _, tc := range []struct {
+		ip           string
+		expectedFQDN string
+	}{
+		// Same expiration time, the smallest name is returned.
+		{ip: "10.0.0.1", expectedFQDN: "a.example.com"},
+		// The FQDN with the latest expiration time is returned.
+		{ip: "10.0.0.2", expectedFQDN: "www.example.com"},
+		{ip: "::ffff:10.0.0.2", expectedFQDN: "www.example.com"},
+		{ip: "fd00::1", expectedFQDN: "example.com"},
+		{ip: "10.0.0.3", expectedFQDN: ""},
+	} {
+		assert.Equal(t, tc.expectedFQDN, controller.GetFQDNForIP(netip.MustParseAddr(tc.ip)), "Unexpected FQDN for IP %s", tc.ip)
+	}
+
+	// The reversed index is updated when the IPs of a FQDN change or the FQDN is removed.
+	f.setDNSEntry("www.example.com", dnsMeta{
+		responseIPs: map[string]ipWithExpiration{
+			"10.0.0.3": {ip: net.ParseIP("10.0.0.3"), expirationTime: expirationTime},
+		},
+	})
+	assert.Equal(t, "example.com", controller.GetFQDNForIP(netip.MustParseAddr("10.0.0.2")))
+	assert.Equal(t, "www.example.com", controller.GetFQDNForIP(netip.MustParseAddr("10.0.0.3")))
+	f.deleteDNSEntry("www.example.com")
+	f.deleteDNSEntry("example.com")
+	assert.Empty(t, controller.GetFQDNForIP(netip.MustParseAddr("10.0.0.3")))
+	assert.Equal(t, map[string]sets.Set[string]{"10.0.0.1": sets.New[string]("a.example.com")}, f.ipToFQDNs)
+}
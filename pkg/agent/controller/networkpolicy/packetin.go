@@ -29,6 +29,7 @@ import (
 	"antrea.io/antrea/v2/pkg/agent/flowexporter/connection"
 	flowexporterutils "antrea.io/antrea/v2/pkg/agent/flowexporter/utils"
 	"antrea.io/antrea/v2/pkg/agent/openflow"
+	"antrea.io/antrea/v2/pkg/agent/types"
 	binding "antrea.io/antrea/v2/pkg/ovs/openflow"
 )
 
@@ -63,6 +64,12 @@ func (c *Controller) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
 			return err
 		}
 	}
+	// Parsing the packet is skipped if no RingBuffer PacketCapture is waiting for a packet to be dropped.
+	if c.policyRuleDropNotifier != nil && c.policyRuleDropWanted() {
+		if err := c.notifyPolicyRuleDropAction(pktIn); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -191,6 +198,62 @@ func (c *Controller) storeDenyConnectionParsed(pktIn *ofctrl.PacketIn, packet *b
 	return nil
 }
 
+func (c *Controller) notifyPolicyRuleDrop(pktIn *ofctrl.PacketIn) error {
+	packet, err := binding.ParsePacketIn(pktIn)
+	if err != nil {
+		return fmt.Errorf("error in parsing packetIn: %w", err)
+	}
+	return c.notifyPolicyRuleDropParsed(pktIn, packet)
+}
+
+// notifyPolicyRuleDropParsed takes a parsed packet as input, making it easier to unit test than notifyPolicyRuleDrop.
+// It notifies the packet to policyRuleDropNotifier if it is dropped or rejected by an Antrea-native policy rule.
+func (c *Controller) notifyPolicyRuleDropParsed(pktIn *ofctrl.PacketIn, packet *binding.Packet) error {
+	matchers := pktIn.GetMatches()
+	var disposition uint32
+	// The disposition is only loaded to the register if logging or deny tracking is enabled. Packets sent to the
+	// agent for the Reject operation only are always rejected.
+	if match := getMatchRegField(matchers, openflow.APDispositionField); match != nil {
+		id, err := getInfoInReg(match, openflow.APDispositionField.GetRange().ToNXRange())
+		if err != nil {
+			return fmt.Errorf("error when getting disposition from reg: %v", err)
+		}
+		disposition = id
+	} else if len(pktIn.UserData) > 1 && pktIn.UserData[1]&openflow.PacketInNPRejectOperation != 0 {
+		disposition = openflow.DispositionRej
+	}
+	if disposition != openflow.DispositionDrop && disposition != openflow.DispositionRej {
+		return nil
+	}
+	// The conjunction ID is not set for the default drops of K8s NetworkPolicies.
+	match := getMatch(matchers, getPacketInTableID(pktIn), disposition)
+	if match == nil {
+		return nil
+	}
+	ruleID, err := getInfoInReg(match, nil)
+	if err != nil {
+		return fmt.Errorf("error when obtaining rule id from reg: %v", err)
+	}
+	rule := c.GetRuleByFlowID(ruleID)
+	if rule == nil || rule.PolicyRef == nil {
+		return nil
+	}
+	drop := &types.PolicyRuleDrop{
+		PolicyRef:     rule.PolicyRef,
+		RuleName:      rule.Name,
+		Disposition:   openflow.DispositionToString[disposition],
+		SourceIP:      packet.SourceIP,
+		DestinationIP: packet.DestinationIP,
+	}
+	if nwDstValue := getCTNwDstValue(matchers); nwDstValue.IsValid() {
+		if originalDestinationIP := net.IP(nwDstValue.AsSlice()); !originalDestinationIP.Equal(packet.DestinationIP) {
+			drop.OriginalDestinationIP = originalDestinationIP
+		}
+	}
+	c.policyRuleDropNotifier.Notify(drop)
+	return nil
+}
+
 func isAntreaPolicyIngressTable(tableID uint8) bool {
 	for _, table := range openflow.GetAntreaPolicyIngressTables() {
 		if table.IsInitialized() && table.GetID() == tableID {
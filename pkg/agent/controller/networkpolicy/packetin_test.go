@@ -16,6 +16,7 @@ package networkpolicy
 
 import (
 	"fmt"
+	"net"
 	"net/netip"
 	"testing"
 	"time"
@@ -106,6 +107,28 @@ func TestController_HandlePacketIn(t *testing.T) {
 	}
 }
 
+func TestController_HandlePacketInPolicyRuleDrop(t *testing.T) {
+	controller, _, _ := newTestController()
+	var wanted bool
+	var notified int
+	controller.SetPolicyRuleDropNotifier(channel.NewSubscribableChannel("policy rule drop channel", 100), func() bool { return wanted })
+	controller.notifyPolicyRuleDropAction = func(in *ofctrl.PacketIn) error {
+		notified++
+		return nil
+	}
+	pktIn := &ofctrl.PacketIn{
+		PacketIn: &openflow15.PacketIn{},
+		UserData: []byte{uint8(openflow.PacketInCategoryNP), 0},
+	}
+
+	// The packet-in is not processed for the notifier when no capture is waiting for a dropped packet.
+	require.NoError(t, controller.HandlePacketIn(pktIn))
+	assert.Equal(t, 0, notified)
+	wanted = true
+	require.NoError(t, controller.HandlePacketIn(pktIn))
+	assert.Equal(t, 1, notified)
+}
+
 // fakeRuleCache implements Reconciler and is a static cache from rule ID to PolicyRule, used for testing.
 type fakeRuleCache struct {
 	cache map[uint32]*types.PolicyRule
@@ -233,3 +256,132 @@ func TestPublishDenyConnection(t *testing.T) {
 		})
 	}
 }
+
+func TestNotifyPolicyRuleDrop(t *testing.T) {
+	prepareMockTables()
+
+	sourceIP := net.ParseIP("1.2.3.4").To4()
+	destinationIP := net.ParseIP("5.6.7.8").To4()
+	policyRef := &v1beta2.NetworkPolicyReference{
+		Type:      v1beta2.AntreaNetworkPolicy,
+		Name:      "my-policy",
+		Namespace: "ns",
+		UID:       k8stypes.UID(uuid.New().String()),
+	}
+	ruleCache := &fakeRuleCache{
+		map[uint32]*types.PolicyRule{
+			0x11111111: {
+				PolicyRef: policyRef,
+				Name:      "my-rule",
+			},
+		},
+	}
+	allowMatch := generateRegMatch(openflow.APDispositionField.GetRegID(), []byte{0x11, 0x00, 0x00, 0x11})
+	dropMatch := generateRegMatch(openflow.APDispositionField.GetRegID(), []byte{0x11, 0x00, 0x0c, 0x11})
+	rejectMatch := generateRegMatch(openflow.APDispositionField.GetRegID(), []byte{0x11, 0x00, 0x10, 0x11})
+	conjunctionMatch := generateRegMatch(openflow.APConjIDField.GetRegID(), []byte{0x11, 0x11, 0x11, 0x11})
+	unknownConjunctionMatch := generateRegMatch(openflow.APConjIDField.GetRegID(), []byte{0x22, 0x22, 0x22, 0x22})
+
+	testCases := []struct {
+		name         string
+		matchers     []openflow15.MatchField
+		operations   uint8
+		expectedDrop *types.PolicyRuleDrop
+	}{
+		{
+			name:       "drop",
+			matchers:   []openflow15.MatchField{dropMatch, conjunctionMatch},
+			operations: openflow.PacketInNPLoggingOperation,
+			expectedDrop: &types.PolicyRuleDrop{
+				PolicyRef:     policyRef,
+				RuleName:      "my-rule",
+				Disposition:   actionDrop,
+				SourceIP:      sourceIP,
+				DestinationIP: destinationIP,
+			},
+		},
+		{
+			name:       "reject",
+			matchers:   []openflow15.MatchField{rejectMatch, conjunctionMatch},
+			operations: openflow.PacketInNPLoggingOperation | openflow.PacketInNPRejectOperation,
+			expectedDrop: &types.PolicyRuleDrop{
+				PolicyRef:     policyRef,
+				RuleName:      "my-rule",
+				Disposition:   actionReject,
+				SourceIP:      sourceIP,
+				DestinationIP: destinationIP,
+			},
+		},
+		{
+			name:       "reject without disposition",
+			matchers:   []openflow15.MatchField{conjunctionMatch},
+			operations: openflow.PacketInNPRejectOperation,
+			expectedDrop: &types.PolicyRuleDrop{
+				PolicyRef:     policyRef,
+				RuleName:      "my-rule",
+				Disposition:   actionReject,
+				SourceIP:      sourceIP,
+				DestinationIP: destinationIP,
+			},
+		},
+		{
+			name:       "allow",
+			matchers:   []openflow15.MatchField{allowMatch, conjunctionMatch},
+			operations: openflow.PacketInNPLoggingOperation,
+		},
+		{
+			name:       "K8s NetworkPolicy default drop",
+			matchers:   []openflow15.MatchField{dropMatch},
+			operations: openflow.PacketInNPLoggingOperation,
+		},
+		{
+			name:       "unknown rule",
+			matchers:   []openflow15.MatchField{dropMatch, unknownConjunctionMatch},
+			operations: openflow.PacketInNPLoggingOperation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dropReceivedCh := make(chan *types.PolicyRuleDrop, 1)
+			controller, _, _ := newTestController()
+			dropChannel := channel.NewSubscribableChannel("policy rule drop channel", 100)
+			dropChannel.Subscribe(func(dropInterface any) {
+				dropReceivedCh <- dropInterface.(*types.PolicyRuleDrop)
+			})
+			go dropChannel.Run(t.Context().Done())
+
+			controller.policyRuleDropNotifier = dropChannel
+			controller.podReconciler = ruleCache
+			pktIn := &ofctrl.PacketIn{
+				PacketIn: &openflow15.PacketIn{
+					TableId: openflow.AntreaPolicyIngressRuleTable.GetID(),
+					Match: openflow15.Match{
+						Fields: tc.matchers,
+					},
+				},
+				UserData: []byte{uint8(openflow.PacketInCategoryNP), tc.operations},
+			}
+			packet := &binding.Packet{
+				SourceIP:      sourceIP,
+				DestinationIP: destinationIP,
+			}
+			require.NoError(t, controller.notifyPolicyRuleDropParsed(pktIn, packet))
+
+			if tc.expectedDrop == nil {
+				select {
+				case drop := <-dropReceivedCh:
+					assert.Fail(t, "unexpected drop notified", "drop", drop)
+				case <-time.After(100 * time.Millisecond):
+				}
+				return
+			}
+			select {
+			case drop := <-dropReceivedCh:
+				assert.Equal(t, tc.expectedDrop, drop)
+			case <-time.After(time.Second):
+				require.Fail(t, "policy rule drop channel did not receive the expected drop")
+			}
+		})
+	}
+}
@@ -98,6 +98,12 @@ type Connection struct {
 	EgressUID                            string
 	EgressIP                             string
 	EgressNodeName                       string
+	// DestinationFQDN is only set for Pod-to-External connections, if the destination IP
+	// is a resolution result of a FQDN tracked for FQDN NetworkPolicy rules.
+	DestinationFQDN string
+	// Fields specific to deny connections rejected by layer 7 NetworkPolicy rules
+	AppProtocolName string
+	HttpVals        string
 }
 
 // NewConnectionKey creates 5-tuple of flow as connection key
@@ -258,6 +258,8 @@ func (cs *connectionStore) UpdateConnAndQueue(pqItem *priorityqueue.ItemToExpire
 		conn.PrevTCPState = conn.TCPState
 		conn.PrevReverseBytes = conn.ReverseBytes
 		conn.PrevReversePackets = conn.ReversePackets
+		conn.AppProtocolName = ""
+		conn.HttpVals = ""
 		cs.expirePriorityQueue.ResetActiveExpireTimeAndPush(pqItem, currTime)
 	}
 }
@@ -95,6 +95,10 @@ func (ds *DenyConnectionStore) AddOrUpdateConn(conn *connection.Connection) {
 		existingConn.OriginalPackets += 1
 		existingConn.StopTime = conn.StartTime
 		existingConn.IsActive = true
+		if conn.AppProtocolName != "" {
+			existingConn.AppProtocolName = conn.AppProtocolName
+			existingConn.HttpVals = conn.HttpVals
+		}
 		existingItem, exists := ds.expirePriorityQueue.KeyToItem[connKey]
 		if !exists {
 			ds.expirePriorityQueue.WriteItemToQueue(connKey, existingConn)
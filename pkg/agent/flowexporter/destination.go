@@ -55,6 +55,9 @@ type DestinationConfig struct {
 
 	isNetworkPolicyOnly bool
 	tlsConfig           *api.FlowExporterTLSConfig
+	// sharding specifies whether connections are distributed across the endpoints of the
+	// collector Service. In this case, the exporter resolves the endpoints itself.
+	sharding bool
 
 	// allowProtocolFilter specifies whether the incoming connections will be accepted
 	allowProtocolFilter []string
@@ -77,6 +80,7 @@ type Destination struct {
 
 	nodeRouteController *noderoute.Controller
 	egressQuerier       querier.EgressQuerier
+	npQuerier           querier.AgentNetworkPolicyInfoQuerier
 
 	exp       exporter.Interface
 	connected bool
@@ -120,6 +124,7 @@ func NewDestination(
 
 		nodeRouteController: nodeRouteController,
 		egressQuerier:       egressQuerier,
+		npQuerier:           npQuerier,
 
 		exp:         exporter,
 		exportConns: make([]connection.Connection, 0, maxConnsToExport*2),
@@ -172,13 +177,16 @@ func (d *Destination) getExporterTLSConfig(ctx context.Context) (*exporter.TLSCo
 func (d *Destination) Connect(ctx context.Context) error {
 	klog.V(4).InfoS("Connecting to destination", "address", d.address)
 
-	addr, err := resolveCollectorAddress(ctx, d.k8sClient, d.address)
-	if err != nil {
-		return err
+	addr := d.address
+	if !d.sharding {
+		var err error
+		addr, err = resolveCollectorAddress(ctx, d.k8sClient, d.address)
+		if err != nil {
+			return err
+		}
 	}
 
-	var tlsConfig *exporter.TLSConfig
-	tlsConfig, err = d.getExporterTLSConfig(ctx)
+	tlsConfig, err := d.getExporterTLSConfig(ctx)
 	if err != nil {
 		return err
 	}
@@ -291,6 +299,7 @@ func (d *Destination) exportConn(conn *connection.Connection) error {
 	if conn.FlowType == utils.FlowTypeToExternal {
 		if conn.SourcePodNamespace != "" && conn.SourcePodName != "" {
 			d.fillEgressInfo(conn)
+			d.fillDestinationFQDN(conn)
 		} else {
 			// Skip exporting the Pod-to-External connection at the Egress Node if it's different from the Source Node
 			return nil
@@ -355,6 +364,13 @@ func (d *Destination) fillEgressInfo(conn *connection.Connection) {
 	}
 }
 
+func (d *Destination) fillDestinationFQDN(conn *connection.Connection) {
+	if d.npQuerier == nil {
+		return
+	}
+	conn.DestinationFQDN = d.npQuerier.GetFQDNForIP(conn.FlowKey.DestinationAddress)
+}
+
 func getMinTime(t1, t2 time.Duration) time.Duration {
 	if t1 <= t2 {
 		return t1
@@ -17,6 +17,7 @@ package flowexporter
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"strings"
 	"testing"
 	"time"
@@ -308,6 +309,29 @@ func TestDestination_fillEgressInfo(t *testing.T) {
 	}
 }
 
+func TestDestination_fillDestinationFQDN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	npQuerier := queriertest.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
+	dest := &Destination{
+		npQuerier: npQuerier,
+	}
+	conn := connection.Connection{
+		FlowKey: connection.Tuple{
+			SourceAddress:      netip.MustParseAddr("10.10.0.1"),
+			DestinationAddress: netip.MustParseAddr("93.184.215.14"),
+		},
+	}
+	npQuerier.EXPECT().GetFQDNForIP(conn.FlowKey.DestinationAddress).Return("www.example.com")
+	dest.fillDestinationFQDN(&conn)
+	assert.Equal(t, "www.example.com", conn.DestinationFQDN)
+
+	// The FQDN is not filled without a NetworkPolicy querier.
+	conn.DestinationFQDN = ""
+	dest.npQuerier = nil
+	dest.fillDestinationFQDN(&conn)
+	assert.Empty(t, conn.DestinationFQDN)
+}
+
 func testSendFlowRecords(t *testing.T, v4Enabled bool, v6Enabled bool) {
 	destination := &Destination{}
 
@@ -360,6 +384,25 @@ func TestDestination_Connect(t *testing.T) {
 	metrics.ReconnectionsToFlowCollector.Dec()
 }
 
+func TestDestination_ConnectWithSharding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExporter := exportertesting.NewMockInterface(ctrl)
+	collectorAddr := "flow-aggregator/flow-aggregator:14739"
+	exp := &Destination{
+		DestinationConfig: DestinationConfig{
+			address:  collectorAddr,
+			sharding: true,
+		},
+		// The Service does not exist: the address should not be resolved to the ClusterIP.
+		k8sClient: fake.NewSimpleClientset(),
+		exp:       mockExporter,
+	}
+	mockExporter.EXPECT().ConnectToCollector(collectorAddr, nil)
+	require.NoError(t, exp.Connect(context.Background()))
+	assert.True(t, exp.connected)
+	metrics.ReconnectionsToFlowCollector.Dec()
+}
+
 func checkTotalReconnectionsMetric(t *testing.T) {
 	expected := `
 	# HELP antrea_agent_flow_collector_reconnection_count [ALPHA] Number of re-connections between Flow Exporter and flow collector. This metric gets updated whenever the connection is re-established between the Flow Exporter and the flow collector (e.g. the Flow Aggregator).
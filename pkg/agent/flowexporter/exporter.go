@@ -471,7 +471,14 @@ func (fe *FlowExporter) createDestinationFromResource(res *api.FlowExporterDesti
 		return nil, fmt.Errorf("failed resource validation: %w", err)
 	}
 	protocol := getExporterProtocol(res.Spec.Protocol)
-	exp := fe.createExporter(protocol)
+	var exp exporter.Interface
+	if res.Spec.EnableSharding {
+		exp = newShardedExporter(fe.k8sClient, func() exporter.Interface {
+			return fe.createExporter(protocol)
+		})
+	} else {
+		exp = fe.createExporter(protocol)
+	}
 	if exp == nil {
 		return nil, fmt.Errorf("failed to create exporter")
 	}
@@ -486,6 +493,7 @@ func (fe *FlowExporter) createDestinationFromResource(res *api.FlowExporterDesti
 
 		isNetworkPolicyOnly: fe.isNetworkPolicyOnly,
 		tlsConfig:           res.Spec.TLSConfig,
+		sharding:            res.Spec.EnableSharding,
 		allowProtocolFilter: ptr.Deref(res.Spec.Filter, api.FlowExporterFilter{}).Protocols,
 
 		networkPolicyReadyTime: fe.networkPolicyReadyTime,
@@ -559,6 +567,7 @@ func createStaticDestinationResFromOptions(o *options.FlowExporterOptions) (*api
 			ActiveFlowExportTimeoutSeconds: int32(o.ActiveFlowTimeout.Seconds()),
 			IdleFlowExportTimeoutSeconds:   int32(o.IdleFlowTimeout.Seconds()),
 			TLSConfig:                      feTLSConfig,
+			EnableSharding:                 o.EnableSharding,
 		},
 	}, nil
 }
@@ -593,6 +602,15 @@ func validateResource(res *api.FlowExporterDestination) error {
 			return fmt.Errorf("missing spec.TLSConfig for IPFIX connection over TLS")
 		}
 	}
+	if res.Spec.EnableSharding {
+		host, _, err := net.SplitHostPort(res.Spec.Address)
+		if err != nil {
+			return fmt.Errorf("invalid spec.address: %w", err)
+		}
+		if ns, _ := k8sutil.SplitNamespacedName(host); ns == "" {
+			return fmt.Errorf("spec.address must reference a Service (<namespace>/<name>:<port>) when sharding is enabled")
+		}
+	}
 
 	return nil
 }
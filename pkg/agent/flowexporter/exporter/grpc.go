@@ -137,6 +137,7 @@ func (e *grpcExporter) createMessage(conn *connection.Connection) *flowpb.Flow {
 			EgressName:                     conn.EgressName,
 			EgressNodeName:                 conn.EgressNodeName,
 			EgressUid:                      conn.EgressUID,
+			DestinationFqdn:                conn.DestinationFQDN,
 		},
 		Stats: &flowpb.Stats{
 			PacketTotalCount: conn.OriginalPackets,
@@ -163,6 +164,12 @@ func (e *grpcExporter) createMessage(conn *connection.Connection) *flowpb.Flow {
 		flow.K8S.DestinationNodeName = e.nodeName
 		flow.K8S.DestinationNodeUid = e.nodeUID
 	}
+	if conn.AppProtocolName != "" {
+		flow.App = &flowpb.App{
+			ProtocolName: conn.AppProtocolName,
+			HttpVals:     []byte(conn.HttpVals),
+		}
+	}
 	if conn.DestinationServicePortName != "" {
 		flow.K8S.DestinationClusterIp = conn.OriginalDestinationAddress.AsSlice()
 		flow.K8S.DestinationServicePort = uint32(conn.OriginalDestinationPort)
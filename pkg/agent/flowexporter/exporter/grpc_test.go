@@ -32,6 +32,7 @@ import (
 
 func TestGRPCExporterCreateMessage(t *testing.T) {
 	conn := flowexportertesting.GetConnection(false, true, 302, 6, "ESTABLISHED")
+	conn.DestinationFQDN = "www.example.com"
 	exp := &grpcExporter{
 		nodeName:    "this-node",
 		obsDomainID: 0xabcd,
@@ -75,6 +76,7 @@ func TestGRPCExporterCreateMessage(t *testing.T) {
 			EgressName:                    "my-egress",
 			EgressUid:                     conn.EgressUID,
 			EgressNodeName:                "egress-node",
+			DestinationFqdn:               "www.example.com",
 		},
 		Stats: &flowpb.Stats{
 			PacketTotalCount: 0xab,
@@ -92,3 +94,18 @@ func TestGRPCExporterCreateMessage(t *testing.T) {
 	msg.Ipfix.ExportTime = nil // need to reset this field as createMessage will use the current time from the system clock
 	assert.Empty(t, cmp.Diff(expectedMsg, msg, protocmp.Transform()))
 }
+
+func TestGRPCExporterCreateMessageWithApp(t *testing.T) {
+	conn := flowexportertesting.GetConnection(false, true, 302, 6, "ESTABLISHED")
+	exp := &grpcExporter{}
+	msg := exp.createMessage(conn)
+	assert.Nil(t, msg.App)
+
+	conn.AppProtocolName = "http"
+	conn.HttpVals = `{"hostname":"10.10.1.6","url":"/admin","http_method":"GET"}`
+	msg = exp.createMessage(conn)
+	assert.Empty(t, cmp.Diff(&flowpb.App{
+		ProtocolName: "http",
+		HttpVals:     []byte(`{"hostname":"10.10.1.6","url":"/admin","http_method":"GET"}`),
+	}, msg.App, protocmp.Transform()))
+}
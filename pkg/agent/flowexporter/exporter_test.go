@@ -142,6 +142,30 @@ func Test_createDestinationResFromOptions(t *testing.T) {
 					},
 				},
 			},
+		}, {
+			name: "address is namespace/name - sharding",
+			o: &options.FlowExporterOptions{
+				EnableStaticDestination: true,
+				FlowCollectorAddr:       "ns1/svc1:5678",
+				FlowCollectorProto:      "tcp",
+				ActiveFlowTimeout:       5 * time.Second,
+				IdleFlowTimeout:         2 * time.Second,
+				EnableSharding:          true,
+			},
+			want: &api.FlowExporterDestination{
+				Spec: api.FlowExporterDestinationSpec{
+					Address: "ns1/svc1:5678",
+					Protocol: api.FlowExporterProtocol{
+						IPFIX: &api.FlowExporterIPFIXConfig{
+							Transport: api.FlowExporterTransportTCP,
+						},
+					},
+					Filter:                         &api.FlowExporterFilter{},
+					ActiveFlowExportTimeoutSeconds: 5,
+					IdleFlowExportTimeoutSeconds:   2,
+					EnableSharding:                 true,
+				},
+			},
 		}, {
 			name: "address is ip - udp",
 			o: &options.FlowExporterOptions{
@@ -216,9 +240,11 @@ func Test_createDestinationResFromOptions(t *testing.T) {
 
 func TestFlowExporter_createDestinationFromResource(t *testing.T) {
 	tests := []struct {
-		name string
-		res  *api.FlowExporterDestination
-		want *Destination
+		name        string
+		res         *api.FlowExporterDestination
+		want        *Destination
+		wantSharded bool
+		wantErr     string
 	}{
 		{
 			name: "populates config",
@@ -258,13 +284,62 @@ func TestFlowExporter_createDestinationFromResource(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "sharding",
+			res: &api.FlowExporterDestination{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "dest1",
+				},
+				Spec: api.FlowExporterDestinationSpec{
+					Address: "flow-aggregator/flow-aggregator:4739",
+					Protocol: api.FlowExporterProtocol{
+						IPFIX: &api.FlowExporterIPFIXConfig{Transport: api.FlowExporterTransportTCP},
+					},
+					ActiveFlowExportTimeoutSeconds: 4,
+					IdleFlowExportTimeoutSeconds:   6,
+					EnableSharding:                 true,
+				},
+			},
+			want: &Destination{
+				DestinationConfig: DestinationConfig{
+					name:              "dest1",
+					address:           "flow-aggregator/flow-aggregator:4739",
+					activeFlowTimeout: 4 * time.Second,
+					idleFlowTimeout:   6 * time.Second,
+					sharding:          true,
+				},
+			},
+			wantSharded: true,
+		},
+		{
+			name: "sharding without Service address",
+			res: &api.FlowExporterDestination{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "dest1",
+				},
+				Spec: api.FlowExporterDestinationSpec{
+					Address: "12.23.34.45:4739",
+					Protocol: api.FlowExporterProtocol{
+						IPFIX: &api.FlowExporterIPFIXConfig{Transport: api.FlowExporterTransportTCP},
+					},
+					EnableSharding: true,
+				},
+			},
+			wantErr: "spec.address must reference a Service",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fe := &FlowExporter{}
 			got, err := fe.createDestinationFromResource(tt.res)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
 			require.NoError(t, err)
 			assert.Equal(t, tt.want.DestinationConfig, got.DestinationConfig)
+			_, sharded := got.exp.(*shardedExporter)
+			assert.Equal(t, tt.wantSharded, sharded)
 		})
 	}
 }
@@ -26,4 +26,5 @@ type FlowExporterOptions struct {
 	PollInterval            time.Duration
 	ConnectUplinkToBridge   bool
 	ProtocolFilter          []string
+	EnableSharding          bool
 }
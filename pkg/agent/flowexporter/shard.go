@@ -0,0 +1,312 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowexporter
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	discoveryinformers "k8s.io/client-go/informers/discovery/v1"
+	"k8s.io/client-go/kubernetes"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"antrea.io/antrea/v2/pkg/agent/flowexporter/connection"
+	"antrea.io/antrea/v2/pkg/agent/flowexporter/exporter"
+	k8sutil "antrea.io/antrea/v2/pkg/util/k8s"
+)
+
+// How long to wait for the initial list of EndpointSlices of the collector Service.
+const endpointSliceSyncTimeout = 30 * time.Second
+
+type shard struct {
+	address string
+	// seed is derived from the address, and is combined with the hash of a connection
+	// 5-tuple to compute the score of the shard for this connection.
+	seed uint64
+	exp  exporter.Interface
+}
+
+// shardedExporter implements exporter.Interface by distributing connections across the ready
+// endpoints of the collector Service, so that a collector which needs to receive all the
+// records for a given connection (e.g., the Flow Aggregator in Aggregate mode) can be scaled
+// horizontally. The endpoints are discovered from the EndpointSlices of the Service.
+//
+// Each connection is assigned to an endpoint using rendezvous hashing of its 5-tuple: as long
+// as they have the same view of the endpoints, the Agents on the source and destination Nodes
+// of a connection select the same endpoint, and when an endpoint is added or removed, only the
+// connections assigned to that endpoint are reassigned.
+type shardedExporter struct {
+	k8sClient   kubernetes.Interface
+	newExporter func() exporter.Interface
+
+	// The following fields are set by ConnectToCollector.
+	namespace      string
+	serviceName    string
+	servicePort    int32
+	tlsConfig      *exporter.TLSConfig
+	informerStopCh chan struct{}
+	lister         discoverylisters.EndpointSliceLister
+	// endpointsChanged is set by the EndpointSlice event handlers, and the shards are updated
+	// before the next connection is exported.
+	endpointsChanged atomic.Bool
+
+	// shards is sorted by address.
+	shards []shard
+}
+
+func newShardedExporter(k8sClient kubernetes.Interface, newExporter func() exporter.Interface) *shardedExporter {
+	return &shardedExporter{
+		k8sClient:   k8sClient,
+		newExporter: newExporter,
+	}
+}
+
+// ConnectToCollector starts watching the EndpointSlices of the collector Service and connects
+// to all of its ready endpoints. addr must be a reference to the Service, with format
+// <namespace>/<name>:<port>.
+func (e *shardedExporter) ConnectToCollector(addr string, tlsConfig *exporter.TLSConfig) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	namespace, name := k8sutil.SplitNamespacedName(host)
+	if namespace == "" {
+		return fmt.Errorf("address %s does not reference a Service, which is required for sharding", addr)
+	}
+	servicePort, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid port in address %s: %w", addr, err)
+	}
+	e.namespace = namespace
+	e.serviceName = name
+	e.servicePort = int32(servicePort)
+	e.tlsConfig = tlsConfig
+
+	e.informerStopCh = make(chan struct{})
+	informer := discoveryinformers.NewFilteredEndpointSliceInformer(e.k8sClient, namespace, 0, cache.Indexers{}, func(options *metav1.ListOptions) {
+		options.LabelSelector = labels.Set{discoveryv1.LabelServiceName: name}.String()
+	})
+	onChange := func() {
+		e.endpointsChanged.Store(true)
+	}
+	registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj any) { onChange() },
+		UpdateFunc: func(oldObj, newObj any) { onChange() },
+		DeleteFunc: func(obj any) { onChange() },
+	})
+	if err != nil {
+		return err
+	}
+	e.lister = discoverylisters.NewEndpointSliceLister(informer.GetIndexer())
+	go informer.Run(e.informerStopCh)
+	ctx, cancel := context.WithTimeout(context.Background(), endpointSliceSyncTimeout)
+	defer cancel()
+	// Wait for the handler to receive the initial list, so that the events for the initial
+	// list do not cause the shards to be synced again.
+	if !cache.WaitForCacheSync(ctx.Done(), registration.HasSynced) {
+		return fmt.Errorf("timed out waiting for EndpointSlices of Service %s/%s", namespace, name)
+	}
+	e.endpointsChanged.Store(false)
+	return e.syncShards()
+}
+
+func (e *shardedExporter) Export(conn *connection.Connection) error {
+	if e.endpointsChanged.Swap(false) {
+		if err := e.syncShards(); err != nil {
+			return err
+		}
+	}
+	return e.shards[selectShard(e.shards, &conn.FlowKey)].exp.Export(conn)
+}
+
+func (e *shardedExporter) CloseConnToCollector() {
+	if e.informerStopCh != nil {
+		close(e.informerStopCh)
+		e.informerStopCh = nil
+	}
+	for _, s := range e.shards {
+		s.exp.CloseConnToCollector()
+	}
+	e.shards = nil
+}
+
+// syncShards updates the shards to match the current ready endpoints of the collector Service.
+// It connects to the new endpoints and disconnects from the endpoints which have been removed.
+func (e *shardedExporter) syncShards() error {
+	addresses, err := e.getEndpointAddresses()
+	if err != nil {
+		return err
+	}
+	if len(addresses) == 0 {
+		return fmt.Errorf("no ready endpoint for Service %s/%s", e.namespace, e.serviceName)
+	}
+	existingShards := make(map[string]shard, len(e.shards))
+	for _, s := range e.shards {
+		existingShards[s.address] = s
+	}
+	shards := make([]shard, 0, len(addresses))
+	var errs []error
+	for _, address := range addresses {
+		if s, ok := existingShards[address]; ok {
+			shards = append(shards, s)
+			delete(existingShards, address)
+			continue
+		}
+		exp := e.newExporter()
+		if err := exp.ConnectToCollector(address, e.tlsConfig); err != nil {
+			errs = append(errs, fmt.Errorf("failed to connect to endpoint %s: %w", address, err))
+			exp.CloseConnToCollector()
+			continue
+		}
+		shards = append(shards, shard{
+			address: address,
+			seed:    hashShardAddress(address),
+			exp:     exp,
+		})
+	}
+	for _, s := range existingShards {
+		s.exp.CloseConnToCollector()
+	}
+	e.shards = shards
+	if len(errs) > 0 {
+		// Connections are not exported if one of the endpoints is not reachable, as it
+		// would cause records for the same connection to be sent to different endpoints.
+		return errors.Join(errs...)
+	}
+	klog.InfoS("Updated collector endpoints for sharding", "service", klog.KRef(e.namespace, e.serviceName), "endpoints", addresses)
+	return nil
+}
+
+// getEndpointAddresses returns the sorted addresses (<IP>:<port>) of the ready endpoints of the
+// collector Service, for the Service port used by the exporter.
+func (e *shardedExporter) getEndpointAddresses() ([]string, error) {
+	// The EndpointSlice ports are matched with the Service port using its name.
+	svc, err := e.k8sClient.CoreV1().Services(e.namespace).Get(context.TODO(), e.serviceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Service %s/%s: %w", e.namespace, e.serviceName, err)
+	}
+	var servicePort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Port == e.servicePort {
+			servicePort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if servicePort == nil {
+		return nil, fmt.Errorf("port %d not found in Service %s/%s", e.servicePort, e.namespace, e.serviceName)
+	}
+	protocol := servicePort.Protocol
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	// Only the endpoints of the primary IP family of the Service are used, so that each
+	// collector instance is only selected once.
+	addressType := discoveryv1.AddressTypeIPv4
+	if len(svc.Spec.IPFamilies) > 0 && svc.Spec.IPFamilies[0] == corev1.IPv6Protocol {
+		addressType = discoveryv1.AddressTypeIPv6
+	}
+
+	endpointSlices, err := e.lister.EndpointSlices(e.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var addresses []string
+	for _, endpointSlice := range endpointSlices {
+		if endpointSlice.AddressType != addressType {
+			continue
+		}
+		var port int32
+		for _, p := range endpointSlice.Ports {
+			if ptr.Deref(p.Name, "") == servicePort.Name && ptr.Deref(p.Protocol, corev1.ProtocolTCP) == protocol && p.Port != nil {
+				port = *p.Port
+				break
+			}
+		}
+		if port == 0 {
+			continue
+		}
+		for _, endpoint := range endpointSlice.Endpoints {
+			if !ptr.Deref(endpoint.Conditions.Ready, true) || len(endpoint.Addresses) == 0 {
+				continue
+			}
+			addresses = append(addresses, net.JoinHostPort(endpoint.Addresses[0], strconv.Itoa(int(port))))
+		}
+	}
+	// The same endpoint may transiently be part of multiple EndpointSlices.
+	slices.Sort(addresses)
+	return slices.Compact(addresses), nil
+}
+
+func hashShardAddress(address string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(address))
+	return h.Sum64()
+}
+
+// hashFlowKey hashes the 5-tuple of a connection. The result only depends on the 5-tuple, so
+// that all the Agents compute the same hash for a given connection.
+func hashFlowKey(flowKey *connection.Tuple) uint64 {
+	h := fnv.New64a()
+	h.Write(flowKey.SourceAddress.AsSlice())
+	h.Write(flowKey.DestinationAddress.AsSlice())
+	var buf [5]byte
+	buf[0] = flowKey.Protocol
+	binary.BigEndian.PutUint16(buf[1:3], flowKey.SourcePort)
+	binary.BigEndian.PutUint16(buf[3:5], flowKey.DestinationPort)
+	h.Write(buf[:])
+	return h.Sum64()
+}
+
+// mix64 is the finalizer of the SplitMix64 generator, which is used to combine the hash of a
+// 5-tuple with the seed of a shard.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// selectShard returns the index of the shard with the highest score for the given 5-tuple.
+// shards must not be empty.
+func selectShard(shards []shard, flowKey *connection.Tuple) int {
+	keyHash := hashFlowKey(flowKey)
+	selected := 0
+	var maxScore uint64
+	for i := range shards {
+		score := mix64(keyHash ^ shards[i].seed)
+		if i == 0 || score > maxScore {
+			selected = i
+			maxScore = score
+		}
+	}
+	return selected
+}
@@ -0,0 +1,290 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowexporter
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"antrea.io/antrea/v2/pkg/agent/flowexporter/connection"
+	"antrea.io/antrea/v2/pkg/agent/flowexporter/exporter"
+	exportertesting "antrea.io/antrea/v2/pkg/agent/flowexporter/exporter/testing"
+)
+
+func newTestFlowKey(i int) connection.Tuple {
+	return connection.Tuple{
+		SourceAddress:      netip.AddrFrom4([4]byte{10, 10, byte(i >> 8), byte(i)}),
+		DestinationAddress: netip.MustParseAddr("10.20.0.1"),
+		Protocol:           6,
+		SourcePort:         uint16(30000 + i),
+		DestinationPort:    80,
+	}
+}
+
+func newTestShards(addresses ...string) []shard {
+	shards := make([]shard, len(addresses))
+	for i, address := range addresses {
+		shards[i] = shard{address: address, seed: hashShardAddress(address)}
+	}
+	return shards
+}
+
+func TestSelectShard(t *testing.T) {
+	const numConns = 3000
+	shards := newTestShards("10.0.0.1:14739", "10.0.0.2:14739", "10.0.0.3:14739")
+	assignments := make([]string, numConns)
+	counts := make(map[string]int)
+	for i := range numConns {
+		flowKey := newTestFlowKey(i)
+		address := shards[selectShard(shards, &flowKey)].address
+		assignments[i] = address
+		counts[address]++
+		// The selection is deterministic.
+		assert.Equal(t, address, shards[selectShard(shards, &flowKey)].address)
+	}
+	// Connections are distributed across all the shards.
+	for _, s := range shards {
+		assert.InDelta(t, numConns/len(shards), counts[s.address], numConns/10, "Unexpected number of connections for shard %s", s.address)
+	}
+
+	// Only the connections assigned to the removed shard are reassigned.
+	remainingShards := newTestShards("10.0.0.1:14739", "10.0.0.3:14739")
+	for i := range numConns {
+		flowKey := newTestFlowKey(i)
+		address := remainingShards[selectShard(remainingShards, &flowKey)].address
+		if assignments[i] != "10.0.0.2:14739" {
+			assert.Equal(t, assignments[i], address)
+		}
+	}
+
+	// Only connections assigned to the new shard are reassigned.
+	moreShards := newTestShards("10.0.0.1:14739", "10.0.0.2:14739", "10.0.0.3:14739", "10.0.0.4:14739")
+	moved := 0
+	for i := range numConns {
+		flowKey := newTestFlowKey(i)
+		address := moreShards[selectShard(moreShards, &flowKey)].address
+		if address != assignments[i] {
+			assert.Equal(t, "10.0.0.4:14739", address)
+			moved++
+		}
+	}
+	assert.InDelta(t, numConns/4, moved, numConns/10)
+}
+
+func newTestCollectorEndpointSlice(name string, addressType discoveryv1.AddressType, port int32, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "flow-aggregator",
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: "flow-aggregator",
+			},
+		},
+		AddressType: addressType,
+		Endpoints:   endpoints,
+		Ports: []discoveryv1.EndpointPort{
+			{
+				Name:     ptr.To("ipfix-tcp"),
+				Port:     ptr.To[int32](4739),
+				Protocol: ptr.To(corev1.ProtocolTCP),
+			},
+			{
+				Name:     ptr.To("grpc"),
+				Port:     ptr.To(port),
+				Protocol: ptr.To(corev1.ProtocolTCP),
+			},
+		},
+	}
+}
+
+func newTestCollectorEndpoint(address string, ready bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses: []string{address},
+		Conditions: discoveryv1.EndpointConditions{
+			Ready: ptr.To(ready),
+		},
+	}
+}
+
+func TestShardedExporter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "flow-aggregator",
+			Namespace: "flow-aggregator",
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:  "10.96.0.10",
+			IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+			Ports: []corev1.ServicePort{
+				{Name: "ipfix-tcp", Port: 4739, Protocol: corev1.ProtocolTCP},
+				{Name: "grpc", Port: 14739, Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+	endpointSlice := newTestCollectorEndpointSlice("flow-aggregator-abcde", discoveryv1.AddressTypeIPv4, 14740,
+		newTestCollectorEndpoint("10.0.0.1", true),
+		newTestCollectorEndpoint("10.0.0.2", true),
+		newTestCollectorEndpoint("10.0.0.3", false),
+	)
+	ipv6EndpointSlice := newTestCollectorEndpointSlice("flow-aggregator-fghij", discoveryv1.AddressTypeIPv6, 14740,
+		newTestCollectorEndpoint("fd00::1", true),
+	)
+	k8sClient := fake.NewSimpleClientset(svc, endpointSlice, ipv6EndpointSlice)
+
+	exporters := make(map[string]*exportertesting.MockInterface)
+	newExporter := func(address string) {
+		exp := exportertesting.NewMockInterface(ctrl)
+		exp.EXPECT().ConnectToCollector(address, nil)
+		exporters[address] = exp
+	}
+	var pendingExporters []*exportertesting.MockInterface
+	e := newShardedExporter(k8sClient, func() exporter.Interface {
+		exp := pendingExporters[0]
+		pendingExporters = pendingExporters[1:]
+		return exp
+	})
+
+	newExporter("10.0.0.1:14740")
+	newExporter("10.0.0.2:14740")
+	pendingExporters = append(pendingExporters, exporters["10.0.0.1:14740"], exporters["10.0.0.2:14740"])
+	require.NoError(t, e.ConnectToCollector("flow-aggregator/flow-aggregator:14739", nil))
+	require.Len(t, e.shards, 2)
+	assert.Equal(t, "10.0.0.1:14740", e.shards[0].address)
+	assert.Equal(t, "10.0.0.2:14740", e.shards[1].address)
+
+	exportConn := func(i int) string {
+		conn := &connection.Connection{FlowKey: newTestFlowKey(i)}
+		address := e.shards[selectShard(e.shards, &conn.FlowKey)].address
+		exporters[address].EXPECT().Export(conn)
+		require.NoError(t, e.Export(conn))
+		return address
+	}
+	exportedAddresses := make(map[string]bool)
+	for i := range 20 {
+		exportedAddresses[exportConn(i)] = true
+	}
+	assert.Len(t, exportedAddresses, 2)
+
+	// The third endpoint becomes ready, and the first one is removed.
+	newExporter("10.0.0.3:14740")
+	pendingExporters = append(pendingExporters, exporters["10.0.0.3:14740"])
+	exporters["10.0.0.1:14740"].EXPECT().CloseConnToCollector()
+	endpointSlice = newTestCollectorEndpointSlice("flow-aggregator-abcde", discoveryv1.AddressTypeIPv4, 14740,
+		newTestCollectorEndpoint("10.0.0.2", true),
+		newTestCollectorEndpoint("10.0.0.3", true),
+	)
+	_, err := k8sClient.DiscoveryV1().EndpointSlices("flow-aggregator").Update(context.TODO(), endpointSlice, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, e.endpointsChanged.Load, 2*time.Second, 10*time.Millisecond)
+	exporters["10.0.0.2:14740"].EXPECT().Export(gomock.Any()).AnyTimes()
+	exporters["10.0.0.3:14740"].EXPECT().Export(gomock.Any()).AnyTimes()
+	for i := range 20 {
+		require.NoError(t, e.Export(&connection.Connection{FlowKey: newTestFlowKey(i)}))
+	}
+	require.Len(t, e.shards, 2)
+	assert.Equal(t, "10.0.0.2:14740", e.shards[0].address)
+	assert.Equal(t, "10.0.0.3:14740", e.shards[1].address)
+
+	exporters["10.0.0.2:14740"].EXPECT().CloseConnToCollector()
+	exporters["10.0.0.3:14740"].EXPECT().CloseConnToCollector()
+	e.CloseConnToCollector()
+	assert.Empty(t, e.shards)
+}
+
+func TestShardedExporterErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "flow-aggregator",
+			Namespace: "flow-aggregator",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "grpc", Port: 14739, Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		objects     []runtime.Object
+		address     string
+		connectErr  error
+		expectedErr string
+	}{
+		{
+			name:        "IP address",
+			address:     "10.96.0.10:14739",
+			expectedErr: "does not reference a Service",
+		},
+		{
+			name:        "missing Service",
+			address:     "flow-aggregator/flow-aggregator:14739",
+			expectedErr: "failed to get Service",
+		},
+		{
+			name:        "unknown port",
+			objects:     []runtime.Object{svc},
+			address:     "flow-aggregator/flow-aggregator:4739",
+			expectedErr: "port 4739 not found in Service",
+		},
+		{
+			name: "no ready endpoint",
+			objects: []runtime.Object{svc, newTestCollectorEndpointSlice("flow-aggregator-abcde", discoveryv1.AddressTypeIPv4, 14739,
+				newTestCollectorEndpoint("10.0.0.1", false),
+			)},
+			address:     "flow-aggregator/flow-aggregator:14739",
+			expectedErr: "no ready endpoint",
+		},
+		{
+			name: "unreachable endpoint",
+			objects: []runtime.Object{svc, newTestCollectorEndpointSlice("flow-aggregator-abcde", discoveryv1.AddressTypeIPv4, 14739,
+				newTestCollectorEndpoint("10.0.0.1", true),
+			)},
+			address:     "flow-aggregator/flow-aggregator:14739",
+			connectErr:  fmt.Errorf("connection refused"),
+			expectedErr: "failed to connect to endpoint 10.0.0.1:14739: connection refused",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := fake.NewSimpleClientset(tc.objects...)
+			e := newShardedExporter(k8sClient, func() exporter.Interface {
+				exp := exportertesting.NewMockInterface(ctrl)
+				exp.EXPECT().ConnectToCollector(gomock.Any(), nil).Return(tc.connectErr)
+				exp.EXPECT().CloseConnToCollector()
+				return exp
+			})
+			err := e.ConnectToCollector(tc.address, nil)
+			assert.ErrorContains(t, err, tc.expectedErr)
+			assert.Empty(t, e.shards)
+			e.CloseConnToCollector()
+		})
+	}
+}
@@ -70,6 +70,17 @@ var (
 		},
 	)
 
+	L7NetworkPolicyRejectedRequestCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "l7_networkpolicy_rejected_request_count",
+			Help:           "Number of requests rejected by layer 7 NetworkPolicy rules on local Node, partitioned by rule direction and application layer protocol.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"direction", "app_protocol"},
+	)
+
 	OVSTotalFlowCount = metrics.NewGauge(&metrics.GaugeOpts{
 		Namespace:      metricNamespaceAntrea,
 		Subsystem:      metricSubsystemAgent,
@@ -195,6 +206,85 @@ var (
 			Buckets: []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
 		},
 	)
+
+	BGPPeerSessionEstablished = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "bgp_peer_session_established",
+			Help:           "Whether the BGP session with the BGP peer is established (1) or not (0).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"peer_address", "peer_asn"},
+	)
+
+	BGPPeerSessionUptime = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "bgp_peer_session_uptime_seconds",
+			Help:           "Uptime of the BGP session with the BGP peer, which is 0 if the session is not established. A decrease indicates that the session has been re-established.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"peer_address", "peer_asn"},
+	)
+
+	BGPPeerAdvertisedRouteCount = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "bgp_peer_advertised_route_count",
+			Help:           "Number of routes advertised to the BGP peer.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"peer_address", "peer_asn"},
+	)
+
+	BGPPeerReceivedRouteCount = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "bgp_peer_received_route_count",
+			Help:           "Number of routes received from the BGP peer.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"peer_address", "peer_asn"},
+	)
+
+	ConnectivityProbeSentCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "connectivity_probe_sent_count",
+			Help:           "Number of probes sent by the ConnectivityProbe from the Pods on local Node.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"probe"},
+	)
+
+	ConnectivityProbeSucceededCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "connectivity_probe_succeeded_count",
+			Help:           "Number of successful probes sent by the ConnectivityProbe from the Pods on local Node.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"probe"},
+	)
+
+	ConnectivityProbeLatency = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "connectivity_probe_latency_seconds",
+			Help:           "Round-trip time of the successful probes sent by the ConnectivityProbe from the Pods on local Node.",
+			StabilityLevel: metrics.ALPHA,
+			// Buckets for round-trip times in seconds (100us to 5s range)
+			Buckets: []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+		},
+		[]string{"probe"},
+	)
 )
 
 func InitializePrometheusMetrics() {
@@ -204,6 +294,8 @@ func InitializePrometheusMetrics() {
 	InitializeNetworkPolicyMetrics()
 	InitializeOVSMetrics()
 	InitializeConnectionMetrics()
+	InitializeBGPMetrics()
+	InitializeConnectivityProbeMetrics()
 }
 
 func InitializePodMetrics() {
@@ -224,6 +316,10 @@ func InitializeNetworkPolicyMetrics() {
 	if err := legacyregistry.Register(NetworkPolicyCount); err != nil {
 		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_networkpolicy_count")
 	}
+
+	if err := legacyregistry.Register(L7NetworkPolicyRejectedRequestCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_l7_networkpolicy_rejected_request_count")
+	}
 }
 
 func InitializeOVSMetrics() {
@@ -278,3 +374,30 @@ func InitializeConnectionMetrics() {
 		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_conntrack_poll_cycle_duration_seconds")
 	}
 }
+
+func InitializeBGPMetrics() {
+	if err := legacyregistry.Register(BGPPeerSessionEstablished); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_bgp_peer_session_established")
+	}
+	if err := legacyregistry.Register(BGPPeerSessionUptime); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_bgp_peer_session_uptime_seconds")
+	}
+	if err := legacyregistry.Register(BGPPeerAdvertisedRouteCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_bgp_peer_advertised_route_count")
+	}
+	if err := legacyregistry.Register(BGPPeerReceivedRouteCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_bgp_peer_received_route_count")
+	}
+}
+
+func InitializeConnectivityProbeMetrics() {
+	if err := legacyregistry.Register(ConnectivityProbeSentCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_connectivity_probe_sent_count")
+	}
+	if err := legacyregistry.Register(ConnectivityProbeSucceededCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_connectivity_probe_succeeded_count")
+	}
+	if err := legacyregistry.Register(ConnectivityProbeLatency); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_connectivity_probe_latency_seconds")
+	}
+}
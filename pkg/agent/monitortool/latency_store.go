@@ -17,14 +17,21 @@
 package monitortool
 
 import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"net"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/containernetworking/plugins/pkg/ip"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
@@ -45,8 +52,55 @@ type LatencyStore struct {
 	// If the agent is running in networkPolicyOnly mode, the value will be the transport IP of the Node.
 	// Otherwise, the value will be the gateway IP of the Node
 	nodeTargetIPsMap map[string][]net.IP
+	// The map of Node name to Node labels, it will be changed by Node watcher
+	nodeLabelsMap map[string]labels.Set
+	// The map of Node name to all the IPs from which the Node may send probes (gateway and
+	// transport IPs), it will be changed by Node watcher
+	nodeSourceIPsMap map[string]sets.Set[string]
+	// The map of Node IP to the names of the Nodes which have this IP, it is the reverse index of
+	// nodeSourceIPsMap and is used to validate the source of the probes received by the agent
+	sourceIPNodesMap map[string]sets.Set[string]
+	// The selection of the Nodes to which probes are sent
+	selection nodeSelection
 }
 
+// nodeSelection determines the peer Nodes to which probes are sent.
+type nodeSelection struct {
+	// selector selects the peer Nodes by labels. nil means all Nodes are selected.
+	selector labels.Selector
+	// maxNodes is the maximum number of peer Nodes to probe. 0 means no limit.
+	maxNodes int
+	// localNodeName is combined with the names of the peer Nodes to rank them when there are
+	// more than maxNodes selected Nodes, so that each Node probes a different subset.
+	localNodeName string
+}
+
+// latencyThresholds are the thresholds above which the connection to a peer Node is considered
+// degraded.
+type latencyThresholds struct {
+	// latency is the threshold for the measured rtts. 0 means no threshold.
+	latency time.Duration
+	// packetLossPercent is the threshold for the packet loss percentage. 0 means no threshold.
+	packetLossPercent int32
+}
+
+func (t latencyThresholds) enabled() bool {
+	return t.latency > 0 || t.packetLossPercent > 0
+}
+
+// lossWindowSize is the number of most recent probes over which the packet loss percentage is
+// calculated.
+const lossWindowSize = 100
+
+// jitterGain is the gain of the jitter estimator, as defined in RFC 3550.
+const jitterGain = 16
+
+// latencyThresholdSamples is the number of consecutive rtt measurements which must all exceed the
+// latency threshold for the connection to be considered degraded, and which must all be within the
+// threshold for the connection to be considered recovered. This avoids flapping when the rtt
+// fluctuates around the threshold.
+const latencyThresholdSamples = 3
+
 // NodeIPLatencyEntry is the entry of the latency map.
 type NodeIPLatencyEntry struct {
 	// The timestamp of the last sent packet
@@ -55,6 +109,122 @@ type NodeIPLatencyEntry struct {
 	LastRecvTime time.Time
 	// The last valid rtt of the connection
 	LastMeasuredRTT time.Duration
+	// The number of sent packets
+	PacketsSent int64
+	// The number of sent packets for which no reply was received
+	PacketsLost int64
+	// The smoothed mean deviation of the difference between consecutive rtts
+	Jitter time.Duration
+
+	// The send times of the packets for which a reply is still expected, keyed by sequence number
+	outstandingProbes map[uint16]time.Time
+	// The most recent rtts, used as a ring buffer
+	recentRTTs [latencyThresholdSamples]time.Duration
+	// The index in recentRTTs at which the next rtt is stored
+	recentRTTsIndex int
+	// The number of rtts stored in recentRTTs
+	recentRTTsCount int
+	// Whether the rtt exceeded the latency threshold the last time it was checked
+	latencyExceeded bool
+	// The outcomes (true for lost) of the most recent packets, used as a ring buffer
+	recentLosses [lossWindowSize]bool
+	// The index in recentLosses at which the next outcome is stored
+	recentLossesIndex int
+	// The number of outcomes stored in recentLosses
+	recentLossesCount int
+}
+
+// recordSend updates the entry when the packet with the given sequence number is sent.
+func (e *NodeIPLatencyEntry) recordSend(seq uint16, sendTime time.Time) {
+	if e.outstandingProbes == nil {
+		e.outstandingProbes = make(map[uint16]time.Time)
+	}
+	e.outstandingProbes[seq] = sendTime
+	e.LastSendTime = sendTime
+	e.PacketsSent++
+}
+
+// recordReply updates the entry when the reply to the packet with the given sequence number is
+// received. It returns false if no reply is expected for this packet, i.e. if it was never sent or
+// if it has already been answered or considered lost, in which case the reply is ignored.
+func (e *NodeIPLatencyEntry) recordReply(seq uint16, recvTime time.Time) bool {
+	sendTime, ok := e.outstandingProbes[seq]
+	if !ok {
+		return false
+	}
+	delete(e.outstandingProbes, seq)
+	rtt := recvTime.Sub(sendTime)
+	// Jitter is only defined once there are 2 rtt measurements.
+	if !e.LastRecvTime.IsZero() {
+		d := rtt - e.LastMeasuredRTT
+		if d < 0 {
+			d = -d
+		}
+		e.Jitter += (d - e.Jitter) / jitterGain
+	}
+	e.LastRecvTime = recvTime
+	e.LastMeasuredRTT = rtt
+	e.recentRTTs[e.recentRTTsIndex] = rtt
+	e.recentRTTsIndex = (e.recentRTTsIndex + 1) % latencyThresholdSamples
+	e.recentRTTsCount = min(e.recentRTTsCount+1, latencyThresholdSamples)
+	e.recordOutcome(false)
+	return true
+}
+
+// checkLatency returns whether the rtt exceeds the given threshold, and the highest of the most
+// recent rtts. The result only changes when the latencyThresholdSamples most recent rtts are all
+// above, or all within, the threshold.
+func (e *NodeIPLatencyEntry) checkLatency(threshold time.Duration) (bool, time.Duration) {
+	var exceeded int
+	var maxRTT time.Duration
+	for _, rtt := range e.recentRTTs[:e.recentRTTsCount] {
+		if rtt > threshold {
+			exceeded++
+		}
+		maxRTT = max(maxRTT, rtt)
+	}
+	if e.recentRTTsCount == latencyThresholdSamples {
+		switch exceeded {
+		case latencyThresholdSamples:
+			e.latencyExceeded = true
+		case 0:
+			e.latencyExceeded = false
+		}
+	}
+	return e.latencyExceeded, maxRTT
+}
+
+// expireProbes considers lost the packets which were sent at least timeout before now and have not
+// been answered.
+func (e *NodeIPLatencyEntry) expireProbes(now time.Time, timeout time.Duration) {
+	for seq, sendTime := range e.outstandingProbes {
+		if now.Sub(sendTime) < timeout {
+			continue
+		}
+		delete(e.outstandingProbes, seq)
+		e.PacketsLost++
+		e.recordOutcome(true)
+	}
+}
+
+func (e *NodeIPLatencyEntry) recordOutcome(lost bool) {
+	e.recentLosses[e.recentLossesIndex] = lost
+	e.recentLossesIndex = (e.recentLossesIndex + 1) % lossWindowSize
+	e.recentLossesCount = min(e.recentLossesCount+1, lossWindowSize)
+}
+
+// PacketLossPercent returns the percentage of lost packets among the most recent packets.
+func (e *NodeIPLatencyEntry) PacketLossPercent() int32 {
+	if e.recentLossesCount == 0 {
+		return 0
+	}
+	var lost int
+	for i := range e.recentLossesCount {
+		if e.recentLosses[i] {
+			lost++
+		}
+	}
+	return int32(lost * 100 / e.recentLossesCount)
 }
 
 // NewLatencyStore creates a new LatencyStore.
@@ -62,6 +232,9 @@ func NewLatencyStore(isNetworkPolicyOnly bool) *LatencyStore {
 	store := &LatencyStore{
 		nodeIPLatencyMap:    make(map[string]*NodeIPLatencyEntry),
 		nodeTargetIPsMap:    make(map[string][]net.IP),
+		nodeLabelsMap:       make(map[string]labels.Set),
+		nodeSourceIPsMap:    make(map[string]sets.Set[string]),
+		sourceIPNodesMap:    make(map[string]sets.Set[string]),
 		isNetworkPolicyOnly: isNetworkPolicyOnly,
 	}
 
@@ -111,6 +284,32 @@ func (s *LatencyStore) SetNodeIPLatencyEntry(nodeIP string, mutator func(entry *
 	mutator(entry)
 }
 
+// updateNodeIPLatencyEntry updates the NodeIPLatencyEntry for the given Node IP if it exists, and
+// returns whether it exists.
+func (s *LatencyStore) updateNodeIPLatencyEntry(nodeIP string, mutator func(entry *NodeIPLatencyEntry)) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, ok := s.nodeIPLatencyMap[nodeIP]
+	if !ok {
+		return false
+	}
+	mutator(entry)
+	return true
+}
+
+// expireProbes considers lost the packets sent to all the Node IPs which were sent at least timeout
+// ago and have not been answered.
+func (s *LatencyStore) expireProbes(timeout time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	for _, entry := range s.nodeIPLatencyMap {
+		entry.expireProbes(now, timeout)
+	}
+}
+
 // addNode adds a Node to the latency store
 func (s *LatencyStore) addNode(node *corev1.Node) {
 	s.mutex.Lock()
@@ -125,6 +324,8 @@ func (s *LatencyStore) deleteNode(node *corev1.Node) {
 	defer s.mutex.Unlock()
 
 	delete(s.nodeTargetIPsMap, node.Name)
+	delete(s.nodeLabelsMap, node.Name)
+	s.setNodeSourceIPs(node.Name, nil)
 }
 
 // updateNode updates a Node name in the latency store
@@ -138,6 +339,8 @@ func (s *LatencyStore) updateNode(new *corev1.Node) {
 
 // updateNodeMap updates the nodeTargetIPsMap with the IPs of the given Node.
 func (s *LatencyStore) updateNodeMap(node *corev1.Node) {
+	s.setNodeSourceIPs(node.Name, getNodeSourceIPs(node))
+
 	nodeIPs, err := s.getNodeIPs(node)
 	if err != nil {
 		klog.ErrorS(err, "Failed to get IPs for Node", "nodeName", node.Name)
@@ -145,6 +348,104 @@ func (s *LatencyStore) updateNodeMap(node *corev1.Node) {
 	}
 
 	s.nodeTargetIPsMap[node.Name] = nodeIPs
+	s.nodeLabelsMap[node.Name] = labels.Set(node.Labels)
+}
+
+// setNodeSourceIPs replaces the source IPs of the given Node and updates the reverse index
+// accordingly. The caller must hold the lock.
+func (s *LatencyStore) setNodeSourceIPs(nodeName string, sourceIPs sets.Set[string]) {
+	for nodeIP := range s.nodeSourceIPsMap[nodeName] {
+		if sourceIPs.Has(nodeIP) {
+			continue
+		}
+		nodeNames := s.sourceIPNodesMap[nodeIP]
+		nodeNames.Delete(nodeName)
+		if nodeNames.Len() == 0 {
+			delete(s.sourceIPNodesMap, nodeIP)
+		}
+	}
+	if sourceIPs.Len() == 0 {
+		delete(s.nodeSourceIPsMap, nodeName)
+		return
+	}
+	for nodeIP := range sourceIPs {
+		nodeNames, ok := s.sourceIPNodesMap[nodeIP]
+		if !ok {
+			nodeNames = sets.New[string]()
+			s.sourceIPNodesMap[nodeIP] = nodeNames
+		}
+		nodeNames.Insert(nodeName)
+	}
+	s.nodeSourceIPsMap[nodeName] = sourceIPs
+}
+
+// getNodeSourceIPs returns all the IPs from which the given Node may send probes, regardless of
+// the agent mode: the probes are sent from the gateway IPs when the peer gateway IPs are probed,
+// and from the transport IPs otherwise.
+func getNodeSourceIPs(node *corev1.Node) sets.Set[string] {
+	sourceIPs := sets.New[string]()
+	// Errors are ignored as a Node may not have a PodCIDR, in which case only its transport IPs
+	// are known.
+	gwIPs, _ := getGWIPs(node)
+	transportIPs, _ := getTransportIPs(node)
+	for _, nodeIP := range append(gwIPs, transportIPs...) {
+		sourceIPs.Insert(nodeIP.String())
+	}
+	return sourceIPs
+}
+
+// IsNodeIP returns whether the given IP is one of the gateway or transport IPs of a known peer
+// Node.
+func (s *LatencyStore) IsNodeIP(nodeIP string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, ok := s.sourceIPNodesMap[nodeIP]
+	return ok
+}
+
+// setNodeSelection sets the selection of the Nodes to which probes are sent.
+func (s *LatencyStore) setNodeSelection(selection nodeSelection) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.selection = selection
+}
+
+// selectedNodeNames returns the names of the Nodes to which probes are sent. When more than
+// maxNodes Nodes match the selector, rendezvous hashing is used to select the subset: the Nodes
+// are ranked by the hash of their name combined with the name of the local Node, so that the
+// subset only changes marginally when Nodes are added or removed, and different Nodes probe
+// different subsets. The caller must hold the lock.
+func (s *LatencyStore) selectedNodeNames() []string {
+	nodeNames := make([]string, 0, len(s.nodeTargetIPsMap))
+	for nodeName := range s.nodeTargetIPsMap {
+		if s.selection.selector != nil && !s.selection.selector.Matches(s.nodeLabelsMap[nodeName]) {
+			continue
+		}
+		nodeNames = append(nodeNames, nodeName)
+	}
+	if s.selection.maxNodes <= 0 || len(nodeNames) <= s.selection.maxNodes {
+		return nodeNames
+	}
+
+	type rankedNode struct {
+		name string
+		rank uint64
+	}
+	rankedNodes := make([]rankedNode, 0, len(nodeNames))
+	for _, nodeName := range nodeNames {
+		h := sha256.Sum256([]byte(s.selection.localNodeName + "/" + nodeName))
+		rankedNodes = append(rankedNodes, rankedNode{name: nodeName, rank: binary.BigEndian.Uint64(h[:8])})
+	}
+	slices.SortFunc(rankedNodes, func(a, b rankedNode) int {
+		return cmp.Or(cmp.Compare(a.rank, b.rank), cmp.Compare(a.name, b.name))
+	})
+	nodeNames = nodeNames[:0]
+	for _, node := range rankedNodes[:s.selection.maxNodes] {
+		nodeNames = append(nodeNames, node.name)
+	}
+	return nodeNames
 }
 
 // getNodeIPs returns the target IPs of the given Node based on the agent mode.
@@ -219,16 +520,17 @@ func getPodCIDRsOnNode(node *corev1.Node) []string {
 	return []string{node.Spec.PodCIDR}
 }
 
-// ListNodeIPs returns the list of all Node IPs in the latency store.
+// ListNodeIPs returns the list of the IPs of the selected Nodes in the latency store.
 func (s *LatencyStore) ListNodeIPs() []net.IP {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	// Allocate a slice with a capacity equal to twice the size of the map,
+	nodeNames := s.selectedNodeNames()
+	// Allocate a slice with a capacity equal to twice the number of Nodes,
 	// as we can have up to 2 IP addresses per Node in dual-stack case.
-	nodeIPs := make([]net.IP, 0, 2*len(s.nodeTargetIPsMap))
-	for _, ips := range s.nodeTargetIPsMap {
-		nodeIPs = append(nodeIPs, ips...)
+	nodeIPs := make([]net.IP, 0, 2*len(nodeNames))
+	for _, nodeName := range nodeNames {
+		nodeIPs = append(nodeIPs, s.nodeTargetIPsMap[nodeName]...)
 	}
 
 	return nodeIPs
@@ -239,9 +541,10 @@ func (s *LatencyStore) DeleteStaleNodeIPs() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	// The entries of the Nodes which are no longer selected are deleted as well.
 	nodeIPSet := sets.New[string]()
-	for _, ips := range s.nodeTargetIPsMap {
-		for _, ip := range ips {
+	for _, nodeName := range s.selectedNodeNames() {
+		for _, ip := range s.nodeTargetIPsMap[nodeName] {
 			nodeIPSet.Insert(ip.String())
 		}
 	}
@@ -258,10 +561,13 @@ func (l *LatencyStore) ConvertList(currentNodeName string) []statsv1alpha1.PeerN
 	l.mutex.RLock()
 	defer l.mutex.RUnlock()
 
-	// PeerNodeLatencyStats should be a list of size N-1, where N is the number of Nodes in the cluster.
+	// PeerNodeLatencyStats should be a list of size N-1, where N is the number of Nodes in the cluster,
+	// unless only a subset of the Nodes is selected.
 	// TargetIPLatencyStats will be a list of size 1 (single-stack case) or 2 (dual-stack case).
-	peerNodeLatencyStatsList := make([]statsv1alpha1.PeerNodeLatencyStats, 0, len(l.nodeIPLatencyMap))
-	for nodeName, nodeIPs := range l.nodeTargetIPsMap {
+	nodeNames := l.selectedNodeNames()
+	peerNodeLatencyStatsList := make([]statsv1alpha1.PeerNodeLatencyStats, 0, len(nodeNames))
+	for _, nodeName := range nodeNames {
+		nodeIPs := l.nodeTargetIPsMap[nodeName]
 		// Even though the current Node should already be excluded from the map, we add an extra check as an additional guarantee.
 		if nodeName == currentNodeName {
 			continue
@@ -279,6 +585,10 @@ func (l *LatencyStore) ConvertList(currentNodeName string) []statsv1alpha1.PeerN
 				LastSendTime:               metav1.NewTime(latencyEntry.LastSendTime),
 				LastRecvTime:               metav1.NewTime(latencyEntry.LastRecvTime),
 				LastMeasuredRTTNanoseconds: latencyEntry.LastMeasuredRTT.Nanoseconds(),
+				PacketsSent:                latencyEntry.PacketsSent,
+				PacketsLost:                latencyEntry.PacketsLost,
+				PacketLossPercent:          latencyEntry.PacketLossPercent(),
+				JitterNanoseconds:          latencyEntry.Jitter.Nanoseconds(),
 			}
 			targetIPLatencyStats = append(targetIPLatencyStats, entry)
 		}
@@ -292,3 +602,38 @@ func (l *LatencyStore) ConvertList(currentNodeName string) []statsv1alpha1.PeerN
 
 	return peerNodeLatencyStatsList
 }
+
+// getDegradedNodes returns a map from the names of the selected Nodes whose connection exceeds the
+// thresholds, for at least one of their IPs, to the reasons. It also returns the names of all the
+// selected Nodes. The latency threshold is only considered exceeded, or no longer exceeded, once
+// latencyThresholdSamples consecutive rtts are above, or within, the threshold.
+func (s *LatencyStore) getDegradedNodes(thresholds latencyThresholds) (map[string]string, sets.Set[string]) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	nodeNames := s.selectedNodeNames()
+	degradedNodes := make(map[string]string)
+	for _, nodeName := range nodeNames {
+		var reasons []string
+		for _, nodeIP := range s.nodeTargetIPsMap[nodeName] {
+			entry, ok := s.nodeIPLatencyMap[nodeIP.String()]
+			if !ok {
+				continue
+			}
+			if thresholds.latency > 0 {
+				// While the latency threshold is exceeded, at least one of the most recent rtts is
+				// above the threshold, and the highest one is reported.
+				if exceeded, rtt := entry.checkLatency(thresholds.latency); exceeded {
+					reasons = append(reasons, fmt.Sprintf("RTT %v to %s exceeds %v", rtt, nodeIP, thresholds.latency))
+				}
+			}
+			if lossPercent := entry.PacketLossPercent(); thresholds.packetLossPercent > 0 && lossPercent > thresholds.packetLossPercent {
+				reasons = append(reasons, fmt.Sprintf("packet loss %d%% to %s exceeds %d%%", lossPercent, nodeIP, thresholds.packetLossPercent))
+			}
+		}
+		if len(reasons) > 0 {
+			degradedNodes[nodeName] = strings.Join(reasons, "; ")
+		}
+	}
+	return degradedNodes, sets.New(nodeNames...)
+}
@@ -17,13 +17,18 @@
 package monitortool
 
 import (
+	"fmt"
 	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	statsv1alpha1 "antrea.io/antrea/v2/pkg/apis/stats/v1alpha1"
 )
 
 var (
@@ -175,3 +180,229 @@ func TestLatencyStore_ListNodeIPs(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeIPLatencyEntry_recordSendAndReply(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var e NodeIPLatencyEntry
+	// The first packet is answered: no loss, and no jitter until there are 2 measurements.
+	e.recordSend(1, start)
+	assert.True(t, e.recordReply(1, start.Add(10*time.Millisecond)))
+	assert.Equal(t, 10*time.Millisecond, e.LastMeasuredRTT)
+	assert.Equal(t, int64(1), e.PacketsSent)
+	assert.Equal(t, int64(0), e.PacketsLost)
+	assert.Equal(t, int32(0), e.PacketLossPercent())
+	assert.Equal(t, time.Duration(0), e.Jitter)
+
+	// The second packet is answered with a larger rtt.
+	e.recordSend(2, start.Add(time.Second))
+	assert.True(t, e.recordReply(2, start.Add(time.Second+26*time.Millisecond)))
+	assert.Equal(t, time.Millisecond, e.Jitter)
+
+	// The third packet is not answered, it is considered lost once the timeout has elapsed.
+	e.recordSend(3, start.Add(2*time.Second))
+	e.expireProbes(start.Add(2*time.Second+999*time.Millisecond), time.Second)
+	assert.Equal(t, int64(0), e.PacketsLost)
+	e.recordSend(4, start.Add(3*time.Second))
+	e.expireProbes(start.Add(3*time.Second), time.Second)
+	assert.Equal(t, int64(4), e.PacketsSent)
+	assert.Equal(t, int64(1), e.PacketsLost)
+	assert.Equal(t, int32(33), e.PacketLossPercent())
+	// A late reply to the third packet is ignored, and is not credited to the fourth one.
+	assert.False(t, e.recordReply(3, start.Add(3*time.Second+10*time.Millisecond)))
+	assert.Equal(t, 26*time.Millisecond, e.LastMeasuredRTT)
+	assert.True(t, e.recordReply(4, start.Add(3*time.Second+26*time.Millisecond)))
+	assert.Equal(t, int32(25), e.PacketLossPercent())
+	assert.Equal(t, time.Millisecond*15/16, e.Jitter)
+	assert.Equal(t, start.Add(3*time.Second), e.LastSendTime)
+	assert.Equal(t, start.Add(3*time.Second+26*time.Millisecond), e.LastRecvTime)
+
+	// Replies received out of order are matched with their packets, and duplicate replies are
+	// ignored.
+	e.recordSend(5, start.Add(4*time.Second))
+	e.recordSend(6, start.Add(4*time.Second+500*time.Millisecond))
+	assert.True(t, e.recordReply(6, start.Add(4*time.Second+510*time.Millisecond)))
+	assert.Equal(t, 10*time.Millisecond, e.LastMeasuredRTT)
+	assert.True(t, e.recordReply(5, start.Add(4*time.Second+520*time.Millisecond)))
+	assert.Equal(t, 520*time.Millisecond, e.LastMeasuredRTT)
+	assert.False(t, e.recordReply(5, start.Add(4*time.Second+530*time.Millisecond)))
+	e.expireProbes(start.Add(10*time.Second), time.Second)
+	assert.Equal(t, int64(6), e.PacketsSent)
+	assert.Equal(t, int64(1), e.PacketsLost)
+
+	// Only the most recent packets are used to calculate the loss percentage.
+	for i := range lossWindowSize {
+		sendTime := start.Add(time.Duration(5+i) * time.Second)
+		e.recordSend(uint16(7+i), sendTime)
+		e.recordReply(uint16(7+i), sendTime.Add(26*time.Millisecond))
+	}
+	assert.Equal(t, int64(1), e.PacketsLost)
+	assert.Equal(t, int32(0), e.PacketLossPercent())
+}
+
+func TestLatencyStore_NodeSelection(t *testing.T) {
+	newStore := func(numNodes int) *LatencyStore {
+		s := NewLatencyStore(true)
+		for i := range numNodes {
+			node := makeNode(fmt.Sprintf("node%d", i), []string{fmt.Sprintf("192.168.77.%d", i)}, []string{fmt.Sprintf("10.0.%d.0/24", i)})
+			node.Labels = map[string]string{"zone": fmt.Sprintf("zone%d", i%2)}
+			s.addNode(node)
+		}
+		return s
+	}
+	nodeIPSet := func(s *LatencyStore) map[string]bool {
+		ips := map[string]bool{}
+		for _, ip := range s.ListNodeIPs() {
+			ips[ip.String()] = true
+		}
+		return ips
+	}
+
+	t.Run("selector", func(t *testing.T) {
+		s := newStore(10)
+		s.setNodeSelection(nodeSelection{selector: labels.SelectorFromSet(labels.Set{"zone": "zone1"})})
+		assert.Equal(t, map[string]bool{"192.168.77.1": true, "192.168.77.3": true, "192.168.77.5": true, "192.168.77.7": true, "192.168.77.9": true}, nodeIPSet(s))
+	})
+
+	t.Run("max Nodes", func(t *testing.T) {
+		s := newStore(100)
+		s.setNodeSelection(nodeSelection{maxNodes: 10, localNodeName: "node-a"})
+		ips := nodeIPSet(s)
+		require.Len(t, ips, 10)
+		// The selection is deterministic.
+		assert.Equal(t, ips, nodeIPSet(s))
+
+		// Another Node selects a different subset.
+		s.setNodeSelection(nodeSelection{maxNodes: 10, localNodeName: "node-b"})
+		assert.NotEqual(t, ips, nodeIPSet(s))
+
+		// Adding a Node changes at most one of the selected Nodes.
+		s.setNodeSelection(nodeSelection{maxNodes: 10, localNodeName: "node-a"})
+		s.addNode(makeNode("node100", []string{"192.168.78.100"}, []string{"10.1.100.0/24"}))
+		newIPs := nodeIPSet(s)
+		require.Len(t, newIPs, 10)
+		var common int
+		for ip := range newIPs {
+			if ips[ip] {
+				common++
+			}
+		}
+		assert.GreaterOrEqual(t, common, 9)
+	})
+
+	t.Run("stats and stale entries", func(t *testing.T) {
+		s := newStore(4)
+		for _, ip := range s.ListNodeIPs() {
+			s.SetNodeIPLatencyEntry(ip.String(), func(entry *NodeIPLatencyEntry) {
+				entry.recordSend(1, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			})
+		}
+		s.setNodeSelection(nodeSelection{selector: labels.SelectorFromSet(labels.Set{"zone": "zone0"})})
+		s.DeleteStaleNodeIPs()
+		assert.ElementsMatch(t, []string{"192.168.77.0", "192.168.77.2"}, s.getNodeIPLatencyKeys())
+		stats := s.ConvertList("node-a")
+		require.Len(t, stats, 2)
+		assert.ElementsMatch(t, []string{"node0", "node2"}, []string{stats[0].NodeName, stats[1].NodeName})
+		assert.Equal(t, []statsv1alpha1.TargetIPLatencyStats{{
+			TargetIP:     "192.168.77.0",
+			LastSendTime: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			PacketsSent:  1,
+		}}, s.ConvertList("node2")[0].TargetIPLatencyStats)
+	})
+}
+
+func TestLatencyStore_getDegradedNodes(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewLatencyStore(true)
+	for i := range 4 {
+		s.addNode(makeNode(fmt.Sprintf("node%d", i), []string{fmt.Sprintf("192.168.77.%d", i)}, []string{fmt.Sprintf("10.0.%d.0/24", i)}))
+	}
+	// node0 is healthy, node1 has had a high rtt for the last 3 probes, node2 has lost half of
+	// the probes and node3 has not replied yet.
+	rtts := map[string][]time.Duration{
+		"192.168.77.0": {time.Millisecond, time.Millisecond},
+		"192.168.77.1": {time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond},
+		"192.168.77.2": {0, time.Millisecond},
+	}
+	for ip, ipRTTs := range rtts {
+		for i, rtt := range ipRTTs {
+			sendTime := start.Add(time.Duration(i) * time.Second)
+			s.SetNodeIPLatencyEntry(ip, func(entry *NodeIPLatencyEntry) {
+				entry.recordSend(uint16(i), sendTime)
+				if rtt > 0 {
+					entry.recordReply(uint16(i), sendTime.Add(rtt))
+				} else {
+					entry.expireProbes(sendTime.Add(time.Second), time.Second)
+				}
+			})
+		}
+	}
+	s.SetNodeIPLatencyEntry("192.168.77.3", func(entry *NodeIPLatencyEntry) {
+		entry.recordSend(0, start)
+	})
+
+	degradedNodes, selectedNodes := s.getDegradedNodes(latencyThresholds{latency: 10 * time.Millisecond, packetLossPercent: 10})
+	assert.Equal(t, map[string]string{
+		"node1": "RTT 20ms to 192.168.77.1 exceeds 10ms",
+		"node2": "packet loss 50% to 192.168.77.2 exceeds 10%",
+	}, degradedNodes)
+	assert.ElementsMatch(t, []string{"node0", "node1", "node2", "node3"}, selectedNodes.UnsortedList())
+
+	degradedNodes, _ = s.getDegradedNodes(latencyThresholds{packetLossPercent: 50})
+	assert.Empty(t, degradedNodes)
+}
+
+func TestNodeIPLatencyEntry_checkLatency(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	threshold := 10 * time.Millisecond
+	e := &NodeIPLatencyEntry{}
+	var seq uint16
+	checkRTT := func(rtt time.Duration) (bool, time.Duration) {
+		seq++
+		e.recordSend(seq, start)
+		e.recordReply(seq, start.Add(rtt))
+		return e.checkLatency(threshold)
+	}
+
+	// A rtt fluctuating around the threshold does not exceed it.
+	for _, rtt := range []time.Duration{20 * time.Millisecond, time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond, time.Millisecond} {
+		exceeded, _ := checkRTT(rtt)
+		assert.False(t, exceeded)
+	}
+	exceeded, _ := checkRTT(20 * time.Millisecond)
+	assert.False(t, exceeded)
+	exceeded, _ = checkRTT(20 * time.Millisecond)
+	assert.False(t, exceeded)
+	exceeded, maxRTT := checkRTT(30 * time.Millisecond)
+	assert.True(t, exceeded)
+	assert.Equal(t, 30*time.Millisecond, maxRTT)
+
+	// Once exceeded, a rtt fluctuating around the threshold keeps exceeding it.
+	for _, rtt := range []time.Duration{time.Millisecond, time.Millisecond, 20 * time.Millisecond, time.Millisecond, time.Millisecond} {
+		exceeded, _ := checkRTT(rtt)
+		assert.True(t, exceeded)
+	}
+	exceeded, maxRTT = checkRTT(time.Millisecond)
+	assert.False(t, exceeded)
+	assert.Equal(t, time.Millisecond, maxRTT)
+}
+
+func TestLatencyStore_IsNodeIP(t *testing.T) {
+	s := NewLatencyStore(false)
+	s.addNode(makeNode("node1", []string{"192.168.77.101"}, []string{"10.0.1.0/24"}))
+	// node2 shares a transport IP with node1, e.g. because of a stale Node object.
+	s.addNode(makeNode("node2", []string{"192.168.77.101"}, []string{"10.0.2.0/24"}))
+	// Both the gateway IPs and the transport IPs are known, regardless of the agent mode.
+	for _, ip := range []string{"10.0.1.1", "10.0.2.1", "192.168.77.101"} {
+		assert.True(t, s.IsNodeIP(ip), ip)
+	}
+	assert.False(t, s.IsNodeIP("10.0.3.1"))
+
+	s.updateNode(makeNode("node1", []string{"192.168.77.111"}, []string{"10.0.1.0/24"}))
+	assert.True(t, s.IsNodeIP("192.168.77.101"))
+	assert.True(t, s.IsNodeIP("192.168.77.111"))
+
+	s.deleteNode(makeNode("node2", nil, []string{"10.0.2.0/24"}))
+	assert.False(t, s.IsNodeIP("10.0.2.1"))
+	assert.False(t, s.IsNodeIP("192.168.77.101"))
+	assert.True(t, s.IsNodeIP("10.0.1.1"))
+}
@@ -18,10 +18,16 @@ package monitortool
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
 	"math/rand/v2"
 	"net"
+	"strconv"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"golang.org/x/net/icmp"
@@ -29,8 +35,13 @@ import (
 	"golang.org/x/net/ipv6"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/v2/pkg/agent/client"
@@ -50,6 +61,24 @@ const (
 	protocolICMPv6      = 58
 	minReportInterval   = 10 * time.Second
 	reportJitter        = time.Second
+	defaultProbePort    = 10352
+	// maxProbeTimeout is the maximum time to wait for the reply to a probe, or for a TCP connection
+	// to be established. Probes which are not answered within the timeout are considered lost.
+	maxProbeTimeout = 5 * time.Second
+	// minSocketRetryDelay and maxSocketRetryDelay bound the delay before retrying to create the
+	// sockets used for probes, which fails if the probe port is in use.
+	minSocketRetryDelay = time.Second
+	maxSocketRetryDelay = time.Minute
+
+	// Reasons and action of the Events emitted when the connection to a peer Node crosses the thresholds.
+	reasonNodeLatencyDegraded  = "NodeLatencyDegraded"
+	reasonNodeLatencyRecovered = "NodeLatencyRecovered"
+	actionMonitorLatency       = "MonitorLatency"
+)
+
+const (
+	udpProbeRequest byte = 1
+	udpProbeReply   byte = 2
 )
 
 type PacketListener interface {
@@ -62,6 +91,26 @@ func (l *ICMPListener) ListenPacket(network, address string) (net.PacketConn, er
 	return icmp.ListenPacket(network, address)
 }
 
+type UDPListener struct{}
+
+func (l *UDPListener) ListenPacket(network, address string) (net.PacketConn, error) {
+	return net.ListenPacket(network, address)
+}
+
+// TCPProber is used to send TCP probes to other Nodes, and to accept the TCP probes sent by other Nodes.
+type TCPProber interface {
+	Listen(network, address string) (net.Listener, error)
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+type NetTCPProber struct {
+	net.Dialer
+}
+
+func (p *NetTCPProber) Listen(network, address string) (net.Listener, error) {
+	return net.Listen(network, address)
+}
+
 // NodeLatencyMonitor is a tool to monitor the latency of the Node.
 type NodeLatencyMonitor struct {
 	// latencyStore is the cache to store the latency of each Nodes.
@@ -81,9 +130,28 @@ type NodeLatencyMonitor struct {
 	nodeInformerSynced cache.InformerSynced
 	nlmInformerSynced  cache.InformerSynced
 
-	listener PacketListener
-
-	icmpSeqNum atomic.Uint32
+	listener    PacketListener
+	udpListener PacketListener
+	tcpProber   TCPProber
+	// tcpProbes is used to wait for the ongoing TCP probes.
+	tcpProbes sync.WaitGroup
+
+	// probeSeqNum is used to generate the sequence numbers of the probes, which are used to match
+	// the replies with the probes.
+	probeSeqNum atomic.Uint32
+
+	// k8sClient is used to emit Events.
+	k8sClient clientset.Interface
+	// recorder is used to emit Events on the current Node when the connection to a peer Node
+	// crosses the thresholds. It is created when the NodeLatencyMonitor is started.
+	recorder events.EventRecorder
+	// degradedNodesMutex protects thresholdsEnabled and degradedNodes, which are read by the
+	// agent querier.
+	degradedNodesMutex sync.RWMutex
+	thresholdsEnabled  bool
+	// degradedNodes is a map from the names of the peer Nodes whose connection exceeds the
+	// thresholds to the reasons.
+	degradedNodes map[string]string
 }
 
 // latencyConfig is the config for the latency monitor.
@@ -92,10 +160,25 @@ type latencyConfig struct {
 	Enable bool
 	// Interval is the interval time to ping all Nodes.
 	Interval time.Duration
+	// ProbeType is the type of the probes.
+	ProbeType v1alpha1.NodeLatencyProbeType
+	// ProbePort is the port of TCP and UDP probes.
+	ProbePort int
+	// NodeSelection determines the Nodes to which probes are sent.
+	NodeSelection nodeSelection
+	// Thresholds are the thresholds above which the connection to a peer Node is considered
+	// degraded.
+	Thresholds latencyThresholds
+}
+
+// probeTimeout returns the time after which a probe which is not answered is considered lost.
+func (c latencyConfig) probeTimeout() time.Duration {
+	return min(c.Interval, maxProbeTimeout)
 }
 
 // NewNodeLatencyMonitor creates a new NodeLatencyMonitor.
 func NewNodeLatencyMonitor(
+	k8sClient clientset.Interface,
 	antreaClientProvider client.AntreaClientProvider,
 	nodeInformer coreinformers.NodeInformer,
 	nlmInformer crdinformers.NodeLatencyMonitorInformer,
@@ -110,6 +193,9 @@ func NewNodeLatencyMonitor(
 		nlmInformerSynced:    nlmInformer.Informer().HasSynced,
 		nodeName:             nodeConfig.Name,
 		listener:             &ICMPListener{},
+		udpListener:          &UDPListener{},
+		tcpProber:            &NetTCPProber{},
+		k8sClient:            k8sClient,
 	}
 
 	m.isIPv4Enabled, _ = config.IsIPv4Enabled(nodeConfig, trafficEncapMode)
@@ -206,10 +292,43 @@ func (m *NodeLatencyMonitor) onNodeLatencyMonitorUpdate(oldObj, newObj interface
 // updateLatencyConfig updates the latency config based on the NodeLatencyMonitor CRD.
 func (m *NodeLatencyMonitor) updateLatencyConfig(nlm *v1alpha1.NodeLatencyMonitor) {
 	pingInterval := time.Duration(nlm.Spec.PingIntervalSeconds) * time.Second
+	probeType := nlm.Spec.ProbeType
+	if probeType == "" {
+		probeType = v1alpha1.NodeLatencyProbeTypeICMP
+	}
+	probePort := int(nlm.Spec.ProbePort)
+	if probePort == 0 {
+		probePort = defaultProbePort
+	}
+	var selector labels.Selector
+	if nlm.Spec.NodeSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(nlm.Spec.NodeSelector)
+		if err != nil {
+			klog.ErrorS(err, "Ignoring NodeLatencyMonitor with invalid nodeSelector", "NodeLatencyMonitor", klog.KObj(nlm))
+			return
+		}
+	}
+
+	var thresholds latencyThresholds
+	if nlm.Spec.Thresholds != nil {
+		thresholds = latencyThresholds{
+			latency:           time.Duration(nlm.Spec.Thresholds.LatencyMilliseconds) * time.Millisecond,
+			packetLossPercent: nlm.Spec.Thresholds.PacketLossPercent,
+		}
+	}
 
 	latencyConfig := latencyConfig{
-		Enable:   true,
-		Interval: pingInterval,
+		Enable:    true,
+		Interval:  pingInterval,
+		ProbeType: probeType,
+		ProbePort: probePort,
+		NodeSelection: nodeSelection{
+			selector:      selector,
+			maxNodes:      int(nlm.Spec.MaxTargetNodes),
+			localNodeName: m.nodeName,
+		},
+		Thresholds: thresholds,
 	}
 
 	m.latencyConfigChanged <- latencyConfig
@@ -240,7 +359,7 @@ func (m *NodeLatencyMonitor) sendPing(socket net.PacketConn, addr net.IP) error
 	}
 
 	timeStart := time.Now()
-	seqID := m.getICMPSeqNum()
+	seqID := m.getProbeSeqNum()
 	body := &icmp.Echo{
 		ID:   int(icmpEchoID),
 		Seq:  int(seqID),
@@ -259,18 +378,41 @@ func (m *NodeLatencyMonitor) sendPing(socket net.PacketConn, addr net.IP) error
 		return err
 	}
 
+	// The probe is recorded before it is sent, so that the reply cannot be received before.
+	m.recordProbeSent(addr, seqID, timeStart)
 	// Send the ICMP message
 	if _, err = socket.WriteTo(msgBytes, ip); err != nil {
 		return err
 	}
+	return nil
+}
 
+// recordProbeSent updates the latency store when the probe with the given sequence number is sent
+// to the target IP address.
+func (m *NodeLatencyMonitor) recordProbeSent(addr net.IP, seq uint16, sendTime time.Time) {
 	// Create or update the latency store
 	mutator := func(entry *NodeIPLatencyEntry) {
-		entry.LastSendTime = timeStart
+		entry.recordSend(seq, sendTime)
 	}
 	m.latencyStore.SetNodeIPLatencyEntry(addr.String(), mutator)
+}
 
-	return nil
+// recordProbeReply updates the latency store when the reply to the probe with the given sequence
+// number is received from the peer IP address. Replies to unknown probes, or to probes which have
+// already been considered lost, are ignored.
+func (m *NodeLatencyMonitor) recordProbeReply(peerIP string, seq uint16) {
+	end := time.Now()
+	var rtt time.Duration
+	var recorded bool
+	m.latencyStore.updateNodeIPLatencyEntry(peerIP, func(entry *NodeIPLatencyEntry) {
+		recorded = entry.recordReply(seq, end)
+		rtt = entry.LastMeasuredRTT
+	})
+	if !recorded {
+		klog.V(4).InfoS("Ignoring reply to unknown or expired probe", "IP", peerIP, "seq", seq)
+		return
+	}
+	klog.V(4).InfoS("Updated latency entry for Node IP", "IP", peerIP, "seq", seq, "lastRecvTime", end, "RTT", rtt)
 }
 
 func (m *NodeLatencyMonitor) handlePing(buffer []byte, peerIP string, isIPv4 bool) {
@@ -322,24 +464,7 @@ func (m *NodeLatencyMonitor) handlePing(buffer []byte, peerIP string, isIPv4 boo
 
 	klog.V(4).InfoS("Received ICMP message", "IP", peerIP, "msg", msg)
 
-	// Parse the time from the ICMP data
-	sentTime, err := time.Parse(time.RFC3339Nano, string(echo.Data))
-	if err != nil {
-		klog.ErrorS(err, "Failed to parse time from ICMP data")
-		return
-	}
-
-	// Calculate the round-trip time
-	end := time.Now()
-	rtt := end.Sub(sentTime)
-	klog.V(4).InfoS("Updating latency entry for Node IP", "IP", peerIP, "lastSendTime", sentTime, "lastRecvTime", end, "RTT", rtt)
-
-	// Update the latency store
-	mutator := func(entry *NodeIPLatencyEntry) {
-		entry.LastRecvTime = end
-		entry.LastMeasuredRTT = rtt
-	}
-	m.latencyStore.SetNodeIPLatencyEntry(peerIP, mutator)
+	m.recordProbeReply(peerIP, uint16(echo.Seq))
 }
 
 // recvPings receives ICMP messages.
@@ -360,21 +485,169 @@ func (m *NodeLatencyMonitor) recvPings(socket net.PacketConn, isIPv4 bool) {
 	}
 }
 
-// pingAll sends ICMP messages to all the Nodes.
-func (m *NodeLatencyMonitor) pingAll(ipv4Socket, ipv6Socket net.PacketConn) {
-	klog.V(4).InfoS("Pinging all Nodes")
+// udpProbeData returns the payload of a UDP probe: the message type followed by the sequence
+// number of the probe.
+func udpProbeData(msgType byte, seq uint16) []byte {
+	return binary.BigEndian.AppendUint16([]byte{msgType}, seq)
+}
+
+// sendUDPProbe sends a UDP probe to the target IP address. The probe is echoed back by the Antrea
+// Agent running on the peer Node.
+func (m *NodeLatencyMonitor) sendUDPProbe(socket net.PacketConn, addr net.IP, port int) error {
+	timeStart := time.Now()
+	seq := m.getProbeSeqNum()
+	udpAddr := &net.UDPAddr{IP: addr, Port: port}
+	klog.V(4).InfoS("Sending UDP probe", "addr", udpAddr, "seq", seq)
+	m.recordProbeSent(addr, seq, timeStart)
+	if _, err := socket.WriteTo(udpProbeData(udpProbeRequest, seq), udpAddr); err != nil {
+		return err
+	}
+	return nil
+}
+
+// isNodeAddr returns the IP of the peer address and whether it is the IP of a known peer Node.
+func (m *NodeLatencyMonitor) isNodeAddr(peer net.Addr) (string, bool) {
+	host, _, err := net.SplitHostPort(peer.String())
+	if err != nil {
+		klog.ErrorS(err, "Failed to parse peer address", "addr", peer)
+		return "", false
+	}
+	peerIP := net.ParseIP(host)
+	if peerIP == nil {
+		return host, false
+	}
+	return peerIP.String(), m.latencyStore.IsNodeIP(peerIP.String())
+}
+
+// handleUDPProbe handles a UDP message received from the peer address: requests sent by other
+// Nodes are echoed back, and replies to our own requests are used to measure latency. Messages
+// which are not sent by a known peer Node are dropped, so that the agent cannot be used to reflect
+// traffic to arbitrary addresses.
+func (m *NodeLatencyMonitor) handleUDPProbe(socket net.PacketConn, buffer []byte, peer net.Addr) {
+	if len(buffer) == 0 {
+		klog.V(5).InfoS("Ignoring empty UDP message", "addr", peer)
+		return
+	}
+	peerIP, ok := m.isNodeAddr(peer)
+	if !ok {
+		klog.V(4).InfoS("Ignoring UDP message from unknown Node", "addr", peer)
+		return
+	}
+	switch buffer[0] {
+	case udpProbeRequest:
+		buffer[0] = udpProbeReply
+		if _, err := socket.WriteTo(buffer, peer); err != nil {
+			klog.ErrorS(err, "Failed to reply to UDP probe", "addr", peer)
+		}
+	case udpProbeReply:
+		klog.V(4).InfoS("Received UDP probe reply", "IP", peerIP)
+		if len(buffer) != 3 {
+			klog.V(2).InfoS("Ignoring invalid UDP probe reply", "addr", peer, "length", len(buffer))
+			return
+		}
+		m.recordProbeReply(peerIP, binary.BigEndian.Uint16(buffer[1:]))
+	default:
+		klog.V(5).InfoS("Ignoring unknown UDP message", "addr", peer)
+	}
+}
+
+// recvUDPProbes receives UDP messages.
+func (m *NodeLatencyMonitor) recvUDPProbes(socket net.PacketConn) {
+	// We only expect small packets, if we receive a larger packet, we will drop the extra data.
+	readBuffer := make([]byte, 128)
+	for {
+		n, peer, err := socket.ReadFrom(readBuffer)
+		if err != nil {
+			klog.ErrorS(err, "Failed to read UDP message")
+			return
+		}
+
+		m.handleUDPProbe(socket, readBuffer[:n], peer)
+	}
+}
+
+// sendTCPProbe measures the time taken to establish a TCP connection to the target IP address.
+func (m *NodeLatencyMonitor) sendTCPProbe(addr net.IP, port int, timeout time.Duration) {
+	timeStart := time.Now()
+	seq := m.getProbeSeqNum()
+	m.recordProbeSent(addr, seq, timeStart)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	klog.V(4).InfoS("Sending TCP probe", "IP", addr, "port", port)
+	conn, err := m.tcpProber.DialContext(ctx, "tcp", net.JoinHostPort(addr.String(), strconv.Itoa(port)))
+	if err != nil {
+		// A refused connection means that the peer Node replied with a TCP RST, which is as good as
+		// a completed handshake to measure latency.
+		if !errors.Is(err, syscall.ECONNREFUSED) {
+			klog.V(2).InfoS("TCP probe failed", "IP", addr, "port", port, "err", err)
+			return
+		}
+	} else {
+		conn.Close()
+	}
+	m.recordProbeReply(addr.String(), seq)
+}
+
+// acceptTCPProbes accepts the TCP connections established by other Nodes to measure latency.
+// Connections which are not established by a known peer Node are reset.
+func (m *NodeLatencyMonitor) acceptTCPProbes(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			klog.ErrorS(err, "Failed to accept TCP probe")
+			return
+		}
+		if _, ok := m.isNodeAddr(conn.RemoteAddr()); !ok {
+			klog.V(4).InfoS("Resetting TCP connection from unknown Node", "addr", conn.RemoteAddr())
+			// A zero linger timeout makes Close send a TCP RST.
+			if tcpConn, ok := conn.(interface{ SetLinger(sec int) error }); ok {
+				tcpConn.SetLinger(0)
+			}
+		}
+		// The probe is complete once the connection is established.
+		conn.Close()
+	}
+}
+
+// pingAll sends probes to all the selected Nodes. For ICMP and UDP probes, ipv4Socket and
+// ipv6Socket are the sockets of the corresponding type.
+func (m *NodeLatencyMonitor) pingAll(config latencyConfig, ipv4Socket, ipv6Socket net.PacketConn) {
+	klog.V(4).InfoS("Pinging all Nodes", "probeType", config.ProbeType)
+	timeout := config.probeTimeout()
+	// The probes sent in the previous rounds which have not been answered within the timeout are
+	// considered lost.
+	m.latencyStore.expireProbes(timeout)
 	nodeIPs := m.latencyStore.ListNodeIPs()
+	if config.ProbeType == v1alpha1.NodeLatencyProbeTypeTCP {
+		for _, toIP := range nodeIPs {
+			if (toIP.To4() != nil && !m.isIPv4Enabled) || (toIP.To4() == nil && !m.isIPv6Enabled) {
+				klog.V(3).InfoS("Cannot send TCP probe to Node IP because IP family is not enabled", "IP", toIP)
+				continue
+			}
+			m.tcpProbes.Go(func() {
+				m.sendTCPProbe(toIP, config.ProbePort, timeout)
+			})
+		}
+		klog.V(4).InfoS("Done pinging all Nodes")
+		return
+	}
+	sendProbe := func(socket net.PacketConn, toIP net.IP) error {
+		if config.ProbeType == v1alpha1.NodeLatencyProbeTypeUDP {
+			return m.sendUDPProbe(socket, toIP, config.ProbePort)
+		}
+		return m.sendPing(socket, toIP)
+	}
 	for _, toIP := range nodeIPs {
 		if toIP.To4() != nil && ipv4Socket != nil {
-			if err := m.sendPing(ipv4Socket, toIP); err != nil {
-				klog.ErrorS(err, "Cannot send ICMP message to Node IP", "IP", toIP)
+			if err := sendProbe(ipv4Socket, toIP); err != nil {
+				klog.ErrorS(err, "Cannot send probe to Node IP", "IP", toIP, "probeType", config.ProbeType)
 			}
 		} else if toIP.To16() != nil && ipv6Socket != nil {
-			if err := m.sendPing(ipv6Socket, toIP); err != nil {
-				klog.ErrorS(err, "Cannot send ICMP message to Node IP", "IP", toIP)
+			if err := sendProbe(ipv6Socket, toIP); err != nil {
+				klog.ErrorS(err, "Cannot send probe to Node IP", "IP", toIP, "probeType", config.ProbeType)
 			}
 		} else {
-			klog.V(3).InfoS("Cannot send ICMP message to Node IP because socket is not initialized for IP family", "IP", toIP)
+			klog.V(3).InfoS("Cannot send probe to Node IP because socket is not initialized for IP family", "IP", toIP, "probeType", config.ProbeType)
 		}
 	}
 	klog.V(4).InfoS("Done pinging all Nodes")
@@ -402,12 +675,69 @@ func (m *NodeLatencyMonitor) report() {
 	}
 }
 
+// checkThresholds updates the peer Nodes whose connection exceeds the thresholds, and emits an
+// Event on the current Node for each peer Node whose connection becomes degraded or recovers.
+func (m *NodeLatencyMonitor) checkThresholds(thresholds latencyThresholds) {
+	var degradedNodes map[string]string
+	var selectedNodes sets.Set[string]
+	if thresholds.enabled() {
+		degradedNodes, selectedNodes = m.latencyStore.getDegradedNodes(thresholds)
+	}
+
+	m.degradedNodesMutex.Lock()
+	previousDegradedNodes := m.degradedNodes
+	m.thresholdsEnabled = thresholds.enabled()
+	m.degradedNodes = degradedNodes
+	m.degradedNodesMutex.Unlock()
+	// No Event is emitted when the thresholds are removed.
+	if !thresholds.enabled() {
+		return
+	}
+
+	nodeRef := &corev1.ObjectReference{Kind: "Node", Name: m.nodeName}
+	for nodeName, reason := range degradedNodes {
+		if _, ok := previousDegradedNodes[nodeName]; ok {
+			continue
+		}
+		klog.InfoS("Connection to peer Node exceeds the thresholds", "peerNode", nodeName, "reason", reason)
+		peerNodeRef := &corev1.ObjectReference{Kind: "Node", Name: nodeName}
+		m.recorder.Eventf(nodeRef, peerNodeRef, corev1.EventTypeWarning, reasonNodeLatencyDegraded, actionMonitorLatency,
+			"Connection to peer Node %s exceeds the thresholds: %s", nodeName, reason)
+	}
+	for nodeName := range previousDegradedNodes {
+		// Nodes which have been deleted or are no longer selected are not reported as recovered.
+		if _, ok := degradedNodes[nodeName]; ok || !selectedNodes.Has(nodeName) {
+			continue
+		}
+		klog.InfoS("Connection to peer Node is back within the thresholds", "peerNode", nodeName)
+		peerNodeRef := &corev1.ObjectReference{Kind: "Node", Name: nodeName}
+		m.recorder.Eventf(nodeRef, peerNodeRef, corev1.EventTypeNormal, reasonNodeLatencyRecovered, actionMonitorLatency,
+			"Connection to peer Node %s is back within the thresholds", nodeName)
+	}
+}
+
+// GetDegradedPeerNodes returns a map from the names of the peer Nodes whose connection exceeds the
+// thresholds to the reasons, and whether any threshold is configured.
+func (m *NodeLatencyMonitor) GetDegradedPeerNodes() (map[string]string, bool) {
+	m.degradedNodesMutex.RLock()
+	defer m.degradedNodesMutex.RUnlock()
+	return maps.Clone(m.degradedNodes), m.thresholdsEnabled
+}
+
 // Run starts the NodeLatencyMonitor.
 func (m *NodeLatencyMonitor) Run(stopCh <-chan struct{}) {
 	if !cache.WaitForNamedCacheSync("NodeLatencyMonitor", stopCh, m.nodeInformerSynced, m.nlmInformerSynced) {
 		return
 	}
 
+	eventBroadcaster := events.NewBroadcaster(&events.EventSinkImpl{
+		Interface: m.k8sClient.EventsV1(),
+	})
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(stopCh)
+	defer eventBroadcaster.Shutdown()
+	m.recorder = eventBroadcaster.NewRecorder(scheme.Scheme, "NodeLatencyMonitor")
+
 	go m.monitorLoop(stopCh)
 
 	<-stopCh
@@ -418,16 +748,52 @@ func (m *NodeLatencyMonitor) monitorLoop(stopCh <-chan struct{}) {
 	klog.InfoS("NodeLatencyMonitor is running")
 	var pingTicker, reportTicker *time.Ticker
 	var pingTickerCh, reportTickerCh <-chan time.Time
+	// The sockets are used to send and receive ICMP or UDP probes, and the listeners to accept
+	// TCP probes, depending on the probe type of currentConfig.
 	var ipv4Socket, ipv6Socket net.PacketConn
+	var ipv4Listener, ipv6Listener net.Listener
+	var currentConfig latencyConfig
 	var err error
+	// socketRetryTimer is used to retry creating the sockets after a failure.
+	var socketRetryTimer *time.Timer
+	var socketRetryTimerCh <-chan time.Time
+	socketRetryDelay := minSocketRetryDelay
+	stopSocketRetry := func() {
+		if socketRetryTimer != nil {
+			socketRetryTimer.Stop()
+			socketRetryTimer = nil
+		}
+		socketRetryTimerCh = nil
+	}
 
-	defer func() {
-		if ipv4Socket != nil {
-			ipv4Socket.Close()
+	wg := sync.WaitGroup{}
+	// closeSockets closes the sockets and listeners, and waits for the goroutines using them to
+	// return.
+	closeSockets := func() {
+		// We close the sockets as a signal to recvPing that it needs to stop.
+		// Note that at that point, we are guaranteed that there is no ongoing Write
+		// to the socket, because pingAll runs in the same goroutine as this code.
+		for _, socket := range []net.PacketConn{ipv4Socket, ipv6Socket} {
+			if socket != nil {
+				socket.Close()
+			}
 		}
-		if ipv6Socket != nil {
-			ipv6Socket.Close()
+		for _, listener := range []net.Listener{ipv4Listener, ipv6Listener} {
+			if listener != nil {
+				listener.Close()
+			}
 		}
+
+		// After closing the sockets, wait for the recvPing goroutines to return
+		wg.Wait()
+		m.tcpProbes.Wait()
+		ipv4Socket, ipv6Socket = nil, nil
+		ipv4Listener, ipv6Listener = nil, nil
+	}
+
+	defer func() {
+		closeSockets()
+		stopSocketRetry()
 		if pingTicker != nil {
 			pingTicker.Stop()
 		}
@@ -459,57 +825,131 @@ func (m *NodeLatencyMonitor) monitorLoop(stopCh <-chan struct{}) {
 		klog.V(4).InfoS("Updated report interval", "requested", interval, "minimum", minReportInterval, "actualWithJitter", reportInterval)
 	}
 
-	wg := sync.WaitGroup{}
+	// listenPacket creates a socket for ICMP or UDP probes, and starts receiving messages from it.
+	listenPacket := func(probeType v1alpha1.NodeLatencyProbeType, port int, isIPv4 bool) (net.PacketConn, error) {
+		var socket net.PacketConn
+		var err error
+		switch {
+		case probeType == v1alpha1.NodeLatencyProbeTypeUDP && isIPv4:
+			socket, err = m.udpListener.ListenPacket("udp4", net.JoinHostPort("0.0.0.0", strconv.Itoa(port)))
+		case probeType == v1alpha1.NodeLatencyProbeTypeUDP:
+			socket, err = m.udpListener.ListenPacket("udp6", net.JoinHostPort("::", strconv.Itoa(port)))
+		case isIPv4:
+			socket, err = m.listener.ListenPacket(ipv4ProtocolICMPRaw, "0.0.0.0")
+		default:
+			socket, err = m.listener.ListenPacket(ipv6ProtocolICMPRaw, "::")
+		}
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if probeType == v1alpha1.NodeLatencyProbeTypeUDP {
+				m.recvUDPProbes(socket)
+			} else {
+				m.recvPings(socket, isIPv4)
+			}
+		}()
+		return socket, nil
+	}
+
+	// listenTCP creates a listener for TCP probes, and starts accepting connections from it.
+	listenTCP := func(port int, isIPv4 bool) (net.Listener, error) {
+		var listener net.Listener
+		var err error
+		if isIPv4 {
+			listener, err = m.tcpProber.Listen("tcp4", net.JoinHostPort("0.0.0.0", strconv.Itoa(port)))
+		} else {
+			listener, err = m.tcpProber.Listen("tcp6", net.JoinHostPort("::", strconv.Itoa(port)))
+		}
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.acceptTCPProbes(listener)
+		}()
+		return listener, nil
+	}
+
+	// openSockets creates the sockets or listeners required by currentConfig which do not exist
+	// yet. If any of them cannot be created, e.g. because the probe port is in use, it retries
+	// later with an exponential backoff. In the meantime, probes are still sent, and the probes
+	// of the IP family whose socket is missing are skipped.
+	openSockets := func() {
+		stopSocketRetry()
+		var errs []error
+		if currentConfig.ProbeType == v1alpha1.NodeLatencyProbeTypeTCP {
+			if ipv4Listener == nil && m.isIPv4Enabled {
+				if ipv4Listener, err = listenTCP(currentConfig.ProbePort, true); err != nil {
+					errs = append(errs, fmt.Errorf("failed to create TCP listener for IPv4: %w", err))
+				}
+			}
+			if ipv6Listener == nil && m.isIPv6Enabled {
+				if ipv6Listener, err = listenTCP(currentConfig.ProbePort, false); err != nil {
+					errs = append(errs, fmt.Errorf("failed to create TCP listener for IPv6: %w", err))
+				}
+			}
+		} else {
+			if ipv4Socket == nil && m.isIPv4Enabled {
+				if ipv4Socket, err = listenPacket(currentConfig.ProbeType, currentConfig.ProbePort, true); err != nil {
+					errs = append(errs, fmt.Errorf("failed to create socket for IPv4: %w", err))
+				}
+			}
+			if ipv6Socket == nil && m.isIPv6Enabled {
+				if ipv6Socket, err = listenPacket(currentConfig.ProbeType, currentConfig.ProbePort, false); err != nil {
+					errs = append(errs, fmt.Errorf("failed to create socket for IPv6: %w", err))
+				}
+			}
+		}
+		if len(errs) == 0 {
+			socketRetryDelay = minSocketRetryDelay
+			return
+		}
+		klog.ErrorS(errors.Join(errs...), "Failed to create sockets for probes, will retry", "probeType", currentConfig.ProbeType, "probePort", currentConfig.ProbePort, "retryDelay", socketRetryDelay)
+		socketRetryTimer = time.NewTimer(socketRetryDelay)
+		socketRetryTimerCh = socketRetryTimer.C
+		socketRetryDelay = min(2*socketRetryDelay, maxSocketRetryDelay)
+	}
+
 	// Start the pingAll goroutine
 	for {
 		select {
 		case <-pingTickerCh:
 			// Try to send pingAll signal
-			m.pingAll(ipv4Socket, ipv6Socket)
+			m.pingAll(currentConfig, ipv4Socket, ipv6Socket)
 			// We no not delete IPs from nodeIPLatencyMap as part of the Node delete event handler
 			// to avoid consistency issues and because it would not be sufficient to avoid stale entries completely.
 			// This means that we have to periodically invoke DeleteStaleNodeIPs to avoid stale entries in the map.
 			m.latencyStore.DeleteStaleNodeIPs()
 		case <-reportTickerCh:
 			m.report()
+			m.checkThresholds(currentConfig.Thresholds)
+		case <-socketRetryTimerCh:
+			openSockets()
 		case <-stopCh:
 			return
 		case latencyConfig := <-m.latencyConfigChanged:
-			klog.InfoS("NodeLatencyMonitor configuration has changed", "enabled", latencyConfig.Enable, "interval", latencyConfig.Interval)
+			klog.InfoS("NodeLatencyMonitor configuration has changed", "enabled", latencyConfig.Enable, "interval", latencyConfig.Interval, "probeType", latencyConfig.ProbeType, "probePort", latencyConfig.ProbePort)
 			// Start or stop the pingAll goroutine based on the latencyConfig
 			if latencyConfig.Enable {
+				// The sockets need to be recreated when the probe type or the probe port changes.
+				if latencyConfig.ProbeType != currentConfig.ProbeType || latencyConfig.ProbePort != currentConfig.ProbePort {
+					closeSockets()
+					stopSocketRetry()
+					socketRetryDelay = minSocketRetryDelay
+				}
+				currentConfig = latencyConfig
+				m.latencyStore.setNodeSelection(latencyConfig.NodeSelection)
+
 				// latencyConfig changed
 				updatePingTicker(latencyConfig.Interval)
 				updateReportTicker(latencyConfig.Interval)
 
-				// If the recvPing socket is closed,
-				// recreate it if it is closed (CR is deleted).
-				if ipv4Socket == nil && m.isIPv4Enabled {
-					// Create a new socket for IPv4 when it is IPv4-only
-					ipv4Socket, err = m.listener.ListenPacket(ipv4ProtocolICMPRaw, "0.0.0.0")
-					if err != nil {
-						klog.ErrorS(err, "Failed to create ICMP socket for IPv4")
-						return
-					}
-					wg.Add(1)
-					go func() {
-						defer wg.Done()
-						m.recvPings(ipv4Socket, true)
-					}()
-				}
-				if ipv6Socket == nil && m.isIPv6Enabled {
-					// Create a new socket for IPv6 when it is IPv6-only
-					ipv6Socket, err = m.listener.ListenPacket(ipv6ProtocolICMPRaw, "::")
-					if err != nil {
-						klog.ErrorS(err, "Failed to create ICMP socket for IPv6")
-						return
-					}
-					wg.Add(1)
-					go func() {
-						defer wg.Done()
-						m.recvPings(ipv6Socket, false)
-					}()
-				}
+				// If the sockets are closed (CR is deleted or probe type has changed), recreate them.
+				openSockets()
 			} else {
 				if pingTicker != nil {
 					pingTicker.Stop()
@@ -521,29 +961,19 @@ func (m *NodeLatencyMonitor) monitorLoop(stopCh <-chan struct{}) {
 				}
 				pingTickerCh, reportTickerCh = nil, nil
 
-				// We close the sockets as a signal to recvPing that it needs to stop.
-				// Note that at that point, we are guaranteed that there is no ongoing Write
-				// to the socket, because pingAll runs in the same goroutine as this code.
-				if ipv4Socket != nil {
-					ipv4Socket.Close()
-				}
-				if ipv6Socket != nil {
-					ipv6Socket.Close()
-				}
-
-				// After closing the sockets, wait for the recvPing goroutines to return
-				wg.Wait()
-				ipv4Socket = nil
-				ipv6Socket = nil
+				closeSockets()
+				stopSocketRetry()
+				socketRetryDelay = minSocketRetryDelay
+				currentConfig = latencyConfig
+				m.checkThresholds(latencyThresholds{})
 			}
 		}
 	}
 }
 
-// getICMPSeqNum returns the sequence number to be used when sending the next
-// ICMP echo request. It wraps around to 0 after reaching the maximum value for
-// uint16.
-func (m *NodeLatencyMonitor) getICMPSeqNum() uint16 {
-	newSeqNum := m.icmpSeqNum.Add(1)
+// getProbeSeqNum returns the sequence number to be used when sending the next
+// probe. It wraps around to 0 after reaching the maximum value for uint16.
+func (m *NodeLatencyMonitor) getProbeSeqNum() uint16 {
+	newSeqNum := m.probeSeqNum.Add(1)
 	return uint16(newSeqNum)
 }
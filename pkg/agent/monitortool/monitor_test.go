@@ -17,9 +17,12 @@
 package monitortool
 
 import (
+	"context"
+	"fmt"
 	"net"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"testing"
 	"testing/synctest"
 	"time"
@@ -37,6 +40,7 @@ import (
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
 
 	"antrea.io/antrea/v2/pkg/agent/config"
 	monitortesting "antrea.io/antrea/v2/pkg/agent/monitortool/testing"
@@ -132,6 +136,8 @@ type testMonitor struct {
 	crdInformerFactory crdinformers.SharedInformerFactory
 	ctrl               *gomock.Controller
 	mockListener       *monitortesting.MockPacketListener
+	mockUDPListener    *monitortesting.MockPacketListener
+	mockTCPProber      *monitortesting.MockTCPProber
 }
 
 func newTestMonitor(
@@ -170,9 +176,13 @@ func newTestMonitor(
 	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClientset, 0)
 	nlmInformer := crdInformerFactory.Crd().V1alpha1().NodeLatencyMonitors()
 	antreaClientProvider := &antreaClientGetter{crdClientset}
-	m := NewNodeLatencyMonitor(antreaClientProvider, nodeInformer, nlmInformer, nodeConfig, trafficEncapMode)
+	m := NewNodeLatencyMonitor(clientset, antreaClientProvider, nodeInformer, nlmInformer, nodeConfig, trafficEncapMode)
 	mockListener := monitortesting.NewMockPacketListener(ctrl)
 	m.listener = mockListener
+	mockUDPListener := monitortesting.NewMockPacketListener(ctrl)
+	m.udpListener = mockUDPListener
+	mockTCPProber := monitortesting.NewMockTCPProber(ctrl)
+	m.tcpProber = mockTCPProber
 
 	return &testMonitor{
 		NodeLatencyMonitor: m,
@@ -182,6 +192,8 @@ func newTestMonitor(
 		crdInformerFactory: crdInformerFactory,
 		ctrl:               ctrl,
 		mockListener:       mockListener,
+		mockUDPListener:    mockUDPListener,
+		mockTCPProber:      mockTCPProber,
 	}
 }
 
@@ -429,7 +441,7 @@ func TestSendPing(t *testing.T) {
 				now := time.Now()
 				m := newTestMonitor(t, nodeConfigDualStack, config.TrafficEncapModeEncap, nil, nil)
 				const icmpSeqNum = 12
-				m.icmpSeqNum.Store(icmpSeqNum)
+				m.probeSeqNum.Store(icmpSeqNum)
 				expectedMsg := icmp.Message{
 					Type: tc.requestType,
 					Code: 0,
@@ -482,13 +494,15 @@ func TestRecvPings(t *testing.T) {
 		require.NoError(t, err)
 		peerIP := "10.0.2.1"
 		peerAddr := &testAddr{network: ipv4ProtocolICMPRaw, address: peerIP}
+		m.recordProbeSent(net.ParseIP(peerIP), 13, time.Now())
 		inCh <- &nettest.Packet{
 			Addr:  peerAddr,
 			Bytes: msgBytes,
 		}
 		synctest.Wait()
-		_, ok := m.latencyStore.getNodeIPLatencyEntry(peerIP)
-		assert.True(t, ok)
+		entry, ok := m.latencyStore.getNodeIPLatencyEntry(peerIP)
+		require.True(t, ok)
+		assert.Equal(t, time.Now(), entry.LastRecvTime)
 
 		pConn.Close()
 	})
@@ -608,14 +622,14 @@ func TestHandlePing(t *testing.T) {
 			isValid: false,
 		},
 		{
-			name: "invalid payload",
+			name: "unknown sequence number",
 			msgFn: func() []byte {
 				return MustMarshal(&icmp.Message{
 					Type: ipv4.ICMPTypeEchoReply,
 					Body: &icmp.Echo{
 						ID:   int(icmpEchoID),
-						Seq:  1,
-						Data: []byte("foobar"),
+						Seq:  2,
+						Data: icmpEchoData(time.Now()),
 					},
 				})
 			},
@@ -633,22 +647,317 @@ func TestHandlePing(t *testing.T) {
 					peerIP = "2001:ab03:cd04:55ee:100b::1"
 				}
 				msgBytes := tc.msgFn()
+				m.recordProbeSent(net.ParseIP(peerIP), 1, time.Now())
 				const rtt = 1 * time.Second
 				time.Sleep(rtt)
 				m.handlePing(msgBytes, peerIP, tc.isIPv4)
 				entry, ok := m.latencyStore.getNodeIPLatencyEntry(peerIP)
+				require.True(t, ok)
 				if tc.isValid {
-					require.True(t, ok)
 					assert.Equal(t, time.Now(), entry.LastRecvTime)
 					assert.Equal(t, rtt, entry.LastMeasuredRTT)
 				} else {
-					assert.False(t, ok)
+					assert.True(t, entry.LastRecvTime.IsZero())
 				}
 			})
 		})
 	}
 }
 
+func TestHandleUDPProbe(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		m := newTestMonitor(t, nodeConfigDualStack, config.TrafficEncapModeEncap, nil, nil)
+		outCh := make(chan *nettest.Packet, 1)
+		pConn := nettest.NewPacketConn(&testAddr{network: "udp", address: "0.0.0.0:10352"}, nil, outCh)
+		peerAddr := &testAddr{network: "udp", address: "10.0.2.1:10352"}
+		m.latencyStore.addNode(node2)
+
+		// Probes which are not sent by a known Node are dropped.
+		m.handleUDPProbe(pConn, udpProbeData(udpProbeRequest, 1), &testAddr{network: "udp", address: "10.0.9.1:10352"})
+		m.handleUDPProbe(pConn, udpProbeData(udpProbeRequest, 1), &testAddr{network: "udp", address: "10.0.3.1:10352"})
+		assert.Empty(t, outCh)
+
+		// Probes sent by other Nodes are echoed back.
+		request := udpProbeData(udpProbeRequest, 1)
+		m.handleUDPProbe(pConn, request, peerAddr)
+		select {
+		case p := <-outCh:
+			assert.Equal(t, peerAddr, p.Addr)
+			assert.Equal(t, udpProbeData(udpProbeReply, 1), p.Bytes)
+		default:
+			assert.Fail(t, "UDP probe was not echoed back")
+		}
+		_, ok := m.latencyStore.getNodeIPLatencyEntry("10.0.2.1")
+		assert.False(t, ok)
+
+		// Replies to our own probes are used to measure latency.
+		require.NoError(t, m.sendUDPProbe(pConn, net.ParseIP("10.0.2.1"), 10352))
+		p := <-outCh
+		assert.Equal(t, "10.0.2.1:10352", p.Addr.String())
+		const rtt = 1 * time.Second
+		time.Sleep(rtt)
+		reply := p.Bytes
+		reply[0] = udpProbeReply
+		m.handleUDPProbe(pConn, reply, peerAddr)
+		entry, ok := m.latencyStore.getNodeIPLatencyEntry("10.0.2.1")
+		require.True(t, ok)
+		assert.Equal(t, rtt, entry.LastMeasuredRTT)
+		assert.Equal(t, int64(1), entry.PacketsSent)
+
+		// Duplicate replies are ignored.
+		time.Sleep(rtt)
+		m.handleUDPProbe(pConn, reply, peerAddr)
+		entry, _ = m.latencyStore.getNodeIPLatencyEntry("10.0.2.1")
+		assert.Equal(t, rtt, entry.LastMeasuredRTT)
+
+		// Invalid messages are ignored.
+		m.handleUDPProbe(pConn, []byte{udpProbeReply, 'f', 'o', 'o'}, &testAddr{network: "udp", address: "10.0.3.1:10352"})
+		m.handleUDPProbe(pConn, []byte{3}, &testAddr{network: "udp", address: "10.0.3.1:10352"})
+		_, ok = m.latencyStore.getNodeIPLatencyEntry("10.0.3.1")
+		assert.False(t, ok)
+		assert.Empty(t, outCh)
+	})
+}
+
+func TestSendTCPProbe(t *testing.T) {
+	testCases := []struct {
+		name          string
+		dialErr       error
+		expectedReply bool
+	}{
+		{
+			name:          "connection established",
+			expectedReply: true,
+		},
+		{
+			name:          "connection refused",
+			dialErr:       &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			expectedReply: true,
+		},
+		{
+			name:    "timeout",
+			dialErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			synctest.Test(t, func(t *testing.T) {
+				m := newTestMonitor(t, nodeConfigDualStack, config.TrafficEncapModeEncap, nil, nil)
+				const rtt = 10 * time.Millisecond
+				m.mockTCPProber.EXPECT().DialContext(gomock.Any(), "tcp", "[2001:ab03:cd04:55ee:100b::1]:10352").DoAndReturn(
+					func(ctx context.Context, network, address string) (net.Conn, error) {
+						time.Sleep(rtt)
+						if tc.dialErr != nil {
+							return nil, tc.dialErr
+						}
+						conn, _ := net.Pipe()
+						return conn, nil
+					})
+				m.sendTCPProbe(net.ParseIP("2001:ab03:cd04:55ee:100b::1"), 10352, time.Second)
+				entry, ok := m.latencyStore.getNodeIPLatencyEntry("2001:ab03:cd04:55ee:100b::1")
+				require.True(t, ok)
+				assert.Equal(t, int64(1), entry.PacketsSent)
+				if tc.expectedReply {
+					assert.Equal(t, rtt, entry.LastMeasuredRTT)
+					assert.Equal(t, time.Now(), entry.LastRecvTime)
+				} else {
+					assert.True(t, entry.LastRecvTime.IsZero())
+				}
+			})
+		})
+	}
+}
+
+// fakeListener is a net.Listener which returns the connections received from connCh, and an error
+// from Accept once closed or once connCh is closed.
+type fakeListener struct {
+	connCh  chan net.Conn
+	closeCh chan struct{}
+	once    sync.Once
+}
+
+func newFakeListener() *fakeListener {
+	return &fakeListener{closeCh: make(chan struct{})}
+}
+
+func (l *fakeListener) Accept() (net.Conn, error) {
+	select {
+	case conn, ok := <-l.connCh:
+		if ok {
+			return conn, nil
+		}
+	case <-l.closeCh:
+	}
+	return nil, net.ErrClosed
+}
+
+func (l *fakeListener) Close() error {
+	l.once.Do(func() { close(l.closeCh) })
+	return nil
+}
+
+func (l *fakeListener) IsClosed() bool {
+	select {
+	case <-l.closeCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *fakeListener) Addr() net.Addr {
+	return &testAddr{network: "tcp", address: "0.0.0.0:10352"}
+}
+
+// fakeTCPConn is a net.Conn which records whether it was reset.
+type fakeTCPConn struct {
+	net.Conn
+	remoteAddr net.Addr
+	reset      bool
+	closed     bool
+}
+
+func (c *fakeTCPConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+func (c *fakeTCPConn) SetLinger(sec int) error {
+	c.reset = sec == 0
+	return nil
+}
+
+func (c *fakeTCPConn) Close() error {
+	c.closed = true
+	return c.Conn.Close()
+}
+
+func TestAcceptTCPProbes(t *testing.T) {
+	m := newTestMonitor(t, nodeConfigDualStack, config.TrafficEncapModeEncap, nil, nil)
+	m.latencyStore.addNode(node2)
+	newConn := func(address string) *fakeTCPConn {
+		conn, _ := net.Pipe()
+		return &fakeTCPConn{Conn: conn, remoteAddr: &testAddr{network: "tcp", address: address}}
+	}
+	knownConn := newConn("[2001:ab03:cd04:55ee:100b::1]:34567")
+	unknownConn := newConn("10.0.9.1:34567")
+	listener := newFakeListener()
+	listener.connCh = make(chan net.Conn, 2)
+	listener.connCh <- knownConn
+	listener.connCh <- unknownConn
+	close(listener.connCh)
+
+	m.acceptTCPProbes(listener)
+	assert.True(t, knownConn.closed)
+	assert.False(t, knownConn.reset)
+	// Connections which are not established by a known Node are reset.
+	assert.True(t, unknownConn.closed)
+	assert.True(t, unknownConn.reset)
+}
+
+func TestUpdateMonitorProbeType(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := t.Context()
+		stopCh := ctx.Done()
+		m := newTestMonitor(t, nodeConfigIPv4, config.TrafficEncapModeEncap, []runtime.Object{node1, node2, node3}, []runtime.Object{nlm})
+		m.crdInformerFactory.Start(stopCh)
+		m.informerFactory.Start(stopCh)
+		m.crdInformerFactory.WaitForCacheSync(stopCh)
+		m.informerFactory.WaitForCacheSync(stopCh)
+
+		pConnICMP := nettest.NewPacketConn(testAddrIPv4, nil, nil)
+		m.mockListener.EXPECT().ListenPacket(ipv4ProtocolICMPRaw, "0.0.0.0").Return(pConnICMP, nil)
+		go m.Run(stopCh)
+		synctest.Wait()
+		require.True(t, m.ctrl.Satisfied())
+
+		// Switching to UDP probes replaces the ICMP socket with a UDP socket bound to the probe port.
+		outCh := make(chan *nettest.Packet, 10)
+		collect := collectProbePackets(t, outCh, stopCh)
+		pConnUDP := nettest.NewPacketConn(&testAddr{network: "udp", address: "0.0.0.0:10000"}, nil, outCh)
+		m.mockUDPListener.EXPECT().ListenPacket("udp4", "0.0.0.0:10000").Return(pConnUDP, nil)
+		newNLM := nlm.DeepCopy()
+		newNLM.Spec.ProbeType = crdv1alpha1.NodeLatencyProbeTypeUDP
+		newNLM.Spec.ProbePort = 10000
+		newNLM.Spec.MaxTargetNodes = 1
+		newNLM.Generation = 1
+		_, err := m.crdClientset.CrdV1alpha1().NodeLatencyMonitors().Update(ctx, newNLM, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		synctest.Wait()
+		require.True(t, m.ctrl.Satisfied())
+		assert.True(t, pConnICMP.IsClosed())
+
+		// Only one of the 2 peer Nodes is probed.
+		time.Sleep(60 * time.Second)
+		synctest.Wait()
+		packets := collect(nil)
+		require.Len(t, packets, 1)
+		assert.Contains(t, []string{"10.0.2.1:10000", "10.0.3.1:10000"}, packets[0].Addr.String())
+
+		// Switching to TCP probes replaces the UDP socket with a TCP listener.
+		listener := newFakeListener()
+		m.mockTCPProber.EXPECT().Listen("tcp4", "0.0.0.0:10000").Return(listener, nil)
+		for _, ip := range []string{"10.0.2.1", "10.0.3.1"} {
+			m.mockTCPProber.EXPECT().DialContext(gomock.Any(), "tcp", fmt.Sprintf("%s:10000", ip)).Return(nil, context.DeadlineExceeded)
+		}
+		newNLM = newNLM.DeepCopy()
+		newNLM.Spec.ProbeType = crdv1alpha1.NodeLatencyProbeTypeTCP
+		newNLM.Spec.MaxTargetNodes = 0
+		newNLM.Generation = 2
+		_, err = m.crdClientset.CrdV1alpha1().NodeLatencyMonitors().Update(ctx, newNLM, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		synctest.Wait()
+		assert.True(t, pConnUDP.IsClosed())
+
+		time.Sleep(60 * time.Second)
+		synctest.Wait()
+		require.True(t, m.ctrl.Satisfied())
+
+		require.NoError(t, m.crdClientset.CrdV1alpha1().NodeLatencyMonitors().Delete(ctx, nlm.Name, metav1.DeleteOptions{}))
+		synctest.Wait()
+		assert.True(t, listener.IsClosed())
+	})
+}
+
+func TestSocketCreationRetry(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := t.Context()
+		stopCh := ctx.Done()
+		udpNLM := nlm.DeepCopy()
+		udpNLM.Spec.ProbeType = crdv1alpha1.NodeLatencyProbeTypeUDP
+		udpNLM.Spec.ProbePort = 10000
+		m := newTestMonitor(t, nodeConfigIPv4, config.TrafficEncapModeEncap, []runtime.Object{node1, node2}, []runtime.Object{udpNLM})
+		m.crdInformerFactory.Start(stopCh)
+		m.informerFactory.Start(stopCh)
+		m.crdInformerFactory.WaitForCacheSync(stopCh)
+		m.informerFactory.WaitForCacheSync(stopCh)
+
+		// The probe port is in use when the NodeLatencyMonitor starts.
+		bindErr := &net.OpError{Op: "listen", Net: "udp4", Err: syscall.EADDRINUSE}
+		m.mockUDPListener.EXPECT().ListenPacket("udp4", "0.0.0.0:10000").Return(nil, bindErr).Times(2)
+		go m.Run(stopCh)
+		synctest.Wait()
+
+		// The creation of the socket is retried with an exponential backoff, after 1s and 2s.
+		time.Sleep(time.Second)
+		synctest.Wait()
+		require.True(t, m.ctrl.Satisfied())
+		outCh := make(chan *nettest.Packet, 10)
+		collect := collectProbePackets(t, outCh, stopCh)
+		pConn := nettest.NewPacketConn(&testAddr{network: "udp", address: "0.0.0.0:10000"}, nil, outCh)
+		m.mockUDPListener.EXPECT().ListenPacket("udp4", "0.0.0.0:10000").Return(pConn, nil)
+		time.Sleep(2 * time.Second)
+		synctest.Wait()
+		require.True(t, m.ctrl.Satisfied())
+
+		// The NodeLatencyMonitor keeps running, and probes are sent once the socket is created.
+		time.Sleep(57 * time.Second)
+		synctest.Wait()
+		packets := collect(nil)
+		require.Len(t, packets, 1)
+		assert.Equal(t, "10.0.2.1:10000", packets[0].Addr.String())
+	})
+}
+
 func TestNodeAddUpdateDelete(t *testing.T) {
 	node := makeNode("node3", []string{"192.168.77.103", "192:168:77::103"}, []string{"10.0.3.0/24", "2001:ab03:cd04:55ee:100c::/80"})
 	updatedNode := makeNode("node3", []string{"192.168.77.104", "192:168:77::104"}, []string{"10.0.4.0/24", "2001:ab03:cd04:55ee:100d::/80"})
@@ -825,3 +1134,175 @@ func TestMonitorLoop(t *testing.T) {
 		assert.Equal(t, 1, int(reportCount.Load()), "Expected report after jittered interval (total 122s)")
 	})
 }
+
+func TestCheckThresholds(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newTestMonitor(t, nodeConfigIPv4, config.TrafficEncapModeNetworkPolicyOnly, nil, nil)
+	recorder := events.NewFakeRecorder(10)
+	m.recorder = recorder
+	m.latencyStore.addNode(node2)
+	m.latencyStore.addNode(node3)
+	var seq uint16
+	addRTTs := func(ip string, rtts ...time.Duration) {
+		m.latencyStore.SetNodeIPLatencyEntry(ip, func(entry *NodeIPLatencyEntry) {
+			for _, rtt := range rtts {
+				seq++
+				entry.recordSend(seq, start)
+				entry.recordReply(seq, start.Add(rtt))
+			}
+		})
+	}
+	// setRTT records enough identical rtts for the latency threshold to be crossed.
+	setRTT := func(ip string, rtt time.Duration) {
+		for range latencyThresholdSamples {
+			addRTTs(ip, rtt)
+		}
+	}
+	thresholds := latencyThresholds{latency: 10 * time.Millisecond}
+
+	setRTT("192.168.77.102", time.Millisecond)
+	setRTT("192.168.77.103", 20*time.Millisecond)
+	m.checkThresholds(thresholds)
+	degradedNodes, enabled := m.GetDegradedPeerNodes()
+	assert.True(t, enabled)
+	assert.Equal(t, map[string]string{"node3": "RTT 20ms to 192.168.77.103 exceeds 10ms"}, degradedNodes)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning NodeLatencyDegraded Connection to peer Node node3 exceeds the thresholds: RTT 20ms to 192.168.77.103 exceeds 10ms", <-recorder.Events)
+
+	// No Event is emitted while the connection remains degraded.
+	setRTT("192.168.77.103", 30*time.Millisecond)
+	m.checkThresholds(thresholds)
+	assert.Empty(t, recorder.Events)
+
+	setRTT("192.168.77.102", 20*time.Millisecond)
+	setRTT("192.168.77.103", time.Millisecond)
+	m.checkThresholds(thresholds)
+	degradedNodes, _ = m.GetDegradedPeerNodes()
+	assert.Equal(t, map[string]string{"node2": "RTT 20ms to 192.168.77.102 exceeds 10ms"}, degradedNodes)
+	require.Len(t, recorder.Events, 2)
+	assert.ElementsMatch(t, []string{
+		"Warning NodeLatencyDegraded Connection to peer Node node2 exceeds the thresholds: RTT 20ms to 192.168.77.102 exceeds 10ms",
+		"Normal NodeLatencyRecovered Connection to peer Node node3 is back within the thresholds",
+	}, []string{<-recorder.Events, <-recorder.Events})
+
+	// A deleted Node is not reported as recovered.
+	m.latencyStore.deleteNode(node2)
+	m.checkThresholds(thresholds)
+	degradedNodes, _ = m.GetDegradedPeerNodes()
+	assert.Empty(t, degradedNodes)
+	assert.Empty(t, recorder.Events)
+
+	// No Event is emitted when the thresholds are removed.
+	m.latencyStore.addNode(node2)
+	m.checkThresholds(thresholds)
+	require.Len(t, recorder.Events, 1)
+	<-recorder.Events
+	m.checkThresholds(latencyThresholds{})
+	degradedNodes, enabled = m.GetDegradedPeerNodes()
+	assert.False(t, enabled)
+	assert.Empty(t, degradedNodes)
+	assert.Empty(t, recorder.Events)
+
+	// No Event is emitted while the rtt fluctuates around the threshold, whether the connection
+	// is degraded or not.
+	fluctuate := func() {
+		for range 5 {
+			addRTTs("192.168.77.102", 20*time.Millisecond)
+			m.checkThresholds(thresholds)
+			addRTTs("192.168.77.102", time.Millisecond)
+			m.checkThresholds(thresholds)
+		}
+	}
+	setRTT("192.168.77.102", time.Millisecond)
+	m.checkThresholds(thresholds)
+	fluctuate()
+	degradedNodes, _ = m.GetDegradedPeerNodes()
+	assert.Empty(t, degradedNodes)
+	assert.Empty(t, recorder.Events)
+
+	setRTT("192.168.77.102", 20*time.Millisecond)
+	m.checkThresholds(thresholds)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning NodeLatencyDegraded Connection to peer Node node2 exceeds the thresholds: RTT 20ms to 192.168.77.102 exceeds 10ms", <-recorder.Events)
+	fluctuate()
+	degradedNodes, _ = m.GetDegradedPeerNodes()
+	assert.Equal(t, map[string]string{"node2": "RTT 20ms to 192.168.77.102 exceeds 10ms"}, degradedNodes)
+	assert.Empty(t, recorder.Events)
+
+	setRTT("192.168.77.102", time.Millisecond)
+	m.checkThresholds(thresholds)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal NodeLatencyRecovered Connection to peer Node node2 is back within the thresholds", <-recorder.Events)
+}
+
+func TestMonitorLoopWithThresholds(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := t.Context()
+		stopCh := ctx.Done()
+		nlmWithThresholds := nlm.DeepCopy()
+		nlmWithThresholds.Spec.Thresholds = &crdv1alpha1.NodeLatencyThresholds{LatencyMilliseconds: 500}
+		m := newTestMonitor(t, nodeConfigIPv4, config.TrafficEncapModeEncap, []runtime.Object{node1, node2, node3}, []runtime.Object{nlmWithThresholds})
+		m.crdInformerFactory.Start(stopCh)
+		m.informerFactory.Start(stopCh)
+		m.crdInformerFactory.WaitForCacheSync(stopCh)
+		m.informerFactory.WaitForCacheSync(stopCh)
+
+		inCh := make(chan *nettest.Packet, 10)
+		outCh := make(chan *nettest.Packet, 10)
+		collect := collectProbePackets(t, outCh, stopCh)
+		pConn := nettest.NewPacketConn(testAddrIPv4, inCh, outCh)
+		m.mockListener.EXPECT().ListenPacket(ipv4ProtocolICMPRaw, "0.0.0.0").Return(pConn, nil)
+
+		go m.Run(stopCh)
+		synctest.Wait()
+		_, enabled := m.GetDegradedPeerNodes()
+		assert.False(t, enabled, "Thresholds should only be checked when reporting")
+
+		// Only node2 replies, after 800ms. Probes are sent every 60s and the thresholds are checked
+		// after reporting, at 61s, 122s and 183s.
+		start := time.Now()
+		sleepUntil := func(d time.Duration) {
+			time.Sleep(time.Until(start.Add(d)))
+			synctest.Wait()
+		}
+		for i := range latencyThresholdSamples {
+			sleepUntil(time.Duration(i+1) * 60 * time.Second)
+			packets := collect(nil)
+			require.Len(t, packets, 2)
+			sleepUntil(time.Duration(i+1)*60*time.Second + 800*time.Millisecond)
+			for _, packet := range packets {
+				if packet.Addr.String() != "10.0.2.1" {
+					continue
+				}
+				request, err := icmp.ParseMessage(protocolICMP, packet.Bytes)
+				require.NoError(t, err)
+				inCh <- &nettest.Packet{
+					Addr:  packet.Addr,
+					Bytes: MustMarshal(&icmp.Message{Type: ipv4.ICMPTypeEchoReply, Body: request.Body}),
+				}
+			}
+			synctest.Wait()
+			if i == latencyThresholdSamples-1 {
+				break
+			}
+			// The latency threshold is not exceeded until enough rtts have been measured.
+			sleepUntil(time.Duration(i+1)*61*time.Second + 200*time.Millisecond)
+			degradedNodes, enabled := m.GetDegradedPeerNodes()
+			assert.True(t, enabled)
+			assert.Empty(t, degradedNodes)
+		}
+
+		sleepUntil(183*time.Second + 200*time.Millisecond)
+		degradedNodes, enabled := m.GetDegradedPeerNodes()
+		assert.True(t, enabled)
+		assert.Equal(t, map[string]string{"node2": "RTT 800ms to 10.0.2.1 exceeds 500ms"}, degradedNodes)
+		eventList, err := m.clientset.EventsV1().Events(metav1.NamespaceDefault).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, eventList.Items, 1)
+		event := eventList.Items[0]
+		assert.Equal(t, corev1.EventTypeWarning, event.Type)
+		assert.Equal(t, reasonNodeLatencyDegraded, event.Reason)
+		assert.Equal(t, corev1.ObjectReference{Kind: "Node", Name: "node1"}, event.Regarding)
+		assert.Equal(t, &corev1.ObjectReference{Kind: "Node", Name: "node2"}, event.Related)
+	})
+}
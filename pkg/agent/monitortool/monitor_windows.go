@@ -18,6 +18,7 @@ package monitortool
 
 import (
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
 
 	"antrea.io/antrea/v2/pkg/agent/client"
 	"antrea.io/antrea/v2/pkg/agent/config"
@@ -27,6 +28,7 @@ import (
 type NodeLatencyMonitor struct{}
 
 func NewNodeLatencyMonitor(
+	k8sClient clientset.Interface,
 	antreaClientProvider client.AntreaClientProvider,
 	nodeInformer coreinformers.NodeInformer,
 	nlmInformer crdinformers.NodeLatencyMonitorInformer,
@@ -38,4 +40,8 @@ func NewNodeLatencyMonitor(
 
 func (m *NodeLatencyMonitor) Run(stopCh <-chan struct{}) {}
 
+func (m *NodeLatencyMonitor) GetDegradedPeerNodes() (map[string]string, bool) {
+	return nil, false
+}
+
 // Not supported on Windows.
@@ -14,17 +14,18 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: antrea.io/antrea/v2/pkg/agent/monitortool (interfaces: PacketListener)
+// Source: antrea.io/antrea/v2/pkg/agent/monitortool (interfaces: PacketListener,TCPProber)
 //
 // Generated by this command:
 //
-//	mockgen -copyright_file hack/boilerplate/license_header.raw.txt -destination pkg/agent/monitortool/testing/mock_monitortool.go -package testing antrea.io/antrea/v2/pkg/agent/monitortool PacketListener
+//	mockgen -copyright_file hack/boilerplate/license_header.raw.txt -destination pkg/agent/monitortool/testing/mock_monitortool.go -package testing antrea.io/antrea/v2/pkg/agent/monitortool PacketListener,TCPProber
 //
 
 // Package testing is a generated GoMock package.
 package testing
 
 import (
+	context "context"
 	net "net"
 	reflect "reflect"
 
@@ -69,3 +70,57 @@ func (mr *MockPacketListenerMockRecorder) ListenPacket(network, address any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenPacket", reflect.TypeOf((*MockPacketListener)(nil).ListenPacket), network, address)
 }
+
+// MockTCPProber is a mock of TCPProber interface.
+type MockTCPProber struct {
+	ctrl     *gomock.Controller
+	recorder *MockTCPProberMockRecorder
+	isgomock struct{}
+}
+
+// MockTCPProberMockRecorder is the mock recorder for MockTCPProber.
+type MockTCPProberMockRecorder struct {
+	mock *MockTCPProber
+}
+
+// NewMockTCPProber creates a new mock instance.
+func NewMockTCPProber(ctrl *gomock.Controller) *MockTCPProber {
+	mock := &MockTCPProber{ctrl: ctrl}
+	mock.recorder = &MockTCPProberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTCPProber) EXPECT() *MockTCPProberMockRecorder {
+	return m.recorder
+}
+
+// DialContext mocks base method.
+func (m *MockTCPProber) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DialContext", ctx, network, address)
+	ret0, _ := ret[0].(net.Conn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DialContext indicates an expected call of DialContext.
+func (mr *MockTCPProberMockRecorder) DialContext(ctx, network, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DialContext", reflect.TypeOf((*MockTCPProber)(nil).DialContext), ctx, network, address)
+}
+
+// Listen mocks base method.
+func (m *MockTCPProber) Listen(network, address string) (net.Listener, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", network, address)
+	ret0, _ := ret[0].(net.Listener)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockTCPProberMockRecorder) Listen(network, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockTCPProber)(nil).Listen), network, address)
+}
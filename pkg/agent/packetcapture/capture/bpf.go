@@ -81,6 +81,7 @@ var ProtocolMap = map[string]uint32{
 	"TCP":    6,
 	"ICMP":   1,
 	"ICMPV6": 58,
+	"SCTP":   132,
 }
 
 var ICMPMsgTypeMap = map[crdv1alpha1.ICMPMsgType]uint32{
@@ -383,6 +384,120 @@ func compilePacketFilter(packetSpec *crdv1alpha1.Packet, srcIP, dstIP net.IP, di
 	return compileGenericPacketFilter(ipv4Handler, packetSpec, srcIP, dstIP, direction)
 }
 
+// ServiceDestination is an IP address and the ports to which the packets sent to a Service are destined: the virtual
+// IP of the Service with the Service ports, or, once the packets have been DNATed by OVS, the IP of one of the
+// Endpoints of the Service with the target ports.
+type ServiceDestination struct {
+	IP    net.IP
+	Ports []v1.ServicePort
+	// Endpoint is true if IP is the IP of an Endpoint. The packets are then matched with the TargetPort of the ports,
+	// which must be resolved to a number.
+	Endpoint bool
+}
+
+// compileServicePacketFilter generates the BPF instructions matching the packets which match packetSpec and are sent
+// to any of the ports of the given Service destinations. A filter is compiled for each IP address and port by
+// compilePacketFilter, and all of them are concatenated, except for their last instruction which drops the
+// non-matching packets: as it is the target of all the jumps for non-matching packets, such packets are passed to the
+// filter of the next port instead. If no destination is given, it is the same as compilePacketFilter with dstIP.
+func compileServicePacketFilter(packetSpec *crdv1alpha1.Packet, srcIP, dstIP net.IP, destinations []ServiceDestination, direction crdv1alpha1.CaptureDirection) []bpf.Instruction {
+	if len(destinations) == 0 {
+		return compilePacketFilter(packetSpec, srcIP, dstIP, direction)
+	}
+	var inst []bpf.Instruction
+	for _, destination := range destinations {
+		for _, servicePort := range destination.Ports {
+			dstPort := servicePort.Port
+			if destination.Endpoint {
+				dstPort = servicePort.TargetPort.IntVal
+			}
+			portPacketSpec, ok := servicePortPacket(packetSpec, servicePort, dstPort)
+			if !ok {
+				continue
+			}
+			portInst := compilePacketFilter(portPacketSpec, srcIP, destination.IP, direction)
+			inst = append(inst, portInst[:len(portInst)-1]...)
+		}
+	}
+	// return (drop)
+	inst = append(inst, returnDrop)
+	return inst
+}
+
+// servicePortPacket returns the Packet spec matching the packets which match packetSpec and are sent to the given
+// Service port, dstPort being the destination port of these packets: the Service port, or the target port once the
+// packets have been DNATed. It returns false if no such packet exists, e.g. when packetSpec is for another protocol or
+// port, the destination port of packetSpec being a Service port. The ports of SCTP Services are not matched, as the
+// Packet spec only supports the TCP and UDP ports.
+func servicePortPacket(packetSpec *crdv1alpha1.Packet, servicePort v1.ServicePort, dstPort int32) (*crdv1alpha1.Packet, bool) {
+	var packet *crdv1alpha1.Packet
+	if packetSpec != nil {
+		packet = packetSpec.DeepCopy()
+	} else {
+		packet = &crdv1alpha1.Packet{}
+	}
+	protocol := servicePort.Protocol
+	if protocol == "" {
+		protocol = v1.ProtocolTCP
+	}
+	if packet.Protocol != nil {
+		var proto uint32
+		if packet.Protocol.Type == intstr.Int {
+			proto = uint32(packet.Protocol.IntVal)
+		} else {
+			proto = ProtocolMap[strings.ToUpper(packet.Protocol.StrVal)]
+		}
+		if proto != ProtocolMap[string(protocol)] {
+			return nil, false
+		}
+	}
+	packet.Protocol = ptr.To(intstr.FromString(string(protocol)))
+	transport := &packet.TransportHeader
+	if transport.ICMP != nil || transport.ICMPv6 != nil {
+		return nil, false
+	}
+	switch protocol {
+	case v1.ProtocolTCP:
+		if transport.UDP != nil {
+			return nil, false
+		}
+		if transport.TCP == nil {
+			transport.TCP = &crdv1alpha1.TCPHeader{}
+		}
+		if transport.TCP.DstPort != nil && *transport.TCP.DstPort != servicePort.Port {
+			return nil, false
+		}
+		transport.TCP.DstPort = ptr.To(dstPort)
+	case v1.ProtocolUDP:
+		if transport.TCP != nil {
+			return nil, false
+		}
+		if transport.UDP == nil {
+			transport.UDP = &crdv1alpha1.UDPHeader{}
+		}
+		if transport.UDP.DstPort != nil && *transport.UDP.DstPort != servicePort.Port {
+			return nil, false
+		}
+		transport.UDP.DstPort = ptr.To(dstPort)
+	default:
+		if transport.TCP != nil || transport.UDP != nil {
+			return nil, false
+		}
+	}
+	return packet, true
+}
+
+// FilterServicePorts returns the ports of a Service to which the packets matching packetSpec can be sent.
+func FilterServicePorts(packetSpec *crdv1alpha1.Packet, servicePorts []v1.ServicePort) []v1.ServicePort {
+	var ports []v1.ServicePort
+	for _, servicePort := range servicePorts {
+		if _, ok := servicePortPacket(packetSpec, servicePort, servicePort.Port); ok {
+			ports = append(ports, servicePort)
+		}
+	}
+	return ports
+}
+
 // compileGenericPacketFilter compiles the CRD spec to BPF instructions using a
 // protocol-specific handler to manage differences between IPv4 and IPv6.
 func compileGenericPacketFilter(handler *ipFamilyHandler, packetSpec *crdv1alpha1.Packet, srcIP, dstIP net.IP, direction crdv1alpha1.CaptureDirection) []bpf.Instruction {
@@ -705,3 +820,29 @@ func calculateInstructionsSize(handler *ipFamilyHandler, packet *crdv1alpha1.Pac
 	count += 2
 	return count
 }
+
+// PacketMatcher matches packets against a Packet spec in user space, by running the same BPF filter which is
+// installed on the capture socket for the spec.
+type PacketMatcher struct {
+	vm *bpf.VM
+}
+
+// NewPacketMatcher creates a PacketMatcher for the given Packet spec, IP addresses, Service destinations and direction.
+// A nil spec matches all the IPv4 packets between the IP addresses. If Service destinations are given, they are matched
+// instead of dstIP, like in the BPF filter of a capture with a Service destination.
+func NewPacketMatcher(packetSpec *crdv1alpha1.Packet, srcIP, dstIP net.IP, destinations []ServiceDestination, direction crdv1alpha1.CaptureDirection) (*PacketMatcher, error) {
+	if packetSpec == nil {
+		packetSpec = &crdv1alpha1.Packet{}
+	}
+	vm, err := bpf.NewVM(compileServicePacketFilter(packetSpec, srcIP, dstIP, destinations, direction))
+	if err != nil {
+		return nil, err
+	}
+	return &PacketMatcher{vm: vm}, nil
+}
+
+// Match returns whether the given packet data, starting with the Ethernet header, matches the spec.
+func (m *PacketMatcher) Match(data []byte) bool {
+	n, err := m.vm.Run(data)
+	return err == nil && n > 0
+}
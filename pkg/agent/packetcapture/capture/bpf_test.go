@@ -18,7 +18,10 @@ import (
 	"net"
 	"testing"
 
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/net/bpf"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -1084,3 +1087,301 @@ func TestPacketCaptureCompileBPF(t *testing.T) {
 		})
 	}
 }
+
+func TestPacketMatcher(t *testing.T) {
+	srcIP := net.ParseIP("127.0.0.1")
+	dstIP := net.ParseIP("127.0.0.2")
+	craftTCPPacket := func(srcIP, dstIP net.IP, srcPort, dstPort layers.TCPPort, rst bool) []byte {
+		buffer := gopacket.NewSerializeBuffer()
+		ip := &layers.IPv4{Version: 4, IHL: 5, TTL: 64, Protocol: layers.IPProtocolTCP, SrcIP: srcIP, DstIP: dstIP}
+		tcp := &layers.TCP{SrcPort: srcPort, DstPort: dstPort, RST: rst}
+		require.NoError(t, tcp.SetNetworkLayerForChecksum(ip))
+		require.NoError(t, gopacket.SerializeLayers(buffer, gopacket.SerializeOptions{FixLengths: true},
+			&layers.Ethernet{
+				SrcMAC:       net.HardwareAddr{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0x01},
+				DstMAC:       net.HardwareAddr{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0x02},
+				EthernetType: layers.EthernetTypeIPv4,
+			},
+			ip, tcp,
+		))
+		return buffer.Bytes()
+	}
+	rstSpec := &crdv1alpha1.Packet{
+		Protocol: &testTCPProtocol,
+		TransportHeader: crdv1alpha1.TransportHeader{
+			TCP: &crdv1alpha1.TCPHeader{
+				DstPort: &testDstPort,
+				Flags:   []crdv1alpha1.TCPFlagsMatcher{{Value: 0x4}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		spec      *crdv1alpha1.Packet
+		direction crdv1alpha1.CaptureDirection
+		data      []byte
+		expected  bool
+	}{
+		{
+			name:      "any packet",
+			direction: crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:      craftTCPPacket(srcIP, dstIP, 12345, 80, false),
+			expected:  true,
+		},
+		{
+			name:      "matching packet",
+			spec:      rstSpec,
+			direction: crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:      craftTCPPacket(srcIP, dstIP, 12345, 80, true),
+			expected:  true,
+		},
+		{
+			name:      "unmatched flags",
+			spec:      rstSpec,
+			direction: crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:      craftTCPPacket(srcIP, dstIP, 12345, 80, false),
+			expected:  false,
+		},
+		{
+			name:      "unmatched direction",
+			spec:      rstSpec,
+			direction: crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:      craftTCPPacket(dstIP, srcIP, 80, 12345, true),
+			expected:  false,
+		},
+		{
+			name:      "truncated packet",
+			spec:      rstSpec,
+			direction: crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:      craftTCPPacket(srcIP, dstIP, 12345, 80, true)[:40],
+			expected:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := NewPacketMatcher(tt.spec, srcIP, dstIP, nil, tt.direction)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, matcher.Match(tt.data))
+		})
+	}
+}
+
+func TestCompileServicePacketFilter(t *testing.T) {
+	srcIP := net.ParseIP("10.10.0.2")
+	serviceIP := net.ParseIP("10.96.0.10")
+	craftPacket := func(srcIP, dstIP net.IP, srcPort, dstPort uint16, udp bool) []byte {
+		buffer := gopacket.NewSerializeBuffer()
+		ip := &layers.IPv4{Version: 4, IHL: 5, TTL: 64, Protocol: layers.IPProtocolTCP, SrcIP: srcIP, DstIP: dstIP}
+		var transport gopacket.SerializableLayer
+		if udp {
+			ip.Protocol = layers.IPProtocolUDP
+			transport = &layers.UDP{SrcPort: layers.UDPPort(srcPort), DstPort: layers.UDPPort(dstPort)}
+		} else {
+			transport = &layers.TCP{SrcPort: layers.TCPPort(srcPort), DstPort: layers.TCPPort(dstPort)}
+		}
+		require.NoError(t, gopacket.SerializeLayers(buffer, gopacket.SerializeOptions{FixLengths: true},
+			&layers.Ethernet{
+				SrcMAC:       net.HardwareAddr{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0x01},
+				DstMAC:       net.HardwareAddr{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0x02},
+				EthernetType: layers.EthernetTypeIPv4,
+			},
+			ip, transport,
+		))
+		return buffer.Bytes()
+	}
+	dnsPorts := []v1.ServicePort{
+		{Name: "dns", Port: 53, Protocol: v1.ProtocolUDP},
+		{Name: "dns-tcp", Port: 53, Protocol: v1.ProtocolTCP},
+		{Name: "metrics", Port: 9153, Protocol: v1.ProtocolTCP},
+	}
+	serviceDestination := ServiceDestination{IP: serviceIP, Ports: dnsPorts}
+	endpointIP := net.ParseIP("10.10.1.5")
+	endpointDestination := ServiceDestination{IP: endpointIP, Endpoint: true, Ports: []v1.ServicePort{
+		{Name: "dns", Port: 53, TargetPort: intstr.FromInt32(5353), Protocol: v1.ProtocolUDP},
+		{Name: "dns-tcp", Port: 53, TargetPort: intstr.FromInt32(5353), Protocol: v1.ProtocolTCP},
+		{Name: "metrics", Port: 9153, TargetPort: intstr.FromInt32(9153), Protocol: v1.ProtocolTCP},
+	}}
+	dnsDestinations := []ServiceDestination{serviceDestination}
+	dnatDestinations := []ServiceDestination{serviceDestination, endpointDestination}
+
+	tests := []struct {
+		name         string
+		spec         *crdv1alpha1.Packet
+		destinations []ServiceDestination
+		direction    crdv1alpha1.CaptureDirection
+		data         []byte
+		expected     bool
+	}{
+		{
+			name:         "UDP port",
+			destinations: dnsDestinations,
+			direction:    crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:         craftPacket(srcIP, serviceIP, 12345, 53, true),
+			expected:     true,
+		},
+		{
+			name:         "last TCP port",
+			destinations: dnsDestinations,
+			direction:    crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:         craftPacket(srcIP, serviceIP, 12345, 9153, false),
+			expected:     true,
+		},
+		{
+			name:         "unmatched port",
+			destinations: dnsDestinations,
+			direction:    crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:         craftPacket(srcIP, serviceIP, 12345, 9153, true),
+			expected:     false,
+		},
+		{
+			name:         "unmatched Service IP",
+			destinations: dnsDestinations,
+			direction:    crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:         craftPacket(srcIP, net.ParseIP("10.96.0.11"), 12345, 53, true),
+			expected:     false,
+		},
+		{
+			name:         "reply",
+			destinations: dnsDestinations,
+			direction:    crdv1alpha1.CaptureDirectionBoth,
+			data:         craftPacket(serviceIP, srcIP, 9153, 12345, false),
+			expected:     true,
+		},
+		{
+			name:         "port excluded by protocol",
+			spec:         &crdv1alpha1.Packet{Protocol: &testTCPProtocol},
+			destinations: dnsDestinations,
+			direction:    crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:         craftPacket(srcIP, serviceIP, 12345, 53, true),
+			expected:     false,
+		},
+		{
+			name: "port excluded by destination port",
+			spec: &crdv1alpha1.Packet{
+				Protocol:        &testTCPProtocol,
+				TransportHeader: crdv1alpha1.TransportHeader{TCP: &crdv1alpha1.TCPHeader{DstPort: ptr.To(int32(53))}},
+			},
+			destinations: dnsDestinations,
+			direction:    crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:         craftPacket(srcIP, serviceIP, 12345, 9153, false),
+			expected:     false,
+		},
+		{
+			name:         "DNATed packet",
+			destinations: dnatDestinations,
+			direction:    crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:         craftPacket(srcIP, endpointIP, 12345, 5353, true),
+			expected:     true,
+		},
+		{
+			name:         "DNATed reply",
+			destinations: dnatDestinations,
+			direction:    crdv1alpha1.CaptureDirectionBoth,
+			data:         craftPacket(endpointIP, srcIP, 5353, 12345, false),
+			expected:     true,
+		},
+		{
+			name:         "packet sent to the Service port of an Endpoint",
+			destinations: dnatDestinations,
+			direction:    crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:         craftPacket(srcIP, endpointIP, 12345, 53, true),
+			expected:     false,
+		},
+		{
+			name:         "DNATed packet with Service destination port",
+			destinations: dnatDestinations,
+			spec: &crdv1alpha1.Packet{
+				Protocol:        &testUDPProtocol,
+				TransportHeader: crdv1alpha1.TransportHeader{UDP: &crdv1alpha1.UDPHeader{DstPort: ptr.To(int32(53))}},
+			},
+			direction: crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:      craftPacket(srcIP, endpointIP, 12345, 5353, true),
+			expected:  true,
+		},
+		{
+			name:         "DNATed packet excluded by destination port",
+			destinations: dnatDestinations,
+			spec: &crdv1alpha1.Packet{
+				Protocol:        &testTCPProtocol,
+				TransportHeader: crdv1alpha1.TransportHeader{TCP: &crdv1alpha1.TCPHeader{DstPort: ptr.To(int32(9153))}},
+			},
+			direction: crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:      craftPacket(srcIP, endpointIP, 12345, 5353, false),
+			expected:  false,
+		},
+		{
+			name:         "DNATed packet not captured without Endpoints",
+			destinations: dnsDestinations,
+			direction:    crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:         craftPacket(srcIP, endpointIP, 12345, 5353, true),
+			expected:     false,
+		},
+		{
+			name:      "no port",
+			direction: crdv1alpha1.CaptureDirectionSourceToDestination,
+			data:      craftPacket(srcIP, serviceIP, 12345, 8080, false),
+			expected:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := tt.spec
+			if spec == nil {
+				spec = &crdv1alpha1.Packet{}
+			}
+			vm, err := bpf.NewVM(compileServicePacketFilter(spec, srcIP, serviceIP, tt.destinations, tt.direction))
+			require.NoError(t, err)
+			n, err := vm.Run(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, n > 0)
+		})
+	}
+}
+
+func TestFilterServicePorts(t *testing.T) {
+	httpPort := v1.ServicePort{Name: "http", Port: 80, Protocol: v1.ProtocolTCP}
+	dnsPort := v1.ServicePort{Name: "dns", Port: 53, Protocol: v1.ProtocolUDP}
+	sctpPort := v1.ServicePort{Name: "sctp", Port: 9999, Protocol: v1.ProtocolSCTP}
+	servicePorts := []v1.ServicePort{httpPort, dnsPort, sctpPort}
+
+	tests := []struct {
+		name     string
+		spec     *crdv1alpha1.Packet
+		expected []v1.ServicePort
+	}{
+		{
+			name:     "no spec",
+			expected: servicePorts,
+		},
+		{
+			name:     "protocol",
+			spec:     &crdv1alpha1.Packet{Protocol: &testUDPProtocol},
+			expected: []v1.ServicePort{dnsPort},
+		},
+		{
+			name:     "protocol number",
+			spec:     &crdv1alpha1.Packet{Protocol: ptr.To(intstr.FromInt32(132))},
+			expected: []v1.ServicePort{sctpPort},
+		},
+		{
+			name: "destination port",
+			spec: &crdv1alpha1.Packet{
+				TransportHeader: crdv1alpha1.TransportHeader{TCP: &crdv1alpha1.TCPHeader{DstPort: ptr.To(int32(80))}},
+			},
+			expected: []v1.ServicePort{httpPort},
+		},
+		{
+			name: "ICMP",
+			spec: &crdv1alpha1.Packet{
+				Protocol:        &testICMPProtocol,
+				TransportHeader: crdv1alpha1.TransportHeader{ICMP: &crdv1alpha1.ICMPHeader{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FilterServicePorts(tt.spec, servicePorts))
+		})
+	}
+}
@@ -41,10 +41,10 @@ func zeroFilter() []bpf.Instruction {
 	return []bpf.Instruction{returnDrop}
 }
 
-func (p *pcapCapture) Capture(ctx context.Context, device string, snapLen int, srcIP, dstIP net.IP, packet *crdv1alpha1.Packet, direction crdv1alpha1.CaptureDirection) (chan gopacket.Packet, error) {
+func (p *pcapCapture) Capture(ctx context.Context, device string, snapLen int, srcIP, dstIP net.IP, packet *crdv1alpha1.Packet, serviceDestinations []ServiceDestination, direction crdv1alpha1.CaptureDirection) (chan gopacket.Packet, error) {
 	// Compile the BPF filter in advance to reduce the time window between starting the capture and applying the filter.
-	inst := compilePacketFilter(packet, srcIP, dstIP, direction)
-	klog.V(5).InfoS("Generated bpf instructions for PacketCapture", "device", device, "srcIP", srcIP, "dstIP", dstIP, "packetSpec", packet, "bpf", inst)
+	inst := compileServicePacketFilter(packet, srcIP, dstIP, serviceDestinations, direction)
+	klog.V(5).InfoS("Generated bpf instructions for PacketCapture", "device", device, "srcIP", srcIP, "dstIP", dstIP, "packetSpec", packet, "serviceDestinations", serviceDestinations, "bpf", inst)
 	rawInst, err := bpf.Assemble(inst)
 	if err != nil {
 		return nil, err
@@ -59,6 +59,10 @@ func (p *pcapCapture) Capture(ctx context.Context, device string, snapLen int, s
 	if err != nil {
 		return nil, err
 	}
+	// Close the socket once the capture is stopped, which also unblocks the goroutine reading packets from it.
+	context.AfterFunc(ctx, func() {
+		eth.Close()
+	})
 	if err = eth.SetPromiscuous(false); err != nil {
 		return nil, err
 	}
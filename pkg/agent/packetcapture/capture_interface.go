@@ -20,9 +20,10 @@ import (
 
 	"github.com/gopacket/gopacket"
 
+	"antrea.io/antrea/v2/pkg/agent/packetcapture/capture"
 	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
 )
 
 type PacketCapturer interface {
-	Capture(ctx context.Context, device string, snapLen int, srcIP, dstIP net.IP, packet *crdv1alpha1.Packet, direction crdv1alpha1.CaptureDirection) (chan gopacket.Packet, error)
+	Capture(ctx context.Context, device string, snapLen int, srcIP, dstIP net.IP, packet *crdv1alpha1.Packet, serviceDestinations []capture.ServiceDestination, direction crdv1alpha1.CaptureDirection) (chan gopacket.Packet, error)
 }
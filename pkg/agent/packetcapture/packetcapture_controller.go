@@ -15,9 +15,13 @@
 package packetcapture
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"maps"
 	"net"
 	"os"
@@ -25,6 +29,7 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gopacket/gopacket"
@@ -33,8 +38,10 @@ import (
 	"github.com/spf13/afero"
 	"golang.org/x/time/rate"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -43,7 +50,9 @@ import (
 	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
+	"antrea.io/antrea/v2/pkg/agent/config"
 	"antrea.io/antrea/v2/pkg/agent/interfacestore"
 	"antrea.io/antrea/v2/pkg/agent/packetcapture/capture"
 	"antrea.io/antrea/v2/pkg/agent/util"
@@ -51,15 +60,12 @@ import (
 	clientsetversioned "antrea.io/antrea/v2/pkg/client/clientset/versioned"
 	crdinformers "antrea.io/antrea/v2/pkg/client/informers/externalversions/crd/v1alpha1"
 	crdlisters "antrea.io/antrea/v2/pkg/client/listers/crd/v1alpha1"
+	"antrea.io/antrea/v2/pkg/ovs/ovsconfig"
+	"antrea.io/antrea/v2/pkg/querier"
 	"antrea.io/antrea/v2/pkg/util/auth"
+	"antrea.io/antrea/v2/pkg/util/channel"
 	"antrea.io/antrea/v2/pkg/util/env"
-	"antrea.io/antrea/v2/pkg/util/sftp"
-)
-
-type storageProtocolType string
-
-const (
-	sftpProtocol storageProtocolType = "sftp"
+	"antrea.io/antrea/v2/pkg/util/fileupload"
 )
 
 const (
@@ -79,8 +85,17 @@ const (
 	// #nosec G101
 	fileServerAuthSecretName = "antrea-packetcapture-fileserver-auth"
 
-	// max packet size we can capture.
-	snapLen = 65536
+	// max packet size we can capture, which is also the default snap length.
+	defaultSnapLen = 65536
+
+	// The default destination ports of the tunnels, which are part of the names of the tunnel devices of the OVS
+	// kernel datapath.
+	defaultGenevePort = 6081
+	defaultVXLANPort  = 4789
+
+	// maxServiceFilterPorts is the maximum number of the ports of the ClusterIP and of the Endpoints of a Service
+	// matched by the BPF filter of a capture, whose size is proportional to it and cannot exceed 4096 instructions.
+	maxServiceFilterPorts = 64
 )
 
 type packetCapturePhase string
@@ -94,6 +109,8 @@ const (
 var (
 	packetDirectory = filepath.Join(os.TempDir(), "antrea", "packetcapture", "packets")
 	defaultFS       = afero.NewOsFs()
+
+	ErrPacketCaptureFileNotFound = errors.New("packets file not found")
 )
 
 type packetCaptureState struct {
@@ -105,6 +122,8 @@ type packetCaptureState struct {
 	targetCapturedPacketsNum int32
 	// phase is the phase of the PacketCapture.
 	phase packetCapturePhase
+	// stopReason is the reason why the capture stopped.
+	stopReason crdv1alpha1.PacketCaptureStopReason
 	// filePath is the final path shown in PacketCapture's status.
 	filePath string
 	// captureErr is the error observed during the capturing phase.
@@ -113,6 +132,17 @@ type packetCaptureState struct {
 	uploadErr error
 	// cancel is the cancel function for capture context.
 	cancel context.CancelFunc
+	// policyRuleTrigger is set while a RingBuffer capture waits for a packet to be dropped by a NetworkPolicy rule.
+	policyRuleTrigger *policyRuleTrigger
+}
+
+// captureDevice is a network device on which packets are captured.
+type captureDevice struct {
+	name string
+	// description describes the port of the OVS bridge corresponding to the device, e.g. "Pod default/web". It is
+	// written to the pcapng file with the name of the Node, so that the packets captured on different Nodes can be
+	// told apart once the files are merged.
+	description string
 }
 
 func (pcs *packetCaptureState) isCaptureSuccessful() bool {
@@ -126,13 +156,20 @@ type Controller struct {
 	packetCaptureLister   crdlisters.PacketCaptureLister
 	packetCaptureSynced   cache.InformerSynced
 	interfaceStore        interfacestore.InterfaceStore
+	nodeConfig            *config.NodeConfig
+	networkConfig         *config.NetworkConfig
+	networkPolicyQuerier  querier.AgentNetworkPolicyInfoQuerier
+	denyTrackingEnabled   bool
 	queue                 workqueue.TypedRateLimitingInterface[string]
-	sftpUploader          sftp.Uploader
+	uploader              *fileupload.Uploader
 	captureInterface      PacketCapturer
 	mutex                 sync.Mutex
 	// A name-state mapping for all PacketCapture CRs.
 	captures           map[string]*packetCaptureState
 	numRunningCaptures int
+	// numPolicyRuleTriggers is the number of RingBuffer captures waiting for a packet to be dropped by a NetworkPolicy
+	// rule. It can be read without holding mutex.
+	numPolicyRuleTriggers atomic.Int32
 }
 
 func NewPacketCaptureController(
@@ -140,6 +177,11 @@ func NewPacketCaptureController(
 	crdClient clientsetversioned.Interface,
 	packetCaptureInformer crdinformers.PacketCaptureInformer,
 	interfaceStore interfacestore.InterfaceStore,
+	nodeConfig *config.NodeConfig,
+	networkConfig *config.NetworkConfig,
+	npQuerier querier.AgentNetworkPolicyInfoQuerier,
+	denyTrackingEnabled bool,
+	policyRuleDropSubscriber channel.Subscriber,
 ) (*Controller, error) {
 	c := &Controller{
 		kubeClient:            kubeClient,
@@ -148,12 +190,16 @@ func NewPacketCaptureController(
 		packetCaptureLister:   packetCaptureInformer.Lister(),
 		packetCaptureSynced:   packetCaptureInformer.Informer().HasSynced,
 		interfaceStore:        interfaceStore,
+		nodeConfig:            nodeConfig,
+		networkConfig:         networkConfig,
+		networkPolicyQuerier:  npQuerier,
+		denyTrackingEnabled:   denyTrackingEnabled,
 		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
 			workqueue.NewTypedItemExponentialFailureRateLimiter[string](minRetryDelay, maxRetryDelay),
 			workqueue.TypedRateLimitingQueueConfig[string]{Name: "packetcapture"},
 		),
-		sftpUploader: sftp.NewUploader(),
-		captures:     make(map[string]*packetCaptureState),
+		uploader: fileupload.NewUploader(),
+		captures: make(map[string]*packetCaptureState),
 	}
 
 	packetCaptureInformer.Informer().AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
@@ -161,6 +207,9 @@ func NewPacketCaptureController(
 		UpdateFunc: c.updatePacketCapture,
 		DeleteFunc: c.deletePacketCapture,
 	}, resyncPeriod)
+	if policyRuleDropSubscriber != nil {
+		policyRuleDropSubscriber.Subscribe(c.handlePolicyRuleDrop)
+	}
 
 	capture, err := capture.NewPcapCapture()
 	if err != nil {
@@ -263,10 +312,17 @@ func (c *Controller) syncPacketCapture(pcName string) error {
 		c.cleanupPacketCapture(pcName)
 		return nil
 	}
+	// A capture performed on multiple Nodes is fanned out by antrea-controller, which creates a PacketCapture for each
+	// Node and aggregates their status.
+	if crdv1alpha1.IsMultiNodePacketCapture(pc) {
+		klog.V(4).InfoS("Skipping multi-Node PacketCapture", "name", pcName)
+		return nil
+	}
 
-	// Capture will not occur on this Node if a corresponding Pod interface is not found.
-	device := c.getTargetCaptureDevice(pc)
-	if device == "" {
+	// Capture will not occur on this Node if a corresponding Pod interface is not found, or if the target Node is
+	// another Node.
+	devices, deviceErr := c.getTargetCaptureDevices(pc)
+	if len(devices) == 0 && deviceErr == nil {
 		klog.V(4).InfoS("Skipping unrelated PacketCapture", "name", pcName)
 		return nil
 	}
@@ -277,8 +333,10 @@ func (c *Controller) syncPacketCapture(pcName string) error {
 		state := c.captures[pcName]
 		if state == nil {
 			state = &packetCaptureState{
-				phase:                    packetCapturePhasePending,
-				targetCapturedPacketsNum: pc.Spec.CaptureConfig.FirstN.Number,
+				phase: packetCapturePhasePending,
+			}
+			if pc.Spec.CaptureConfig.FirstN != nil {
+				state.targetCapturedPacketsNum = pc.Spec.CaptureConfig.FirstN.Number
 			}
 			c.captures[pcName] = state
 		}
@@ -292,6 +350,16 @@ func (c *Controller) syncPacketCapture(pcName string) error {
 			state.captureErr = err
 			return *state, nil
 		}
+		if deviceErr != nil {
+			state.captureErr = deviceErr
+			return *state, nil
+		}
+		if ringBuffer := pc.Spec.CaptureConfig.RingBuffer; ringBuffer != nil && ringBuffer.Trigger.PolicyRule != nil {
+			if err := c.validatePolicyRuleTrigger(ringBuffer.Trigger.PolicyRule); err != nil {
+				state.captureErr = err
+				return *state, nil
+			}
+		}
 		// Return the error as it's a transient error.
 		if c.numRunningCaptures >= maxConcurrentCaptures {
 			state.captureErr = fmt.Errorf("PacketCapture running count reach limit")
@@ -305,7 +373,7 @@ func (c *Controller) syncPacketCapture(pcName string) error {
 		state.phase = packetCapturePhaseStarted
 		// Start the capture goroutine in a separate goroutine. The goroutine will decrease numRunningCaptures on exit.
 		c.numRunningCaptures += 1
-		go c.startCapture(ctx, pc, state, device)
+		go c.startCapture(ctx, pc, state, devices)
 		return *state, nil
 	}()
 
@@ -317,41 +385,53 @@ func (c *Controller) syncPacketCapture(pcName string) error {
 
 func (c *Controller) validatePacketCapture(spec *crdv1alpha1.PacketCaptureSpec) error {
 	if spec.Packet != nil {
-		protocol := spec.Packet.Protocol
-		if protocol != nil {
-			if protocol.Type == intstr.String {
-				if _, ok := capture.ProtocolMap[strings.ToUpper(protocol.StrVal)]; !ok {
-					return fmt.Errorf("invalid protocol string, supported values are: %v (case insensitive)", slices.Collect(maps.Keys(capture.ProtocolMap)))
-				}
+		if err := validatePacket(spec.Packet); err != nil {
+			return err
+		}
+	}
+	if ringBuffer := spec.CaptureConfig.RingBuffer; ringBuffer != nil && ringBuffer.Trigger.Packet != nil {
+		if err := validatePacket(ringBuffer.Trigger.Packet); err != nil {
+			return fmt.Errorf("invalid trigger: %w", err)
+		}
+	}
+	return nil
+}
+
+func validatePacket(packet *crdv1alpha1.Packet) error {
+	protocol := packet.Protocol
+	if protocol != nil {
+		if protocol.Type == intstr.String {
+			if _, ok := capture.ProtocolMap[strings.ToUpper(protocol.StrVal)]; !ok {
+				return fmt.Errorf("invalid protocol string, supported values are: %v (case insensitive)", slices.Collect(maps.Keys(capture.ProtocolMap)))
 			}
 		}
-		if spec.Packet.TransportHeader.ICMP != nil {
-			for _, f := range spec.Packet.TransportHeader.ICMP.Messages {
-				switch f.Type.Type {
-				case intstr.Int:
-					if f.Type.IntVal < 0 || f.Type.IntVal > 255 {
-						return fmt.Errorf("invalid ICMP type integer: %d; must be between 0 and 255", f.Type.IntVal)
-					}
-				case intstr.String:
-					if _, ok := capture.ICMPMsgTypeMap[crdv1alpha1.ICMPMsgType(strings.ToLower(f.Type.StrVal))]; !ok {
-						return fmt.Errorf("invalid ICMP type string: %q; supported values are: %v (case insensitive)",
-							f.Type.StrVal, slices.Collect(maps.Keys(capture.ICMPMsgTypeMap)))
-					}
+	}
+	if packet.TransportHeader.ICMP != nil {
+		for _, f := range packet.TransportHeader.ICMP.Messages {
+			switch f.Type.Type {
+			case intstr.Int:
+				if f.Type.IntVal < 0 || f.Type.IntVal > 255 {
+					return fmt.Errorf("invalid ICMP type integer: %d; must be between 0 and 255", f.Type.IntVal)
+				}
+			case intstr.String:
+				if _, ok := capture.ICMPMsgTypeMap[crdv1alpha1.ICMPMsgType(strings.ToLower(f.Type.StrVal))]; !ok {
+					return fmt.Errorf("invalid ICMP type string: %q; supported values are: %v (case insensitive)",
+						f.Type.StrVal, slices.Collect(maps.Keys(capture.ICMPMsgTypeMap)))
 				}
 			}
 		}
-		if spec.Packet.TransportHeader.ICMPv6 != nil {
-			for _, f := range spec.Packet.TransportHeader.ICMPv6.Messages {
-				switch f.Type.Type {
-				case intstr.Int:
-					if f.Type.IntVal < 0 || f.Type.IntVal > 255 {
-						return fmt.Errorf("invalid ICMPv6 type integer: %d; must be between 0 and 255", f.Type.IntVal)
-					}
-				case intstr.String:
-					if _, ok := capture.ICMPv6MsgTypeMap[crdv1alpha1.ICMPv6MsgType(strings.ToLower(f.Type.StrVal))]; !ok {
-						return fmt.Errorf("invalid ICMPv6 type string: %q; supported values are: %v (case insensitive)",
-							f.Type.StrVal, slices.Collect(maps.Keys(capture.ICMPv6MsgTypeMap)))
-					}
+	}
+	if packet.TransportHeader.ICMPv6 != nil {
+		for _, f := range packet.TransportHeader.ICMPv6.Messages {
+			switch f.Type.Type {
+			case intstr.Int:
+				if f.Type.IntVal < 0 || f.Type.IntVal > 255 {
+					return fmt.Errorf("invalid ICMPv6 type integer: %d; must be between 0 and 255", f.Type.IntVal)
+				}
+			case intstr.String:
+				if _, ok := capture.ICMPv6MsgTypeMap[crdv1alpha1.ICMPv6MsgType(strings.ToLower(f.Type.StrVal))]; !ok {
+					return fmt.Errorf("invalid ICMPv6 type string: %q; supported values are: %v (case insensitive)",
+						f.Type.StrVal, slices.Collect(maps.Keys(capture.ICMPv6MsgTypeMap)))
 				}
 			}
 		}
@@ -377,6 +457,27 @@ func (c *Controller) cleanupPacketCapture(pcName string) {
 	}
 }
 
+// GetPacketCaptureFile returns the file storing the packets captured by the PacketCapture with the given name on the
+// current Node. It returns ErrPacketCaptureFileNotFound if the capture is not complete on the Node or no packet was
+// captured.
+func (c *Controller) GetPacketCaptureFile(name string) (io.ReadSeekCloser, error) {
+	c.mutex.Lock()
+	state, exists := c.captures[name]
+	found := exists && state.phase == packetCapturePhaseComplete && state.filePath != ""
+	c.mutex.Unlock()
+	if !found {
+		return nil, ErrPacketCaptureFileNotFound
+	}
+	file, err := defaultFS.Open(nameToPath(name))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrPacketCaptureFileNotFound
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
 func getPacketFile(filePath string) (afero.File, error) {
 	var file afero.File
 	if _, err := os.Stat(filePath); err == nil {
@@ -392,11 +493,27 @@ func getPacketFile(filePath string) (afero.File, error) {
 	return file, nil
 }
 
-// getTargetCaptureDevice is trying to locate the target device for packet capture. If the target
-// Pod does not exist on the current Node, the agent on this Node will not perform the capture.
-// In the PacketCapture spec, at least one of `.Spec.Source.Pod` or `.Spec.Destination.Pod`
-// should be set.
-func (c *Controller) getTargetCaptureDevice(pc *crdv1alpha1.PacketCapture) string {
+// getTargetCaptureDevices is trying to locate the target devices for packet capture. If no target
+// Pod exists on the current Node, the agent on this Node will not perform the capture.
+// In the PacketCapture spec, at least one of `.Spec.Source.Pod`, `.Spec.Destination.Pod` or
+// `.Spec.Node` should be set. Multiple devices are returned when CapturePoint is Both and both
+// Pods run on the current Node. An error is returned if the target is a port of the current Node
+// which can't be captured on.
+func (c *Controller) getTargetCaptureDevices(pc *crdv1alpha1.PacketCapture) ([]captureDevice, error) {
+	if pc.Spec.Node != nil {
+		if pc.Spec.Node.Name != c.nodeConfig.Name {
+			return nil, nil
+		}
+		device, err := c.getNodePortDevice(pc.Spec.Node)
+		if err != nil {
+			return nil, err
+		}
+		description := fmt.Sprintf("%s port", pc.Spec.Node.Port)
+		if pc.Spec.Node.Port == crdv1alpha1.PacketCapturePortTypeOVSPort {
+			description = fmt.Sprintf("OVS port %s", pc.Spec.Node.PortName)
+		}
+		return []captureDevice{{name: device, description: description}}, nil
+	}
 	// Set CapturePoint to 'Source' if a Source Pod is specified; otherwise, use 'Destination'.
 	if pc.Spec.CapturePoint == "" {
 		if pc.Spec.Source.Pod != nil {
@@ -406,14 +523,65 @@ func (c *Controller) getTargetCaptureDevice(pc *crdv1alpha1.PacketCapture) strin
 		}
 	}
 
-	var device string
+	var pods []*crdv1alpha1.PodReference
 	switch pc.Spec.CapturePoint {
 	case crdv1alpha1.CapturePointSource:
-		device = c.getPodDevice(pc.Spec.Source.Pod)
+		pods = append(pods, pc.Spec.Source.Pod)
 	case crdv1alpha1.CapturePointDestination:
-		device = c.getPodDevice(pc.Spec.Destination.Pod)
+		pods = append(pods, pc.Spec.Destination.Pod)
+	case crdv1alpha1.CapturePointBoth:
+		pods = append(pods, pc.Spec.Source.Pod, pc.Spec.Destination.Pod)
 	}
-	return device
+	var devices []captureDevice
+	for _, pod := range pods {
+		if device := c.getPodDevice(pod); device != "" && !slices.ContainsFunc(devices, func(d captureDevice) bool { return d.name == device }) {
+			devices = append(devices, captureDevice{name: device, description: fmt.Sprintf("Pod %s/%s", pod.Namespace, pod.Name)})
+		}
+	}
+	return devices, nil
+}
+
+// getNodePortDevice returns the network device on which the packets going through the given port of the OVS bridge
+// are captured.
+func (c *Controller) getNodePortDevice(node *crdv1alpha1.PacketCaptureNode) (string, error) {
+	switch node.Port {
+	case crdv1alpha1.PacketCapturePortTypeGateway:
+		return c.nodeConfig.GatewayConfig.Name, nil
+	case crdv1alpha1.PacketCapturePortTypeUplink:
+		return c.nodeConfig.NodeTransportInterfaceName, nil
+	case crdv1alpha1.PacketCapturePortTypeTunnel:
+		return c.getTunnelDevice()
+	case crdv1alpha1.PacketCapturePortTypeOVSPort:
+		iface, ok := c.interfaceStore.GetInterfaceByName(node.PortName)
+		if !ok {
+			return "", fmt.Errorf("OVS port %s not found on Node %s", node.PortName, node.Name)
+		}
+		// The OVS tunnel port has no network device.
+		if iface.Type == interfacestore.TunnelInterface {
+			return c.getTunnelDevice()
+		}
+		return iface.InterfaceName, nil
+	}
+	return "", fmt.Errorf("unsupported port type %s", node.Port)
+}
+
+// getTunnelDevice returns the tunnel device created by the OVS kernel datapath for the tunnel port. The packets are
+// received on it after decapsulation, and sent to it before encapsulation.
+func (c *Controller) getTunnelDevice() (string, error) {
+	var prefix string
+	var port int32
+	switch c.networkConfig.TunnelType {
+	case ovsconfig.GeneveTunnel:
+		prefix, port = "genev_sys_", defaultGenevePort
+	case ovsconfig.VXLANTunnel:
+		prefix, port = "vxlan_sys_", defaultVXLANPort
+	default:
+		return "", fmt.Errorf("capturing packets on the tunnel port is not supported with tunnel type %s", c.networkConfig.TunnelType)
+	}
+	if c.networkConfig.TunnelPort != 0 {
+		port = c.networkConfig.TunnelPort
+	}
+	return fmt.Sprintf("%s%d", prefix, port), nil
 }
 
 // getPodDevice returns the network device name for the given PodReference using the interfaceStore.
@@ -425,13 +593,15 @@ func (c *Controller) getPodDevice(pod *crdv1alpha1.PodReference) string {
 	return podInterfaces[0].InterfaceName
 }
 
-func (c *Controller) startCapture(ctx context.Context, pc *crdv1alpha1.PacketCapture, state *packetCaptureState, device string) {
-	klog.InfoS("Starting packet capture on the current Node", "name", pc.Name, "device", device)
-	defer klog.InfoS("Stopped packet capture on the current Node", "name", pc.Name, "device", device)
+func (c *Controller) startCapture(ctx context.Context, pc *crdv1alpha1.PacketCapture, state *packetCaptureState, devices []captureDevice) {
+	deviceNames := deviceNames(devices)
+	klog.InfoS("Starting packet capture on the current Node", "name", pc.Name, "devices", deviceNames)
+	defer klog.InfoS("Stopped packet capture on the current Node", "name", pc.Name, "devices", deviceNames)
 	// Resync the PacketCapture on exit of the capture goroutine.
 	defer c.enqueuePacketCapture(pc)
 
 	var filePath string
+	var stopReason crdv1alpha1.PacketCaptureStopReason
 	var captureErr, uploadErr error
 	func() {
 		localFilePath := nameToPath(pc.Name)
@@ -443,7 +613,7 @@ func (c *Controller) startCapture(ctx context.Context, pc *crdv1alpha1.PacketCap
 		defer file.Close()
 
 		var capturedAny bool
-		capturedAny, captureErr = c.performCapture(ctx, pc, state, file, device)
+		capturedAny, stopReason, captureErr = c.performCapture(ctx, pc, state, file, devices)
 		// If nothing is captured, no need to proceed.
 		if !capturedAny {
 			return
@@ -458,11 +628,14 @@ func (c *Controller) startCapture(ctx context.Context, pc *crdv1alpha1.PacketCap
 		if uploadErr = c.uploadPackets(context.TODO(), pc, file); uploadErr != nil {
 			return
 		}
-		filePath = fmt.Sprintf("%s/%s.pcapng", pc.Spec.FileServer.URL, pc.Name)
+		filePath = fmt.Sprintf("%s/%s", pc.Spec.FileServer.URL, c.generatePacketsPathForServer(pc.Name))
 	}()
 
 	if captureErr != nil {
 		klog.ErrorS(captureErr, "PacketCapture failed capturing packets", "name", pc.Name)
+		if stopReason == "" {
+			stopReason = crdv1alpha1.PacketCaptureStopReasonError
+		}
 	}
 	if uploadErr != nil {
 		klog.ErrorS(uploadErr, "PacketCapture failed uploading packets", "name", pc.Name)
@@ -471,77 +644,273 @@ func (c *Controller) startCapture(ctx context.Context, pc *crdv1alpha1.PacketCap
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	state.phase = packetCapturePhaseComplete
+	state.stopReason = stopReason
 	state.filePath = filePath
 	state.captureErr = captureErr
 	state.uploadErr = uploadErr
 	c.numRunningCaptures -= 1
 }
 
-// performCapture blocks until either the target number of packets have been captured, the context is canceled, or the
-// context reaches its deadline.
-// It returns a boolean indicating whether any packet is captured, and an error if the target number of packets are not
-// captured.
+// performCapture blocks until a stop condition of the capture config is met, the context is canceled, or the context
+// reaches its deadline.
+// It returns a boolean indicating whether any packet is captured, the reason why the capture stopped, and an error if
+// the capture stopped before a stop condition is met. For RingBuffer captures, the packets in the buffer are saved even
+// if the context reaches its deadline before the capture is triggered. Once triggered, a RingBuffer capture writes the
+// packets in the buffer, and keeps capturing the number of packets specified by AfterTrigger before it stops.
 func (c *Controller) performCapture(
 	ctx context.Context,
 	pc *crdv1alpha1.PacketCapture,
 	captureState *packetCaptureState,
 	file afero.File,
-	device string,
-) (bool, error) {
-	srcIP, dstIP, err := c.parseIPs(ctx, pc)
+	devices []captureDevice,
+) (bool, crdv1alpha1.PacketCaptureStopReason, error) {
+	srcIP, dstIP, serviceDestinations, err := c.parseIPs(ctx, pc)
 	if err != nil {
-		return false, err
+		return false, "", err
+	}
+	// Stop capturing on all the devices when the capture stops.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	captureConfig := &pc.Spec.CaptureConfig
+	snapLen := defaultSnapLen
+	if captureConfig.SnapLen != nil {
+		snapLen = int(*captureConfig.SnapLen)
+	}
+	var maxBytes int64
+	if captureConfig.MaxBytes != nil {
+		maxBytes = *captureConfig.MaxBytes
+	}
+	var ringBuffer *packetRingBuffer
+	var trigger *capture.PacketMatcher
+	var policyRuleTriggerCh <-chan struct{}
+	if captureConfig.RingBuffer != nil {
+		ringBuffer = newPacketRingBuffer(int(captureConfig.RingBuffer.Number), maxBytes)
+		if captureConfig.RingBuffer.Trigger.PolicyRule != nil {
+			policyRuleTrigger := newPolicyRuleTrigger(pc, srcIP, dstIP)
+			c.setPolicyRuleTrigger(captureState, policyRuleTrigger)
+			defer c.setPolicyRuleTrigger(captureState, nil)
+			policyRuleTriggerCh = policyRuleTrigger.ch
+		} else if trigger, err = newTriggerMatcher(pc, srcIP, dstIP, serviceDestinations); err != nil {
+			return false, "", fmt.Errorf("couldn't compile the trigger: %w", err)
+		}
 	}
 
-	// set SnapLength here to make tcpdump on Mac OSX works. By default, its value is
-	// 0 and means unlimited, but tcpdump on Mac OSX will complain:
-	// 'tcpdump: pcap_loop: invalid packet capture length <len>, bigger than snaplen of 524288'
-	ngInterface := pcapgo.DefaultNgInterface
-	ngInterface.SnapLength = snapLen
-	ngInterface.LinkType = layers.LinkTypeEthernet
-	pcapngWriter, err := pcapgo.NewNgWriterInterface(file, ngInterface, pcapgo.DefaultNgWriterOptions)
+	// Each device is described by an interface of the pcapng file, which is annotated with the Node and the port.
+	ngInterfaces := make([]pcapgo.NgInterface, len(devices))
+	for i, device := range devices {
+		// set SnapLength here to make tcpdump on Mac OSX works. By default, its value is
+		// 0 and means unlimited, but tcpdump on Mac OSX will complain:
+		// 'tcpdump: pcap_loop: invalid packet capture length <len>, bigger than snaplen of 524288'
+		ngInterface := pcapgo.DefaultNgInterface
+		ngInterface.Name = device.name
+		ngInterface.Description = fmt.Sprintf("%s on Node %s", device.description, c.nodeConfig.Name)
+		ngInterface.SnapLength = uint32(snapLen)
+		ngInterface.LinkType = layers.LinkTypeEthernet
+		ngInterfaces[i] = ngInterface
+	}
+	pcapngWriter, err := pcapgo.NewNgWriterInterface(file, ngInterfaces[0], pcapgo.DefaultNgWriterOptions)
 	if err != nil {
-		return false, fmt.Errorf("couldn't initialize a pcap writer: %w", err)
+		return false, "", fmt.Errorf("couldn't initialize a pcap writer: %w", err)
 	}
 	defer pcapngWriter.Flush()
+	for _, ngInterface := range ngInterfaces[1:] {
+		if _, err := pcapngWriter.AddInterface(ngInterface); err != nil {
+			return false, "", fmt.Errorf("couldn't initialize a pcap writer: %w", err)
+		}
+	}
 	updateRateLimiter := rate.NewLimiter(rate.Every(captureStatusUpdatePeriod), 1)
-	packets, err := c.captureInterface.Capture(ctx, device, snapLen, srcIP, dstIP, pc.Spec.Packet, pc.Spec.Direction)
+	packets, err := c.captureOnDevices(ctx, devices, snapLen, srcIP, dstIP, pc, serviceDestinations)
 	if err != nil {
-		return false, err
+		return false, "", err
+	}
+	var durationCh <-chan time.Time
+	if captureConfig.Duration != nil {
+		durationTimer := time.NewTimer(time.Duration(captureConfig.Duration.Seconds) * time.Second)
+		defer durationTimer.Stop()
+		durationCh = durationTimer.C
 	}
 	// Track whether any packet is captured.
 	capturedAny := false
+	var capturedBytes int64
+	// packetsAfterTrigger is the number of packets which remain to be captured after a RingBuffer capture is
+	// triggered.
+	var packetsAfterTrigger int32
+	// saveRingBuffer writes the packets in the ring buffer to the file when the capture stops or is triggered.
+	saveRingBuffer := func() error {
+		if ringBuffer == nil {
+			return nil
+		}
+		for _, p := range ringBuffer.list() {
+			if err := pcapngWriter.WritePacket(p.ci, p.data); err != nil {
+				return fmt.Errorf("couldn't write packets: %w", err)
+			}
+			capturedAny = true
+			capturedBytes += int64(len(p.data))
+		}
+		return nil
+	}
+	// onTrigger saves the packets in the ring buffer, after which the packets are written to the file as they are
+	// captured. It returns true if the capture should stop.
+	onTrigger := func() (bool, error) {
+		if err := saveRingBuffer(); err != nil {
+			return true, err
+		}
+		ringBuffer = nil
+		packetsAfterTrigger = captureConfig.RingBuffer.AfterTrigger
+		return packetsAfterTrigger == 0, nil
+	}
 	for {
 		select {
-		case packet := <-packets:
+		case packet, ok := <-packets:
+			// The channel is closed when the context is done or the packet sources fail. Stop receiving from it
+			// and wait for the context to be done.
+			if !ok {
+				packets = nil
+				continue
+			}
+			data := packet.Data()
+			// The original length of the packet is unknown if the packet source doesn't report it.
+			length := max(packet.Metadata().Length, len(data))
+			if len(data) > snapLen {
+				data = data[:snapLen]
+			}
 			ci := gopacket.CaptureInfo{
-				Timestamp:     time.Now(),
-				CaptureLength: len(packet.Data()),
-				Length:        len(packet.Data()),
+				Timestamp:      time.Now(),
+				CaptureLength:  len(data),
+				Length:         length,
+				InterfaceIndex: packet.interfaceIndex,
 			}
 			klog.V(5).InfoS("Captured packet", "name", pc.Name, "len", ci.Length)
-			if err = pcapngWriter.WritePacket(ci, packet.Data()); err != nil {
-				return capturedAny, fmt.Errorf("couldn't write packets: %w", err)
-			}
-			capturedAny = true
 
-			if success := func() bool {
-				c.mutex.Lock()
-				defer c.mutex.Unlock()
-				captureState.capturedPacketsNum++
-				klog.V(5).InfoS("Captured packets count", "name", pc.Name, "count", captureState.capturedPacketsNum)
-				return captureState.isCaptureSuccessful()
-			}(); success {
-				return true, nil
+			if ringBuffer != nil {
+				// The packet data may be reused by the packet source after the next packet is received.
+				ringBuffer.add(capturedPacket{ci: ci, data: bytes.Clone(data)})
+				func() {
+					c.mutex.Lock()
+					defer c.mutex.Unlock()
+					captureState.capturedPacketsNum = int32(ringBuffer.len())
+				}()
+				if trigger != nil && trigger.Match(data) {
+					klog.V(2).InfoS("Captured trigger packet", "name", pc.Name)
+					if stop, err := onTrigger(); stop {
+						return capturedAny, crdv1alpha1.PacketCaptureStopReasonTriggered, err
+					}
+				}
+			} else {
+				if maxBytes > 0 && capturedBytes+int64(len(data)) > maxBytes {
+					return capturedAny, crdv1alpha1.PacketCaptureStopReasonByteLimit, nil
+				}
+				if err = pcapngWriter.WritePacket(ci, data); err != nil {
+					return capturedAny, "", fmt.Errorf("couldn't write packets: %w", err)
+				}
+				capturedAny = true
+				capturedBytes += int64(len(data))
+
+				if success := func() bool {
+					c.mutex.Lock()
+					defer c.mutex.Unlock()
+					captureState.capturedPacketsNum++
+					klog.V(5).InfoS("Captured packets count", "name", pc.Name, "count", captureState.capturedPacketsNum)
+					return captureState.isCaptureSuccessful()
+				}(); success {
+					return true, crdv1alpha1.PacketCaptureStopReasonPacketLimit, nil
+				}
+				if packetsAfterTrigger > 0 {
+					packetsAfterTrigger--
+					if packetsAfterTrigger == 0 {
+						return true, crdv1alpha1.PacketCaptureStopReasonTriggered, nil
+					}
+				}
+				if maxBytes > 0 && capturedBytes == maxBytes {
+					return true, crdv1alpha1.PacketCaptureStopReasonByteLimit, nil
+				}
 			}
 			// use rate limiter to reduce the times we need to update status.
 			if updateRateLimiter.Allow() {
 				c.enqueuePacketCapture(pc)
 			}
+		case <-policyRuleTriggerCh:
+			klog.V(2).InfoS("Capture triggered by NetworkPolicy rule", "name", pc.Name)
+			// The capture is only triggered once.
+			policyRuleTriggerCh = nil
+			if stop, err := onTrigger(); stop {
+				return capturedAny, crdv1alpha1.PacketCaptureStopReasonTriggered, err
+			}
+		case <-durationCh:
+			return capturedAny, crdv1alpha1.PacketCaptureStopReasonDuration, nil
 		case <-ctx.Done():
-			return capturedAny, ctx.Err()
+			if err := saveRingBuffer(); err != nil {
+				return capturedAny, "", err
+			}
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return capturedAny, crdv1alpha1.PacketCaptureStopReasonTimeout, ctx.Err()
+			}
+			return capturedAny, "", ctx.Err()
+		}
+	}
+}
+
+// devicePacket is a packet captured on the device with the given index in the devices of a capture.
+type devicePacket struct {
+	gopacket.Packet
+	interfaceIndex int
+}
+
+// captureOnDevices starts capturing packets on all the given devices, and returns a channel receiving the packets
+// captured on any of them. The channel is closed when the packet sources of all the devices are closed.
+func (c *Controller) captureOnDevices(ctx context.Context, devices []captureDevice, snapLen int, srcIP, dstIP net.IP, pc *crdv1alpha1.PacketCapture, serviceDestinations []capture.ServiceDestination) (<-chan devicePacket, error) {
+	sources := make([]chan gopacket.Packet, len(devices))
+	for i, device := range devices {
+		source, err := c.captureInterface.Capture(ctx, device.name, snapLen, srcIP, dstIP, pc.Spec.Packet, serviceDestinations, pc.Spec.Direction)
+		if err != nil {
+			return nil, err
 		}
+		sources[i] = source
+	}
+	packets := make(chan devicePacket)
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for packet := range source {
+				select {
+				case packets <- devicePacket{Packet: packet, interfaceIndex: i}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
 	}
+	go func() {
+		wg.Wait()
+		close(packets)
+	}()
+	return packets, nil
+}
+
+func deviceNames(devices []captureDevice) []string {
+	var names []string
+	for _, device := range devices {
+		names = append(names, device.name)
+	}
+	return names
+}
+
+// newTriggerMatcher creates a PacketMatcher for the trigger packets of a RingBuffer capture. The trigger packets are
+// a subset of the captured packets, so they are matched with the same IP addresses, Service destinations, direction
+// and IP family.
+func newTriggerMatcher(pc *crdv1alpha1.PacketCapture, srcIP, dstIP net.IP, serviceDestinations []capture.ServiceDestination) (*capture.PacketMatcher, error) {
+	triggerPacket := pc.Spec.CaptureConfig.RingBuffer.Trigger.Packet.DeepCopy()
+	if triggerPacket == nil {
+		triggerPacket = &crdv1alpha1.Packet{}
+	}
+	triggerPacket.IPFamily = v1.IPv4Protocol
+	if pc.Spec.Packet != nil && pc.Spec.Packet.IPFamily != "" {
+		triggerPacket.IPFamily = pc.Spec.Packet.IPFamily
+	}
+	return capture.NewPacketMatcher(triggerPacket, srcIP, dstIP, serviceDestinations, pc.Spec.Direction)
 }
 
 func (c *Controller) getPodIP(ctx context.Context, podRef *crdv1alpha1.PodReference, ipFamily v1.IPFamily) (net.IP, error) {
@@ -574,7 +943,107 @@ func (c *Controller) getPodIP(ctx context.Context, podRef *crdv1alpha1.PodRefere
 	return podIP, nil
 }
 
-func (c *Controller) parseIPs(ctx context.Context, pc *crdv1alpha1.PacketCapture) (srcIP, dstIP net.IP, err error) {
+// getServiceDestinations returns the ClusterIP of the given Service with the given IP family, and the destinations of
+// the packets matching packetSpec which are sent to the Service: the ClusterIP with the Service ports to which such
+// packets can be sent and, if withEndpoints is true, the IPs of the current Endpoints of the Service with the
+// corresponding target ports, to which the packets are DNATed by OVS before reaching the gateway, tunnel or uplink.
+func (c *Controller) getServiceDestinations(ctx context.Context, serviceRef *crdv1alpha1.ServiceReference, packetSpec *crdv1alpha1.Packet, ipFamily v1.IPFamily, withEndpoints bool) (net.IP, []capture.ServiceDestination, error) {
+	service, err := c.kubeClient.CoreV1().Services(serviceRef.Namespace).Get(ctx, serviceRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get Service %s/%s: %w", serviceRef.Namespace, serviceRef.Name, err)
+	}
+	var clusterIPs []net.IP
+	for _, ip := range service.Spec.ClusterIPs {
+		// The ClusterIP of headless Services is "None", which is ignored.
+		if parsedIP := net.ParseIP(ip); parsedIP != nil {
+			clusterIPs = append(clusterIPs, parsedIP)
+		}
+	}
+	var serviceIP net.IP
+	if ipFamily == v1.IPv6Protocol {
+		serviceIP, _ = util.GetIPWithFamily(clusterIPs, util.FamilyIPv6)
+	} else {
+		serviceIP = util.GetIPv4Addr(clusterIPs)
+	}
+	if serviceIP == nil {
+		return nil, nil, fmt.Errorf("cannot find ClusterIP with %s address family for Service %s/%s", ipFamily, serviceRef.Namespace, serviceRef.Name)
+	}
+	ports := capture.FilterServicePorts(packetSpec, service.Spec.Ports)
+	if len(ports) == 0 {
+		return nil, nil, fmt.Errorf("no port of Service %s/%s matches the packet spec", serviceRef.Namespace, serviceRef.Name)
+	}
+	destinations := []capture.ServiceDestination{{IP: serviceIP, Ports: ports}}
+	if !withEndpoints {
+		return serviceIP, destinations, nil
+	}
+	endpointDestinations, err := c.getEndpointDestinations(ctx, serviceRef, ports, ipFamily)
+	if err != nil {
+		return nil, nil, err
+	}
+	filterPorts := len(ports)
+	for _, destination := range endpointDestinations {
+		filterPorts += len(destination.Ports)
+	}
+	if filterPorts > maxServiceFilterPorts {
+		return nil, nil, fmt.Errorf("too many Endpoints and ports for Service %s/%s to capture its packets on a Node, the maximum number of ports is %d", serviceRef.Namespace, serviceRef.Name, maxServiceFilterPorts)
+	}
+	return serviceIP, append(destinations, endpointDestinations...), nil
+}
+
+// getEndpointDestinations returns the IPs of the current Endpoints of the given Service with the given IP family, with
+// the given Service ports whose TargetPort is set to the port number of the Endpoints, as resolved in the
+// EndpointSlices of the Service.
+func (c *Controller) getEndpointDestinations(ctx context.Context, serviceRef *crdv1alpha1.ServiceReference, ports []v1.ServicePort, ipFamily v1.IPFamily) ([]capture.ServiceDestination, error) {
+	endpointSlices, err := c.kubeClient.DiscoveryV1().EndpointSlices(serviceRef.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{discoveryv1.LabelServiceName: serviceRef.Name}.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list EndpointSlices of Service %s/%s: %w", serviceRef.Namespace, serviceRef.Name, err)
+	}
+	addressType := discoveryv1.AddressTypeIPv4
+	if ipFamily == v1.IPv6Protocol {
+		addressType = discoveryv1.AddressTypeIPv6
+	}
+	var destinations []capture.ServiceDestination
+	endpointIPs := sets.New[string]()
+	for _, endpointSlice := range endpointSlices.Items {
+		if endpointSlice.AddressType != addressType {
+			continue
+		}
+		var endpointPorts []v1.ServicePort
+		for _, port := range ports {
+			for _, slicePort := range endpointSlice.Ports {
+				if ptr.Deref(slicePort.Name, "") != port.Name || ptr.Deref(slicePort.Protocol, v1.ProtocolTCP) != cmp.Or(port.Protocol, v1.ProtocolTCP) || slicePort.Port == nil {
+					continue
+				}
+				endpointPort := port
+				endpointPort.TargetPort = intstr.FromInt32(*slicePort.Port)
+				endpointPorts = append(endpointPorts, endpointPort)
+				break
+			}
+		}
+		if len(endpointPorts) == 0 {
+			continue
+		}
+		for _, endpoint := range endpointSlice.Endpoints {
+			for _, address := range endpoint.Addresses {
+				ip := net.ParseIP(address)
+				if ip == nil || endpointIPs.Has(ip.String()) {
+					continue
+				}
+				endpointIPs.Insert(ip.String())
+				destinations = append(destinations, capture.ServiceDestination{IP: ip, Ports: endpointPorts, Endpoint: true})
+			}
+		}
+	}
+	return destinations, nil
+}
+
+// parseIPs returns the source and destination IPs of the packets to capture. If the destination is a Service, the
+// destination IP is the ClusterIP of the Service, and the destinations of the packets sent to the Service are also
+// returned. When capturing on a Node port, they include the Endpoints of the Service, as the packets captured on the
+// gateway, tunnel or uplink have already been DNATed.
+func (c *Controller) parseIPs(ctx context.Context, pc *crdv1alpha1.PacketCapture) (srcIP, dstIP net.IP, serviceDestinations []capture.ServiceDestination, err error) {
 	ipFamily := v1.IPv4Protocol
 	if pc.Spec.Packet != nil {
 		ipFamily = pc.Spec.Packet.IPFamily
@@ -596,6 +1065,11 @@ func (c *Controller) parseIPs(ctx context.Context, pc *crdv1alpha1.PacketCapture
 		if err != nil {
 			return
 		}
+	} else if pc.Spec.Destination.Service != nil {
+		dstIP, serviceDestinations, err = c.getServiceDestinations(ctx, pc.Spec.Destination.Service, pc.Spec.Packet, ipFamily, pc.Spec.Node != nil)
+		if err != nil {
+			return
+		}
 	} else if pc.Spec.Destination.IP != nil {
 		dstIP = net.ParseIP(*pc.Spec.Destination.IP)
 		if dstIP == nil {
@@ -605,59 +1079,86 @@ func (c *Controller) parseIPs(ctx context.Context, pc *crdv1alpha1.PacketCapture
 	return
 }
 
-func (c *Controller) getUploaderByProtocol(protocol storageProtocolType) (sftp.Uploader, error) {
-	if protocol == sftpProtocol {
-		return c.sftpUploader, nil
-	}
-	return nil, fmt.Errorf("unsupported protocol %s", protocol)
-}
-
 func (c *Controller) generatePacketsPathForServer(name string) string {
 	return name + ".pcapng"
 }
 
 func (c *Controller) uploadPackets(ctx context.Context, pc *crdv1alpha1.PacketCapture, outputFile afero.File) error {
 	klog.V(2).InfoS("Uploading captured packets for PacketCapture", "name", pc.Name)
-	uploader, err := c.getUploaderByProtocol(sftpProtocol)
+	protocol, err := fileupload.GetProtocol(pc.Spec.FileServer.URL)
 	if err != nil {
 		return fmt.Errorf("failed to upload packets while getting uploader: %w", err)
 	}
-	if _, err := outputFile.Seek(0, 0); err != nil {
-		return fmt.Errorf("failed to upload to the file server while setting offset: %v", err)
-	}
 	authSecret := v1.SecretReference{
 		Name:      fileServerAuthSecretName,
 		Namespace: env.GetAntreaNamespace(),
 	}
-	serverAuth, err := auth.GetAuthConfigurationFromSecret(ctx, auth.BasicAuthenticationType, &authSecret, c.kubeClient)
+	var serverAuth *auth.AuthConfiguration
+	switch protocol {
+	case fileupload.S3Protocol:
+		serverAuth, err = auth.GetAuthConfigurationFromSecret(ctx, auth.AccessKeyType, &authSecret, c.kubeClient)
+	case fileupload.HTTPProtocol, fileupload.HTTPSProtocol:
+		// A bearer token is used if the Secret contains one, otherwise a username and a password are expected.
+		serverAuth, err = auth.GetAuthConfigurationFromSecret(ctx, auth.BearerTokenType, &authSecret, c.kubeClient)
+		if err != nil {
+			serverAuth, err = auth.GetAuthConfigurationFromSecret(ctx, auth.BasicAuthenticationType, &authSecret, c.kubeClient)
+		}
+	default:
+		serverAuth, err = auth.GetAuthConfigurationFromSecret(ctx, auth.BasicAuthenticationType, &authSecret, c.kubeClient)
+	}
 	if err != nil {
 		klog.ErrorS(err, "Failed to get authentication for the file server", "name", pc.Name, "authSecret", authSecret)
 		return err
 	}
-	if serverAuth.BasicAuthentication == nil {
-		return fmt.Errorf("failed to get basic authentication info for the file server")
+	fileServer := &fileupload.FileServer{
+		URL:           pc.Spec.FileServer.URL,
+		HostPublicKey: pc.Spec.FileServer.HostPublicKey,
 	}
-	cfg, err := sftp.GetSSHClientConfig(
-		serverAuth.BasicAuthentication.Username,
-		serverAuth.BasicAuthentication.Password,
-		pc.Spec.FileServer.HostPublicKey,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to generate SSH client config: %w", err)
+	if s3Config := pc.Spec.FileServer.S3; s3Config != nil {
+		fileServer.S3Endpoint = s3Config.Endpoint
+		fileServer.S3Region = s3Config.Region
 	}
-	return uploader.Upload(pc.Spec.FileServer.URL, c.generatePacketsPathForServer(pc.Name), cfg, outputFile)
+	return c.uploader.Upload(ctx, fileServer, serverAuth, c.generatePacketsPathForServer(pc.Name), outputFile)
 }
 
 func (c *Controller) updateStatus(ctx context.Context, pc *crdv1alpha1.PacketCapture, state packetCaptureState) error {
 	// Make a deepcopy as the object returned from lister must not be updated directly.
 	toUpdate := pc.DeepCopy()
-	var conditions []crdv1alpha1.PacketCaptureCondition
 	t := metav1.Now()
 	desiredStatus := crdv1alpha1.PacketCaptureStatus{
 		NumberCaptured: state.capturedPacketsNum,
 		FilePath:       state.filePath,
+		StopReason:     state.stopReason,
+		Conditions:     newConditions(pc, &state, t),
 	}
 
+	if retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if crdv1alpha1.PacketCaptureStatusEqual(toUpdate.Status, desiredStatus) {
+			return nil
+		}
+
+		desiredStatus.Conditions = mergeConditions(toUpdate.Status.Conditions, desiredStatus.Conditions)
+		toUpdate.Status = desiredStatus
+		klog.V(2).InfoS("Updating PacketCapture", "name", pc.Name, "status", toUpdate.Status)
+		_, updateErr := c.crdClient.CrdV1alpha1().PacketCaptures().UpdateStatus(ctx, toUpdate, metav1.UpdateOptions{})
+		if updateErr != nil && apierrors.IsConflict(updateErr) {
+			var getErr error
+			if toUpdate, getErr = c.crdClient.CrdV1alpha1().PacketCaptures().Get(ctx, pc.Name, metav1.GetOptions{}); getErr != nil {
+				return getErr
+			}
+		}
+		// Return the error from UPDATE.
+		return updateErr
+	}); retryErr != nil {
+		return retryErr
+	}
+	klog.V(2).InfoS("Updated PacketCapture", "name", pc.Name)
+	return nil
+}
+
+// newConditions returns the conditions of a PacketCapture according to the state of the capture on the current Node.
+func newConditions(pc *crdv1alpha1.PacketCapture, state *packetCaptureState, t metav1.Time) []crdv1alpha1.PacketCaptureCondition {
+	var conditions []crdv1alpha1.PacketCaptureCondition
 	var conditionStarted, conditionComplete, conditionUploaded crdv1alpha1.PacketCaptureCondition
 	switch state.phase {
 	case packetCapturePhasePending:
@@ -739,30 +1240,7 @@ func (c *Controller) updateStatus(ctx context.Context, pc *crdv1alpha1.PacketCap
 		}
 	}
 
-	desiredStatus.Conditions = conditions
-
-	if retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		if crdv1alpha1.PacketCaptureStatusEqual(toUpdate.Status, desiredStatus) {
-			return nil
-		}
-
-		desiredStatus.Conditions = mergeConditions(toUpdate.Status.Conditions, desiredStatus.Conditions)
-		toUpdate.Status = desiredStatus
-		klog.V(2).InfoS("Updating PacketCapture", "name", pc.Name, "status", toUpdate.Status)
-		_, updateErr := c.crdClient.CrdV1alpha1().PacketCaptures().UpdateStatus(ctx, toUpdate, metav1.UpdateOptions{})
-		if updateErr != nil && apierrors.IsConflict(updateErr) {
-			var getErr error
-			if toUpdate, getErr = c.crdClient.CrdV1alpha1().PacketCaptures().Get(ctx, pc.Name, metav1.GetOptions{}); getErr != nil {
-				return getErr
-			}
-		}
-		// Return the error from UPDATE.
-		return updateErr
-	}); retryErr != nil {
-		return retryErr
-	}
-	klog.V(2).InfoS("Updated PacketCapture", "name", pc.Name)
-	return nil
+	return conditions
 }
 
 func mergeConditions(oldConditions, newConditions []crdv1alpha1.PacketCaptureCondition) []crdv1alpha1.PacketCaptureCondition {
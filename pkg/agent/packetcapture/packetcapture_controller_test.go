@@ -25,12 +25,14 @@ import (
 
 	"github.com/gopacket/gopacket"
 	"github.com/gopacket/gopacket/layers"
+	"github.com/gopacket/gopacket/pcapgo"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/ssh"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -38,15 +40,22 @@ import (
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
 
+	"antrea.io/antrea/v2/pkg/agent/config"
 	"antrea.io/antrea/v2/pkg/agent/interfacestore"
+	"antrea.io/antrea/v2/pkg/agent/packetcapture/capture"
 	"antrea.io/antrea/v2/pkg/agent/util"
 	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
 	fakeversioned "antrea.io/antrea/v2/pkg/client/clientset/versioned/fake"
 	crdinformers "antrea.io/antrea/v2/pkg/client/informers/externalversions"
+	"antrea.io/antrea/v2/pkg/ovs/ovsconfig"
+	"antrea.io/antrea/v2/pkg/util/auth"
 	"antrea.io/antrea/v2/pkg/util/k8s"
+	"antrea.io/antrea/v2/pkg/util/s3upload"
 	sftptesting "antrea.io/antrea/v2/pkg/util/sftp/testing"
 )
 
@@ -101,6 +110,9 @@ var (
 			Name:      "pod-3",
 			Namespace: "default",
 		},
+		Spec: v1.PodSpec{
+			NodeName: "node-2",
+		},
 		Status: v1.PodStatus{
 			PodIPs: []v1.PodIP{
 				{IP: pod3IPv4},
@@ -109,6 +121,60 @@ var (
 		},
 	}
 
+	service1 = v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "service-1",
+			Namespace: "default",
+		},
+		Spec: v1.ServiceSpec{
+			ClusterIPs: []string{"10.96.0.10", "fd00:10:96::10"},
+			Ports: []v1.ServicePort{
+				{Name: "dns", Port: 53, Protocol: v1.ProtocolUDP},
+				{Name: "dns-tcp", Port: 53, Protocol: v1.ProtocolTCP},
+			},
+		},
+	}
+
+	// endpointSlice1 is an EndpointSlice of service1, which resolves the target ports of its two Endpoints.
+	endpointSlice1 = discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "service-1-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "service-1"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{pod2IPv4}},
+			{Addresses: []string{pod3IPv4}},
+		},
+		Ports: []discoveryv1.EndpointPort{
+			{Name: ptr.To("dns"), Port: ptr.To(int32(5353)), Protocol: ptr.To(v1.ProtocolUDP)},
+			{Name: ptr.To("dns-tcp"), Port: ptr.To(int32(5353)), Protocol: ptr.To(v1.ProtocolTCP)},
+		},
+	}
+	// endpointSlice1IPv6 is an IPv6 EndpointSlice of service1, which is ignored when capturing IPv4 packets.
+	endpointSlice1IPv6 = discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "service-1-fghij",
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "service-1"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv6,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{pod2IPv6}}},
+		Ports: []discoveryv1.EndpointPort{
+			{Name: ptr.To("dns"), Port: ptr.To(int32(5353)), Protocol: ptr.To(v1.ProtocolUDP)},
+		},
+	}
+
+	testNodeConfig = &config.NodeConfig{
+		Name:                       "node-1",
+		NodeTransportInterfaceName: "eth0",
+		GatewayConfig:              &config.GatewayConfig{Name: "antrea-gw0"},
+	}
+	testNetworkConfig = &config.NetworkConfig{
+		TunnelType: ovsconfig.GeneveTunnel,
+	}
+
 	secret1 = v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fileServerAuthSecretName,
@@ -179,6 +245,32 @@ func (uploader *testUploader) Upload(url string, fileName string, config *ssh.Cl
 	return nil
 }
 
+type testS3Uploader struct {
+	url      string
+	fileName string
+	config   *s3upload.Config
+}
+
+func (uploader *testS3Uploader) Upload(ctx context.Context, url string, fileName string, config *s3upload.Config, file io.ReadSeeker) error {
+	uploader.url = url
+	uploader.fileName = fileName
+	uploader.config = config
+	return nil
+}
+
+type testHTTPUploader struct {
+	url        string
+	fileName   string
+	serverAuth *auth.AuthConfiguration
+}
+
+func (uploader *testHTTPUploader) Upload(ctx context.Context, url string, fileName string, serverAuth *auth.AuthConfiguration, file io.ReadSeeker) error {
+	uploader.url = url
+	uploader.fileName = fileName
+	uploader.serverAuth = serverAuth
+	return nil
+}
+
 func craftTestPacket() gopacket.Packet {
 	buffer := gopacket.NewSerializeBuffer()
 	options := gopacket.SerializeOptions{}
@@ -204,7 +296,7 @@ func craftTestPacket() gopacket.Packet {
 type testCapture struct {
 }
 
-func (p *testCapture) Capture(ctx context.Context, device string, snapLen int, srcIP, dstIP net.IP, packet *crdv1alpha1.Packet, direction crdv1alpha1.CaptureDirection) (chan gopacket.Packet, error) {
+func (p *testCapture) Capture(ctx context.Context, device string, snapLen int, srcIP, dstIP net.IP, packet *crdv1alpha1.Packet, serviceDestinations []capture.ServiceDestination, direction crdv1alpha1.CaptureDirection) (chan gopacket.Packet, error) {
 	ch := make(chan gopacket.Packet, testCaptureNum)
 	for i := 0; i < 15; i++ {
 		ch <- craftTestPacket()
@@ -223,7 +315,7 @@ type fakePacketCaptureController struct {
 
 func newFakePacketCaptureController(t *testing.T, runtimeObjects []runtime.Object, initObjects []runtime.Object) *fakePacketCaptureController {
 	controller := gomock.NewController(t)
-	objs := append(runtimeObjects, &pod1, &pod2, &pod3, &secret1)
+	objs := append(runtimeObjects, &pod1, &pod2, &pod3, &service1, &endpointSlice1, &endpointSlice1IPv6, &secret1)
 	kubeClient := fake.NewSimpleClientset(objs...)
 	crdClient := fakeversioned.NewSimpleClientset(initObjects...)
 	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
@@ -235,7 +327,7 @@ func newFakePacketCaptureController(t *testing.T, runtimeObjects []runtime.Objec
 	addPodInterface(ifaceStore, pod2.Namespace, pod2.Name, []string{pod2IPv4, pod2IPv6}, pod2MAC.String(), int32(ofPortPod2))
 
 	// NewPacketCaptureController dont work on windows
-	pcController, err := NewPacketCaptureController(kubeClient, crdClient, packetCaptureInformer, ifaceStore)
+	pcController, err := NewPacketCaptureController(kubeClient, crdClient, packetCaptureInformer, ifaceStore, testNodeConfig, testNetworkConfig, nil, false, nil)
 	if err != nil {
 		pcController = &Controller{
 			kubeClient:            kubeClient,
@@ -244,6 +336,8 @@ func newFakePacketCaptureController(t *testing.T, runtimeObjects []runtime.Objec
 			packetCaptureLister:   packetCaptureInformer.Lister(),
 			packetCaptureSynced:   packetCaptureInformer.Informer().HasSynced,
 			interfaceStore:        ifaceStore,
+			nodeConfig:            testNodeConfig,
+			networkConfig:         testNetworkConfig,
 			captures:              make(map[string]*packetCaptureState),
 		}
 		packetCaptureInformer.Informer().AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
@@ -253,7 +347,9 @@ func newFakePacketCaptureController(t *testing.T, runtimeObjects []runtime.Objec
 		}, resyncPeriod)
 	}
 
-	pcController.sftpUploader = &testUploader{}
+	pcController.uploader.SFTPUploader = &testUploader{}
+	pcController.uploader.S3Uploader = &testS3Uploader{}
+	pcController.uploader.HTTPUploader = &testHTTPUploader{}
 	pcController.captureInterface = &testCapture{}
 	pcController.queue = workqueue.NewTypedRateLimitingQueueWithConfig(
 		workqueue.NewTypedItemExponentialFailureRateLimiter[string](time.Millisecond*50, time.Millisecond*200),
@@ -301,7 +397,7 @@ func TestMultiplePacketCaptures(t *testing.T) {
 		objs = append(objs, genTestCR(nameFunc(i), testCaptureNum))
 	}
 	pcc := newFakePacketCaptureController(t, nil, objs)
-	pcc.sftpUploader = &testUploader{url: testFTPUrl}
+	pcc.uploader.SFTPUploader = &testUploader{url: testFTPUrl}
 	stopCh := make(chan struct{})
 	defer close(stopCh)
 	pcc.crdInformerFactory.Start(stopCh)
@@ -647,7 +743,7 @@ func TestPacketCaptureControllerRun(t *testing.T) {
 		objs = append(objs, pc.pc)
 	}
 	pcc := newFakePacketCaptureController(t, nil, objs)
-	pcc.sftpUploader = &testUploader{url: "sftp://127.0.0.1:22/aaa"}
+	pcc.uploader.SFTPUploader = &testUploader{url: "sftp://127.0.0.1:22/aaa"}
 	stopCh := make(chan struct{})
 	defer close(stopCh)
 	defer defaultFS.Remove(packetDirectory)
@@ -685,7 +781,336 @@ func TestPacketCaptureControllerRun(t *testing.T) {
 	}
 }
 
-func TestGetTargetCaptureDevice(t *testing.T) {
+type testPacketsCapture struct {
+	packets []gopacket.Packet
+}
+
+func (p *testPacketsCapture) Capture(ctx context.Context, device string, snapLen int, srcIP, dstIP net.IP, packet *crdv1alpha1.Packet, serviceDestinations []capture.ServiceDestination, direction crdv1alpha1.CaptureDirection) (chan gopacket.Packet, error) {
+	ch := make(chan gopacket.Packet, len(p.packets))
+	for _, packet := range p.packets {
+		ch <- packet
+	}
+	return ch, nil
+}
+
+func craftTestTCPPacket(t *testing.T, srcIP, dstIP string, rst bool) gopacket.Packet {
+	buffer := gopacket.NewSerializeBuffer()
+	ip := &layers.IPv4{Version: 4, IHL: 5, TTL: 64, Protocol: layers.IPProtocolTCP, SrcIP: net.ParseIP(srcIP), DstIP: net.ParseIP(dstIP)}
+	tcp := &layers.TCP{SrcPort: 12345, DstPort: 80, RST: rst}
+	require.NoError(t, tcp.SetNetworkLayerForChecksum(ip))
+	require.NoError(t, gopacket.SerializeLayers(buffer, gopacket.SerializeOptions{FixLengths: true},
+		&layers.Ethernet{SrcMAC: pod1MAC, DstMAC: pod2MAC, EthernetType: layers.EthernetTypeIPv4},
+		ip, tcp, gopacket.Payload(make([]byte, 100)),
+	))
+	return gopacket.NewPacket(buffer.Bytes(), layers.LayerTypeEthernet, gopacket.Default)
+}
+
+func TestPerformCapture(t *testing.T) {
+	defaultFS = afero.NewMemMapFs()
+	defer func() {
+		defaultFS = afero.NewOsFs()
+	}()
+	tcpPacket := craftTestTCPPacket(t, pod1IPv4, pod2IPv4, false)
+	rstPacket := craftTestTCPPacket(t, pod1IPv4, pod2IPv4, true)
+	packetLen := len(tcpPacket.Data())
+	repeat := func(packet gopacket.Packet, n int) []gopacket.Packet {
+		return slices.Repeat([]gopacket.Packet{packet}, n)
+	}
+
+	tests := []struct {
+		name                string
+		captureConfig       crdv1alpha1.CaptureConfig
+		packets             []gopacket.Packet
+		expectedStopReason  crdv1alpha1.PacketCaptureStopReason
+		expectedErr         error
+		expectedNumCaptured int32
+		expectedPackets     []gopacket.Packet
+		expectedCaptureLen  int
+	}{
+		{
+			name: "first N",
+			captureConfig: crdv1alpha1.CaptureConfig{
+				FirstN: &crdv1alpha1.PacketCaptureFirstNConfig{Number: 3},
+			},
+			packets:             repeat(tcpPacket, 5),
+			expectedStopReason:  crdv1alpha1.PacketCaptureStopReasonPacketLimit,
+			expectedNumCaptured: 3,
+			expectedPackets:     repeat(tcpPacket, 3),
+		},
+		{
+			name: "first N with byte limit",
+			captureConfig: crdv1alpha1.CaptureConfig{
+				FirstN:   &crdv1alpha1.PacketCaptureFirstNConfig{Number: 5},
+				MaxBytes: ptr.To(int64(packetLen*2 + 1)),
+			},
+			packets:             repeat(tcpPacket, 5),
+			expectedStopReason:  crdv1alpha1.PacketCaptureStopReasonByteLimit,
+			expectedNumCaptured: 2,
+			expectedPackets:     repeat(tcpPacket, 2),
+		},
+		{
+			name: "first N with snap length",
+			captureConfig: crdv1alpha1.CaptureConfig{
+				FirstN:  &crdv1alpha1.PacketCaptureFirstNConfig{Number: 2},
+				SnapLen: ptr.To(int32(64)),
+			},
+			packets:             repeat(tcpPacket, 2),
+			expectedStopReason:  crdv1alpha1.PacketCaptureStopReasonPacketLimit,
+			expectedNumCaptured: 2,
+			expectedPackets:     repeat(tcpPacket, 2),
+			expectedCaptureLen:  64,
+		},
+		{
+			name: "first N timeout",
+			captureConfig: crdv1alpha1.CaptureConfig{
+				FirstN: &crdv1alpha1.PacketCaptureFirstNConfig{Number: 5},
+			},
+			packets:             repeat(tcpPacket, 2),
+			expectedStopReason:  crdv1alpha1.PacketCaptureStopReasonTimeout,
+			expectedErr:         context.DeadlineExceeded,
+			expectedNumCaptured: 2,
+			expectedPackets:     repeat(tcpPacket, 2),
+		},
+		{
+			name: "duration",
+			captureConfig: crdv1alpha1.CaptureConfig{
+				Duration: &crdv1alpha1.PacketCaptureDurationConfig{Seconds: 1},
+			},
+			packets:             repeat(tcpPacket, 4),
+			expectedStopReason:  crdv1alpha1.PacketCaptureStopReasonDuration,
+			expectedNumCaptured: 4,
+			expectedPackets:     repeat(tcpPacket, 4),
+		},
+		{
+			name: "ring buffer triggered",
+			captureConfig: crdv1alpha1.CaptureConfig{
+				RingBuffer: &crdv1alpha1.PacketCaptureRingBufferConfig{
+					Number: 3,
+					Trigger: crdv1alpha1.PacketCaptureTrigger{
+						Packet: &crdv1alpha1.Packet{
+							Protocol: &tcpProto,
+							TransportHeader: crdv1alpha1.TransportHeader{
+								TCP: &crdv1alpha1.TCPHeader{Flags: []crdv1alpha1.TCPFlagsMatcher{{Value: 0x4}}},
+							},
+						},
+					},
+				},
+			},
+			packets:             append(repeat(tcpPacket, 5), rstPacket, tcpPacket),
+			expectedStopReason:  crdv1alpha1.PacketCaptureStopReasonTriggered,
+			expectedNumCaptured: 3,
+			expectedPackets:     []gopacket.Packet{tcpPacket, tcpPacket, rstPacket},
+		},
+		{
+			name: "ring buffer triggered by first packet",
+			captureConfig: crdv1alpha1.CaptureConfig{
+				RingBuffer: &crdv1alpha1.PacketCaptureRingBufferConfig{
+					Number: 3,
+					Trigger: crdv1alpha1.PacketCaptureTrigger{
+						Packet: &crdv1alpha1.Packet{
+							Protocol: &tcpProto,
+							TransportHeader: crdv1alpha1.TransportHeader{
+								TCP: &crdv1alpha1.TCPHeader{Flags: []crdv1alpha1.TCPFlagsMatcher{{Value: 0x4}}},
+							},
+						},
+					},
+				},
+			},
+			packets:             append([]gopacket.Packet{rstPacket}, repeat(tcpPacket, 5)...),
+			expectedStopReason:  crdv1alpha1.PacketCaptureStopReasonTriggered,
+			expectedNumCaptured: 1,
+			expectedPackets:     []gopacket.Packet{rstPacket},
+		},
+		{
+			name: "ring buffer triggered with packets after trigger",
+			captureConfig: crdv1alpha1.CaptureConfig{
+				RingBuffer: &crdv1alpha1.PacketCaptureRingBufferConfig{
+					Number: 2,
+					Trigger: crdv1alpha1.PacketCaptureTrigger{
+						Packet: &crdv1alpha1.Packet{
+							Protocol: &tcpProto,
+							TransportHeader: crdv1alpha1.TransportHeader{
+								TCP: &crdv1alpha1.TCPHeader{Flags: []crdv1alpha1.TCPFlagsMatcher{{Value: 0x4}}},
+							},
+						},
+					},
+					AfterTrigger: 3,
+				},
+			},
+			packets:             append(append(repeat(tcpPacket, 5), rstPacket), repeat(tcpPacket, 2)...),
+			expectedStopReason:  crdv1alpha1.PacketCaptureStopReasonTimeout,
+			expectedErr:         context.DeadlineExceeded,
+			expectedNumCaptured: 4,
+			expectedPackets:     append([]gopacket.Packet{tcpPacket, rstPacket}, repeat(tcpPacket, 2)...),
+		},
+		{
+			name: "ring buffer stopped after packets after trigger",
+			captureConfig: crdv1alpha1.CaptureConfig{
+				RingBuffer: &crdv1alpha1.PacketCaptureRingBufferConfig{
+					Number: 2,
+					Trigger: crdv1alpha1.PacketCaptureTrigger{
+						Packet: &crdv1alpha1.Packet{
+							Protocol: &tcpProto,
+							TransportHeader: crdv1alpha1.TransportHeader{
+								TCP: &crdv1alpha1.TCPHeader{Flags: []crdv1alpha1.TCPFlagsMatcher{{Value: 0x4}}},
+							},
+						},
+					},
+					AfterTrigger: 2,
+				},
+			},
+			packets:             append(append(repeat(tcpPacket, 5), rstPacket), repeat(tcpPacket, 3)...),
+			expectedStopReason:  crdv1alpha1.PacketCaptureStopReasonTriggered,
+			expectedNumCaptured: 4,
+			expectedPackets:     append([]gopacket.Packet{tcpPacket, rstPacket}, repeat(tcpPacket, 2)...),
+		},
+		{
+			name: "ring buffer timeout with byte limit",
+			captureConfig: crdv1alpha1.CaptureConfig{
+				RingBuffer: &crdv1alpha1.PacketCaptureRingBufferConfig{
+					Number: 3,
+					Trigger: crdv1alpha1.PacketCaptureTrigger{
+						Packet: &crdv1alpha1.Packet{
+							Protocol: &tcpProto,
+							TransportHeader: crdv1alpha1.TransportHeader{
+								TCP: &crdv1alpha1.TCPHeader{Flags: []crdv1alpha1.TCPFlagsMatcher{{Value: 0x4}}},
+							},
+						},
+					},
+				},
+				MaxBytes: ptr.To(int64(packetLen * 2)),
+			},
+			packets:             repeat(tcpPacket, 5),
+			expectedStopReason:  crdv1alpha1.PacketCaptureStopReasonTimeout,
+			expectedErr:         context.DeadlineExceeded,
+			expectedNumCaptured: 2,
+			expectedPackets:     repeat(tcpPacket, 2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pcc := newFakePacketCaptureController(t, nil, nil)
+			pcc.captureInterface = &testPacketsCapture{packets: tt.packets}
+			pc := genTestCR("pc", 0)
+			pc.Spec.Packet = &crdv1alpha1.Packet{Protocol: &tcpProto}
+			pc.Spec.Direction = crdv1alpha1.CaptureDirectionSourceToDestination
+			pc.Spec.CaptureConfig = tt.captureConfig
+			state := &packetCaptureState{}
+			if tt.captureConfig.FirstN != nil {
+				state.targetCapturedPacketsNum = tt.captureConfig.FirstN.Number
+			}
+			file, err := getPacketFile(nameToPath(pc.Name))
+			require.NoError(t, err)
+			defer file.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+			defer cancel()
+			capturedAny, stopReason, err := pcc.performCapture(ctx, pc, state, file, []captureDevice{{name: "test"}})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedStopReason, stopReason)
+			assert.Equal(t, len(tt.expectedPackets) > 0, capturedAny)
+			assert.Equal(t, tt.expectedNumCaptured, state.capturedPacketsNum)
+
+			_, err = file.Seek(0, io.SeekStart)
+			require.NoError(t, err)
+			reader, err := pcapgo.NewNgReader(file, pcapgo.DefaultNgReaderOptions)
+			require.NoError(t, err)
+			for _, expectedPacket := range tt.expectedPackets {
+				data, ci, err := reader.ReadPacketData()
+				require.NoError(t, err)
+				expectedData := expectedPacket.Data()
+				if tt.expectedCaptureLen > 0 {
+					expectedData = expectedData[:tt.expectedCaptureLen]
+				}
+				assert.Equal(t, expectedData, data)
+				assert.Equal(t, len(expectedPacket.Data()), ci.Length)
+			}
+			_, _, err = reader.ReadPacketData()
+			assert.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+func TestPerformCaptureOnMultipleDevices(t *testing.T) {
+	defaultFS = afero.NewMemMapFs()
+	defer func() {
+		defaultFS = afero.NewOsFs()
+	}()
+	pcc := newFakePacketCaptureController(t, nil, nil)
+	tcpPacket := craftTestTCPPacket(t, pod1IPv4, pod2IPv4, false)
+	pcc.captureInterface = &testPacketsCapture{packets: slices.Repeat([]gopacket.Packet{tcpPacket}, 2)}
+	pc := genTestCR("pc", 0)
+	pc.Spec.CapturePoint = crdv1alpha1.CapturePointBoth
+	pc.Spec.CaptureConfig = crdv1alpha1.CaptureConfig{FirstN: &crdv1alpha1.PacketCaptureFirstNConfig{Number: 4}}
+	state := &packetCaptureState{targetCapturedPacketsNum: 4}
+	file, err := getPacketFile(nameToPath(pc.Name))
+	require.NoError(t, err)
+	defer file.Close()
+
+	devices := []captureDevice{
+		{name: "pod1-eth0", description: "Pod default/pod-1"},
+		{name: "pod2-eth0", description: "Pod default/pod-2"},
+	}
+	capturedAny, stopReason, err := pcc.performCapture(context.Background(), pc, state, file, devices)
+	require.NoError(t, err)
+	assert.True(t, capturedAny)
+	assert.Equal(t, crdv1alpha1.PacketCaptureStopReasonPacketLimit, stopReason)
+	assert.Equal(t, int32(4), state.capturedPacketsNum)
+
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	reader, err := pcapgo.NewNgReader(file, pcapgo.DefaultNgReaderOptions)
+	require.NoError(t, err)
+	packetsPerInterface := map[int]int{}
+	for range 4 {
+		_, ci, err := reader.ReadPacketData()
+		require.NoError(t, err)
+		packetsPerInterface[ci.InterfaceIndex]++
+	}
+	assert.Equal(t, map[int]int{0: 2, 1: 2}, packetsPerInterface)
+	require.Equal(t, 2, reader.NInterfaces())
+	for i, device := range devices {
+		ngInterface, err := reader.Interface(i)
+		require.NoError(t, err)
+		assert.Equal(t, device.name, ngInterface.Name)
+		assert.Equal(t, device.description+" on Node node-1", ngInterface.Description)
+	}
+}
+
+// TestSyncMultiNodePacketCapture verifies that a multi-Node PacketCapture is left to antrea-controller, while the
+// PacketCapture created by antrea-controller for the current Node is processed.
+func TestSyncMultiNodePacketCapture(t *testing.T) {
+	parent := genTestCR("pc-both", testCaptureNum)
+	parent.Spec.CapturePoint = crdv1alpha1.CapturePointBoth
+	child := genTestCR("pc-both-node-1", testCaptureNum)
+	child.Spec.CapturePoint = crdv1alpha1.CapturePointBoth
+	child.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(parent, crdv1alpha1.SchemeGroupVersion.WithKind("PacketCapture"))}
+
+	pcc := newFakePacketCaptureController(t, nil, []runtime.Object{parent, child})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	pcc.crdInformerFactory.Start(stopCh)
+	pcc.crdInformerFactory.WaitForCacheSync(stopCh)
+	// Keep the PacketCaptures pending so that no capture is started.
+	pcc.numRunningCaptures = maxConcurrentCaptures
+
+	require.NoError(t, pcc.syncPacketCapture(parent.Name))
+	assert.NotContains(t, pcc.captures, parent.Name)
+	assert.EqualError(t, pcc.syncPacketCapture(child.Name), "PacketCapture running count reach limit")
+	assert.Contains(t, pcc.captures, child.Name)
+
+	for _, action := range pcc.crdClient.Actions() {
+		if action.GetVerb() == "update" {
+			assert.Equal(t, child.Name, action.(k8stesting.UpdateAction).GetObject().(*crdv1alpha1.PacketCapture).Name)
+		}
+	}
+}
+
+func TestGetTargetCaptureDevices(t *testing.T) {
 	pod1Ref := &crdv1alpha1.PodReference{Namespace: pod1.Namespace, Name: pod1.Name}
 	pod2Ref := &crdv1alpha1.PodReference{Namespace: pod2.Namespace, Name: pod2.Name}
 
@@ -698,12 +1123,22 @@ func TestGetTargetCaptureDevice(t *testing.T) {
 	pccPod1Only := newFakePacketCaptureController(t, nil, nil)
 	pccPod1Only.interfaceStore = interfacestore.NewInterfaceStore()
 	addPodInterface(pccPod1Only.interfaceStore, pod1.Namespace, pod1.Name, []string{pod1IPv4}, pod1MAC.String(), int32(ofPortPod1))
+	pcc.interfaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		Type:          interfacestore.TunnelInterface,
+		InterfaceName: "antrea-tun0",
+	})
+
+	pccVXLAN := newFakePacketCaptureController(t, nil, nil)
+	pccVXLAN.networkConfig = &config.NetworkConfig{TunnelType: ovsconfig.VXLANTunnel, TunnelPort: 8472}
+	pccGRE := newFakePacketCaptureController(t, nil, nil)
+	pccGRE.networkConfig = &config.NetworkConfig{TunnelType: ovsconfig.GRETunnel}
 
 	testCases := []struct {
-		name           string
-		controller     *Controller
-		pcSpec         crdv1alpha1.PacketCaptureSpec
-		expectedDevice string
+		name            string
+		controller      *Controller
+		pcSpec          crdv1alpha1.PacketCaptureSpec
+		expectedDevices []string
+		expectedErr     string
 	}{
 		{
 			name:       "Source capture, source Pod is local",
@@ -712,7 +1147,7 @@ func TestGetTargetCaptureDevice(t *testing.T) {
 				Source:       crdv1alpha1.Source{Pod: pod1Ref},
 				CapturePoint: crdv1alpha1.CapturePointSource,
 			},
-			expectedDevice: pod1Device,
+			expectedDevices: []string{pod1Device},
 		},
 		{
 			name:       "Source capture, source Pod is remote",
@@ -721,7 +1156,7 @@ func TestGetTargetCaptureDevice(t *testing.T) {
 				Source:       crdv1alpha1.Source{Pod: pod2Ref},
 				CapturePoint: crdv1alpha1.CapturePointSource,
 			},
-			expectedDevice: "",
+			expectedDevices: nil,
 		},
 		{
 			name:       "Destination capture, destination Pod is local",
@@ -730,7 +1165,7 @@ func TestGetTargetCaptureDevice(t *testing.T) {
 				Destination:  crdv1alpha1.Destination{Pod: pod2Ref},
 				CapturePoint: crdv1alpha1.CapturePointDestination,
 			},
-			expectedDevice: pod2Device,
+			expectedDevices: []string{pod2Device},
 		},
 		{
 			name:       "Default point (source only)",
@@ -738,7 +1173,7 @@ func TestGetTargetCaptureDevice(t *testing.T) {
 			pcSpec: crdv1alpha1.PacketCaptureSpec{
 				Source: crdv1alpha1.Source{Pod: pod1Ref},
 			},
-			expectedDevice: pod1Device,
+			expectedDevices: []string{pod1Device},
 		},
 		{
 			name:       "Default point (destination only)",
@@ -746,15 +1181,233 @@ func TestGetTargetCaptureDevice(t *testing.T) {
 			pcSpec: crdv1alpha1.PacketCaptureSpec{
 				Destination: crdv1alpha1.Destination{Pod: pod1Ref},
 			},
-			expectedDevice: pod1Device,
+			expectedDevices: []string{pod1Device},
+		},
+		{
+			name:       "Both capture, both Pods are local",
+			controller: pcc.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Source:       crdv1alpha1.Source{Pod: pod1Ref},
+				Destination:  crdv1alpha1.Destination{Pod: pod2Ref},
+				CapturePoint: crdv1alpha1.CapturePointBoth,
+			},
+			expectedDevices: []string{pod1Device, pod2Device},
+		},
+		{
+			name:       "Both capture, only source Pod is local",
+			controller: pccPod1Only.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Source:       crdv1alpha1.Source{Pod: pod1Ref},
+				Destination:  crdv1alpha1.Destination{Pod: pod2Ref},
+				CapturePoint: crdv1alpha1.CapturePointBoth,
+			},
+			expectedDevices: []string{pod1Device},
+		},
+		{
+			name:       "Remote Node",
+			controller: pcc.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Node: &crdv1alpha1.PacketCaptureNode{Name: "node-2", Port: crdv1alpha1.PacketCapturePortTypeGateway},
+			},
+			expectedDevices: nil,
+		},
+		{
+			name:       "Gateway port",
+			controller: pcc.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Source: crdv1alpha1.Source{Pod: pod1Ref},
+				Node:   &crdv1alpha1.PacketCaptureNode{Name: "node-1", Port: crdv1alpha1.PacketCapturePortTypeGateway},
+			},
+			expectedDevices: []string{"antrea-gw0"},
+		},
+		{
+			name:       "Uplink port",
+			controller: pcc.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Node: &crdv1alpha1.PacketCaptureNode{Name: "node-1", Port: crdv1alpha1.PacketCapturePortTypeUplink},
+			},
+			expectedDevices: []string{"eth0"},
+		},
+		{
+			name:       "Geneve tunnel port",
+			controller: pcc.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Node: &crdv1alpha1.PacketCaptureNode{Name: "node-1", Port: crdv1alpha1.PacketCapturePortTypeTunnel},
+			},
+			expectedDevices: []string{"genev_sys_6081"},
+		},
+		{
+			name:       "VXLAN tunnel port with custom port",
+			controller: pccVXLAN.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Node: &crdv1alpha1.PacketCaptureNode{Name: "node-1", Port: crdv1alpha1.PacketCapturePortTypeTunnel},
+			},
+			expectedDevices: []string{"vxlan_sys_8472"},
+		},
+		{
+			name:       "GRE tunnel port",
+			controller: pccGRE.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Node: &crdv1alpha1.PacketCaptureNode{Name: "node-1", Port: crdv1alpha1.PacketCapturePortTypeTunnel},
+			},
+			expectedErr: "capturing packets on the tunnel port is not supported with tunnel type gre",
+		},
+		{
+			name:       "OVS port of a Pod",
+			controller: pcc.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Node: &crdv1alpha1.PacketCaptureNode{Name: "node-1", Port: crdv1alpha1.PacketCapturePortTypeOVSPort, PortName: pod2Device},
+			},
+			expectedDevices: []string{pod2Device},
+		},
+		{
+			name:       "OVS tunnel port",
+			controller: pcc.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Node: &crdv1alpha1.PacketCaptureNode{Name: "node-1", Port: crdv1alpha1.PacketCapturePortTypeOVSPort, PortName: "antrea-tun0"},
+			},
+			expectedDevices: []string{"genev_sys_6081"},
+		},
+		{
+			name:       "Unknown OVS port",
+			controller: pcc.Controller,
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Node: &crdv1alpha1.PacketCaptureNode{Name: "node-1", Port: crdv1alpha1.PacketCapturePortTypeOVSPort, PortName: "foo"},
+			},
+			expectedErr: "OVS port foo not found on Node node-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pc := &crdv1alpha1.PacketCapture{Spec: tc.pcSpec}
+			devices, err := tc.controller.getTargetCaptureDevices(pc)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedDevices, deviceNames(devices))
+		})
+	}
+}
+
+func TestParseIPs(t *testing.T) {
+	pcc := newFakePacketCaptureController(t, nil, nil)
+	pod1Ref := &crdv1alpha1.PodReference{Namespace: pod1.Namespace, Name: pod1.Name}
+	service1Ref := &crdv1alpha1.ServiceReference{Namespace: service1.Namespace, Name: service1.Name}
+	udpProto := intstr.FromString("UDP")
+
+	withTargetPort := func(port v1.ServicePort, targetPort int32) v1.ServicePort {
+		port.TargetPort = intstr.FromInt32(targetPort)
+		return port
+	}
+
+	testCases := []struct {
+		name                        string
+		pcSpec                      crdv1alpha1.PacketCaptureSpec
+		expectedSrcIP               net.IP
+		expectedDstIP               net.IP
+		expectedServiceDestinations []capture.ServiceDestination
+		expectedErr                 string
+	}{
+		{
+			name: "Pod to IP",
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Source:      crdv1alpha1.Source{Pod: pod1Ref},
+				Destination: crdv1alpha1.Destination{IP: ptr.To("10.10.0.1")},
+			},
+			expectedSrcIP: net.ParseIP(pod1IPv4),
+			expectedDstIP: net.ParseIP("10.10.0.1"),
+		},
+		{
+			name: "Pod to Service",
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Source:      crdv1alpha1.Source{Pod: pod1Ref},
+				Destination: crdv1alpha1.Destination{Service: service1Ref},
+			},
+			expectedSrcIP: net.ParseIP(pod1IPv4),
+			expectedDstIP: net.ParseIP("10.96.0.10"),
+			expectedServiceDestinations: []capture.ServiceDestination{
+				{IP: net.ParseIP("10.96.0.10"), Ports: service1.Spec.Ports},
+			},
+		},
+		{
+			name: "Node to Service",
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Node:        &crdv1alpha1.PacketCaptureNode{Name: "node-1"},
+				Destination: crdv1alpha1.Destination{Service: service1Ref},
+			},
+			expectedDstIP: net.ParseIP("10.96.0.10"),
+			expectedServiceDestinations: []capture.ServiceDestination{
+				{IP: net.ParseIP("10.96.0.10"), Ports: service1.Spec.Ports},
+				{
+					IP:       net.ParseIP(pod2IPv4),
+					Ports:    []v1.ServicePort{withTargetPort(service1.Spec.Ports[0], 5353), withTargetPort(service1.Spec.Ports[1], 5353)},
+					Endpoint: true,
+				},
+				{
+					IP:       net.ParseIP(pod3IPv4),
+					Ports:    []v1.ServicePort{withTargetPort(service1.Spec.Ports[0], 5353), withTargetPort(service1.Spec.Ports[1], 5353)},
+					Endpoint: true,
+				},
+			},
+		},
+		{
+			name: "Node to Service with IPv6 and protocol",
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Node:        &crdv1alpha1.PacketCaptureNode{Name: "node-1"},
+				Destination: crdv1alpha1.Destination{Service: service1Ref},
+				Packet:      &crdv1alpha1.Packet{IPFamily: v1.IPv6Protocol, Protocol: &udpProto},
+			},
+			expectedDstIP: net.ParseIP("fd00:10:96::10"),
+			expectedServiceDestinations: []capture.ServiceDestination{
+				{IP: net.ParseIP("fd00:10:96::10"), Ports: service1.Spec.Ports[:1]},
+				{IP: net.ParseIP(pod2IPv6), Ports: []v1.ServicePort{withTargetPort(service1.Spec.Ports[0], 5353)}, Endpoint: true},
+			},
+		},
+		{
+			name: "Pod to Service with IPv6 and protocol",
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Source:      crdv1alpha1.Source{Pod: pod1Ref},
+				Destination: crdv1alpha1.Destination{Service: service1Ref},
+				Packet:      &crdv1alpha1.Packet{IPFamily: v1.IPv6Protocol, Protocol: &udpProto},
+			},
+			expectedSrcIP: net.ParseIP(pod1IPv6),
+			expectedDstIP: net.ParseIP("fd00:10:96::10"),
+			expectedServiceDestinations: []capture.ServiceDestination{
+				{IP: net.ParseIP("fd00:10:96::10"), Ports: service1.Spec.Ports[:1]},
+			},
+		},
+		{
+			name: "Service port not matched",
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Destination: crdv1alpha1.Destination{Service: service1Ref},
+				Packet:      &crdv1alpha1.Packet{Protocol: &icmpProto},
+			},
+			expectedErr: "no port of Service default/service-1 matches the packet spec",
+		},
+		{
+			name: "Service not found",
+			pcSpec: crdv1alpha1.PacketCaptureSpec{
+				Destination: crdv1alpha1.Destination{Service: &crdv1alpha1.ServiceReference{Namespace: "default", Name: "foo"}},
+			},
+			expectedErr: "failed to get Service default/foo: services \"foo\" not found",
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			pc := &crdv1alpha1.PacketCapture{Spec: tc.pcSpec}
-			device := tc.controller.getTargetCaptureDevice(pc)
-			assert.Equal(t, tc.expectedDevice, device)
+			srcIP, dstIP, serviceDestinations, err := pcc.parseIPs(context.Background(), pc)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tc.expectedSrcIP.Equal(srcIP), "expected source IP %s, got %s", tc.expectedSrcIP, srcIP)
+			assert.True(t, tc.expectedDstIP.Equal(dstIP), "expected destination IP %s, got %s", tc.expectedDstIP, dstIP)
+			assert.Equal(t, tc.expectedServiceDestinations, serviceDestinations)
 		})
 	}
 }
@@ -827,10 +1480,12 @@ func TestUpdateStatus(t *testing.T) {
 				captureErr:         nil,
 				capturedPacketsNum: 10,
 				filePath:           "path/to/pc-dst-success.pcapng",
+				stopReason:         crdv1alpha1.PacketCaptureStopReasonPacketLimit,
 			},
 			expectedStatus: crdv1alpha1.PacketCaptureStatus{
 				NumberCaptured: 10,
 				FilePath:       "path/to/pc-dst-success.pcapng",
+				StopReason:     crdv1alpha1.PacketCaptureStopReasonPacketLimit,
 				Conditions: []crdv1alpha1.PacketCaptureCondition{
 					{Type: crdv1alpha1.PacketCaptureStarted, Status: metav1.ConditionTrue, Reason: "Started"},
 					{Type: crdv1alpha1.PacketCaptureComplete, Status: metav1.ConditionTrue, Reason: "Succeed"},
@@ -1010,7 +1665,7 @@ func TestUploadPackets(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pc := genTestCR("foo", testCaptureNum)
 			pcc := newFakePacketCaptureController(t, nil, nil)
-			pcc.sftpUploader = &testUploader{
+			pcc.uploader.SFTPUploader = &testUploader{
 				url:      testFTPUrl,
 				fileName: pcc.generatePacketsPathForServer(pc.Name),
 				hostKey:  tc.serverHostKey,
@@ -1028,3 +1683,142 @@ func TestUploadPackets(t *testing.T) {
 		})
 	}
 }
+
+func TestUploadPacketsByProtocol(t *testing.T) {
+	ctx := context.Background()
+	fs := afero.NewMemMapFs()
+
+	testCases := []struct {
+		name               string
+		fileServer         crdv1alpha1.PacketCaptureFileServer
+		secretData         map[string][]byte
+		expectedS3Config   *s3upload.Config
+		expectedServerAuth *auth.AuthConfiguration
+		expectedErr        string
+	}{
+		{
+			name: "s3",
+			fileServer: crdv1alpha1.PacketCaptureFileServer{
+				URL: "s3://packets/cluster-1",
+				S3: &crdv1alpha1.PacketCaptureS3Config{
+					Endpoint: "http://minio.minio.svc:9000",
+					Region:   "eu-west-1",
+				},
+			},
+			secretData: map[string][]byte{
+				"accessKeyId":     []byte("id"),
+				"secretAccessKey": []byte("secret"),
+			},
+			expectedS3Config: &s3upload.Config{
+				Endpoint:        "http://minio.minio.svc:9000",
+				Region:          "eu-west-1",
+				AccessKeyID:     "id",
+				SecretAccessKey: "secret",
+			},
+		},
+		{
+			name: "s3 without access key",
+			fileServer: crdv1alpha1.PacketCaptureFileServer{
+				URL: "s3://packets",
+			},
+			secretData:  secret1.Data,
+			expectedErr: "missing key \"accessKeyId\"",
+		},
+		{
+			name: "https with bearer token",
+			fileServer: crdv1alpha1.PacketCaptureFileServer{
+				URL: "https://api.example.com:8443/v1/packets/",
+			},
+			secretData: map[string][]byte{
+				"token": []byte("token"),
+			},
+			expectedServerAuth: &auth.AuthConfiguration{
+				AuthType:    auth.BearerTokenType,
+				BearerToken: "token",
+			},
+		},
+		{
+			name: "http with basic authentication",
+			fileServer: crdv1alpha1.PacketCaptureFileServer{
+				URL: "http://10.0.0.1/upload",
+			},
+			secretData: secret1.Data,
+			expectedServerAuth: &auth.AuthConfiguration{
+				AuthType: auth.BasicAuthenticationType,
+				BasicAuthentication: &auth.BasicAuthentication{
+					Username: "username",
+					Password: "password",
+				},
+			},
+		},
+		{
+			name: "unsupported protocol",
+			fileServer: crdv1alpha1.PacketCaptureFileServer{
+				URL: "ftp://10.0.0.1/upload",
+			},
+			secretData:  secret1.Data,
+			expectedErr: "unsupported protocol ftp",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pc := genTestCR("foo", testCaptureNum)
+			pc.Spec.FileServer = &tc.fileServer
+			pcc := newFakePacketCaptureController(t, nil, nil)
+			s3Uploader := &testS3Uploader{}
+			httpUploader := &testHTTPUploader{}
+			pcc.uploader.S3Uploader = s3Uploader
+			pcc.uploader.HTTPUploader = httpUploader
+			secret := secret1.DeepCopy()
+			secret.Data = tc.secretData
+			_, err := pcc.kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+			require.NoError(t, err)
+			f, err := afero.TempFile(fs, "", "upload-test")
+			require.NoError(t, err)
+			defer f.Close()
+
+			err = pcc.uploadPackets(ctx, pc, f)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectedS3Config != nil {
+				assert.Equal(t, tc.fileServer.URL, s3Uploader.url)
+				assert.Equal(t, "foo.pcapng", s3Uploader.fileName)
+				assert.Equal(t, tc.expectedS3Config, s3Uploader.config)
+			}
+			if tc.expectedServerAuth != nil {
+				assert.Equal(t, tc.fileServer.URL, httpUploader.url)
+				assert.Equal(t, "foo.pcapng", httpUploader.fileName)
+				assert.Equal(t, tc.expectedServerAuth, httpUploader.serverAuth)
+			}
+		})
+	}
+}
+
+func TestGetPacketCaptureFile(t *testing.T) {
+	defaultFS = afero.NewMemMapFs()
+	defer func() {
+		defaultFS = afero.NewOsFs()
+	}()
+	pcc := newFakePacketCaptureController(t, nil, nil)
+	pcc.captures["pc-complete"] = &packetCaptureState{phase: packetCapturePhaseComplete, filePath: "antrea-agent:" + nameToPath("pc-complete")}
+	pcc.captures["pc-started"] = &packetCaptureState{phase: packetCapturePhaseStarted}
+	pcc.captures["pc-no-packets"] = &packetCaptureState{phase: packetCapturePhaseComplete}
+	pcc.captures["pc-file-deleted"] = &packetCaptureState{phase: packetCapturePhaseComplete, filePath: "antrea-agent:" + nameToPath("pc-file-deleted")}
+	require.NoError(t, afero.WriteFile(defaultFS, nameToPath("pc-complete"), []byte("packets"), 0600))
+
+	file, err := pcc.GetPacketCaptureFile("pc-complete")
+	require.NoError(t, err)
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "packets", string(data))
+
+	for _, name := range []string{"pc-started", "pc-no-packets", "pc-file-deleted", "pc-unknown"} {
+		_, err := pcc.GetPacketCaptureFile(name)
+		assert.ErrorIs(t, err, ErrPacketCaptureFileNotFound, name)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"fmt"
+	"net"
+
+	"k8s.io/klog/v2"
+
+	agenttypes "antrea.io/antrea/v2/pkg/agent/types"
+	"antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	crdv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+	"antrea.io/antrea/v2/pkg/querier"
+)
+
+// policyRuleTrigger triggers a RingBuffer capture when a packet between the source and destination of the capture is
+// dropped or rejected by a NetworkPolicy rule.
+type policyRuleTrigger struct {
+	rule      *crdv1alpha1.PacketCapturePolicyRuleTrigger
+	srcIP     net.IP
+	dstIP     net.IP
+	direction crdv1alpha1.CaptureDirection
+	// ch receives a value when the capture is triggered. It is buffered so that the capture can be triggered
+	// without blocking.
+	ch chan struct{}
+}
+
+func newPolicyRuleTrigger(pc *crdv1alpha1.PacketCapture, srcIP, dstIP net.IP) *policyRuleTrigger {
+	return &policyRuleTrigger{
+		rule:      pc.Spec.CaptureConfig.RingBuffer.Trigger.PolicyRule,
+		srcIP:     srcIP,
+		dstIP:     dstIP,
+		direction: pc.Spec.Direction,
+		ch:        make(chan struct{}, 1),
+	}
+}
+
+// matches returns whether the dropped packet is dropped by the trigger rule, and is sent between the source and
+// destination of the capture in the captured direction. A nil source or destination IP matches any IP.
+func (t *policyRuleTrigger) matches(drop *agenttypes.PolicyRuleDrop) bool {
+	policyRef := drop.PolicyRef
+	if string(policyRef.Type) != string(t.rule.Type) || policyRef.Namespace != t.rule.Namespace || policyRef.Name != t.rule.Name {
+		return false
+	}
+	if t.rule.RuleName != "" && drop.RuleName != t.rule.RuleName {
+		return false
+	}
+	ipMatches := func(ip net.IP, ips ...net.IP) bool {
+		if ip == nil {
+			return true
+		}
+		for _, i := range ips {
+			if ip.Equal(i) {
+				return true
+			}
+		}
+		return false
+	}
+	// The destination may be a Service, in which case the dropped packet has already been DNATed.
+	srcToDst := ipMatches(t.srcIP, drop.SourceIP) && ipMatches(t.dstIP, drop.DestinationIP, drop.OriginalDestinationIP)
+	dstToSrc := ipMatches(t.dstIP, drop.SourceIP) && ipMatches(t.srcIP, drop.DestinationIP, drop.OriginalDestinationIP)
+	switch t.direction {
+	case crdv1alpha1.CaptureDirectionDestinationToSource:
+		return dstToSrc
+	case crdv1alpha1.CaptureDirectionBoth:
+		return srcToDst || dstToSrc
+	default:
+		return srcToDst
+	}
+}
+
+func (t *policyRuleTrigger) trigger() {
+	select {
+	case t.ch <- struct{}{}:
+	default:
+	}
+}
+
+// handlePolicyRuleDrop is called for every packet dropped or rejected by an Antrea-native policy rule on the Node. It
+// triggers the RingBuffer captures waiting for a drop by this rule.
+func (c *Controller) handlePolicyRuleDrop(obj interface{}) {
+	drop := obj.(*agenttypes.PolicyRuleDrop)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for name, state := range c.captures {
+		if state.policyRuleTrigger == nil || !state.policyRuleTrigger.matches(drop) {
+			continue
+		}
+		klog.V(2).InfoS("Packet dropped by trigger NetworkPolicy rule", "name", name, "policy", drop.PolicyRef.ToString(), "rule", drop.RuleName, "srcIP", drop.SourceIP, "dstIP", drop.DestinationIP)
+		state.policyRuleTrigger.trigger()
+	}
+}
+
+// setPolicyRuleTrigger sets the trigger of a RingBuffer capture, or clears it if trigger is nil.
+func (c *Controller) setPolicyRuleTrigger(state *packetCaptureState, trigger *policyRuleTrigger) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if state.policyRuleTrigger == nil && trigger != nil {
+		c.numPolicyRuleTriggers.Add(1)
+	} else if state.policyRuleTrigger != nil && trigger == nil {
+		c.numPolicyRuleTriggers.Add(-1)
+	}
+	state.policyRuleTrigger = trigger
+}
+
+// HasPolicyRuleTriggers returns whether any RingBuffer capture is waiting for a packet to be dropped by a NetworkPolicy
+// rule. It is cheap enough to be called for every packet-in message.
+func (c *Controller) HasPolicyRuleTriggers() bool {
+	return c.numPolicyRuleTriggers.Load() > 0
+}
+
+// validatePolicyRuleTrigger returns an error if the packets dropped by the trigger rule are never sent to the agent,
+// in which case the capture would never be triggered. Packet-in messages are only sent for the drops of rules with
+// logging enabled or with a Reject action, unless deny tracking is enabled. The policy may not be applied on this Node
+// yet, in which case the trigger can't be validated.
+func (c *Controller) validatePolicyRuleTrigger(trigger *crdv1alpha1.PacketCapturePolicyRuleTrigger) error {
+	if c.denyTrackingEnabled || c.networkPolicyQuerier == nil {
+		return nil
+	}
+	policies := c.networkPolicyQuerier.GetNetworkPolicies(&querier.NetworkPolicyQueryFilter{
+		SourceName: trigger.Name,
+		Namespace:  trigger.Namespace,
+		SourceType: v1beta2.NetworkPolicyType(trigger.Type),
+	})
+	if len(policies) == 0 {
+		return nil
+	}
+	for _, policy := range policies {
+		for _, rule := range policy.Rules {
+			if trigger.RuleName != "" && rule.Name != trigger.RuleName {
+				continue
+			}
+			if rule.Action == nil {
+				continue
+			}
+			// The capture can't be triggered by an Allow or a Pass rule.
+			if *rule.Action == crdv1beta1.RuleActionReject || (*rule.Action == crdv1beta1.RuleActionDrop && rule.EnableLogging) {
+				return nil
+			}
+		}
+	}
+	if trigger.RuleName != "" {
+		return fmt.Errorf("packets dropped by rule %s of %s %s are not reported to the agent, the rule must have a Reject action or enableLogging set to true",
+			trigger.RuleName, trigger.Type, policyName(trigger))
+	}
+	return fmt.Errorf("packets dropped by %s %s are not reported to the agent, a rule must have a Reject action or enableLogging set to true",
+		trigger.Type, policyName(trigger))
+}
+
+func policyName(trigger *crdv1alpha1.PacketCapturePolicyRuleTrigger) string {
+	if trigger.Namespace == "" {
+		return trigger.Name
+	}
+	return trigger.Namespace + "/" + trigger.Name
+}
@@ -0,0 +1,333 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/pcapgo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+
+	"antrea.io/antrea/v2/pkg/agent/packetcapture/capture"
+	agenttypes "antrea.io/antrea/v2/pkg/agent/types"
+	"antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	crdv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+	"antrea.io/antrea/v2/pkg/querier"
+	queriertesting "antrea.io/antrea/v2/pkg/querier/testing"
+)
+
+var (
+	testACNPRef = &v1beta2.NetworkPolicyReference{
+		Type: v1beta2.AntreaClusterNetworkPolicy,
+		Name: "acnp-1",
+	}
+	testPolicyRule = &crdv1alpha1.PacketCapturePolicyRuleTrigger{
+		Type:     crdv1alpha1.PacketCapturePolicyTypeAntreaClusterNetworkPolicy,
+		Name:     "acnp-1",
+		RuleName: "drop-web",
+	}
+)
+
+func TestPolicyRuleTriggerMatches(t *testing.T) {
+	srcIP := net.ParseIP(pod1IPv4)
+	dstIP := net.ParseIP(pod2IPv4)
+	clusterIP := net.ParseIP("10.96.0.10")
+	tests := []struct {
+		name      string
+		rule      *crdv1alpha1.PacketCapturePolicyRuleTrigger
+		srcIP     net.IP
+		dstIP     net.IP
+		direction crdv1alpha1.CaptureDirection
+		drop      *agenttypes.PolicyRuleDrop
+		expected  bool
+	}{
+		{
+			name:     "matching rule",
+			rule:     testPolicyRule,
+			srcIP:    srcIP,
+			dstIP:    dstIP,
+			drop:     &agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-web", SourceIP: srcIP, DestinationIP: dstIP},
+			expected: true,
+		},
+		{
+			name:     "other rule",
+			rule:     testPolicyRule,
+			srcIP:    srcIP,
+			dstIP:    dstIP,
+			drop:     &agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-db", SourceIP: srcIP, DestinationIP: dstIP},
+			expected: false,
+		},
+		{
+			name: "any rule of the policy",
+			rule: &crdv1alpha1.PacketCapturePolicyRuleTrigger{
+				Type: crdv1alpha1.PacketCapturePolicyTypeAntreaClusterNetworkPolicy,
+				Name: "acnp-1",
+			},
+			srcIP:    srcIP,
+			dstIP:    dstIP,
+			drop:     &agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-db", SourceIP: srcIP, DestinationIP: dstIP},
+			expected: true,
+		},
+		{
+			name: "other policy type",
+			rule: testPolicyRule,
+			drop: &agenttypes.PolicyRuleDrop{
+				PolicyRef: &v1beta2.NetworkPolicyReference{Type: v1beta2.AntreaNetworkPolicy, Namespace: "default", Name: "acnp-1"},
+				RuleName:  "drop-web",
+			},
+			expected: false,
+		},
+		{
+			name:     "other source",
+			rule:     testPolicyRule,
+			srcIP:    srcIP,
+			dstIP:    dstIP,
+			drop:     &agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-web", SourceIP: net.ParseIP("10.10.0.1"), DestinationIP: dstIP},
+			expected: false,
+		},
+		{
+			name:     "reverse direction",
+			rule:     testPolicyRule,
+			srcIP:    srcIP,
+			dstIP:    dstIP,
+			drop:     &agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-web", SourceIP: dstIP, DestinationIP: srcIP},
+			expected: false,
+		},
+		{
+			name:      "reverse direction with direction Both",
+			rule:      testPolicyRule,
+			srcIP:     srcIP,
+			dstIP:     dstIP,
+			direction: crdv1alpha1.CaptureDirectionBoth,
+			drop:      &agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-web", SourceIP: dstIP, DestinationIP: srcIP},
+			expected:  true,
+		},
+		{
+			name:      "direction DestinationToSource",
+			rule:      testPolicyRule,
+			srcIP:     srcIP,
+			dstIP:     dstIP,
+			direction: crdv1alpha1.CaptureDirectionDestinationToSource,
+			drop:      &agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-web", SourceIP: dstIP, DestinationIP: srcIP},
+			expected:  true,
+		},
+		{
+			name:     "Service destination",
+			rule:     testPolicyRule,
+			srcIP:    srcIP,
+			dstIP:    clusterIP,
+			drop:     &agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-web", SourceIP: srcIP, DestinationIP: dstIP, OriginalDestinationIP: clusterIP},
+			expected: true,
+		},
+		{
+			name:     "any destination",
+			rule:     testPolicyRule,
+			srcIP:    srcIP,
+			drop:     &agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-web", SourceIP: srcIP, DestinationIP: net.ParseIP("8.8.8.8")},
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := genTestCR("pc", 0)
+			pc.Spec.Direction = tt.direction
+			pc.Spec.CaptureConfig = crdv1alpha1.CaptureConfig{
+				RingBuffer: &crdv1alpha1.PacketCaptureRingBufferConfig{
+					Number:  10,
+					Trigger: crdv1alpha1.PacketCaptureTrigger{PolicyRule: tt.rule},
+				},
+			}
+			trigger := newPolicyRuleTrigger(pc, tt.srcIP, tt.dstIP)
+			assert.Equal(t, tt.expected, trigger.matches(tt.drop))
+		})
+	}
+}
+
+func TestValidatePolicyRuleTrigger(t *testing.T) {
+	policyWithRules := func(rules ...v1beta2.NetworkPolicyRule) []v1beta2.NetworkPolicy {
+		return []v1beta2.NetworkPolicy{{SourceRef: testACNPRef, Rules: rules}}
+	}
+	dropRule := v1beta2.NetworkPolicyRule{Name: "drop-web", Action: ptr.To(crdv1beta1.RuleActionDrop)}
+	loggedDropRule := v1beta2.NetworkPolicyRule{Name: "drop-web", Action: ptr.To(crdv1beta1.RuleActionDrop), EnableLogging: true}
+	rejectRule := v1beta2.NetworkPolicyRule{Name: "reject-db", Action: ptr.To(crdv1beta1.RuleActionReject)}
+	policyTrigger := &crdv1alpha1.PacketCapturePolicyRuleTrigger{
+		Type: crdv1alpha1.PacketCapturePolicyTypeAntreaClusterNetworkPolicy,
+		Name: "acnp-1",
+	}
+
+	tests := []struct {
+		name                string
+		trigger             *crdv1alpha1.PacketCapturePolicyRuleTrigger
+		denyTrackingEnabled bool
+		policies            []v1beta2.NetworkPolicy
+		expectedErr         string
+	}{
+		{
+			name:     "policy not applied on the Node",
+			trigger:  testPolicyRule,
+			policies: nil,
+		},
+		{
+			name:     "rule with logging enabled",
+			trigger:  testPolicyRule,
+			policies: policyWithRules(loggedDropRule, rejectRule),
+		},
+		{
+			name:        "rule without logging",
+			trigger:     testPolicyRule,
+			policies:    policyWithRules(dropRule, rejectRule),
+			expectedErr: "packets dropped by rule drop-web of AntreaClusterNetworkPolicy acnp-1 are not reported to the agent",
+		},
+		{
+			name:                "rule without logging and deny tracking enabled",
+			trigger:             testPolicyRule,
+			denyTrackingEnabled: true,
+		},
+		{
+			name:     "policy with a Reject rule",
+			trigger:  policyTrigger,
+			policies: policyWithRules(dropRule, rejectRule),
+		},
+		{
+			name:        "policy without logging",
+			trigger:     policyTrigger,
+			policies:    policyWithRules(dropRule),
+			expectedErr: "packets dropped by AntreaClusterNetworkPolicy acnp-1 are not reported to the agent",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			npQuerier := queriertesting.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
+			if !tt.denyTrackingEnabled {
+				npQuerier.EXPECT().GetNetworkPolicies(&querier.NetworkPolicyQueryFilter{
+					SourceName: "acnp-1",
+					SourceType: v1beta2.AntreaClusterNetworkPolicy,
+				}).Return(tt.policies)
+			}
+			c := &Controller{networkPolicyQuerier: npQuerier, denyTrackingEnabled: tt.denyTrackingEnabled}
+			err := c.validatePolicyRuleTrigger(tt.trigger)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// testStreamCapture returns the same channel for all the devices, so that the packets can be sent one by one.
+type testStreamCapture struct {
+	packets chan gopacket.Packet
+}
+
+func (p *testStreamCapture) Capture(ctx context.Context, device string, snapLen int, srcIP, dstIP net.IP, packet *crdv1alpha1.Packet, serviceDestinations []capture.ServiceDestination, direction crdv1alpha1.CaptureDirection) (chan gopacket.Packet, error) {
+	return p.packets, nil
+}
+
+func TestPerformCaptureTriggeredByPolicyRule(t *testing.T) {
+	defaultFS = afero.NewMemMapFs()
+	defer func() {
+		defaultFS = afero.NewOsFs()
+	}()
+	tcpPacket := craftTestTCPPacket(t, pod1IPv4, pod2IPv4, false)
+	rstPacket := craftTestTCPPacket(t, pod1IPv4, pod2IPv4, true)
+	pcc := newFakePacketCaptureController(t, nil, nil)
+	source := &testStreamCapture{packets: make(chan gopacket.Packet)}
+	pcc.captureInterface = source
+	pc := genTestCR("pc", 0)
+	pc.Spec.Packet = &crdv1alpha1.Packet{Protocol: &tcpProto}
+	pc.Spec.CaptureConfig = crdv1alpha1.CaptureConfig{
+		RingBuffer: &crdv1alpha1.PacketCaptureRingBufferConfig{
+			Number:       2,
+			Trigger:      crdv1alpha1.PacketCaptureTrigger{PolicyRule: testPolicyRule},
+			AfterTrigger: 1,
+		},
+	}
+	state := &packetCaptureState{}
+	pcc.captures[pc.Name] = state
+	file, err := getPacketFile(nameToPath(pc.Name))
+	require.NoError(t, err)
+	defer file.Close()
+
+	type captureResult struct {
+		capturedAny bool
+		stopReason  crdv1alpha1.PacketCaptureStopReason
+		err         error
+	}
+	resultCh := make(chan captureResult, 1)
+	go func() {
+		capturedAny, stopReason, err := pcc.performCapture(context.Background(), pc, state, file, []captureDevice{{name: "test"}})
+		resultCh <- captureResult{capturedAny, stopReason, err}
+	}()
+	getNumCaptured := func() int32 {
+		pcc.mutex.Lock()
+		defer pcc.mutex.Unlock()
+		return state.capturedPacketsNum
+	}
+	isTriggerPending := func() bool {
+		pcc.mutex.Lock()
+		defer pcc.mutex.Unlock()
+		return state.policyRuleTrigger == nil || len(state.policyRuleTrigger.ch) > 0
+	}
+
+	// Only the last 2 packets are kept in the buffer until the capture is triggered.
+	for range 3 {
+		source.packets <- tcpPacket
+	}
+	require.Eventually(t, func() bool { return getNumCaptured() == 2 }, time.Second, 10*time.Millisecond)
+	assert.True(t, pcc.HasPolicyRuleTriggers())
+	// A packet dropped by another rule doesn't trigger the capture.
+	pcc.handlePolicyRuleDrop(&agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-db", SourceIP: net.ParseIP(pod1IPv4), DestinationIP: net.ParseIP(pod2IPv4)})
+	assert.False(t, isTriggerPending())
+	pcc.handlePolicyRuleDrop(&agenttypes.PolicyRuleDrop{PolicyRef: testACNPRef, RuleName: "drop-web", SourceIP: net.ParseIP(pod1IPv4), DestinationIP: net.ParseIP(pod2IPv4)})
+	require.Eventually(t, func() bool { return !isTriggerPending() }, time.Second, 10*time.Millisecond)
+	// The packet captured after the trigger is saved, and stops the capture.
+	source.packets <- rstPacket
+
+	select {
+	case result := <-resultCh:
+		require.NoError(t, result.err)
+		assert.True(t, result.capturedAny)
+		assert.Equal(t, crdv1alpha1.PacketCaptureStopReasonTriggered, result.stopReason)
+	case <-time.After(time.Second):
+		require.Fail(t, "capture did not stop after the trigger")
+	}
+	assert.Equal(t, int32(3), getNumCaptured())
+	assert.Nil(t, state.policyRuleTrigger)
+	assert.False(t, pcc.HasPolicyRuleTriggers())
+
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	reader, err := pcapgo.NewNgReader(file, pcapgo.DefaultNgReaderOptions)
+	require.NoError(t, err)
+	for _, expectedPacket := range []gopacket.Packet{tcpPacket, tcpPacket, rstPacket} {
+		data, _, err := reader.ReadPacketData()
+		require.NoError(t, err)
+		assert.Equal(t, expectedPacket.Data(), data)
+	}
+	_, _, err = reader.ReadPacketData()
+	assert.ErrorIs(t, err, io.EOF)
+}
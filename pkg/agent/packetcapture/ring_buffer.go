@@ -0,0 +1,69 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"github.com/gopacket/gopacket"
+)
+
+// capturedPacket is a packet kept in memory before it is written to the pcapng file.
+type capturedPacket struct {
+	ci   gopacket.CaptureInfo
+	data []byte
+}
+
+// packetRingBuffer keeps the last captured packets, up to a maximum number of packets and optionally a maximum total
+// size in bytes. The oldest packets are dropped to make room for new ones.
+type packetRingBuffer struct {
+	maxPackets int
+	// maxBytes is the maximum total size of the packets in the buffer. 0 means no limit.
+	maxBytes int64
+	packets  []capturedPacket
+	bytes    int64
+}
+
+func newPacketRingBuffer(maxPackets int, maxBytes int64) *packetRingBuffer {
+	return &packetRingBuffer{
+		maxPackets: maxPackets,
+		maxBytes:   maxBytes,
+	}
+}
+
+// add adds a packet to the buffer, dropping the oldest packets if needed. The packet data must not be reused by the
+// caller. It returns false if the packet is larger than maxBytes, in which case the buffer is not changed.
+func (b *packetRingBuffer) add(packet capturedPacket) bool {
+	size := int64(len(packet.data))
+	if b.maxBytes > 0 && size > b.maxBytes {
+		return false
+	}
+	for len(b.packets) > 0 && (len(b.packets) >= b.maxPackets || (b.maxBytes > 0 && b.bytes+size > b.maxBytes)) {
+		b.bytes -= int64(len(b.packets[0].data))
+		// Release the reference to the packet data so that it can be garbage collected.
+		b.packets[0] = capturedPacket{}
+		b.packets = b.packets[1:]
+	}
+	b.packets = append(b.packets, packet)
+	b.bytes += size
+	return true
+}
+
+func (b *packetRingBuffer) len() int {
+	return len(b.packets)
+}
+
+// list returns the packets in the buffer, from the oldest to the newest.
+func (b *packetRingBuffer) list() []capturedPacket {
+	return b.packets
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPacketRingBuffer(t *testing.T) {
+	packet := func(id byte, size int) capturedPacket {
+		data := make([]byte, size)
+		data[0] = id
+		return capturedPacket{data: data}
+	}
+	ids := func(b *packetRingBuffer) []byte {
+		var result []byte
+		for _, p := range b.list() {
+			result = append(result, p.data[0])
+		}
+		return result
+	}
+
+	tests := []struct {
+		name          string
+		maxPackets    int
+		maxBytes      int64
+		packets       []capturedPacket
+		expectedAdded []bool
+		expectedIDs   []byte
+	}{
+		{
+			name:          "not full",
+			maxPackets:    3,
+			packets:       []capturedPacket{packet(1, 10), packet(2, 10)},
+			expectedAdded: []bool{true, true},
+			expectedIDs:   []byte{1, 2},
+		},
+		{
+			name:          "packet limit",
+			maxPackets:    3,
+			packets:       []capturedPacket{packet(1, 10), packet(2, 10), packet(3, 10), packet(4, 10), packet(5, 10)},
+			expectedAdded: []bool{true, true, true, true, true},
+			expectedIDs:   []byte{3, 4, 5},
+		},
+		{
+			name:          "byte limit",
+			maxPackets:    10,
+			maxBytes:      25,
+			packets:       []capturedPacket{packet(1, 10), packet(2, 10), packet(3, 10), packet(4, 20)},
+			expectedAdded: []bool{true, true, true, true},
+			expectedIDs:   []byte{4},
+		},
+		{
+			name:          "packet larger than byte limit",
+			maxPackets:    10,
+			maxBytes:      25,
+			packets:       []capturedPacket{packet(1, 10), packet(2, 30), packet(3, 10)},
+			expectedAdded: []bool{true, false, true},
+			expectedIDs:   []byte{1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newPacketRingBuffer(tt.maxPackets, tt.maxBytes)
+			var added []bool
+			for _, p := range tt.packets {
+				added = append(added, b.add(p))
+			}
+			assert.Equal(t, tt.expectedAdded, added)
+			assert.Equal(t, tt.expectedIDs, ids(b))
+			assert.Equal(t, len(tt.expectedIDs), b.len())
+		})
+	}
+}
@@ -15,6 +15,12 @@
 package querier
 
 import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientset "k8s.io/client-go/kubernetes"
@@ -34,6 +40,10 @@ import (
 
 var _ AgentQuerier = new(agentQuerier)
 
+// maxDegradedNodesInCondition is the maximum number of degraded peer Nodes named in the NodeLatencyHealthy
+// condition, to keep the size of AntreaAgentInfo bounded in large clusters.
+const maxDegradedNodesInCondition = 10
+
 type AgentQuerier interface {
 	GetNodeConfig() *config.NodeConfig
 	GetNetworkConfig() *config.NetworkConfig
@@ -63,6 +73,10 @@ type agentQuerier struct {
 	memberlistCluster        memberlist.Interface
 	nodeLister               corelisters.NodeLister
 	bgpPolicyInfoQuerier     querier.AgentBGPPolicyInfoQuerier
+	// nodeLatencyMonitorQuerier is nil if NodeLatencyMonitor is not enabled.
+	nodeLatencyMonitorQuerier querier.AgentNodeLatencyMonitorQuerier
+	// connectivityProbeQuerier is nil if ConnectivityProbe is not enabled.
+	connectivityProbeQuerier querier.AgentConnectivityProbeQuerier
 }
 
 func NewAgentQuerier(
@@ -79,21 +93,25 @@ func NewAgentQuerier(
 	memberlistCluster memberlist.Interface,
 	nodeLister corelisters.NodeLister,
 	bgpPolicyInfoQuerier querier.AgentBGPPolicyInfoQuerier,
+	nodeLatencyMonitorQuerier querier.AgentNodeLatencyMonitorQuerier,
+	connectivityProbeQuerier querier.AgentConnectivityProbeQuerier,
 ) *agentQuerier {
 	return &agentQuerier{
-		nodeConfig:               nodeConfig,
-		networkConfig:            networkConfig,
-		interfaceStore:           interfaceStore,
-		k8sClient:                k8sClient,
-		ofClient:                 ofClient,
-		ovsBridgeClient:          ovsBridgeClient,
-		proxier:                  proxier,
-		networkPolicyInfoQuerier: networkPolicyInfoQuerier,
-		apiPort:                  apiPort,
-		nplRange:                 nplRange,
-		memberlistCluster:        memberlistCluster,
-		nodeLister:               nodeLister,
-		bgpPolicyInfoQuerier:     bgpPolicyInfoQuerier,
+		nodeConfig:                nodeConfig,
+		networkConfig:             networkConfig,
+		interfaceStore:            interfaceStore,
+		k8sClient:                 k8sClient,
+		ofClient:                  ofClient,
+		ovsBridgeClient:           ovsBridgeClient,
+		proxier:                   proxier,
+		networkPolicyInfoQuerier:  networkPolicyInfoQuerier,
+		apiPort:                   apiPort,
+		nplRange:                  nplRange,
+		memberlistCluster:         memberlistCluster,
+		nodeLister:                nodeLister,
+		bgpPolicyInfoQuerier:      bgpPolicyInfoQuerier,
+		nodeLatencyMonitorQuerier: nodeLatencyMonitorQuerier,
+		connectivityProbeQuerier:  connectivityProbeQuerier,
 	}
 }
 
@@ -182,7 +200,7 @@ func (aq agentQuerier) getAgentConditions(ovsConnected bool) []v1beta1.AgentCond
 	if !aq.ofClient.IsConnected() {
 		openflowConnectionStatus = v1.ConditionFalse
 	}
-	return []v1beta1.AgentCondition{
+	conditions := []v1beta1.AgentCondition{
 		{
 			Type:              v1beta1.AgentHealthy,
 			Status:            v1.ConditionTrue,
@@ -204,6 +222,38 @@ func (aq agentQuerier) getAgentConditions(ovsConnected bool) []v1beta1.AgentCond
 			LastHeartbeatTime: lastHeartbeatTime,
 		},
 	}
+	if aq.nodeLatencyMonitorQuerier != nil {
+		if degradedNodes, enabled := aq.nodeLatencyMonitorQuerier.GetDegradedPeerNodes(); enabled {
+			conditions = append(conditions, getNodeLatencyCondition(degradedNodes, lastHeartbeatTime))
+		}
+	}
+	return conditions
+}
+
+// getNodeLatencyCondition gets the NodeLatencyHealthy condition, which names the peer Nodes whose connection
+// exceeds the thresholds of the NodeLatencyMonitor.
+func getNodeLatencyCondition(degradedNodes map[string]string, lastHeartbeatTime metav1.Time) v1beta1.AgentCondition {
+	condition := v1beta1.AgentCondition{
+		Type:              v1beta1.NodeLatencyHealthy,
+		Status:            v1.ConditionTrue,
+		LastHeartbeatTime: lastHeartbeatTime,
+	}
+	if len(degradedNodes) == 0 {
+		return condition
+	}
+	nodeNames := slices.Sorted(maps.Keys(degradedNodes))
+	nodes := make([]string, 0, min(len(nodeNames), maxDegradedNodesInCondition))
+	for _, nodeName := range nodeNames[:min(len(nodeNames), maxDegradedNodesInCondition)] {
+		nodes = append(nodes, fmt.Sprintf("%s (%s)", nodeName, degradedNodes[nodeName]))
+	}
+	message := "Connections to peer Nodes exceed the thresholds: " + strings.Join(nodes, ", ")
+	if len(nodeNames) > maxDegradedNodesInCondition {
+		message += fmt.Sprintf(" and %d more", len(nodeNames)-maxDegradedNodesInCondition)
+	}
+	condition.Status = v1.ConditionFalse
+	condition.Reason = "ThresholdExceeded"
+	condition.Message = message
+	return condition
 }
 
 // getNetworkPolicyControllerInfo gets current network policy controller info
@@ -218,7 +268,8 @@ func (aq agentQuerier) getNetworkPolicyControllerInfo() v1beta1.NetworkPolicyCon
 
 // GetAgentInfo gets current agent pod info.
 func (aq agentQuerier) GetAgentInfo(agentInfo *v1beta1.AntreaAgentInfo, partial bool) {
-	// LocalPodNum, FlowTable, NetworkPolicyControllerInfo, OVSVersion and AgentConditions can be changed, so reset these fields.
+	// LocalPodNum, FlowTable, NetworkPolicyControllerInfo, OVSVersion, AgentConditions, BGPPolicyInfo and
+	// ConnectivityProbeInfos can be changed, so reset these fields.
 	// Only these fields are updated when partial is true.
 	agentInfo.Name = aq.nodeConfig.Name
 	agentInfo.LocalPodNum = int32(aq.interfaceStore.GetContainerInterfaceNum())
@@ -232,6 +283,10 @@ func (aq agentQuerier) GetAgentInfo(agentInfo *v1beta1.AntreaAgentInfo, partial
 		agentInfo.OVSInfo.Version = ovsVersion
 	}
 	agentInfo.AgentConditions = aq.getAgentConditions(ovsConnected)
+	agentInfo.BGPPolicyInfo = aq.getBGPPolicyInfo()
+	if aq.connectivityProbeQuerier != nil {
+		agentInfo.ConnectivityProbeInfos = aq.connectivityProbeQuerier.GetConnectivityProbeInfos()
+	}
 
 	// Some other fields are needed when partial is false.
 	if !partial {
@@ -254,6 +309,15 @@ func (aq agentQuerier) GetAgentInfo(agentInfo *v1beta1.AntreaAgentInfo, partial
 	}
 }
 
+// getBGPPolicyInfo gets the status of the effective BGPPolicy, or nil if BGPPolicy is not enabled or there is no
+// effective BGPPolicy.
+func (aq agentQuerier) getBGPPolicyInfo() *v1beta1.BGPPolicyInfo {
+	if aq.bgpPolicyInfoQuerier == nil || reflect.ValueOf(aq.bgpPolicyInfoQuerier).IsNil() {
+		return nil
+	}
+	return aq.bgpPolicyInfoQuerier.GetBGPPolicyStatus()
+}
+
 // getNetworkInfo gets network information including transport interface details and Pod MTU.
 func (aq agentQuerier) getNetworkInfo() v1beta1.NetworkInfo {
 	networkInfo := v1beta1.NetworkInfo{
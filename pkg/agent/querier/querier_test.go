@@ -15,6 +15,7 @@
 package querier
 
 import (
+	"fmt"
 	"net"
 	"testing"
 
@@ -75,13 +76,33 @@ func TestAgentQuerierGetAgentInfo(t *testing.T) {
 	networkPolicyInfoQuerier.EXPECT().GetAddressGroupNum().Return(30).AnyTimes()
 	networkPolicyInfoQuerier.EXPECT().GetControllerConnectionStatus().Return(true).AnyTimes()
 
+	nodeLatencyMonitorQuerier := queriertest.NewMockAgentNodeLatencyMonitorQuerier(ctrl)
+	nodeLatencyMonitorQuerier.EXPECT().GetDegradedPeerNodes().Return(map[string]string{"bar": "RTT 20ms to 10.10.0.11 exceeds 10ms"}, true).AnyTimes()
+
+	bgpPolicyInfo := &v1beta1.BGPPolicyInfo{
+		Name:     "policy1",
+		RouterID: "10.10.0.10",
+		Peers:    []v1beta1.BGPPeerInfo{{Address: "10.10.0.1", ASN: 65000, SessionState: "Established"}},
+	}
+	bgpPolicyInfoQuerier := queriertest.NewMockAgentBGPPolicyInfoQuerier(ctrl)
+	bgpPolicyInfoQuerier.EXPECT().GetBGPPolicyStatus().Return(bgpPolicyInfo).AnyTimes()
+
+	connectivityProbeInfos := []v1beta1.ConnectivityProbeInfo{
+		{Name: "probe1", ProbesSent: 4, ProbesSucceeded: 3, AverageLatencyNanoseconds: 1000000},
+	}
+	connectivityProbeQuerier := queriertest.NewMockAgentConnectivityProbeQuerier(ctrl)
+	connectivityProbeQuerier.EXPECT().GetConnectivityProbeInfos().Return(connectivityProbeInfos).AnyTimes()
+
 	tests := []struct {
-		name              string
-		nodeConfig        *config.NodeConfig
-		networkConfig     *config.NetworkConfig
-		apiPort           int
-		partial           bool
-		expectedAgentInfo *v1beta1.AntreaAgentInfo
+		name                      string
+		nodeConfig                *config.NodeConfig
+		networkConfig             *config.NetworkConfig
+		nodeLatencyMonitorQuerier *queriertest.MockAgentNodeLatencyMonitorQuerier
+		bgpPolicyInfoQuerier      *queriertest.MockAgentBGPPolicyInfoQuerier
+		connectivityProbeQuerier  *queriertest.MockAgentConnectivityProbeQuerier
+		apiPort                   int
+		partial                   bool
+		expectedAgentInfo         *v1beta1.AntreaAgentInfo
 	}{
 		{
 			name: "networkPolicyOnly-mode non-partial",
@@ -202,6 +223,129 @@ func TestAgentQuerierGetAgentInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "NodeLatencyMonitor thresholds partial",
+			nodeConfig: &config.NodeConfig{
+				Name: "foo",
+			},
+			nodeLatencyMonitorQuerier: nodeLatencyMonitorQuerier,
+			partial:                   true,
+			expectedAgentInfo: &v1beta1.AntreaAgentInfo{
+				ObjectMeta: v1.ObjectMeta{Name: "foo"},
+				OVSInfo: v1beta1.OVSInfo{
+					Version:   ovsVersion,
+					FlowTable: map[string]int32{"1": 2},
+				},
+				NetworkPolicyControllerInfo: v1beta1.NetworkPolicyControllerInfo{
+					NetworkPolicyNum:  10,
+					AppliedToGroupNum: 20,
+					AddressGroupNum:   30,
+				},
+				LocalPodNum: 2,
+				AgentConditions: []v1beta1.AgentCondition{
+					{
+						Type:   v1beta1.AgentHealthy,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.ControllerConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.OVSDBConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.OpenflowConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.NodeLatencyHealthy,
+						Status: corev1.ConditionFalse,
+					},
+				},
+			},
+		},
+		{
+			name: "BGPPolicy partial",
+			nodeConfig: &config.NodeConfig{
+				Name: "foo",
+			},
+			bgpPolicyInfoQuerier: bgpPolicyInfoQuerier,
+			partial:              true,
+			expectedAgentInfo: &v1beta1.AntreaAgentInfo{
+				ObjectMeta: v1.ObjectMeta{Name: "foo"},
+				OVSInfo: v1beta1.OVSInfo{
+					Version:   ovsVersion,
+					FlowTable: map[string]int32{"1": 2},
+				},
+				NetworkPolicyControllerInfo: v1beta1.NetworkPolicyControllerInfo{
+					NetworkPolicyNum:  10,
+					AppliedToGroupNum: 20,
+					AddressGroupNum:   30,
+				},
+				LocalPodNum: 2,
+				AgentConditions: []v1beta1.AgentCondition{
+					{
+						Type:   v1beta1.AgentHealthy,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.ControllerConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.OVSDBConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.OpenflowConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+				},
+				BGPPolicyInfo: bgpPolicyInfo,
+			},
+		},
+		{
+			name: "ConnectivityProbe partial",
+			nodeConfig: &config.NodeConfig{
+				Name: "foo",
+			},
+			connectivityProbeQuerier: connectivityProbeQuerier,
+			partial:                  true,
+			expectedAgentInfo: &v1beta1.AntreaAgentInfo{
+				ObjectMeta: v1.ObjectMeta{Name: "foo"},
+				OVSInfo: v1beta1.OVSInfo{
+					Version:   ovsVersion,
+					FlowTable: map[string]int32{"1": 2},
+				},
+				NetworkPolicyControllerInfo: v1beta1.NetworkPolicyControllerInfo{
+					NetworkPolicyNum:  10,
+					AppliedToGroupNum: 20,
+					AddressGroupNum:   30,
+				},
+				LocalPodNum: 2,
+				AgentConditions: []v1beta1.AgentCondition{
+					{
+						Type:   v1beta1.AgentHealthy,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.ControllerConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.OVSDBConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.OpenflowConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+				},
+				ConnectivityProbeInfos: connectivityProbeInfos,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -215,6 +359,15 @@ func TestAgentQuerierGetAgentInfo(t *testing.T) {
 				apiPort:                  tt.apiPort,
 				nplRange:                 defaultNPLPortRange,
 			}
+			if tt.nodeLatencyMonitorQuerier != nil {
+				aq.nodeLatencyMonitorQuerier = tt.nodeLatencyMonitorQuerier
+			}
+			if tt.bgpPolicyInfoQuerier != nil {
+				aq.bgpPolicyInfoQuerier = tt.bgpPolicyInfoQuerier
+			}
+			if tt.connectivityProbeQuerier != nil {
+				aq.connectivityProbeQuerier = tt.connectivityProbeQuerier
+			}
 			agentInfo := &v1beta1.AntreaAgentInfo{}
 			aq.GetAgentInfo(agentInfo, tt.partial)
 			// Check AgentConditions separately as it contains timestamp we cannot predict.
@@ -230,3 +383,60 @@ func TestAgentQuerierGetAgentInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNodeLatencyCondition(t *testing.T) {
+	lastHeartbeatTime := v1.Now()
+	manyDegradedNodes := map[string]string{}
+	for i := range 12 {
+		manyDegradedNodes[fmt.Sprintf("node%02d", i)] = "packet loss 100% to 10.0.0.1 exceeds 10%"
+	}
+	tests := []struct {
+		name              string
+		degradedNodes     map[string]string
+		expectedCondition v1beta1.AgentCondition
+	}{
+		{
+			name: "no degraded Node",
+			expectedCondition: v1beta1.AgentCondition{
+				Type:              v1beta1.NodeLatencyHealthy,
+				Status:            corev1.ConditionTrue,
+				LastHeartbeatTime: lastHeartbeatTime,
+			},
+		},
+		{
+			name: "degraded Nodes",
+			degradedNodes: map[string]string{
+				"node3": "packet loss 20% to 10.0.3.1 exceeds 10%",
+				"node2": "RTT 20ms to 10.0.2.1 exceeds 10ms",
+			},
+			expectedCondition: v1beta1.AgentCondition{
+				Type:              v1beta1.NodeLatencyHealthy,
+				Status:            corev1.ConditionFalse,
+				LastHeartbeatTime: lastHeartbeatTime,
+				Reason:            "ThresholdExceeded",
+				Message:           "Connections to peer Nodes exceed the thresholds: node2 (RTT 20ms to 10.0.2.1 exceeds 10ms), node3 (packet loss 20% to 10.0.3.1 exceeds 10%)",
+			},
+		},
+		{
+			name:          "too many degraded Nodes",
+			degradedNodes: manyDegradedNodes,
+			expectedCondition: v1beta1.AgentCondition{
+				Type:              v1beta1.NodeLatencyHealthy,
+				Status:            corev1.ConditionFalse,
+				LastHeartbeatTime: lastHeartbeatTime,
+				Reason:            "ThresholdExceeded",
+				Message: "Connections to peer Nodes exceed the thresholds: " +
+					"node00 (packet loss 100% to 10.0.0.1 exceeds 10%), node01 (packet loss 100% to 10.0.0.1 exceeds 10%), " +
+					"node02 (packet loss 100% to 10.0.0.1 exceeds 10%), node03 (packet loss 100% to 10.0.0.1 exceeds 10%), " +
+					"node04 (packet loss 100% to 10.0.0.1 exceeds 10%), node05 (packet loss 100% to 10.0.0.1 exceeds 10%), " +
+					"node06 (packet loss 100% to 10.0.0.1 exceeds 10%), node07 (packet loss 100% to 10.0.0.1 exceeds 10%), " +
+					"node08 (packet loss 100% to 10.0.0.1 exceeds 10%), node09 (packet loss 100% to 10.0.0.1 exceeds 10%) and 2 more",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedCondition, getNodeLatencyCondition(tt.degradedNodes, lastHeartbeatTime))
+		})
+	}
+}
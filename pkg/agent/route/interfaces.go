@@ -108,6 +108,17 @@ type Interface interface {
 	// DeleteRouteForLink deletes a route entry for a specific link.
 	DeleteRouteForLink(dstCIDR *net.IPNet, linkIndex int) error
 
+	// AddBGPRoute adds or replaces a route entry for a route received from a BGP peer in format:
+	// "dstCIDR" via "nextHop" proto 163
+	AddBGPRoute(dstCIDR *net.IPNet, nextHop net.IP) error
+
+	// DeleteBGPRoute deletes the route entry installed by AddBGPRoute.
+	DeleteBGPRoute(dstCIDR *net.IPNet) error
+
+	// RestoreBGPRoutes restores the route entries installed by AddBGPRoute before the agent restarted to the cache, and
+	// returns their destination CIDRs, so that the stale ones can be deleted by DeleteBGPRoute.
+	RestoreBGPRoutes() ([]*net.IPNet, error)
+
 	// ClearConntrackEntryForService deletes a conntrack entry for a Service connection.
 	ClearConntrackEntryForService(svcIP net.IP, svcPort uint16, endpointIP net.IP, protocol binding.Protocol) error
 
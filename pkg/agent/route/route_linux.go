@@ -17,6 +17,7 @@ package route
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
@@ -60,6 +61,11 @@ const (
 	vxlanPort  = 4789
 	genevePort = 6081
 
+	// bgpRouteProtocol is the protocol of the routes received from BGP peers and installed by Antrea. It is not
+	// RTPROT_BGP, which is used by the BGP daemons running on the Node (e.g. FRR, BIRD), so that only the routes
+	// installed by Antrea are restored and deleted. The value is not registered in /etc/iproute2/rt_protos.
+	bgpRouteProtocol netlink.RouteProtocol = 0xa3
+
 	// Antrea managed ipset.
 	// antreaPodIPSet contains all Per-Node IPAM Pod CIDRs of this cluster.
 	antreaPodIPSet = "ANTREA-POD-IP"
@@ -233,6 +239,8 @@ type Client struct {
 	egressRules sync.Map
 	// egressNeighbors caches neighbors installed for Egress.
 	egressNeighbors sync.Map
+	// bgpRoutes caches ip routes received from BGP peers, keyed by destination CIDR.
+	bgpRoutes sync.Map
 	// The latest calculated Service CIDRs can be got from serviceCIDRProvider.
 	serviceCIDRProvider servicecidr.Interface
 	// nodeNetworkPolicyIPSetsIPv4 caches all existing IPv4 ipsets for NodeNetworkPolicy.
@@ -538,6 +546,9 @@ func (c *Client) syncRoute() error {
 		}
 		return true
 	})
+	c.bgpRoutes.Range(func(_, v any) bool {
+		return restoreRoute(v.(*netlink.Route))
+	})
 	// These routes are installed automatically by the kernel when the address is configured on
 	// the interface (with "proto kernel"). If these routes are deleted manually by mistake, we
 	// restore them as part of this sync (without "proto kernel"). An alternative would be to
@@ -2711,6 +2722,52 @@ func (c *Client) DeleteRouteForLink(cidr *net.IPNet, linkIndex int) error {
 	return nil
 }
 
+func (c *Client) AddBGPRoute(dstCIDR *net.IPNet, nextHop net.IP) error {
+	route := &netlink.Route{
+		Dst:      dstCIDR,
+		Gw:       nextHop,
+		Protocol: bgpRouteProtocol,
+	}
+	if err := c.netlink.RouteReplace(route); err != nil {
+		return fmt.Errorf("failed to install route for %s via %s: %w", dstCIDR, nextHop, err)
+	}
+	c.bgpRoutes.Store(dstCIDR.String(), route)
+	return nil
+}
+
+func (c *Client) DeleteBGPRoute(dstCIDR *net.IPNet) error {
+	value, exists := c.bgpRoutes.Load(dstCIDR.String())
+	if !exists {
+		return nil
+	}
+	route := value.(*netlink.Route)
+	if err := c.netlink.RouteDel(route); err != nil {
+		if !errors.Is(err, unix.ESRCH) {
+			return fmt.Errorf("failed to delete route for %s: %w", dstCIDR, err)
+		}
+	}
+	c.bgpRoutes.Delete(dstCIDR.String())
+	return nil
+}
+
+func (c *Client) RestoreBGPRoutes() ([]*net.IPNet, error) {
+	// Without RT_FILTER_TABLE, only the routes in the main table are returned.
+	routes, err := c.netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Protocol: bgpRouteProtocol}, netlink.RT_FILTER_PROTOCOL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BGP routes: %w", err)
+	}
+	var dstCIDRs []*net.IPNet
+	for i := range routes {
+		route := routes[i]
+		if route.Dst == nil {
+			continue
+		}
+		c.bgpRoutes.Store(route.Dst.String(), &route)
+		dstCIDRs = append(dstCIDRs, route.Dst)
+	}
+	return dstCIDRs, nil
+}
+
 func (c *Client) ClearConntrackEntryForService(svcIP net.IP, svcPort uint16, endpointIP net.IP, protocol binding.Protocol) error {
 	var protoVar uint8
 	var ipFamilyVar uint8
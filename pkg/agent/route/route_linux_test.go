@@ -78,10 +78,13 @@ func TestSyncRoutes(t *testing.T) {
 	serviceRoute2 := &netlink.Route{Dst: ip.MustParseCIDR("169.254.0.252/32"), Gw: net.ParseIP("169.254.0.253")}
 	egressRoute1 := &netlink.Route{Scope: netlink.SCOPE_LINK, Dst: ip.MustParseCIDR("10.10.10.0/24"), LinkIndex: 10, Table: 101}
 	egressRoute2 := &netlink.Route{Gw: net.ParseIP("10.10.10.1"), LinkIndex: 10, Table: 101}
-	mockNetlink.EXPECT().RouteList(nil, netlink.FAMILY_ALL).Return([]netlink.Route{*nodeRoute1, *serviceRoute1, *egressRoute1}, nil)
+	bgpRoute1 := &netlink.Route{Dst: ip.MustParseCIDR("10.20.0.0/16"), Gw: net.ParseIP("1.1.1.3"), Protocol: bgpRouteProtocol}
+	bgpRoute2 := &netlink.Route{Dst: ip.MustParseCIDR("10.30.0.0/16"), Gw: net.ParseIP("1.1.1.4"), Protocol: bgpRouteProtocol}
+	mockNetlink.EXPECT().RouteList(nil, netlink.FAMILY_ALL).Return([]netlink.Route{*nodeRoute1, *serviceRoute1, *egressRoute1, *bgpRoute1}, nil)
 	mockNetlink.EXPECT().RouteReplace(nodeRoute2)
 	mockNetlink.EXPECT().RouteReplace(serviceRoute2)
 	mockNetlink.EXPECT().RouteReplace(egressRoute2)
+	mockNetlink.EXPECT().RouteReplace(bgpRoute2)
 	mockNetlink.EXPECT().RouteReplace(&netlink.Route{
 		LinkIndex: 10,
 		Dst:       ip.MustParseCIDR("192.168.0.0/24"),
@@ -116,6 +119,8 @@ func TestSyncRoutes(t *testing.T) {
 	c.serviceRoutes.Store("169.254.0.253/32", serviceRoute1)
 	c.serviceRoutes.Store("169.254.0.252/32", serviceRoute2)
 	c.egressRoutes.Store(101, []*netlink.Route{egressRoute1, egressRoute2})
+	c.bgpRoutes.Store("10.20.0.0/16", bgpRoute1)
+	c.bgpRoutes.Store("10.30.0.0/16", bgpRoute2)
 
 	assert.NoError(t, c.syncRoute())
 }
@@ -3319,6 +3324,84 @@ func TestEgressRoutes(t *testing.T) {
 	}
 }
 
+func TestBGPRoute(t *testing.T) {
+	tests := []struct {
+		name           string
+		dst            *net.IPNet
+		nextHop        net.IP
+		updatedNextHop net.IP
+		expectedCalls  func(mockNetlink *netlinktest.MockInterfaceMockRecorder)
+	}{
+		{
+			name:           "IPv4",
+			dst:            ip.MustParseCIDR("10.20.0.0/24"),
+			nextHop:        net.ParseIP("1.1.1.1"),
+			updatedNextHop: net.ParseIP("1.1.1.2"),
+			expectedCalls: func(mockNetlink *netlinktest.MockInterfaceMockRecorder) {
+				mockNetlink.RouteReplace(&netlink.Route{Dst: ip.MustParseCIDR("10.20.0.0/24"), Gw: net.ParseIP("1.1.1.1"), Protocol: bgpRouteProtocol})
+				mockNetlink.RouteReplace(&netlink.Route{Dst: ip.MustParseCIDR("10.20.0.0/24"), Gw: net.ParseIP("1.1.1.2"), Protocol: bgpRouteProtocol})
+				mockNetlink.RouteDel(&netlink.Route{Dst: ip.MustParseCIDR("10.20.0.0/24"), Gw: net.ParseIP("1.1.1.2"), Protocol: bgpRouteProtocol})
+			},
+		},
+		{
+			name:           "IPv6",
+			dst:            ip.MustParseCIDR("1122:3344::/64"),
+			nextHop:        net.ParseIP("1122::1"),
+			updatedNextHop: net.ParseIP("1122::2"),
+			expectedCalls: func(mockNetlink *netlinktest.MockInterfaceMockRecorder) {
+				mockNetlink.RouteReplace(&netlink.Route{Dst: ip.MustParseCIDR("1122:3344::/64"), Gw: net.ParseIP("1122::1"), Protocol: bgpRouteProtocol})
+				mockNetlink.RouteReplace(&netlink.Route{Dst: ip.MustParseCIDR("1122:3344::/64"), Gw: net.ParseIP("1122::2"), Protocol: bgpRouteProtocol})
+				mockNetlink.RouteDel(&netlink.Route{Dst: ip.MustParseCIDR("1122:3344::/64"), Gw: net.ParseIP("1122::2"), Protocol: bgpRouteProtocol})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockNetlink := netlinktest.NewMockInterface(ctrl)
+			c := &Client{
+				netlink:    mockNetlink,
+				nodeConfig: nodeConfig,
+			}
+			tt.expectedCalls(mockNetlink.EXPECT())
+
+			assert.NoError(t, c.AddBGPRoute(tt.dst, tt.nextHop))
+			assert.NoError(t, c.AddBGPRoute(tt.dst, tt.updatedNextHop))
+			assert.NoError(t, c.DeleteBGPRoute(tt.dst))
+			// Deleting a route which doesn't exist should do nothing.
+			assert.NoError(t, c.DeleteBGPRoute(tt.dst))
+			c.bgpRoutes.Range(func(key, value any) bool {
+				t.Errorf("The bgpRoutes should be empty but contains %v:%v", key, value)
+				return true
+			})
+		})
+	}
+}
+
+func TestRestoreBGPRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNetlink := netlinktest.NewMockInterface(ctrl)
+	route1 := netlink.Route{Dst: ip.MustParseCIDR("10.20.0.0/24"), Gw: net.ParseIP("1.1.1.1"), Protocol: bgpRouteProtocol}
+	route2 := netlink.Route{Dst: ip.MustParseCIDR("1122:3344::/64"), Gw: net.ParseIP("1122::1"), Protocol: bgpRouteProtocol}
+	// A default route has no destination and is ignored.
+	route3 := netlink.Route{Gw: net.ParseIP("1.1.1.1"), Protocol: bgpRouteProtocol}
+	mockNetlink.EXPECT().RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Protocol: bgpRouteProtocol}, netlink.RT_FILTER_PROTOCOL).Return([]netlink.Route{route1, route2, route3}, nil)
+	// The route may have been deleted already.
+	mockNetlink.EXPECT().RouteDel(&route1).Return(unix.ESRCH)
+	c := &Client{
+		netlink:    mockNetlink,
+		nodeConfig: nodeConfig,
+	}
+
+	dstCIDRs, err := c.RestoreBGPRoutes()
+	require.NoError(t, err)
+	assert.Equal(t, []*net.IPNet{route1.Dst, route2.Dst}, dstCIDRs)
+	// The restored routes can be deleted.
+	assert.NoError(t, c.DeleteBGPRoute(route1.Dst))
+	_, exists := c.bgpRoutes.Load(route2.Dst.String())
+	assert.True(t, exists)
+}
+
 func TestEgressRule(t *testing.T) {
 	tests := []struct {
 		name          string
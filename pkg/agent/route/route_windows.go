@@ -613,6 +613,18 @@ func (c *Client) DeleteRouteForLink(dstCIDR *net.IPNet, linkIndex int) error {
 	return errors.New("DeleteRouteForLink is not implemented on Windows")
 }
 
+func (c *Client) AddBGPRoute(dstCIDR *net.IPNet, nextHop net.IP) error {
+	return errors.New("AddBGPRoute is not implemented on Windows")
+}
+
+func (c *Client) DeleteBGPRoute(dstCIDR *net.IPNet) error {
+	return errors.New("DeleteBGPRoute is not implemented on Windows")
+}
+
+func (c *Client) RestoreBGPRoutes() ([]*net.IPNet, error) {
+	return nil, nil
+}
+
 func (c *Client) ClearConntrackEntryForService(svcIP net.IP, svcPort uint16, endpointIP net.IP, protocol binding.Protocol) error {
 	return errors.New("ClearConntrackEntryForService is not implemented on Windows")
 }
@@ -59,6 +59,20 @@ func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AddBGPRoute mocks base method.
+func (m *MockInterface) AddBGPRoute(dstCIDR *net.IPNet, nextHop net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBGPRoute", dstCIDR, nextHop)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBGPRoute indicates an expected call of AddBGPRoute.
+func (mr *MockInterfaceMockRecorder) AddBGPRoute(dstCIDR, nextHop any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBGPRoute", reflect.TypeOf((*MockInterface)(nil).AddBGPRoute), dstCIDR, nextHop)
+}
+
 // AddEgressRoutes mocks base method.
 func (m *MockInterface) AddEgressRoutes(tableID uint32, dev int, gateway net.IP, prefixLength int) error {
 	m.ctrl.T.Helper()
@@ -213,6 +227,20 @@ func (mr *MockInterfaceMockRecorder) ClearConntrackEntryForService(svcIP, svcPor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearConntrackEntryForService", reflect.TypeOf((*MockInterface)(nil).ClearConntrackEntryForService), svcIP, svcPort, endpointIP, protocol)
 }
 
+// DeleteBGPRoute mocks base method.
+func (m *MockInterface) DeleteBGPRoute(dstCIDR *net.IPNet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBGPRoute", dstCIDR)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBGPRoute indicates an expected call of DeleteBGPRoute.
+func (mr *MockInterfaceMockRecorder) DeleteBGPRoute(dstCIDR any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBGPRoute", reflect.TypeOf((*MockInterface)(nil).DeleteBGPRoute), dstCIDR)
+}
+
 // DeleteEgressRoutes mocks base method.
 func (m *MockInterface) DeleteEgressRoutes(tableID uint32) error {
 	m.ctrl.T.Helper()
@@ -395,6 +423,21 @@ func (mr *MockInterfaceMockRecorder) Reconcile(podCIDRs any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockInterface)(nil).Reconcile), podCIDRs)
 }
 
+// RestoreBGPRoutes mocks base method.
+func (m *MockInterface) RestoreBGPRoutes() ([]*net.IPNet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBGPRoutes")
+	ret0, _ := ret[0].([]*net.IPNet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBGPRoutes indicates an expected call of RestoreBGPRoutes.
+func (mr *MockInterfaceMockRecorder) RestoreBGPRoutes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBGPRoutes", reflect.TypeOf((*MockInterface)(nil).RestoreBGPRoutes))
+}
+
 // RestoreEgressRoutesAndRules mocks base method.
 func (m *MockInterface) RestoreEgressRoutesAndRules(minTableID, maxTableID int) error {
 	m.ctrl.T.Helper()
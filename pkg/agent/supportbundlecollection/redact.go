@@ -0,0 +1,112 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlecollection
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"antrea.io/antrea/v2/pkg/agent/interfacestore"
+	"antrea.io/antrea/v2/pkg/apis/controlplane"
+	cpv1b2 "antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+	"antrea.io/antrea/v2/pkg/querier"
+	"antrea.io/antrea/v2/pkg/support"
+)
+
+// newRedactor returns a Redactor for the names which can appear in the bundle of the agent: the local Node or
+// ExternalNode, the Pods and ExternalEntities connected to OVS, the members of the NetworkPolicy groups and the
+// NetworkPolicies themselves. On a Node, the names and labels of all the Nodes, the labels of the local Pods and the
+// names of the Services are also redacted. Pseudonyms are derived from key. The names are read from the informer
+// caches of the agent, so that collecting redacted bundles does not generate additional requests to the Kubernetes
+// API.
+func (c *SupportBundleController) newRedactor(key []byte) (*support.Redactor, error) {
+	redactor := support.NewRedactor(key)
+	redactor.AddNames("node", c.nodeName)
+	if c.supportBundleNodeType == controlplane.SupportBundleCollectionNodeTypeExternalNode {
+		redactor.AddNames("namespace", c.namespace)
+	}
+
+	ifaceStore := c.aq.GetInterfaceStore()
+	for _, iface := range ifaceStore.GetInterfacesByType(interfacestore.ContainerInterface) {
+		redactor.AddNames("namespace", iface.PodNamespace)
+		redactor.AddNames("pod", iface.PodName)
+	}
+	for _, iface := range ifaceStore.GetInterfacesByType(interfacestore.ExternalEntityInterface) {
+		redactor.AddNames("namespace", iface.EntityNamespace)
+		redactor.AddNames("externalentity", iface.EntityName)
+	}
+
+	if c.supportBundleNodeType == controlplane.SupportBundleCollectionNodeTypeNode {
+		nodes, err := c.aq.GetNodeLister().List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("error when listing Nodes: %w", err)
+		}
+		for _, node := range nodes {
+			redactor.AddNames("node", node.Name)
+			redactor.AddLabels(node.Labels)
+		}
+		pods, err := c.podLister.List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("error when listing Pods: %w", err)
+		}
+		for _, pod := range pods {
+			redactor.AddNames("namespace", pod.Namespace)
+			redactor.AddNames("pod", pod.Name)
+			redactor.AddLabels(pod.Labels)
+		}
+		services, err := c.serviceLister.List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("error when listing Services: %w", err)
+		}
+		for _, service := range services {
+			redactor.AddNames("namespace", service.Namespace)
+			redactor.AddNames("service", service.Name)
+		}
+	}
+
+	addMembers := func(members []cpv1b2.GroupMember) {
+		for _, member := range members {
+			if member.Pod != nil {
+				redactor.AddNames("namespace", member.Pod.Namespace)
+				redactor.AddNames("pod", member.Pod.Name)
+			}
+			if member.ExternalEntity != nil {
+				redactor.AddNames("namespace", member.ExternalEntity.Namespace)
+				redactor.AddNames("externalentity", member.ExternalEntity.Name)
+			}
+			if member.Node != nil {
+				redactor.AddNames("node", member.Node.Name)
+			}
+			if member.Service != nil {
+				redactor.AddNames("namespace", member.Service.Namespace)
+				redactor.AddNames("service", member.Service.Name)
+			}
+		}
+	}
+	for _, group := range c.npq.GetAddressGroups() {
+		addMembers(group.GroupMembers)
+	}
+	for _, group := range c.npq.GetAppliedToGroups() {
+		addMembers(group.GroupMembers)
+	}
+	for _, policy := range c.npq.GetNetworkPolicies(&querier.NetworkPolicyQueryFilter{}) {
+		if policy.SourceRef != nil {
+			redactor.AddNames("namespace", policy.SourceRef.Namespace)
+			redactor.AddNames("policy", policy.SourceRef.Name)
+		}
+	}
+	return redactor, nil
+}
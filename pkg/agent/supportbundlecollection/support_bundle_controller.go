@@ -26,6 +26,9 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/exec"
@@ -37,16 +40,13 @@ import (
 	"antrea.io/antrea/v2/pkg/ovs/ovsctl"
 	"antrea.io/antrea/v2/pkg/querier"
 	"antrea.io/antrea/v2/pkg/support"
+	"antrea.io/antrea/v2/pkg/util/auth"
 	"antrea.io/antrea/v2/pkg/util/compress"
+	"antrea.io/antrea/v2/pkg/util/fileupload"
 	"antrea.io/antrea/v2/pkg/util/k8s"
-	"antrea.io/antrea/v2/pkg/util/sftp"
 )
 
-type ProtocolType string
-
 const (
-	sftpProtocol ProtocolType = "sftp"
-
 	controllerName = "SupportBundleCollectionController"
 )
 
@@ -71,7 +71,13 @@ type SupportBundleController struct {
 	npq                          querier.AgentNetworkPolicyInfoQuerier
 	v4Enabled                    bool
 	v6Enabled                    bool
-	sftpUploader                 sftp.Uploader
+	uploader                     *fileupload.Uploader
+
+	// The following listers are used to collect the names to redact in the bundle. They are nil on an ExternalNode.
+	podLister           corelisters.PodLister
+	podListerSynced     cache.InformerSynced
+	serviceLister       corelisters.ServiceLister
+	serviceListerSynced cache.InformerSynced
 }
 
 func NewSupportBundleController(nodeName string,
@@ -81,6 +87,8 @@ func NewSupportBundleController(nodeName string,
 	ovsCtlClient ovsctl.OVSCtlClient,
 	aq agentquerier.AgentQuerier,
 	npq querier.AgentNetworkPolicyInfoQuerier,
+	localPodInformer cache.SharedIndexInformer,
+	serviceInformer coreinformers.ServiceInformer,
 	v4Enabled,
 	v6Enabled bool) *SupportBundleController {
 	c := &SupportBundleController{
@@ -96,7 +104,15 @@ func NewSupportBundleController(nodeName string,
 		npq:          npq,
 		v4Enabled:    v4Enabled,
 		v6Enabled:    v6Enabled,
-		sftpUploader: sftp.NewUploader(),
+		uploader:     fileupload.NewUploader(),
+	}
+	if localPodInformer != nil {
+		c.podLister = corelisters.NewPodLister(localPodInformer.GetIndexer())
+		c.podListerSynced = localPodInformer.HasSynced
+	}
+	if serviceInformer != nil {
+		c.serviceLister = serviceInformer.Lister()
+		c.serviceListerSynced = serviceInformer.Informer().HasSynced
 	}
 	return c
 }
@@ -176,6 +192,17 @@ func (c *SupportBundleController) Run(stopCh <-chan struct{}) {
 	klog.InfoS("Starting", "controllerName", controllerName)
 	defer klog.InfoS("Shutting down", "controllerName", controllerName)
 
+	var cacheSyncs []cache.InformerSynced
+	if c.podListerSynced != nil {
+		cacheSyncs = append(cacheSyncs, c.podListerSynced)
+	}
+	if c.serviceListerSynced != nil {
+		cacheSyncs = append(cacheSyncs, c.serviceListerSynced)
+	}
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, cacheSyncs...) {
+		return
+	}
+
 	go wait.NonSlidingUntil(c.watchSupportBundleCollections, 5*time.Second, stopCh)
 
 	go wait.Until(c.worker, time.Second, stopCh)
@@ -237,32 +264,26 @@ func (c *SupportBundleController) generateSupportBundle(supportBundle *cpv1b2.Su
 	defer defaultFS.RemoveAll(basedir)
 
 	agentDumper := newAgentDumper(defaultFS, defaultExecutor, c.ovsCtlClient, c.aq, c.npq, supportBundle.SinceTime, c.v4Enabled, c.v6Enabled)
-	if err = agentDumper.DumpLog(basedir); err != nil {
-		return err
-	}
-	if err = agentDumper.DumpHostNetworkInfo(basedir); err != nil {
-		return err
-	}
-	if err = agentDumper.DumpFlows(basedir); err != nil {
-		return err
-	}
-	if err = agentDumper.DumpGroups(basedir); err != nil {
-		return err
-	}
-	if err = agentDumper.DumpNetworkPolicyResources(basedir); err != nil {
-		return err
-	}
-	if err = agentDumper.DumpAgentInfo(basedir); err != nil {
-		return err
-	}
-	if err = agentDumper.DumpHeapPprof(basedir); err != nil {
+	dumpFuncs, err := support.AgentDumpFuncs(agentDumper, support.Profile(supportBundle.Profile))
+	if err != nil {
 		return err
 	}
-	if err = agentDumper.DumpGoroutinePprof(basedir); err != nil {
-		return err
+	for _, dump := range dumpFuncs {
+		if err = dump(basedir); err != nil {
+			return err
+		}
 	}
-	if err = agentDumper.DumpOVSPorts(basedir); err != nil {
-		return err
+	if supportBundle.Redact {
+		klog.V(2).InfoS("Redacting support bundle collection", "name", supportBundle.Name)
+		// The pseudonyms are keyed by the UID of the collection, so that they are consistent across the bundles of
+		// all the Nodes and of the antrea-controller.
+		redactor, err := c.newRedactor([]byte(supportBundle.UID))
+		if err != nil {
+			return fmt.Errorf("error when preparing redaction of support bundle: %w", err)
+		}
+		if err := redactor.RedactDir(defaultFS, basedir); err != nil {
+			return fmt.Errorf("error when redacting support bundle: %w", err)
+		}
 	}
 
 	outputFile, err := afero.TempFile(defaultFS, "", "bundle_*.tar.gz")
@@ -288,31 +309,44 @@ func (c *SupportBundleController) generateSupportBundle(supportBundle *cpv1b2.Su
 
 func (c *SupportBundleController) uploadSupportBundle(supportBundle *cpv1b2.SupportBundleCollection, outputFile afero.File) error {
 	klog.V(2).InfoS("Uploading support bundle collection", "name", supportBundle.Name)
-	uploader, err := c.getUploaderByProtocol(sftpProtocol)
-	if err != nil {
-		return fmt.Errorf("failed to upload support bundle while getting uploader: %v", err)
+	fileServer := &fileupload.FileServer{
+		URL:           supportBundle.FileServer.URL,
+		HostPublicKey: supportBundle.FileServer.HostPublicKey,
 	}
-
-	if _, err := outputFile.Seek(0, 0); err != nil {
-		return fmt.Errorf("failed to upload to the file server while setting offset: %v", err)
+	if s3Config := supportBundle.FileServer.S3; s3Config != nil {
+		fileServer.S3Endpoint = s3Config.Endpoint
+		fileServer.S3Region = s3Config.Region
 	}
 	fileName := c.nodeName + "_" + supportBundle.Name + ".tar.gz"
-	cfg, err := sftp.GetSSHClientConfig(
-		supportBundle.Authentication.BasicAuthentication.Username,
-		supportBundle.Authentication.BasicAuthentication.Password,
-		supportBundle.FileServer.HostPublicKey,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to generate SSH client config: %w", err)
-	}
-	return uploader.Upload(supportBundle.FileServer.URL, fileName, cfg, outputFile)
+	return c.uploader.Upload(context.TODO(), fileServer, getServerAuth(&supportBundle.Authentication), fileName, outputFile)
 }
 
-func (c *SupportBundleController) getUploaderByProtocol(protocol ProtocolType) (sftp.Uploader, error) {
-	if protocol == sftpProtocol {
-		return c.sftpUploader, nil
+// getServerAuth returns the authentication configuration for the file server. The antrea-controller only sets the
+// credentials matching the authentication type of the SupportBundleCollection.
+func getServerAuth(authentication *cpv1b2.BundleServerAuthConfiguration) *auth.AuthConfiguration {
+	switch {
+	case authentication.BearerToken != "":
+		return &auth.AuthConfiguration{AuthType: auth.BearerTokenType, BearerToken: authentication.BearerToken}
+	case authentication.APIKey != "":
+		return &auth.AuthConfiguration{AuthType: auth.APIKeyType, APIKey: authentication.APIKey}
+	case authentication.BasicAuthentication != nil:
+		return &auth.AuthConfiguration{
+			AuthType: auth.BasicAuthenticationType,
+			BasicAuthentication: &auth.BasicAuthentication{
+				Username: authentication.BasicAuthentication.Username,
+				Password: authentication.BasicAuthentication.Password,
+			},
+		}
+	case authentication.AccessKey != nil:
+		return &auth.AuthConfiguration{
+			AuthType: auth.AccessKeyType,
+			AccessKey: &auth.AccessKey{
+				AccessKeyID:     authentication.AccessKey.AccessKeyID,
+				SecretAccessKey: authentication.AccessKey.SecretAccessKey,
+			},
+		}
 	}
-	return nil, fmt.Errorf("unsupported protocol %s", protocol)
+	return nil
 }
 
 func (c *SupportBundleController) updateSupportBundleCollectionStatus(key string, complete bool, genErr error) error {
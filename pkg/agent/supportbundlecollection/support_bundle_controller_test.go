@@ -15,8 +15,12 @@
 package supportbundlecollection
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"slices"
 	"testing"
 
@@ -25,20 +29,31 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/exec"
 
+	"antrea.io/antrea/v2/pkg/agent/interfacestore"
 	agentquerier "antrea.io/antrea/v2/pkg/agent/querier"
+	agentqueriertesting "antrea.io/antrea/v2/pkg/agent/querier/testing"
 	"antrea.io/antrea/v2/pkg/apis/controlplane"
 	cpv1b2 "antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
 	"antrea.io/antrea/v2/pkg/client/clientset/versioned"
 	fakeversioned "antrea.io/antrea/v2/pkg/client/clientset/versioned/fake"
 	"antrea.io/antrea/v2/pkg/ovs/ovsctl"
 	"antrea.io/antrea/v2/pkg/querier"
+	queriertesting "antrea.io/antrea/v2/pkg/querier/testing"
 	"antrea.io/antrea/v2/pkg/support"
+	"antrea.io/antrea/v2/pkg/util/auth"
+	"antrea.io/antrea/v2/pkg/util/compress"
+	"antrea.io/antrea/v2/pkg/util/s3upload"
 	"antrea.io/antrea/v2/pkg/util/sftp"
 	sftptesting "antrea.io/antrea/v2/pkg/util/sftp/testing"
 )
@@ -60,7 +75,7 @@ func newFakeController(t *testing.T) (*fakeController, *fakeversioned.Clientset)
 	controller := gomock.NewController(t)
 	clientset := &fakeversioned.Clientset{}
 	supportBundleController := NewSupportBundleController("vm1", controlplane.SupportBundleCollectionNodeTypeExternalNode, "vm-ns", &antreaClientGetter{clientset}, nil,
-		nil, nil, true, true)
+		nil, nil, nil, nil, true, true)
 	return &fakeController{
 		SupportBundleController: supportBundleController,
 		mockController:          controller,
@@ -98,12 +113,10 @@ func TestSupportBundleCollectionAdd(t *testing.T) {
 		},
 		{
 			name:                    "Add SupportBundleCollection with unsupported url prefix",
-			supportBundleCollection: generateSupportbundleCollection("supportBundle3", "https://10.220.175.92:22/root/supportbundle", nil),
+			supportBundleCollection: generateSupportbundleCollection("supportBundle3", "ftp://10.220.175.92:22/root/supportbundle", nil),
 			agentDumper:             &mockAgentDumper{},
-			uploader: &testUploader{
-				err: uploadErr,
-			},
-			expectedSyncErr: uploadErr.Error(),
+			uploader:                &testUploader{},
+			expectedSyncErr:         "unsupported protocol ftp",
 		},
 		{
 			name:                    "Add SupportBundleCollection with retry logics",
@@ -214,7 +227,7 @@ func TestSupportBundleCollectionAdd(t *testing.T) {
 				newAgentDumper = support.NewAgentDumper
 			}()
 			controller, clientset := newFakeController(t)
-			controller.sftpUploader = tt.uploader
+			controller.uploader.SFTPUploader = tt.uploader
 			var bundleStatus *cpv1b2.SupportBundleCollectionStatus
 			clientset.AddReactor("update", "supportbundlecollections/status", k8stesting.ReactionFunc(func(action k8stesting.Action) (bool, runtime.Object, error) {
 				bundleStatus = action.(k8stesting.UpdateAction).GetObject().(*cpv1b2.SupportBundleCollectionStatus)
@@ -242,9 +255,204 @@ func TestSupportBundleCollectionDelete(t *testing.T) {
 	assert.NoError(t, controller.syncSupportBundleCollection("deletedBundle"))
 }
 
+func TestSupportBundleCollectionProfile(t *testing.T) {
+	testcases := []struct {
+		profile         string
+		expectedCalls   []string
+		expectedSyncErr string
+	}{
+		{
+			profile:       "",
+			expectedCalls: []string{"DumpLog", "DumpHostNetworkInfo", "DumpFlows", "DumpGroups", "DumpNetworkPolicyResources", "DumpAgentInfo", "DumpHeapPprof", "DumpGoroutinePprof", "DumpOVSPorts"},
+		},
+		{
+			profile:       "All",
+			expectedCalls: []string{"DumpLog", "DumpHostNetworkInfo", "DumpFlows", "DumpGroups", "DumpNetworkPolicyResources", "DumpAgentInfo", "DumpHeapPprof", "DumpGoroutinePprof", "DumpOVSPorts"},
+		},
+		{
+			profile:       "LogsOnly",
+			expectedCalls: []string{"DumpLog", "DumpAgentInfo"},
+		},
+		{
+			profile:       "DatapathOnly",
+			expectedCalls: []string{"DumpHostNetworkInfo", "DumpFlows", "DumpGroups", "DumpOVSPorts", "DumpAgentInfo"},
+		},
+		{
+			profile:       "PolicyOnly",
+			expectedCalls: []string{"DumpNetworkPolicyResources", "DumpAgentInfo"},
+		},
+		{
+			profile:         "Unknown",
+			expectedSyncErr: `unsupported support bundle profile "Unknown"`,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.profile, func(t *testing.T) {
+			agentDumper := &mockAgentDumper{}
+			newAgentDumper = func(fs afero.Fs, executor exec.Interface, ovsCtlClient ovsctl.OVSCtlClient, aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, since string, v4Enabled, v6Enabled bool) support.AgentDumper {
+				return agentDumper
+			}
+			defer func() {
+				newAgentDumper = support.NewAgentDumper
+			}()
+			controller, _ := newFakeController(t)
+			controller.uploader.SFTPUploader = &testUploader{}
+			supportBundleCollection := generateSupportbundleCollection("supportBundle", "sftp://10.220.175.92:22/root/supportbundle", nil)
+			supportBundleCollection.Profile = tt.profile
+			controller.addSupportBundleCollection(supportBundleCollection)
+			err := controller.syncSupportBundleCollection(supportBundleCollection.Name)
+			if tt.expectedSyncErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedSyncErr)
+			}
+			assert.Equal(t, tt.expectedCalls, agentDumper.calls)
+		})
+	}
+}
+
+func TestSupportBundleCollectionRedact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(interfacestore.NewContainerInterface("web-1-a1b2c3", "c1", "web-1", "frontend", "eth0", "", nil, nil, 0))
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	nodeIndexer.Add(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "k8s-node-2", Labels: map[string]string{"zone": "zone-a"}}})
+	k8sClient := fakeclientset.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "frontend", Name: "web-1", Labels: map[string]string{"app": "web"}}, Spec: corev1.PodSpec{NodeName: "k8s-node-1"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "backend", Name: "db"}},
+	)
+	aq := agentqueriertesting.NewMockAgentQuerier(ctrl)
+	aq.EXPECT().GetInterfaceStore().Return(ifaceStore)
+	aq.EXPECT().GetNodeLister().Return(corelisters.NewNodeLister(nodeIndexer))
+	npq := queriertesting.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
+	npq.EXPECT().GetAddressGroups().Return([]cpv1b2.AddressGroup{{
+		GroupMembers: []cpv1b2.GroupMember{{Pod: &cpv1b2.PodReference{Namespace: "backend", Name: "db-0"}}},
+	}})
+	npq.EXPECT().GetAppliedToGroups().Return(nil)
+	npq.EXPECT().GetNetworkPolicies(gomock.Any()).Return([]cpv1b2.NetworkPolicy{{
+		SourceRef: &cpv1b2.NetworkPolicyReference{Namespace: "frontend", Name: "allow-db"},
+	}})
+
+	agentDumper := &mockAgentDumper{
+		logFiles: map[string]string{
+			"antrea-agent.k8s-node-1.root.log.INFO":  `"Installed Pod" pod="frontend/web-1" IP="10.10.1.5" labels="map[app:web]"`,
+			"antrea-agent.k8s-node-1.root.log.ERROR": `"Failed to connect" pod="backend/db-0" IP="10.10.2.3" node="k8s-node-2" policy="frontend/allow-db" service="backend/db"`,
+		},
+	}
+	newAgentDumper = func(fs afero.Fs, executor exec.Interface, ovsCtlClient ovsctl.OVSCtlClient, aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, since string, v4Enabled, v6Enabled bool) support.AgentDumper {
+		return agentDumper
+	}
+	defer func() {
+		newAgentDumper = support.NewAgentDumper
+	}()
+	clientset := &fakeversioned.Clientset{}
+	informerFactory := informers.NewSharedInformerFactory(k8sClient, 0)
+	podInformer := informerFactory.Core().V1().Pods()
+	controller := NewSupportBundleController("k8s-node-1", controlplane.SupportBundleCollectionNodeTypeNode, "", &antreaClientGetter{clientset}, nil, aq, npq,
+		podInformer.Informer(), informerFactory.Core().V1().Services(), true, true)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+	uploader := &testUploader{}
+	controller.uploader.SFTPUploader = uploader
+	supportBundleCollection := generateSupportbundleCollection("supportBundle", "sftp://10.220.175.92:22/root/supportbundle", nil)
+	supportBundleCollection.Profile = "LogsOnly"
+	supportBundleCollection.Redact = true
+	controller.addSupportBundleCollection(supportBundleCollection)
+	require.NoError(t, controller.syncSupportBundleCollection(supportBundleCollection.Name))
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, compress.UnpackReader(fs, bytes.NewReader(uploader.data), true, "bundle"))
+	files := map[string]string{}
+	require.NoError(t, afero.Walk(fs, "bundle", func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := afero.ReadFile(fs, path)
+		files[filepath.Base(path)] = string(data)
+		return err
+	}))
+	assert.Equal(t, map[string]string{
+		"antrea-agent.node-1.root.log.INFO":  `"Installed Pod" pod="namespace-1/pod-1" IP="240.0.0.2" labels="map[app:label-2]"`,
+		"antrea-agent.node-1.root.log.ERROR": `"Failed to connect" pod="namespace-2/pod-2" IP="240.0.0.1" node="node-2" policy="namespace-1/policy-1" service="namespace-2/service-1"`,
+	}, files)
+}
+
+type testHTTPUploader struct {
+	url        string
+	fileName   string
+	serverAuth *auth.AuthConfiguration
+}
+
+func (uploader *testHTTPUploader) Upload(ctx context.Context, url string, fileName string, serverAuth *auth.AuthConfiguration, file io.ReadSeeker) error {
+	uploader.url = url
+	uploader.fileName = fileName
+	uploader.serverAuth = serverAuth
+	return nil
+}
+
+type testS3Uploader struct {
+	url      string
+	fileName string
+	config   *s3upload.Config
+}
+
+func (uploader *testS3Uploader) Upload(ctx context.Context, url string, fileName string, config *s3upload.Config, file io.ReadSeeker) error {
+	uploader.url = url
+	uploader.fileName = fileName
+	uploader.config = config
+	return nil
+}
+
+func TestSupportBundleCollectionUpload(t *testing.T) {
+	newAgentDumper = func(fs afero.Fs, executor exec.Interface, ovsCtlClient ovsctl.OVSCtlClient, aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, since string, v4Enabled, v6Enabled bool) support.AgentDumper {
+		return &mockAgentDumper{}
+	}
+	defer func() {
+		newAgentDumper = support.NewAgentDumper
+	}()
+
+	t.Run("https", func(t *testing.T) {
+		controller, _ := newFakeController(t)
+		httpUploader := &testHTTPUploader{}
+		controller.uploader.HTTPUploader = httpUploader
+		supportBundleCollection := generateSupportbundleCollection("supportBundle", "https://api.example.com/v1/supportbundles/", nil)
+		supportBundleCollection.Authentication = cpv1b2.BundleServerAuthConfiguration{APIKey: "key"}
+		controller.addSupportBundleCollection(supportBundleCollection)
+		require.NoError(t, controller.syncSupportBundleCollection(supportBundleCollection.Name))
+		assert.Equal(t, "https://api.example.com/v1/supportbundles/", httpUploader.url)
+		assert.Equal(t, "vm1_supportBundle.tar.gz", httpUploader.fileName)
+		assert.Equal(t, &auth.AuthConfiguration{AuthType: auth.APIKeyType, APIKey: "key"}, httpUploader.serverAuth)
+	})
+
+	t.Run("s3", func(t *testing.T) {
+		controller, _ := newFakeController(t)
+		s3Uploader := &testS3Uploader{}
+		controller.uploader.S3Uploader = s3Uploader
+		supportBundleCollection := generateSupportbundleCollection("supportBundle", "s3://bundles/antrea", nil)
+		supportBundleCollection.FileServer.S3 = &cpv1b2.BundleS3Config{Endpoint: "http://minio.minio.svc:9000", Region: "us-west-2"}
+		supportBundleCollection.Authentication = cpv1b2.BundleServerAuthConfiguration{
+			AccessKey: &cpv1b2.AccessKey{AccessKeyID: "id", SecretAccessKey: "secret"},
+		}
+		controller.addSupportBundleCollection(supportBundleCollection)
+		require.NoError(t, controller.syncSupportBundleCollection(supportBundleCollection.Name))
+		assert.Equal(t, "s3://bundles/antrea", s3Uploader.url)
+		assert.Equal(t, "vm1_supportBundle.tar.gz", s3Uploader.fileName)
+		assert.Equal(t, &s3upload.Config{
+			Endpoint:        "http://minio.minio.svc:9000",
+			Region:          "us-west-2",
+			AccessKeyID:     "id",
+			SecretAccessKey: "secret",
+		}, s3Uploader.config)
+	})
+}
+
 type testUploader struct {
 	err     error
 	hostKey ssh.PublicKey
+	// data stores the content of the last uploaded file.
+	data []byte
 }
 
 func (uploader *testUploader) Upload(address string, path string, config *ssh.ClientConfig, tarGzFile io.Reader) error {
@@ -263,6 +471,11 @@ func (uploader *testUploader) Upload(address string, path string, config *ssh.Cl
 			return fmt.Errorf("invalid host key: %w", err)
 		}
 	}
+	data, err := io.ReadAll(tarGzFile)
+	if err != nil {
+		return err
+	}
+	uploader.data = data
 	return nil
 }
 
@@ -286,6 +499,10 @@ func generateSupportbundleCollection(name string, url string, hostPublicKey []by
 }
 
 type mockAgentDumper struct {
+	// calls records the names of the called methods.
+	calls []string
+	// logFiles are written under the basedir by DumpLog.
+	logFiles                      map[string]string
 	dumpLogErr                    error
 	dumpFlowsErr                  error
 	dumpGroupsErr                 error
@@ -299,41 +516,56 @@ type mockAgentDumper struct {
 }
 
 func (d *mockAgentDumper) DumpLog(basedir string) error {
+	d.calls = append(d.calls, "DumpLog")
+	for name, data := range d.logFiles {
+		if err := afero.WriteFile(defaultFS, filepath.Join(basedir, name), []byte(data), 0644); err != nil {
+			return err
+		}
+	}
 	return d.dumpLogErr
 }
 
 func (d *mockAgentDumper) DumpFlows(basedir string) error {
+	d.calls = append(d.calls, "DumpFlows")
 	return d.dumpFlowsErr
 }
 
 func (d *mockAgentDumper) DumpGroups(basedir string) error {
+	d.calls = append(d.calls, "DumpGroups")
 	return d.dumpGroupsErr
 }
 
 func (d *mockAgentDumper) DumpHostNetworkInfo(basedir string) error {
+	d.calls = append(d.calls, "DumpHostNetworkInfo")
 	return d.dumpHostNetworkInfoErr
 }
 
 func (d *mockAgentDumper) DumpAgentInfo(basedir string) error {
+	d.calls = append(d.calls, "DumpAgentInfo")
 	return d.dumpAgentInfoErr
 }
 
 func (d *mockAgentDumper) DumpNetworkPolicyResources(basedir string) error {
+	d.calls = append(d.calls, "DumpNetworkPolicyResources")
 	return d.dumpNetworkPolicyResourcesErr
 }
 
 func (d *mockAgentDumper) DumpHeapPprof(basedir string) error {
+	d.calls = append(d.calls, "DumpHeapPprof")
 	return d.dumpHeapPprofErr
 }
 
 func (d *mockAgentDumper) DumpGoroutinePprof(basedir string) error {
+	d.calls = append(d.calls, "DumpGoroutinePprof")
 	return d.dumpGoroutinePprofErr
 }
 
 func (d *mockAgentDumper) DumpOVSPorts(basedir string) error {
+	d.calls = append(d.calls, "DumpOVSPorts")
 	return d.dumpOVSPortsErr
 }
 
 func (d *mockAgentDumper) DumpMemberlist(basedir string) error {
+	d.calls = append(d.calls, "DumpMemberlist")
 	return d.dumpMemberlistErr
 }
@@ -14,6 +14,12 @@
 
 package types
 
+import (
+	"net"
+
+	"antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+)
+
 type PodUpdate struct {
 	PodNamespace string
 	PodName      string
@@ -21,3 +27,17 @@ type PodUpdate struct {
 	NetNS        string
 	IsAdd        bool
 }
+
+// PolicyRuleDrop is the event of a packet dropped or rejected by an Antrea-native policy rule, as reported by OVS
+// through packet-in messages.
+type PolicyRuleDrop struct {
+	PolicyRef *v1beta2.NetworkPolicyReference
+	RuleName  string
+	// Disposition is either "Drop" or "Reject".
+	Disposition   string
+	SourceIP      net.IP
+	DestinationIP net.IP
+	// OriginalDestinationIP is the destination IP of the packet before DNAT, e.g. the ClusterIP of a Service. It is
+	// nil if the packet was not DNATed.
+	OriginalDestinationIP net.IP
+}
@@ -19,6 +19,7 @@ import (
 
 	agentapis "antrea.io/antrea/v2/pkg/agent/apis"
 	fallbackversion "antrea.io/antrea/v2/pkg/antctl/fallback/version"
+	analyzebundle "antrea.io/antrea/v2/pkg/antctl/raw/analyze/bundle"
 	checkcluster "antrea.io/antrea/v2/pkg/antctl/raw/check/cluster"
 	checkinstallation "antrea.io/antrea/v2/pkg/antctl/raw/check/installation"
 	"antrea.io/antrea/v2/pkg/antctl/raw/featuregates"
@@ -717,8 +718,8 @@ $ antctl get podmulticaststats pod -n namespace`,
 						{
 							name:            "type",
 							shorthand:       "T",
-							usage:           "Get advertised bgp routes of a specific type. Valid types are EgressIP, ServiceLoadBalancerIP, ServiceExternalIP, ServiceClusterIP or NodeIPAMPodCIDR.",
-							supportedValues: []string{"EgressIP", "ServiceLoadBalancerIP", "ServiceExternalIP", "ServiceClusterIP", "NodeIPAMPodCIDR"},
+							usage:           "Get advertised bgp routes of a specific type. Valid types are EgressIP, ServiceLoadBalancerIP, ServiceExternalIP, ServiceClusterIP, NodeIPAMPodCIDR or PodIP.",
+							supportedValues: []string{"EgressIP", "ServiceLoadBalancerIP", "ServiceExternalIP", "ServiceClusterIP", "NodeIPAMPodCIDR", "PodIP"},
 						},
 					},
 					outputType: multiple,
@@ -772,6 +773,12 @@ $ antctl get podmulticaststats pod -n namespace`,
 			supportAgent:      true,
 			supportController: true,
 		},
+		{
+			cobraCommand:      analyzebundle.Command,
+			supportAgent:      false,
+			supportController: false,
+			commandGroup:      analyze,
+		},
 		{
 			cobraCommand:      traceflow.Command,
 			supportAgent:      true,
@@ -71,6 +71,7 @@ const (
 	mc
 	upgrade
 	check
+	analyze
 )
 
 var groupCommands = map[commandGroup]*cobra.Command{
@@ -98,6 +99,10 @@ var groupCommands = map[commandGroup]*cobra.Command{
 		Use:   "check",
 		Short: "Performs pre and post installation checks",
 	},
+	analyze: {
+		Use:   "analyze",
+		Short: "Analyze collected data for known problems",
+	},
 }
 
 type endpointResponder interface {
@@ -0,0 +1,372 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	agentStartMessage = "Starting Antrea Agent"
+)
+
+var (
+	// The files whose content is required by the checks. Other files are skipped when loading a bundle.
+	analyzedFiles = map[string]bool{
+		"agentinfo":      true,
+		"clusterinfo":    true,
+		"controllerinfo": true,
+		"featuregates":   true,
+		"flows":          true,
+		"podinterfaces":  true,
+		"route":          true,
+	}
+	// klogHeaderRegex matches the header of klog lines, e.g. "I0817 06:55:10.123456".
+	klogHeaderRegex = regexp.MustCompile(`^[IWEF](\d{4} \d{2}:\d{2}:\d{2})`)
+	// klogFileTimeRegex matches the creation time in the names of klog files, e.g.
+	// "antrea-agent.node-1.root.log.INFO.20230817-065510.1".
+	klogFileTimeRegex = regexp.MustCompile(`\.(\d{4})\d{4}-\d{6}\.`)
+)
+
+// agentInfo is the subset of the antrea-agent information dumped in the "agentinfo" file which is used by the checks.
+// The fields are matched case-insensitively, so it can be decoded from both the lowercase keys written by the
+// antrea-agent and the output of "antctl get agentinfo".
+type agentInfo struct {
+	NodeRef struct {
+		Name string `json:"name"`
+	} `json:"nodeRef"`
+	NodeSubnets []string `json:"nodeSubnets"`
+}
+
+// podInterface is the Pod interface information dumped in the "podinterfaces" file.
+type podInterface struct {
+	PodName       string   `json:"podName"`
+	PodNamespace  string   `json:"podNamespace"`
+	InterfaceName string   `json:"interfaceName"`
+	IPs           []string `json:"ips"`
+	OFPort        int32    `json:"ofPort"`
+}
+
+type agentBundle struct {
+	name          string
+	dir           string
+	info          agentInfo
+	featureGates  map[string]bool
+	podInterfaces []podInterface
+	flows         []byte
+	routes        []byte
+	// starts holds the times the antrea-agent was started, as recorded in its logs, in ascending order.
+	starts []time.Time
+}
+
+type controllerBundle struct {
+	dir          string
+	featureGates map[string]bool
+}
+
+type clusterInfo struct {
+	pods  []corev1.Pod
+	nodes []corev1.Node
+}
+
+// supportBundle holds the content of a support bundle which is analyzed by the checks.
+type supportBundle struct {
+	agents     []*agentBundle
+	controller *controllerBundle
+	cluster    *clusterInfo
+	// certificates holds the content of the files which may contain PEM-encoded certificates, indexed by path.
+	certificates map[string][]byte
+}
+
+// bundleLoader reads the files of a support bundle. The archives of the components found in the bundle are expanded
+// in memory, and their files are recorded under the path of the archive without its extension.
+type bundleLoader struct {
+	files map[string][]byte
+	// startLines holds the lines of the antrea-agent logs which record a start of the antrea-agent, indexed by path.
+	startLines map[string][]string
+}
+
+func isArchive(name string) bool {
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz")
+}
+
+func trimArchiveExt(name string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(name, ".tar.gz"), ".tgz")
+}
+
+func isAgentLog(filePath string) bool {
+	return strings.Contains(filePath, "logs/agent/") && strings.HasPrefix(path.Base(filePath), "antrea-agent")
+}
+
+// loadBundle loads the support bundle located at bundlePath, which can be either the directory created by "antctl
+// supportbundle" or a gzipped tarball, such as the files uploaded for a SupportBundleCollection.
+func loadBundle(fs afero.Fs, bundlePath string) (*supportBundle, error) {
+	info, err := fs.Stat(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	l := &bundleLoader{
+		files:      make(map[string][]byte),
+		startLines: make(map[string][]string),
+	}
+	if info.IsDir() {
+		err = afero.Walk(fs, bundlePath, func(filePath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			relPath, err := filepath.Rel(bundlePath, filePath)
+			if err != nil {
+				return err
+			}
+			f, err := fs.Open(filePath)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			return l.loadFile(filepath.ToSlash(relPath), f)
+		})
+	} else {
+		var f afero.File
+		if f, err = fs.Open(bundlePath); err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		err = l.loadArchive("", f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error when reading support bundle %s: %w", bundlePath, err)
+	}
+	return l.build()
+}
+
+func (l *bundleLoader) loadArchive(prefix string, r io.Reader) error {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := l.loadFile(path.Join(prefix, path.Clean(header.Name)), tarReader); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *bundleLoader) loadFile(filePath string, r io.Reader) error {
+	name := path.Base(filePath)
+	switch {
+	case isArchive(name):
+		if err := l.loadArchive(trimArchiveExt(filePath), r); err != nil {
+			return fmt.Errorf("error when reading archive %s: %w", filePath, err)
+		}
+	case analyzedFiles[name], path.Ext(name) == ".crt", path.Ext(name) == ".pem":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		l.files[filePath] = data
+	case isAgentLog(filePath):
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if line := scanner.Text(); strings.Contains(line, agentStartMessage) {
+				l.startLines[filePath] = append(l.startLines[filePath], line)
+			}
+		}
+		return scanner.Err()
+	}
+	return nil
+}
+
+// build groups the loaded files by component. The root directory of an antrea-agent is the one including the
+// "agentinfo" file, and the root directory of the antrea-controller is the one including the "controllerinfo" file.
+func (l *bundleLoader) build() (*supportBundle, error) {
+	b := &supportBundle{certificates: make(map[string][]byte)}
+	for filePath, data := range l.files {
+		dir, name := path.Split(filePath)
+		dir = path.Clean(dir)
+		switch name {
+		case "agentinfo":
+			agent := &agentBundle{dir: dir}
+			if err := yaml.Unmarshal(data, &agent.info); err != nil {
+				return nil, fmt.Errorf("error when parsing %s: %w", filePath, err)
+			}
+			agent.name = agent.info.NodeRef.Name
+			if agent.name == "" {
+				agent.name = path.Base(dir)
+			}
+			b.agents = append(b.agents, agent)
+		case "controllerinfo":
+			b.controller = &controllerBundle{dir: dir}
+		case "clusterinfo":
+			cluster, err := parseClusterInfo(data)
+			if err != nil {
+				return nil, fmt.Errorf("error when parsing %s: %w", filePath, err)
+			}
+			b.cluster = cluster
+			b.certificates[filePath] = data
+		default:
+			if ext := path.Ext(name); ext == ".crt" || ext == ".pem" {
+				b.certificates[filePath] = data
+			}
+		}
+	}
+	sort.Slice(b.agents, func(i, j int) bool {
+		return b.agents[i].name < b.agents[j].name
+	})
+
+	for _, agent := range b.agents {
+		if data, ok := l.files[path.Join(agent.dir, "featuregates")]; ok {
+			if err := yaml.Unmarshal(data, &agent.featureGates); err != nil {
+				return nil, fmt.Errorf("error when parsing feature gates of antrea-agent %s: %w", agent.name, err)
+			}
+		}
+		if data, ok := l.files[path.Join(agent.dir, "podinterfaces")]; ok {
+			if err := yaml.Unmarshal(data, &agent.podInterfaces); err != nil {
+				return nil, fmt.Errorf("error when parsing Pod interfaces of antrea-agent %s: %w", agent.name, err)
+			}
+		}
+		agent.flows = l.files[path.Join(agent.dir, "flows")]
+		agent.routes = l.files[path.Join(agent.dir, "route")]
+		agent.starts = l.getAgentStarts(agent.dir)
+	}
+	if b.controller != nil {
+		if data, ok := l.files[path.Join(b.controller.dir, "featuregates")]; ok {
+			if err := yaml.Unmarshal(data, &b.controller.featureGates); err != nil {
+				return nil, fmt.Errorf("error when parsing feature gates of antrea-controller: %w", err)
+			}
+		}
+	}
+	return b, nil
+}
+
+// getAgentStarts returns the distinct times at which the antrea-agent whose bundle is located in dir was started.
+// klog headers do not include the year, which is taken from the name of the log file when possible.
+func (l *bundleLoader) getAgentStarts(dir string) []time.Time {
+	startSet := make(map[time.Time]struct{})
+	for filePath, lines := range l.startLines {
+		if dir != "." && !strings.HasPrefix(filePath, dir+"/") {
+			continue
+		}
+		year := now().Year()
+		if matches := klogFileTimeRegex.FindStringSubmatch(path.Base(filePath)); matches != nil {
+			fmt.Sscanf(matches[1], "%d", &year)
+		}
+		for _, line := range lines {
+			matches := klogHeaderRegex.FindStringSubmatch(line)
+			if matches == nil {
+				continue
+			}
+			t, err := time.Parse("0102 15:04:05", matches[1])
+			if err != nil {
+				continue
+			}
+			startSet[t.AddDate(year-t.Year(), 0, 0)] = struct{}{}
+		}
+	}
+	starts := make([]time.Time, 0, len(startSet))
+	for t := range startSet {
+		starts = append(starts, t)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+	return starts
+}
+
+// parseClusterInfo parses the "clusterinfo" file written by "antctl supportbundle", which includes YAML documents
+// separated by "---", grouped in sections starting with a comment naming the resource, e.g. "#pods".
+func parseClusterInfo(data []byte) (*clusterInfo, error) {
+	cluster := &clusterInfo{}
+	var section string
+	var doc bytes.Buffer
+	flush := func() error {
+		defer doc.Reset()
+		if len(bytes.TrimSpace(doc.Bytes())) == 0 {
+			return nil
+		}
+		switch section {
+		case "pods":
+			var pod corev1.Pod
+			if err := yaml.Unmarshal(doc.Bytes(), &pod); err != nil {
+				return err
+			}
+			cluster.pods = append(cluster.pods, pod)
+		case "nodes":
+			var node corev1.Node
+			if err := yaml.Unmarshal(doc.Bytes(), &node); err != nil {
+				return err
+			}
+			cluster.nodes = append(cluster.nodes, node)
+		}
+		return nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "#"):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			section = strings.TrimPrefix(line, "#")
+		case line == "---":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		default:
+			doc.WriteString(line)
+			doc.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
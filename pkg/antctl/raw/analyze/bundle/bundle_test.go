@@ -0,0 +1,218 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"antrea.io/antrea/v2/pkg/util/compress"
+)
+
+const (
+	// The files written by the antrea-agent use the lowercase keys of the YAML encoder.
+	testAgentInfo = `noderef:
+  kind: Node
+  name: node-1
+nodesubnets:
+- 10.10.1.0/24
+`
+	testPodInterfaces = `- podname: pod-1
+  podnamespace: default
+  interfacename: pod-1-6b2a3c
+  ips:
+  - 10.10.1.5
+  mac: 0a:58:0a:0a:01:05
+  ofport: 5
+- podname: pod-2
+  podnamespace: default
+  interfacename: pod-2-d71ef4
+  ips:
+  - 10.10.1.6
+  ofport: 6
+`
+	testFlows = ` cookie=0x1010000000000, table=Classifier, n_packets=10, n_bytes=740, priority=190,in_port="pod-1-6b2a3c" actions=load:0x3->NXM_NX_REG0[0..3],goto_table:SpoofGuard
+ cookie=0x1010000000000, table=L3Forwarding, n_packets=0, n_bytes=0, priority=200,ip,reg0=0x200/0x200,nw_dst=10.10.1.5 actions=goto_table:L2ForwardingCalc
+ cookie=0x1010000000000, table=L3Forwarding, n_packets=0, n_bytes=0, priority=200,ip,reg0=0x200/0x200,nw_dst=10.10.1.6 actions=goto_table:L2ForwardingCalc`
+	testRoutes = `default via 172.18.0.1 dev eth0
+10.10.0.0/24 via 10.10.0.1 dev antrea-gw0 onlink
+10.10.1.0/24 dev antrea-gw0 proto kernel scope link src 10.10.1.1
+10.10.2.0/24 via 10.10.2.1 dev antrea-gw0 onlink
+10.96.0.0/12 via 169.254.0.253 dev antrea-gw0 onlink
+172.18.0.0/16 dev eth0 proto kernel scope link src 172.18.0.3`
+	testAgentFeatureGates = `AntreaPolicy: true
+Multicast: true
+NodeLatencyMonitor: false
+`
+	testAgentLog = `I0101 10:00:00.000000       1 agent.go:116] "Starting Antrea Agent" version="v2.5.0"
+I0101 10:00:01.000000       1 agent.go:120] "Initializing"
+I0101 10:10:00.000000       1 agent.go:116] "Starting Antrea Agent" version="v2.5.0"
+I0101 10:20:00.000000       1 agent.go:116] "Starting Antrea Agent" version="v2.5.0"
+`
+	testControllerFeatureGates = `AntreaPolicy: true
+Multicast: false
+Multicluster: false
+`
+	testClusterInfo = `#pods
+metadata:
+  labels:
+    app: antrea
+    component: antrea-agent
+  name: antrea-agent-x7k2p
+  namespace: kube-system
+spec:
+  containers: null
+  nodeName: node-1
+status:
+  containerStatuses:
+  - image: antrea/antrea-agent-ubuntu:latest
+    imageID: ""
+    name: antrea-agent
+    ready: false
+    restartCount: 7
+    state:
+      waiting:
+        reason: CrashLoopBackOff
+  - image: antrea/antrea-agent-ubuntu:latest
+    imageID: ""
+    name: antrea-ovs
+    ready: true
+    restartCount: 0
+    state:
+      running: {}
+---
+#nodes
+metadata:
+  name: node-0
+spec:
+  podCIDR: 10.10.0.0/24
+  podCIDRs:
+  - 10.10.0.0/24
+status: {}
+---
+metadata:
+  name: node-1
+spec:
+  podCIDR: 10.10.1.0/24
+  podCIDRs:
+  - 10.10.1.0/24
+status: {}
+---
+#configs
+`
+)
+
+// writeTestBundle writes the files of a bundle as created by "antctl supportbundle" in dir: the clusterinfo file, and
+// the archives of the controller and of the antrea-agent of node-1.
+func writeTestBundle(t *testing.T, fs afero.Fs, dir string) {
+	writeArchive := func(name string, files map[string]string) {
+		tmpDir := filepath.Join("/tmp", name)
+		for filePath, content := range files {
+			require.NoError(t, fs.MkdirAll(filepath.Dir(filepath.Join(tmpDir, filePath)), 0755))
+			require.NoError(t, afero.WriteFile(fs, filepath.Join(tmpDir, filePath), []byte(content), 0644))
+		}
+		f, err := fs.Create(filepath.Join(dir, name))
+		require.NoError(t, err)
+		defer f.Close()
+		_, err = compress.PackDir(fs, tmpDir, f)
+		require.NoError(t, err)
+	}
+	require.NoError(t, fs.MkdirAll(dir, 0755))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "clusterinfo"), []byte(testClusterInfo), 0644))
+	writeArchive("agent_node-1.tar.gz", map[string]string{
+		"agentinfo":     testAgentInfo,
+		"podinterfaces": testPodInterfaces,
+		"flows":         testFlows,
+		"route":         testRoutes,
+		"featuregates":  testAgentFeatureGates,
+		"logs/agent/antrea-agent.node-1.root.log.INFO.20250101-100000.1": testAgentLog,
+		"logs/ovs/ovs-vswitchd.log":                                      "2025-01-01T10:00:00.000Z|00001|vlog|INFO|Starting Antrea Agent",
+	})
+	writeArchive("controller.tar.gz", map[string]string{
+		"controllerinfo": "name: antrea-controller\n",
+		"featuregates":   testControllerFeatureGates,
+	})
+}
+
+func TestLoadBundle(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeTestBundle(t, fs, "/bundle")
+
+	b, err := loadBundle(fs, "/bundle")
+	require.NoError(t, err)
+	require.Len(t, b.agents, 1)
+	agent := b.agents[0]
+	assert.Equal(t, "node-1", agent.name)
+	assert.Equal(t, "agent_node-1", agent.dir)
+	assert.Equal(t, []string{"10.10.1.0/24"}, agent.info.NodeSubnets)
+	assert.Equal(t, map[string]bool{"AntreaPolicy": true, "Multicast": true, "NodeLatencyMonitor": false}, agent.featureGates)
+	assert.Equal(t, []podInterface{
+		{PodName: "pod-1", PodNamespace: "default", InterfaceName: "pod-1-6b2a3c", IPs: []string{"10.10.1.5"}, OFPort: 5},
+		{PodName: "pod-2", PodNamespace: "default", InterfaceName: "pod-2-d71ef4", IPs: []string{"10.10.1.6"}, OFPort: 6},
+	}, agent.podInterfaces)
+	assert.Equal(t, testFlows, string(agent.flows))
+	assert.Equal(t, testRoutes, string(agent.routes))
+	// The OVS logs are ignored, and the year is taken from the name of the log file.
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 10, 10, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 10, 20, 0, 0, time.UTC),
+	}, agent.starts)
+
+	require.NotNil(t, b.controller)
+	assert.Equal(t, "controller", b.controller.dir)
+	assert.Equal(t, map[string]bool{"AntreaPolicy": true, "Multicast": false, "Multicluster": false}, b.controller.featureGates)
+
+	require.NotNil(t, b.cluster)
+	require.Len(t, b.cluster.pods, 1)
+	assert.Equal(t, "antrea-agent-x7k2p", b.cluster.pods[0].Name)
+	require.Len(t, b.cluster.nodes, 2)
+	assert.Equal(t, []string{"10.10.1.0/24"}, b.cluster.nodes[1].Spec.PodCIDRs)
+	assert.Contains(t, b.certificates, "clusterinfo")
+}
+
+func TestLoadBundleArchive(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeTestBundle(t, fs, "/bundle")
+	f, err := fs.Create("/bundle.tar.gz")
+	require.NoError(t, err)
+	_, err = compress.PackDir(fs, "/bundle", f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	b, err := loadBundle(fs, "/bundle.tar.gz")
+	require.NoError(t, err)
+	require.Len(t, b.agents, 1)
+	assert.Equal(t, "node-1", b.agents[0].name)
+	assert.Len(t, b.agents[0].starts, 3)
+	require.NotNil(t, b.controller)
+	require.NotNil(t, b.cluster)
+	assert.Len(t, b.cluster.nodes, 2)
+}
+
+func TestLoadBundleError(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := loadBundle(fs, "/bundle")
+	assert.Error(t, err)
+
+	require.NoError(t, afero.WriteFile(fs, "/bundle.tar.gz", []byte("not an archive"), 0644))
+	_, err = loadBundle(fs, "/bundle.tar.gz")
+	assert.ErrorContains(t, err, "error when reading support bundle /bundle.tar.gz")
+}
@@ -0,0 +1,410 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "Error"
+	SeverityWarning Severity = "Warning"
+)
+
+const (
+	checkMissingPodFlows     = "MissingPodFlows"
+	checkStaleRoute          = "StaleRoute"
+	checkAgentRestartLoop    = "AgentRestartLoop"
+	checkFeatureGateMismatch = "FeatureGateMismatch"
+	checkCertificateExpiry   = "CertificateExpiry"
+
+	componentController = "antrea-controller"
+
+	gatewayInterface = "antrea-gw0"
+
+	// An antrea-agent restarted at least restartThreshold times, or started at least restartThreshold times within
+	// restartWindow according to its logs, is considered to be in a restart loop.
+	restartThreshold = 3
+	restartWindow    = time.Hour
+
+	certificateExpiryWarning = 30 * 24 * time.Hour
+)
+
+var (
+	// Declared as a variable for testing.
+	now = time.Now
+
+	inPortRegex = regexp.MustCompile(`in_port=("[^"]*"|[^,\s]+)`)
+	ipDstRegex  = regexp.MustCompile(`(?:nw_dst|ipv6_dst)=([^,\s]+)`)
+)
+
+// Finding is a problem found in a support bundle.
+type Finding struct {
+	Check     string   `json:"check"`
+	Severity  Severity `json:"severity"`
+	Component string   `json:"component"`
+	Message   string   `json:"message"`
+}
+
+func (f Finding) GetTableHeader() []string {
+	return []string{"CHECK", "SEVERITY", "COMPONENT", "MESSAGE"}
+}
+
+func (f Finding) GetTableRow(_ int) []string {
+	return []string{f.Check, string(f.Severity), f.Component, f.Message}
+}
+
+func (f Finding) SortRows() bool {
+	// Findings are already sorted by severity.
+	return false
+}
+
+type check func(b *supportBundle) []Finding
+
+var checks = []check{
+	checkPodFlows,
+	checkRoutes,
+	checkAgentRestarts,
+	checkFeatureGates,
+	checkCertificates,
+}
+
+// analyze runs all the checks against the support bundle, and returns the findings sorted by severity, check and
+// component.
+func analyze(b *supportBundle) []Finding {
+	findings := []Finding{}
+	for _, c := range checks {
+		findings = append(findings, c(b)...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity == SeverityError
+		}
+		if findings[i].Check != findings[j].Check {
+			return findings[i].Check < findings[j].Check
+		}
+		return findings[i].Component < findings[j].Component
+	})
+	return findings
+}
+
+func agentComponent(nodeName string) string {
+	return "antrea-agent/" + nodeName
+}
+
+// checkPodFlows reports the Pods of the interface store of an antrea-agent for which the OVS flows do not include
+// any flow matching the OpenFlow port of the Pod, or any flow forwarding traffic to the IPs of the Pod.
+func checkPodFlows(b *supportBundle) []Finding {
+	var findings []Finding
+	for _, agent := range b.agents {
+		if len(agent.flows) == 0 || len(agent.podInterfaces) == 0 {
+			continue
+		}
+		inPorts := make(map[string]bool)
+		for _, match := range inPortRegex.FindAllSubmatch(agent.flows, -1) {
+			inPorts[strings.Trim(string(match[1]), `"`)] = true
+		}
+		ipDsts := make(map[string]bool)
+		for _, match := range ipDstRegex.FindAllSubmatch(agent.flows, -1) {
+			ipDsts[string(match[1])] = true
+		}
+		for _, iface := range agent.podInterfaces {
+			if iface.OFPort <= 0 {
+				continue
+			}
+			pod := iface.PodNamespace + "/" + iface.PodName
+			if !inPorts[iface.InterfaceName] && !inPorts[fmt.Sprint(iface.OFPort)] {
+				findings = append(findings, Finding{
+					Check:     checkMissingPodFlows,
+					Severity:  SeverityError,
+					Component: agentComponent(agent.name),
+					Message:   fmt.Sprintf("No OVS flow matches traffic from Pod %s (interface %s, ofport %d)", pod, iface.InterfaceName, iface.OFPort),
+				})
+			}
+			for _, ip := range iface.IPs {
+				if !ipDsts[ip] {
+					findings = append(findings, Finding{
+						Check:     checkMissingPodFlows,
+						Severity:  SeverityError,
+						Component: agentComponent(agent.name),
+						Message:   fmt.Sprintf("No OVS flow forwards traffic to IP %s of Pod %s", ip, pod),
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// checkRoutes reports the routes through the Antrea gateway which do not match the Pod CIDR of any Node. The check
+// requires the Nodes of the cluster, so it is skipped when the bundle does not include them.
+func checkRoutes(b *supportBundle) []Finding {
+	if b.cluster == nil || len(b.cluster.nodes) == 0 {
+		return nil
+	}
+	var podCIDRs []netip.Prefix
+	addPodCIDR := func(cidr string) {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil {
+			podCIDRs = append(podCIDRs, prefix.Masked())
+		}
+	}
+	for _, node := range b.cluster.nodes {
+		for _, cidr := range node.Spec.PodCIDRs {
+			addPodCIDR(cidr)
+		}
+		addPodCIDR(node.Spec.PodCIDR)
+	}
+	for _, agent := range b.agents {
+		for _, cidr := range agent.info.NodeSubnets {
+			addPodCIDR(cidr)
+		}
+	}
+	isPodCIDR := func(dst netip.Prefix) bool {
+		for _, cidr := range podCIDRs {
+			if cidr.Bits() <= dst.Bits() && cidr.Contains(dst.Addr()) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var findings []Finding
+	for _, agent := range b.agents {
+		scanner := bufio.NewScanner(bytes.NewReader(agent.routes))
+		for scanner.Scan() {
+			route := strings.TrimSpace(scanner.Text())
+			dst, ok := parseGatewayRoute(route)
+			if !ok || isPodCIDR(dst) {
+				continue
+			}
+			findings = append(findings, Finding{
+				Check:     checkStaleRoute,
+				Severity:  SeverityWarning,
+				Component: agentComponent(agent.name),
+				Message:   fmt.Sprintf("Route %q does not match the Pod CIDR of any Node", route),
+			})
+		}
+	}
+	return findings
+}
+
+// parseGatewayRoute returns the destination of a route of the "ip route" output if the route goes through the
+// Antrea gateway and may point to the Pod CIDR of a Node. Default routes and the routes through link-local addresses,
+// such as the routes for Services, are ignored.
+func parseGatewayRoute(route string) (netip.Prefix, bool) {
+	fields := strings.Fields(route)
+	if len(fields) == 0 || fields[0] == "default" {
+		return netip.Prefix{}, false
+	}
+	var dev, via string
+	for i := 1; i < len(fields)-1; i++ {
+		switch fields[i] {
+		case "dev":
+			dev = fields[i+1]
+		case "via":
+			via = fields[i+1]
+		}
+	}
+	if dev != gatewayInterface {
+		return netip.Prefix{}, false
+	}
+	if via != "" {
+		if viaAddr, err := netip.ParseAddr(via); err != nil || viaAddr.IsLinkLocalUnicast() {
+			return netip.Prefix{}, false
+		}
+	}
+	dst, err := netip.ParsePrefix(fields[0])
+	if err != nil {
+		addr, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			return netip.Prefix{}, false
+		}
+		dst = netip.PrefixFrom(addr, addr.BitLen())
+	}
+	if dst.Addr().IsLinkLocalUnicast() {
+		return netip.Prefix{}, false
+	}
+	return dst, true
+}
+
+// checkAgentRestarts reports the antrea-agent Pods whose containers are restarted repeatedly, according to the Pods
+// of the cluster and to the start messages of the antrea-agent logs.
+func checkAgentRestarts(b *supportBundle) []Finding {
+	var findings []Finding
+	if b.cluster != nil {
+		for _, pod := range b.cluster.pods {
+			if pod.Labels["component"] != "antrea-agent" {
+				continue
+			}
+			for _, status := range pod.Status.ContainerStatuses {
+				if waiting := status.State.Waiting; waiting != nil && waiting.Reason == "CrashLoopBackOff" {
+					findings = append(findings, Finding{
+						Check:     checkAgentRestartLoop,
+						Severity:  SeverityError,
+						Component: agentComponent(pod.Spec.NodeName),
+						Message:   fmt.Sprintf("Container %s of Pod %s/%s is in CrashLoopBackOff after %d restarts", status.Name, pod.Namespace, pod.Name, status.RestartCount),
+					})
+				} else if status.RestartCount >= restartThreshold {
+					findings = append(findings, Finding{
+						Check:     checkAgentRestartLoop,
+						Severity:  SeverityWarning,
+						Component: agentComponent(pod.Spec.NodeName),
+						Message:   fmt.Sprintf("Container %s of Pod %s/%s has restarted %d times", status.Name, pod.Namespace, pod.Name, status.RestartCount),
+					})
+				}
+			}
+		}
+	}
+	for _, agent := range b.agents {
+		// Find the largest number of starts within restartWindow.
+		maxStarts, lastStart := 0, time.Time{}
+		for i, j := 0, 0; j < len(agent.starts); j++ {
+			for agent.starts[j].Sub(agent.starts[i]) > restartWindow {
+				i++
+			}
+			if j-i+1 > maxStarts {
+				maxStarts, lastStart = j-i+1, agent.starts[j]
+			}
+		}
+		if maxStarts >= restartThreshold {
+			findings = append(findings, Finding{
+				Check:     checkAgentRestartLoop,
+				Severity:  SeverityWarning,
+				Component: agentComponent(agent.name),
+				Message:   fmt.Sprintf("antrea-agent started %d times within %v according to its logs, last time at %s", maxStarts, restartWindow, lastStart.Format(time.DateTime)),
+			})
+		}
+	}
+	return findings
+}
+
+// checkFeatureGates reports the feature gates whose status differs between the antrea-controller and the
+// antrea-agents. The gates which are only supported by one of the components are ignored.
+func checkFeatureGates(b *supportBundle) []Finding {
+	if b.controller == nil || b.controller.featureGates == nil {
+		return nil
+	}
+	// The names of the antrea-agents for each mismatched feature gate.
+	mismatches := make(map[string][]string)
+	for _, agent := range b.agents {
+		for gate, enabled := range agent.featureGates {
+			if controllerEnabled, ok := b.controller.featureGates[gate]; ok && controllerEnabled != enabled {
+				mismatches[gate] = append(mismatches[gate], agent.name)
+			}
+		}
+	}
+	var findings []Finding
+	for gate, agents := range mismatches {
+		status := func(enabled bool) string {
+			if enabled {
+				return "enabled"
+			}
+			return "disabled"
+		}
+		controllerEnabled := b.controller.featureGates[gate]
+		findings = append(findings, Finding{
+			Check:     checkFeatureGateMismatch,
+			Severity:  SeverityWarning,
+			Component: componentController,
+			Message: fmt.Sprintf("Feature gate %s is %s in antrea-controller but %s in antrea-agents: %s",
+				gate, status(controllerEnabled), status(!controllerEnabled), strings.Join(agents, ", ")),
+		})
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Message < findings[j].Message
+	})
+	return findings
+}
+
+// checkCertificates reports the certificates included in the bundle which have expired or will expire soon. The
+// certificates may be embedded in YAML files, such as the antrea-ca ConfigMap of the "clusterinfo" file.
+func checkCertificates(b *supportBundle) []Finding {
+	var findings []Finding
+	paths := make([]string, 0, len(b.certificates))
+	for filePath := range b.certificates {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+	seen := make(map[string]bool)
+	currentTime := now()
+	for _, filePath := range paths {
+		for _, cert := range parseCertificates(b.certificates[filePath]) {
+			key := cert.Issuer.String() + "/" + cert.SerialNumber.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			var severity Severity
+			var message string
+			if currentTime.After(cert.NotAfter) {
+				severity = SeverityError
+				message = fmt.Sprintf("Certificate %q expired at %s", cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+			} else if cert.NotAfter.Sub(currentTime) < certificateExpiryWarning {
+				severity = SeverityWarning
+				message = fmt.Sprintf("Certificate %q expires at %s", cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+			} else {
+				continue
+			}
+			findings = append(findings, Finding{
+				Check:     checkCertificateExpiry,
+				Severity:  severity,
+				Component: filePath,
+				Message:   message,
+			})
+		}
+	}
+	return findings
+}
+
+// parseCertificates returns the certificates found in the PEM blocks of data. The lines are unindented first, so that
+// the PEM blocks embedded in YAML block scalars can be decoded.
+func parseCertificates(data []byte) []*x509.Certificate {
+	if !bytes.Contains(data, []byte("-----BEGIN CERTIFICATE-----")) {
+		return nil
+	}
+	var unindented bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		unindented.Write(bytes.TrimSpace(scanner.Bytes()))
+		unindented.WriteByte('\n')
+	}
+	var certs []*x509.Certificate
+	rest := unindented.Bytes()
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
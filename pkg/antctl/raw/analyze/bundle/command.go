@@ -0,0 +1,88 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	antctlOutput "antrea.io/antrea/v2/pkg/antctl/output"
+)
+
+var Command *cobra.Command
+
+// Declared as a variable for testing.
+var defaultFS = afero.NewOsFs()
+
+var option = &struct {
+	outputFormat string
+}{}
+
+var longDescription = strings.TrimSpace(`
+Analyze a support bundle and report known problems. The bundle can be either the directory created by "antctl supportbundle", or a gzipped tarball, such as the bundles uploaded for a SupportBundleCollection. The following checks are run:
+  - MissingPodFlows: the OVS flows of an Antrea agent do not match the OpenFlow port or the IPs of a Pod of its interface store
+  - StaleRoute: a route through the Antrea gateway does not match the Pod CIDR of any Node
+  - AgentRestartLoop: an Antrea agent is in CrashLoopBackOff, or has restarted repeatedly
+  - FeatureGateMismatch: a feature gate has a different status in the Antrea controller and in some Antrea agents
+  - CertificateExpiry: a certificate of the bundle has expired or will expire within 30 days
+`)
+
+var example = strings.Trim(`
+  Analyze the support bundles collected with "antctl supportbundle" in a directory
+  $ antctl analyze bundle ~/support-bundles_20260101T000000Z
+  Analyze a support bundle uploaded for a SupportBundleCollection and print the findings in JSON format
+  $ antctl analyze bundle ./node-1_sbc-1.tar.gz -o json
+`, "\n")
+
+func init() {
+	Command = &cobra.Command{
+		Use:     "bundle <dir|tgz>",
+		Short:   "Analyze a support bundle",
+		Long:    longDescription,
+		Example: example,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runE,
+	}
+	Command.Flags().StringVarP(&option.outputFormat, "output", "o", "", "Output format. Supported formats: json|yaml")
+}
+
+func runE(cmd *cobra.Command, args []string) error {
+	b, err := loadBundle(defaultFS, args[0])
+	if err != nil {
+		return err
+	}
+	return output(analyze(b), option.outputFormat, cmd.OutOrStdout())
+}
+
+func output(findings []Finding, outputFormat string, writer io.Writer) error {
+	switch outputFormat {
+	case "json":
+		return antctlOutput.JsonOutput(findings, writer)
+	case "yaml":
+		return antctlOutput.YamlOutput(findings, writer)
+	case "":
+		if len(findings) == 0 {
+			_, err := fmt.Fprintln(writer, "No problem found in the support bundle")
+			return err
+		}
+		return antctlOutput.TableOutputForGetCommands(findings, writer)
+	default:
+		return fmt.Errorf("unsupported output format %q", outputFormat)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"os"
 	"path"
 	"strconv"
 	"strings"
@@ -29,6 +30,7 @@ import (
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -48,12 +50,17 @@ var (
 	maxPacketCaptureTimeout = time.Second * 300
 	Command                 *cobra.Command
 	getCopier               = getPodFileCopier
+	getAgentClient          = createAgentClient
 	defaultFS               = afero.NewOsFs()
 )
 
 type packetCaptureOptions struct {
 	source       string
 	dest         string
+	service      string
+	node         string
+	nodeSelector string
+	port         string
 	nowait       bool
 	timeout      time.Duration
 	number       int32
@@ -61,6 +68,7 @@ type packetCaptureOptions struct {
 	outputDir    string
 	capturePoint string
 	direction    string
+	insecure     bool
 }
 
 var options = &packetCaptureOptions{}
@@ -71,6 +79,8 @@ var packetCaptureExample = `  Start capturing packets from pod1 to pod2, both Po
   $ antctl packetcapture -S ns1/pod1 -D 192.168.123.123
   Start capturing packets from pod1 to pod2, captures at dst pod
   $ antctl packetcapture -S pod1 -D pod2 -p Destination
+  Start capturing packets from pod1 to pod2, captures at both Pods and merges the packets files of their Nodes
+  $ antctl packetcapture -S pod1 -D pod2 -p Both
   Start capturing TCP FIN packets from pod1 to pod2, with destination port 80
   $ antctl packetcapture -S pod1 -D pod2 -f tcp,tcp_dst=80,tcp_flags=+fin
   Start capturing TCP SYNs that are not ACKs from pod1 to pod2, with destination port 80
@@ -89,6 +99,14 @@ var packetCaptureExample = `  Start capturing packets from pod1 to pod2, both Po
   $ antctl packetcapture -S pod1 -D pod2 -f icmpv6,icmpv6_type=icmpv6-unreach,icmpv6_code=1
   Start capturing ICMPv6 echo reply packets from pod1 to pod2
   $ antctl packetcapture -S pod1 -D pod2 -f icmpv6,icmpv6_type=129
+  Start capturing packets from pod1 to Service svc1 in Namespace ns1, before they are load-balanced to the Service Endpoints
+  $ antctl packetcapture -S pod1 --service ns1/svc1
+  Start capturing all packets going through the gateway port of node1
+  $ antctl packetcapture --node node1 --port Gateway
+  Start capturing packets from pod1 going through the tunnel port of node1
+  $ antctl packetcapture -S pod1 --node node1 --port Tunnel
+  Start capturing packets to pod2 going through the uplink of all the Nodes with label zone=a, and merge the packets files
+  $ antctl packetcapture -D pod2 --node-selector zone=a --port Uplink
   Save the packets file to a specified directory
   $ antctl packetcapture -S 192.168.123.123 -D pod2 -f tcp,tcp_dst=80 -o /tmp
 `
@@ -105,12 +123,17 @@ func init() {
 
 	Command.Flags().StringVarP(&options.source, "source", "S", "", "source of the the PacketCapture: Namespace/Pod, Pod, or IP")
 	Command.Flags().StringVarP(&options.dest, "destination", "D", "", "destination of the PacketCapture: Namespace/Pod, Pod, or IP")
+	Command.Flags().StringVar(&options.service, "service", "", "destination Service of the PacketCapture: Namespace/Service or Service, exclusive with --destination")
+	Command.Flags().StringVar(&options.node, "node", "", "capture packets on a port of this Node instead of the interface of the source or destination Pod")
+	Command.Flags().StringVar(&options.nodeSelector, "node-selector", "", "capture packets on a port of the Nodes matching this label selector, exclusive with --node")
+	Command.Flags().StringVar(&options.port, "port", "", "port of the Nodes specified by --node or --node-selector on which packets are captured: Gateway, Tunnel, Uplink, or the name of an OVS port")
 	Command.Flags().Int32VarP(&options.number, "number", "n", 1, "target number of packets to capture, the capture will stop when it is reached")
 	Command.Flags().StringVarP(&options.flow, "flow", "f", "", "specify the flow (packet headers) of the PacketCapture, including tcp_src, tcp_dst, tcp_flags, udp_src, udp_dst, icmp_type, icmp_code")
-	Command.Flags().StringVarP(&options.capturePoint, "capture-point", "p", "", "specify where the packet capture should be performed: Source or Destination")
+	Command.Flags().StringVarP(&options.capturePoint, "capture-point", "p", "", "specify where the packet capture should be performed: Source, Destination or Both")
 	Command.Flags().BoolVarP(&options.nowait, "nowait", "", false, "if set, command returns without retrieving results")
 	Command.Flags().StringVarP(&options.outputDir, "output-dir", "o", ".", "save the packets file to the target directory")
 	Command.Flags().StringVarP(&options.direction, "direction", "d", "SourceToDestination", "direction of the traffic to capture: SourceToDestination, DestinationToSource, or Both")
+	Command.Flags().BoolVar(&options.insecure, "insecure", false, "Skip TLS verification when downloading the packets file from the Antrea Agent API")
 }
 
 var protocols = map[string]int32{
@@ -135,6 +158,19 @@ func getPodFileCopier(config *rest.Config, client kubernetes.Interface) raw.PodF
 	return raw.NewPodFileCopier(config, client)
 }
 
+func createAgentClient(ctx context.Context, restConfig *rest.Config, k8sClient kubernetes.Interface, antreaClient antrea.Interface, nodeName string, insecure bool) (rest.Interface, error) {
+	agentClientCfg, err := raw.CreateAgentClientCfg(ctx, k8sClient, antreaClient, restConfig, nodeName, insecure)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating agent client config: %w", err)
+	}
+	agentClientCfg.GroupVersion = &schema.GroupVersion{Group: "", Version: ""}
+	agentClient, err := rest.RESTClientFor(agentClientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating agent client: %w", err)
+	}
+	return agentClient, nil
+}
+
 func getConfigAndClients(cmd *cobra.Command) (*rest.Config, kubernetes.Interface, antrea.Interface, error) {
 	kubeConfig, err := raw.ResolveKubeconfig(cmd)
 	if err != nil {
@@ -158,6 +194,16 @@ func getPCName(options *packetCaptureOptions) string {
 	if options.dest != "" {
 		parts = append(parts, replace(options.dest))
 	}
+	if options.service != "" {
+		parts = append(parts, replace(options.service))
+	}
+	if options.node != "" {
+		parts = append(parts, options.node, strings.ToLower(options.port))
+	}
+	// Label selectors can't be part of a resource name.
+	if options.nodeSelector != "" {
+		parts = append(parts, "nodes", strings.ToLower(options.port))
+	}
 	prefix := strings.Join(parts, "-")
 	if options.nowait {
 		return prefix
@@ -236,13 +282,111 @@ func packetCaptureRun(ctx context.Context, out io.Writer, restConfig *rest.Confi
 		return fmt.Errorf("error when checking PacketCapture status: %w", err)
 	}
 
-	splits := strings.Split(latestPC.Status.FilePath, ":")
-	fileName := path.Base(splits[1])
-	copier := getCopier(restConfig, k8sClient)
-	if err := copier.CopyFromPod(ctx, defaultFS, env.GetAntreaNamespace(), splits[0], "antrea-agent", splits[1], options.outputDir); err != nil {
-		return fmt.Errorf("error when copying pcapng file from container: %w", err)
+	var dstPath string
+	if len(latestPC.Status.Nodes) > 0 {
+		dstPath, err = fetchAndMergePacketsFiles(ctx, out, restConfig, k8sClient, antreaClient, latestPC, options.outputDir, options.insecure)
+	} else {
+		dstPath, err = fetchPacketsFile(ctx, out, restConfig, k8sClient, antreaClient, latestPC.Name, latestPC.Status.FilePath, options.outputDir, options.insecure)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Captured packets file: %s\n", dstPath)
+	return nil
+}
+
+// fetchPacketsFile saves the packets file of a PacketCapture at filePath, formatted as <antrea-agent-pod-name>:<path>,
+// to dstDir, and returns the path of the saved file.
+func fetchPacketsFile(ctx context.Context, out io.Writer, restConfig *rest.Config, k8sClient kubernetes.Interface, antreaClient antrea.Interface, pcName, filePath, dstDir string, insecure bool) (string, error) {
+	splits := strings.Split(filePath, ":")
+	dstPath := path.Join(dstDir, path.Base(splits[1]))
+	if err := downloadPacketsFile(ctx, restConfig, k8sClient, antreaClient, pcName, splits[0], dstPath, insecure); err != nil {
+		// Fall back to copying the file out of the antrea-agent container, which works with the Agents that don't
+		// serve packets files.
+		fmt.Fprintf(out, "Failed to download packets file from Antrea Agent API, copying it from the antrea-agent container: %v\n", err)
+		copier := getCopier(restConfig, k8sClient)
+		if err := copier.CopyFromPod(ctx, defaultFS, env.GetAntreaNamespace(), splits[0], "antrea-agent", splits[1], dstDir); err != nil {
+			return "", fmt.Errorf("error when copying pcapng file from container: %w", err)
+		}
+	}
+	return dstPath, nil
+}
+
+// fetchAndMergePacketsFiles saves the packets files of a PacketCapture performed on multiple Nodes, and merges them
+// into a single file in outputDir, in which the interfaces are annotated with the Node and the port on which the
+// packets were captured. It returns the path of the merged file.
+func fetchAndMergePacketsFiles(ctx context.Context, out io.Writer, restConfig *rest.Config, k8sClient kubernetes.Interface, antreaClient antrea.Interface, pc *v1alpha1.PacketCapture, outputDir string, insecure bool) (string, error) {
+	tmpDir, err := afero.TempDir(defaultFS, "", "antctl-packetcapture-")
+	if err != nil {
+		return "", fmt.Errorf("error when creating temporary directory: %w", err)
+	}
+	defer defaultFS.RemoveAll(tmpDir)
+
+	var files []io.Reader
+	for _, nodeStatus := range pc.Status.Nodes {
+		// No file is saved if no packet is captured on the Node.
+		if nodeStatus.FilePath == "" {
+			continue
+		}
+		nodeDir := path.Join(tmpDir, nodeStatus.NodeName)
+		if err := defaultFS.MkdirAll(nodeDir, 0700); err != nil {
+			return "", fmt.Errorf("error when creating temporary directory: %w", err)
+		}
+		// The capture is performed on each Node by the PacketCapture created for the Node by antrea-controller.
+		filePath, err := fetchPacketsFile(ctx, out, restConfig, k8sClient, antreaClient, nodeStatus.PacketCapture, nodeStatus.FilePath, nodeDir, insecure)
+		if err != nil {
+			return "", fmt.Errorf("error when fetching packets file of Node %s: %w", nodeStatus.NodeName, err)
+		}
+		file, err := defaultFS.Open(filePath)
+		if err != nil {
+			return "", fmt.Errorf("error when opening packets file of Node %s: %w", nodeStatus.NodeName, err)
+		}
+		defer file.Close()
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return "", errors.New("no packets were captured on any Node")
+	}
+
+	dstPath := path.Join(outputDir, pc.Name+".pcapng")
+	dstFile, err := defaultFS.OpenFile(dstPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", fmt.Errorf("error when creating packets file: %w", err)
+	}
+	defer dstFile.Close()
+	if err := mergePacketsFiles(dstFile, files); err != nil {
+		return "", fmt.Errorf("error when merging packets files: %w", err)
+	}
+	return dstPath, nil
+}
+
+// downloadPacketsFile streams the packets file of a PacketCapture from the API of the Antrea Agent which performed the
+// capture, and saves it to dstPath.
+func downloadPacketsFile(ctx context.Context, restConfig *rest.Config, k8sClient kubernetes.Interface, antreaClient antrea.Interface, pcName, agentPodName, dstPath string, insecure bool) error {
+	agentPod, err := k8sClient.CoreV1().Pods(env.GetAntreaNamespace()).Get(ctx, agentPodName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting antrea-agent Pod %s: %w", agentPodName, err)
+	}
+	if agentPod.Spec.NodeName == "" {
+		return fmt.Errorf("antrea-agent Pod %s is not scheduled to a Node", agentPodName)
+	}
+	agentClient, err := getAgentClient(ctx, restConfig, k8sClient, antreaClient, agentPod.Spec.NodeName, insecure)
+	if err != nil {
+		return err
+	}
+	stream, err := agentClient.Get().AbsPath("/packetcaptures").Param("name", pcName).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("error when requesting packets file: %w", err)
+	}
+	defer stream.Close()
+	file, err := defaultFS.Create(dstPath)
+	if err != nil {
+		return fmt.Errorf("error when creating packets file: %w", err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, stream); err != nil {
+		return fmt.Errorf("error when downloading packets file: %w", err)
 	}
-	fmt.Fprintf(out, "Captured packets file: %s\n", path.Join(options.outputDir, fileName))
 	return nil
 }
 
@@ -269,6 +413,52 @@ func parseEndpoint(endpoint string) (*v1alpha1.PodReference, *string) {
 	return pod, ip
 }
 
+func parseServiceReference(service string) *v1alpha1.ServiceReference {
+	split := strings.Split(service, "/")
+	if len(split) == 1 && len(split[0]) != 0 {
+		return &v1alpha1.ServiceReference{
+			Namespace: "default",
+			Name:      split[0],
+		}
+	} else if len(split) == 2 && len(split[0]) != 0 && len(split[1]) != 0 {
+		return &v1alpha1.ServiceReference{
+			Namespace: split[0],
+			Name:      split[1],
+		}
+	}
+	return nil
+}
+
+func parseNode(node, nodeSelector, port string) (*v1alpha1.PacketCaptureNode, error) {
+	if node == "" && nodeSelector == "" {
+		if port != "" {
+			return nil, errors.New("--port can only be specified with --node or --node-selector")
+		}
+		return nil, nil
+	}
+	if node != "" && nodeSelector != "" {
+		return nil, errors.New("--node and --node-selector cannot be specified together")
+	}
+	result := &v1alpha1.PacketCaptureNode{Name: node}
+	if nodeSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(nodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --node-selector: %w", err)
+		}
+		result.NodeSelector = selector
+	}
+	switch v1alpha1.PacketCapturePortType(port) {
+	case "":
+		return nil, errors.New("--port must be specified with --node or --node-selector")
+	case v1alpha1.PacketCapturePortTypeGateway, v1alpha1.PacketCapturePortTypeTunnel, v1alpha1.PacketCapturePortTypeUplink:
+		result.Port = v1alpha1.PacketCapturePortType(port)
+	default:
+		result.Port = v1alpha1.PacketCapturePortTypeOVSPort
+		result.PortName = port
+	}
+	return result, nil
+}
+
 func getFlowFields(flow string) (map[string]string, error) {
 	fields := map[string]string{}
 	for _, v := range strings.Split(flow, ",") {
@@ -492,16 +682,19 @@ func parseCapturePoint(captPointStr string) (v1alpha1.CapturePoint, error) {
 	}
 
 	switch v1alpha1.CapturePoint(captPointStr) {
-	case v1alpha1.CapturePointSource, v1alpha1.CapturePointDestination:
+	case v1alpha1.CapturePointSource, v1alpha1.CapturePointDestination, v1alpha1.CapturePointBoth:
 		return v1alpha1.CapturePoint(captPointStr), nil
 	default:
-		return "", fmt.Errorf("invalid capture point: %q, must be either Source or Destination", captPointStr)
+		return "", fmt.Errorf("invalid capture point: %q, must be one of Source, Destination, or Both", captPointStr)
 	}
 }
 
 func newPacketCapture(options *packetCaptureOptions) (*v1alpha1.PacketCapture, error) {
-	if options.source == "" && options.dest == "" {
-		return nil, errors.New("must specify at least one of --source or --destination")
+	if options.source == "" && options.dest == "" && options.service == "" && options.node == "" && options.nodeSelector == "" {
+		return nil, errors.New("must specify at least one of --source, --destination, --service, --node or --node-selector")
+	}
+	if options.dest != "" && options.service != "" {
+		return nil, errors.New("--destination and --service cannot be specified together")
 	}
 
 	var src v1alpha1.Source
@@ -519,8 +712,18 @@ func newPacketCapture(options *packetCaptureOptions) (*v1alpha1.PacketCapture, e
 			return nil, fmt.Errorf("destination should be in the format of Namespace/Pod, Pod, or IPv4/IPv6")
 		}
 	}
+	if options.service != "" {
+		dst.Service = parseServiceReference(options.service)
+		if dst.Service == nil {
+			return nil, fmt.Errorf("service should be in the format of Namespace/Service or Service")
+		}
+	}
 
-	if src.Pod == nil && dst.Pod == nil {
+	node, err := parseNode(options.node, options.nodeSelector, options.port)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil && src.Pod == nil && dst.Pod == nil {
 		return nil, errors.New("one of source and destination must be a Pod")
 	}
 	pkt, err := parseFlow(options)
@@ -538,10 +741,14 @@ func newPacketCapture(options *packetCaptureOptions) (*v1alpha1.PacketCapture, e
 		return nil, err
 	}
 	switch {
+	case capturePoint != "" && node != nil:
+		return nil, fmt.Errorf("capture-point cannot be specified with --node or --node-selector")
 	case capturePoint == v1alpha1.CapturePointSource && src.Pod == nil:
 		return nil, fmt.Errorf("a source Pod must be specified when capture-point is 'Source'")
 	case capturePoint == v1alpha1.CapturePointDestination && dst.Pod == nil:
 		return nil, fmt.Errorf("a destination Pod must be specified when capture-point is 'Destination'")
+	case capturePoint == v1alpha1.CapturePointBoth && (src.Pod == nil || dst.Pod == nil):
+		return nil, fmt.Errorf("a source Pod and a destination Pod must be specified when capture-point is 'Both'")
 	}
 
 	name := getPCName(options)
@@ -557,6 +764,7 @@ func newPacketCapture(options *packetCaptureOptions) (*v1alpha1.PacketCapture, e
 			Timeout:      &timeout,
 			Packet:       pkt,
 			CapturePoint: capturePoint,
+			Node:         node,
 			CaptureConfig: v1alpha1.CaptureConfig{
 				FirstN: &v1alpha1.PacketCaptureFirstNConfig{
 					Number: options.number,
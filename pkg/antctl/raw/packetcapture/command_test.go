@@ -18,6 +18,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -31,12 +35,15 @@ import (
 	"k8s.io/client-go/kubernetes"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/rest/fake"
 	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/utils/ptr"
 
 	"antrea.io/antrea/v2/pkg/antctl/raw"
 	"antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	antrea "antrea.io/antrea/v2/pkg/client/clientset/versioned"
 	antreafakeclient "antrea.io/antrea/v2/pkg/client/clientset/versioned/fake"
+	"antrea.io/antrea/v2/pkg/client/clientset/versioned/scheme"
 )
 
 const (
@@ -69,9 +76,12 @@ var (
 	k8sClient = k8sfake.NewSimpleClientset(&pod1, &pod2, &antreaAgentPod)
 )
 
-type testPodFile struct{}
+type testPodFile struct {
+	copied bool
+}
 
 func (p *testPodFile) CopyFromPod(ctx context.Context, fs afero.Fs, namespace, name, containerName, srcPath, dstDir string) error {
+	p.copied = true
 	return nil
 }
 
@@ -198,6 +208,174 @@ func TestPacketCaptureRun(t *testing.T) {
 	}
 }
 
+func TestPacketCaptureRunDownload(t *testing.T) {
+	agentPod := antreaAgentPod.DeepCopy()
+	agentPod.Spec.NodeName = "node-1"
+	tcs := []struct {
+		name           string
+		statusCode     int
+		expectedCopied bool
+		expectedOutput string
+	}{
+		{
+			name:           "download from agent",
+			statusCode:     http.StatusOK,
+			expectedOutput: "Captured packets file: antrea-agent-1.pcapng\n",
+		},
+		{
+			name:           "fall back to copying from container",
+			statusCode:     http.StatusNotFound,
+			expectedCopied: true,
+			expectedOutput: "Failed to download packets file from Antrea Agent API",
+		},
+	}
+
+	for _, tt := range tcs {
+		t.Run(tt.name, func(t *testing.T) {
+			defaultFS = afero.NewMemMapFs()
+			client := antreafakeclient.NewSimpleClientset()
+			client.PrependReactor("create", "packetcaptures", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				obj := action.(k8stesting.CreateAction).GetObject().(*v1alpha1.PacketCapture)
+				obj.Status.FilePath = fmt.Sprintf("%s:/tmp/antrea/packages/%s.pcapng", agentPod.Name, agentPod.Name)
+				obj.Status.Conditions = []v1alpha1.PacketCaptureCondition{
+					{
+						Type:   v1alpha1.PacketCaptureComplete,
+						Status: metav1.ConditionTrue,
+					},
+				}
+				return false, obj, nil
+			})
+			copier := &testPodFile{}
+			getCopier = func(config *rest.Config, client kubernetes.Interface) raw.PodFileCopier {
+				return copier
+			}
+			var requestedNode, requestedPath, requestedName string
+			getAgentClient = func(ctx context.Context, restConfig *rest.Config, k8sClient kubernetes.Interface, antreaClient antrea.Interface, nodeName string, insecure bool) (rest.Interface, error) {
+				requestedNode = nodeName
+				return &fake.RESTClient{
+					NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+					Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+						requestedPath = req.URL.Path
+						requestedName = req.URL.Query().Get("name")
+						return &http.Response{StatusCode: tt.statusCode, Body: io.NopCloser(bytes.NewBufferString("packets"))}, nil
+					}),
+				}, nil
+			}
+			defer func() {
+				defaultFS = afero.NewOsFs()
+				getCopier = getPodFileCopier
+				getAgentClient = createAgentClient
+			}()
+
+			option := packetCaptureOptions{
+				source: srcPod,
+				dest:   dstPod,
+				number: testNum,
+			}
+			buf := new(bytes.Buffer)
+			err := packetCaptureRun(context.TODO(), buf, nil, k8sfake.NewSimpleClientset(&pod1, &pod2, agentPod), client, &option)
+			require.NoError(t, err)
+			assert.Contains(t, buf.String(), tt.expectedOutput)
+			assert.Equal(t, "node-1", requestedNode)
+			assert.Equal(t, "/packetcaptures", requestedPath)
+			assert.Regexp(t, "^default-pod-1-pod-2-", requestedName)
+			assert.Equal(t, tt.expectedCopied, copier.copied)
+			if !tt.expectedCopied {
+				data, err := afero.ReadFile(defaultFS, "antrea-agent-1.pcapng")
+				require.NoError(t, err)
+				assert.Equal(t, "packets", string(data))
+			}
+		})
+	}
+}
+
+func TestPacketCaptureRunMultiNode(t *testing.T) {
+	agentPod1 := antreaAgentPod.DeepCopy()
+	agentPod1.Spec.NodeName = "node-1"
+	agentPod2 := antreaAgentPod.DeepCopy()
+	agentPod2.Name = "antrea-agent-2"
+	agentPod2.Spec.NodeName = "node-2"
+	start := time.Unix(1700000000, 0)
+	packetsFiles := map[string][]byte{
+		"node-1": newTestPacketsFile(t, "pod-1-abcd", "Pod default/pod-1 on Node node-1", start, start.Add(2*time.Millisecond)),
+		"node-2": newTestPacketsFile(t, "pod-2-abcd", "Pod default/pod-2 on Node node-2", start.Add(time.Millisecond)),
+	}
+
+	defaultFS = afero.NewMemMapFs()
+	var pcName string
+	client := antreafakeclient.NewSimpleClientset()
+	client.PrependReactor("create", "packetcaptures", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.CreateAction).GetObject().(*v1alpha1.PacketCapture)
+		pcName = obj.Name
+		obj.Status.Conditions = []v1alpha1.PacketCaptureCondition{
+			{
+				Type:   v1alpha1.PacketCaptureComplete,
+				Status: metav1.ConditionTrue,
+			},
+		}
+		obj.Status.Nodes = []v1alpha1.PacketCaptureNodeStatus{
+			{
+				NodeName:       "node-1",
+				PacketCapture:  obj.Name + "-node-1",
+				NumberCaptured: 2,
+				FilePath:       fmt.Sprintf("%s:/tmp/antrea/packetcapture/packets/%s-node-1.pcapng", agentPod1.Name, obj.Name),
+			},
+			{
+				NodeName:       "node-2",
+				PacketCapture:  obj.Name + "-node-2",
+				NumberCaptured: 1,
+				FilePath:       fmt.Sprintf("%s:/tmp/antrea/packetcapture/packets/%s-node-2.pcapng", agentPod2.Name, obj.Name),
+			},
+			{
+				NodeName:      "node-3",
+				PacketCapture: obj.Name + "-node-3",
+			},
+		}
+		return false, obj, nil
+	})
+	getCopier = func(config *rest.Config, client kubernetes.Interface) raw.PodFileCopier {
+		return &testPodFile{}
+	}
+	getAgentClient = func(ctx context.Context, restConfig *rest.Config, k8sClient kubernetes.Interface, antreaClient antrea.Interface, nodeName string, insecure bool) (rest.Interface, error) {
+		return &fake.RESTClient{
+			NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+			Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+				// The file of each Node is served for the PacketCapture created for the Node.
+				if req.URL.Query().Get("name") != pcName+"-"+nodeName {
+					return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(packetsFiles[nodeName]))}, nil
+			}),
+		}, nil
+	}
+	defer func() {
+		defaultFS = afero.NewOsFs()
+		getCopier = getPodFileCopier
+		getAgentClient = createAgentClient
+	}()
+
+	option := packetCaptureOptions{
+		source:       srcPod,
+		dest:         dstPod,
+		capturePoint: "Both",
+		number:       testNum,
+	}
+	buf := new(bytes.Buffer)
+	err := packetCaptureRun(context.TODO(), buf, nil, k8sfake.NewSimpleClientset(&pod1, &pod2, agentPod1, agentPod2), client, &option)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), fmt.Sprintf("Captured packets file: %s.pcapng\n", pcName))
+
+	data, err := afero.ReadFile(defaultFS, pcName+".pcapng")
+	require.NoError(t, err)
+	interfaces, packetInterfaces := readTestPacketsFile(t, data)
+	assert.Equal(t, []string{"pod-1-abcd: Pod default/pod-1 on Node node-1", "pod-2-abcd: Pod default/pod-2 on Node node-2"}, interfaces)
+	assert.Equal(t, []string{"pod-1-abcd", "pod-2-abcd", "pod-1-abcd"}, packetInterfaces)
+	// The temporary directory should be removed.
+	tmpDirs, err := afero.Glob(defaultFS, filepath.Join(os.TempDir(), "antctl-packetcapture-*"))
+	require.NoError(t, err)
+	assert.Empty(t, tmpDirs)
+}
+
 func TestTokenizeTCPFlags(t *testing.T) {
 	tcs := []struct {
 		name        string
@@ -309,7 +487,7 @@ func TestNewPacketCapture(t *testing.T) {
 				source: "",
 				dest:   "",
 			},
-			expectErr: "must specify at least one of --source or --destination",
+			expectErr: "must specify at least one of --source, --destination, --service, --node or --node-selector",
 		},
 		{
 			name: "no-pod",
@@ -410,7 +588,7 @@ func TestNewPacketCapture(t *testing.T) {
 				flow:         "tcp,tcp_dst=80",
 				capturePoint: "Src",
 			},
-			expectErr: "invalid capture point: \"Src\", must be either Source or Destination",
+			expectErr: "invalid capture point: \"Src\", must be one of Source, Destination, or Both",
 		},
 		{
 			name: "no-src-pod-given-captPointSrc",
@@ -473,6 +651,233 @@ func TestNewPacketCapture(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "pod-2-service",
+			option: packetCaptureOptions{
+				source:  srcPod,
+				service: "ns1/svc1",
+				number:  testNum,
+			},
+			expectPC: &v1alpha1.PacketCapture{
+				Spec: v1alpha1.PacketCaptureSpec{
+					Source: v1alpha1.Source{
+						Pod: &v1alpha1.PodReference{
+							Namespace: "default",
+							Name:      "pod-1",
+						},
+					},
+					Destination: v1alpha1.Destination{
+						Service: &v1alpha1.ServiceReference{
+							Namespace: "ns1",
+							Name:      "svc1",
+						},
+					},
+					Timeout: ptr.To(int32(0)),
+					CaptureConfig: v1alpha1.CaptureConfig{
+						FirstN: &v1alpha1.PacketCaptureFirstNConfig{
+							Number: testNum,
+						},
+					},
+					Packet: &v1alpha1.Packet{
+						IPFamily: v1.IPv4Protocol,
+					},
+				},
+			},
+		},
+		{
+			name: "node-gateway",
+			option: packetCaptureOptions{
+				node:   "node-1",
+				port:   "Gateway",
+				number: testNum,
+			},
+			expectPC: &v1alpha1.PacketCapture{
+				Spec: v1alpha1.PacketCaptureSpec{
+					Node: &v1alpha1.PacketCaptureNode{
+						Name: "node-1",
+						Port: v1alpha1.PacketCapturePortTypeGateway,
+					},
+					Timeout: ptr.To(int32(0)),
+					CaptureConfig: v1alpha1.CaptureConfig{
+						FirstN: &v1alpha1.PacketCaptureFirstNConfig{
+							Number: testNum,
+						},
+					},
+					Packet: &v1alpha1.Packet{
+						IPFamily: v1.IPv4Protocol,
+					},
+				},
+			},
+		},
+		{
+			name: "node-ovs-port",
+			option: packetCaptureOptions{
+				dest:   "10.10.0.1",
+				node:   "node-1",
+				port:   "pod-1-abcd",
+				number: testNum,
+			},
+			expectPC: &v1alpha1.PacketCapture{
+				Spec: v1alpha1.PacketCaptureSpec{
+					Destination: v1alpha1.Destination{
+						IP: ptr.To("10.10.0.1"),
+					},
+					Node: &v1alpha1.PacketCaptureNode{
+						Name:     "node-1",
+						Port:     v1alpha1.PacketCapturePortTypeOVSPort,
+						PortName: "pod-1-abcd",
+					},
+					Timeout: ptr.To(int32(0)),
+					CaptureConfig: v1alpha1.CaptureConfig{
+						FirstN: &v1alpha1.PacketCaptureFirstNConfig{
+							Number: testNum,
+						},
+					},
+					Packet: &v1alpha1.Packet{
+						IPFamily: v1.IPv4Protocol,
+					},
+				},
+			},
+		},
+		{
+			name: "destination-and-service",
+			option: packetCaptureOptions{
+				source:  srcPod,
+				dest:    dstPod,
+				service: "svc1",
+			},
+			expectErr: "--destination and --service cannot be specified together",
+		},
+		{
+			name: "invalid-service",
+			option: packetCaptureOptions{
+				source:  srcPod,
+				service: "ns1/svc1/foo",
+			},
+			expectErr: "service should be in the format of Namespace/Service or Service",
+		},
+		{
+			name: "node-without-port",
+			option: packetCaptureOptions{
+				node: "node-1",
+			},
+			expectErr: "--port must be specified with --node",
+		},
+		{
+			name: "port-without-node",
+			option: packetCaptureOptions{
+				source: srcPod,
+				port:   "Gateway",
+			},
+			expectErr: "--port can only be specified with --node",
+		},
+		{
+			name: "node-with-capture-point",
+			option: packetCaptureOptions{
+				source:       srcPod,
+				node:         "node-1",
+				port:         "Gateway",
+				capturePoint: "Source",
+			},
+			expectErr: "capture-point cannot be specified with --node",
+		},
+		{
+			name: "both-capture-points",
+			option: packetCaptureOptions{
+				source:       srcPod,
+				dest:         dstPod,
+				capturePoint: "Both",
+				number:       testNum,
+			},
+			expectPC: &v1alpha1.PacketCapture{
+				Spec: v1alpha1.PacketCaptureSpec{
+					Source: v1alpha1.Source{
+						Pod: &v1alpha1.PodReference{
+							Namespace: "default",
+							Name:      "pod-1",
+						},
+					},
+					Destination: v1alpha1.Destination{
+						Pod: &v1alpha1.PodReference{
+							Namespace: "default",
+							Name:      "pod-2",
+						},
+					},
+					Timeout: ptr.To(int32(0)),
+					CaptureConfig: v1alpha1.CaptureConfig{
+						FirstN: &v1alpha1.PacketCaptureFirstNConfig{
+							Number: testNum,
+						},
+					},
+					Packet: &v1alpha1.Packet{
+						IPFamily: v1.IPv4Protocol,
+					},
+					CapturePoint: v1alpha1.CapturePointBoth,
+				},
+			},
+		},
+		{
+			name: "both-capture-points-without-dst-pod",
+			option: packetCaptureOptions{
+				source:       srcPod,
+				dest:         "10.10.0.1",
+				capturePoint: "Both",
+				number:       testNum,
+			},
+			expectErr: "a source Pod and a destination Pod must be specified when capture-point is 'Both'",
+		},
+		{
+			name: "node-selector-uplink",
+			option: packetCaptureOptions{
+				dest:         dstPod,
+				nodeSelector: "zone=a",
+				port:         "Uplink",
+				number:       testNum,
+			},
+			expectPC: &v1alpha1.PacketCapture{
+				Spec: v1alpha1.PacketCaptureSpec{
+					Destination: v1alpha1.Destination{
+						Pod: &v1alpha1.PodReference{
+							Namespace: "default",
+							Name:      "pod-2",
+						},
+					},
+					Node: &v1alpha1.PacketCaptureNode{
+						NodeSelector: &metav1.LabelSelector{
+							MatchLabels:      map[string]string{"zone": "a"},
+							MatchExpressions: []metav1.LabelSelectorRequirement{},
+						},
+						Port: v1alpha1.PacketCapturePortTypeUplink,
+					},
+					Timeout: ptr.To(int32(0)),
+					CaptureConfig: v1alpha1.CaptureConfig{
+						FirstN: &v1alpha1.PacketCaptureFirstNConfig{
+							Number: testNum,
+						},
+					},
+					Packet: &v1alpha1.Packet{
+						IPFamily: v1.IPv4Protocol,
+					},
+				},
+			},
+		},
+		{
+			name: "node-and-node-selector",
+			option: packetCaptureOptions{
+				node:         "node-1",
+				nodeSelector: "zone=a",
+				port:         "Gateway",
+			},
+			expectErr: "--node and --node-selector cannot be specified together",
+		},
+		{
+			name: "invalid-node-selector",
+			option: packetCaptureOptions{
+				nodeSelector: "zone in a",
+				port:         "Gateway",
+			},
+			expectErr: "invalid --node-selector",
+		},
 	}
 
 	for _, tt := range tcs {
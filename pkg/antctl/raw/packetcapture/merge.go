@@ -0,0 +1,119 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/pcapgo"
+)
+
+// packetsFileReader reads the packets of one of the pcapng files to merge, keeping the next packet to write.
+type packetsFileReader struct {
+	reader *pcapgo.NgReader
+	// interfaces maps the interface indexes of the file to the ones of the merged file.
+	interfaces map[int]int
+	data       []byte
+	ci         gopacket.CaptureInfo
+	done       bool
+}
+
+func (r *packetsFileReader) next() error {
+	data, ci, err := r.reader.ReadPacketData()
+	if errors.Is(err, io.EOF) {
+		r.done = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	r.data, r.ci = data, ci
+	return nil
+}
+
+// mergePacketsFiles merges pcapng files into a single pcapng file written to dst, in which the packets are ordered by
+// timestamp. The interfaces of the input files are all kept, with their names and descriptions, so that each packet
+// can be related to the Node and the port on which it was captured.
+func mergePacketsFiles(dst io.Writer, srcs []io.Reader) error {
+	readers := make([]*packetsFileReader, 0, len(srcs))
+	for _, src := range srcs {
+		reader, err := pcapgo.NewNgReader(src, pcapgo.DefaultNgReaderOptions)
+		if err != nil {
+			return fmt.Errorf("error when reading pcapng file: %w", err)
+		}
+		r := &packetsFileReader{reader: reader, interfaces: map[int]int{}}
+		if err := r.next(); err != nil {
+			return fmt.Errorf("error when reading pcapng file: %w", err)
+		}
+		readers = append(readers, r)
+	}
+	if len(readers) == 0 {
+		return errors.New("no pcapng file to merge")
+	}
+
+	var writer *pcapgo.NgWriter
+	// addInterface adds the interface of the given input file to the merged file when its first packet is written. The
+	// interfaces of the input files are only known once their packets are read.
+	addInterface := func(r *packetsFileReader, index int) (int, error) {
+		ngInterface, err := r.reader.Interface(index)
+		if err != nil {
+			return 0, err
+		}
+		// The timestamps returned by the reader already include the offset.
+		ngInterface.TimestampOffset = 0
+		if writer == nil {
+			writer, err = pcapgo.NewNgWriterInterface(dst, ngInterface, pcapgo.DefaultNgWriterOptions)
+			return 0, err
+		}
+		return writer.AddInterface(ngInterface)
+	}
+	for {
+		var earliest *packetsFileReader
+		for _, r := range readers {
+			if !r.done && (earliest == nil || r.ci.Timestamp.Before(earliest.ci.Timestamp)) {
+				earliest = r
+			}
+		}
+		if earliest == nil {
+			break
+		}
+		index, ok := earliest.interfaces[earliest.ci.InterfaceIndex]
+		if !ok {
+			var err error
+			if index, err = addInterface(earliest, earliest.ci.InterfaceIndex); err != nil {
+				return fmt.Errorf("error when adding interface to pcapng file: %w", err)
+			}
+			earliest.interfaces[earliest.ci.InterfaceIndex] = index
+		}
+		ci := earliest.ci
+		ci.InterfaceIndex = index
+		if err := writer.WritePacket(ci, earliest.data); err != nil {
+			return fmt.Errorf("error when writing pcapng file: %w", err)
+		}
+		if err := earliest.next(); err != nil {
+			return fmt.Errorf("error when reading pcapng file: %w", err)
+		}
+	}
+	if writer == nil {
+		// None of the files has any packet, only keep the interface of the first file.
+		if _, err := addInterface(readers[0], 0); err != nil {
+			return fmt.Errorf("error when adding interface to pcapng file: %w", err)
+		}
+	}
+	return writer.Flush()
+}
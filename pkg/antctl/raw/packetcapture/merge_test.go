@@ -0,0 +1,156 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	"github.com/gopacket/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPacketsFile returns a pcapng file with a single interface and one packet for each of the given timestamps.
+func newTestPacketsFile(t *testing.T, name, description string, timestamps ...time.Time) []byte {
+	var buf bytes.Buffer
+	writer, err := pcapgo.NewNgWriterInterface(&buf, pcapgo.NgInterface{
+		Name:        name,
+		Description: description,
+		LinkType:    layers.LinkTypeEthernet,
+		SnapLength:  65536,
+	}, pcapgo.DefaultNgWriterOptions)
+	require.NoError(t, err)
+	for _, ts := range timestamps {
+		data := []byte(name)
+		require.NoError(t, writer.WritePacket(gopacket.CaptureInfo{Timestamp: ts, CaptureLength: len(data), Length: len(data)}, data))
+	}
+	require.NoError(t, writer.Flush())
+	return buf.Bytes()
+}
+
+// readTestPacketsFile returns the interfaces of a pcapng file, formatted as "<name>: <description>", and the name of
+// the interface of each packet, which must be ordered by timestamp.
+func readTestPacketsFile(t *testing.T, data []byte) ([]string, []string) {
+	reader, err := pcapgo.NewNgReader(bytes.NewReader(data), pcapgo.DefaultNgReaderOptions)
+	require.NoError(t, err)
+	var packetInterfaces []string
+	var lastTimestamp time.Time
+	for {
+		data, ci, err := reader.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		assert.False(t, ci.Timestamp.Before(lastTimestamp), "packets are not ordered by timestamp")
+		lastTimestamp = ci.Timestamp
+		ngInterface, err := reader.Interface(ci.InterfaceIndex)
+		require.NoError(t, err)
+		assert.Equal(t, ngInterface.Name, string(data))
+		packetInterfaces = append(packetInterfaces, ngInterface.Name)
+	}
+	var interfaces []string
+	for i := range reader.NInterfaces() {
+		ngInterface, err := reader.Interface(i)
+		require.NoError(t, err)
+		interfaces = append(interfaces, fmt.Sprintf("%s: %s", ngInterface.Name, ngInterface.Description))
+	}
+	return interfaces, packetInterfaces
+}
+
+func TestMergePacketsFiles(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tcs := []struct {
+		name                     string
+		files                    [][]byte
+		expectedInterfaces       []string
+		expectedPacketInterfaces []string
+		expectedErr              string
+	}{
+		{
+			name: "interleaved packets",
+			files: [][]byte{
+				newTestPacketsFile(t, "pod-1-abcd", "Pod default/pod-1 on Node node-1", start, start.Add(2*time.Millisecond), start.Add(4*time.Millisecond)),
+				newTestPacketsFile(t, "pod-2-abcd", "Pod default/pod-2 on Node node-2", start.Add(time.Millisecond), start.Add(3*time.Millisecond)),
+			},
+			expectedInterfaces: []string{
+				"pod-1-abcd: Pod default/pod-1 on Node node-1",
+				"pod-2-abcd: Pod default/pod-2 on Node node-2",
+			},
+			expectedPacketInterfaces: []string{"pod-1-abcd", "pod-2-abcd", "pod-1-abcd", "pod-2-abcd", "pod-1-abcd"},
+		},
+		{
+			name: "interfaces added in order of first packet",
+			files: [][]byte{
+				newTestPacketsFile(t, "antrea-gw0", "Gateway port on Node node-1", start.Add(time.Millisecond)),
+				newTestPacketsFile(t, "antrea-gw0", "Gateway port on Node node-2", start),
+			},
+			expectedInterfaces: []string{
+				"antrea-gw0: Gateway port on Node node-2",
+				"antrea-gw0: Gateway port on Node node-1",
+			},
+			expectedPacketInterfaces: []string{"antrea-gw0", "antrea-gw0"},
+		},
+		{
+			name: "single file",
+			files: [][]byte{
+				newTestPacketsFile(t, "pod-1-abcd", "Pod default/pod-1 on Node node-1", start),
+			},
+			expectedInterfaces:       []string{"pod-1-abcd: Pod default/pod-1 on Node node-1"},
+			expectedPacketInterfaces: []string{"pod-1-abcd"},
+		},
+		{
+			name: "no packet",
+			files: [][]byte{
+				newTestPacketsFile(t, "pod-1-abcd", "Pod default/pod-1 on Node node-1"),
+				newTestPacketsFile(t, "pod-2-abcd", "Pod default/pod-2 on Node node-2"),
+			},
+			expectedInterfaces: []string{"pod-1-abcd: Pod default/pod-1 on Node node-1"},
+		},
+		{
+			name:        "no file",
+			expectedErr: "no pcapng file to merge",
+		},
+		{
+			name:        "invalid file",
+			files:       [][]byte{[]byte("invalid")},
+			expectedErr: "error when reading pcapng file",
+		},
+	}
+	for _, tt := range tcs {
+		t.Run(tt.name, func(t *testing.T) {
+			var srcs []io.Reader
+			for _, file := range tt.files {
+				srcs = append(srcs, bytes.NewReader(file))
+			}
+			var dst bytes.Buffer
+			err := mergePacketsFiles(&dst, srcs)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			interfaces, packetInterfaces := readTestPacketsFile(t, dst.Bytes())
+			assert.Equal(t, tt.expectedInterfaces, interfaces)
+			assert.Equal(t, tt.expectedPacketInterfaces, packetInterfaces)
+		})
+	}
+}
@@ -330,11 +330,13 @@ type Service struct {
 type L7Protocol struct {
 	HTTP *HTTPProtocol
 	TLS  *TLSProtocol
+	GRPC *GRPCProtocol
+	DNS  *DNSProtocol
 }
 
-// HTTPProtocol matches HTTP requests with specific host, method, and path. All
-// fields could be used alone or together. If all fields are not provided, this
-// matches all HTTP requests.
+// HTTPProtocol matches HTTP requests with specific host, method, path, headers,
+// and query parameters. All fields could be used alone or together. If all
+// fields are not provided, this matches all HTTP requests.
 type HTTPProtocol struct {
 	// Host represents the hostname present in the URI or the HTTP Host header to match.
 	// It does not contain the port associated with the host.
@@ -344,6 +346,31 @@ type HTTPProtocol struct {
 	Method string
 	// Path represents the URI path to match (Ex. "/index.html", "/admin").
 	Path string
+	// PathRegex represents a regular expression to match the URI path.
+	PathRegex string
+	// Headers represents the request headers to match. All of them must be matched.
+	Headers []HTTPHeaderMatch
+	// QueryParams represents the URI query parameters to match. All of them must be matched.
+	QueryParams []HTTPQueryParamMatch
+}
+
+// HTTPHeaderMatch matches an HTTP request header with specific name and value.
+type HTTPHeaderMatch struct {
+	// Name represents the name of the header to match. It is case-insensitive.
+	Name string
+	// Value represents the value of the header to match. If it is empty, any
+	// request carrying the header is matched.
+	Value string
+}
+
+// HTTPQueryParamMatch matches an HTTP request URI query parameter with specific
+// name and value.
+type HTTPQueryParamMatch struct {
+	// Name represents the name of the query parameter to match.
+	Name string
+	// Value represents the value of the query parameter to match. If it is
+	// empty, any request carrying the query parameter is matched.
+	Value string
 }
 
 // TLSProtocol matches TLS handshake packets with specific SNI. If the field is not provided, this
@@ -353,6 +380,27 @@ type TLSProtocol struct {
 	SNI string
 }
 
+// GRPCProtocol matches gRPC requests with specific service and method, which are derived from the HTTP/2 :path
+// pseudo-header "/<service>/<method>". All fields could be used alone or together. If all fields are not provided, it
+// matches all gRPC requests.
+type GRPCProtocol struct {
+	// Service represents the fully-qualified name of the gRPC service to match (Ex. "helloworld.Greeter").
+	Service string
+	// Method represents the name of the gRPC method to match (Ex. "SayHello").
+	Method string
+}
+
+// DNSProtocol matches DNS queries with specific query name and query type. All fields could be used alone or together.
+// If all fields are not provided, it matches all DNS queries.
+type DNSProtocol struct {
+	// QueryName represents the domain name in the DNS query to match. Both exact matches and wildcards are supported
+	// (Ex. "www.foo.com", "*.foo.com").
+	QueryName string
+	// QueryType represents the type of the DNS query to match.
+	// It could be A, AAAA, CNAME, MX, NS, PTR, SOA, SRV, TXT and ANY.
+	QueryType string
+}
+
 // NetworkPolicyPeer describes a peer of NetworkPolicyRules.
 // It could contain one of the subfields or a combination of them.
 type NetworkPolicyPeer struct {
@@ -553,16 +601,29 @@ type SupportBundleCollection struct {
 	SinceTime      string
 	FileServer     BundleFileServer
 	Authentication BundleServerAuthConfiguration
+	Profile        string
+	Redact         bool
 }
 
 // BundleFileServer specifies the bundle file server information.
 type BundleFileServer struct {
 	// The URL of the bundle file server. It is set with format: scheme://host[:port][/path],
-	// e.g, https://api.example.com:8443/v1/supportbundles/. If scheme is not set, https is used by default.
+	// e.g, https://api.example.com:8443/v1/supportbundles/. The supported schemes are sftp, s3,
+	// http and https. If scheme is not set, sftp is used by default.
 	URL string
 	// HostPublicKey specifies the only host public key that will be accepted when connecting to
 	// the file server. If omitted, any host key will be accepted, which is not recommended.
 	HostPublicKey []byte
+	// S3 specifies the configuration of the S3 service when the URL uses the s3 scheme.
+	S3 *BundleS3Config
+}
+
+// BundleS3Config specifies how to access the S3 service used to store the bundle files.
+type BundleS3Config struct {
+	// Endpoint is the URL of an S3-compatible service. If empty, AWS S3 is used.
+	Endpoint string
+	// Region is the region of the bucket.
+	Region string
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -579,10 +640,16 @@ type BasicAuthentication struct {
 	Password string
 }
 
+type AccessKey struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
 type BundleServerAuthConfiguration struct {
 	BearerToken         string
 	APIKey              string
 	BasicAuthentication *BasicAuthentication
+	AccessKey           *AccessKey
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
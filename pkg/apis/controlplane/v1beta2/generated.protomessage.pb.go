@@ -19,6 +19,8 @@
 
 package v1beta2
 
+func (*AccessKey) ProtoMessage() {}
+
 func (*AddressGroup) ProtoMessage() {}
 
 func (*AddressGroupList) ProtoMessage() {}
@@ -35,10 +37,14 @@ func (*BasicAuthentication) ProtoMessage() {}
 
 func (*BundleFileServer) ProtoMessage() {}
 
+func (*BundleS3Config) ProtoMessage() {}
+
 func (*BundleServerAuthConfiguration) ProtoMessage() {}
 
 func (*ClusterGroupMembers) ProtoMessage() {}
 
+func (*DNSProtocol) ProtoMessage() {}
+
 func (*EgressGroup) ProtoMessage() {}
 
 func (*EgressGroupList) ProtoMessage() {}
@@ -49,6 +55,8 @@ func (*Entity) ProtoMessage() {}
 
 func (*ExternalEntityReference) ProtoMessage() {}
 
+func (*GRPCProtocol) ProtoMessage() {}
+
 func (*GroupAssociation) ProtoMessage() {}
 
 func (*GroupMember) ProtoMessage() {}
@@ -57,8 +65,12 @@ func (*GroupMembers) ProtoMessage() {}
 
 func (*GroupReference) ProtoMessage() {}
 
+func (*HTTPHeaderMatch) ProtoMessage() {}
+
 func (*HTTPProtocol) ProtoMessage() {}
 
+func (*HTTPQueryParamMatch) ProtoMessage() {}
+
 func (*IPBlock) ProtoMessage() {}
 
 func (*IPGroupAssociation) ProtoMessage() {}
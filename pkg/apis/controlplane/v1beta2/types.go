@@ -330,10 +330,12 @@ type Service struct {
 type L7Protocol struct {
 	HTTP *HTTPProtocol `json:"http,omitempty" protobuf:"bytes,1,opt,name=http"`
 	TLS  *TLSProtocol  `json:"tls,omitempty" protobuf:"bytes,2,opt,name=tls"`
+	GRPC *GRPCProtocol `json:"grpc,omitempty" protobuf:"bytes,3,opt,name=grpc"`
+	DNS  *DNSProtocol  `json:"dns,omitempty" protobuf:"bytes,4,opt,name=dns"`
 }
 
-// HTTPProtocol matches HTTP requests with specific host, method, and path. All fields could be used alone or together.
-// If all fields are not provided, it matches all HTTP requests.
+// HTTPProtocol matches HTTP requests with specific host, method, path, headers, and query parameters. All fields could
+// be used alone or together. If all fields are not provided, it matches all HTTP requests.
 type HTTPProtocol struct {
 	// Host represents the hostname present in the URI or the HTTP Host header to match.
 	// It does not contain the port associated with the host.
@@ -343,6 +345,31 @@ type HTTPProtocol struct {
 	Method string `json:"method,omitempty" protobuf:"bytes,2,opt,name=method"`
 	// Path represents the URI path to match (Ex. "/index.html", "/admin").
 	Path string `json:"path,omitempty" protobuf:"bytes,3,opt,name=path"`
+	// PathRegex represents a regular expression to match the URI path (Ex. "^/api/v[0-9]+/users$").
+	PathRegex string `json:"pathRegex,omitempty" protobuf:"bytes,4,opt,name=pathRegex"`
+	// Headers represents the request headers to match. A request is matched only if all headers are matched.
+	Headers []HTTPHeaderMatch `json:"headers,omitempty" protobuf:"bytes,5,rep,name=headers"`
+	// QueryParams represents the URI query parameters to match. A request is matched only if all query parameters
+	// are matched.
+	QueryParams []HTTPQueryParamMatch `json:"queryParams,omitempty" protobuf:"bytes,6,rep,name=queryParams"`
+}
+
+// HTTPHeaderMatch matches an HTTP request header with specific name and value.
+type HTTPHeaderMatch struct {
+	// Name represents the name of the header to match. It is case-insensitive.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Value represents the value of the header to match. If it is not provided, any request carrying the header is
+	// matched.
+	Value string `json:"value,omitempty" protobuf:"bytes,2,opt,name=value"`
+}
+
+// HTTPQueryParamMatch matches an HTTP request URI query parameter with specific name and value.
+type HTTPQueryParamMatch struct {
+	// Name represents the name of the query parameter to match.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Value represents the value of the query parameter to match. If it is not provided, any request carrying the
+	// query parameter is matched.
+	Value string `json:"value,omitempty" protobuf:"bytes,2,opt,name=value"`
 }
 
 // TLSProtocol matches TLS handshake packets with specific SNI. If the field is not provided, this
@@ -352,6 +379,27 @@ type TLSProtocol struct {
 	SNI string `json:"sni,omitempty" protobuf:"bytes,1,opt,name=sni"`
 }
 
+// GRPCProtocol matches gRPC requests with specific service and method, which are derived from the HTTP/2 :path
+// pseudo-header "/<service>/<method>". All fields could be used alone or together. If all fields are not provided, it
+// matches all gRPC requests.
+type GRPCProtocol struct {
+	// Service represents the fully-qualified name of the gRPC service to match (Ex. "helloworld.Greeter").
+	Service string `json:"service,omitempty" protobuf:"bytes,1,opt,name=service"`
+	// Method represents the name of the gRPC method to match (Ex. "SayHello").
+	Method string `json:"method,omitempty" protobuf:"bytes,2,opt,name=method"`
+}
+
+// DNSProtocol matches DNS queries with specific query name and query type. All fields could be used alone or together.
+// If all fields are not provided, it matches all DNS queries.
+type DNSProtocol struct {
+	// QueryName represents the domain name in the DNS query to match. Both exact matches and wildcards are supported
+	// (Ex. "www.foo.com", "*.foo.com").
+	QueryName string `json:"queryName,omitempty" protobuf:"bytes,1,opt,name=queryName"`
+	// QueryType represents the type of the DNS query to match.
+	// It could be A, AAAA, CNAME, MX, NS, PTR, SOA, SRV, TXT and ANY.
+	QueryType string `json:"queryType,omitempty" protobuf:"bytes,2,opt,name=queryType"`
+}
+
 // NetworkPolicyPeer describes a peer of NetworkPolicyRules.
 // It could be a list of names of AddressGroups and/or a list of IPBlock.
 type NetworkPolicyPeer struct {
@@ -587,8 +635,14 @@ type SupportBundleCollectionNodeStatus struct {
 }
 
 type BundleFileServer struct {
-	URL           string `json:"url" protobuf:"bytes,1,opt,name=url"`
-	HostPublicKey []byte `json:"hostPublicKey,omitempty" protobuf:"bytes,2,opt,name=hostPublicKey"`
+	URL           string          `json:"url" protobuf:"bytes,1,opt,name=url"`
+	HostPublicKey []byte          `json:"hostPublicKey,omitempty" protobuf:"bytes,2,opt,name=hostPublicKey"`
+	S3            *BundleS3Config `json:"s3,omitempty" protobuf:"bytes,3,opt,name=s3"`
+}
+
+type BundleS3Config struct {
+	Endpoint string `json:"endpoint,omitempty" protobuf:"bytes,1,opt,name=endpoint"`
+	Region   string `json:"region,omitempty" protobuf:"bytes,2,opt,name=region"`
 }
 
 type BasicAuthentication struct {
@@ -596,10 +650,16 @@ type BasicAuthentication struct {
 	Password string `json:"password" protobuf:"bytes,2,opt,name=password"`
 }
 
+type AccessKey struct {
+	AccessKeyID     string `json:"accessKeyId" protobuf:"bytes,1,opt,name=accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey" protobuf:"bytes,2,opt,name=secretAccessKey"`
+}
+
 type BundleServerAuthConfiguration struct {
 	BearerToken         string               `json:"bearerToken,omitempty" protobuf:"bytes,1,opt,name=bearerToken"`
 	APIKey              string               `json:"apiKey,omitempty" protobuf:"bytes,2,opt,name=apiKey"`
 	BasicAuthentication *BasicAuthentication `json:"basicAuthentication,omitempty" protobuf:"bytes,3,opt,name=basicAuthentication"`
+	AccessKey           *AccessKey           `json:"accessKey,omitempty" protobuf:"bytes,4,opt,name=accessKey"`
 }
 
 // +genclient
@@ -615,6 +675,8 @@ type SupportBundleCollection struct {
 	SinceTime         string                        `json:"sinceTime,omitempty" protobuf:"bytes,3,opt,name=sinceTime"`
 	FileServer        BundleFileServer              `json:"fileServer,omitempty" protobuf:"bytes,4,opt,name=fileServer"`
 	Authentication    BundleServerAuthConfiguration `json:"authentication,omitempty" protobuf:"bytes,5,opt,name=authentication"`
+	Profile           string                        `json:"profile,omitempty" protobuf:"bytes,6,opt,name=profile"`
+	Redact            bool                          `json:"redact,omitempty" protobuf:"varint,7,opt,name=redact"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
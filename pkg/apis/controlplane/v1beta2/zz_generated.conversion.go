@@ -39,6 +39,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AccessKey)(nil), (*controlplane.AccessKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_AccessKey_To_controlplane_AccessKey(a.(*AccessKey), b.(*controlplane.AccessKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controlplane.AccessKey)(nil), (*AccessKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controlplane_AccessKey_To_v1beta2_AccessKey(a.(*controlplane.AccessKey), b.(*AccessKey), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AddressGroup)(nil), (*controlplane.AddressGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_AddressGroup_To_controlplane_AddressGroup(a.(*AddressGroup), b.(*controlplane.AddressGroup), scope)
 	}); err != nil {
@@ -119,6 +129,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*BundleS3Config)(nil), (*controlplane.BundleS3Config)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_BundleS3Config_To_controlplane_BundleS3Config(a.(*BundleS3Config), b.(*controlplane.BundleS3Config), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controlplane.BundleS3Config)(nil), (*BundleS3Config)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controlplane_BundleS3Config_To_v1beta2_BundleS3Config(a.(*controlplane.BundleS3Config), b.(*BundleS3Config), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*BundleServerAuthConfiguration)(nil), (*controlplane.BundleServerAuthConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_BundleServerAuthConfiguration_To_controlplane_BundleServerAuthConfiguration(a.(*BundleServerAuthConfiguration), b.(*controlplane.BundleServerAuthConfiguration), scope)
 	}); err != nil {
@@ -139,6 +159,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DNSProtocol)(nil), (*controlplane.DNSProtocol)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_DNSProtocol_To_controlplane_DNSProtocol(a.(*DNSProtocol), b.(*controlplane.DNSProtocol), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controlplane.DNSProtocol)(nil), (*DNSProtocol)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controlplane_DNSProtocol_To_v1beta2_DNSProtocol(a.(*controlplane.DNSProtocol), b.(*DNSProtocol), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*EgressGroup)(nil), (*controlplane.EgressGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_EgressGroup_To_controlplane_EgressGroup(a.(*EgressGroup), b.(*controlplane.EgressGroup), scope)
 	}); err != nil {
@@ -189,6 +219,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GRPCProtocol)(nil), (*controlplane.GRPCProtocol)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_GRPCProtocol_To_controlplane_GRPCProtocol(a.(*GRPCProtocol), b.(*controlplane.GRPCProtocol), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controlplane.GRPCProtocol)(nil), (*GRPCProtocol)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controlplane_GRPCProtocol_To_v1beta2_GRPCProtocol(a.(*controlplane.GRPCProtocol), b.(*GRPCProtocol), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GroupAssociation)(nil), (*controlplane.GroupAssociation)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_GroupAssociation_To_controlplane_GroupAssociation(a.(*GroupAssociation), b.(*controlplane.GroupAssociation), scope)
 	}); err != nil {
@@ -229,6 +269,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HTTPHeaderMatch)(nil), (*controlplane.HTTPHeaderMatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_HTTPHeaderMatch_To_controlplane_HTTPHeaderMatch(a.(*HTTPHeaderMatch), b.(*controlplane.HTTPHeaderMatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controlplane.HTTPHeaderMatch)(nil), (*HTTPHeaderMatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controlplane_HTTPHeaderMatch_To_v1beta2_HTTPHeaderMatch(a.(*controlplane.HTTPHeaderMatch), b.(*HTTPHeaderMatch), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HTTPProtocol)(nil), (*controlplane.HTTPProtocol)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_HTTPProtocol_To_controlplane_HTTPProtocol(a.(*HTTPProtocol), b.(*controlplane.HTTPProtocol), scope)
 	}); err != nil {
@@ -239,6 +289,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HTTPQueryParamMatch)(nil), (*controlplane.HTTPQueryParamMatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_HTTPQueryParamMatch_To_controlplane_HTTPQueryParamMatch(a.(*HTTPQueryParamMatch), b.(*controlplane.HTTPQueryParamMatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controlplane.HTTPQueryParamMatch)(nil), (*HTTPQueryParamMatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controlplane_HTTPQueryParamMatch_To_v1beta2_HTTPQueryParamMatch(a.(*controlplane.HTTPQueryParamMatch), b.(*HTTPQueryParamMatch), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IPBlock)(nil), (*controlplane.IPBlock)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_IPBlock_To_controlplane_IPBlock(a.(*IPBlock), b.(*controlplane.IPBlock), scope)
 	}); err != nil {
@@ -537,6 +597,28 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1beta2_AccessKey_To_controlplane_AccessKey(in *AccessKey, out *controlplane.AccessKey, s conversion.Scope) error {
+	out.AccessKeyID = in.AccessKeyID
+	out.SecretAccessKey = in.SecretAccessKey
+	return nil
+}
+
+// Convert_v1beta2_AccessKey_To_controlplane_AccessKey is an autogenerated conversion function.
+func Convert_v1beta2_AccessKey_To_controlplane_AccessKey(in *AccessKey, out *controlplane.AccessKey, s conversion.Scope) error {
+	return autoConvert_v1beta2_AccessKey_To_controlplane_AccessKey(in, out, s)
+}
+
+func autoConvert_controlplane_AccessKey_To_v1beta2_AccessKey(in *controlplane.AccessKey, out *AccessKey, s conversion.Scope) error {
+	out.AccessKeyID = in.AccessKeyID
+	out.SecretAccessKey = in.SecretAccessKey
+	return nil
+}
+
+// Convert_controlplane_AccessKey_To_v1beta2_AccessKey is an autogenerated conversion function.
+func Convert_controlplane_AccessKey_To_v1beta2_AccessKey(in *controlplane.AccessKey, out *AccessKey, s conversion.Scope) error {
+	return autoConvert_controlplane_AccessKey_To_v1beta2_AccessKey(in, out, s)
+}
+
 func autoConvert_v1beta2_AddressGroup_To_controlplane_AddressGroup(in *AddressGroup, out *controlplane.AddressGroup, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	out.GroupMembers = *(*[]controlplane.GroupMember)(unsafe.Pointer(&in.GroupMembers))
@@ -698,6 +780,7 @@ func Convert_controlplane_BasicAuthentication_To_v1beta2_BasicAuthentication(in
 func autoConvert_v1beta2_BundleFileServer_To_controlplane_BundleFileServer(in *BundleFileServer, out *controlplane.BundleFileServer, s conversion.Scope) error {
 	out.URL = in.URL
 	out.HostPublicKey = *(*[]byte)(unsafe.Pointer(&in.HostPublicKey))
+	out.S3 = (*controlplane.BundleS3Config)(unsafe.Pointer(in.S3))
 	return nil
 }
 
@@ -709,6 +792,7 @@ func Convert_v1beta2_BundleFileServer_To_controlplane_BundleFileServer(in *Bundl
 func autoConvert_controlplane_BundleFileServer_To_v1beta2_BundleFileServer(in *controlplane.BundleFileServer, out *BundleFileServer, s conversion.Scope) error {
 	out.URL = in.URL
 	out.HostPublicKey = *(*[]byte)(unsafe.Pointer(&in.HostPublicKey))
+	out.S3 = (*BundleS3Config)(unsafe.Pointer(in.S3))
 	return nil
 }
 
@@ -717,10 +801,33 @@ func Convert_controlplane_BundleFileServer_To_v1beta2_BundleFileServer(in *contr
 	return autoConvert_controlplane_BundleFileServer_To_v1beta2_BundleFileServer(in, out, s)
 }
 
+func autoConvert_v1beta2_BundleS3Config_To_controlplane_BundleS3Config(in *BundleS3Config, out *controlplane.BundleS3Config, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Region = in.Region
+	return nil
+}
+
+// Convert_v1beta2_BundleS3Config_To_controlplane_BundleS3Config is an autogenerated conversion function.
+func Convert_v1beta2_BundleS3Config_To_controlplane_BundleS3Config(in *BundleS3Config, out *controlplane.BundleS3Config, s conversion.Scope) error {
+	return autoConvert_v1beta2_BundleS3Config_To_controlplane_BundleS3Config(in, out, s)
+}
+
+func autoConvert_controlplane_BundleS3Config_To_v1beta2_BundleS3Config(in *controlplane.BundleS3Config, out *BundleS3Config, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Region = in.Region
+	return nil
+}
+
+// Convert_controlplane_BundleS3Config_To_v1beta2_BundleS3Config is an autogenerated conversion function.
+func Convert_controlplane_BundleS3Config_To_v1beta2_BundleS3Config(in *controlplane.BundleS3Config, out *BundleS3Config, s conversion.Scope) error {
+	return autoConvert_controlplane_BundleS3Config_To_v1beta2_BundleS3Config(in, out, s)
+}
+
 func autoConvert_v1beta2_BundleServerAuthConfiguration_To_controlplane_BundleServerAuthConfiguration(in *BundleServerAuthConfiguration, out *controlplane.BundleServerAuthConfiguration, s conversion.Scope) error {
 	out.BearerToken = in.BearerToken
 	out.APIKey = in.APIKey
 	out.BasicAuthentication = (*controlplane.BasicAuthentication)(unsafe.Pointer(in.BasicAuthentication))
+	out.AccessKey = (*controlplane.AccessKey)(unsafe.Pointer(in.AccessKey))
 	return nil
 }
 
@@ -733,6 +840,7 @@ func autoConvert_controlplane_BundleServerAuthConfiguration_To_v1beta2_BundleSer
 	out.BearerToken = in.BearerToken
 	out.APIKey = in.APIKey
 	out.BasicAuthentication = (*BasicAuthentication)(unsafe.Pointer(in.BasicAuthentication))
+	out.AccessKey = (*AccessKey)(unsafe.Pointer(in.AccessKey))
 	return nil
 }
 
@@ -771,6 +879,28 @@ func Convert_controlplane_ClusterGroupMembers_To_v1beta2_ClusterGroupMembers(in
 	return autoConvert_controlplane_ClusterGroupMembers_To_v1beta2_ClusterGroupMembers(in, out, s)
 }
 
+func autoConvert_v1beta2_DNSProtocol_To_controlplane_DNSProtocol(in *DNSProtocol, out *controlplane.DNSProtocol, s conversion.Scope) error {
+	out.QueryName = in.QueryName
+	out.QueryType = in.QueryType
+	return nil
+}
+
+// Convert_v1beta2_DNSProtocol_To_controlplane_DNSProtocol is an autogenerated conversion function.
+func Convert_v1beta2_DNSProtocol_To_controlplane_DNSProtocol(in *DNSProtocol, out *controlplane.DNSProtocol, s conversion.Scope) error {
+	return autoConvert_v1beta2_DNSProtocol_To_controlplane_DNSProtocol(in, out, s)
+}
+
+func autoConvert_controlplane_DNSProtocol_To_v1beta2_DNSProtocol(in *controlplane.DNSProtocol, out *DNSProtocol, s conversion.Scope) error {
+	out.QueryName = in.QueryName
+	out.QueryType = in.QueryType
+	return nil
+}
+
+// Convert_controlplane_DNSProtocol_To_v1beta2_DNSProtocol is an autogenerated conversion function.
+func Convert_controlplane_DNSProtocol_To_v1beta2_DNSProtocol(in *controlplane.DNSProtocol, out *DNSProtocol, s conversion.Scope) error {
+	return autoConvert_controlplane_DNSProtocol_To_v1beta2_DNSProtocol(in, out, s)
+}
+
 func autoConvert_v1beta2_EgressGroup_To_controlplane_EgressGroup(in *EgressGroup, out *controlplane.EgressGroup, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	out.GroupMembers = *(*[]controlplane.GroupMember)(unsafe.Pointer(&in.GroupMembers))
@@ -881,6 +1011,28 @@ func Convert_controlplane_ExternalEntityReference_To_v1beta2_ExternalEntityRefer
 	return autoConvert_controlplane_ExternalEntityReference_To_v1beta2_ExternalEntityReference(in, out, s)
 }
 
+func autoConvert_v1beta2_GRPCProtocol_To_controlplane_GRPCProtocol(in *GRPCProtocol, out *controlplane.GRPCProtocol, s conversion.Scope) error {
+	out.Service = in.Service
+	out.Method = in.Method
+	return nil
+}
+
+// Convert_v1beta2_GRPCProtocol_To_controlplane_GRPCProtocol is an autogenerated conversion function.
+func Convert_v1beta2_GRPCProtocol_To_controlplane_GRPCProtocol(in *GRPCProtocol, out *controlplane.GRPCProtocol, s conversion.Scope) error {
+	return autoConvert_v1beta2_GRPCProtocol_To_controlplane_GRPCProtocol(in, out, s)
+}
+
+func autoConvert_controlplane_GRPCProtocol_To_v1beta2_GRPCProtocol(in *controlplane.GRPCProtocol, out *GRPCProtocol, s conversion.Scope) error {
+	out.Service = in.Service
+	out.Method = in.Method
+	return nil
+}
+
+// Convert_controlplane_GRPCProtocol_To_v1beta2_GRPCProtocol is an autogenerated conversion function.
+func Convert_controlplane_GRPCProtocol_To_v1beta2_GRPCProtocol(in *controlplane.GRPCProtocol, out *GRPCProtocol, s conversion.Scope) error {
+	return autoConvert_controlplane_GRPCProtocol_To_v1beta2_GRPCProtocol(in, out, s)
+}
+
 func autoConvert_v1beta2_GroupAssociation_To_controlplane_GroupAssociation(in *GroupAssociation, out *controlplane.GroupAssociation, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	out.AssociatedGroups = *(*[]controlplane.GroupReference)(unsafe.Pointer(&in.AssociatedGroups))
@@ -987,10 +1139,35 @@ func Convert_controlplane_GroupReference_To_v1beta2_GroupReference(in *controlpl
 	return autoConvert_controlplane_GroupReference_To_v1beta2_GroupReference(in, out, s)
 }
 
+func autoConvert_v1beta2_HTTPHeaderMatch_To_controlplane_HTTPHeaderMatch(in *HTTPHeaderMatch, out *controlplane.HTTPHeaderMatch, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1beta2_HTTPHeaderMatch_To_controlplane_HTTPHeaderMatch is an autogenerated conversion function.
+func Convert_v1beta2_HTTPHeaderMatch_To_controlplane_HTTPHeaderMatch(in *HTTPHeaderMatch, out *controlplane.HTTPHeaderMatch, s conversion.Scope) error {
+	return autoConvert_v1beta2_HTTPHeaderMatch_To_controlplane_HTTPHeaderMatch(in, out, s)
+}
+
+func autoConvert_controlplane_HTTPHeaderMatch_To_v1beta2_HTTPHeaderMatch(in *controlplane.HTTPHeaderMatch, out *HTTPHeaderMatch, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_controlplane_HTTPHeaderMatch_To_v1beta2_HTTPHeaderMatch is an autogenerated conversion function.
+func Convert_controlplane_HTTPHeaderMatch_To_v1beta2_HTTPHeaderMatch(in *controlplane.HTTPHeaderMatch, out *HTTPHeaderMatch, s conversion.Scope) error {
+	return autoConvert_controlplane_HTTPHeaderMatch_To_v1beta2_HTTPHeaderMatch(in, out, s)
+}
+
 func autoConvert_v1beta2_HTTPProtocol_To_controlplane_HTTPProtocol(in *HTTPProtocol, out *controlplane.HTTPProtocol, s conversion.Scope) error {
 	out.Host = in.Host
 	out.Method = in.Method
 	out.Path = in.Path
+	out.PathRegex = in.PathRegex
+	out.Headers = *(*[]controlplane.HTTPHeaderMatch)(unsafe.Pointer(&in.Headers))
+	out.QueryParams = *(*[]controlplane.HTTPQueryParamMatch)(unsafe.Pointer(&in.QueryParams))
 	return nil
 }
 
@@ -1003,6 +1180,9 @@ func autoConvert_controlplane_HTTPProtocol_To_v1beta2_HTTPProtocol(in *controlpl
 	out.Host = in.Host
 	out.Method = in.Method
 	out.Path = in.Path
+	out.PathRegex = in.PathRegex
+	out.Headers = *(*[]HTTPHeaderMatch)(unsafe.Pointer(&in.Headers))
+	out.QueryParams = *(*[]HTTPQueryParamMatch)(unsafe.Pointer(&in.QueryParams))
 	return nil
 }
 
@@ -1011,6 +1191,28 @@ func Convert_controlplane_HTTPProtocol_To_v1beta2_HTTPProtocol(in *controlplane.
 	return autoConvert_controlplane_HTTPProtocol_To_v1beta2_HTTPProtocol(in, out, s)
 }
 
+func autoConvert_v1beta2_HTTPQueryParamMatch_To_controlplane_HTTPQueryParamMatch(in *HTTPQueryParamMatch, out *controlplane.HTTPQueryParamMatch, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1beta2_HTTPQueryParamMatch_To_controlplane_HTTPQueryParamMatch is an autogenerated conversion function.
+func Convert_v1beta2_HTTPQueryParamMatch_To_controlplane_HTTPQueryParamMatch(in *HTTPQueryParamMatch, out *controlplane.HTTPQueryParamMatch, s conversion.Scope) error {
+	return autoConvert_v1beta2_HTTPQueryParamMatch_To_controlplane_HTTPQueryParamMatch(in, out, s)
+}
+
+func autoConvert_controlplane_HTTPQueryParamMatch_To_v1beta2_HTTPQueryParamMatch(in *controlplane.HTTPQueryParamMatch, out *HTTPQueryParamMatch, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_controlplane_HTTPQueryParamMatch_To_v1beta2_HTTPQueryParamMatch is an autogenerated conversion function.
+func Convert_controlplane_HTTPQueryParamMatch_To_v1beta2_HTTPQueryParamMatch(in *controlplane.HTTPQueryParamMatch, out *HTTPQueryParamMatch, s conversion.Scope) error {
+	return autoConvert_controlplane_HTTPQueryParamMatch_To_v1beta2_HTTPQueryParamMatch(in, out, s)
+}
+
 func autoConvert_v1beta2_IPBlock_To_controlplane_IPBlock(in *IPBlock, out *controlplane.IPBlock, s conversion.Scope) error {
 	if err := Convert_v1beta2_IPNet_To_controlplane_IPNet(&in.CIDR, &out.CIDR, s); err != nil {
 		return err
@@ -1084,6 +1286,8 @@ func Convert_controlplane_IPNet_To_v1beta2_IPNet(in *controlplane.IPNet, out *IP
 func autoConvert_v1beta2_L7Protocol_To_controlplane_L7Protocol(in *L7Protocol, out *controlplane.L7Protocol, s conversion.Scope) error {
 	out.HTTP = (*controlplane.HTTPProtocol)(unsafe.Pointer(in.HTTP))
 	out.TLS = (*controlplane.TLSProtocol)(unsafe.Pointer(in.TLS))
+	out.GRPC = (*controlplane.GRPCProtocol)(unsafe.Pointer(in.GRPC))
+	out.DNS = (*controlplane.DNSProtocol)(unsafe.Pointer(in.DNS))
 	return nil
 }
 
@@ -1095,6 +1299,8 @@ func Convert_v1beta2_L7Protocol_To_controlplane_L7Protocol(in *L7Protocol, out *
 func autoConvert_controlplane_L7Protocol_To_v1beta2_L7Protocol(in *controlplane.L7Protocol, out *L7Protocol, s conversion.Scope) error {
 	out.HTTP = (*HTTPProtocol)(unsafe.Pointer(in.HTTP))
 	out.TLS = (*TLSProtocol)(unsafe.Pointer(in.TLS))
+	out.GRPC = (*GRPCProtocol)(unsafe.Pointer(in.GRPC))
+	out.DNS = (*DNSProtocol)(unsafe.Pointer(in.DNS))
 	return nil
 }
 
@@ -1734,6 +1940,8 @@ func autoConvert_v1beta2_SupportBundleCollection_To_controlplane_SupportBundleCo
 	if err := Convert_v1beta2_BundleServerAuthConfiguration_To_controlplane_BundleServerAuthConfiguration(&in.Authentication, &out.Authentication, s); err != nil {
 		return err
 	}
+	out.Profile = in.Profile
+	out.Redact = in.Redact
 	return nil
 }
 
@@ -1752,6 +1960,8 @@ func autoConvert_controlplane_SupportBundleCollection_To_v1beta2_SupportBundleCo
 	if err := Convert_controlplane_BundleServerAuthConfiguration_To_v1beta2_BundleServerAuthConfiguration(&in.Authentication, &out.Authentication, s); err != nil {
 		return err
 	}
+	out.Profile = in.Profile
+	out.Redact = in.Redact
 	return nil
 }
 
@@ -26,6 +26,22 @@ import (
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessKey) DeepCopyInto(out *AccessKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessKey.
+func (in *AccessKey) DeepCopy() *AccessKey {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddressGroup) DeepCopyInto(out *AddressGroup) {
 	*out = *in
@@ -262,6 +278,11 @@ func (in *BundleFileServer) DeepCopyInto(out *BundleFileServer) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(BundleS3Config)
+		**out = **in
+	}
 	return
 }
 
@@ -275,6 +296,22 @@ func (in *BundleFileServer) DeepCopy() *BundleFileServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleS3Config) DeepCopyInto(out *BundleS3Config) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleS3Config.
+func (in *BundleS3Config) DeepCopy() *BundleS3Config {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleS3Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundleServerAuthConfiguration) DeepCopyInto(out *BundleServerAuthConfiguration) {
 	*out = *in
@@ -283,6 +320,11 @@ func (in *BundleServerAuthConfiguration) DeepCopyInto(out *BundleServerAuthConfi
 		*out = new(BasicAuthentication)
 		**out = **in
 	}
+	if in.AccessKey != nil {
+		in, out := &in.AccessKey, &out.AccessKey
+		*out = new(AccessKey)
+		**out = **in
+	}
 	return
 }
 
@@ -336,6 +378,22 @@ func (in *ClusterGroupMembers) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProtocol) DeepCopyInto(out *DNSProtocol) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProtocol.
+func (in *DNSProtocol) DeepCopy() *DNSProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressGroup) DeepCopyInto(out *EgressGroup) {
 	*out = *in
@@ -479,6 +537,22 @@ func (in *ExternalEntityReference) DeepCopy() *ExternalEntityReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCProtocol) DeepCopyInto(out *GRPCProtocol) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCProtocol.
+func (in *GRPCProtocol) DeepCopy() *GRPCProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupAssociation) DeepCopyInto(out *GroupAssociation) {
 	*out = *in
@@ -618,9 +692,35 @@ func (in *GroupReference) DeepCopy() *GroupReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeaderMatch) DeepCopyInto(out *HTTPHeaderMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeaderMatch.
+func (in *HTTPHeaderMatch) DeepCopy() *HTTPHeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPProtocol) DeepCopyInto(out *HTTPProtocol) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryParams != nil {
+		in, out := &in.QueryParams, &out.QueryParams
+		*out = make([]HTTPQueryParamMatch, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -634,6 +734,22 @@ func (in *HTTPProtocol) DeepCopy() *HTTPProtocol {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPQueryParamMatch) DeepCopyInto(out *HTTPQueryParamMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPQueryParamMatch.
+func (in *HTTPQueryParamMatch) DeepCopy() *HTTPQueryParamMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPQueryParamMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in IPAddress) DeepCopyInto(out *IPAddress) {
 	{
@@ -736,13 +852,23 @@ func (in *L7Protocol) DeepCopyInto(out *L7Protocol) {
 	if in.HTTP != nil {
 		in, out := &in.HTTP, &out.HTTP
 		*out = new(HTTPProtocol)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(TLSProtocol)
 		**out = **in
 	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPCProtocol)
+		**out = **in
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSProtocol)
+		**out = **in
+	}
 	return
 }
 
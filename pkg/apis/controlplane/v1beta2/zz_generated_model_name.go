@@ -19,6 +19,11 @@
 
 package v1beta2
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in AccessKey) OpenAPIModelName() string {
+	return "io.antrea.controlplane.v1beta2.AccessKey"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in AddressGroup) OpenAPIModelName() string {
 	return "io.antrea.controlplane.v1beta2.AddressGroup"
@@ -59,6 +64,11 @@ func (in BundleFileServer) OpenAPIModelName() string {
 	return "io.antrea.controlplane.v1beta2.BundleFileServer"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in BundleS3Config) OpenAPIModelName() string {
+	return "io.antrea.controlplane.v1beta2.BundleS3Config"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in BundleServerAuthConfiguration) OpenAPIModelName() string {
 	return "io.antrea.controlplane.v1beta2.BundleServerAuthConfiguration"
@@ -69,6 +79,11 @@ func (in ClusterGroupMembers) OpenAPIModelName() string {
 	return "io.antrea.controlplane.v1beta2.ClusterGroupMembers"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in DNSProtocol) OpenAPIModelName() string {
+	return "io.antrea.controlplane.v1beta2.DNSProtocol"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in EgressGroup) OpenAPIModelName() string {
 	return "io.antrea.controlplane.v1beta2.EgressGroup"
@@ -94,6 +109,11 @@ func (in ExternalEntityReference) OpenAPIModelName() string {
 	return "io.antrea.controlplane.v1beta2.ExternalEntityReference"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in GRPCProtocol) OpenAPIModelName() string {
+	return "io.antrea.controlplane.v1beta2.GRPCProtocol"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in GroupAssociation) OpenAPIModelName() string {
 	return "io.antrea.controlplane.v1beta2.GroupAssociation"
@@ -114,11 +134,21 @@ func (in GroupReference) OpenAPIModelName() string {
 	return "io.antrea.controlplane.v1beta2.GroupReference"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HTTPHeaderMatch) OpenAPIModelName() string {
+	return "io.antrea.controlplane.v1beta2.HTTPHeaderMatch"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in HTTPProtocol) OpenAPIModelName() string {
 	return "io.antrea.controlplane.v1beta2.HTTPProtocol"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HTTPQueryParamMatch) OpenAPIModelName() string {
+	return "io.antrea.controlplane.v1beta2.HTTPQueryParamMatch"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in IPBlock) OpenAPIModelName() string {
 	return "io.antrea.controlplane.v1beta2.IPBlock"
@@ -63,6 +63,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&BGPPolicyList{},
 		&PacketCapture{},
 		&PacketCaptureList{},
+		&ConnectivityProbe{},
+		&ConnectivityProbeList{},
 	)
 
 	metav1.AddToGroupVersion(
@@ -107,8 +107,52 @@ type SupportBundleCollectionSpec struct {
 	SinceTime      string                        `json:"sinceTime,omitempty"`
 	FileServer     BundleFileServer              `json:"fileServer"`
 	Authentication BundleServerAuthConfiguration `json:"authentication"`
+	// Profile specifies which information is collected in the bundle.
+	// Default is All.
+	// +optional
+	Profile SupportBundleCollectionProfile `json:"profile,omitempty"`
+	// Redact indicates whether the IP addresses, the names of the Kubernetes
+	// resources and the label values are pseudonymized in all the files of the
+	// bundle before it is uploaded. Values sourced from Secrets and ConfigMaps,
+	// e.g. in environment variables or configuration files, are not redacted.
+	// +optional
+	Redact bool `json:"redact,omitempty"`
+	// Components specifies the Antrea components, other than the Agents, whose
+	// bundles are also collected and uploaded to the file server.
+	// +optional
+	Components *BundleComponents `json:"components,omitempty"`
 }
 
+// BundleComponents specifies the Antrea components whose bundles are collected in
+// addition to the bundles of the Nodes and ExternalNodes.
+type BundleComponents struct {
+	// Controller indicates whether the bundle of the antrea-controller is collected.
+	// +optional
+	Controller bool `json:"controller,omitempty"`
+	// FlowAggregator indicates whether the bundle of the flow-aggregator is collected.
+	// +optional
+	FlowAggregator bool `json:"flowAggregator,omitempty"`
+	// FlowAggregatorNamespace is the Namespace in which the flow-aggregator is deployed.
+	// Default is flow-aggregator.
+	// +optional
+	FlowAggregatorNamespace string `json:"flowAggregatorNamespace,omitempty"`
+}
+
+// SupportBundleCollectionProfile defines the information collected in a bundle.
+type SupportBundleCollectionProfile string
+
+const (
+	// SupportBundleProfileAll collects all the available information.
+	SupportBundleProfileAll SupportBundleCollectionProfile = "All"
+	// SupportBundleProfileLogsOnly only collects the logs.
+	SupportBundleProfileLogsOnly SupportBundleCollectionProfile = "LogsOnly"
+	// SupportBundleProfileDatapathOnly only collects the OVS flows, groups and ports,
+	// and the host network configuration.
+	SupportBundleProfileDatapathOnly SupportBundleCollectionProfile = "DatapathOnly"
+	// SupportBundleProfilePolicyOnly only collects the NetworkPolicy resources.
+	SupportBundleProfilePolicyOnly SupportBundleCollectionProfile = "PolicyOnly"
+)
+
 type SupportBundleCollectionStatus struct {
 	// The number of Nodes and ExternalNodes that have completed the SupportBundleCollection.
 	CollectedNodes int32 `json:"collectedNodes"`
@@ -174,12 +218,28 @@ type BundleExternalNodes struct {
 // BundleFileServer specifies the bundle file server information.
 type BundleFileServer struct {
 	// The URL of the bundle file server. It is set with format: scheme://host[:port][/path],
-	// e.g, https://api.example.com:8443/v1/supportbundles/. If scheme is not set, https is used by default.
+	// e.g, https://api.example.com:8443/v1/supportbundles/. The supported schemes are sftp, s3,
+	// http and https. For s3, the host is the name of the bucket. If scheme is not set, sftp is
+	// used by default.
 	URL string `json:"url"`
 	// HostPublicKey specifies the only host public key that will be accepted when connecting to
 	// the file server. If omitted, any host key will be accepted, which is not recommended.
 	// For SFTP, the key must be formatted for use in the SSH wire protocol according to RFC 4253, section 6.6.
 	HostPublicKey []byte `json:"hostPublicKey,omitempty"`
+	// S3 specifies the configuration of the S3 service. It can only be set when the URL uses the `s3` protocol.
+	// +optional
+	S3 *BundleS3Config `json:"s3,omitempty"`
+}
+
+// BundleS3Config specifies how to access the S3 service used to store the bundle files.
+type BundleS3Config struct {
+	// Endpoint is the URL of an S3-compatible service, e.g., http://minio.minio.svc:9000. Path-style addressing is
+	// used when it is set. If not specified, AWS S3 is used.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region is the region of the bucket. Default is us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
 }
 
 // BundleServerAuthType defines the authentication type to access the BundleFileServer.
@@ -189,6 +249,7 @@ const (
 	APIKey              BundleServerAuthType = "APIKey"
 	BearerToken         BundleServerAuthType = "BearerToken"
 	BasicAuthentication BundleServerAuthType = "BasicAuthentication"
+	AccessKey           BundleServerAuthType = "AccessKey"
 )
 
 // BundleServerAuthConfiguration defines the authentication parameters that Antrea uses to access
@@ -217,8 +278,53 @@ type NodeLatencyMonitorSpec struct {
 	// PingInterval specifies the interval in seconds between ping requests.
 	// Ping interval should be greater than or equal to 1s.
 	PingIntervalSeconds int32 `json:"pingIntervalSeconds"`
+	// ProbeType specifies the type of the probes used to measure latency. Defaults to ICMP.
+	// +optional
+	ProbeType NodeLatencyProbeType `json:"probeType,omitempty"`
+	// ProbePort is the port to which TCP and UDP probes are sent. Each Antrea Agent listens on
+	// this port to answer the probes sent by other Nodes. Defaults to 10352.
+	// +optional
+	ProbePort int32 `json:"probePort,omitempty"`
+	// NodeSelector selects the peer Nodes to which probes are sent. If not set, all Nodes are
+	// selected.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// MaxTargetNodes is the maximum number of peer Nodes to which each Antrea Agent sends
+	// probes. When more Nodes are selected, each Agent probes a different subset of them, which
+	// is stable as long as the set of selected Nodes does not change. 0 means no limit.
+	// +optional
+	MaxTargetNodes int32 `json:"maxTargetNodes,omitempty"`
+	// Thresholds configures the thresholds above which the connection to a peer Node is
+	// considered degraded. When a threshold is crossed, the Antrea Agent emits an Event and
+	// reports the peer Node in the NodeLatencyHealthy condition of its AntreaAgentInfo.
+	// +optional
+	Thresholds *NodeLatencyThresholds `json:"thresholds,omitempty"`
+}
+
+// NodeLatencyThresholds defines the thresholds above which the connection to a peer Node is
+// considered degraded. At least one threshold must be set.
+type NodeLatencyThresholds struct {
+	// LatencyMilliseconds is the threshold for the RTT to the peer Node, in milliseconds. It is
+	// only considered exceeded, or no longer exceeded, once 3 consecutive RTT measurements are
+	// above, or within, the threshold. 0 means no threshold.
+	// +optional
+	LatencyMilliseconds int32 `json:"latencyMilliseconds,omitempty"`
+	// PacketLossPercent is the threshold for the percentage of lost probes among the most
+	// recent probes sent to the peer Node. 0 means no threshold.
+	// +optional
+	PacketLossPercent int32 `json:"packetLossPercent,omitempty"`
 }
 
+type NodeLatencyProbeType string
+
+const (
+	NodeLatencyProbeTypeICMP NodeLatencyProbeType = "ICMP"
+	// TCP probes measure the time taken to establish a TCP connection.
+	NodeLatencyProbeTypeTCP NodeLatencyProbeType = "TCP"
+	// UDP probes are echoed back by the Antrea Agent running on the peer Node.
+	NodeLatencyProbeTypeUDP NodeLatencyProbeType = "UDP"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // NodeLatencyMonitor is only a singleton resource, so it does not use a list type.
@@ -235,7 +341,6 @@ type NodeLatencyMonitorList struct {
 
 // +genclient
 // +genclient:nonNamespaced
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // BGPPolicy defines BGP configuration applied to Nodes.
@@ -244,7 +349,8 @@ type BGPPolicy struct {
 	// Standard metadata of the object.
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec BGPPolicySpec `json:"spec"`
+	Spec   BGPPolicySpec   `json:"spec"`
+	Status BGPPolicyStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -277,18 +383,72 @@ type BGPPolicySpec struct {
 
 	// BGPPeers is the list of BGP peers.
 	BGPPeers []BGPPeer `json:"bgpPeers,omitempty"`
+
+	// ReceivedRoutes configures the installation of routes received from BGP peers into the host routing table. If
+	// omitted, received routes are not installed. It is only supported on Linux Nodes.
+	ReceivedRoutes *ReceivedRoutes `json:"receivedRoutes,omitempty"`
+}
+
+// BGPPolicyStatus is the status of a BGPPolicy. It is reported by the Antrea Agents of the Nodes on which the BGPPolicy
+// is effective.
+type BGPPolicyStatus struct {
+	// Nodes is the list of the Nodes on which the BGPPolicy is effective, with the status of their BGP peers.
+	Nodes []BGPPolicyNodeStatus `json:"nodes,omitempty"`
+}
+
+type BGPPolicyNodeStatus struct {
+	// NodeName is the name of the Node.
+	NodeName string `json:"nodeName"`
+
+	// RouterID is the BGP router ID used by the BGP process on the Node.
+	RouterID string `json:"routerID,omitempty"`
+
+	// Peers is the status of the BGP peers of the Node.
+	Peers []BGPPeerStatus `json:"peers,omitempty"`
+
+	// LastUpdateTime is the last time the status of the Node was updated.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+type BGPPeerStatus struct {
+	// Address is the IP address of the BGP peer.
+	Address string `json:"address"`
+
+	// ASN is the AS number of the BGP peer.
+	ASN int32 `json:"asn"`
+
+	// SessionState is the state of the BGP session with the BGP peer, e.g. "Established" or "Active".
+	SessionState string `json:"sessionState"`
+
+	// EstablishedTime is the time when the BGP session was established. It is unset if the BGP session is not
+	// established. The uptime of the BGP session can be derived from it.
+	EstablishedTime *metav1.Time `json:"establishedTime,omitempty"`
+
+	// AdvertisedRoutes is the number of routes advertised to the BGP peer.
+	AdvertisedRoutes int32 `json:"advertisedRoutes"`
+
+	// ReceivedRoutes is the number of routes received from the BGP peer.
+	ReceivedRoutes int32 `json:"receivedRoutes"`
+
+	// BFDSessionState is the state of the BFD session with the BGP peer, e.g. "Up" or "Down". It is unset if BFD is
+	// disabled for the BGP peer.
+	BFDSessionState string `json:"bfdSessionState,omitempty"`
+}
+
+type ReceivedRoutes struct {
+	// AllowedPrefixes is the list of CIDRs within which received routes can be installed. A received route is
+	// installed only if its prefix is within one of the CIDRs. The import policies of BGP peers still apply.
+	AllowedPrefixes []string `json:"allowedPrefixes"`
 }
 
 type Advertisements struct {
 	// Service specifies how to advertise Service IPs.
 	Service *ServiceAdvertisement `json:"service,omitempty"`
 
-	// Pod specifies how to advertise Pod IPs. Currently, if this is set, NodeIPAM Pod CIDR instead of specific Pods IPs
-	// will be advertised since pod selector is not added yet.
+	// Pod specifies how to advertise Pod IPs.
 	Pod *PodAdvertisement `json:"pod,omitempty"`
 
-	// Egress specifies how to advertise Egress IPs. Currently, if this is set, all Egress IPs will be advertised since
-	// Egress selector is not added yet.
+	// Egress specifies how to advertise Egress IPs.
 	Egress *EgressAdvertisement `json:"egress,omitempty"`
 }
 
@@ -309,22 +469,47 @@ const (
 )
 
 type ServiceAdvertisement struct {
-	// IPTypes specifies the types of Service IPs from the selected Services to be advertised. Currently, all Services
-	// will be selected since Service selector is not added yet.
+	// IPTypes specifies the types of Service IPs from the selected Services to be advertised.
 	IPTypes []ServiceIPType `json:"ipTypes,omitempty"`
 
-	// Empty now, selectors to be added later, which are used to select specific Services.
-	// Selectors []Selector `json:"selectors,omitempty"`
+	// Selectors select the Services whose IPs are advertised. A Service is selected if it matches any of the
+	// selectors. If empty, all Services are selected.
+	Selectors []ServiceAdvertisementSelector `json:"selectors,omitempty"`
+}
+
+type ServiceAdvertisementSelector struct {
+	// Select Services by their labels. If not set, all Services in the selected Namespaces are selected.
+	ServiceSelector *metav1.LabelSelector `json:"serviceSelector,omitempty"`
+
+	// Select the Namespaces of Services by their labels. If not set, the Services in all Namespaces are selected.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 type PodAdvertisement struct {
-	// Empty now, selectors to be added later, which are used to select specific Pods.
-	// Selectors []Selector `json:"selectors,omitempty"`
+	// Selectors select the Pods whose IPs are advertised. A Pod is selected if it matches any of the selectors. If
+	// empty, the NodeIPAM Pod CIDR of the Node is advertised. Otherwise, the IPs of the selected Pods running on the
+	// Node are advertised individually, which is required to advertise the Pods whose IPs are not allocated from the
+	// NodeIPAM Pod CIDR, e.g. the Pods using AntreaIPAM IPPools.
+	Selectors []PodAdvertisementSelector `json:"selectors,omitempty"`
+}
+
+type PodAdvertisementSelector struct {
+	// Select Pods by their labels. If not set, all Pods in the selected Namespaces are selected.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// Select the Namespaces of Pods by their labels. If not set, the Pods in all Namespaces are selected.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 type EgressAdvertisement struct {
-	// Empty now, selectors to be added later, which are used to select specific Egresses.
-	// Selectors []Selector `json:"selectors,omitempty"`
+	// Selectors select the Egresses whose IPs are advertised. An Egress is selected if it matches any of the
+	// selectors. If empty, all Egresses are selected.
+	Selectors []EgressAdvertisementSelector `json:"selectors,omitempty"`
+}
+
+type EgressAdvertisementSelector struct {
+	// Select Egresses by their labels. If not set, all Egresses are selected.
+	EgressSelector *metav1.LabelSelector `json:"egressSelector,omitempty"`
 }
 
 type BGPPeer struct {
@@ -344,6 +529,102 @@ type BGPPeer struct {
 	// GracefulRestartTimeSeconds specifies how long the BGP peer would wait for the BGP session to re-establish after
 	// a restart before deleting stale routes. The range of the value is from 1 to 3600, and the default value is 120.
 	GracefulRestartTimeSeconds *int32 `json:"gracefulRestartTimeSeconds,omitempty"`
+
+	// ExportPolicy specifies which routes are advertised to the BGP peer, and the path attributes set on them. If
+	// unset, all routes are advertised to the BGP peer without additional path attributes.
+	ExportPolicy *BGPRoutePolicy `json:"exportPolicy,omitempty"`
+
+	// ImportPolicy specifies which routes received from the BGP peer are accepted, and the path attributes set on
+	// them. If unset, all routes received from the BGP peer are accepted.
+	ImportPolicy *BGPRoutePolicy `json:"importPolicy,omitempty"`
+
+	// BFD enables Bidirectional Forwarding Detection (BFD) for the BGP session with the BGP peer, so that a failure of
+	// the path to the BGP peer tears down the BGP session without waiting for the hold timer to expire. Only
+	// single-hop BFD is supported, so the BGP peer must be directly connected. If unset, BFD is disabled.
+	BFD *BGPPeerBFD `json:"bfd,omitempty"`
+}
+
+// BGPPeerBFD defines the BFD configuration for a BGP peer.
+type BGPPeerBFD struct {
+	// MinTxIntervalMilliseconds is the desired minimum interval between the BFD Control packets sent to the BGP peer.
+	// The range of the value is from 10 to 60000, and the default value is 300.
+	MinTxIntervalMilliseconds *int32 `json:"minTxIntervalMilliseconds,omitempty"`
+
+	// MinRxIntervalMilliseconds is the required minimum interval between the BFD Control packets received from the
+	// BGP peer. The range of the value is from 10 to 60000, and the default value is 300.
+	MinRxIntervalMilliseconds *int32 `json:"minRxIntervalMilliseconds,omitempty"`
+
+	// Multiplier is the number of BFD Control packets which can be missed before the BFD session is considered down.
+	// The range of the value is from 1 to 255, and the default value is 3.
+	Multiplier *int32 `json:"multiplier,omitempty"`
+}
+
+type BGPRouteAction string
+
+const (
+	BGPRouteActionAccept BGPRouteAction = "Accept"
+	BGPRouteActionReject BGPRouteAction = "Reject"
+)
+
+type BGPRouteType string
+
+const (
+	BGPRouteTypeServiceClusterIP      BGPRouteType = "ServiceClusterIP"
+	BGPRouteTypeServiceExternalIP     BGPRouteType = "ServiceExternalIP"
+	BGPRouteTypeServiceLoadBalancerIP BGPRouteType = "ServiceLoadBalancerIP"
+	BGPRouteTypeEgressIP              BGPRouteType = "EgressIP"
+	BGPRouteTypeNodeIPAMPodCIDR       BGPRouteType = "NodeIPAMPodCIDR"
+	BGPRouteTypePodIP                 BGPRouteType = "PodIP"
+)
+
+// BGPRoutePolicy defines a policy applied to the routes advertised to or received from a BGP peer.
+type BGPRoutePolicy struct {
+	// Rules is an ordered list of route policy rules. A route is handled by the first rule it matches.
+	Rules []BGPRoutePolicyRule `json:"rules,omitempty"`
+
+	// DefaultAction is the action taken on the routes which don't match any rule. The default value is Accept.
+	DefaultAction BGPRouteAction `json:"defaultAction,omitempty"`
+}
+
+type BGPRoutePolicyRule struct {
+	// Match selects the routes to which the rule applies. If empty, all routes are selected.
+	Match BGPRouteMatch `json:"match,omitempty"`
+
+	// Action is the action taken on the selected routes. The default value is Accept.
+	Action BGPRouteAction `json:"action,omitempty"`
+
+	// Attributes specifies the path attributes set on the selected routes when they are accepted.
+	Attributes *BGPPathAttributes `json:"attributes,omitempty"`
+}
+
+// BGPRouteMatch selects routes by their types and prefixes. When both fields are set, a route must match both of
+// them.
+type BGPRouteMatch struct {
+	// RouteTypes selects the routes advertised by Antrea of the given types. As the routes received from BGP peers
+	// are not of any of these types, a rule of an ImportPolicy with this field set never matches.
+	RouteTypes []BGPRouteType `json:"routeTypes,omitempty"`
+
+	// Prefixes selects the routes whose prefixes are within any of the given CIDRs.
+	Prefixes []string `json:"prefixes,omitempty"`
+}
+
+// BGPPathAttributes defines the BGP path attributes set on routes.
+type BGPPathAttributes struct {
+	// Communities is the list of BGP communities (RFC 1997) added to the routes. A community can be specified in
+	// the format "<ASN>:<value>", where both parts are 16-bit integers, or with a well-known community name such as
+	// "no-export", "no-advertise" or "no-export-subconfed".
+	Communities []string `json:"communities,omitempty"`
+
+	// LargeCommunities is the list of BGP large communities (RFC 8092) added to the routes, in the format
+	// "<global administrator>:<local data part 1>:<local data part 2>", where all parts are 32-bit integers.
+	LargeCommunities []string `json:"largeCommunities,omitempty"`
+
+	// LocalPreference is the LOCAL_PREF attribute set on the routes. It is only exchanged with internal BGP peers
+	// when set in an ExportPolicy.
+	LocalPreference *int64 `json:"localPreference,omitempty"`
+
+	// MED is the MULTI_EXIT_DISC attribute set on the routes.
+	MED *int64 `json:"med,omitempty"`
 }
 
 type PodReference struct {
@@ -351,6 +632,11 @@ type PodReference struct {
 	Name      string `json:"name"`
 }
 
+type ServiceReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
 // Source describes the source spec of the packetcapture.
 type Source struct {
 	// Pod is the source Pod, mutually exclusive with IP.
@@ -361,8 +647,11 @@ type Source struct {
 
 // Destination describes the destination spec of the PacketCapture.
 type Destination struct {
-	// Pod is the destination Pod, exclusive with destination IP.
+	// Pod is the destination Pod, exclusive with destination Service and IP.
 	Pod *PodReference `json:"pod,omitempty"`
+	// Service is the destination Service, exclusive with destination Pod and IP. The packets sent to the ClusterIP and
+	// the ports of the Service are captured, before they are load-balanced to the Endpoints of the Service.
+	Service *ServiceReference `json:"service,omitempty"`
 	// IP is the destination IPv4 or IPv6 address.
 	IP *string `json:"ip,omitempty"`
 }
@@ -490,21 +779,102 @@ type PacketCapture struct {
 	Status            PacketCaptureStatus `json:"status"`
 }
 
+// PacketCaptureDurationConfig contains the config for the Duration type capture, meaning capturing all the target
+// packets for the specified period of time.
+type PacketCaptureDurationConfig struct {
+	// Seconds is the duration of the capture in seconds. It must not be greater than the timeout of the capture.
+	Seconds int32 `json:"seconds"`
+}
+
+// PacketCaptureTrigger describes the packets which stop a RingBuffer type capture.
+// At most one of Packet and PolicyRule can be set.
+type PacketCaptureTrigger struct {
+	// Packet defines the header fields of the trigger packets, which are matched against the packets captured
+	// between the source and destination. The IP family is always the one of the captured packets. If neither Packet
+	// nor PolicyRule is specified, any captured packet is a trigger packet.
+	Packet *Packet `json:"packet,omitempty"`
+	// PolicyRule triggers the capture when a packet between the source and destination is dropped or rejected by
+	// a rule of an Antrea-native policy on the Node performing the capture.
+	PolicyRule *PacketCapturePolicyRuleTrigger `json:"policyRule,omitempty"`
+}
+
+type PacketCapturePolicyType string
+
+const (
+	PacketCapturePolicyTypeAntreaClusterNetworkPolicy PacketCapturePolicyType = "AntreaClusterNetworkPolicy"
+	PacketCapturePolicyTypeAntreaNetworkPolicy        PacketCapturePolicyType = "AntreaNetworkPolicy"
+)
+
+// PacketCapturePolicyRuleTrigger references the Antrea-native policy rule whose drops trigger a RingBuffer capture.
+// The agent is only notified of the packets dropped by a rule if the rule has logging enabled, if its action is
+// Reject, or if the FlowExporter is enabled. Otherwise the capture is not started.
+type PacketCapturePolicyRuleTrigger struct {
+	// Type is the type of the policy: AntreaClusterNetworkPolicy or AntreaNetworkPolicy.
+	Type PacketCapturePolicyType `json:"type"`
+	// Namespace is the Namespace of the policy. It must be set if and only if Type is AntreaNetworkPolicy.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the policy.
+	Name string `json:"name"`
+	// RuleName is the name of the rule. If not specified, a drop or reject by any rule of the policy triggers the
+	// capture.
+	RuleName string `json:"ruleName,omitempty"`
+}
+
+// PacketCaptureRingBufferConfig contains the config for the RingBuffer type capture, meaning keeping the last N
+// captured packets in a rolling buffer until a trigger packet is captured.
+type PacketCaptureRingBufferConfig struct {
+	// Number is the maximum number of packets kept in the buffer. When the buffer is full, the oldest packet is
+	// dropped to make room for a new one.
+	Number int32 `json:"number"`
+	// Trigger specifies the packets which stop the capture. The packets in the buffer, including the trigger packet,
+	// are saved when the capture is triggered. If the capture times out before being triggered, the packets in the
+	// buffer are saved as well.
+	Trigger PacketCaptureTrigger `json:"trigger"`
+	// AfterTrigger is the number of packets which are still captured after the trigger, before the capture stops.
+	// If not specified, the capture stops right after the trigger.
+	AfterTrigger int32 `json:"afterTrigger,omitempty"`
+}
+
 type CaptureConfig struct {
 	// FirstN means we only capture first N packets from the target traffic.
-	// At the moment this is the only supported configuration and it is required for every capture.
+	// Exactly one of FirstN, Duration and RingBuffer must be specified for every capture.
 	FirstN *PacketCaptureFirstNConfig `json:"firstN,omitempty"`
+	// Duration means we capture all the target traffic for a period of time.
+	Duration *PacketCaptureDurationConfig `json:"duration,omitempty"`
+	// RingBuffer means we keep the last N packets of the target traffic until a trigger packet is captured.
+	RingBuffer *PacketCaptureRingBufferConfig `json:"ringBuffer,omitempty"`
+	// MaxBytes is the maximum total size in bytes of the saved packets. For FirstN and Duration captures, the capture
+	// stops when the limit is reached. For RingBuffer captures, the oldest packets are dropped from the buffer to
+	// keep it under the limit. If not specified, there is no limit.
+	MaxBytes *int64 `json:"maxBytes,omitempty"`
+	// SnapLen is the maximum number of bytes saved for each packet. Bytes beyond it are truncated, which can be used
+	// to capture the headers only and leave the payloads out. If not specified, defaults to 65536.
+	SnapLen *int32 `json:"snapLen,omitempty"`
 }
 
 // PacketCaptureFileServer specifies the PacketCapture file server information.
 type PacketCaptureFileServer struct {
-	// The URL of the file server. It is set with format: scheme://host[:port][/path],
-	// e.g., https://api.example.com:8443/v1/packets/. Currently only `sftp` protocol is supported.
+	// The URL of the file server. The following protocols are supported:
+	// - `sftp`: the URL is set with format sftp://host:port[/path], e.g., sftp://10.0.0.1:22/upload.
+	// - `s3`: the URL is set with format s3://bucket[/prefix], e.g., s3://packets/cluster-1.
+	// - `http` and `https`: the file is uploaded with a PUT request to the URL with the file name appended to its
+	//   path, e.g., https://api.example.com:8443/v1/packets/.
 	URL string `json:"url"`
 	// HostPublicKey specifies the only host public key that will be accepted when connecting to
 	// the file server. If omitted, any host key will be accepted, which is not recommended.
 	// For SFTP, the key must be formatted for use in the SSH wire protocol according to RFC 4253, section 6.6.
 	HostPublicKey []byte `json:"hostPublicKey,omitempty"`
+	// S3 specifies the configuration of the S3 service. It can only be set when the URL uses the `s3` protocol.
+	S3 *PacketCaptureS3Config `json:"s3,omitempty"`
+}
+
+// PacketCaptureS3Config specifies how to access the S3 service used to store the captured packets.
+type PacketCaptureS3Config struct {
+	// Endpoint is the URL of an S3-compatible service, e.g., http://minio.minio.svc:9000. Path-style addressing is
+	// used when it is set. If not specified, AWS S3 is used.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region is the region of the bucket. If not specified, defaults to us-east-1.
+	Region string `json:"region,omitempty"`
 }
 
 type CaptureDirection string
@@ -520,22 +890,60 @@ type CapturePoint string
 const (
 	CapturePointSource      CapturePoint = "Source"
 	CapturePointDestination CapturePoint = "Destination"
+	// CapturePointBoth means packets are captured on the interfaces of both the source and destination Pods, which
+	// may be on different Nodes.
+	CapturePointBoth CapturePoint = "Both"
+)
+
+type PacketCapturePortType string
+
+const (
+	// PacketCapturePortTypeGateway is the gateway port of the Node, connecting the OVS bridge to the host network.
+	PacketCapturePortTypeGateway PacketCapturePortType = "Gateway"
+	// PacketCapturePortTypeTunnel is the tunnel port of the Node. The packets are captured without the encapsulation
+	// headers, which is only supported with the Geneve and VXLAN tunnel types.
+	PacketCapturePortTypeTunnel PacketCapturePortType = "Tunnel"
+	// PacketCapturePortTypeUplink is the transport interface of the Node, used to send the traffic to other Nodes.
+	PacketCapturePortTypeUplink PacketCapturePortType = "Uplink"
+	// PacketCapturePortTypeOVSPort is a port of the OVS bridge selected by its name.
+	PacketCapturePortTypeOVSPort PacketCapturePortType = "OVSPort"
 )
 
+// PacketCaptureNode specifies a port of one or multiple Nodes on which packets are captured. Exactly one of Name or
+// NodeSelector must be set.
+type PacketCaptureNode struct {
+	// Name is the name of the Node.
+	Name string `json:"name,omitempty"`
+	// NodeSelector selects the Nodes on which packets are captured. The packets captured on each Node are reported
+	// separately in the status.
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// Port is the type of the port on which packets are captured.
+	Port PacketCapturePortType `json:"port"`
+	// PortName is the name of the OVS port. It must be set if and only if Port is OVSPort.
+	PortName string `json:"portName,omitempty"`
+}
+
 type PacketCaptureSpec struct {
 	// Timeout is the timeout for this capture session. If not specified, defaults to 60s.
 	Timeout       *int32        `json:"timeout,omitempty"`
 	CaptureConfig CaptureConfig `json:"captureConfig"`
 	// Source is the traffic source we want to perform capture on. At least one of Source or Destination must be specified
-	// for a capture session, and at least one `Pod` should be present either in the source or the destination.
+	// for a capture session, and at least one `Pod` should be present either in the source or the destination, unless
+	// Node is set.
 	Source      Source      `json:"source"`
 	Destination Destination `json:"destination"`
 	// Direction specifies which packets to capture (source -> destination, destination -> source or both).
 	// If not specified, defaults to SourceToDestination.
 	Direction CaptureDirection `json:"direction,omitempty"`
-	// CapturePoint specifies where to perform the packet capture: 'Source' or 'Destination'.
-	// If not set, it defaults to 'Source' when source.pod is available, otherwise 'Destination'.
+	// CapturePoint specifies where to perform the packet capture: 'Source', 'Destination' or 'Both'. With 'Both',
+	// source.pod and destination.pod must be set, and packets are captured on the Nodes of both Pods.
+	// If not set, it defaults to 'Source' when source.pod is available, otherwise 'Destination'. It must not be set
+	// when Node is set.
 	CapturePoint CapturePoint `json:"capturePoint,omitempty"`
+	// Node specifies a port of a Node on which packets are captured, instead of the interface of the source or
+	// destination Pod. Source and Destination are then only used to filter the captured packets, and may both be
+	// empty to capture all the packets going through the port.
+	Node *PacketCaptureNode `json:"node,omitempty"`
 	// Packet defines what kind of traffic we want to capture between the source and destination. If not specified,
 	// all kinds of traffic will count.
 	Packet *Packet `json:"packet,omitempty"`
@@ -547,16 +955,60 @@ type PacketCaptureSpec struct {
 
 type PacketCaptureStatus struct {
 	// NumberCaptured records how many packets have been captured. If it reaches the target number, the capture
-	// can be considered as finished.
+	// can be considered as finished. For RingBuffer captures, it is the number of packets in the buffer.
 	NumberCaptured int32 `json:"numberCaptured"`
 	// FilePath specifies the location where captured packets are stored. It can either be a URL to download the pcap file (if "Spec.FileServer" is specified)
 	// or a local file path on the antrea-agent Pod where the packet was captured, formatted as : <antrea-agent-pod-name>:<path>.
 	// When using a local file path, the file will be automatically removed after the PacketCapture resource is deleted.
+	// It is empty for captures performed on multiple Nodes, for which the location of each file is in Nodes.
 	FilePath string `json:"filePath"`
+	// StopReason indicates why the capture stopped. It is empty until the capture stops. For captures performed on
+	// multiple Nodes, it is only set if the capture stopped for the same reason on all the Nodes.
+	StopReason PacketCaptureStopReason `json:"stopReason,omitempty"`
 	// Condition represents the latest available observations of the PacketCapture's current state.
+	// For captures performed on multiple Nodes, they are aggregated from the conditions of all the Nodes.
 	Conditions []PacketCaptureCondition `json:"conditions"`
+	// Nodes is the status of the capture on each Node, for captures performed on multiple Nodes, i.e. when
+	// CapturePoint is 'Both' or Node.NodeSelector is set. antrea-controller creates a PacketCapture for each Node,
+	// and aggregates their status.
+	Nodes []PacketCaptureNodeStatus `json:"nodes,omitempty"`
+}
+
+// PacketCaptureNodeStatus is the status of a capture performed on multiple Nodes, on one of the Nodes.
+type PacketCaptureNodeStatus struct {
+	// NodeName is the name of the Node.
+	NodeName string `json:"nodeName"`
+	// PacketCapture is the name of the PacketCapture created by antrea-controller for the Node.
+	PacketCapture string `json:"packetCapture,omitempty"`
+	// NumberCaptured records how many packets have been captured on the Node.
+	NumberCaptured int32 `json:"numberCaptured"`
+	// FilePath specifies the location where the packets captured on the Node are stored, in the same format as
+	// the FilePath of PacketCaptureStatus.
+	FilePath string `json:"filePath,omitempty"`
+	// StopReason indicates why the capture stopped on the Node.
+	StopReason PacketCaptureStopReason `json:"stopReason,omitempty"`
+	// Conditions represent the latest available observations of the capture on the Node.
+	Conditions []PacketCaptureCondition `json:"conditions,omitempty"`
 }
 
+type PacketCaptureStopReason string
+
+const (
+	// PacketCaptureStopReasonPacketLimit means the number of packets specified by FirstN have been captured.
+	PacketCaptureStopReasonPacketLimit PacketCaptureStopReason = "PacketLimitReached"
+	// PacketCaptureStopReasonByteLimit means the number of bytes specified by MaxBytes have been captured.
+	PacketCaptureStopReasonByteLimit PacketCaptureStopReason = "ByteLimitReached"
+	// PacketCaptureStopReasonDuration means the duration specified by Duration has elapsed.
+	PacketCaptureStopReasonDuration PacketCaptureStopReason = "DurationElapsed"
+	// PacketCaptureStopReasonTriggered means a RingBuffer capture has been triggered, and the packets to capture
+	// after the trigger, if any, have been captured.
+	PacketCaptureStopReasonTriggered PacketCaptureStopReason = "Triggered"
+	// PacketCaptureStopReasonTimeout means the capture timed out before any other stop condition was met.
+	PacketCaptureStopReasonTimeout PacketCaptureStopReason = "Timeout"
+	// PacketCaptureStopReasonError means the capture stopped because of an error.
+	PacketCaptureStopReasonError PacketCaptureStopReason = "Error"
+)
+
 type PacketCaptureConditionType string
 
 const (
@@ -704,6 +1156,14 @@ type FlowExporterDestinationSpec struct {
 	// TLSConfig is used to configure TLS when using gRPC protocol or IPFIX protocol with TLS transport.
 	// +optional
 	TLSConfig *FlowExporterTLSConfig `json:"tlsConfig,omitempty"`
+
+	// EnableSharding distributes flow records across the ready endpoints of the collector
+	// Service, based on a hash of the connection 5-tuple, instead of sending all flow records
+	// to the Service ClusterIP. All the records for a given connection are sent to the same
+	// endpoint, which lets the Flow Aggregator run with multiple replicas in Aggregate mode.
+	// When enabled, Address must reference a Service (<namespace>/<name>:<port>).
+	// +optional
+	EnableSharding bool `json:"enableSharding,omitempty"`
 }
 
 // FlowExporterProtocol defines the protocol used to send flow details.
@@ -780,3 +1240,116 @@ type FlowExporterDestinationList struct {
 
 	Items []FlowExporterDestination `json:"items"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConnectivityProbe periodically injects synthetic probes from a set of source Pods to a destination, through the
+// same datapath as the traffic of the Pods, and reports the success rate and latency of the probes. Unlike
+// NodeLatencyMonitor, which only measures the latency between Nodes, it detects connectivity issues caused by the
+// Pod datapath, NetworkPolicies or Service load-balancing.
+type ConnectivityProbe struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConnectivityProbeSpec   `json:"spec"`
+	Status ConnectivityProbeStatus `json:"status,omitempty"`
+}
+
+type ConnectivityProbeSpec struct {
+	// Source selects the Pods from which probes are sent.
+	Source ConnectivityProbeSource `json:"source"`
+	// Destination is the destination of the probes. Exactly one of Pod, Service or FQDN must be set.
+	Destination ConnectivityProbeDestination `json:"destination"`
+	// Protocol is the protocol of the probes. Defaults to ICMP.
+	// +optional
+	Protocol ConnectivityProbeProtocol `json:"protocol,omitempty"`
+	// Port is the destination port of the probes. It must be set if and only if Protocol is TCP or UDP.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// IntervalSeconds is the interval in seconds between two rounds of probes. Defaults to 60.
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+	// TimeoutSeconds is the time in seconds after which a probe without reply is considered as failed. It must be
+	// smaller than IntervalSeconds. Defaults to 5.
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// ConnectivityProbeSource selects the source Pods of the probes. At least one of NamespaceSelector or PodSelector
+// must be set.
+type ConnectivityProbeSource struct {
+	// NamespaceSelector selects the Namespaces of the source Pods. If not set, the Pods are selected in all the
+	// Namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// PodSelector selects the source Pods. If not set, all the Pods of the selected Namespaces are selected.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// MaxPodsPerNode is the maximum number of selected Pods from which each Antrea Agent sends probes. 0 means no
+	// limit. Defaults to 1.
+	// +optional
+	MaxPodsPerNode *int32 `json:"maxPodsPerNode,omitempty"`
+}
+
+// ConnectivityProbeDestination describes the destination of the probes.
+type ConnectivityProbeDestination struct {
+	// Pod is the destination Pod.
+	Pod *PodReference `json:"pod,omitempty"`
+	// Service is the destination Service. Probes are sent to its ClusterIP, and are load-balanced to its Endpoints
+	// like the traffic of the source Pods.
+	Service *ServiceReference `json:"service,omitempty"`
+	// FQDN is the domain name of the destination. It is resolved by the Antrea Agent before each round of probes.
+	FQDN string `json:"fqdn,omitempty"`
+}
+
+type ConnectivityProbeProtocol string
+
+const (
+	// ICMP probes are echo requests, which succeed when an echo reply is received.
+	ConnectivityProbeProtocolICMP ConnectivityProbeProtocol = "ICMP"
+	// TCP probes are SYN packets, which succeed when a SYN-ACK is received.
+	ConnectivityProbeProtocolTCP ConnectivityProbeProtocol = "TCP"
+	// UDP probes are datagrams, which succeed when any reply is received from the destination.
+	ConnectivityProbeProtocolUDP ConnectivityProbeProtocol = "UDP"
+)
+
+type ConnectivityProbeStatus struct {
+	// ProbesSent is the number of probes sent from all the Nodes.
+	ProbesSent int64 `json:"probesSent,omitempty"`
+	// ProbesSucceeded is the number of successful probes sent from all the Nodes.
+	ProbesSucceeded int64 `json:"probesSucceeded,omitempty"`
+	// SuccessRatePercent is the percentage of successful probes.
+	SuccessRatePercent int32 `json:"successRatePercent,omitempty"`
+	// AverageLatencyNanoseconds is the average round-trip time of the successful probes.
+	AverageLatencyNanoseconds int64 `json:"averageLatencyNanoseconds,omitempty"`
+	// Nodes is the status of the probes sent from each Node.
+	Nodes []ConnectivityProbeNodeStatus `json:"nodes,omitempty"`
+}
+
+// ConnectivityProbeNodeStatus is the status of the probes sent from the source Pods running on a Node.
+type ConnectivityProbeNodeStatus struct {
+	// NodeName is the name of the Node.
+	NodeName string `json:"nodeName"`
+	// ProbesSent is the number of probes sent from the Node.
+	ProbesSent int64 `json:"probesSent"`
+	// ProbesSucceeded is the number of successful probes sent from the Node.
+	ProbesSucceeded int64 `json:"probesSucceeded"`
+	// AverageLatencyNanoseconds is the average round-trip time of the successful probes sent from the Node.
+	AverageLatencyNanoseconds int64 `json:"averageLatencyNanoseconds,omitempty"`
+	// LastProbeTime is the time of the last round of probes.
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastFailureMessage describes why the last failed probe failed.
+	LastFailureMessage string `json:"lastFailureMessage,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ConnectivityProbeList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ConnectivityProbe `json:"items"`
+}
@@ -54,6 +54,26 @@ func ConditionSliceEqualsIgnoreLastTransitionTime(as, bs []PacketCaptureConditio
 	return true
 }
 
+// IsMultiNodePacketCapture returns whether the PacketCapture is performed on multiple Nodes, i.e. CapturePoint is
+// 'Both' or Node.NodeSelector is set. antrea-controller creates a PacketCapture for each of the Nodes, which is not a
+// multi-Node PacketCapture itself, and aggregates their status.
+func IsMultiNodePacketCapture(pc *PacketCapture) bool {
+	if GetParentPacketCapture(pc) != "" {
+		return false
+	}
+	return pc.Spec.CapturePoint == CapturePointBoth || (pc.Spec.Node != nil && pc.Spec.Node.NodeSelector != nil)
+}
+
+// GetParentPacketCapture returns the name of the multi-Node PacketCapture for which antrea-controller created the
+// given PacketCapture, or an empty string if it was not created by antrea-controller.
+func GetParentPacketCapture(pc *PacketCapture) string {
+	owner := metav1.GetControllerOf(pc)
+	if owner == nil || owner.APIVersion != SchemeGroupVersion.String() || owner.Kind != "PacketCapture" {
+		return ""
+	}
+	return owner.Name
+}
+
 // Flow exporter protocol name constants
 const (
 	FlowExporterProtocolGRPC  = "grpc"
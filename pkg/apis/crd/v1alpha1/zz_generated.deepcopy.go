@@ -37,12 +37,12 @@ func (in *Advertisements) DeepCopyInto(out *Advertisements) {
 	if in.Pod != nil {
 		in, out := &in.Pod, &out.Pod
 		*out = new(PodAdvertisement)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Egress != nil {
 		in, out := &in.Egress, &out.Egress
 		*out = new(EgressAdvertisement)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -139,6 +139,42 @@ func (in *AntreaNodeConfigSpec) DeepCopy() *AntreaNodeConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPPathAttributes) DeepCopyInto(out *BGPPathAttributes) {
+	*out = *in
+	if in.Communities != nil {
+		in, out := &in.Communities, &out.Communities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LargeCommunities != nil {
+		in, out := &in.LargeCommunities, &out.LargeCommunities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocalPreference != nil {
+		in, out := &in.LocalPreference, &out.LocalPreference
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MED != nil {
+		in, out := &in.MED, &out.MED
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPPathAttributes.
+func (in *BGPPathAttributes) DeepCopy() *BGPPathAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPPathAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BGPPeer) DeepCopyInto(out *BGPPeer) {
 	*out = *in
@@ -157,6 +193,21 @@ func (in *BGPPeer) DeepCopyInto(out *BGPPeer) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ExportPolicy != nil {
+		in, out := &in.ExportPolicy, &out.ExportPolicy
+		*out = new(BGPRoutePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImportPolicy != nil {
+		in, out := &in.ImportPolicy, &out.ImportPolicy
+		*out = new(BGPRoutePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(BGPPeerBFD)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -170,12 +221,64 @@ func (in *BGPPeer) DeepCopy() *BGPPeer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPPeerBFD) DeepCopyInto(out *BGPPeerBFD) {
+	*out = *in
+	if in.MinTxIntervalMilliseconds != nil {
+		in, out := &in.MinTxIntervalMilliseconds, &out.MinTxIntervalMilliseconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinRxIntervalMilliseconds != nil {
+		in, out := &in.MinRxIntervalMilliseconds, &out.MinRxIntervalMilliseconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Multiplier != nil {
+		in, out := &in.Multiplier, &out.Multiplier
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPPeerBFD.
+func (in *BGPPeerBFD) DeepCopy() *BGPPeerBFD {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPPeerBFD)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPPeerStatus) DeepCopyInto(out *BGPPeerStatus) {
+	*out = *in
+	if in.EstablishedTime != nil {
+		in, out := &in.EstablishedTime, &out.EstablishedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPPeerStatus.
+func (in *BGPPeerStatus) DeepCopy() *BGPPeerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPPeerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BGPPolicy) DeepCopyInto(out *BGPPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -230,6 +333,30 @@ func (in *BGPPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPPolicyNodeStatus) DeepCopyInto(out *BGPPolicyNodeStatus) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]BGPPeerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPPolicyNodeStatus.
+func (in *BGPPolicyNodeStatus) DeepCopy() *BGPPolicyNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPPolicyNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BGPPolicySpec) DeepCopyInto(out *BGPPolicySpec) {
 	*out = *in
@@ -252,6 +379,11 @@ func (in *BGPPolicySpec) DeepCopyInto(out *BGPPolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReceivedRoutes != nil {
+		in, out := &in.ReceivedRoutes, &out.ReceivedRoutes
+		*out = new(ReceivedRoutes)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -265,6 +397,116 @@ func (in *BGPPolicySpec) DeepCopy() *BGPPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPPolicyStatus) DeepCopyInto(out *BGPPolicyStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]BGPPolicyNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPPolicyStatus.
+func (in *BGPPolicyStatus) DeepCopy() *BGPPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPRouteMatch) DeepCopyInto(out *BGPRouteMatch) {
+	*out = *in
+	if in.RouteTypes != nil {
+		in, out := &in.RouteTypes, &out.RouteTypes
+		*out = make([]BGPRouteType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPRouteMatch.
+func (in *BGPRouteMatch) DeepCopy() *BGPRouteMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPRouteMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPRoutePolicy) DeepCopyInto(out *BGPRoutePolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]BGPRoutePolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPRoutePolicy.
+func (in *BGPRoutePolicy) DeepCopy() *BGPRoutePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPRoutePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPRoutePolicyRule) DeepCopyInto(out *BGPRoutePolicyRule) {
+	*out = *in
+	in.Match.DeepCopyInto(&out.Match)
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = new(BGPPathAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPRoutePolicyRule.
+func (in *BGPRoutePolicyRule) DeepCopy() *BGPRoutePolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPRoutePolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleComponents) DeepCopyInto(out *BundleComponents) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleComponents.
+func (in *BundleComponents) DeepCopy() *BundleComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundleExternalNodes) DeepCopyInto(out *BundleExternalNodes) {
 	*out = *in
@@ -299,6 +541,11 @@ func (in *BundleFileServer) DeepCopyInto(out *BundleFileServer) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(BundleS3Config)
+		**out = **in
+	}
 	return
 }
 
@@ -338,6 +585,22 @@ func (in *BundleNodes) DeepCopy() *BundleNodes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleS3Config) DeepCopyInto(out *BundleS3Config) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleS3Config.
+func (in *BundleS3Config) DeepCopy() *BundleS3Config {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleS3Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundleServerAuthConfiguration) DeepCopyInto(out *BundleServerAuthConfiguration) {
 	*out = *in
@@ -349,54 +612,250 @@ func (in *BundleServerAuthConfiguration) DeepCopyInto(out *BundleServerAuthConfi
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleServerAuthConfiguration.
-func (in *BundleServerAuthConfiguration) DeepCopy() *BundleServerAuthConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleServerAuthConfiguration.
+func (in *BundleServerAuthConfiguration) DeepCopy() *BundleServerAuthConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleServerAuthConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CaptureConfig) DeepCopyInto(out *CaptureConfig) {
+	*out = *in
+	if in.FirstN != nil {
+		in, out := &in.FirstN, &out.FirstN
+		*out = new(PacketCaptureFirstNConfig)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(PacketCaptureDurationConfig)
+		**out = **in
+	}
+	if in.RingBuffer != nil {
+		in, out := &in.RingBuffer, &out.RingBuffer
+		*out = new(PacketCaptureRingBufferConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SnapLen != nil {
+		in, out := &in.SnapLen, &out.SnapLen
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaptureConfig.
+func (in *CaptureConfig) DeepCopy() *CaptureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CaptureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Confederation) DeepCopyInto(out *Confederation) {
+	*out = *in
+	if in.MemberASNs != nil {
+		in, out := &in.MemberASNs, &out.MemberASNs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Confederation.
+func (in *Confederation) DeepCopy() *Confederation {
+	if in == nil {
+		return nil
+	}
+	out := new(Confederation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityProbe) DeepCopyInto(out *ConnectivityProbe) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityProbe.
+func (in *ConnectivityProbe) DeepCopy() *ConnectivityProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConnectivityProbe) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityProbeDestination) DeepCopyInto(out *ConnectivityProbeDestination) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(PodReference)
+		**out = **in
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityProbeDestination.
+func (in *ConnectivityProbeDestination) DeepCopy() *ConnectivityProbeDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityProbeDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityProbeList) DeepCopyInto(out *ConnectivityProbeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConnectivityProbe, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityProbeList.
+func (in *ConnectivityProbeList) DeepCopy() *ConnectivityProbeList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityProbeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConnectivityProbeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityProbeNodeStatus) DeepCopyInto(out *ConnectivityProbeNodeStatus) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityProbeNodeStatus.
+func (in *ConnectivityProbeNodeStatus) DeepCopy() *ConnectivityProbeNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityProbeNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityProbeSource) DeepCopyInto(out *ConnectivityProbeSource) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxPodsPerNode != nil {
+		in, out := &in.MaxPodsPerNode, &out.MaxPodsPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityProbeSource.
+func (in *ConnectivityProbeSource) DeepCopy() *ConnectivityProbeSource {
 	if in == nil {
 		return nil
 	}
-	out := new(BundleServerAuthConfiguration)
+	out := new(ConnectivityProbeSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CaptureConfig) DeepCopyInto(out *CaptureConfig) {
+func (in *ConnectivityProbeSpec) DeepCopyInto(out *ConnectivityProbeSpec) {
 	*out = *in
-	if in.FirstN != nil {
-		in, out := &in.FirstN, &out.FirstN
-		*out = new(PacketCaptureFirstNConfig)
-		**out = **in
-	}
+	in.Source.DeepCopyInto(&out.Source)
+	in.Destination.DeepCopyInto(&out.Destination)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaptureConfig.
-func (in *CaptureConfig) DeepCopy() *CaptureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityProbeSpec.
+func (in *ConnectivityProbeSpec) DeepCopy() *ConnectivityProbeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CaptureConfig)
+	out := new(ConnectivityProbeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Confederation) DeepCopyInto(out *Confederation) {
+func (in *ConnectivityProbeStatus) DeepCopyInto(out *ConnectivityProbeStatus) {
 	*out = *in
-	if in.MemberASNs != nil {
-		in, out := &in.MemberASNs, &out.MemberASNs
-		*out = make([]int32, len(*in))
-		copy(*out, *in)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]ConnectivityProbeNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Confederation.
-func (in *Confederation) DeepCopy() *Confederation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityProbeStatus.
+func (in *ConnectivityProbeStatus) DeepCopy() *ConnectivityProbeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Confederation)
+	out := new(ConnectivityProbeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -409,6 +868,11 @@ func (in *Destination) DeepCopyInto(out *Destination) {
 		*out = new(PodReference)
 		**out = **in
 	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceReference)
+		**out = **in
+	}
 	if in.IP != nil {
 		in, out := &in.IP, &out.IP
 		*out = new(string)
@@ -430,6 +894,13 @@ func (in *Destination) DeepCopy() *Destination {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressAdvertisement) DeepCopyInto(out *EgressAdvertisement) {
 	*out = *in
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]EgressAdvertisementSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -443,6 +914,27 @@ func (in *EgressAdvertisement) DeepCopy() *EgressAdvertisement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressAdvertisementSelector) DeepCopyInto(out *EgressAdvertisementSelector) {
+	*out = *in
+	if in.EgressSelector != nil {
+		in, out := &in.EgressSelector, &out.EgressSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressAdvertisementSelector.
+func (in *EgressAdvertisementSelector) DeepCopy() *EgressAdvertisementSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressAdvertisementSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalNode) DeepCopyInto(out *ExternalNode) {
 	*out = *in
@@ -862,7 +1354,7 @@ func (in *NodeLatencyMonitor) DeepCopyInto(out *NodeLatencyMonitor) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
@@ -920,6 +1412,16 @@ func (in *NodeLatencyMonitorList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeLatencyMonitorSpec) DeepCopyInto(out *NodeLatencyMonitorSpec) {
 	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = new(NodeLatencyThresholds)
+		**out = **in
+	}
 	return
 }
 
@@ -933,6 +1435,22 @@ func (in *NodeLatencyMonitorSpec) DeepCopy() *NodeLatencyMonitorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLatencyThresholds) DeepCopyInto(out *NodeLatencyThresholds) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLatencyThresholds.
+func (in *NodeLatencyThresholds) DeepCopy() *NodeLatencyThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLatencyThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVSBridgeConfig) DeepCopyInto(out *OVSBridgeConfig) {
 	*out = *in
@@ -1044,6 +1562,22 @@ func (in *PacketCaptureCondition) DeepCopy() *PacketCaptureCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacketCaptureDurationConfig) DeepCopyInto(out *PacketCaptureDurationConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureDurationConfig.
+func (in *PacketCaptureDurationConfig) DeepCopy() *PacketCaptureDurationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PacketCaptureDurationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PacketCaptureFileServer) DeepCopyInto(out *PacketCaptureFileServer) {
 	*out = *in
@@ -1052,6 +1586,11 @@ func (in *PacketCaptureFileServer) DeepCopyInto(out *PacketCaptureFileServer) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(PacketCaptureS3Config)
+		**out = **in
+	}
 	return
 }
 
@@ -1114,6 +1653,99 @@ func (in *PacketCaptureList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacketCaptureNode) DeepCopyInto(out *PacketCaptureNode) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureNode.
+func (in *PacketCaptureNode) DeepCopy() *PacketCaptureNode {
+	if in == nil {
+		return nil
+	}
+	out := new(PacketCaptureNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacketCaptureNodeStatus) DeepCopyInto(out *PacketCaptureNodeStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]PacketCaptureCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureNodeStatus.
+func (in *PacketCaptureNodeStatus) DeepCopy() *PacketCaptureNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PacketCaptureNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacketCapturePolicyRuleTrigger) DeepCopyInto(out *PacketCapturePolicyRuleTrigger) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCapturePolicyRuleTrigger.
+func (in *PacketCapturePolicyRuleTrigger) DeepCopy() *PacketCapturePolicyRuleTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(PacketCapturePolicyRuleTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacketCaptureRingBufferConfig) DeepCopyInto(out *PacketCaptureRingBufferConfig) {
+	*out = *in
+	in.Trigger.DeepCopyInto(&out.Trigger)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureRingBufferConfig.
+func (in *PacketCaptureRingBufferConfig) DeepCopy() *PacketCaptureRingBufferConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PacketCaptureRingBufferConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacketCaptureS3Config) DeepCopyInto(out *PacketCaptureS3Config) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureS3Config.
+func (in *PacketCaptureS3Config) DeepCopy() *PacketCaptureS3Config {
+	if in == nil {
+		return nil
+	}
+	out := new(PacketCaptureS3Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PacketCaptureSpec) DeepCopyInto(out *PacketCaptureSpec) {
 	*out = *in
@@ -1125,6 +1757,11 @@ func (in *PacketCaptureSpec) DeepCopyInto(out *PacketCaptureSpec) {
 	in.CaptureConfig.DeepCopyInto(&out.CaptureConfig)
 	in.Source.DeepCopyInto(&out.Source)
 	in.Destination.DeepCopyInto(&out.Destination)
+	if in.Node != nil {
+		in, out := &in.Node, &out.Node
+		*out = new(PacketCaptureNode)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Packet != nil {
 		in, out := &in.Packet, &out.Packet
 		*out = new(Packet)
@@ -1158,6 +1795,13 @@ func (in *PacketCaptureStatus) DeepCopyInto(out *PacketCaptureStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]PacketCaptureNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1171,9 +1815,42 @@ func (in *PacketCaptureStatus) DeepCopy() *PacketCaptureStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacketCaptureTrigger) DeepCopyInto(out *PacketCaptureTrigger) {
+	*out = *in
+	if in.Packet != nil {
+		in, out := &in.Packet, &out.Packet
+		*out = new(Packet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PolicyRule != nil {
+		in, out := &in.PolicyRule, &out.PolicyRule
+		*out = new(PacketCapturePolicyRuleTrigger)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureTrigger.
+func (in *PacketCaptureTrigger) DeepCopy() *PacketCaptureTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(PacketCaptureTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodAdvertisement) DeepCopyInto(out *PodAdvertisement) {
 	*out = *in
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]PodAdvertisementSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1187,6 +1864,32 @@ func (in *PodAdvertisement) DeepCopy() *PodAdvertisement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodAdvertisementSelector) DeepCopyInto(out *PodAdvertisementSelector) {
+	*out = *in
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodAdvertisementSelector.
+func (in *PodAdvertisementSelector) DeepCopy() *PodAdvertisementSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(PodAdvertisementSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodReference) DeepCopyInto(out *PodReference) {
 	*out = *in
@@ -1203,6 +1906,27 @@ func (in *PodReference) DeepCopy() *PodReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceivedRoutes) DeepCopyInto(out *ReceivedRoutes) {
+	*out = *in
+	if in.AllowedPrefixes != nil {
+		in, out := &in.AllowedPrefixes, &out.AllowedPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceivedRoutes.
+func (in *ReceivedRoutes) DeepCopy() *ReceivedRoutes {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceivedRoutes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecondaryNetworkConfig) DeepCopyInto(out *SecondaryNetworkConfig) {
 	*out = *in
@@ -1234,6 +1958,13 @@ func (in *ServiceAdvertisement) DeepCopyInto(out *ServiceAdvertisement) {
 		*out = make([]ServiceIPType, len(*in))
 		copy(*out, *in)
 	}
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]ServiceAdvertisementSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1247,6 +1978,48 @@ func (in *ServiceAdvertisement) DeepCopy() *ServiceAdvertisement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAdvertisementSelector) DeepCopyInto(out *ServiceAdvertisementSelector) {
+	*out = *in
+	if in.ServiceSelector != nil {
+		in, out := &in.ServiceSelector, &out.ServiceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAdvertisementSelector.
+func (in *ServiceAdvertisementSelector) DeepCopy() *ServiceAdvertisementSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAdvertisementSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (in *ServiceReference) DeepCopy() *ServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
@@ -1366,6 +2139,11 @@ func (in *SupportBundleCollectionSpec) DeepCopyInto(out *SupportBundleCollection
 	}
 	in.FileServer.DeepCopyInto(&out.FileServer)
 	in.Authentication.DeepCopyInto(&out.Authentication)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = new(BundleComponents)
+		**out = **in
+	}
 	return
 }
 
@@ -52,6 +52,12 @@ type AntreaAgentInfo struct {
 	NodePortLocalPortRange string `json:"nodePortLocalPortRange,omitempty"`
 	// Network information
 	NetworkInfo NetworkInfo `json:"networkInfo,omitempty"`
+	// Information of the BGPPolicy effective on the Node, which is aggregated into the BGPPolicy status by the
+	// Antrea Controller. It is unset if no BGPPolicy is effective on the Node.
+	BGPPolicyInfo *BGPPolicyInfo `json:"bgpPolicyInfo,omitempty"`
+	// Results of the ConnectivityProbes sent from the Node, which are aggregated into the ConnectivityProbe status
+	// by the Antrea Controller.
+	ConnectivityProbeInfos []ConnectivityProbeInfo `json:"connectivityProbeInfos,omitempty"`
 }
 
 type OVSInfo struct {
@@ -73,6 +79,47 @@ type NetworkInfo struct {
 	TransportInterfaceIPs []string `json:"transportInterfaceIPs,omitempty"`
 }
 
+type BGPPolicyInfo struct {
+	// Name of the effective BGPPolicy
+	Name string `json:"name"`
+	// BGP router ID used by the BGP process on the Node
+	RouterID string `json:"routerID,omitempty"`
+	// Status of the BGP peers
+	Peers []BGPPeerInfo `json:"peers,omitempty"`
+}
+
+type BGPPeerInfo struct {
+	// IP address of the BGP peer
+	Address string `json:"address"`
+	// AS number of the BGP peer
+	ASN int32 `json:"asn"`
+	// State of the BGP session, e.g. "Established" or "Active"
+	SessionState string `json:"sessionState"`
+	// Time when the BGP session was established, unset if the session is not established
+	EstablishedTime *metav1.Time `json:"establishedTime,omitempty"`
+	// Number of routes advertised to the BGP peer
+	AdvertisedRoutes int32 `json:"advertisedRoutes"`
+	// Number of routes received from the BGP peer
+	ReceivedRoutes int32 `json:"receivedRoutes"`
+	// State of the BFD session, unset if BFD is disabled for the BGP peer
+	BFDSessionState string `json:"bfdSessionState,omitempty"`
+}
+
+type ConnectivityProbeInfo struct {
+	// Name of the ConnectivityProbe
+	Name string `json:"name"`
+	// Number of probes sent from the Node
+	ProbesSent int64 `json:"probesSent"`
+	// Number of probes sent from the Node which succeeded
+	ProbesSucceeded int64 `json:"probesSucceeded"`
+	// Average latency of the probes which succeeded, in nanoseconds
+	AverageLatencyNanoseconds int64 `json:"averageLatencyNanoseconds,omitempty"`
+	// Time of the last round of probes
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// Message of the last probe which failed
+	LastFailureMessage string `json:"lastFailureMessage,omitempty"`
+}
+
 type AgentConditionType string
 
 const (
@@ -84,6 +131,9 @@ const (
 	OVSDBConnectionUp AgentConditionType = "OVSDBConnectionUp"
 	// OpenflowConnectionUp is used to mark Openflow connection status.
 	OpenflowConnectionUp AgentConditionType = "OpenflowConnectionUp"
+	// NodeLatencyHealthy is used to mark whether the connections to the peer Nodes are within the thresholds of the
+	// NodeLatencyMonitor. It is only reported when thresholds are configured.
+	NodeLatencyHealthy AgentConditionType = "NodeLatencyHealthy"
 )
 
 type AgentCondition struct {
@@ -931,10 +981,12 @@ type IGMPProtocol struct {
 type L7Protocol struct {
 	HTTP *HTTPProtocol `json:"http,omitempty"`
 	TLS  *TLSProtocol  `json:"tls,omitempty"`
+	GRPC *GRPCProtocol `json:"grpc,omitempty"`
+	DNS  *DNSProtocol  `json:"dns,omitempty"`
 }
 
-// HTTPProtocol matches HTTP requests with specific host, method, and path. All fields could be used alone or together.
-// If all fields are not provided, it matches all HTTP requests.
+// HTTPProtocol matches HTTP requests with specific host, method, path, headers, and query parameters. All fields could
+// be used alone or together. If all fields are not provided, it matches all HTTP requests.
 type HTTPProtocol struct {
 	// Host represents the hostname present in the URI or the HTTP Host header to match.
 	// It does not contain the port associated with the host.
@@ -944,6 +996,32 @@ type HTTPProtocol struct {
 	Method string `json:"method,omitempty"`
 	// Path represents the URI path to match (Ex. "/index.html", "/admin").
 	Path string `json:"path,omitempty"`
+	// PathRegex represents a regular expression to match the URI path (Ex. "^/api/v[0-9]+/users$").
+	// It cannot be used together with Path.
+	PathRegex string `json:"pathRegex,omitempty"`
+	// Headers represents the request headers to match. A request is matched only if all headers are matched.
+	Headers []HTTPHeaderMatch `json:"headers,omitempty"`
+	// QueryParams represents the URI query parameters to match. A request is matched only if all query parameters
+	// are matched.
+	QueryParams []HTTPQueryParamMatch `json:"queryParams,omitempty"`
+}
+
+// HTTPHeaderMatch matches an HTTP request header with specific name and value.
+type HTTPHeaderMatch struct {
+	// Name represents the name of the header to match. It is case-insensitive.
+	Name string `json:"name"`
+	// Value represents the value of the header to match. If it is not provided, any request carrying the header is
+	// matched.
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPQueryParamMatch matches an HTTP request URI query parameter with specific name and value.
+type HTTPQueryParamMatch struct {
+	// Name represents the name of the query parameter to match.
+	Name string `json:"name"`
+	// Value represents the value of the query parameter to match. If it is not provided, any request carrying the
+	// query parameter is matched.
+	Value string `json:"value,omitempty"`
 }
 
 // TLSProtocol matches TLS handshake packets with specific SNI. If the field is not provided, this
@@ -953,6 +1031,27 @@ type TLSProtocol struct {
 	SNI string `json:"sni,omitempty"`
 }
 
+// GRPCProtocol matches gRPC requests with specific service and method, which are derived from the HTTP/2 :path
+// pseudo-header "/<service>/<method>". All fields could be used alone or together. If all fields are not provided, it
+// matches all gRPC requests.
+type GRPCProtocol struct {
+	// Service represents the fully-qualified name of the gRPC service to match (Ex. "helloworld.Greeter").
+	Service string `json:"service,omitempty"`
+	// Method represents the name of the gRPC method to match (Ex. "SayHello").
+	Method string `json:"method,omitempty"`
+}
+
+// DNSProtocol matches DNS queries with specific query name and query type. All fields could be used alone or together.
+// If all fields are not provided, it matches all DNS queries.
+type DNSProtocol struct {
+	// QueryName represents the domain name in the DNS query to match. Both exact matches and wildcards are supported
+	// (Ex. "www.foo.com", "*.foo.com").
+	QueryName string `json:"queryName,omitempty"`
+	// QueryType represents the type of the DNS query to match.
+	// It could be A, AAAA, CNAME, MX, NS, PTR, SOA, SRV, TXT and ANY.
+	QueryType string `json:"queryType,omitempty"`
+}
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -70,6 +70,18 @@ func (in *AntreaAgentInfo) DeepCopyInto(out *AntreaAgentInfo) {
 		copy(*out, *in)
 	}
 	in.NetworkInfo.DeepCopyInto(&out.NetworkInfo)
+	if in.BGPPolicyInfo != nil {
+		in, out := &in.BGPPolicyInfo, &out.BGPPolicyInfo
+		*out = new(BGPPolicyInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectivityProbeInfos != nil {
+		in, out := &in.ConnectivityProbeInfos, &out.ConnectivityProbeInfos
+		*out = make([]ConnectivityProbeInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -240,6 +252,49 @@ func (in *AppliedTo) DeepCopy() *AppliedTo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPPeerInfo) DeepCopyInto(out *BGPPeerInfo) {
+	*out = *in
+	if in.EstablishedTime != nil {
+		in, out := &in.EstablishedTime, &out.EstablishedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPPeerInfo.
+func (in *BGPPeerInfo) DeepCopy() *BGPPeerInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPPeerInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPPolicyInfo) DeepCopyInto(out *BGPPolicyInfo) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]BGPPeerInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPPolicyInfo.
+func (in *BGPPolicyInfo) DeepCopy() *BGPPolicyInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPPolicyInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bandwidth) DeepCopyInto(out *Bandwidth) {
 	*out = *in
@@ -415,6 +470,23 @@ func (in *ClusterNetworkPolicySpec) DeepCopy() *ClusterNetworkPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityProbeInfo) DeepCopyInto(out *ConnectivityProbeInfo) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityProbeInfo.
+func (in *ConnectivityProbeInfo) DeepCopy() *ConnectivityProbeInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityProbeInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerCondition) DeepCopyInto(out *ControllerCondition) {
 	*out = *in
@@ -432,6 +504,22 @@ func (in *ControllerCondition) DeepCopy() *ControllerCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProtocol) DeepCopyInto(out *DNSProtocol) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProtocol.
+func (in *DNSProtocol) DeepCopy() *DNSProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Destination) DeepCopyInto(out *Destination) {
 	*out = *in
@@ -686,6 +774,22 @@ func (in *ExternalIPPoolStatus) DeepCopy() *ExternalIPPoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCProtocol) DeepCopyInto(out *GRPCProtocol) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCProtocol.
+func (in *GRPCProtocol) DeepCopy() *GRPCProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Group) DeepCopyInto(out *Group) {
 	*out = *in
@@ -840,9 +944,35 @@ func (in *GroupStatus) DeepCopy() *GroupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeaderMatch) DeepCopyInto(out *HTTPHeaderMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeaderMatch.
+func (in *HTTPHeaderMatch) DeepCopy() *HTTPHeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPProtocol) DeepCopyInto(out *HTTPProtocol) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryParams != nil {
+		in, out := &in.QueryParams, &out.QueryParams
+		*out = make([]HTTPQueryParamMatch, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -856,6 +986,22 @@ func (in *HTTPProtocol) DeepCopy() *HTTPProtocol {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPQueryParamMatch) DeepCopyInto(out *HTTPQueryParamMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPQueryParamMatch.
+func (in *HTTPQueryParamMatch) DeepCopy() *HTTPQueryParamMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPQueryParamMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ICMPEchoRequestHeader) DeepCopyInto(out *ICMPEchoRequestHeader) {
 	*out = *in
@@ -1165,13 +1311,23 @@ func (in *L7Protocol) DeepCopyInto(out *L7Protocol) {
 	if in.HTTP != nil {
 		in, out := &in.HTTP, &out.HTTP
 		*out = new(HTTPProtocol)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(TLSProtocol)
 		**out = **in
 	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPCProtocol)
+		**out = **in
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSProtocol)
+		**out = **in
+	}
 	return
 }
 
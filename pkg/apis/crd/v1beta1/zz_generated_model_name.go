@@ -49,6 +49,16 @@ func (in AppliedTo) OpenAPIModelName() string {
 	return "io.antrea.crd.v1beta1.AppliedTo"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in BGPPeerInfo) OpenAPIModelName() string {
+	return "io.antrea.crd.v1beta1.BGPPeerInfo"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in BGPPolicyInfo) OpenAPIModelName() string {
+	return "io.antrea.crd.v1beta1.BGPPolicyInfo"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in Bandwidth) OpenAPIModelName() string {
 	return "io.antrea.crd.v1beta1.Bandwidth"
@@ -79,11 +89,21 @@ func (in ClusterNetworkPolicySpec) OpenAPIModelName() string {
 	return "io.antrea.crd.v1beta1.ClusterNetworkPolicySpec"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in ConnectivityProbeInfo) OpenAPIModelName() string {
+	return "io.antrea.crd.v1beta1.ConnectivityProbeInfo"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in ControllerCondition) OpenAPIModelName() string {
 	return "io.antrea.crd.v1beta1.ControllerCondition"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in DNSProtocol) OpenAPIModelName() string {
+	return "io.antrea.crd.v1beta1.DNSProtocol"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in Destination) OpenAPIModelName() string {
 	return "io.antrea.crd.v1beta1.Destination"
@@ -134,6 +154,11 @@ func (in ExternalIPPoolStatus) OpenAPIModelName() string {
 	return "io.antrea.crd.v1beta1.ExternalIPPoolStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in GRPCProtocol) OpenAPIModelName() string {
+	return "io.antrea.crd.v1beta1.GRPCProtocol"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in Group) OpenAPIModelName() string {
 	return "io.antrea.crd.v1beta1.Group"
@@ -159,11 +184,21 @@ func (in GroupStatus) OpenAPIModelName() string {
 	return "io.antrea.crd.v1beta1.GroupStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HTTPHeaderMatch) OpenAPIModelName() string {
+	return "io.antrea.crd.v1beta1.HTTPHeaderMatch"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in HTTPProtocol) OpenAPIModelName() string {
 	return "io.antrea.crd.v1beta1.HTTPProtocol"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HTTPQueryParamMatch) OpenAPIModelName() string {
+	return "io.antrea.crd.v1beta1.HTTPQueryParamMatch"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in ICMPEchoRequestHeader) OpenAPIModelName() string {
 	return "io.antrea.crd.v1beta1.ICMPEchoRequestHeader"
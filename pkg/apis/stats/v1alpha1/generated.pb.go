@@ -681,6 +681,18 @@ func (m *TargetIPLatencyStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.JitterNanoseconds))
+	i--
+	dAtA[i] = 0x40
+	i = encodeVarintGenerated(dAtA, i, uint64(m.PacketLossPercent))
+	i--
+	dAtA[i] = 0x38
+	i = encodeVarintGenerated(dAtA, i, uint64(m.PacketsLost))
+	i--
+	dAtA[i] = 0x30
+	i = encodeVarintGenerated(dAtA, i, uint64(m.PacketsSent))
+	i--
+	dAtA[i] = 0x28
 	i = encodeVarintGenerated(dAtA, i, uint64(m.LastMeasuredRTTNanoseconds))
 	i--
 	dAtA[i] = 0x20
@@ -983,6 +995,10 @@ func (m *TargetIPLatencyStats) Size() (n int) {
 	l = m.LastRecvTime.Size()
 	n += 1 + l + sovGenerated(uint64(l))
 	n += 1 + sovGenerated(uint64(m.LastMeasuredRTTNanoseconds))
+	n += 1 + sovGenerated(uint64(m.PacketsSent))
+	n += 1 + sovGenerated(uint64(m.PacketsLost))
+	n += 1 + sovGenerated(uint64(m.PacketLossPercent))
+	n += 1 + sovGenerated(uint64(m.JitterNanoseconds))
 	return n
 }
 
@@ -1209,6 +1225,10 @@ func (this *TargetIPLatencyStats) String() string {
 		`LastSendTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.LastSendTime), "Time", "v1.Time", 1), `&`, ``, 1) + `,`,
 		`LastRecvTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.LastRecvTime), "Time", "v1.Time", 1), `&`, ``, 1) + `,`,
 		`LastMeasuredRTTNanoseconds:` + fmt.Sprintf("%v", this.LastMeasuredRTTNanoseconds) + `,`,
+		`PacketsSent:` + fmt.Sprintf("%v", this.PacketsSent) + `,`,
+		`PacketsLost:` + fmt.Sprintf("%v", this.PacketsLost) + `,`,
+		`PacketLossPercent:` + fmt.Sprintf("%v", this.PacketLossPercent) + `,`,
+		`JitterNanoseconds:` + fmt.Sprintf("%v", this.JitterNanoseconds) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2991,6 +3011,82 @@ func (m *TargetIPLatencyStats) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PacketsSent", wireType)
+			}
+			m.PacketsSent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PacketsSent |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PacketsLost", wireType)
+			}
+			m.PacketsLost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PacketsLost |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PacketLossPercent", wireType)
+			}
+			m.PacketLossPercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PacketLossPercent |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JitterNanoseconds", wireType)
+			}
+			m.JitterNanoseconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.JitterNanoseconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -180,6 +180,15 @@ type TargetIPLatencyStats struct {
 	LastRecvTime metav1.Time `json:"lastRecvTime,omitempty" protobuf:"bytes,3,opt,name=lastRecvTime"`
 	// The last measured RTT for this target IP, in nanoseconds.
 	LastMeasuredRTTNanoseconds int64 `json:"lastMeasuredRTTNanoseconds,omitempty" protobuf:"varint,4,opt,name=lastMeasuredRTTNanoseconds"`
+	// The number of probes sent to this target IP.
+	PacketsSent int64 `json:"packetsSent,omitempty" protobuf:"varint,5,opt,name=packetsSent"`
+	// The number of probes sent to this target IP for which no reply was received.
+	PacketsLost int64 `json:"packetsLost,omitempty" protobuf:"varint,6,opt,name=packetsLost"`
+	// The percentage of lost probes among the most recent probes (up to 100) sent to this target IP.
+	PacketLossPercent int32 `json:"packetLossPercent,omitempty" protobuf:"varint,7,opt,name=packetLossPercent"`
+	// The RTT jitter for this target IP, in nanoseconds. It is computed as the smoothed mean deviation
+	// of the difference between consecutive RTT measurements, as described in RFC 3550.
+	JitterNanoseconds int64 `json:"jitterNanoseconds,omitempty" protobuf:"varint,8,opt,name=jitterNanoseconds"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -35,6 +35,7 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
+		v1beta2.AccessKey{}.OpenAPIModelName():                            schema_pkg_apis_controlplane_v1beta2_AccessKey(ref),
 		v1beta2.AddressGroup{}.OpenAPIModelName():                         schema_pkg_apis_controlplane_v1beta2_AddressGroup(ref),
 		v1beta2.AddressGroupList{}.OpenAPIModelName():                     schema_pkg_apis_controlplane_v1beta2_AddressGroupList(ref),
 		v1beta2.AddressGroupPatch{}.OpenAPIModelName():                    schema_pkg_apis_controlplane_v1beta2_AddressGroupPatch(ref),
@@ -43,18 +44,23 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		v1beta2.AppliedToGroupPatch{}.OpenAPIModelName():                  schema_pkg_apis_controlplane_v1beta2_AppliedToGroupPatch(ref),
 		v1beta2.BasicAuthentication{}.OpenAPIModelName():                  schema_pkg_apis_controlplane_v1beta2_BasicAuthentication(ref),
 		v1beta2.BundleFileServer{}.OpenAPIModelName():                     schema_pkg_apis_controlplane_v1beta2_BundleFileServer(ref),
+		v1beta2.BundleS3Config{}.OpenAPIModelName():                       schema_pkg_apis_controlplane_v1beta2_BundleS3Config(ref),
 		v1beta2.BundleServerAuthConfiguration{}.OpenAPIModelName():        schema_pkg_apis_controlplane_v1beta2_BundleServerAuthConfiguration(ref),
 		v1beta2.ClusterGroupMembers{}.OpenAPIModelName():                  schema_pkg_apis_controlplane_v1beta2_ClusterGroupMembers(ref),
+		v1beta2.DNSProtocol{}.OpenAPIModelName():                          schema_pkg_apis_controlplane_v1beta2_DNSProtocol(ref),
 		v1beta2.EgressGroup{}.OpenAPIModelName():                          schema_pkg_apis_controlplane_v1beta2_EgressGroup(ref),
 		v1beta2.EgressGroupList{}.OpenAPIModelName():                      schema_pkg_apis_controlplane_v1beta2_EgressGroupList(ref),
 		v1beta2.EgressGroupPatch{}.OpenAPIModelName():                     schema_pkg_apis_controlplane_v1beta2_EgressGroupPatch(ref),
 		v1beta2.Entity{}.OpenAPIModelName():                               schema_pkg_apis_controlplane_v1beta2_Entity(ref),
 		v1beta2.ExternalEntityReference{}.OpenAPIModelName():              schema_pkg_apis_controlplane_v1beta2_ExternalEntityReference(ref),
+		v1beta2.GRPCProtocol{}.OpenAPIModelName():                         schema_pkg_apis_controlplane_v1beta2_GRPCProtocol(ref),
 		v1beta2.GroupAssociation{}.OpenAPIModelName():                     schema_pkg_apis_controlplane_v1beta2_GroupAssociation(ref),
 		v1beta2.GroupMember{}.OpenAPIModelName():                          schema_pkg_apis_controlplane_v1beta2_GroupMember(ref),
 		v1beta2.GroupMembers{}.OpenAPIModelName():                         schema_pkg_apis_controlplane_v1beta2_GroupMembers(ref),
 		v1beta2.GroupReference{}.OpenAPIModelName():                       schema_pkg_apis_controlplane_v1beta2_GroupReference(ref),
+		v1beta2.HTTPHeaderMatch{}.OpenAPIModelName():                      schema_pkg_apis_controlplane_v1beta2_HTTPHeaderMatch(ref),
 		v1beta2.HTTPProtocol{}.OpenAPIModelName():                         schema_pkg_apis_controlplane_v1beta2_HTTPProtocol(ref),
+		v1beta2.HTTPQueryParamMatch{}.OpenAPIModelName():                  schema_pkg_apis_controlplane_v1beta2_HTTPQueryParamMatch(ref),
 		v1beta2.IPBlock{}.OpenAPIModelName():                              schema_pkg_apis_controlplane_v1beta2_IPBlock(ref),
 		v1beta2.IPGroupAssociation{}.OpenAPIModelName():                   schema_pkg_apis_controlplane_v1beta2_IPGroupAssociation(ref),
 		v1beta2.IPNet{}.OpenAPIModelName():                                schema_pkg_apis_controlplane_v1beta2_IPNet(ref),
@@ -97,6 +103,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		v1beta1.ClusterNetworkPolicyList{}.OpenAPIModelName():             schema_pkg_apis_crd_v1beta1_ClusterNetworkPolicyList(ref),
 		v1beta1.ClusterNetworkPolicySpec{}.OpenAPIModelName():             schema_pkg_apis_crd_v1beta1_ClusterNetworkPolicySpec(ref),
 		v1beta1.ControllerCondition{}.OpenAPIModelName():                  schema_pkg_apis_crd_v1beta1_ControllerCondition(ref),
+		v1beta1.DNSProtocol{}.OpenAPIModelName():                          schema_pkg_apis_crd_v1beta1_DNSProtocol(ref),
 		v1beta1.Destination{}.OpenAPIModelName():                          schema_pkg_apis_crd_v1beta1_Destination(ref),
 		v1beta1.Egress{}.OpenAPIModelName():                               schema_pkg_apis_crd_v1beta1_Egress(ref),
 		v1beta1.EgressCondition{}.OpenAPIModelName():                      schema_pkg_apis_crd_v1beta1_EgressCondition(ref),
@@ -107,12 +114,15 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		v1beta1.ExternalIPPoolList{}.OpenAPIModelName():                   schema_pkg_apis_crd_v1beta1_ExternalIPPoolList(ref),
 		v1beta1.ExternalIPPoolSpec{}.OpenAPIModelName():                   schema_pkg_apis_crd_v1beta1_ExternalIPPoolSpec(ref),
 		v1beta1.ExternalIPPoolStatus{}.OpenAPIModelName():                 schema_pkg_apis_crd_v1beta1_ExternalIPPoolStatus(ref),
+		v1beta1.GRPCProtocol{}.OpenAPIModelName():                         schema_pkg_apis_crd_v1beta1_GRPCProtocol(ref),
 		v1beta1.Group{}.OpenAPIModelName():                                schema_pkg_apis_crd_v1beta1_Group(ref),
 		v1beta1.GroupCondition{}.OpenAPIModelName():                       schema_pkg_apis_crd_v1beta1_GroupCondition(ref),
 		v1beta1.GroupList{}.OpenAPIModelName():                            schema_pkg_apis_crd_v1beta1_GroupList(ref),
 		v1beta1.GroupSpec{}.OpenAPIModelName():                            schema_pkg_apis_crd_v1beta1_GroupSpec(ref),
 		v1beta1.GroupStatus{}.OpenAPIModelName():                          schema_pkg_apis_crd_v1beta1_GroupStatus(ref),
+		v1beta1.HTTPHeaderMatch{}.OpenAPIModelName():                      schema_pkg_apis_crd_v1beta1_HTTPHeaderMatch(ref),
 		v1beta1.HTTPProtocol{}.OpenAPIModelName():                         schema_pkg_apis_crd_v1beta1_HTTPProtocol(ref),
+		v1beta1.HTTPQueryParamMatch{}.OpenAPIModelName():                  schema_pkg_apis_crd_v1beta1_HTTPQueryParamMatch(ref),
 		v1beta1.ICMPEchoRequestHeader{}.OpenAPIModelName():                schema_pkg_apis_crd_v1beta1_ICMPEchoRequestHeader(ref),
 		v1beta1.ICMPProtocol{}.OpenAPIModelName():                         schema_pkg_apis_crd_v1beta1_ICMPProtocol(ref),
 		v1beta1.IGMPProtocol{}.OpenAPIModelName():                         schema_pkg_apis_crd_v1beta1_IGMPProtocol(ref),
@@ -471,6 +481,33 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 	}
 }
 
+func schema_pkg_apis_controlplane_v1beta2_AccessKey(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"accessKeyId": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"secretAccessKey": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"accessKeyId", "secretAccessKey"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_controlplane_v1beta2_AddressGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -834,10 +871,41 @@ func schema_pkg_apis_controlplane_v1beta2_BundleFileServer(ref common.ReferenceC
 							Format: "byte",
 						},
 					},
+					"s3": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(v1beta2.BundleS3Config{}.OpenAPIModelName()),
+						},
+					},
 				},
 				Required: []string{"url"},
 			},
 		},
+		Dependencies: []string{
+			v1beta2.BundleS3Config{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_controlplane_v1beta2_BundleS3Config(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"region": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -864,11 +932,16 @@ func schema_pkg_apis_controlplane_v1beta2_BundleServerAuthConfiguration(ref comm
 							Ref: ref(v1beta2.BasicAuthentication{}.OpenAPIModelName()),
 						},
 					},
+					"accessKey": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(v1beta2.AccessKey{}.OpenAPIModelName()),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			v1beta2.BasicAuthentication{}.OpenAPIModelName()},
+			v1beta2.AccessKey{}.OpenAPIModelName(), v1beta2.BasicAuthentication{}.OpenAPIModelName()},
 	}
 }
 
@@ -955,6 +1028,33 @@ func schema_pkg_apis_controlplane_v1beta2_ClusterGroupMembers(ref common.Referen
 	}
 }
 
+func schema_pkg_apis_controlplane_v1beta2_DNSProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DNSProtocol matches DNS queries with specific query name and query type. All fields could be used alone or together. If all fields are not provided, it matches all DNS queries.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"queryName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QueryName represents the domain name in the DNS query to match. Both exact matches and wildcards are supported (Ex. \"www.foo.com\", \"*.foo.com\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"queryType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QueryType represents the type of the DNS query to match. It could be A, AAAA, CNAME, MX, NS, PTR, SOA, SRV, TXT and ANY.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_controlplane_v1beta2_EgressGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1153,6 +1253,33 @@ func schema_pkg_apis_controlplane_v1beta2_ExternalEntityReference(ref common.Ref
 	}
 }
 
+func schema_pkg_apis_controlplane_v1beta2_GRPCProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GRPCProtocol matches gRPC requests with specific service and method, which are derived from the HTTP/2 :path pseudo-header \"/<service>/<method>\". All fields could be used alone or together. If all fields are not provided, it matches all gRPC requests.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"service": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Service represents the fully-qualified name of the gRPC service to match (Ex. \"helloworld.Greeter\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"method": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Method represents the name of the gRPC method to match (Ex. \"SayHello\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_controlplane_v1beta2_GroupAssociation(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1386,11 +1513,40 @@ func schema_pkg_apis_controlplane_v1beta2_GroupReference(ref common.ReferenceCal
 	}
 }
 
+func schema_pkg_apis_controlplane_v1beta2_HTTPHeaderMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HTTPHeaderMatch matches an HTTP request header with specific name and value.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name represents the name of the header to match. It is case-insensitive.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value represents the value of the header to match. If it is not provided, any request carrying the header is matched.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_controlplane_v1beta2_HTTPProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "HTTPProtocol matches HTTP requests with specific host, method, and path. All fields could be used alone or together. If all fields are not provided, it matches all HTTP requests.",
+				Description: "HTTPProtocol matches HTTP requests with specific host, method, path, headers, and query parameters. All fields could be used alone or together. If all fields are not provided, it matches all HTTP requests.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"host": {
@@ -1414,7 +1570,73 @@ func schema_pkg_apis_controlplane_v1beta2_HTTPProtocol(ref common.ReferenceCallb
 							Format:      "",
 						},
 					},
+					"pathRegex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PathRegex represents a regular expression to match the URI path (Ex. \"^/api/v[0-9]+/users$\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Headers represents the request headers to match. A request is matched only if all headers are matched.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1beta2.HTTPHeaderMatch{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"queryParams": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QueryParams represents the URI query parameters to match. A request is matched only if all query parameters are matched.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1beta2.HTTPQueryParamMatch{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1beta2.HTTPHeaderMatch{}.OpenAPIModelName(), v1beta2.HTTPQueryParamMatch{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_controlplane_v1beta2_HTTPQueryParamMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HTTPQueryParamMatch matches an HTTP request URI query parameter with specific name and value.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name represents the name of the query parameter to match.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value represents the value of the query parameter to match. If it is not provided, any request carrying the query parameter is matched.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"name"},
 			},
 		},
 	}
@@ -1548,11 +1770,21 @@ func schema_pkg_apis_controlplane_v1beta2_L7Protocol(ref common.ReferenceCallbac
 							Ref: ref(v1beta2.TLSProtocol{}.OpenAPIModelName()),
 						},
 					},
+					"grpc": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(v1beta2.GRPCProtocol{}.OpenAPIModelName()),
+						},
+					},
+					"dns": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(v1beta2.DNSProtocol{}.OpenAPIModelName()),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			v1beta2.HTTPProtocol{}.OpenAPIModelName(), v1beta2.TLSProtocol{}.OpenAPIModelName()},
+			v1beta2.DNSProtocol{}.OpenAPIModelName(), v1beta2.GRPCProtocol{}.OpenAPIModelName(), v1beta2.HTTPProtocol{}.OpenAPIModelName(), v1beta2.TLSProtocol{}.OpenAPIModelName()},
 	}
 }
 
@@ -2607,6 +2839,18 @@ func schema_pkg_apis_controlplane_v1beta2_SupportBundleCollection(ref common.Ref
 							Ref:     ref(v1beta2.BundleServerAuthConfiguration{}.OpenAPIModelName()),
 						},
 					},
+					"profile": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"redact": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
 				},
 			},
 		},
@@ -3563,6 +3807,33 @@ func schema_pkg_apis_crd_v1beta1_ControllerCondition(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_crd_v1beta1_DNSProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DNSProtocol matches DNS queries with specific query name and query type. All fields could be used alone or together. If all fields are not provided, it matches all DNS queries.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"queryName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QueryName represents the domain name in the DNS query to match. Both exact matches and wildcards are supported (Ex. \"www.foo.com\", \"*.foo.com\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"queryType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QueryType represents the type of the DNS query to match. It could be A, AAAA, CNAME, MX, NS, PTR, SOA, SRV, TXT and ANY.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_crd_v1beta1_Destination(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4025,6 +4296,33 @@ func schema_pkg_apis_crd_v1beta1_ExternalIPPoolStatus(ref common.ReferenceCallba
 	}
 }
 
+func schema_pkg_apis_crd_v1beta1_GRPCProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GRPCProtocol matches gRPC requests with specific service and method, which are derived from the HTTP/2 :path pseudo-header \"/<service>/<method>\". All fields could be used alone or together. If all fields are not provided, it matches all gRPC requests.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"service": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Service represents the fully-qualified name of the gRPC service to match (Ex. \"helloworld.Greeter\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"method": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Method represents the name of the gRPC method to match (Ex. \"SayHello\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_crd_v1beta1_Group(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4258,11 +4556,40 @@ func schema_pkg_apis_crd_v1beta1_GroupStatus(ref common.ReferenceCallback) commo
 	}
 }
 
+func schema_pkg_apis_crd_v1beta1_HTTPHeaderMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HTTPHeaderMatch matches an HTTP request header with specific name and value.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name represents the name of the header to match. It is case-insensitive.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value represents the value of the header to match. If it is not provided, any request carrying the header is matched.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_crd_v1beta1_HTTPProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "HTTPProtocol matches HTTP requests with specific host, method, and path. All fields could be used alone or together. If all fields are not provided, it matches all HTTP requests.",
+				Description: "HTTPProtocol matches HTTP requests with specific host, method, path, headers, and query parameters. All fields could be used alone or together. If all fields are not provided, it matches all HTTP requests.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"host": {
@@ -4286,7 +4613,73 @@ func schema_pkg_apis_crd_v1beta1_HTTPProtocol(ref common.ReferenceCallback) comm
 							Format:      "",
 						},
 					},
+					"pathRegex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PathRegex represents a regular expression to match the URI path (Ex. \"^/api/v[0-9]+/users$\"). It cannot be used together with Path.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Headers represents the request headers to match. A request is matched only if all headers are matched.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1beta1.HTTPHeaderMatch{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"queryParams": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QueryParams represents the URI query parameters to match. A request is matched only if all query parameters are matched.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1beta1.HTTPQueryParamMatch{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1beta1.HTTPHeaderMatch{}.OpenAPIModelName(), v1beta1.HTTPQueryParamMatch{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_crd_v1beta1_HTTPQueryParamMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HTTPQueryParamMatch matches an HTTP request URI query parameter with specific name and value.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name represents the name of the query parameter to match.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value represents the value of the query parameter to match. If it is not provided, any request carrying the query parameter is matched.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"name"},
 			},
 		},
 	}
@@ -4776,11 +5169,21 @@ func schema_pkg_apis_crd_v1beta1_L7Protocol(ref common.ReferenceCallback) common
 							Ref: ref(v1beta1.TLSProtocol{}.OpenAPIModelName()),
 						},
 					},
+					"grpc": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(v1beta1.GRPCProtocol{}.OpenAPIModelName()),
+						},
+					},
+					"dns": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(v1beta1.DNSProtocol{}.OpenAPIModelName()),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			v1beta1.HTTPProtocol{}.OpenAPIModelName(), v1beta1.TLSProtocol{}.OpenAPIModelName()},
+			v1beta1.DNSProtocol{}.OpenAPIModelName(), v1beta1.GRPCProtocol{}.OpenAPIModelName(), v1beta1.HTTPProtocol{}.OpenAPIModelName(), v1beta1.TLSProtocol{}.OpenAPIModelName()},
 	}
 }
 
@@ -7012,6 +7415,34 @@ func schema_pkg_apis_stats_v1alpha1_TargetIPLatencyStats(ref common.ReferenceCal
 							Format:      "int64",
 						},
 					},
+					"packetsSent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The number of probes sent to this target IP.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"packetsLost": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The number of probes sent to this target IP for which no reply was received.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"packetLossPercent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The percentage of lost probes among the most recent probes (up to 100) sent to this target IP.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"jitterNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The RTT jitter for this target IP, in nanoseconds. It is computed as the smoothed mean deviation of the difference between consecutive RTT measurements, as described in RFC 3550.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 			},
 		},
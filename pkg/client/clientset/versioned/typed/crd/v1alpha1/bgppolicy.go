@@ -37,6 +37,8 @@ type BGPPoliciesGetter interface {
 type BGPPolicyInterface interface {
 	Create(ctx context.Context, bGPPolicy *crdv1alpha1.BGPPolicy, opts v1.CreateOptions) (*crdv1alpha1.BGPPolicy, error)
 	Update(ctx context.Context, bGPPolicy *crdv1alpha1.BGPPolicy, opts v1.UpdateOptions) (*crdv1alpha1.BGPPolicy, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, bGPPolicy *crdv1alpha1.BGPPolicy, opts v1.UpdateOptions) (*crdv1alpha1.BGPPolicy, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*crdv1alpha1.BGPPolicy, error)
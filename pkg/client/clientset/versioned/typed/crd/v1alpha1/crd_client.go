@@ -28,6 +28,7 @@ type CrdV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	AntreaNodeConfigsGetter
 	BGPPoliciesGetter
+	ConnectivityProbesGetter
 	ExternalNodesGetter
 	FlowExporterDestinationsGetter
 	NodeLatencyMonitorsGetter
@@ -48,6 +49,10 @@ func (c *CrdV1alpha1Client) BGPPolicies() BGPPolicyInterface {
 	return newBGPPolicies(c)
 }
 
+func (c *CrdV1alpha1Client) ConnectivityProbes() ConnectivityProbeInterface {
+	return newConnectivityProbes(c)
+}
+
 func (c *CrdV1alpha1Client) ExternalNodes(namespace string) ExternalNodeInterface {
 	return newExternalNodes(c, namespace)
 }
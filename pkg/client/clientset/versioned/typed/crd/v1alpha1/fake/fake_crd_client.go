@@ -34,6 +34,10 @@ func (c *FakeCrdV1alpha1) BGPPolicies() v1alpha1.BGPPolicyInterface {
 	return newFakeBGPPolicies(c)
 }
 
+func (c *FakeCrdV1alpha1) ConnectivityProbes() v1alpha1.ConnectivityProbeInterface {
+	return newFakeConnectivityProbes(c)
+}
+
 func (c *FakeCrdV1alpha1) ExternalNodes(namespace string) v1alpha1.ExternalNodeInterface {
 	return newFakeExternalNodes(c, namespace)
 }
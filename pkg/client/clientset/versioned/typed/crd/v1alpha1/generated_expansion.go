@@ -20,6 +20,8 @@ type AntreaNodeConfigExpansion interface{}
 
 type BGPPolicyExpansion interface{}
 
+type ConnectivityProbeExpansion interface{}
+
 type ExternalNodeExpansion interface{}
 
 type FlowExporterDestinationExpansion interface{}
@@ -26,6 +26,8 @@ type Interface interface {
 	AntreaNodeConfigs() AntreaNodeConfigInformer
 	// BGPPolicies returns a BGPPolicyInformer.
 	BGPPolicies() BGPPolicyInformer
+	// ConnectivityProbes returns a ConnectivityProbeInformer.
+	ConnectivityProbes() ConnectivityProbeInformer
 	// ExternalNodes returns a ExternalNodeInformer.
 	ExternalNodes() ExternalNodeInformer
 	// FlowExporterDestinations returns a FlowExporterDestinationInformer.
@@ -59,6 +61,11 @@ func (v *version) BGPPolicies() BGPPolicyInformer {
 	return &bGPPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ConnectivityProbes returns a ConnectivityProbeInformer.
+func (v *version) ConnectivityProbes() ConnectivityProbeInformer {
+	return &connectivityProbeInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ExternalNodes returns a ExternalNodeInformer.
 func (v *version) ExternalNodes() ExternalNodeInformer {
 	return &externalNodeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
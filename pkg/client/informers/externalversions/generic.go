@@ -57,6 +57,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().AntreaNodeConfigs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("bgppolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().BGPPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("connectivityprobes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().ConnectivityProbes().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("externalnodes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().ExternalNodes().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("flowexporterdestinations"):
@@ -24,6 +24,10 @@ type AntreaNodeConfigListerExpansion interface{}
 // BGPPolicyLister.
 type BGPPolicyListerExpansion interface{}
 
+// ConnectivityProbeListerExpansion allows custom methods to be added to
+// ConnectivityProbeLister.
+type ConnectivityProbeListerExpansion interface{}
+
 // ExternalNodeListerExpansion allows custom methods to be added to
 // ExternalNodeLister.
 type ExternalNodeListerExpansion interface{}
@@ -207,6 +207,8 @@ type AgentConfig struct {
 	ExternalNode ExternalNodeConfig `yaml:"externalNode,omitempty"`
 	// AuditLogging supports configuring log rotation for audit logs.
 	AuditLogging AuditLoggingConfig `yaml:"auditLogging,omitempty"`
+	// L7NetworkPolicy related configurations.
+	L7NetworkPolicy L7NetworkPolicyConfig `yaml:"l7NetworkPolicy,omitempty"`
 	// Antrea's native secondary network configuration.
 	SecondaryNetwork SecondaryNetworkConfig `yaml:"secondaryNetwork,omitempty"`
 	// PacketInRate defines the OVS controller packet rate limits for different
@@ -333,6 +335,12 @@ type FlowExporterConfig struct {
 	// protocols are exported which are:
 	// "tcp", "udp", "sctp"
 	ProtocolFilter []string `yaml:"protocolFilter,omitempty"`
+	// Distribute flow records across the ready endpoints of the collector Service,
+	// based on a hash of the connection 5-tuple, instead of sending all flow records
+	// to the Service ClusterIP. This is required when running the Flow Aggregator
+	// with multiple replicas in Aggregate mode. FlowCollectorAddr must reference a
+	// Service. Defaults to false.
+	EnableSharding bool `yaml:"enableSharding,omitempty"`
 }
 
 type MulticastConfig struct {
@@ -433,6 +441,13 @@ type AuditLoggingConfig struct {
 	Compress *bool `yaml:"compress,omitempty"`
 }
 
+type L7NetworkPolicyConfig struct {
+	// The engine enforcing layer 7 NetworkPolicy rules. Valid values are "suricata" and "builtin". "suricata" runs
+	// Suricata in the antrea-agent container and supports all layer 7 protocols. "builtin" runs a lightweight engine
+	// in antrea-agent, which doesn't require Suricata but only supports HTTP and TLS. Defaults to "suricata".
+	Engine string `yaml:"engine,omitempty"`
+}
+
 type SecondaryNetworkConfig struct {
 	// Configuration of OVS bridges for secondary networks. At the moment, only a
 	// single OVS bridge is supported.
@@ -70,6 +70,11 @@ type FlowAggregatorConfig struct {
 	S3Uploader S3UploaderConfig `yaml:"s3Uploader,omitempty"`
 	// FlowLogger contains configuration options for writing flow records to a local log file.
 	FlowLogger FlowLoggerConfig `yaml:"flowLogger,omitempty"`
+	// Kafka contains configuration options for publishing flow records to Apache Kafka.
+	Kafka KafkaConfig `yaml:"kafka,omitempty"`
+	// OTLP contains configuration options for exporting flow records to an OpenTelemetry
+	// collector.
+	OTLP OTLPConfig `yaml:"otlp,omitempty"`
 	// RecordBufferSize is the number of entries in the ring buffer used to distribute
 	// flow records to exporters. Each exporter independently consumes from the buffer.
 	// Defaults to 8192.
@@ -169,6 +174,8 @@ type ClickHouseConfig struct {
 	CommitInterval string `yaml:"commitInterval,omitempty"`
 	// TLS configuration options, when using TLS to connect to the ClickHouse service.
 	TLS ClickHouseTLSConfig `yaml:"tls,omitempty"`
+	// Spool configures a disk spool to store flow records while ClickHouse is unavailable.
+	Spool SpoolConfig `yaml:"spool,omitempty"`
 }
 
 type ClickHouseTLSConfig struct {
@@ -209,6 +216,26 @@ type S3UploaderConfig struct {
 	MaxRecordsPerFile int32 `yaml:"maxRecordsPerFile,omitempty"`
 	// UploadInterval is the duration between each file upload to S3.
 	UploadInterval string `yaml:"uploadInterval,omitempty"`
+	// Spool configures a disk spool to store flow records while S3 is unavailable.
+	Spool SpoolConfig `yaml:"spool,omitempty"`
+}
+
+type SpoolConfig struct {
+	// Enable is the switch to enable the disk spool. When enabled, flow records are appended
+	// to the spool while the exporter fails to write to its destination, or while it has too
+	// many records pending in memory, and they are replayed in order once the destination
+	// recovers. The spool is stored in the /var/lib/flow-aggregator/spool directory, which
+	// should be backed by a PersistentVolume for the records to survive the deletion of the
+	// Pod.
+	Enable bool `yaml:"enable,omitempty"`
+	// MaxSize is the maximum disk space used by the spool, as a Kubernetes resource quantity
+	// (e.g., "1Gi"). Defaults to "1Gi". Min value allowed is "1Mi".
+	MaxSize string `yaml:"maxSize,omitempty"`
+	// OverflowPolicy determines which records are discarded once the spool reaches MaxSize.
+	// Must be one of "DropOldest" (discard the oldest spooled records to make room for new
+	// ones) or "DropNewest" (discard new records until room is available). Defaults to
+	// "DropOldest".
+	OverflowPolicy string `yaml:"overflowPolicy,omitempty"`
 }
 
 type FlowLoggerConfig struct {
@@ -239,6 +266,121 @@ type FlowLoggerConfig struct {
 	PrettyPrint *bool `yaml:"prettyPrint,omitempty"`
 }
 
+type KafkaConfig struct {
+	// Enable is the switch to enable publishing flow records to Apache Kafka.
+	Enable bool `yaml:"enable,omitempty"`
+	// Brokers is the list of Kafka bootstrap brokers, each with format <host>:<port>. If this
+	// field is empty, initialization will fail.
+	Brokers []string `yaml:"brokers,omitempty"`
+	// Topic is the Kafka topic to which flow records are published. Defaults to "antrea-flows".
+	Topic string `yaml:"topic,omitempty"`
+	// RecordFormat defines the serialization of the flow records published to Kafka, each
+	// record being a serialized Flow message as defined in
+	// https://github.com/antrea-io/antrea/blob/main/pkg/apis/flow/v1alpha1/flow.proto.
+	// Supported formats are "Protobuf" and "JSON". Defaults to "Protobuf".
+	RecordFormat string `yaml:"recordFormat,omitempty"`
+	// PartitionKey determines the key of the Kafka messages, and therefore the partition to
+	// which each flow record is published. Supported values are "FlowKey" (the 5-tuple of the
+	// connection, so that all the records of a connection are in the same partition) and
+	// "Namespace" (the Namespace of the source Pod, or of the destination Pod if the source is
+	// not a Pod). Defaults to "FlowKey".
+	PartitionKey string `yaml:"partitionKey,omitempty"`
+	// Compression is the compression codec used for the messages. Supported values are
+	// "none", "gzip", "snappy", "lz4" and "zstd". Defaults to "none".
+	Compression string `yaml:"compression,omitempty"`
+	// TLS configuration options, when using TLS to connect to the Kafka brokers.
+	TLS KafkaTLSConfig `yaml:"tls,omitempty"`
+	// SASL configuration options, when using SASL to authenticate to the Kafka brokers.
+	SASL KafkaSASLConfig `yaml:"sasl,omitempty"`
+}
+
+type KafkaTLSConfig struct {
+	// Enable TLS.
+	Enable bool `yaml:"enable,omitempty"`
+	// Name of the Secret containing the CA certificate used to authenticate the Kafka brokers.
+	// Default root CAs will be used if this field is empty. The Secret must be created in the
+	// Namespace in which the Flow Aggregator is deployed, and it must contain the ca.crt key.
+	CASecretName string `yaml:"caSecretName,omitempty"`
+	// ServerName is used to verify the hostname on the returned certificates. If this field is
+	// omitted, the hostname of each broker address will be used.
+	ServerName string `yaml:"serverName,omitempty"`
+	// Name of the Secret containing the client's certificate and private key for mTLS. If
+	// omitted, client authentication will be disabled. The Secret must be created in Namespace
+	// in which the Flow Aggregator is deployed, and it must be of type kubernetes.io/tls and
+	// contain the tls.crt and tls.key keys.
+	ClientSecretName string `yaml:"clientSecretName,omitempty"`
+	// InsecureSkipVerify determines whether to skip the verification of the brokers'
+	// certificate chain and host name. Default is false.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+	// TLS min version.
+	MinVersion string `yaml:"minVersion,omitempty"`
+}
+
+type KafkaSASLConfig struct {
+	// Enable SASL authentication. The username and password are read from the KAFKA_USERNAME
+	// and KAFKA_PASSWORD environment variables, which are populated from the
+	// "flow-aggregator-kafka-credentials" Secret.
+	Enable bool `yaml:"enable,omitempty"`
+	// Mechanism is the SASL mechanism. Supported values are "PLAIN", "SCRAM-SHA-256" and
+	// "SCRAM-SHA-512". Defaults to "PLAIN".
+	Mechanism string `yaml:"mechanism,omitempty"`
+}
+
+type OTLPConfig struct {
+	// Enable is the switch to enable exporting flow records to an OpenTelemetry collector, as
+	// OTLP log records.
+	Enable bool `yaml:"enable,omitempty"`
+	// Endpoint is the address of the OTLP receiver, with format <host>:<port>. If this field is
+	// empty, initialization will fail.
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Protocol is the OTLP transport. Supported values are "gRPC" and "HTTP" (binary Protobuf
+	// encoding, with the "/v1/logs" and "/v1/metrics" paths). Defaults to "gRPC".
+	Protocol string `yaml:"protocol,omitempty"`
+	// Headers are additional headers sent with each export request, e.g. for authentication.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Compress enables gzip compression of the export requests. Defaults to true.
+	Compress *bool `yaml:"compress,omitempty"`
+	// Timeout is the timeout for each export request. Defaults to "10s". Valid time units are
+	// "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	Timeout string `yaml:"timeout,omitempty"`
+	// TLS configuration options, when using TLS to connect to the OTLP receiver.
+	TLS OTLPTLSConfig `yaml:"tls,omitempty"`
+	// Metrics contains configuration options for the per-workload-pair metrics derived from
+	// flow records.
+	Metrics OTLPMetricsConfig `yaml:"metrics,omitempty"`
+}
+
+type OTLPTLSConfig struct {
+	// Enable TLS.
+	Enable bool `yaml:"enable,omitempty"`
+	// Name of the Secret containing the CA certificate used to authenticate the OTLP receiver.
+	// Default root CAs will be used if this field is empty. The Secret must be created in the
+	// Namespace in which the Flow Aggregator is deployed, and it must contain the ca.crt key.
+	CASecretName string `yaml:"caSecretName,omitempty"`
+	// ServerName is used to verify the hostname on the returned certificates. If this field is
+	// omitted, the hostname of the endpoint will be used.
+	ServerName string `yaml:"serverName,omitempty"`
+	// Name of the Secret containing the client's certificate and private key for mTLS. If
+	// omitted, client authentication will be disabled. The Secret must be created in Namespace
+	// in which the Flow Aggregator is deployed, and it must be of type kubernetes.io/tls and
+	// contain the tls.crt and tls.key keys.
+	ClientSecretName string `yaml:"clientSecretName,omitempty"`
+	// InsecureSkipVerify determines whether to skip the verification of the receiver's
+	// certificate chain and host name. Default is false.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+	// TLS min version.
+	MinVersion string `yaml:"minVersion,omitempty"`
+}
+
+type OTLPMetricsConfig struct {
+	// Enable is the switch to enable deriving byte and packet counters for each pair of source
+	// and destination workloads from the flow records, and exporting them as OTLP metrics.
+	Enable bool `yaml:"enable,omitempty"`
+	// ExportInterval is the interval between exports of the metrics. Defaults to "60s". Valid
+	// time units are "ns", "us" (or "µs"), "ms", "s", "m", "h". Min value allowed is "1s".
+	ExportInterval string `yaml:"exportInterval,omitempty"`
+}
+
 type NetworkPolicyRuleAction string
 
 const (
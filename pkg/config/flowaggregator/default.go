@@ -50,6 +50,21 @@ const (
 	DefaultLoggerMaxBackups   = 3
 	DefaultLoggerRecordFormat = "CSV"
 
+	DefaultKafkaTopic         = "antrea-flows"
+	DefaultKafkaRecordFormat  = "Protobuf"
+	DefaultKafkaPartitionKey  = "FlowKey"
+	DefaultKafkaCompression   = "none"
+	DefaultKafkaSASLMechanism = "PLAIN"
+
+	DefaultSpoolMaxSize        = "1Gi"
+	MinSpoolMaxSize            = 1 << 20
+	DefaultSpoolOverflowPolicy = "DropOldest"
+
+	DefaultOTLPProtocol              = "gRPC"
+	DefaultOTLPTimeout               = "10s"
+	DefaultOTLPMetricsExportInterval = "60s"
+	MinOTLPMetricsExportInterval     = 1 * time.Second
+
 	DefaultRecordBufferSize = 8192
 )
 
@@ -93,6 +108,7 @@ func SetConfigDefaults(flowAggregatorConf *FlowAggregatorConfig) {
 	if flowAggregatorConf.ClickHouse.CommitInterval == "" {
 		flowAggregatorConf.ClickHouse.CommitInterval = DefaultClickHouseCommitInterval
 	}
+	setSpoolConfigDefaults(&flowAggregatorConf.ClickHouse.Spool)
 	if flowAggregatorConf.S3Uploader.Compress == nil {
 		flowAggregatorConf.S3Uploader.Compress = ptr.To(true)
 	}
@@ -105,6 +121,7 @@ func SetConfigDefaults(flowAggregatorConf *FlowAggregatorConfig) {
 	if flowAggregatorConf.S3Uploader.UploadInterval == "" {
 		flowAggregatorConf.S3Uploader.UploadInterval = DefaultS3UploadInterval
 	}
+	setSpoolConfigDefaults(&flowAggregatorConf.S3Uploader.Spool)
 	if flowAggregatorConf.FlowLogger.Path == "" {
 		flowAggregatorConf.FlowLogger.Path = filepath.Join(os.TempDir(), "antrea-flows.log")
 	}
@@ -123,7 +140,43 @@ func SetConfigDefaults(flowAggregatorConf *FlowAggregatorConfig) {
 	if flowAggregatorConf.FlowLogger.PrettyPrint == nil {
 		flowAggregatorConf.FlowLogger.PrettyPrint = ptr.To(true)
 	}
+	if flowAggregatorConf.Kafka.Topic == "" {
+		flowAggregatorConf.Kafka.Topic = DefaultKafkaTopic
+	}
+	if flowAggregatorConf.Kafka.RecordFormat == "" {
+		flowAggregatorConf.Kafka.RecordFormat = DefaultKafkaRecordFormat
+	}
+	if flowAggregatorConf.Kafka.PartitionKey == "" {
+		flowAggregatorConf.Kafka.PartitionKey = DefaultKafkaPartitionKey
+	}
+	if flowAggregatorConf.Kafka.Compression == "" {
+		flowAggregatorConf.Kafka.Compression = DefaultKafkaCompression
+	}
+	if flowAggregatorConf.Kafka.SASL.Mechanism == "" {
+		flowAggregatorConf.Kafka.SASL.Mechanism = DefaultKafkaSASLMechanism
+	}
+	if flowAggregatorConf.OTLP.Protocol == "" {
+		flowAggregatorConf.OTLP.Protocol = DefaultOTLPProtocol
+	}
+	if flowAggregatorConf.OTLP.Compress == nil {
+		flowAggregatorConf.OTLP.Compress = ptr.To(true)
+	}
+	if flowAggregatorConf.OTLP.Timeout == "" {
+		flowAggregatorConf.OTLP.Timeout = DefaultOTLPTimeout
+	}
+	if flowAggregatorConf.OTLP.Metrics.ExportInterval == "" {
+		flowAggregatorConf.OTLP.Metrics.ExportInterval = DefaultOTLPMetricsExportInterval
+	}
 	if flowAggregatorConf.RecordBufferSize == 0 {
 		flowAggregatorConf.RecordBufferSize = DefaultRecordBufferSize
 	}
 }
+
+func setSpoolConfigDefaults(spoolConf *SpoolConfig) {
+	if spoolConf.MaxSize == "" {
+		spoolConf.MaxSize = DefaultSpoolMaxSize
+	}
+	if spoolConf.OverflowPolicy == "" {
+		spoolConf.OverflowPolicy = DefaultSpoolOverflowPolicy
+	}
+}
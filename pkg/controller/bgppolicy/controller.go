@@ -0,0 +1,323 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgppolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	crdv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+	clientset "antrea.io/antrea/v2/pkg/client/clientset/versioned"
+	crdv1a1informers "antrea.io/antrea/v2/pkg/client/informers/externalversions/crd/v1alpha1"
+	crdv1b1informers "antrea.io/antrea/v2/pkg/client/informers/externalversions/crd/v1beta1"
+	crdv1a1listers "antrea.io/antrea/v2/pkg/client/listers/crd/v1alpha1"
+	crdv1b1listers "antrea.io/antrea/v2/pkg/client/listers/crd/v1beta1"
+)
+
+const (
+	controllerName = "BGPPolicyStatusController"
+	// How long to wait before retrying the processing of a BGPPolicy status change.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing BGPPolicy status changes.
+	defaultWorkers = 4
+	// Set resyncPeriod to 0 to disable resyncing.
+	resyncPeriod time.Duration = 0
+
+	// agentInfoBGPPolicyIndex is the index of AntreaAgentInfos by the name of the BGPPolicy effective on the Node.
+	agentInfoBGPPolicyIndex = "bgpPolicy"
+)
+
+// StatusController aggregates the status of BGPPolicies reported by antrea-agents in their AntreaAgentInfos into the
+// BGPPolicy status. It is the only writer of the BGPPolicy status, which avoids conflicting updates from the agents,
+// and it removes the status of the Nodes which no longer exist.
+type StatusController struct {
+	crdClient clientset.Interface
+
+	bgpPolicyInformer     crdv1a1informers.BGPPolicyInformer
+	bgpPolicyLister       crdv1a1listers.BGPPolicyLister
+	bgpPolicyListerSynced cache.InformerSynced
+
+	agentInfoInformer     crdv1b1informers.AntreaAgentInfoInformer
+	agentInfoLister       crdv1b1listers.AntreaAgentInfoLister
+	agentInfoListerSynced cache.InformerSynced
+
+	nodeLister       corelisters.NodeLister
+	nodeListerSynced cache.InformerSynced
+
+	// queue maintains the names of the BGPPolicies whose status needs to be synced.
+	queue workqueue.TypedRateLimitingInterface[string]
+}
+
+func NewStatusController(crdClient clientset.Interface,
+	bgpPolicyInformer crdv1a1informers.BGPPolicyInformer,
+	agentInfoInformer crdv1b1informers.AntreaAgentInfoInformer,
+	nodeInformer coreinformers.NodeInformer) *StatusController {
+	c := &StatusController{
+		crdClient: crdClient,
+
+		bgpPolicyInformer:     bgpPolicyInformer,
+		bgpPolicyLister:       bgpPolicyInformer.Lister(),
+		bgpPolicyListerSynced: bgpPolicyInformer.Informer().HasSynced,
+
+		agentInfoInformer:     agentInfoInformer,
+		agentInfoLister:       agentInfoInformer.Lister(),
+		agentInfoListerSynced: agentInfoInformer.Informer().HasSynced,
+
+		nodeLister:       nodeInformer.Lister(),
+		nodeListerSynced: nodeInformer.Informer().HasSynced,
+
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[string](minRetryDelay, maxRetryDelay),
+			workqueue.TypedRateLimitingQueueConfig[string]{
+				Name: "bgpPolicyStatus",
+			},
+		),
+	}
+	c.agentInfoInformer.Informer().AddIndexers(cache.Indexers{agentInfoBGPPolicyIndex: agentInfoBGPPolicyIndexFunc})
+	c.bgpPolicyInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addBGPPolicy,
+			UpdateFunc: c.updateBGPPolicy,
+		},
+		resyncPeriod)
+	c.agentInfoInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addAgentInfo,
+			UpdateFunc: c.updateAgentInfo,
+			DeleteFunc: c.deleteAgentInfo,
+		},
+		resyncPeriod)
+	nodeInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			DeleteFunc: c.deleteNode,
+		},
+		resyncPeriod)
+	return c
+}
+
+func agentInfoBGPPolicyIndexFunc(obj interface{}) ([]string, error) {
+	agentInfo, ok := obj.(*crdv1beta1.AntreaAgentInfo)
+	if !ok {
+		return nil, fmt.Errorf("obj is not AntreaAgentInfo: %+v", obj)
+	}
+	if agentInfo.BGPPolicyInfo == nil {
+		return nil, nil
+	}
+	return []string{agentInfo.BGPPolicyInfo.Name}, nil
+}
+
+func (c *StatusController) addBGPPolicy(obj interface{}) {
+	bgpPolicy := obj.(*crdv1alpha1.BGPPolicy)
+	c.queue.Add(bgpPolicy.Name)
+}
+
+func (c *StatusController) updateBGPPolicy(_, obj interface{}) {
+	bgpPolicy := obj.(*crdv1alpha1.BGPPolicy)
+	c.queue.Add(bgpPolicy.Name)
+}
+
+func (c *StatusController) addAgentInfo(obj interface{}) {
+	agentInfo := obj.(*crdv1beta1.AntreaAgentInfo)
+	if agentInfo.BGPPolicyInfo != nil {
+		c.queue.Add(agentInfo.BGPPolicyInfo.Name)
+	}
+}
+
+func (c *StatusController) updateAgentInfo(oldObj, newObj interface{}) {
+	oldAgentInfo := oldObj.(*crdv1beta1.AntreaAgentInfo)
+	newAgentInfo := newObj.(*crdv1beta1.AntreaAgentInfo)
+	// AntreaAgentInfos are updated periodically, ignore the updates which don't change the BGPPolicy status.
+	if apiequality.Semantic.DeepEqual(oldAgentInfo.BGPPolicyInfo, newAgentInfo.BGPPolicyInfo) {
+		return
+	}
+	if oldAgentInfo.BGPPolicyInfo != nil {
+		c.queue.Add(oldAgentInfo.BGPPolicyInfo.Name)
+	}
+	if newAgentInfo.BGPPolicyInfo != nil {
+		c.queue.Add(newAgentInfo.BGPPolicyInfo.Name)
+	}
+}
+
+func (c *StatusController) deleteAgentInfo(obj interface{}) {
+	agentInfo, ok := obj.(*crdv1beta1.AntreaAgentInfo)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Received unexpected object", "object", obj)
+			return
+		}
+		agentInfo, ok = tombstone.Obj.(*crdv1beta1.AntreaAgentInfo)
+		if !ok {
+			klog.ErrorS(nil, "DeletedFinalStateUnknown contains non-AntreaAgentInfo object", "object", tombstone.Obj)
+			return
+		}
+	}
+	if agentInfo.BGPPolicyInfo != nil {
+		c.queue.Add(agentInfo.BGPPolicyInfo.Name)
+	}
+}
+
+func (c *StatusController) deleteNode(obj interface{}) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Received unexpected object", "object", obj)
+			return
+		}
+		node, ok = tombstone.Obj.(*corev1.Node)
+		if !ok {
+			klog.ErrorS(nil, "DeletedFinalStateUnknown contains non-Node object", "object", tombstone.Obj)
+			return
+		}
+	}
+	// The AntreaAgentInfo of a Node is named after the Node.
+	agentInfo, err := c.agentInfoLister.Get(node.Name)
+	if err != nil {
+		return
+	}
+	if agentInfo.BGPPolicyInfo != nil {
+		c.queue.Add(agentInfo.BGPPolicyInfo.Name)
+	}
+}
+
+// Run will create defaultWorkers workers (goroutines) which will process the BGPPolicy status changes from the work
+// queue.
+func (c *StatusController) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting", "controllerName", controllerName)
+	defer klog.InfoS("Shutting down", "controllerName", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.bgpPolicyListerSynced, c.agentInfoListerSynced, c.nodeListerSynced) {
+		return
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *StatusController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *StatusController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncBGPPolicyStatus(key); err == nil {
+		c.queue.Forget(key)
+	} else {
+		c.queue.AddRateLimited(key)
+		klog.ErrorS(err, "Error syncing BGPPolicy status", "BGPPolicy", key)
+	}
+	return true
+}
+
+// syncBGPPolicyStatus sets the status of the given BGPPolicy to the status reported by the Nodes on which it is
+// effective. The status of the Nodes which no longer exist is removed.
+func (c *StatusController) syncBGPPolicyStatus(name string) error {
+	bgpPolicy, err := c.bgpPolicyLister.Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	desiredStatus, err := c.getDesiredBGPPolicyStatus(bgpPolicy)
+	if err != nil {
+		return err
+	}
+	if apiequality.Semantic.DeepEqual(&bgpPolicy.Status, desiredStatus) {
+		return nil
+	}
+	toUpdate := bgpPolicy.DeepCopy()
+	toUpdate.Status = *desiredStatus
+	klog.V(2).InfoS("Updating BGPPolicy status", "BGPPolicy", klog.KObj(bgpPolicy))
+	// Conflicts are retried by the work queue with the latest BGPPolicy in the lister.
+	_, err = c.crdClient.CrdV1alpha1().BGPPolicies().UpdateStatus(context.TODO(), toUpdate, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *StatusController) getDesiredBGPPolicyStatus(bgpPolicy *crdv1alpha1.BGPPolicy) (*crdv1alpha1.BGPPolicyStatus, error) {
+	agentInfos, err := c.agentInfoInformer.Informer().GetIndexer().ByIndex(agentInfoBGPPolicyIndex, bgpPolicy.Name)
+	if err != nil {
+		return nil, err
+	}
+	curNodeStatuses := make(map[string]*crdv1alpha1.BGPPolicyNodeStatus, len(bgpPolicy.Status.Nodes))
+	for i := range bgpPolicy.Status.Nodes {
+		curNodeStatuses[bgpPolicy.Status.Nodes[i].NodeName] = &bgpPolicy.Status.Nodes[i]
+	}
+	desiredStatus := &crdv1alpha1.BGPPolicyStatus{}
+	for _, obj := range agentInfos {
+		agentInfo := obj.(*crdv1beta1.AntreaAgentInfo)
+		// The AntreaAgentInfo of a Node is named after the Node.
+		nodeName := agentInfo.Name
+		if _, err := c.nodeLister.Get(nodeName); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		bgpPolicyInfo := agentInfo.BGPPolicyInfo.DeepCopy()
+		nodeStatus := crdv1alpha1.BGPPolicyNodeStatus{
+			NodeName: nodeName,
+			RouterID: bgpPolicyInfo.RouterID,
+		}
+		for _, peer := range bgpPolicyInfo.Peers {
+			nodeStatus.Peers = append(nodeStatus.Peers, crdv1alpha1.BGPPeerStatus(peer))
+		}
+		// LastUpdateTime is the last time the status of the Node changed.
+		if curNodeStatus, ok := curNodeStatuses[nodeName]; ok && bgpPolicyNodeStatusEqual(curNodeStatus, &nodeStatus) {
+			nodeStatus.LastUpdateTime = curNodeStatus.LastUpdateTime
+		} else {
+			nodeStatus.LastUpdateTime = metav1.Now()
+		}
+		desiredStatus.Nodes = append(desiredStatus.Nodes, nodeStatus)
+	}
+	slices.SortFunc(desiredStatus.Nodes, func(a, b crdv1alpha1.BGPPolicyNodeStatus) int {
+		return strings.Compare(a.NodeName, b.NodeName)
+	})
+	return desiredStatus, nil
+}
+
+// bgpPolicyNodeStatusEqual compares two BGPPolicyNodeStatus objects, ignoring LastUpdateTime.
+func bgpPolicyNodeStatusEqual(a, b *crdv1alpha1.BGPPolicyNodeStatus) bool {
+	return a.NodeName == b.NodeName &&
+		a.RouterID == b.RouterID &&
+		apiequality.Semantic.DeepEqual(a.Peers, b.Peers)
+}
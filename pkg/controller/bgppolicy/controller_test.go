@@ -0,0 +1,222 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgppolicy
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	crdv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+	fakeversioned "antrea.io/antrea/v2/pkg/client/clientset/versioned/fake"
+	crdinformers "antrea.io/antrea/v2/pkg/client/informers/externalversions"
+)
+
+var (
+	lastUpdateTime = metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+	peer1 = crdv1beta1.BGPPeerInfo{Address: "192.168.77.200", ASN: 65001, SessionState: "Established", AdvertisedRoutes: 2}
+	peer2 = crdv1beta1.BGPPeerInfo{Address: "192.168.77.201", ASN: 65002, SessionState: "Active"}
+)
+
+type fakeController struct {
+	*StatusController
+	crdClient *fakeversioned.Clientset
+}
+
+func newFakeController(t *testing.T, objects []runtime.Object, crdObjects []runtime.Object) *fakeController {
+	client := fake.NewSimpleClientset(objects...)
+	crdClient := fakeversioned.NewSimpleClientset(crdObjects...)
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	c := NewStatusController(crdClient,
+		crdInformerFactory.Crd().V1alpha1().BGPPolicies(),
+		crdInformerFactory.Crd().V1beta1().AntreaAgentInfos(),
+		informerFactory.Core().V1().Nodes())
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	informerFactory.Start(stopCh)
+	crdInformerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+	crdInformerFactory.WaitForCacheSync(stopCh)
+	return &fakeController{StatusController: c, crdClient: crdClient}
+}
+
+func generateNode(name string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func generateAgentInfo(nodeName, bgpPolicyName, routerID string, peers ...crdv1beta1.BGPPeerInfo) *crdv1beta1.AntreaAgentInfo {
+	agentInfo := &crdv1beta1.AntreaAgentInfo{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+	if bgpPolicyName != "" {
+		agentInfo.BGPPolicyInfo = &crdv1beta1.BGPPolicyInfo{
+			Name:     bgpPolicyName,
+			RouterID: routerID,
+			Peers:    peers,
+		}
+	}
+	return agentInfo
+}
+
+func generateBGPPolicy(name string, nodeStatuses ...crdv1alpha1.BGPPolicyNodeStatus) *crdv1alpha1.BGPPolicy {
+	return &crdv1alpha1.BGPPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     crdv1alpha1.BGPPolicyStatus{Nodes: nodeStatuses},
+	}
+}
+
+func TestSyncBGPPolicyStatus(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		nodes                []runtime.Object
+		agentInfos           []runtime.Object
+		bgpPolicy            *crdv1alpha1.BGPPolicy
+		expectedNodeStatuses []crdv1alpha1.BGPPolicyNodeStatus
+		// expectedUnchangedNodes are the Nodes whose LastUpdateTime is expected to be kept.
+		expectedUnchangedNodes []string
+		expectedUpdate         bool
+	}{
+		{
+			name:  "aggregate status from Nodes",
+			nodes: []runtime.Object{generateNode("node-b"), generateNode("node-a"), generateNode("node-c")},
+			agentInfos: []runtime.Object{
+				generateAgentInfo("node-b", "policy1", "10.10.0.2", peer2),
+				generateAgentInfo("node-a", "policy1", "10.10.0.1", peer1, peer2),
+				generateAgentInfo("node-c", "policy2", "10.10.0.3", peer1),
+				generateAgentInfo("node-d", "policy1", "10.10.0.4", peer1),
+			},
+			bgpPolicy: generateBGPPolicy("policy1"),
+			expectedNodeStatuses: []crdv1alpha1.BGPPolicyNodeStatus{
+				{
+					NodeName: "node-a",
+					RouterID: "10.10.0.1",
+					Peers:    []crdv1alpha1.BGPPeerStatus{crdv1alpha1.BGPPeerStatus(peer1), crdv1alpha1.BGPPeerStatus(peer2)},
+				},
+				{
+					NodeName: "node-b",
+					RouterID: "10.10.0.2",
+					Peers:    []crdv1alpha1.BGPPeerStatus{crdv1alpha1.BGPPeerStatus(peer2)},
+				},
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:  "update changed Nodes and remove stale Nodes",
+			nodes: []runtime.Object{generateNode("node-a"), generateNode("node-b"), generateNode("node-c")},
+			agentInfos: []runtime.Object{
+				generateAgentInfo("node-a", "policy1", "10.10.0.1", peer1),
+				generateAgentInfo("node-b", "policy1", "10.10.0.2", peer1),
+				generateAgentInfo("node-c", "", ""),
+			},
+			bgpPolicy: generateBGPPolicy("policy1",
+				crdv1alpha1.BGPPolicyNodeStatus{
+					NodeName:       "node-a",
+					RouterID:       "10.10.0.1",
+					Peers:          []crdv1alpha1.BGPPeerStatus{crdv1alpha1.BGPPeerStatus(peer1)},
+					LastUpdateTime: lastUpdateTime,
+				},
+				crdv1alpha1.BGPPolicyNodeStatus{
+					NodeName:       "node-b",
+					RouterID:       "10.10.0.2",
+					Peers:          []crdv1alpha1.BGPPeerStatus{crdv1alpha1.BGPPeerStatus(peer2)},
+					LastUpdateTime: lastUpdateTime,
+				},
+				crdv1alpha1.BGPPolicyNodeStatus{
+					NodeName:       "node-c",
+					RouterID:       "10.10.0.3",
+					LastUpdateTime: lastUpdateTime,
+				},
+				crdv1alpha1.BGPPolicyNodeStatus{
+					NodeName:       "node-d",
+					RouterID:       "10.10.0.4",
+					LastUpdateTime: lastUpdateTime,
+				},
+			),
+			expectedNodeStatuses: []crdv1alpha1.BGPPolicyNodeStatus{
+				{
+					NodeName: "node-a",
+					RouterID: "10.10.0.1",
+					Peers:    []crdv1alpha1.BGPPeerStatus{crdv1alpha1.BGPPeerStatus(peer1)},
+				},
+				{
+					NodeName: "node-b",
+					RouterID: "10.10.0.2",
+					Peers:    []crdv1alpha1.BGPPeerStatus{crdv1alpha1.BGPPeerStatus(peer1)},
+				},
+			},
+			expectedUnchangedNodes: []string{"node-a"},
+			expectedUpdate:         true,
+		},
+		{
+			name:       "status unchanged",
+			nodes:      []runtime.Object{generateNode("node-a")},
+			agentInfos: []runtime.Object{generateAgentInfo("node-a", "policy1", "10.10.0.1", peer1)},
+			bgpPolicy: generateBGPPolicy("policy1",
+				crdv1alpha1.BGPPolicyNodeStatus{
+					NodeName:       "node-a",
+					RouterID:       "10.10.0.1",
+					Peers:          []crdv1alpha1.BGPPeerStatus{crdv1alpha1.BGPPeerStatus(peer1)},
+					LastUpdateTime: lastUpdateTime,
+				},
+			),
+			expectedNodeStatuses: []crdv1alpha1.BGPPolicyNodeStatus{
+				{
+					NodeName: "node-a",
+					RouterID: "10.10.0.1",
+					Peers:    []crdv1alpha1.BGPPeerStatus{crdv1alpha1.BGPPeerStatus(peer1)},
+				},
+			},
+			expectedUnchangedNodes: []string{"node-a"},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeController(t, tt.nodes, append(tt.agentInfos, tt.bgpPolicy))
+			startTime := time.Now().Truncate(time.Second)
+			require.NoError(t, c.syncBGPPolicyStatus(tt.bgpPolicy.Name))
+
+			updated := false
+			for _, action := range c.crdClient.Actions() {
+				if action.GetVerb() == "update" && action.GetSubresource() == "status" {
+					updated = true
+				}
+			}
+			assert.Equal(t, tt.expectedUpdate, updated)
+
+			bgpPolicy, err := c.crdClient.CrdV1alpha1().BGPPolicies().Get(context.TODO(), tt.bgpPolicy.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Len(t, bgpPolicy.Status.Nodes, len(tt.expectedNodeStatuses))
+			for i, nodeStatus := range bgpPolicy.Status.Nodes {
+				if slices.Contains(tt.expectedUnchangedNodes, nodeStatus.NodeName) {
+					assert.Equal(t, lastUpdateTime, nodeStatus.LastUpdateTime)
+				} else {
+					assert.False(t, nodeStatus.LastUpdateTime.Time.Before(startTime))
+				}
+				nodeStatus.LastUpdateTime = metav1.Time{}
+				assert.Equal(t, tt.expectedNodeStatuses[i], nodeStatus)
+			}
+		})
+	}
+}
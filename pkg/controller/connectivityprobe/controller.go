@@ -0,0 +1,333 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectivityprobe
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	crdv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+	clientset "antrea.io/antrea/v2/pkg/client/clientset/versioned"
+	crdv1a1informers "antrea.io/antrea/v2/pkg/client/informers/externalversions/crd/v1alpha1"
+	crdv1b1informers "antrea.io/antrea/v2/pkg/client/informers/externalversions/crd/v1beta1"
+	crdv1a1listers "antrea.io/antrea/v2/pkg/client/listers/crd/v1alpha1"
+	crdv1b1listers "antrea.io/antrea/v2/pkg/client/listers/crd/v1beta1"
+)
+
+const (
+	controllerName = "ConnectivityProbeStatusController"
+	// How long to wait before retrying the processing of a ConnectivityProbe status change.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing ConnectivityProbe status changes.
+	defaultWorkers = 4
+	// Set resyncPeriod to 0 to disable resyncing.
+	resyncPeriod time.Duration = 0
+
+	// The results reported by the agents change after every round of probes. The changes reported by the agents
+	// within statusSyncDelay are aggregated into a single update of the ConnectivityProbe status.
+	statusSyncDelay = 30 * time.Second
+
+	// agentInfoConnectivityProbeIndex is the index of AntreaAgentInfos by the names of the ConnectivityProbes sent
+	// from the Node.
+	agentInfoConnectivityProbeIndex = "connectivityProbe"
+)
+
+// StatusController aggregates the results of the ConnectivityProbes reported by antrea-agents in their
+// AntreaAgentInfos into the ConnectivityProbe status. It is the only writer of the ConnectivityProbe status, which
+// avoids conflicting updates from the agents, and it removes the status of the Nodes which no longer exist.
+type StatusController struct {
+	crdClient clientset.Interface
+
+	connectivityProbeInformer     crdv1a1informers.ConnectivityProbeInformer
+	connectivityProbeLister       crdv1a1listers.ConnectivityProbeLister
+	connectivityProbeListerSynced cache.InformerSynced
+
+	agentInfoInformer     crdv1b1informers.AntreaAgentInfoInformer
+	agentInfoLister       crdv1b1listers.AntreaAgentInfoLister
+	agentInfoListerSynced cache.InformerSynced
+
+	nodeLister       corelisters.NodeLister
+	nodeListerSynced cache.InformerSynced
+
+	// queue maintains the names of the ConnectivityProbes whose status needs to be synced.
+	queue workqueue.TypedRateLimitingInterface[string]
+}
+
+func NewStatusController(crdClient clientset.Interface,
+	connectivityProbeInformer crdv1a1informers.ConnectivityProbeInformer,
+	agentInfoInformer crdv1b1informers.AntreaAgentInfoInformer,
+	nodeInformer coreinformers.NodeInformer) *StatusController {
+	c := &StatusController{
+		crdClient: crdClient,
+
+		connectivityProbeInformer:     connectivityProbeInformer,
+		connectivityProbeLister:       connectivityProbeInformer.Lister(),
+		connectivityProbeListerSynced: connectivityProbeInformer.Informer().HasSynced,
+
+		agentInfoInformer:     agentInfoInformer,
+		agentInfoLister:       agentInfoInformer.Lister(),
+		agentInfoListerSynced: agentInfoInformer.Informer().HasSynced,
+
+		nodeLister:       nodeInformer.Lister(),
+		nodeListerSynced: nodeInformer.Informer().HasSynced,
+
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[string](minRetryDelay, maxRetryDelay),
+			workqueue.TypedRateLimitingQueueConfig[string]{
+				Name: "connectivityProbeStatus",
+			},
+		),
+	}
+	c.agentInfoInformer.Informer().AddIndexers(cache.Indexers{agentInfoConnectivityProbeIndex: agentInfoConnectivityProbeIndexFunc})
+	c.connectivityProbeInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addConnectivityProbe,
+			UpdateFunc: c.updateConnectivityProbe,
+		},
+		resyncPeriod)
+	c.agentInfoInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addAgentInfo,
+			UpdateFunc: c.updateAgentInfo,
+			DeleteFunc: c.deleteAgentInfo,
+		},
+		resyncPeriod)
+	nodeInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			DeleteFunc: c.deleteNode,
+		},
+		resyncPeriod)
+	return c
+}
+
+func agentInfoConnectivityProbeIndexFunc(obj interface{}) ([]string, error) {
+	agentInfo, ok := obj.(*crdv1beta1.AntreaAgentInfo)
+	if !ok {
+		return nil, fmt.Errorf("obj is not AntreaAgentInfo: %+v", obj)
+	}
+	names := make([]string, 0, len(agentInfo.ConnectivityProbeInfos))
+	for _, info := range agentInfo.ConnectivityProbeInfos {
+		names = append(names, info.Name)
+	}
+	return names, nil
+}
+
+func (c *StatusController) addConnectivityProbe(obj interface{}) {
+	cp := obj.(*crdv1alpha1.ConnectivityProbe)
+	c.queue.Add(cp.Name)
+}
+
+func (c *StatusController) updateConnectivityProbe(_, obj interface{}) {
+	cp := obj.(*crdv1alpha1.ConnectivityProbe)
+	c.queue.Add(cp.Name)
+}
+
+// enqueueConnectivityProbes enqueues the ConnectivityProbes reported in the given AntreaAgentInfo after
+// statusSyncDelay, so that the changes reported by all the agents in the meantime are synced together.
+func (c *StatusController) enqueueConnectivityProbes(agentInfo *crdv1beta1.AntreaAgentInfo) {
+	for _, info := range agentInfo.ConnectivityProbeInfos {
+		c.queue.AddAfter(info.Name, statusSyncDelay)
+	}
+}
+
+func (c *StatusController) addAgentInfo(obj interface{}) {
+	agentInfo := obj.(*crdv1beta1.AntreaAgentInfo)
+	c.enqueueConnectivityProbes(agentInfo)
+}
+
+func (c *StatusController) updateAgentInfo(oldObj, newObj interface{}) {
+	oldAgentInfo := oldObj.(*crdv1beta1.AntreaAgentInfo)
+	newAgentInfo := newObj.(*crdv1beta1.AntreaAgentInfo)
+	// AntreaAgentInfos are updated periodically, ignore the updates which don't change the ConnectivityProbe status.
+	if apiequality.Semantic.DeepEqual(oldAgentInfo.ConnectivityProbeInfos, newAgentInfo.ConnectivityProbeInfos) {
+		return
+	}
+	// The ConnectivityProbes which are no longer sent from the Node must be synced too.
+	c.enqueueConnectivityProbes(oldAgentInfo)
+	c.enqueueConnectivityProbes(newAgentInfo)
+}
+
+func (c *StatusController) deleteAgentInfo(obj interface{}) {
+	agentInfo, ok := obj.(*crdv1beta1.AntreaAgentInfo)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Received unexpected object", "object", obj)
+			return
+		}
+		agentInfo, ok = tombstone.Obj.(*crdv1beta1.AntreaAgentInfo)
+		if !ok {
+			klog.ErrorS(nil, "DeletedFinalStateUnknown contains non-AntreaAgentInfo object", "object", tombstone.Obj)
+			return
+		}
+	}
+	c.enqueueConnectivityProbes(agentInfo)
+}
+
+func (c *StatusController) deleteNode(obj interface{}) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Received unexpected object", "object", obj)
+			return
+		}
+		node, ok = tombstone.Obj.(*corev1.Node)
+		if !ok {
+			klog.ErrorS(nil, "DeletedFinalStateUnknown contains non-Node object", "object", tombstone.Obj)
+			return
+		}
+	}
+	// The AntreaAgentInfo of a Node is named after the Node.
+	agentInfo, err := c.agentInfoLister.Get(node.Name)
+	if err != nil {
+		return
+	}
+	c.enqueueConnectivityProbes(agentInfo)
+}
+
+// Run will create defaultWorkers workers (goroutines) which will process the ConnectivityProbe status changes from
+// the work queue.
+func (c *StatusController) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting", "controllerName", controllerName)
+	defer klog.InfoS("Shutting down", "controllerName", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.connectivityProbeListerSynced, c.agentInfoListerSynced, c.nodeListerSynced) {
+		return
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *StatusController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *StatusController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncConnectivityProbeStatus(key); err == nil {
+		c.queue.Forget(key)
+	} else {
+		c.queue.AddRateLimited(key)
+		klog.ErrorS(err, "Error syncing ConnectivityProbe status", "ConnectivityProbe", key)
+	}
+	return true
+}
+
+// syncConnectivityProbeStatus sets the status of the given ConnectivityProbe to the aggregation of the results
+// reported by the Nodes from which it is sent. The status of the Nodes which no longer exist is removed.
+func (c *StatusController) syncConnectivityProbeStatus(name string) error {
+	cp, err := c.connectivityProbeLister.Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	desiredStatus, err := c.getDesiredConnectivityProbeStatus(cp)
+	if err != nil {
+		return err
+	}
+	if apiequality.Semantic.DeepEqual(&cp.Status, desiredStatus) {
+		return nil
+	}
+	toUpdate := cp.DeepCopy()
+	toUpdate.Status = *desiredStatus
+	klog.V(2).InfoS("Updating ConnectivityProbe status", "ConnectivityProbe", klog.KObj(cp))
+	// Conflicts are retried by the work queue with the latest ConnectivityProbe in the lister.
+	_, err = c.crdClient.CrdV1alpha1().ConnectivityProbes().UpdateStatus(context.TODO(), toUpdate, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *StatusController) getDesiredConnectivityProbeStatus(cp *crdv1alpha1.ConnectivityProbe) (*crdv1alpha1.ConnectivityProbeStatus, error) {
+	agentInfos, err := c.agentInfoInformer.Informer().GetIndexer().ByIndex(agentInfoConnectivityProbeIndex, cp.Name)
+	if err != nil {
+		return nil, err
+	}
+	var nodeStatuses []crdv1alpha1.ConnectivityProbeNodeStatus
+	for _, obj := range agentInfos {
+		agentInfo := obj.(*crdv1beta1.AntreaAgentInfo)
+		// The AntreaAgentInfo of a Node is named after the Node.
+		nodeName := agentInfo.Name
+		if _, err := c.nodeLister.Get(nodeName); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		idx := slices.IndexFunc(agentInfo.ConnectivityProbeInfos, func(info crdv1beta1.ConnectivityProbeInfo) bool {
+			return info.Name == cp.Name
+		})
+		info := agentInfo.ConnectivityProbeInfos[idx]
+		nodeStatuses = append(nodeStatuses, crdv1alpha1.ConnectivityProbeNodeStatus{
+			NodeName:                  nodeName,
+			ProbesSent:                info.ProbesSent,
+			ProbesSucceeded:           info.ProbesSucceeded,
+			AverageLatencyNanoseconds: info.AverageLatencyNanoseconds,
+			LastProbeTime:             info.LastProbeTime,
+			LastFailureMessage:        info.LastFailureMessage,
+		})
+	}
+	slices.SortFunc(nodeStatuses, func(a, b crdv1alpha1.ConnectivityProbeNodeStatus) int {
+		return strings.Compare(a.NodeName, b.NodeName)
+	})
+	return aggregateNodeStatus(nodeStatuses), nil
+}
+
+// aggregateNodeStatus returns the status of a ConnectivityProbe, given the status of the probes sent from each of the
+// Nodes sorted by Node name. The average latency is weighted by the number of successful probes sent from each Node.
+func aggregateNodeStatus(nodeStatuses []crdv1alpha1.ConnectivityProbeNodeStatus) *crdv1alpha1.ConnectivityProbeStatus {
+	desiredStatus := &crdv1alpha1.ConnectivityProbeStatus{Nodes: nodeStatuses}
+	var totalLatency int64
+	for _, s := range nodeStatuses {
+		desiredStatus.ProbesSent += s.ProbesSent
+		desiredStatus.ProbesSucceeded += s.ProbesSucceeded
+		totalLatency += s.AverageLatencyNanoseconds * s.ProbesSucceeded
+	}
+	if desiredStatus.ProbesSent > 0 {
+		desiredStatus.SuccessRatePercent = int32(desiredStatus.ProbesSucceeded * 100 / desiredStatus.ProbesSent)
+	}
+	if desiredStatus.ProbesSucceeded > 0 {
+		desiredStatus.AverageLatencyNanoseconds = totalLatency / desiredStatus.ProbesSucceeded
+	}
+	return desiredStatus
+}
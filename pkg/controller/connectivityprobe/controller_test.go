@@ -0,0 +1,212 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectivityprobe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	crdv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+	fakeversioned "antrea.io/antrea/v2/pkg/client/clientset/versioned/fake"
+	crdinformers "antrea.io/antrea/v2/pkg/client/informers/externalversions"
+)
+
+var lastProbeTime = metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+
+type fakeController struct {
+	*StatusController
+	crdClient *fakeversioned.Clientset
+}
+
+func newFakeController(t *testing.T, objects []runtime.Object, crdObjects []runtime.Object) *fakeController {
+	client := fake.NewSimpleClientset(objects...)
+	crdClient := fakeversioned.NewSimpleClientset(crdObjects...)
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	c := NewStatusController(crdClient,
+		crdInformerFactory.Crd().V1alpha1().ConnectivityProbes(),
+		crdInformerFactory.Crd().V1beta1().AntreaAgentInfos(),
+		informerFactory.Core().V1().Nodes())
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	informerFactory.Start(stopCh)
+	crdInformerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+	crdInformerFactory.WaitForCacheSync(stopCh)
+	return &fakeController{StatusController: c, crdClient: crdClient}
+}
+
+func generateNode(name string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func generateAgentInfo(nodeName string, infos ...crdv1beta1.ConnectivityProbeInfo) *crdv1beta1.AntreaAgentInfo {
+	return &crdv1beta1.AntreaAgentInfo{
+		ObjectMeta:             metav1.ObjectMeta{Name: nodeName},
+		ConnectivityProbeInfos: infos,
+	}
+}
+
+func generateProbeInfo(name string, sent, succeeded, latency int64, failureMessage string) crdv1beta1.ConnectivityProbeInfo {
+	return crdv1beta1.ConnectivityProbeInfo{
+		Name:                      name,
+		ProbesSent:                sent,
+		ProbesSucceeded:           succeeded,
+		AverageLatencyNanoseconds: latency,
+		LastProbeTime:             lastProbeTime,
+		LastFailureMessage:        failureMessage,
+	}
+}
+
+func generateNodeStatus(nodeName string, sent, succeeded, latency int64, failureMessage string) crdv1alpha1.ConnectivityProbeNodeStatus {
+	return crdv1alpha1.ConnectivityProbeNodeStatus{
+		NodeName:                  nodeName,
+		ProbesSent:                sent,
+		ProbesSucceeded:           succeeded,
+		AverageLatencyNanoseconds: latency,
+		LastProbeTime:             lastProbeTime,
+		LastFailureMessage:        failureMessage,
+	}
+}
+
+func generateConnectivityProbe(name string, status crdv1alpha1.ConnectivityProbeStatus) *crdv1alpha1.ConnectivityProbe {
+	return &crdv1alpha1.ConnectivityProbe{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     status,
+	}
+}
+
+func TestSyncConnectivityProbeStatus(t *testing.T) {
+	testCases := []struct {
+		name              string
+		nodes             []runtime.Object
+		agentInfos        []runtime.Object
+		connectivityProbe *crdv1alpha1.ConnectivityProbe
+		expectedStatus    crdv1alpha1.ConnectivityProbeStatus
+		expectedUpdate    bool
+	}{
+		{
+			name:  "aggregate status from Nodes",
+			nodes: []runtime.Object{generateNode("node-b"), generateNode("node-a"), generateNode("node-c")},
+			agentInfos: []runtime.Object{
+				generateAgentInfo("node-b", generateProbeInfo("probe1", 4, 2, 1000, "Pod default/pod-b: connection refused")),
+				generateAgentInfo("node-a", generateProbeInfo("probe2", 1, 1, 500, ""), generateProbeInfo("probe1", 4, 4, 2500, "")),
+				generateAgentInfo("node-c", generateProbeInfo("probe2", 2, 2, 500, "")),
+				generateAgentInfo("node-d", generateProbeInfo("probe1", 2, 0, 0, "Pod default/pod-d: no reply received within 5s")),
+			},
+			connectivityProbe: generateConnectivityProbe("probe1", crdv1alpha1.ConnectivityProbeStatus{}),
+			expectedStatus: crdv1alpha1.ConnectivityProbeStatus{
+				ProbesSent:                8,
+				ProbesSucceeded:           6,
+				SuccessRatePercent:        75,
+				AverageLatencyNanoseconds: 2000,
+				Nodes: []crdv1alpha1.ConnectivityProbeNodeStatus{
+					generateNodeStatus("node-a", 4, 4, 2500, ""),
+					generateNodeStatus("node-b", 4, 2, 1000, "Pod default/pod-b: connection refused"),
+				},
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:  "update changed Nodes and remove stale Nodes",
+			nodes: []runtime.Object{generateNode("node-a"), generateNode("node-b")},
+			agentInfos: []runtime.Object{
+				generateAgentInfo("node-a", generateProbeInfo("probe1", 6, 6, 1000, "")),
+				generateAgentInfo("node-b"),
+			},
+			connectivityProbe: generateConnectivityProbe("probe1", crdv1alpha1.ConnectivityProbeStatus{
+				ProbesSent:                8,
+				ProbesSucceeded:           7,
+				SuccessRatePercent:        87,
+				AverageLatencyNanoseconds: 1000,
+				Nodes: []crdv1alpha1.ConnectivityProbeNodeStatus{
+					generateNodeStatus("node-a", 4, 4, 1000, ""),
+					generateNodeStatus("node-b", 2, 1, 1000, "Pod default/pod-b: connection refused"),
+					generateNodeStatus("node-c", 2, 2, 1000, ""),
+				},
+			}),
+			expectedStatus: crdv1alpha1.ConnectivityProbeStatus{
+				ProbesSent:                6,
+				ProbesSucceeded:           6,
+				SuccessRatePercent:        100,
+				AverageLatencyNanoseconds: 1000,
+				Nodes: []crdv1alpha1.ConnectivityProbeNodeStatus{
+					generateNodeStatus("node-a", 6, 6, 1000, ""),
+				},
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:       "status unchanged",
+			nodes:      []runtime.Object{generateNode("node-a")},
+			agentInfos: []runtime.Object{generateAgentInfo("node-a", generateProbeInfo("probe1", 2, 1, 1000, "Pod default/pod-a: connection refused"))},
+			connectivityProbe: generateConnectivityProbe("probe1", crdv1alpha1.ConnectivityProbeStatus{
+				ProbesSent:                2,
+				ProbesSucceeded:           1,
+				SuccessRatePercent:        50,
+				AverageLatencyNanoseconds: 1000,
+				Nodes: []crdv1alpha1.ConnectivityProbeNodeStatus{
+					generateNodeStatus("node-a", 2, 1, 1000, "Pod default/pod-a: connection refused"),
+				},
+			}),
+			expectedStatus: crdv1alpha1.ConnectivityProbeStatus{
+				ProbesSent:                2,
+				ProbesSucceeded:           1,
+				SuccessRatePercent:        50,
+				AverageLatencyNanoseconds: 1000,
+				Nodes: []crdv1alpha1.ConnectivityProbeNodeStatus{
+					generateNodeStatus("node-a", 2, 1, 1000, "Pod default/pod-a: connection refused"),
+				},
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeController(t, tt.nodes, append(tt.agentInfos, tt.connectivityProbe))
+			require.NoError(t, c.syncConnectivityProbeStatus(tt.connectivityProbe.Name))
+
+			updated := false
+			for _, action := range c.crdClient.Actions() {
+				if action.GetVerb() == "update" && action.GetSubresource() == "status" {
+					updated = true
+				}
+			}
+			assert.Equal(t, tt.expectedUpdate, updated)
+
+			cp, err := c.crdClient.CrdV1alpha1().ConnectivityProbes().Get(context.TODO(), tt.connectivityProbe.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, cp.Status)
+		})
+	}
+}
+
+func TestSyncConnectivityProbeStatusNotFound(t *testing.T) {
+	c := newFakeController(t, []runtime.Object{generateNode("node-a")},
+		[]runtime.Object{generateAgentInfo("node-a", generateProbeInfo("probe1", 2, 2, 1000, ""))})
+	require.NoError(t, c.syncConnectivityProbeStatus("probe1"))
+	for _, action := range c.crdClient.Actions() {
+		assert.NotEqual(t, "update", action.GetVerb())
+	}
+}
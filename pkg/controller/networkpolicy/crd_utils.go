@@ -119,13 +119,36 @@ func toAntreaL7ProtocolsForCRD(l7Protocols []crdv1beta1.L7Protocol) []controlpla
 	var antreaL7Protocols []controlplane.L7Protocol
 	for _, l7p := range l7Protocols {
 		antreaL7Protocols = append(antreaL7Protocols, controlplane.L7Protocol{
-			HTTP: (*controlplane.HTTPProtocol)(l7p.HTTP),
+			HTTP: toAntreaHTTPProtocolForCRD(l7p.HTTP),
 			TLS:  (*controlplane.TLSProtocol)(l7p.TLS),
+			GRPC: (*controlplane.GRPCProtocol)(l7p.GRPC),
+			DNS:  (*controlplane.DNSProtocol)(l7p.DNS),
 		})
 	}
 	return antreaL7Protocols
 }
 
+// toAntreaHTTPProtocolForCRD converts a v1beta1.HTTPProtocol object to an
+// Antrea HTTPProtocol object.
+func toAntreaHTTPProtocolForCRD(http *crdv1beta1.HTTPProtocol) *controlplane.HTTPProtocol {
+	if http == nil {
+		return nil
+	}
+	antreaHTTP := &controlplane.HTTPProtocol{
+		Host:      http.Host,
+		Method:    http.Method,
+		Path:      http.Path,
+		PathRegex: http.PathRegex,
+	}
+	for _, header := range http.Headers {
+		antreaHTTP.Headers = append(antreaHTTP.Headers, controlplane.HTTPHeaderMatch(header))
+	}
+	for _, param := range http.QueryParams {
+		antreaHTTP.QueryParams = append(antreaHTTP.QueryParams, controlplane.HTTPQueryParamMatch(param))
+	}
+	return antreaHTTP
+}
+
 // toAntreaIPBlockForCRD converts a crdv1beta1.IPBlock to an Antrea IPBlock.
 func toAntreaIPBlockForCRD(ipBlock *crdv1beta1.IPBlock) (*controlplane.IPBlock, error) {
 	// Convert the allowed IPBlock to networkpolicy.IPNet.
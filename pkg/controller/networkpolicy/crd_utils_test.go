@@ -218,6 +218,22 @@ func TestToAntreaL7ProtocolsForCRD(t *testing.T) {
 				{HTTP: &controlplane.HTTPProtocol{Host: "test.com", Method: "GET", Path: "/admin"}},
 			},
 		},
+		{
+			[]crdv1beta1.L7Protocol{
+				{HTTP: &crdv1beta1.HTTPProtocol{
+					PathRegex:   "^/api/v[0-9]+/",
+					Headers:     []crdv1beta1.HTTPHeaderMatch{{Name: "X-Tenant", Value: "foo"}},
+					QueryParams: []crdv1beta1.HTTPQueryParamMatch{{Name: "debug"}},
+				}},
+			},
+			[]controlplane.L7Protocol{
+				{HTTP: &controlplane.HTTPProtocol{
+					PathRegex:   "^/api/v[0-9]+/",
+					Headers:     []controlplane.HTTPHeaderMatch{{Name: "X-Tenant", Value: "foo"}},
+					QueryParams: []controlplane.HTTPQueryParamMatch{{Name: "debug"}},
+				}},
+			},
+		},
 		{
 			[]crdv1beta1.L7Protocol{
 				{TLS: &crdv1beta1.TLSProtocol{SNI: "test.com"}},
@@ -226,6 +242,16 @@ func TestToAntreaL7ProtocolsForCRD(t *testing.T) {
 				{TLS: &controlplane.TLSProtocol{SNI: "test.com"}},
 			},
 		},
+		{
+			[]crdv1beta1.L7Protocol{
+				{GRPC: &crdv1beta1.GRPCProtocol{Service: "helloworld.Greeter", Method: "SayHello"}},
+				{DNS: &crdv1beta1.DNSProtocol{QueryName: "*.test.com", QueryType: "AAAA"}},
+			},
+			[]controlplane.L7Protocol{
+				{GRPC: &controlplane.GRPCProtocol{Service: "helloworld.Greeter", Method: "SayHello"}},
+				{DNS: &controlplane.DNSProtocol{QueryName: "*.test.com", QueryType: "AAAA"}},
+			},
+		},
 	}
 	for _, table := range tables {
 		gotValue := toAntreaL7ProtocolsForCRD(table.l7Protocol)
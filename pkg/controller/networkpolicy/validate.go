@@ -86,6 +86,18 @@ var (
 	// allowedFQDNChars validates that the matchPattern field contains only valid DNS characters
 	// and the wildcard '*' character.
 	allowedFQDNChars = regexp.MustCompile("^[-0-9a-zA-Z.*]+$")
+	// allowedHTTPHeaderNameChars matches the "token" characters defined in RFC 9110.
+	allowedHTTPHeaderNameChars = regexp.MustCompile("^[-!#$%&'*+.^_`|~0-9a-zA-Z]+$")
+	// allowedGRPCServiceName and allowedGRPCMethodName validate the names of gRPC services and methods, as defined
+	// in the Protocol Buffers language specification.
+	allowedGRPCServiceName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*$`)
+	allowedGRPCMethodName  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	// allowedPathRegexEscapes stores the letters which can be escaped in the pathRegex field of the HTTP protocol, as
+	// they have the same meaning in Go regular expressions, used for validation and by the builtin L7 engine, and in
+	// PCRE, used by Suricata. Any ASCII punctuation character can also be escaped.
+	allowedPathRegexEscapes = sets.New[rune]('d', 'D', 's', 'S', 'w', 'W', 'b', 'B', 'A', 'z', 't', 'n', 'r', 'f', 'x')
+	// supportedDNSQueryTypes stores the set of DNS query types which can be matched by layer 7 NetworkPolicy.
+	supportedDNSQueryTypes = sets.New[string]("A", "AAAA", "CNAME", "MX", "NS", "PTR", "SOA", "SRV", "TXT", "ANY")
 )
 
 // RegisterAntreaPolicyValidator registers an Antrea-native policy validator
@@ -947,22 +959,124 @@ func (v *antreaPolicyValidator) validateL7Protocols(ingressRules, egressRules []
 		if len(r.ToServices) != 0 {
 			return "layer 7 protocols can not be used with toServices", false
 		}
-		haveHTTP := false
+		haveHTTP, haveGRPC, haveDNS := false, false, false
 		for _, p := range r.L7Protocols {
 			if p.HTTP != nil {
 				haveHTTP = true
+				if reason, allowed := validateHTTPProtocol(p.HTTP); !allowed {
+					return reason, false
+				}
+			}
+			if p.GRPC != nil {
+				haveGRPC = true
+				if reason, allowed := validateGRPCProtocol(p.GRPC); !allowed {
+					return reason, false
+				}
+			}
+			if p.DNS != nil {
+				haveDNS = true
+				if reason, allowed := validateDNSProtocol(p.DNS); !allowed {
+					return reason, false
+				}
 			}
 		}
 		for _, port := range r.Ports {
 			if haveHTTP && (port.Protocol != nil && *port.Protocol != v1.ProtocolTCP) {
 				return "HTTP protocol can only be used when layer 4 protocol is TCP or unset", false
 			}
+			if haveGRPC && (port.Protocol != nil && *port.Protocol != v1.ProtocolTCP) {
+				return "gRPC protocol can only be used when layer 4 protocol is TCP or unset", false
+			}
+			if haveDNS && (port.Protocol != nil && *port.Protocol != v1.ProtocolTCP && *port.Protocol != v1.ProtocolUDP) {
+				return "DNS protocol can only be used when layer 4 protocol is TCP, UDP or unset", false
+			}
 		}
 		for _, protocol := range r.Protocols {
 			if haveHTTP && (protocol.IGMP != nil || protocol.ICMP != nil) {
 				return "HTTP protocol can not be used with protocol IGMP or ICMP", false
 			}
+			if haveGRPC && (protocol.IGMP != nil || protocol.ICMP != nil) {
+				return "gRPC protocol can not be used with protocol IGMP or ICMP", false
+			}
+			if haveDNS && (protocol.IGMP != nil || protocol.ICMP != nil) {
+				return "DNS protocol can not be used with protocol IGMP or ICMP", false
+			}
+		}
+	}
+	return "", true
+}
+
+// validateHTTPProtocol validates the path, header and query parameter matchers of an HTTPProtocol.
+func validateHTTPProtocol(http *crdv1beta1.HTTPProtocol) (string, bool) {
+	if http.PathRegex != "" {
+		if http.Path != "" {
+			return "path and pathRegex can not be used together in HTTP protocol", false
+		}
+		if reason, ok := validatePathRegex(http.PathRegex); !ok {
+			return fmt.Sprintf("invalid pathRegex %s in HTTP protocol: %s", http.PathRegex, reason), false
+		}
+	}
+	for _, header := range http.Headers {
+		if !allowedHTTPHeaderNameChars.MatchString(header.Name) {
+			return fmt.Sprintf("invalid header name %q in HTTP protocol", header.Name), false
+		}
+		if strings.ContainsAny(header.Value, "\r\n") {
+			return fmt.Sprintf("invalid header value %q in HTTP protocol", header.Value), false
+		}
+	}
+	for _, param := range http.QueryParams {
+		if param.Name == "" || strings.ContainsAny(param.Name, "&=#? ") {
+			return fmt.Sprintf("invalid query parameter name %q in HTTP protocol", param.Name), false
+		}
+		if strings.ContainsAny(param.Value, "&#") {
+			return fmt.Sprintf("invalid query parameter value %q in HTTP protocol", param.Value), false
+		}
+	}
+	return "", true
+}
+
+// validatePathRegex validates that a pathRegex is a valid regular expression, which only uses the syntax common to Go
+// regular expressions and PCRE, so that it is matched in the same way by all the L7 engines.
+func validatePathRegex(pathRegex string) (string, bool) {
+	escaped := false
+	for _, c := range pathRegex {
+		if c < ' ' || c > '~' {
+			return "only printable ASCII characters are allowed", false
+		}
+		if escaped {
+			escaped = false
+			isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+			if isAlphanumeric && !allowedPathRegexEscapes.Has(c) {
+				return fmt.Sprintf(`escape sequence \%c is not supported`, c), false
+			}
+			continue
 		}
+		escaped = c == '\\'
+	}
+	if _, err := regexp.Compile(pathRegex); err != nil {
+		return err.Error(), false
+	}
+	return "", true
+}
+
+// validateGRPCProtocol validates the service and method names of a GRPCProtocol.
+func validateGRPCProtocol(grpc *crdv1beta1.GRPCProtocol) (string, bool) {
+	if grpc.Service != "" && !allowedGRPCServiceName.MatchString(grpc.Service) {
+		return fmt.Sprintf("invalid service %q in gRPC protocol", grpc.Service), false
+	}
+	if grpc.Method != "" && !allowedGRPCMethodName.MatchString(grpc.Method) {
+		return fmt.Sprintf("invalid method %q in gRPC protocol", grpc.Method), false
+	}
+	return "", true
+}
+
+// validateDNSProtocol validates the query name and query type of a DNSProtocol.
+func validateDNSProtocol(dns *crdv1beta1.DNSProtocol) (string, bool) {
+	if dns.QueryName != "" && !allowedFQDNChars.MatchString(dns.QueryName) {
+		return fmt.Sprintf("invalid queryName %q in DNS protocol, only letters, digits, '-', '.' and '*' are allowed", dns.QueryName), false
+	}
+	if dns.QueryType != "" && !supportedDNSQueryTypes.Has(dns.QueryType) {
+		return fmt.Sprintf("unsupported queryType %q in DNS protocol, supported types are %v", dns.QueryType, sets.List(supportedDNSQueryTypes)), false
 	}
 	return "", true
 }
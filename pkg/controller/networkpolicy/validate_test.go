@@ -1685,6 +1685,412 @@ func TestValidateAntreaClusterNetworkPolicy(t *testing.T) {
 			operation:      admv1.Create,
 			expectedReason: "layer 7 protocols only support Allow",
 		},
+		{
+			name:         "acnp-l7protocols-HTTP-path-and-pathRegex",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									HTTP: &crdv1beta1.HTTPProtocol{
+										Path:      "/api",
+										PathRegex: "^/api/v[0-9]+$",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "path and pathRegex can not be used together in HTTP protocol",
+		},
+		{
+			name:         "acnp-l7protocols-HTTP-invalid-pathRegex",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									HTTP: &crdv1beta1.HTTPProtocol{
+										PathRegex: "^/api/(v1",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid pathRegex ^/api/(v1 in HTTP protocol: error parsing regexp: missing closing ): `^/api/(v1`",
+		},
+		{
+			name:         "acnp-l7protocols-HTTP-pathRegex-unsupported-escape",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									HTTP: &crdv1beta1.HTTPProtocol{
+										PathRegex: `^/api/\p{Greek}+$`,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: `invalid pathRegex ^/api/\p{Greek}+$ in HTTP protocol: escape sequence \p is not supported`,
+		},
+		{
+			name:         "acnp-l7protocols-HTTP-pathRegex-non-ascii",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									HTTP: &crdv1beta1.HTTPProtocol{
+										PathRegex: "^/café$",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid pathRegex ^/café$ in HTTP protocol: only printable ASCII characters are allowed",
+		},
+		{
+			name:         "acnp-l7protocols-HTTP-invalid-header-name",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									HTTP: &crdv1beta1.HTTPProtocol{
+										Headers: []crdv1beta1.HTTPHeaderMatch{
+											{Name: "X Tenant", Value: "foo"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid header name \"X Tenant\" in HTTP protocol",
+		},
+		{
+			name:         "acnp-l7protocols-HTTP-invalid-query-parameter-name",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									HTTP: &crdv1beta1.HTTPProtocol{
+										QueryParams: []crdv1beta1.HTTPQueryParamMatch{
+											{Name: "a=b"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid query parameter name \"a=b\" in HTTP protocol",
+		},
+		{
+			name:         "acnp-l7protocols-HTTP-headers-and-query-parameters",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									HTTP: &crdv1beta1.HTTPProtocol{
+										PathRegex: "^/api/v[0-9]+/",
+										Headers: []crdv1beta1.HTTPHeaderMatch{
+											{Name: "X-Tenant", Value: "foo"},
+										},
+										QueryParams: []crdv1beta1.HTTPQueryParamMatch{
+											{Name: "debug"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
+		{
+			name:         "acnp-l7protocols-gRPC-used-with-UDP",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							Ports: []crdv1beta1.NetworkPolicyPort{
+								{
+									Protocol: &k8sProtocolUDP,
+								},
+							},
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									GRPC: &crdv1beta1.GRPCProtocol{
+										Service: "helloworld.Greeter",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "gRPC protocol can only be used when layer 4 protocol is TCP or unset",
+		},
+		{
+			name:         "acnp-l7protocols-gRPC-invalid-service",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									GRPC: &crdv1beta1.GRPCProtocol{
+										Service: "helloworld/Greeter",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid service \"helloworld/Greeter\" in gRPC protocol",
+		},
+		{
+			name:         "acnp-l7protocols-DNS-used-with-UDP",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							Ports: []crdv1beta1.NetworkPolicyPort{
+								{
+									Protocol: &k8sProtocolUDP,
+								},
+							},
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									DNS: &crdv1beta1.DNSProtocol{
+										QueryName: "*.test.com",
+										QueryType: "AAAA",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
+		{
+			name:         "acnp-l7protocols-DNS-invalid-query-name",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									DNS: &crdv1beta1.DNSProtocol{
+										QueryName: "test_1.com",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid queryName \"test_1.com\" in DNS protocol, only letters, digits, '-', '.' and '*' are allowed",
+		},
+		{
+			name:         "acnp-l7protocols-DNS-unsupported-query-type",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									DNS: &crdv1beta1.DNSProtocol{
+										QueryType: "HINFO",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "unsupported queryType \"HINFO\" in DNS protocol, supported types are [A AAAA ANY CNAME MX NS PTR SOA SRV TXT]",
+		},
 		{
 			name:         "acnp-l7protocols-HTTP-used-with-UDP",
 			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
@@ -0,0 +1,374 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	clientset "antrea.io/antrea/v2/pkg/client/clientset/versioned"
+	crdinformers "antrea.io/antrea/v2/pkg/client/informers/externalversions/crd/v1alpha1"
+	crdlisters "antrea.io/antrea/v2/pkg/client/listers/crd/v1alpha1"
+)
+
+const (
+	controllerName = "PacketCaptureController"
+	// How long to wait before retrying the processing of a PacketCapture change.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing PacketCapture changes.
+	defaultWorkers = 4
+	// Set resyncPeriod to 0 to disable resyncing.
+	resyncPeriod time.Duration = 0
+
+	// Length of the hash suffix of the names of the Node PacketCaptures which are truncated.
+	nameHashLength = 10
+)
+
+// Controller fans out the PacketCaptures performed on multiple Nodes, i.e. with CapturePoint 'Both' or a Node
+// selector: it creates a PacketCapture for each of the Nodes, which is performed by the antrea-agent running on the
+// Node, and aggregates their status into the status of the multi-Node PacketCapture. It is the only writer of the
+// status of multi-Node PacketCaptures, which avoids conflicting updates from the agents.
+type Controller struct {
+	crdClient clientset.Interface
+
+	packetCaptureInformer     crdinformers.PacketCaptureInformer
+	packetCaptureLister       crdlisters.PacketCaptureLister
+	packetCaptureListerSynced cache.InformerSynced
+
+	podLister       corelisters.PodLister
+	podListerSynced cache.InformerSynced
+
+	nodeLister       corelisters.NodeLister
+	nodeListerSynced cache.InformerSynced
+
+	// queue maintains the names of the multi-Node PacketCaptures which need to be synced.
+	queue workqueue.TypedRateLimitingInterface[string]
+}
+
+func NewController(crdClient clientset.Interface,
+	packetCaptureInformer crdinformers.PacketCaptureInformer,
+	podInformer coreinformers.PodInformer,
+	nodeInformer coreinformers.NodeInformer) *Controller {
+	c := &Controller{
+		crdClient: crdClient,
+
+		packetCaptureInformer:     packetCaptureInformer,
+		packetCaptureLister:       packetCaptureInformer.Lister(),
+		packetCaptureListerSynced: packetCaptureInformer.Informer().HasSynced,
+
+		podLister:       podInformer.Lister(),
+		podListerSynced: podInformer.Informer().HasSynced,
+
+		nodeLister:       nodeInformer.Lister(),
+		nodeListerSynced: nodeInformer.Informer().HasSynced,
+
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[string](minRetryDelay, maxRetryDelay),
+			workqueue.TypedRateLimitingQueueConfig[string]{
+				Name: "packetCapture",
+			},
+		),
+	}
+	c.packetCaptureInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addPacketCapture,
+			UpdateFunc: c.updatePacketCapture,
+			DeleteFunc: c.deletePacketCapture,
+		},
+		resyncPeriod)
+	return c
+}
+
+// enqueuePacketCapture enqueues the given PacketCapture if it's performed on multiple Nodes, or the multi-Node
+// PacketCapture for which it was created.
+func (c *Controller) enqueuePacketCapture(pc *crdv1alpha1.PacketCapture) {
+	if crdv1alpha1.IsMultiNodePacketCapture(pc) {
+		c.queue.Add(pc.Name)
+	} else if parent := crdv1alpha1.GetParentPacketCapture(pc); parent != "" {
+		c.queue.Add(parent)
+	}
+}
+
+func (c *Controller) addPacketCapture(obj interface{}) {
+	pc := obj.(*crdv1alpha1.PacketCapture)
+	c.enqueuePacketCapture(pc)
+}
+
+func (c *Controller) updatePacketCapture(_, obj interface{}) {
+	pc := obj.(*crdv1alpha1.PacketCapture)
+	c.enqueuePacketCapture(pc)
+}
+
+func (c *Controller) deletePacketCapture(obj interface{}) {
+	pc, ok := obj.(*crdv1alpha1.PacketCapture)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Received unexpected object", "object", obj)
+			return
+		}
+		pc, ok = tombstone.Obj.(*crdv1alpha1.PacketCapture)
+		if !ok {
+			klog.ErrorS(nil, "DeletedFinalStateUnknown contains non-PacketCapture object", "object", tombstone.Obj)
+			return
+		}
+	}
+	// The PacketCaptures created for a multi-Node PacketCapture are deleted by the garbage collector with it.
+	if parent := crdv1alpha1.GetParentPacketCapture(pc); parent != "" {
+		c.queue.Add(parent)
+	}
+}
+
+// Run will create defaultWorkers workers (goroutines) which will process the PacketCapture changes from the work
+// queue.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting", "controllerName", controllerName)
+	defer klog.InfoS("Shutting down", "controllerName", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.packetCaptureListerSynced, c.podListerSynced, c.nodeListerSynced) {
+		return
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncPacketCapture(key); err == nil {
+		c.queue.Forget(key)
+	} else {
+		c.queue.AddRateLimited(key)
+		klog.ErrorS(err, "Error syncing PacketCapture", "PacketCapture", key)
+	}
+	return true
+}
+
+// syncPacketCapture creates the PacketCaptures of the Nodes on which the given multi-Node PacketCapture is performed,
+// and sets its status to the status aggregated from them. No PacketCapture is created once the capture is complete.
+func (c *Controller) syncPacketCapture(name string) error {
+	pc, err := c.packetCaptureLister.Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !crdv1alpha1.IsMultiNodePacketCapture(pc) {
+		return nil
+	}
+	t := metav1.Now()
+	nodeSpecs, err := c.getNodePacketCaptureSpecs(pc)
+	if err != nil {
+		// The error is not transient, report it in the status.
+		return c.updateStatus(pc, &crdv1alpha1.PacketCaptureStatus{
+			Conditions: []crdv1alpha1.PacketCaptureCondition{{
+				Type:               crdv1alpha1.PacketCaptureStarted,
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: t,
+				Reason:             "NotStarted",
+				Message:            err.Error(),
+			}},
+		})
+	}
+
+	complete := false
+	if cond := findCondition(pc.Status.Conditions, crdv1alpha1.PacketCaptureComplete); cond != nil && cond.Status == metav1.ConditionTrue {
+		complete = true
+	}
+	curNodeStatuses := make(map[string]*crdv1alpha1.PacketCaptureNodeStatus, len(pc.Status.Nodes))
+	for i := range pc.Status.Nodes {
+		curNodeStatuses[pc.Status.Nodes[i].NodeName] = &pc.Status.Nodes[i]
+	}
+	nodeNames := sets.KeySet(nodeSpecs).Union(sets.KeySet(curNodeStatuses))
+	var nodeStatuses []crdv1alpha1.PacketCaptureNodeStatus
+	for _, nodeName := range sets.List(nodeNames) {
+		nodePCName := nodePacketCaptureName(pc.Name, nodeName)
+		nodePC, err := c.packetCaptureLister.Get(nodePCName)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if nodePC != nil {
+			if !metav1.IsControlledBy(nodePC, pc) {
+				// It may be the PacketCapture created for a deleted PacketCapture with the same name, which is
+				// going to be deleted by the garbage collector.
+				return fmt.Errorf("PacketCapture %s already exists and is not controlled by PacketCapture %s", nodePCName, pc.Name)
+			}
+			nodeStatuses = append(nodeStatuses, crdv1alpha1.PacketCaptureNodeStatus{
+				NodeName:       nodeName,
+				PacketCapture:  nodePCName,
+				NumberCaptured: nodePC.Status.NumberCaptured,
+				FilePath:       nodePC.Status.FilePath,
+				StopReason:     nodePC.Status.StopReason,
+				Conditions:     slices.Clone(nodePC.Status.Conditions),
+			})
+			continue
+		}
+		// Keep the last status of the Node if its PacketCapture has been deleted.
+		if curNodeStatus, ok := curNodeStatuses[nodeName]; ok {
+			nodeStatuses = append(nodeStatuses, *curNodeStatus.DeepCopy())
+			continue
+		}
+		if complete {
+			continue
+		}
+		if err := c.createNodePacketCapture(pc, nodePCName, nodeSpecs[nodeName]); err != nil {
+			return err
+		}
+		nodeStatuses = append(nodeStatuses, crdv1alpha1.PacketCaptureNodeStatus{NodeName: nodeName, PacketCapture: nodePCName})
+	}
+	return c.updateStatus(pc, aggregateNodeStatus(nodeStatuses, t))
+}
+
+// getNodePacketCaptureSpecs returns the specs of the PacketCaptures performed on each Node for the given multi-Node
+// PacketCapture: the Nodes selected by the NodeSelector capture on the given port, and the Nodes running the source
+// and destination Pods capture on their interfaces. The Pods which are not running on a Node are ignored.
+func (c *Controller) getNodePacketCaptureSpecs(pc *crdv1alpha1.PacketCapture) (map[string]*crdv1alpha1.PacketCaptureSpec, error) {
+	nodeSpecs := make(map[string]*crdv1alpha1.PacketCaptureSpec)
+	if pc.Spec.Node != nil {
+		selector, err := metav1.LabelSelectorAsSelector(pc.Spec.Node.NodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nodeSelector: %w", err)
+		}
+		nodes, err := c.nodeLister.List(selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			spec := pc.Spec.DeepCopy()
+			spec.Node = &crdv1alpha1.PacketCaptureNode{
+				Name:     node.Name,
+				Port:     pc.Spec.Node.Port,
+				PortName: pc.Spec.Node.PortName,
+			}
+			nodeSpecs[node.Name] = spec
+		}
+		return nodeSpecs, nil
+	}
+	for _, capturePoint := range []crdv1alpha1.CapturePoint{crdv1alpha1.CapturePointSource, crdv1alpha1.CapturePointDestination} {
+		podRef := pc.Spec.Source.Pod
+		if capturePoint == crdv1alpha1.CapturePointDestination {
+			podRef = pc.Spec.Destination.Pod
+		}
+		pod, err := c.podLister.Pods(podRef.Namespace).Get(podRef.Name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		// Capture on both Pods if they are running on the same Node.
+		if spec, ok := nodeSpecs[pod.Spec.NodeName]; ok {
+			spec.CapturePoint = crdv1alpha1.CapturePointBoth
+			continue
+		}
+		spec := pc.Spec.DeepCopy()
+		spec.CapturePoint = capturePoint
+		nodeSpecs[pod.Spec.NodeName] = spec
+	}
+	return nodeSpecs, nil
+}
+
+func (c *Controller) createNodePacketCapture(pc *crdv1alpha1.PacketCapture, name string, spec *crdv1alpha1.PacketCaptureSpec) error {
+	nodePC := &crdv1alpha1.PacketCapture{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: crdv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "PacketCapture",
+				Name:       pc.Name,
+				UID:        pc.UID,
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec: *spec,
+	}
+	klog.V(2).InfoS("Creating PacketCapture for Node", "PacketCapture", klog.KObj(pc), "nodePacketCapture", name)
+	_, err := c.crdClient.CrdV1alpha1().PacketCaptures().Create(context.TODO(), nodePC, metav1.CreateOptions{})
+	// The PacketCapture may have been created by a previous sync while it's not in the lister yet.
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *Controller) updateStatus(pc *crdv1alpha1.PacketCapture, desiredStatus *crdv1alpha1.PacketCaptureStatus) error {
+	// Make a deepcopy as the object returned from lister must not be updated directly.
+	toUpdate := pc.DeepCopy()
+	if crdv1alpha1.PacketCaptureStatusEqual(toUpdate.Status, *desiredStatus) {
+		return nil
+	}
+	// Keep the LastTransitionTime of the conditions which haven't changed.
+	for i := range desiredStatus.Conditions {
+		cond := &desiredStatus.Conditions[i]
+		if curCond := findCondition(toUpdate.Status.Conditions, cond.Type); curCond != nil && crdv1alpha1.ConditionEqualsIgnoreLastTransitionTime(*curCond, *cond) {
+			cond.LastTransitionTime = curCond.LastTransitionTime
+		}
+	}
+	toUpdate.Status = *desiredStatus
+	klog.V(2).InfoS("Updating PacketCapture status", "PacketCapture", klog.KObj(pc))
+	// Conflicts are retried by the work queue with the latest PacketCapture in the lister.
+	_, err := c.crdClient.CrdV1alpha1().PacketCaptures().UpdateStatus(context.TODO(), toUpdate, metav1.UpdateOptions{})
+	return err
+}
+
+// nodePacketCaptureName returns the name of the PacketCapture created on the given Node for a multi-Node
+// PacketCapture, which is also the name of the packets file uploaded by the Node. The name is truncated and suffixed
+// with a hash if it's too long.
+func nodePacketCaptureName(pcName, nodeName string) string {
+	name := pcName + "-" + nodeName
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(name))
+	prefix := strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength-nameHashLength-1], ".-")
+	return prefix + "-" + hex.EncodeToString(hash[:])[:nameHashLength]
+}
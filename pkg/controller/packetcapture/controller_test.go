@@ -0,0 +1,335 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	fakeversioned "antrea.io/antrea/v2/pkg/client/clientset/versioned/fake"
+	crdinformers "antrea.io/antrea/v2/pkg/client/informers/externalversions"
+)
+
+var (
+	pod1Ref = &crdv1alpha1.PodReference{Namespace: "default", Name: "pod-1"}
+	pod2Ref = &crdv1alpha1.PodReference{Namespace: "default", Name: "pod-2"}
+)
+
+type fakeController struct {
+	*Controller
+	crdClient *fakeversioned.Clientset
+}
+
+func newFakeController(t *testing.T, objects []runtime.Object, crdObjects []runtime.Object) *fakeController {
+	client := fake.NewSimpleClientset(objects...)
+	crdClient := fakeversioned.NewSimpleClientset(crdObjects...)
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	c := NewController(crdClient,
+		crdInformerFactory.Crd().V1alpha1().PacketCaptures(),
+		informerFactory.Core().V1().Pods(),
+		informerFactory.Core().V1().Nodes())
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	informerFactory.Start(stopCh)
+	crdInformerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+	crdInformerFactory.WaitForCacheSync(stopCh)
+	return &fakeController{Controller: c, crdClient: crdClient}
+}
+
+func generateNode(name, zone string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"zone": zone}}}
+}
+
+func generatePod(podRef *crdv1alpha1.PodReference, nodeName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: podRef.Namespace, Name: podRef.Name},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+	}
+}
+
+func generatePacketCapture(spec crdv1alpha1.PacketCaptureSpec, nodeStatuses ...crdv1alpha1.PacketCaptureNodeStatus) *crdv1alpha1.PacketCapture {
+	pc := &crdv1alpha1.PacketCapture{
+		ObjectMeta: metav1.ObjectMeta{Name: "pc", UID: "uid-pc"},
+		Spec:       spec,
+	}
+	if len(nodeStatuses) > 0 {
+		pc.Status = *aggregateNodeStatus(nodeStatuses, metav1.Now())
+	}
+	return pc
+}
+
+func generateNodePacketCapture(parentUID types.UID, nodeName string, spec crdv1alpha1.PacketCaptureSpec, conditions ...crdv1alpha1.PacketCaptureCondition) *crdv1alpha1.PacketCapture {
+	return &crdv1alpha1.PacketCapture{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pc-" + nodeName,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "crd.antrea.io/v1alpha1",
+				Kind:       "PacketCapture",
+				Name:       "pc",
+				UID:        parentUID,
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec:   spec,
+		Status: crdv1alpha1.PacketCaptureStatus{Conditions: conditions},
+	}
+}
+
+func TestSyncPacketCapture(t *testing.T) {
+	bothSpec := crdv1alpha1.PacketCaptureSpec{
+		Source:       crdv1alpha1.Source{Pod: pod1Ref},
+		Destination:  crdv1alpha1.Destination{Pod: pod2Ref},
+		CapturePoint: crdv1alpha1.CapturePointBoth,
+	}
+	withCapturePoint := func(capturePoint crdv1alpha1.CapturePoint) crdv1alpha1.PacketCaptureSpec {
+		spec := *bothSpec.DeepCopy()
+		spec.CapturePoint = capturePoint
+		return spec
+	}
+	selectorSpec := crdv1alpha1.PacketCaptureSpec{
+		Destination: crdv1alpha1.Destination{Pod: pod2Ref},
+		Node: &crdv1alpha1.PacketCaptureNode{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "a"}},
+			Port:         crdv1alpha1.PacketCapturePortTypeUplink,
+		},
+	}
+	withNode := func(nodeName string) crdv1alpha1.PacketCaptureSpec {
+		spec := *selectorSpec.DeepCopy()
+		spec.Node = &crdv1alpha1.PacketCaptureNode{Name: nodeName, Port: crdv1alpha1.PacketCapturePortTypeUplink}
+		return spec
+	}
+	nodes := []runtime.Object{generateNode("node-a", "a"), generateNode("node-b", "a"), generateNode("node-c", "b")}
+	nodeAComplete := crdv1alpha1.PacketCaptureNodeStatus{
+		NodeName:       "node-a",
+		PacketCapture:  "pc-node-a",
+		NumberCaptured: 3,
+		FilePath:       "antrea-agent-a:/tmp/pc-node-a.pcapng",
+		StopReason:     crdv1alpha1.PacketCaptureStopReasonPacketLimit,
+		Conditions:     []crdv1alpha1.PacketCaptureCondition{started, succeeded},
+	}
+
+	testCases := []struct {
+		name            string
+		objects         []runtime.Object
+		pc              *crdv1alpha1.PacketCapture
+		nodePCs         []runtime.Object
+		expectedCreated map[string]crdv1alpha1.PacketCaptureSpec
+		expectedStatus  crdv1alpha1.PacketCaptureStatus
+		expectedUpdate  bool
+		expectedErr     string
+	}{
+		{
+			name:    "Nodes selected by label",
+			objects: nodes,
+			pc:      generatePacketCapture(selectorSpec),
+			expectedCreated: map[string]crdv1alpha1.PacketCaptureSpec{
+				"pc-node-a": withNode("node-a"),
+				"pc-node-b": withNode("node-b"),
+			},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				Conditions: []crdv1alpha1.PacketCaptureCondition{pending},
+				Nodes: []crdv1alpha1.PacketCaptureNodeStatus{
+					{NodeName: "node-a", PacketCapture: "pc-node-a"},
+					{NodeName: "node-b", PacketCapture: "pc-node-b"},
+				},
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:    "Pods on different Nodes",
+			objects: append([]runtime.Object{generatePod(pod1Ref, "node-a"), generatePod(pod2Ref, "node-b")}, nodes...),
+			pc:      generatePacketCapture(bothSpec),
+			expectedCreated: map[string]crdv1alpha1.PacketCaptureSpec{
+				"pc-node-a": withCapturePoint(crdv1alpha1.CapturePointSource),
+				"pc-node-b": withCapturePoint(crdv1alpha1.CapturePointDestination),
+			},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				Conditions: []crdv1alpha1.PacketCaptureCondition{pending},
+				Nodes: []crdv1alpha1.PacketCaptureNodeStatus{
+					{NodeName: "node-a", PacketCapture: "pc-node-a"},
+					{NodeName: "node-b", PacketCapture: "pc-node-b"},
+				},
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:    "Pods on the same Node",
+			objects: append([]runtime.Object{generatePod(pod1Ref, "node-a"), generatePod(pod2Ref, "node-a")}, nodes...),
+			pc:      generatePacketCapture(bothSpec),
+			expectedCreated: map[string]crdv1alpha1.PacketCaptureSpec{
+				"pc-node-a": bothSpec,
+			},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				Conditions: []crdv1alpha1.PacketCaptureCondition{pending},
+				Nodes: []crdv1alpha1.PacketCaptureNodeStatus{
+					{NodeName: "node-a", PacketCapture: "pc-node-a"},
+				},
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:    "Pod not scheduled",
+			objects: append([]runtime.Object{generatePod(pod1Ref, "node-a"), generatePod(pod2Ref, "")}, nodes...),
+			pc:      generatePacketCapture(bothSpec),
+			expectedCreated: map[string]crdv1alpha1.PacketCaptureSpec{
+				"pc-node-a": withCapturePoint(crdv1alpha1.CapturePointSource),
+			},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				Conditions: []crdv1alpha1.PacketCaptureCondition{pending},
+				Nodes: []crdv1alpha1.PacketCaptureNodeStatus{
+					{NodeName: "node-a", PacketCapture: "pc-node-a"},
+				},
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:    "aggregate status from Nodes",
+			objects: append([]runtime.Object{generatePod(pod1Ref, "node-a"), generatePod(pod2Ref, "node-b")}, nodes...),
+			pc:      generatePacketCapture(bothSpec),
+			nodePCs: []runtime.Object{
+				func() runtime.Object {
+					nodePC := generateNodePacketCapture("uid-pc", "node-a", withCapturePoint(crdv1alpha1.CapturePointSource), started, succeeded)
+					nodePC.Status.NumberCaptured = 3
+					nodePC.Status.FilePath = "antrea-agent-a:/tmp/pc-node-a.pcapng"
+					nodePC.Status.StopReason = crdv1alpha1.PacketCaptureStopReasonPacketLimit
+					return nodePC
+				}(),
+				generateNodePacketCapture("uid-pc", "node-b", withCapturePoint(crdv1alpha1.CapturePointDestination), started, progressing),
+			},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				NumberCaptured: 3,
+				Conditions:     []crdv1alpha1.PacketCaptureCondition{started, progressing},
+				Nodes: []crdv1alpha1.PacketCaptureNodeStatus{
+					nodeAComplete,
+					{NodeName: "node-b", PacketCapture: "pc-node-b", Conditions: []crdv1alpha1.PacketCaptureCondition{started, progressing}},
+				},
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:    "keep the status of deleted Node PacketCaptures",
+			objects: nodes,
+			pc:      generatePacketCapture(selectorSpec, nodeAComplete, crdv1alpha1.PacketCaptureNodeStatus{NodeName: "node-b", PacketCapture: "pc-node-b"}),
+			nodePCs: []runtime.Object{
+				generateNodePacketCapture("uid-pc", "node-b", withNode("node-b"), started, succeeded),
+			},
+			// The capture stopped for different reasons on the Nodes.
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				NumberCaptured: 3,
+				Conditions:     []crdv1alpha1.PacketCaptureCondition{started, succeeded},
+				Nodes: []crdv1alpha1.PacketCaptureNodeStatus{
+					nodeAComplete,
+					{NodeName: "node-b", PacketCapture: "pc-node-b", Conditions: []crdv1alpha1.PacketCaptureCondition{started, succeeded}},
+				},
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:    "no PacketCapture created once complete",
+			objects: nodes,
+			pc:      generatePacketCapture(selectorSpec, nodeAComplete),
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				NumberCaptured: 3,
+				StopReason:     crdv1alpha1.PacketCaptureStopReasonPacketLimit,
+				Conditions:     []crdv1alpha1.PacketCaptureCondition{started, succeeded},
+				Nodes:          []crdv1alpha1.PacketCaptureNodeStatus{nodeAComplete},
+			},
+		},
+		{
+			name:    "invalid nodeSelector",
+			objects: nodes,
+			pc: generatePacketCapture(crdv1alpha1.PacketCaptureSpec{
+				Node: &crdv1alpha1.PacketCaptureNode{
+					NodeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "zone", Operator: "Foo"}}},
+					Port:         crdv1alpha1.PacketCapturePortTypeUplink,
+				},
+			}),
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				Conditions: []crdv1alpha1.PacketCaptureCondition{
+					{Type: crdv1alpha1.PacketCaptureStarted, Status: metav1.ConditionFalse, Reason: "NotStarted", Message: `invalid nodeSelector: "Foo" is not a valid label selector operator`},
+				},
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:    "Node PacketCapture not controlled by the PacketCapture",
+			objects: nodes,
+			pc:      generatePacketCapture(selectorSpec),
+			nodePCs: []runtime.Object{
+				generateNodePacketCapture("uid-deleted-pc", "node-a", withNode("node-a")),
+			},
+			expectedErr: "PacketCapture pc-node-a already exists and is not controlled by PacketCapture pc",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeController(t, tt.objects, append(tt.nodePCs, tt.pc))
+			err := c.syncPacketCapture(tt.pc.Name)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			created := map[string]crdv1alpha1.PacketCaptureSpec{}
+			updated := false
+			for _, action := range c.crdClient.Actions() {
+				switch {
+				case action.GetVerb() == "create":
+					nodePC := action.(k8stesting.CreateAction).GetObject().(*crdv1alpha1.PacketCapture)
+					assert.True(t, metav1.IsControlledBy(nodePC, tt.pc))
+					created[nodePC.Name] = nodePC.Spec
+				case action.GetVerb() == "update" && action.GetSubresource() == "status":
+					updated = true
+				}
+			}
+			if tt.expectedCreated == nil {
+				tt.expectedCreated = map[string]crdv1alpha1.PacketCaptureSpec{}
+			}
+			assert.Equal(t, tt.expectedCreated, created)
+			assert.Equal(t, tt.expectedUpdate, updated)
+
+			pc, err := c.crdClient.CrdV1alpha1().PacketCaptures().Get(context.TODO(), tt.pc.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.True(t, crdv1alpha1.PacketCaptureStatusEqual(tt.expectedStatus, pc.Status), "Expected: %+v\nGot: %+v", tt.expectedStatus, pc.Status)
+		})
+	}
+}
+
+func TestNodePacketCaptureName(t *testing.T) {
+	assert.Equal(t, "pc-node-a", nodePacketCaptureName("pc", "node-a"))
+
+	longName := nodePacketCaptureName(strings.Repeat("a", 200), strings.Repeat("b", 100))
+	assert.Len(t, longName, validation.DNS1123SubdomainMaxLength)
+	assert.True(t, strings.HasPrefix(longName, strings.Repeat("a", 200)+"-"))
+	assert.NotEqual(t, longName, nodePacketCaptureName(strings.Repeat("a", 200), strings.Repeat("b", 99)+"c"))
+	assert.Empty(t, validation.IsDNS1123Subdomain(longName))
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+)
+
+// aggregateNodeStatus returns the status of a multi-Node PacketCapture, given the status of the capture on each of
+// the Nodes sorted by Node name. The capture is started once it's started on any Node, and it's not complete until
+// it's complete on all the Nodes.
+func aggregateNodeStatus(nodeStatuses []crdv1alpha1.PacketCaptureNodeStatus, t metav1.Time) *crdv1alpha1.PacketCaptureStatus {
+	desiredStatus := &crdv1alpha1.PacketCaptureStatus{Nodes: nodeStatuses}
+	allComplete := len(nodeStatuses) > 0
+	var started, failed, timedOut bool
+	var notStartedMessages, completeMessages, uploadMessages []string
+	var uploaded int
+	stopReasons := sets.New[crdv1alpha1.PacketCaptureStopReason]()
+	for i := range nodeStatuses {
+		s := &nodeStatuses[i]
+		desiredStatus.NumberCaptured += s.NumberCaptured
+		if cond := findCondition(s.Conditions, crdv1alpha1.PacketCaptureStarted); cond != nil {
+			if cond.Status == metav1.ConditionTrue {
+				started = true
+			} else if cond.Message != "" {
+				notStartedMessages = append(notStartedMessages, fmt.Sprintf("%s: %s", s.NodeName, cond.Message))
+			}
+		}
+		cond := findCondition(s.Conditions, crdv1alpha1.PacketCaptureComplete)
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			allComplete = false
+			continue
+		}
+		switch cond.Reason {
+		case "Failed":
+			failed = true
+		case "Timeout":
+			timedOut = true
+		}
+		if cond.Message != "" {
+			completeMessages = append(completeMessages, fmt.Sprintf("%s: %s", s.NodeName, cond.Message))
+		}
+		stopReasons.Insert(s.StopReason)
+		if cond := findCondition(s.Conditions, crdv1alpha1.PacketCaptureFileUploaded); cond != nil {
+			uploaded++
+			if cond.Status != metav1.ConditionTrue {
+				uploadMessages = append(uploadMessages, fmt.Sprintf("%s: %s", s.NodeName, cond.Message))
+			}
+		}
+	}
+
+	if started {
+		desiredStatus.Conditions = append(desiredStatus.Conditions, crdv1alpha1.PacketCaptureCondition{
+			Type:               crdv1alpha1.PacketCaptureStarted,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: t,
+			Reason:             "Started",
+		})
+	} else if len(notStartedMessages) > 0 {
+		desiredStatus.Conditions = append(desiredStatus.Conditions, crdv1alpha1.PacketCaptureCondition{
+			Type:               crdv1alpha1.PacketCaptureStarted,
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: t,
+			Reason:             "NotStarted",
+			Message:            strings.Join(notStartedMessages, "; "),
+		})
+		return desiredStatus
+	} else {
+		desiredStatus.Conditions = append(desiredStatus.Conditions, crdv1alpha1.PacketCaptureCondition{
+			Type:               crdv1alpha1.PacketCaptureStarted,
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: t,
+			Reason:             "Pending",
+		})
+		return desiredStatus
+	}
+
+	if !allComplete {
+		desiredStatus.Conditions = append(desiredStatus.Conditions, crdv1alpha1.PacketCaptureCondition{
+			Type:               crdv1alpha1.PacketCaptureComplete,
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: t,
+			Reason:             "Progressing",
+		})
+		return desiredStatus
+	}
+	reason := "Succeed"
+	if failed {
+		reason = "Failed"
+	} else if timedOut {
+		reason = "Timeout"
+	}
+	desiredStatus.Conditions = append(desiredStatus.Conditions, crdv1alpha1.PacketCaptureCondition{
+		Type:               crdv1alpha1.PacketCaptureComplete,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: t,
+		Reason:             reason,
+		Message:            strings.Join(completeMessages, "; "),
+	})
+	if stopReasons.Len() == 1 {
+		desiredStatus.StopReason, _ = stopReasons.PopAny()
+	}
+	// Set Uploaded condition if any Node has uploaded its packets file.
+	if uploaded > 0 {
+		if len(uploadMessages) > 0 {
+			desiredStatus.Conditions = append(desiredStatus.Conditions, crdv1alpha1.PacketCaptureCondition{
+				Type:               crdv1alpha1.PacketCaptureFileUploaded,
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: t,
+				Reason:             "Failed",
+				Message:            strings.Join(uploadMessages, "; "),
+			})
+		} else {
+			desiredStatus.Conditions = append(desiredStatus.Conditions, crdv1alpha1.PacketCaptureCondition{
+				Type:               crdv1alpha1.PacketCaptureFileUploaded,
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: t,
+				Reason:             "Succeed",
+			})
+		}
+	}
+	return desiredStatus
+}
+
+func findCondition(conditions []crdv1alpha1.PacketCaptureCondition, conditionType crdv1alpha1.PacketCaptureConditionType) *crdv1alpha1.PacketCaptureCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
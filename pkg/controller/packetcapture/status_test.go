@@ -0,0 +1,170 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crdv1alpha1 "antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+)
+
+var (
+	started      = crdv1alpha1.PacketCaptureCondition{Type: crdv1alpha1.PacketCaptureStarted, Status: metav1.ConditionTrue, Reason: "Started"}
+	pending      = crdv1alpha1.PacketCaptureCondition{Type: crdv1alpha1.PacketCaptureStarted, Status: metav1.ConditionFalse, Reason: "Pending"}
+	progressing  = crdv1alpha1.PacketCaptureCondition{Type: crdv1alpha1.PacketCaptureComplete, Status: metav1.ConditionFalse, Reason: "Progressing"}
+	succeeded    = crdv1alpha1.PacketCaptureCondition{Type: crdv1alpha1.PacketCaptureComplete, Status: metav1.ConditionTrue, Reason: "Succeed"}
+	timedOut     = crdv1alpha1.PacketCaptureCondition{Type: crdv1alpha1.PacketCaptureComplete, Status: metav1.ConditionTrue, Reason: "Timeout", Message: "context deadline exceeded"}
+	uploaded     = crdv1alpha1.PacketCaptureCondition{Type: crdv1alpha1.PacketCaptureFileUploaded, Status: metav1.ConditionTrue, Reason: "Succeed"}
+	uploadFailed = crdv1alpha1.PacketCaptureCondition{Type: crdv1alpha1.PacketCaptureFileUploaded, Status: metav1.ConditionFalse, Reason: "Failed", Message: "connection refused"}
+)
+
+func TestAggregateNodeStatus(t *testing.T) {
+	node1Complete := crdv1alpha1.PacketCaptureNodeStatus{
+		NodeName:       "node-1",
+		PacketCapture:  "pc-node-1",
+		NumberCaptured: 5,
+		FilePath:       "antrea-agent-1:/tmp/pc-node-1.pcapng",
+		StopReason:     crdv1alpha1.PacketCaptureStopReasonPacketLimit,
+		Conditions:     []crdv1alpha1.PacketCaptureCondition{started, succeeded},
+	}
+	node2Progressing := crdv1alpha1.PacketCaptureNodeStatus{
+		NodeName:       "node-2",
+		PacketCapture:  "pc-node-2",
+		NumberCaptured: 3,
+		Conditions:     []crdv1alpha1.PacketCaptureCondition{started, progressing},
+	}
+	node2Complete := crdv1alpha1.PacketCaptureNodeStatus{
+		NodeName:       "node-2",
+		PacketCapture:  "pc-node-2",
+		NumberCaptured: 5,
+		FilePath:       "antrea-agent-2:/tmp/pc-node-2.pcapng",
+		StopReason:     crdv1alpha1.PacketCaptureStopReasonPacketLimit,
+		Conditions:     []crdv1alpha1.PacketCaptureCondition{started, succeeded},
+	}
+	node2TimedOut := node2Complete
+	node2TimedOut.StopReason = crdv1alpha1.PacketCaptureStopReasonTimeout
+	node2TimedOut.Conditions = []crdv1alpha1.PacketCaptureCondition{started, timedOut}
+	withConditions := func(s crdv1alpha1.PacketCaptureNodeStatus, conditions ...crdv1alpha1.PacketCaptureCondition) crdv1alpha1.PacketCaptureNodeStatus {
+		s.Conditions = append(append([]crdv1alpha1.PacketCaptureCondition{}, s.Conditions...), conditions...)
+		return s
+	}
+
+	testCases := []struct {
+		name           string
+		nodeStatuses   []crdv1alpha1.PacketCaptureNodeStatus
+		expectedStatus crdv1alpha1.PacketCaptureStatus
+	}{
+		{
+			name: "no Node",
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				Conditions: []crdv1alpha1.PacketCaptureCondition{pending},
+			},
+		},
+		{
+			name: "pending on all Nodes",
+			nodeStatuses: []crdv1alpha1.PacketCaptureNodeStatus{
+				{NodeName: "node-1", PacketCapture: "pc-node-1", Conditions: []crdv1alpha1.PacketCaptureCondition{pending}},
+				{NodeName: "node-2", PacketCapture: "pc-node-2"},
+			},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				Conditions: []crdv1alpha1.PacketCaptureCondition{pending},
+				Nodes: []crdv1alpha1.PacketCaptureNodeStatus{
+					{NodeName: "node-1", PacketCapture: "pc-node-1", Conditions: []crdv1alpha1.PacketCaptureCondition{pending}},
+					{NodeName: "node-2", PacketCapture: "pc-node-2"},
+				},
+			},
+		},
+		{
+			name: "not started on a Node",
+			nodeStatuses: []crdv1alpha1.PacketCaptureNodeStatus{
+				{NodeName: "node-1", PacketCapture: "pc-node-1", Conditions: []crdv1alpha1.PacketCaptureCondition{
+					{Type: crdv1alpha1.PacketCaptureStarted, Status: metav1.ConditionFalse, Reason: "NotStarted", Message: "PacketCapture running count reach limit"},
+				}},
+			},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				Conditions: []crdv1alpha1.PacketCaptureCondition{
+					{Type: crdv1alpha1.PacketCaptureStarted, Status: metav1.ConditionFalse, Reason: "NotStarted", Message: "node-1: PacketCapture running count reach limit"},
+				},
+				Nodes: []crdv1alpha1.PacketCaptureNodeStatus{
+					{NodeName: "node-1", PacketCapture: "pc-node-1", Conditions: []crdv1alpha1.PacketCaptureCondition{
+						{Type: crdv1alpha1.PacketCaptureStarted, Status: metav1.ConditionFalse, Reason: "NotStarted", Message: "PacketCapture running count reach limit"},
+					}},
+				},
+			},
+		},
+		{
+			name:         "complete on a Node, progressing on another Node",
+			nodeStatuses: []crdv1alpha1.PacketCaptureNodeStatus{node1Complete, node2Progressing},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				NumberCaptured: 8,
+				Conditions:     []crdv1alpha1.PacketCaptureCondition{started, progressing},
+				Nodes:          []crdv1alpha1.PacketCaptureNodeStatus{node1Complete, node2Progressing},
+			},
+		},
+		{
+			name:         "complete on a Node, not reported by another Node",
+			nodeStatuses: []crdv1alpha1.PacketCaptureNodeStatus{node1Complete, {NodeName: "node-2", PacketCapture: "pc-node-2"}},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				NumberCaptured: 5,
+				Conditions:     []crdv1alpha1.PacketCaptureCondition{started, progressing},
+				Nodes:          []crdv1alpha1.PacketCaptureNodeStatus{node1Complete, {NodeName: "node-2", PacketCapture: "pc-node-2"}},
+			},
+		},
+		{
+			name: "complete on all Nodes",
+			nodeStatuses: []crdv1alpha1.PacketCaptureNodeStatus{
+				withConditions(node1Complete, uploaded),
+				withConditions(node2Complete, uploaded),
+			},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				NumberCaptured: 10,
+				StopReason:     crdv1alpha1.PacketCaptureStopReasonPacketLimit,
+				Conditions:     []crdv1alpha1.PacketCaptureCondition{started, succeeded, uploaded},
+				Nodes: []crdv1alpha1.PacketCaptureNodeStatus{
+					withConditions(node1Complete, uploaded),
+					withConditions(node2Complete, uploaded),
+				},
+			},
+		},
+		{
+			name: "timeout on a Node",
+			nodeStatuses: []crdv1alpha1.PacketCaptureNodeStatus{
+				withConditions(node1Complete, uploaded),
+				withConditions(node2TimedOut, uploadFailed),
+			},
+			expectedStatus: crdv1alpha1.PacketCaptureStatus{
+				NumberCaptured: 10,
+				Conditions: []crdv1alpha1.PacketCaptureCondition{
+					started,
+					{Type: crdv1alpha1.PacketCaptureComplete, Status: metav1.ConditionTrue, Reason: "Timeout", Message: "node-2: context deadline exceeded"},
+					{Type: crdv1alpha1.PacketCaptureFileUploaded, Status: metav1.ConditionFalse, Reason: "Failed", Message: "node-2: connection refused"},
+				},
+				Nodes: []crdv1alpha1.PacketCaptureNodeStatus{
+					withConditions(node1Complete, uploaded),
+					withConditions(node2TimedOut, uploadFailed),
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := aggregateNodeStatus(tc.nodeStatuses, metav1.Now())
+			assert.True(t, crdv1alpha1.PacketCaptureStatusEqual(tc.expectedStatus, *status), "Expected: %+v\nGot: %+v", tc.expectedStatus, *status)
+		})
+	}
+}
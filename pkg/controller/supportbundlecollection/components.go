@@ -0,0 +1,168 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlecollection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/afero"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/exec"
+
+	"antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/v2/pkg/controller/types"
+	"antrea.io/antrea/v2/pkg/support"
+	"antrea.io/antrea/v2/pkg/util/auth"
+	"antrea.io/antrea/v2/pkg/util/compress"
+	"antrea.io/antrea/v2/pkg/util/fileupload"
+)
+
+const (
+	componentController     = "antrea-controller"
+	componentFlowAggregator = "flow-aggregator"
+
+	defaultFlowAggregatorNamespace = "flow-aggregator"
+)
+
+var (
+	// Declared as variables for testing.
+	defaultFS               = afero.NewOsFs()
+	defaultExecutor         = exec.New()
+	newControllerDumper     = support.NewControllerDumper
+	newFlowAggregatorDumper = support.NewFlowAggregatorDumper
+)
+
+// getBundleComponents returns the names of the components whose bundles are collected by the antrea-controller.
+func getBundleComponents(components *v1alpha1.BundleComponents) []string {
+	if components == nil {
+		return nil
+	}
+	var names []string
+	if components.Controller {
+		names = append(names, componentController)
+	}
+	if components.FlowAggregator {
+		names = append(names, componentFlowAggregator)
+	}
+	return names
+}
+
+// collectComponentBundles collects and uploads the bundles of the components required by the SupportBundleCollection
+// one after another, and records the result of each component, so that it is reported in the status of the
+// SupportBundleCollection together with the results reported by the antrea-agents. It stops when ctx is done.
+func (c *Controller) collectComponentBundles(ctx context.Context, bundleCollection *types.SupportBundleCollection, serverAuth *auth.AuthConfiguration) {
+	for _, component := range getBundleComponents(bundleCollection.Components) {
+		err := c.collectComponentBundle(ctx, bundleCollection, component, serverAuth)
+		if err != nil {
+			klog.ErrorS(err, "Failed to collect support bundle", "name", bundleCollection.Name, "component", component)
+		} else {
+			klog.InfoS("Collected support bundle", "name", bundleCollection.Name, "component", component)
+		}
+		if !c.setComponentStatus(bundleCollection.Name, component, err) {
+			return
+		}
+		c.queue.Add(bundleCollection.Name)
+	}
+}
+
+func (c *Controller) collectComponentBundle(ctx context.Context, bundleCollection *types.SupportBundleCollection, component string, serverAuth *auth.AuthConfiguration) error {
+	basedir, err := afero.TempDir(defaultFS, "", "bundle_tmp_")
+	if err != nil {
+		return fmt.Errorf("error when creating temp dir: %w", err)
+	}
+	defer defaultFS.RemoveAll(basedir)
+
+	profile := support.Profile(bundleCollection.Profile)
+	var dumpFuncs []func(string) error
+	switch component {
+	case componentController:
+		dumpFuncs, err = support.ControllerDumpFuncs(newControllerDumper(defaultFS, defaultExecutor, bundleCollection.SinceTime), profile)
+	case componentFlowAggregator:
+		namespace := bundleCollection.Components.FlowAggregatorNamespace
+		if namespace == "" {
+			namespace = defaultFlowAggregatorNamespace
+		}
+		dumpFuncs, err = support.FlowAggregatorDumpFuncs(newFlowAggregatorDumper(ctx, defaultFS, c.kubeClient, namespace, bundleCollection.SinceTime), profile)
+	}
+	if err != nil {
+		return err
+	}
+	for _, dump := range dumpFuncs {
+		if err := dump(basedir); err != nil {
+			return err
+		}
+	}
+	if bundleCollection.Redact {
+		// The pseudonyms are keyed by the UID of the collection, so that they are consistent across the bundles of
+		// all the components and of the Nodes.
+		redactor, err := c.newRedactor([]byte(bundleCollection.UID))
+		if err != nil {
+			return fmt.Errorf("error when preparing redaction of support bundle: %w", err)
+		}
+		if err := redactor.RedactDir(defaultFS, basedir); err != nil {
+			return fmt.Errorf("error when redacting support bundle: %w", err)
+		}
+	}
+
+	outputFile, err := afero.TempFile(defaultFS, "", "bundle_*.tar.gz")
+	if err != nil {
+		return fmt.Errorf("error when creating temp file: %w", err)
+	}
+	defer func() {
+		outputFile.Close()
+		defaultFS.Remove(outputFile.Name())
+	}()
+	if _, err := compress.PackDir(defaultFS, basedir, outputFile); err != nil {
+		return fmt.Errorf("error when packaging support bundle: %w", err)
+	}
+
+	fileServer := &fileupload.FileServer{
+		URL:           bundleCollection.FileServer.URL,
+		HostPublicKey: bundleCollection.FileServer.HostPublicKey,
+	}
+	if s3Config := bundleCollection.FileServer.S3; s3Config != nil {
+		fileServer.S3Endpoint = s3Config.Endpoint
+		fileServer.S3Region = s3Config.Region
+	}
+	return c.uploader.Upload(ctx, fileServer, serverAuth, component+"_"+bundleCollection.Name+".tar.gz", outputFile)
+}
+
+// setComponentStatus records the result of the collection of a component's bundle. It returns false if the
+// SupportBundleCollection has been deleted meanwhile, in which case the result is dropped.
+func (c *Controller) setComponentStatus(key string, component string, err error) bool {
+	c.statusesLock.Lock()
+	defer c.statusesLock.Unlock()
+	if _, found, _ := c.supportBundleCollectionStore.Get(key); !found {
+		return false
+	}
+	statusPerComponent, exists := c.componentStatuses[key]
+	if !exists {
+		statusPerComponent = make(map[string]error)
+		c.componentStatuses[key] = statusPerComponent
+	}
+	statusPerComponent[component] = err
+	return true
+}
+
+func (c *Controller) getComponentStatuses(key string) map[string]error {
+	c.statusesLock.RLock()
+	defer c.statusesLock.RUnlock()
+	statuses := make(map[string]error, len(c.componentStatuses[key]))
+	for component, err := range c.componentStatuses[key] {
+		statuses[component] = err
+	}
+	return statuses
+}
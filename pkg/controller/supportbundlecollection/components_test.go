@@ -0,0 +1,165 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlecollection
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/exec"
+
+	"antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/v2/pkg/controller/types"
+	"antrea.io/antrea/v2/pkg/support"
+	"antrea.io/antrea/v2/pkg/util/auth"
+	"antrea.io/antrea/v2/pkg/util/fileupload"
+)
+
+type fakeControllerDumper struct {
+	fs afero.Fs
+}
+
+func (d *fakeControllerDumper) dump(basedir string, name string) error {
+	return afero.WriteFile(d.fs, filepath.Join(basedir, name), []byte(name), 0644)
+}
+
+func (d *fakeControllerDumper) DumpLog(basedir string) error { return d.dump(basedir, "logs") }
+func (d *fakeControllerDumper) DumpControllerInfo(basedir string) error {
+	return d.dump(basedir, "controllerinfo")
+}
+func (d *fakeControllerDumper) DumpNetworkPolicyResources(basedir string) error {
+	return d.dump(basedir, "networkpolicies")
+}
+func (d *fakeControllerDumper) DumpHeapPprof(basedir string) error { return d.dump(basedir, "heap") }
+func (d *fakeControllerDumper) DumpGoroutinePprof(basedir string) error {
+	return d.dump(basedir, "goroutine")
+}
+
+type fakeFlowAggregatorDumper struct {
+	namespace string
+}
+
+func (d *fakeFlowAggregatorDumper) DumpLog(basedir string) error {
+	return fmt.Errorf("no flow-aggregator Pod found in Namespace %s", d.namespace)
+}
+func (d *fakeFlowAggregatorDumper) DumpFlowAggregatorInfo(basedir string) error {
+	return fmt.Errorf("no flow-aggregator Pod found in Namespace %s", d.namespace)
+}
+
+type fakeHTTPUploader struct {
+	uploadedFiles []string
+}
+
+func (u *fakeHTTPUploader) Upload(ctx context.Context, url string, fileName string, serverAuth *auth.AuthConfiguration, file io.ReadSeeker) error {
+	if serverAuth == nil || serverAuth.APIKey != testKeyString {
+		return fmt.Errorf("unexpected authentication")
+	}
+	u.uploadedFiles = append(u.uploadedFiles, fileName)
+	return nil
+}
+
+func TestCollectComponentBundles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	defer func(fs afero.Fs, controllerDumper func(afero.Fs, exec.Interface, string) support.ControllerDumper, flowAggregatorDumper func(context.Context, afero.Fs, kubernetes.Interface, string, string) support.FlowAggregatorDumper) {
+		defaultFS = fs
+		newControllerDumper = controllerDumper
+		newFlowAggregatorDumper = flowAggregatorDumper
+	}(defaultFS, newControllerDumper, newFlowAggregatorDumper)
+	defaultFS = fs
+	newControllerDumper = func(fs afero.Fs, executor exec.Interface, since string) support.ControllerDumper {
+		return &fakeControllerDumper{fs: fs}
+	}
+	newFlowAggregatorDumper = func(ctx context.Context, fs afero.Fs, kubeClient kubernetes.Interface, namespace string, since string) support.FlowAggregatorDumper {
+		return &fakeFlowAggregatorDumper{namespace: namespace}
+	}
+
+	collectionName := "b1"
+	components := &v1alpha1.BundleComponents{Controller: true, FlowAggregator: true, FlowAggregatorNamespace: "fa"}
+	testClient := newTestClient(nil, []runtime.Object{
+		&v1alpha1.SupportBundleCollection{
+			ObjectMeta: metav1.ObjectMeta{Name: collectionName},
+			Spec: v1alpha1.SupportBundleCollectionSpec{
+				Components: components,
+				FileServer: v1alpha1.BundleFileServer{
+					URL: "https://api.example.com/v1/supportbundles/",
+				},
+				ExpirationMinutes: 60,
+			},
+			Status: v1alpha1.SupportBundleCollectionStatus{
+				Conditions: []v1alpha1.SupportBundleCollectionCondition{
+					{Type: v1alpha1.CollectionStarted, Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(time.Now())},
+				},
+			},
+		},
+	})
+	controller := newController(testClient)
+	httpUploader := &fakeHTTPUploader{}
+	controller.uploader = &fileupload.Uploader{HTTPUploader: httpUploader}
+	internalBundleCollection := &types.SupportBundleCollection{
+		Name:       collectionName,
+		SpanMeta:   types.SpanMeta{NodeNames: sets.New[string]()},
+		FileServer: v1alpha1.BundleFileServer{URL: "https://api.example.com/v1/supportbundles/"},
+		Profile:    v1alpha1.SupportBundleProfileLogsOnly,
+		Components: components,
+	}
+	require.NoError(t, controller.supportBundleCollectionStore.Create(internalBundleCollection))
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	testClient.start(stopCh)
+	testClient.waitForSync(stopCh)
+
+	serverAuth := &auth.AuthConfiguration{AuthType: auth.APIKeyType, APIKey: testKeyString}
+	controller.collectComponentBundles(context.Background(), internalBundleCollection, serverAuth)
+	assert.Equal(t, []string{"antrea-controller_b1.tar.gz"}, httpUploader.uploadedFiles)
+	assert.Equal(t, 1, controller.queue.Len())
+	// The temporary files are removed after the upload.
+	files, err := afero.ReadDir(fs, os.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	require.NoError(t, controller.syncSupportBundleCollection(collectionName))
+	bundleCollection, err := controller.crdClient.CrdV1alpha1().SupportBundleCollections().Get(context.Background(), collectionName, metav1.GetOptions{})
+	require.NoError(t, err)
+	for _, condition := range []v1alpha1.SupportBundleCollectionCondition{
+		{Type: v1alpha1.BundleCollected, Status: metav1.ConditionTrue},
+		{
+			Type:    v1alpha1.CollectionFailure,
+			Status:  metav1.ConditionTrue,
+			Reason:  string(metav1.StatusReasonInternalError),
+			Message: "Failed to collect flow-aggregator bundle: no flow-aggregator Pod found in Namespace fa",
+		},
+		{Type: v1alpha1.CollectionCompleted, Status: metav1.ConditionTrue},
+	} {
+		assert.True(t, conditionExistsIgnoreLastTransitionTime(bundleCollection.Status.Conditions, condition), "Missing condition %s", condition.Type)
+	}
+
+	// The results of the collections are dropped with the internal SupportBundleCollection.
+	require.NoError(t, controller.deleteInternalSupportBundleCollection(collectionName))
+	assert.Empty(t, controller.getComponentStatuses(collectionName))
+	controller.collectComponentBundles(context.Background(), internalBundleCollection, serverAuth)
+	assert.Empty(t, controller.getComponentStatuses(collectionName))
+}
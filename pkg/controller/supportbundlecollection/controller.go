@@ -30,8 +30,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	networkinginformers "k8s.io/client-go/informers/networking/v1"
 	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
@@ -42,9 +44,12 @@ import (
 	"antrea.io/antrea/v2/pkg/apiserver/storage"
 	clientset "antrea.io/antrea/v2/pkg/client/clientset/versioned"
 	crdinformers "antrea.io/antrea/v2/pkg/client/informers/externalversions/crd/v1alpha1"
+	crdv1beta1informers "antrea.io/antrea/v2/pkg/client/informers/externalversions/crd/v1beta1"
 	crdlisters "antrea.io/antrea/v2/pkg/client/listers/crd/v1alpha1"
+	crdv1beta1listers "antrea.io/antrea/v2/pkg/client/listers/crd/v1beta1"
 	"antrea.io/antrea/v2/pkg/controller/types"
 	"antrea.io/antrea/v2/pkg/util/auth"
+	"antrea.io/antrea/v2/pkg/util/fileupload"
 	"antrea.io/antrea/v2/pkg/util/k8s"
 )
 
@@ -106,6 +111,17 @@ type Controller struct {
 	nodeListerSynced                    cache.InformerSynced
 	externalNodeLister                  crdlisters.ExternalNodeLister
 	externalNodeListerSynced            cache.InformerSynced
+	// The following listers are used to collect the names to redact in the bundles of the Antrea components.
+	podLister                 corelisters.PodLister
+	podListerSynced           cache.InformerSynced
+	serviceLister             corelisters.ServiceLister
+	serviceListerSynced       cache.InformerSynced
+	networkPolicyLister       networkinglisters.NetworkPolicyLister
+	networkPolicyListerSynced cache.InformerSynced
+	acnpLister                crdv1beta1listers.ClusterNetworkPolicyLister
+	acnpListerSynced          cache.InformerSynced
+	annpLister                crdv1beta1listers.NetworkPolicyLister
+	annpListerSynced          cache.InformerSynced
 
 	// queue maintains the ExternalNode objects that need to be synced.
 	queue workqueue.TypedRateLimitingInterface[string]
@@ -119,8 +135,16 @@ type Controller struct {
 	// statuses is a nested map that keeps the realization statuses reported by antrea-agents.
 	// The outer map's keys are the SupportBundleCollection names. The inner map's keys are the Node names. The inner
 	// map's values are statuses reported by each Node for a SupportBundleCollection.
-	statuses     map[string]map[string]*controlplane.SupportBundleCollectionNodeStatus
-	statusesLock sync.RWMutex
+	statuses map[string]map[string]*controlplane.SupportBundleCollectionNodeStatus
+	// componentStatuses is a nested map that keeps the results of the bundle collections of the Antrea components
+	// done by the antrea-controller. The outer map's keys are the SupportBundleCollection names. The inner map's keys
+	// are the component names. The inner map's values are the errors which occurred when collecting the bundles, nil
+	// if the bundles were uploaded successfully.
+	componentStatuses map[string]map[string]error
+	statusesLock      sync.RWMutex
+
+	// uploader is used to upload the bundles of the Antrea components to the file server.
+	uploader *fileupload.Uploader
 }
 
 func NewSupportBundleCollectionController(
@@ -129,6 +153,11 @@ func NewSupportBundleCollectionController(
 	supportBundleInformer crdinformers.SupportBundleCollectionInformer,
 	nodeInformer coreinformers.NodeInformer,
 	externalNodeInformer crdinformers.ExternalNodeInformer,
+	podInformer coreinformers.PodInformer,
+	serviceInformer coreinformers.ServiceInformer,
+	networkPolicyInformer networkinginformers.NetworkPolicyInformer,
+	acnpInformer crdv1beta1informers.ClusterNetworkPolicyInformer,
+	annpInformer crdv1beta1informers.NetworkPolicyInformer,
 	supportBundleCollectionStore storage.Interface) *Controller {
 	c := &Controller{
 		kubeClient: kubeClient,
@@ -141,6 +170,16 @@ func NewSupportBundleCollectionController(
 		nodeListerSynced:                    nodeInformer.Informer().HasSynced,
 		externalNodeLister:                  externalNodeInformer.Lister(),
 		externalNodeListerSynced:            externalNodeInformer.Informer().HasSynced,
+		podLister:                           podInformer.Lister(),
+		podListerSynced:                     podInformer.Informer().HasSynced,
+		serviceLister:                       serviceInformer.Lister(),
+		serviceListerSynced:                 serviceInformer.Informer().HasSynced,
+		networkPolicyLister:                 networkPolicyInformer.Lister(),
+		networkPolicyListerSynced:           networkPolicyInformer.Informer().HasSynced,
+		acnpLister:                          acnpInformer.Lister(),
+		acnpListerSynced:                    acnpInformer.Informer().HasSynced,
+		annpLister:                          annpInformer.Lister(),
+		annpListerSynced:                    annpInformer.Informer().HasSynced,
 		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
 			workqueue.NewTypedItemExponentialFailureRateLimiter[string](minRetryDelay, maxRetryDelay),
 			workqueue.TypedRateLimitingQueueConfig[string]{
@@ -152,7 +191,9 @@ func NewSupportBundleCollectionController(
 			processingNodesIndex:         processingNodesIndexFunc,
 			processingExternalNodesIndex: processingExternalNodesIndexFunc,
 		}),
-		statuses: make(map[string]map[string]*controlplane.SupportBundleCollectionNodeStatus),
+		statuses:          make(map[string]map[string]*controlplane.SupportBundleCollectionNodeStatus),
+		componentStatuses: make(map[string]map[string]error),
+		uploader:          fileupload.NewUploader(),
 	}
 	c.supportBundleCollectionInformer.Informer().AddEventHandlerWithResyncPeriod(
 		cache.ResourceEventHandlerFuncs{
@@ -171,7 +212,8 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	klog.InfoS("Starting", "controllerName", controllerName)
 	defer klog.InfoS("Shutting down", "controllerName", controllerName)
 
-	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.supportBundleCollectionListerSynced, c.nodeListerSynced, c.externalNodeListerSynced) {
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.supportBundleCollectionListerSynced, c.nodeListerSynced, c.externalNodeListerSynced,
+		c.podListerSynced, c.serviceListerSynced, c.networkPolicyListerSynced, c.acnpListerSynced, c.annpListerSynced) {
 		return
 	}
 	if err := c.reconcileSupportBundleCollections(); err != nil {
@@ -401,7 +443,22 @@ func (c *Controller) createInternalSupportBundleCollection(bundle *v1alpha1.Supp
 			Password: authentication.BasicAuthentication.Password,
 		}
 	}
+	if authentication.AccessKey != nil {
+		bundleAuthConfig.AccessKey = &controlplane.AccessKey{
+			AccessKeyID:     authentication.AccessKey.AccessKeyID,
+			SecretAccessKey: authentication.AccessKey.SecretAccessKey,
+		}
+	}
 	internalBundleCollection := c.addInternalSupportBundleCollection(bundle, nodeSpan, bundleAuthConfig, metav1.NewTime(expiredAt))
+	if len(getBundleComponents(internalBundleCollection.Components)) > 0 {
+		// The bundles of the Antrea components must be uploaded before the collection expires, like those of the
+		// antrea-agents.
+		ctx, cancel := context.WithDeadline(context.TODO(), expiredAt)
+		go func() {
+			defer cancel()
+			c.collectComponentBundles(ctx, internalBundleCollection, authentication)
+		}()
+	}
 	// Process the support bundle collection when time is up, this will create a CollectionFailure condition if the
 	// bundle collection is not completed in time because any Agent fails to upload the files and does not report
 	// the failure.
@@ -554,6 +611,9 @@ func (c *Controller) addInternalSupportBundleCollection(
 		FileServer:     bundleCollection.Spec.FileServer,
 		ExpiredAt:      expiredAt,
 		Authentication: *authentication,
+		Profile:        bundleCollection.Spec.Profile,
+		Redact:         bundleCollection.Spec.Redact,
+		Components:     bundleCollection.Spec.Components,
 	}
 	_ = c.supportBundleCollectionStore.Create(internalBundleCollection)
 	return internalBundleCollection
@@ -679,6 +739,23 @@ func (c *Controller) updateStatus(internalBundleCollection *types.SupportBundleC
 			failedNodeReasons[failedReason] = append(failedNodeReasons[failedReason], nodeKey)
 		}
 	}
+	components := getBundleComponents(internalBundleCollection.Components)
+	componentStatuses := c.getComponentStatuses(internalBundleCollection.Name)
+	collectedComponents := 0
+	processedComponents := 0
+	var failedComponentMessages []string
+	for _, component := range components {
+		err, processed := componentStatuses[component]
+		if !processed {
+			continue
+		}
+		processedComponents += 1
+		if err == nil {
+			collectedComponents += 1
+		} else {
+			failedComponentMessages = append(failedComponentMessages, fmt.Sprintf("Failed to collect %s bundle: %v", component, err))
+		}
+	}
 
 	newConditions := []v1alpha1.SupportBundleCollectionCondition{
 		// Mark the support bundle collection as started since the internal resource successfully created.
@@ -686,7 +763,7 @@ func (c *Controller) updateStatus(internalBundleCollection *types.SupportBundleC
 		{Type: v1alpha1.CollectionStarted, Status: metav1.ConditionTrue, LastTransitionTime: metav1.Now()},
 	}
 	bundleCollectedStatus, collectionCompleted := metav1.ConditionFalse, metav1.ConditionFalse
-	if collectedNodes > 0 {
+	if collectedNodes > 0 || collectedComponents > 0 {
 		bundleCollectedStatus = metav1.ConditionTrue
 	}
 	newConditions = append(newConditions,
@@ -696,6 +773,7 @@ func (c *Controller) updateStatus(internalBundleCollection *types.SupportBundleC
 			LastTransitionTime: now,
 		},
 	)
+	var failedMessages []string
 	if failedNodes > 0 {
 		failedNodeErrors := make([]string, 0, len(failedNodeReasons))
 		for k, v := range failedNodeReasons {
@@ -703,7 +781,11 @@ func (c *Controller) updateStatus(internalBundleCollection *types.SupportBundleC
 			failedNodeErrors = append(failedNodeErrors, fmt.Sprintf(`"%s":[%s]`, k, strings.Join(v, ", ")))
 		}
 		sort.Strings(failedNodeErrors)
-		failedConditionMessage := fmt.Sprintf("Failed Agent count: %d, %s", failedNodes, strings.Join(failedNodeErrors, ", "))
+		failedMessages = append(failedMessages, fmt.Sprintf("Failed Agent count: %d, %s", failedNodes, strings.Join(failedNodeErrors, ", ")))
+	}
+	failedMessages = append(failedMessages, failedComponentMessages...)
+	if len(failedMessages) > 0 {
+		failedConditionMessage := strings.Join(failedMessages, "; ")
 		newConditions = append(newConditions,
 			v1alpha1.SupportBundleCollectionCondition{
 				Type:               v1alpha1.CollectionFailure,
@@ -722,7 +804,7 @@ func (c *Controller) updateStatus(internalBundleCollection *types.SupportBundleC
 			},
 		)
 	}
-	if collectedNodes+failedNodes == desiredNodes {
+	if collectedNodes+failedNodes == desiredNodes && processedComponents == len(components) {
 		collectionCompleted = metav1.ConditionTrue
 	}
 	newConditions = append(newConditions,
@@ -769,6 +851,7 @@ func (c *Controller) clearStatuses(key string) {
 	c.statusesLock.Lock()
 	defer c.statusesLock.Unlock()
 	delete(c.statuses, key)
+	delete(c.componentStatuses, key)
 }
 
 func (c *Controller) updateSupportBundleCollectionStatus(name string, updatedStatus *v1alpha1.SupportBundleCollectionStatus) error {
@@ -1714,7 +1714,13 @@ func newController(tc *testClient) *Controller {
 	supportBundleInformer := tc.crdInformerFactory.Crd().V1alpha1().SupportBundleCollections()
 
 	store := bundlecollectionstore.NewSupportBundleCollectionStore()
-	fakeController := NewSupportBundleCollectionController(tc.client, tc.crdClient, supportBundleInformer, nodeInformer, externalNodeInformer, store)
+	fakeController := NewSupportBundleCollectionController(tc.client, tc.crdClient, supportBundleInformer, nodeInformer, externalNodeInformer,
+		tc.informerFactory.Core().V1().Pods(),
+		tc.informerFactory.Core().V1().Services(),
+		tc.informerFactory.Networking().V1().NetworkPolicies(),
+		tc.crdInformerFactory.Crd().V1beta1().ClusterNetworkPolicies(),
+		tc.crdInformerFactory.Crd().V1beta1().NetworkPolicies(),
+		store)
 	return fakeController
 }
 
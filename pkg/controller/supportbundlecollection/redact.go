@@ -0,0 +1,80 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlecollection
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"antrea.io/antrea/v2/pkg/support"
+)
+
+// newRedactor returns a Redactor for the names which can appear in the bundles of the antrea-controller and of the
+// flow-aggregator: the names and labels of the Nodes and Pods, and the names of the Services and NetworkPolicies of
+// the cluster. Pseudonyms are derived from key. The names are read from the informer caches, so that collecting
+// redacted bundles does not generate additional requests to the Kubernetes API.
+func (c *Controller) newRedactor(key []byte) (*support.Redactor, error) {
+	redactor := support.NewRedactor(key)
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Nodes: %w", err)
+	}
+	for _, node := range nodes {
+		redactor.AddNames("node", node.Name)
+		redactor.AddLabels(node.Labels)
+	}
+	pods, err := c.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Pods: %w", err)
+	}
+	for _, pod := range pods {
+		redactor.AddNames("namespace", pod.Namespace)
+		redactor.AddNames("pod", pod.Name)
+		redactor.AddLabels(pod.Labels)
+	}
+	services, err := c.serviceLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Services: %w", err)
+	}
+	for _, service := range services {
+		redactor.AddNames("namespace", service.Namespace)
+		redactor.AddNames("service", service.Name)
+	}
+	networkPolicies, err := c.networkPolicyLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error when listing NetworkPolicies: %w", err)
+	}
+	for _, policy := range networkPolicies {
+		redactor.AddNames("namespace", policy.Namespace)
+		redactor.AddNames("policy", policy.Name)
+	}
+	clusterNetworkPolicies, err := c.acnpLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Antrea ClusterNetworkPolicies: %w", err)
+	}
+	for _, policy := range clusterNetworkPolicies {
+		redactor.AddNames("policy", policy.Name)
+	}
+	antreaNetworkPolicies, err := c.annpLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Antrea NetworkPolicies: %w", err)
+	}
+	for _, policy := range antreaNetworkPolicies {
+		redactor.AddNames("namespace", policy.Namespace)
+		redactor.AddNames("policy", policy.Name)
+	}
+	return redactor, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlecollection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	crdv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+	"antrea.io/antrea/v2/pkg/support"
+)
+
+func TestNewRedactor(t *testing.T) {
+	testClient := newTestClient([]runtime.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "k8s-node-1", Labels: map[string]string{"zone": "eu-west"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "frontend", Name: "web-1", Labels: map[string]string{"app": "web"}}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "frontend", Name: "web-svc"}},
+		&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "backend", Name: "deny-all"}},
+	}, []runtime.Object{
+		&crdv1beta1.ClusterNetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "isolate-tenants"}},
+		&crdv1beta1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "db", Name: "allow-web"}},
+	})
+	controller := newController(testClient)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	testClient.start(stopCh)
+	testClient.waitForSync(stopCh)
+
+	key := []byte("collection-uid")
+	redactor, err := controller.newRedactor(key)
+	require.NoError(t, err)
+	data := "k8s-node-1 eu-west frontend/web-1 app=web frontend/web-svc backend/deny-all isolate-tenants db/allow-web"
+	redacted := string(redactor.Redact([]byte(data)))
+
+	// The pseudonyms are the same as the ones generated by the antrea-agents for the same collection.
+	expectedRedactor := support.NewRedactor(key)
+	expectedRedactor.AddNames("node", "k8s-node-1")
+	expectedRedactor.AddNames("label", "eu-west", "web")
+	expectedRedactor.AddNames("namespace", "frontend", "backend", "db")
+	expectedRedactor.AddNames("pod", "web-1")
+	expectedRedactor.AddNames("service", "web-svc")
+	expectedRedactor.AddNames("policy", "deny-all", "isolate-tenants", "allow-web")
+	assert.Equal(t, string(expectedRedactor.Redact([]byte(data))), redacted)
+	assert.NotContains(t, redacted, "web")
+}
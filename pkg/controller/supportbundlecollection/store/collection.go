@@ -100,7 +100,15 @@ func ToSupportBundleCollectionMsg(in *types.SupportBundleCollection, out *contro
 		URL:           in.FileServer.URL,
 		HostPublicKey: in.FileServer.HostPublicKey,
 	}
+	if in.FileServer.S3 != nil {
+		out.FileServer.S3 = &controlplane.BundleS3Config{
+			Endpoint: in.FileServer.S3.Endpoint,
+			Region:   in.FileServer.S3.Region,
+		}
+	}
 	out.Authentication = in.Authentication
+	out.Profile = string(in.Profile)
+	out.Redact = in.Redact
 }
 
 // SupportBundleCollectionKeyFunc knows how to get the key of a SupportBundleCollection.
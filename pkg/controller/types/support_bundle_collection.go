@@ -32,4 +32,8 @@ type SupportBundleCollection struct {
 	SinceTime      string
 	FileServer     v1alpha1.BundleFileServer
 	Authentication controlplane.BundleServerAuthConfiguration
+	Profile        v1alpha1.SupportBundleCollectionProfile
+	Redact         bool
+	// Components are the Antrea components whose bundles are collected by the antrea-controller.
+	Components *v1alpha1.BundleComponents
 }
@@ -84,6 +84,10 @@ const (
 	// Allows to capture packets for a flow.
 	PacketCapture featuregate.Feature = "PacketCapture"
 
+	// alpha: v2.7
+	// Allows to probe the connectivity between Pods and destinations with synthetic traffic.
+	ConnectivityProbe featuregate.Feature = "ConnectivityProbe"
+
 	// alpha: v0.9
 	// Flow exporter exports IPFIX flow records of Antrea flows seen in conntrack module.
 	FlowExporter featuregate.Feature = "FlowExporter"
@@ -213,6 +217,7 @@ var (
 		CleanupStaleUDPSvcConntrack:   {Default: true, PreRelease: featuregate.Beta},
 		Traceflow:                     {Default: true, PreRelease: featuregate.Beta},
 		PacketCapture:                 {Default: false, PreRelease: featuregate.Alpha},
+		ConnectivityProbe:             {Default: false, PreRelease: featuregate.Alpha},
 		AntreaIPAM:                    {Default: false, PreRelease: featuregate.Alpha},
 		FlowExporter:                  {Default: false, PreRelease: featuregate.Alpha},
 		NFTablesHostNetworkMode:       {Default: false, PreRelease: featuregate.Alpha},
@@ -263,6 +268,7 @@ var (
 		TopologyAwareHints,
 		Traceflow,
 		PacketCapture,
+		ConnectivityProbe,
 		PreferSameTrafficDistribution,
 		TrafficControl,
 		EgressTrafficShaping,
@@ -284,6 +290,7 @@ var (
 		Multicluster,
 		NetworkPolicyStats,
 		NodeIPAM,
+		PacketCapture,
 		ServiceExternalIP,
 		SupportBundleCollection,
 		Traceflow,
@@ -321,6 +328,7 @@ var (
 		FlowExporter:                {},
 		NodeLatencyMonitor:          {},
 		PacketCapture:               {},
+		ConnectivityProbe:           {},
 		NFTablesHostNetworkMode:     {},
 	}
 	// supportedFeaturesOnExternalNode records the features supported on an external
@@ -64,10 +64,12 @@ type RecordMetricsResponse struct {
 	WithS3Exporter         bool  `json:"withS3Exporter,omitempty"`
 	WithLogExporter        bool  `json:"withLogExporter,omitempty"`
 	WithIPFIXExporter      bool  `json:"withIPFIXExporter,omitempty"`
+	WithKafkaExporter      bool  `json:"withKafkaExporter,omitempty"`
+	WithOTLPExporter       bool  `json:"withOTLPExporter,omitempty"`
 }
 
 func (r RecordMetricsResponse) GetTableHeader() []string {
-	return []string{"RECORDS-EXPORTED", "RECORDS-RECEIVED", "RECORDS-DROPPED", "FLOWS", "EXPORTERS-CONNECTED", "CLICKHOUSE-EXPORTER", "S3-EXPORTER", "LOG-EXPORTER", "IPFIX-EXPORTER"}
+	return []string{"RECORDS-EXPORTED", "RECORDS-RECEIVED", "RECORDS-DROPPED", "FLOWS", "EXPORTERS-CONNECTED", "CLICKHOUSE-EXPORTER", "S3-EXPORTER", "LOG-EXPORTER", "IPFIX-EXPORTER", "KAFKA-EXPORTER", "OTLP-EXPORTER"}
 }
 
 func (r RecordMetricsResponse) GetTableRow(maxColumnLength int) []string {
@@ -81,6 +83,8 @@ func (r RecordMetricsResponse) GetTableRow(maxColumnLength int) []string {
 		strconv.FormatBool(r.WithS3Exporter),
 		strconv.FormatBool(r.WithLogExporter),
 		strconv.FormatBool(r.WithIPFIXExporter),
+		strconv.FormatBool(r.WithKafkaExporter),
+		strconv.FormatBool(r.WithOTLPExporter),
 	}
 }
 
@@ -38,6 +38,8 @@ func HandleFunc(faq querier.FlowAggregatorQuerier) http.HandlerFunc {
 			WithS3Exporter:         metrics.WithS3Exporter,
 			WithLogExporter:        metrics.WithLogExporter,
 			WithIPFIXExporter:      metrics.WithIPFIXExporter,
+			WithKafkaExporter:      metrics.WithKafkaExporter,
+			WithOTLPExporter:       metrics.WithOTLPExporter,
 		}
 		err := json.NewEncoder(w).Encode(metricsResponse)
 		if err != nil {
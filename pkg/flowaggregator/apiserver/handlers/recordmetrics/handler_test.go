@@ -41,6 +41,8 @@ func TestRecordMetricsQuery(t *testing.T) {
 		WithS3Exporter:         true,
 		WithLogExporter:        true,
 		WithIPFIXExporter:      true,
+		WithKafkaExporter:      true,
+		WithOTLPExporter:       true,
 	})
 
 	handler := HandleFunc(faq)
@@ -63,8 +65,10 @@ func TestRecordMetricsQuery(t *testing.T) {
 		WithS3Exporter:         true,
 		WithLogExporter:        true,
 		WithIPFIXExporter:      true,
+		WithKafkaExporter:      true,
+		WithOTLPExporter:       true,
 	}, received)
 
-	assert.Equal(t, received.GetTableRow(0), []string{"20", "15", "5", "30", "1", "true", "true", "true", "true"})
+	assert.Equal(t, received.GetTableRow(0), []string{"20", "15", "5", "30", "1", "true", "true", "true", "true", "true", "true"})
 
 }
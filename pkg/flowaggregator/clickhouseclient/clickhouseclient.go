@@ -21,11 +21,15 @@ import (
 	"database/sql"
 	"fmt"
 	"net/url"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/gammazero/deque"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 
@@ -33,67 +37,117 @@ import (
 	"antrea.io/antrea/v2/pkg/flowaggregator/flowrecord"
 )
 
+// flowColumn is a column of the flows table, with the function returning its value for a flow record.
+type flowColumn struct {
+	name  string
+	value func(record *flowrecord.FlowRecord, clusterUUID string) any
+}
+
+// flowColumns are the columns of the flows table in which flow records are inserted, in order.
+var flowColumns = []flowColumn{
+	{"flowStartSeconds", func(r *flowrecord.FlowRecord, _ string) any { return r.FlowStartSeconds }},
+	{"flowEndSeconds", func(r *flowrecord.FlowRecord, _ string) any { return r.FlowEndSeconds }},
+	{"flowEndSecondsFromSourceNode", func(r *flowrecord.FlowRecord, _ string) any { return r.FlowEndSecondsFromSourceNode }},
+	{"flowEndSecondsFromDestinationNode", func(r *flowrecord.FlowRecord, _ string) any { return r.FlowEndSecondsFromDestinationNode }},
+	{"flowEndReason", func(r *flowrecord.FlowRecord, _ string) any { return r.FlowEndReason }},
+	{"sourceIP", func(r *flowrecord.FlowRecord, _ string) any { return r.SourceIP }},
+	{"destinationIP", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationIP }},
+	{"sourceTransportPort", func(r *flowrecord.FlowRecord, _ string) any { return r.SourceTransportPort }},
+	{"destinationTransportPort", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationTransportPort }},
+	{"protocolIdentifier", func(r *flowrecord.FlowRecord, _ string) any { return r.ProtocolIdentifier }},
+	{"packetTotalCount", func(r *flowrecord.FlowRecord, _ string) any { return r.PacketTotalCount }},
+	{"octetTotalCount", func(r *flowrecord.FlowRecord, _ string) any { return r.OctetTotalCount }},
+	{"packetDeltaCount", func(r *flowrecord.FlowRecord, _ string) any { return r.PacketDeltaCount }},
+	{"octetDeltaCount", func(r *flowrecord.FlowRecord, _ string) any { return r.OctetDeltaCount }},
+	{"reversePacketTotalCount", func(r *flowrecord.FlowRecord, _ string) any { return r.ReversePacketTotalCount }},
+	{"reverseOctetTotalCount", func(r *flowrecord.FlowRecord, _ string) any { return r.ReverseOctetTotalCount }},
+	{"reversePacketDeltaCount", func(r *flowrecord.FlowRecord, _ string) any { return r.ReversePacketDeltaCount }},
+	{"reverseOctetDeltaCount", func(r *flowrecord.FlowRecord, _ string) any { return r.ReverseOctetDeltaCount }},
+	{"sourcePodName", func(r *flowrecord.FlowRecord, _ string) any { return r.SourcePodName }},
+	{"sourcePodNamespace", func(r *flowrecord.FlowRecord, _ string) any { return r.SourcePodNamespace }},
+	{"sourceNodeName", func(r *flowrecord.FlowRecord, _ string) any { return r.SourceNodeName }},
+	{"destinationPodName", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationPodName }},
+	{"destinationPodNamespace", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationPodNamespace }},
+	{"destinationNodeName", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationNodeName }},
+	{"destinationClusterIP", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationClusterIP }},
+	{"destinationServicePort", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationServicePort }},
+	{"destinationServicePortName", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationServicePortName }},
+	{"ingressNetworkPolicyName", func(r *flowrecord.FlowRecord, _ string) any { return r.IngressNetworkPolicyName }},
+	{"ingressNetworkPolicyNamespace", func(r *flowrecord.FlowRecord, _ string) any { return r.IngressNetworkPolicyNamespace }},
+	{"ingressNetworkPolicyRuleName", func(r *flowrecord.FlowRecord, _ string) any { return r.IngressNetworkPolicyRuleName }},
+	{"ingressNetworkPolicyRuleAction", func(r *flowrecord.FlowRecord, _ string) any { return r.IngressNetworkPolicyRuleAction }},
+	{"ingressNetworkPolicyType", func(r *flowrecord.FlowRecord, _ string) any { return r.IngressNetworkPolicyType }},
+	{"egressNetworkPolicyName", func(r *flowrecord.FlowRecord, _ string) any { return r.EgressNetworkPolicyName }},
+	{"egressNetworkPolicyNamespace", func(r *flowrecord.FlowRecord, _ string) any { return r.EgressNetworkPolicyNamespace }},
+	{"egressNetworkPolicyRuleName", func(r *flowrecord.FlowRecord, _ string) any { return r.EgressNetworkPolicyRuleName }},
+	{"egressNetworkPolicyRuleAction", func(r *flowrecord.FlowRecord, _ string) any { return r.EgressNetworkPolicyRuleAction }},
+	{"egressNetworkPolicyType", func(r *flowrecord.FlowRecord, _ string) any { return r.EgressNetworkPolicyType }},
+	{"tcpState", func(r *flowrecord.FlowRecord, _ string) any { return r.TcpState }},
+	{"flowType", func(r *flowrecord.FlowRecord, _ string) any { return r.FlowType }},
+	{"sourcePodLabels", func(r *flowrecord.FlowRecord, _ string) any { return r.SourcePodLabels }},
+	{"destinationPodLabels", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationPodLabels }},
+	{"throughput", func(r *flowrecord.FlowRecord, _ string) any { return r.Throughput }},
+	{"reverseThroughput", func(r *flowrecord.FlowRecord, _ string) any { return r.ReverseThroughput }},
+	{"throughputFromSourceNode", func(r *flowrecord.FlowRecord, _ string) any { return r.ThroughputFromSourceNode }},
+	{"throughputFromDestinationNode", func(r *flowrecord.FlowRecord, _ string) any { return r.ThroughputFromDestinationNode }},
+	{"reverseThroughputFromSourceNode", func(r *flowrecord.FlowRecord, _ string) any { return r.ReverseThroughputFromSourceNode }},
+	{"reverseThroughputFromDestinationNode", func(r *flowrecord.FlowRecord, _ string) any { return r.ReverseThroughputFromDestinationNode }},
+	{"clusterUUID", func(_ *flowrecord.FlowRecord, clusterUUID string) any { return clusterUUID }},
+	{"egressName", func(r *flowrecord.FlowRecord, _ string) any { return r.EgressName }},
+	{"egressIP", func(r *flowrecord.FlowRecord, _ string) any { return r.EgressIP }},
+	{"egressNodeName", func(r *flowrecord.FlowRecord, _ string) any { return r.EgressNodeName }},
+	{"sourcePodOwnerKind", func(r *flowrecord.FlowRecord, _ string) any { return r.SourcePodOwnerKind }},
+	{"sourcePodOwnerName", func(r *flowrecord.FlowRecord, _ string) any { return r.SourcePodOwnerName }},
+	{"destinationPodOwnerKind", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationPodOwnerKind }},
+	{"destinationPodOwnerName", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationPodOwnerName }},
+	{"destinationFQDN", func(r *flowrecord.FlowRecord, _ string) any { return r.DestinationFQDN }},
+}
+
+// flowOwnerColumns are the columns of the flows table for the owner workloads and the
+// destination FQDN, which are not present if the table was created before Antrea v2.7.
+var flowOwnerColumns = []string{
+	"sourcePodOwnerKind",
+	"sourcePodOwnerName",
+	"destinationPodOwnerKind",
+	"destinationPodOwnerName",
+	"destinationFQDN",
+}
+
+var (
+	insertQuery = buildInsertQuery(flowColumns)
+	// legacyFlowColumns and legacyInsertQuery are used when the flows table doesn't include
+	// the columns in flowOwnerColumns.
+	legacyFlowColumns = slices.DeleteFunc(slices.Clone(flowColumns), func(column flowColumn) bool {
+		return slices.Contains(flowOwnerColumns, column.name)
+	})
+	legacyInsertQuery = buildInsertQuery(legacyFlowColumns)
+)
+
 const (
 	ProtocolUnknown   = -1
 	maxQueueSize      = 1 << 19 // 524288. ~500MB assuming 1KB per record
 	queueFlushTimeout = 10 * time.Second
-	insertQuery       = `INSERT INTO flows (
-                   flowStartSeconds,
-                   flowEndSeconds,
-                   flowEndSecondsFromSourceNode,
-                   flowEndSecondsFromDestinationNode,
-                   flowEndReason,
-                   sourceIP,
-                   destinationIP,
-                   sourceTransportPort,
-                   destinationTransportPort,
-                   protocolIdentifier,
-                   packetTotalCount,
-                   octetTotalCount,
-                   packetDeltaCount,
-                   octetDeltaCount,
-                   reversePacketTotalCount,
-                   reverseOctetTotalCount,
-                   reversePacketDeltaCount,
-                   reverseOctetDeltaCount,
-                   sourcePodName,
-                   sourcePodNamespace,
-                   sourceNodeName,
-                   destinationPodName,
-                   destinationPodNamespace,
-                   destinationNodeName,
-                   destinationClusterIP,
-                   destinationServicePort,
-                   destinationServicePortName,
-                   ingressNetworkPolicyName,
-                   ingressNetworkPolicyNamespace,
-                   ingressNetworkPolicyRuleName,
-                   ingressNetworkPolicyRuleAction,
-                   ingressNetworkPolicyType,
-                   egressNetworkPolicyName,
-                   egressNetworkPolicyNamespace,
-                   egressNetworkPolicyRuleName,
-                   egressNetworkPolicyRuleAction,
-                   egressNetworkPolicyType,
-                   tcpState,
-                   flowType,
-                   sourcePodLabels,
-                   destinationPodLabels,
-                   throughput,
-                   reverseThroughput,
-                   throughputFromSourceNode,
-                   throughputFromDestinationNode,
-                   reverseThroughputFromSourceNode,
-                   reverseThroughputFromDestinationNode,
-                   clusterUUID,
-                   egressName,
-                   egressIP,
-                   egressNodeName)
-                   VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 
-                           ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
-                           ?, ?, ?, ?, ?)`
+
+	describeQuery = "DESCRIBE TABLE flows"
 )
 
+// buildInsertQuery returns the query inserting a flow record into the given columns.
+func buildInsertQuery(columns []flowColumn) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+	}
+	return fmt.Sprintf("INSERT INTO flows (%s) VALUES (%s)", strings.Join(names, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "))
+}
+
+// insertColumns returns the columns in which flow records are inserted by the given query.
+func insertColumns(query string) []flowColumn {
+	if query == legacyInsertQuery {
+		return legacyFlowColumns
+	}
+	return flowColumns
+}
+
 // PrepareClickHouseConnection is used for unit testing
 var PrepareClickHouseConnection = prepareConnection
 
@@ -121,6 +175,16 @@ type ClickHouseExportProcess struct {
 	// mutex protects configuration state from concurrent access
 	mutex       sync.Mutex
 	clusterUUID string
+	// commitFailed is set when the last batch commit failed. Commits without any record
+	// do not update it.
+	commitFailed atomic.Bool
+	// cachedRecords is the number of records cached so far. It is protected by dequeMutex.
+	cachedRecords uint64
+	// flushedRecords is the number of cached records which have been committed or dropped.
+	flushedRecords atomic.Uint64
+	// insertQuery is the query used to insert flow records, which depends on the columns
+	// of the flows table.
+	insertQuery string
 }
 
 type ClickHouseConfig struct {
@@ -141,7 +205,7 @@ func NewClickHouseClient(config ClickHouseConfig, clusterUUID string) (*ClickHou
 		return nil, fmt.Errorf("DatabaseURL, Username or Password missing in ClickHouse config")
 	}
 
-	connect, err := PrepareClickHouseConnection(config)
+	connect, query, err := PrepareClickHouseConnection(config)
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +215,7 @@ func NewClickHouseClient(config ClickHouseConfig, clusterUUID string) (*ClickHou
 		config:      config,
 		queueSize:   maxQueueSize,
 		clusterUUID: clusterUUID,
+		insertQuery: query,
 	}
 	return chClient, nil
 }
@@ -167,10 +232,30 @@ func (ch *ClickHouseExportProcess) CacheRecord(record *flowpb.Flow) error {
 		ch.deque.PopFront()
 	}
 	ch.deque.PushBack(chRow)
+	ch.cachedRecords++
 
 	return nil
 }
 
+// Healthy returns false if the last attempt to commit cached records to ClickHouse failed.
+func (ch *ClickHouseExportProcess) Healthy() bool {
+	return !ch.commitFailed.Load()
+}
+
+// AvailableCapacity returns the number of records which can still be cached before the
+// oldest cached records start being dropped.
+func (ch *ClickHouseExportProcess) AvailableCapacity() int {
+	ch.dequeMutex.Lock()
+	defer ch.dequeMutex.Unlock()
+	return max(ch.queueSize-ch.deque.Len(), 0)
+}
+
+// FlushedRecordCount returns the number of cached records which are no longer in the
+// deque, because they have been committed or dropped.
+func (ch *ClickHouseExportProcess) FlushedRecordCount() uint64 {
+	return ch.flushedRecords.Load()
+}
+
 func (ch *ClickHouseExportProcess) Start() {
 	ch.startExportProcess()
 }
@@ -237,6 +322,11 @@ func (ch *ClickHouseExportProcess) flowRecordPeriodicCommit() {
 			if err == nil {
 				committedRec += committed
 			}
+			// A commit without any record does not tell whether ClickHouse can be
+			// reached.
+			if err != nil || committed > 0 {
+				ch.commitFailed.Store(err != nil)
+			}
 		case <-logTicker.C:
 			klog.V(4).InfoS("Total number of records committed to DB", "count", committedRec)
 			committedRec = 0
@@ -250,18 +340,21 @@ func (ch *ClickHouseExportProcess) flowRecordPeriodicCommit() {
 func (ch *ClickHouseExportProcess) batchCommitAll(ctx context.Context) (int, error) {
 	ch.dequeMutex.Lock()
 	currSize := ch.deque.Len()
+	if currSize == 0 {
+		ch.flushedRecords.Store(ch.cachedRecords)
+	}
 	ch.dequeMutex.Unlock()
 	if currSize == 0 {
 		return 0, nil
 	}
 
-	var stmt *sql.Stmt
-
 	// start new connection
 	tx, err := ch.db.BeginTx(ctx, nil)
-	if err == nil {
-		stmt, err = tx.PrepareContext(ctx, insertQuery)
+	if err != nil {
+		klog.ErrorS(err, "Error when beginning transaction")
+		return 0, err
 	}
+	stmt, err := tx.PrepareContext(ctx, ch.insertQuery)
 	if err != nil {
 		klog.ErrorS(err, "Error when preparing insert statement")
 		_ = tx.Rollback()
@@ -276,63 +369,17 @@ func (ch *ClickHouseExportProcess) batchCommitAll(ctx context.Context) (int, err
 	for range currSize {
 		recordsToExport = append(recordsToExport, ch.deque.PopFront())
 	}
+	// All the records cached so far are flushed once recordsToExport are committed.
+	cachedRecords := ch.cachedRecords
 	ch.dequeMutex.Unlock()
 
+	columns := insertColumns(ch.insertQuery)
 	for _, record := range recordsToExport {
-		_, err := stmt.ExecContext(
-			ctx,
-			record.FlowStartSeconds,
-			record.FlowEndSeconds,
-			record.FlowEndSecondsFromSourceNode,
-			record.FlowEndSecondsFromDestinationNode,
-			record.FlowEndReason,
-			record.SourceIP,
-			record.DestinationIP,
-			record.SourceTransportPort,
-			record.DestinationTransportPort,
-			record.ProtocolIdentifier,
-			record.PacketTotalCount,
-			record.OctetTotalCount,
-			record.PacketDeltaCount,
-			record.OctetDeltaCount,
-			record.ReversePacketTotalCount,
-			record.ReverseOctetTotalCount,
-			record.ReversePacketDeltaCount,
-			record.ReverseOctetDeltaCount,
-			record.SourcePodName,
-			record.SourcePodNamespace,
-			record.SourceNodeName,
-			record.DestinationPodName,
-			record.DestinationPodNamespace,
-			record.DestinationNodeName,
-			record.DestinationClusterIP,
-			record.DestinationServicePort,
-			record.DestinationServicePortName,
-			record.IngressNetworkPolicyName,
-			record.IngressNetworkPolicyNamespace,
-			record.IngressNetworkPolicyRuleName,
-			record.IngressNetworkPolicyRuleAction,
-			record.IngressNetworkPolicyType,
-			record.EgressNetworkPolicyName,
-			record.EgressNetworkPolicyNamespace,
-			record.EgressNetworkPolicyRuleName,
-			record.EgressNetworkPolicyRuleAction,
-			record.EgressNetworkPolicyType,
-			record.TcpState,
-			record.FlowType,
-			record.SourcePodLabels,
-			record.DestinationPodLabels,
-			record.Throughput,
-			record.ReverseThroughput,
-			record.ThroughputFromSourceNode,
-			record.ThroughputFromDestinationNode,
-			record.ReverseThroughputFromSourceNode,
-			record.ReverseThroughputFromDestinationNode,
-			ch.clusterUUID,
-			record.EgressName,
-			record.EgressIP,
-			record.EgressNodeName,
-		)
+		args := make([]any, len(columns))
+		for i, column := range columns {
+			args[i] = column.value(record, ch.clusterUUID)
+		}
+		_, err := stmt.ExecContext(ctx, args...)
 
 		if err != nil {
 			klog.ErrorS(err, "Error when adding record")
@@ -347,6 +394,7 @@ func (ch *ClickHouseExportProcess) batchCommitAll(ctx context.Context) (int, err
 		ch.pushRecordsToFrontOfQueue(recordsToExport)
 		return 0, err
 	}
+	ch.flushedRecords.Store(cachedRecords)
 
 	return len(recordsToExport), nil
 }
@@ -365,21 +413,65 @@ func (ch *ClickHouseExportProcess) pushRecordsToFrontOfQueue(records []*flowreco
 	}
 }
 
-func prepareConnection(config ClickHouseConfig) (*sql.DB, error) {
+// prepareConnection connects to ClickHouse, and returns the connection and the query to use to
+// insert flow records into the flows table.
+func prepareConnection(config ClickHouseConfig) (*sql.DB, string, error) {
 	connect, err := ConnectClickHouse(&config)
 	if err != nil {
-		return nil, fmt.Errorf("error when connecting to ClickHouse, %w", err)
+		return nil, "", fmt.Errorf("error when connecting to ClickHouse, %w", err)
+	}
+	query, err := getInsertQuery(context.TODO(), connect)
+	if err != nil {
+		return nil, "", err
 	}
 	// Test open Transaction
 	tx, err := connect.Begin()
 	if err == nil {
-		_, err = tx.Prepare(insertQuery)
+		_, err = tx.Prepare(query)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error when preparing insert statement, %v", err)
+		return nil, "", fmt.Errorf("error when preparing insert statement, %v", err)
 	}
 	_ = tx.Commit()
-	return connect, err
+	return connect, query, err
+}
+
+// getInsertQuery returns the query to insert flow records, according to the columns of the
+// flows table. If the table was created before the columns in flowOwnerColumns were added,
+// and has not been upgraded, the values of these columns are not exported.
+func getInsertQuery(ctx context.Context, connect *sql.DB) (string, error) {
+	rows, err := connect.QueryContext(ctx, describeQuery)
+	if err != nil {
+		return "", fmt.Errorf("error when describing flows table: %w", err)
+	}
+	defer rows.Close()
+	fields, err := rows.Columns()
+	if err != nil {
+		return "", fmt.Errorf("error when describing flows table: %w", err)
+	}
+	// The first field is the column name, the others (type, default expression, etc.) are ignored.
+	var column string
+	dest := make([]any, len(fields))
+	dest[0] = &column
+	for i := 1; i < len(dest); i++ {
+		dest[i] = new(any)
+	}
+	columns := sets.New[string]()
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return "", fmt.Errorf("error when describing flows table: %w", err)
+		}
+		columns.Insert(column)
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error when describing flows table: %w", err)
+	}
+	if !columns.HasAll(flowOwnerColumns...) {
+		klog.InfoS("The flows table is missing some columns, the Pod owners and destination FQDN will not be exported to ClickHouse until the table is upgraded",
+			"missingColumns", sets.List(sets.New(flowOwnerColumns...).Difference(columns)))
+		return legacyInsertQuery, nil
+	}
+	return insertQuery, nil
 }
 
 func (ch *ClickHouseExportProcess) UpdateCH(config ClickHouseConfig, connect *sql.DB) {
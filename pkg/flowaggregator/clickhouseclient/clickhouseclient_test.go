@@ -18,6 +18,7 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 
@@ -59,6 +60,7 @@ func TestBatchCommitAll(t *testing.T) {
 
 	chExportProc := &ClickHouseExportProcess{
 		db:          db,
+		insertQuery: insertQuery,
 		queueSize:   maxQueueSize,
 		clusterUUID: fakeClusterUUID,
 	}
@@ -120,7 +122,12 @@ func TestBatchCommitAll(t *testing.T) {
 			fakeClusterUUID,
 			"test-egress",
 			"172.18.0.1",
-			"test-egress-node").
+			"test-egress-node",
+			"Deployment",
+			"perftest-client",
+			"StatefulSet",
+			"perftest-server",
+			"perftest.example.com").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
@@ -137,8 +144,9 @@ func TestBatchCommitAllMultiRecord(t *testing.T) {
 	defer db.Close()
 
 	chExportProc := &ClickHouseExportProcess{
-		db:        db,
-		queueSize: maxQueueSize,
+		db:          db,
+		insertQuery: insertQuery,
+		queueSize:   maxQueueSize,
 	}
 	recordRow := flowrecord.FlowRecord{}
 	fieldCount := reflect.TypeOf(recordRow).NumField() + 1
@@ -168,8 +176,9 @@ func TestBatchCommitAllError(t *testing.T) {
 	defer db.Close()
 
 	chExportProc := &ClickHouseExportProcess{
-		db:        db,
-		queueSize: maxQueueSize,
+		db:          db,
+		insertQuery: insertQuery,
+		queueSize:   maxQueueSize,
 	}
 	recordRow := flowrecord.FlowRecord{}
 	chExportProc.deque.PushBack(&recordRow)
@@ -191,6 +200,152 @@ func TestBatchCommitAllError(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet(), "unfulfilled expectations for db sql operation")
 }
 
+func TestFlushedRecordCount(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err, "error when opening a stub database connection")
+	defer db.Close()
+
+	chExportProc := &ClickHouseExportProcess{
+		db:          db,
+		insertQuery: insertQuery,
+		queueSize:   maxQueueSize,
+	}
+	require.NoError(t, chExportProc.CacheRecord(flowaggregatortesting.PrepareTestFlowRecord(true)))
+	require.NoError(t, chExportProc.CacheRecord(flowaggregatortesting.PrepareTestFlowRecord(false)))
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(insertQuery).ExpectExec().WillReturnError(fmt.Errorf("mock error for sql stmt exec"))
+	mock.ExpectRollback()
+	_, err = chExportProc.batchCommitAll(t.Context())
+	require.Error(t, err)
+	assert.Zero(t, chExportProc.FlushedRecordCount())
+
+	mock.ExpectBegin()
+	expected := mock.ExpectPrepare(insertQuery)
+	expected.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+	expected.ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	count, err := chExportProc.batchCommitAll(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.EqualValues(t, 2, chExportProc.FlushedRecordCount())
+	assert.NoError(t, mock.ExpectationsWereMet(), "unfulfilled expectations for db sql operation")
+}
+
+func TestHealthy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err, "error when opening a stub database connection")
+	defer db.Close()
+
+	chExportProc := &ClickHouseExportProcess{
+		db:          db,
+		insertQuery: insertQuery,
+		config:      ClickHouseConfig{CommitInterval: 10 * time.Millisecond},
+		queueSize:   maxQueueSize,
+	}
+	require.NoError(t, chExportProc.CacheRecord(flowaggregatortesting.PrepareTestFlowRecord(true)))
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("mock error for sql begin"))
+
+	chExportProc.Start()
+	defer chExportProc.stopExportProcess(false)
+	assert.Eventually(t, func() bool {
+		return !chExportProc.Healthy()
+	}, 1*time.Second, 10*time.Millisecond)
+	require.NoError(t, mock.ExpectationsWereMet(), "unfulfilled expectations for db sql operation")
+
+	// Commits without any record do not make the export process healthy again.
+	chExportProc.dequeMutex.Lock()
+	chExportProc.deque.Clear()
+	chExportProc.dequeMutex.Unlock()
+	assert.Never(t, chExportProc.Healthy, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestBatchCommitAllLegacyTable(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err, "error when opening a stub database connection")
+	defer db.Close()
+
+	chExportProc := &ClickHouseExportProcess{
+		db:          db,
+		queueSize:   maxQueueSize,
+		insertQuery: legacyInsertQuery,
+	}
+	recordRow := flowrecord.FlowRecord{}
+	chExportProc.deque.PushBack(&recordRow)
+	// The values of the columns missing in the table are not exported.
+	argList := make([]driver.Value, len(flowColumns)-len(flowOwnerColumns))
+	for i := 0; i < len(argList); i++ {
+		argList[i] = sqlmock.AnyArg()
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(legacyInsertQuery).ExpectExec().WithArgs(argList...).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	count, err := chExportProc.batchCommitAll(t.Context())
+	assert.NoError(t, err, "error occurred when committing record with mock sql db")
+	assert.Equal(t, 1, count)
+	assert.NoError(t, mock.ExpectationsWereMet(), "unfulfilled expectations for db sql operation")
+}
+
+func TestGetInsertQuery(t *testing.T) {
+	legacyColumns := []string{"flowStartSeconds", "flowEndSeconds", "egressNodeName"}
+	for _, tc := range []struct {
+		name          string
+		columns       []string
+		queryErr      error
+		expectedQuery string
+		expectedErr   bool
+	}{
+		{
+			name:          "upgraded table",
+			columns:       append(slices.Clone(legacyColumns), flowOwnerColumns...),
+			expectedQuery: insertQuery,
+		},
+		{
+			name:          "legacy table",
+			columns:       legacyColumns,
+			expectedQuery: legacyInsertQuery,
+		},
+		{
+			name:          "partially upgraded table",
+			columns:       append(slices.Clone(legacyColumns), "sourcePodOwnerKind", "sourcePodOwnerName"),
+			expectedQuery: legacyInsertQuery,
+		},
+		{
+			name:        "query error",
+			queryErr:    fmt.Errorf("table doesn't exist"),
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err, "error when opening a stub database connection")
+			defer db.Close()
+
+			expectedQuery := mock.ExpectQuery(describeQuery)
+			if tc.queryErr != nil {
+				expectedQuery.WillReturnError(tc.queryErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"name", "type", "default_type"})
+				for _, column := range tc.columns {
+					rows.AddRow(column, "String", "")
+				}
+				expectedQuery.WillReturnRows(rows)
+			}
+
+			query, err := getInsertQuery(t.Context(), db)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedQuery, query)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet(), "unfulfilled expectations for db sql operation")
+		})
+	}
+}
+
 func TestPushRecordsToFrontOfQueue(t *testing.T) {
 	chExportProc := &ClickHouseExportProcess{
 		queueSize: 4,
@@ -213,6 +368,7 @@ func TestPushRecordsToFrontOfQueue(t *testing.T) {
 	assert.Equal(t, records[1], chExportProc.deque.At(0), "deque has wrong item at index 0")
 	assert.Equal(t, records[2], chExportProc.deque.At(1), "deque has wrong item at index 1")
 	assert.Equal(t, records[0], chExportProc.deque.At(2), "deque has wrong item at index 2")
+	assert.Equal(t, 1, chExportProc.AvailableCapacity())
 
 	// only newest items should be pushed to front of deque if hitting capacity.
 	// deque before [1,2,0], cap: 4
@@ -225,6 +381,7 @@ func TestPushRecordsToFrontOfQueue(t *testing.T) {
 	assert.Equal(t, records[1], chExportProc.deque.At(1), "deque has wrong item at index 1")
 	assert.Equal(t, records[2], chExportProc.deque.At(2), "deque has wrong item at index 2")
 	assert.Equal(t, records[0], chExportProc.deque.At(3), "deque has wrong item at index 3")
+	assert.Equal(t, 0, chExportProc.AvailableCapacity())
 }
 
 func TestFlushCacheOnStop(t *testing.T) {
@@ -236,9 +393,10 @@ func TestFlushCacheOnStop(t *testing.T) {
 	const commitInterval = time.Hour
 
 	chExportProc := &ClickHouseExportProcess{
-		db:        db,
-		config:    ClickHouseConfig{CommitInterval: commitInterval},
-		queueSize: maxQueueSize,
+		db:          db,
+		insertQuery: insertQuery,
+		config:      ClickHouseConfig{CommitInterval: commitInterval},
+		queueSize:   maxQueueSize,
 	}
 
 	recordRow := flowrecordtesting.PrepareTestFlowRecord()
@@ -270,9 +428,10 @@ func TestUpdateCH(t *testing.T) {
 	const commitInterval = 100 * time.Millisecond
 
 	chExportProc := &ClickHouseExportProcess{
-		db:        db1,
-		config:    ClickHouseConfig{CommitInterval: commitInterval},
-		queueSize: maxQueueSize,
+		db:          db1,
+		insertQuery: insertQuery,
+		config:      ClickHouseConfig{CommitInterval: commitInterval},
+		queueSize:   maxQueueSize,
 	}
 
 	recordRow := flowrecordtesting.PrepareTestFlowRecord()
@@ -34,6 +34,8 @@ import (
 type ClickHouseExporter struct {
 	chConfig        *clickhouseclient.ClickHouseConfig
 	chExportProcess *clickhouseclient.ClickHouseExportProcess
+	// spooler is nil if the spool is disabled.
+	spooler *recordSpooler
 }
 
 const (
@@ -81,9 +83,17 @@ func NewClickHouseExporter(clusterUUID uuid.UUID, opt *options.Options) (*ClickH
 	if err != nil {
 		return nil, err
 	}
+	var spooler *recordSpooler
+	if opt.Config.ClickHouse.Spool.Enable {
+		spooler, err = newRecordSpooler("clickhouse", chExportProcess, &opt.Config.ClickHouse.Spool, opt.ClickHouseSpoolMaxSize)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &ClickHouseExporter{
 		chConfig:        &chConfig,
 		chExportProcess: chExportProcess,
+		spooler:         spooler,
 	}, nil
 }
 
@@ -92,14 +102,25 @@ func NewClickHouseExporter(clusterUUID uuid.UUID, opt *options.Options) (*ClickH
 func (e *ClickHouseExporter) Run(ctx context.Context, buf ringbuffer.BroadcastBuffer[*flowpb.Flow]) {
 	consumer := buf.NewConsumer(ringbuffer.WithMaxConsumeDeadline(consumeDeadline))
 	e.chExportProcess.Start()
-	defer e.chExportProcess.Stop()
+	defer func() {
+		e.chExportProcess.Stop()
+		// The spooler must be closed after the export process has been stopped, so that
+		// the records which could not be written are spooled.
+		if e.spooler != nil {
+			e.spooler.close()
+		}
+	}()
 
 	records := make([]*flowpb.Flow, consumeMultipleBatchSize)
 	for {
 		n, _, shutdown := consumer.ConsumeMultiple(records)
-		for _, record := range records[:n] {
-			if err := e.chExportProcess.CacheRecord(record); err != nil {
-				klog.ErrorS(err, "Error when caching record for ClickHouse")
+		if e.spooler != nil {
+			e.spooler.cacheRecords(records[:n])
+		} else {
+			for _, record := range records[:n] {
+				if err := e.chExportProcess.CacheRecord(record); err != nil {
+					klog.ErrorS(err, "Error when caching record for ClickHouse")
+				}
 			}
 		}
 		if shutdown || ctx.Err() != nil {
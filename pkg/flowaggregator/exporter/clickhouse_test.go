@@ -32,8 +32,8 @@ func TestClickHouse_NewClickHouseExporter(t *testing.T) {
 	t.Setenv("CH_USERNAME", "default")
 	t.Setenv("CH_PASSWORD", "default")
 	PrepareClickHouseConnectionSaved := clickhouseclient.PrepareClickHouseConnection
-	clickhouseclient.PrepareClickHouseConnection = func(input clickhouseclient.ClickHouseConfig) (*sql.DB, error) {
-		return nil, nil
+	clickhouseclient.PrepareClickHouseConnection = func(input clickhouseclient.ClickHouseConfig) (*sql.DB, string, error) {
+		return nil, "", nil
 	}
 	defer func() {
 		clickhouseclient.PrepareClickHouseConnection = PrepareClickHouseConnectionSaved
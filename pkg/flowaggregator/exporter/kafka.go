@@ -0,0 +1,241 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/xdg-go/scram"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+
+	flowpb "antrea.io/antrea/v2/pkg/apis/flow/v1alpha1"
+	flowaggregatorconfig "antrea.io/antrea/v2/pkg/config/flowaggregator"
+	"antrea.io/antrea/v2/pkg/flowaggregator/options"
+	"antrea.io/antrea/v2/pkg/flowaggregator/ringbuffer"
+)
+
+const (
+	kafkaCertDir  = "/etc/flow-aggregator/certs/kafka"
+	kafkaClientID = "antrea-flow-aggregator"
+)
+
+// this is used for unit testing
+var newKafkaProducer = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+	return sarama.NewSyncProducer(brokers, config)
+}
+
+type KafkaExporter struct {
+	config       flowaggregatorconfig.KafkaConfig
+	saramaConfig *sarama.Config
+}
+
+func NewKafkaExporter(opt *options.Options) (*KafkaExporter, error) {
+	config := opt.Config.Kafka
+	klog.InfoS("Kafka configuration", "brokers", config.Brokers, "topic", config.Topic, "recordFormat", config.RecordFormat, "partitionKey", config.PartitionKey,
+		"compression", config.Compression, "tls", config.TLS.Enable, "sasl", config.SASL.Enable, "saslMechanism", config.SASL.Mechanism)
+	saramaConfig, err := buildSaramaConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaExporter{
+		config:       config,
+		saramaConfig: saramaConfig,
+	}, nil
+}
+
+func buildSaramaConfig(config flowaggregatorconfig.KafkaConfig) (*sarama.Config, error) {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = kafkaClientID
+	// Required by the SyncProducer.
+	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	// The default partitioner hashes the message key, so that all the records with the same
+	// key are published to the same partition.
+	saramaConfig.Producer.Partitioner = sarama.NewHashPartitioner
+	if err := saramaConfig.Producer.Compression.UnmarshalText([]byte(config.Compression)); err != nil {
+		return nil, err
+	}
+	if config.TLS.Enable {
+		tlsConfig, err := buildKafkaTLSConfig(config.TLS)
+		if err != nil {
+			return nil, err
+		}
+		saramaConfig.Net.TLS.Enable = true
+		saramaConfig.Net.TLS.Config = tlsConfig
+	}
+	if config.SASL.Enable {
+		saramaConfig.Net.SASL.Enable = true
+		saramaConfig.Net.SASL.User = os.Getenv("KAFKA_USERNAME")
+		saramaConfig.Net.SASL.Password = os.Getenv("KAFKA_PASSWORD")
+		if saramaConfig.Net.SASL.User == "" {
+			return nil, fmt.Errorf("SASL is enabled for Kafka but the KAFKA_USERNAME environment variable is not set")
+		}
+		switch config.SASL.Mechanism {
+		case "SCRAM-SHA-256":
+			saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+			saramaConfig.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &scramClient{hashGenerator: scram.SHA256}
+			}
+		case "SCRAM-SHA-512":
+			saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+			saramaConfig.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &scramClient{hashGenerator: scram.SHA512}
+			}
+		default:
+			saramaConfig.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		}
+	}
+	if err := saramaConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid Kafka configuration: %w", err)
+	}
+	return saramaConfig, nil
+}
+
+func buildKafkaTLSConfig(config flowaggregatorconfig.KafkaTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+		// config.MinVersion has already been validated during FA config validation.
+		MinVersion: options.TLSVersionOrDie(config.MinVersion),
+	}
+	if err := loadTLSCertificates(tlsConfig, kafkaCertDir, config.CASecretName, config.ClientSecretName); err != nil {
+		return nil, err
+	}
+	return tlsConfig, nil
+}
+
+// scramClient implements the sarama.SCRAMClient interface.
+type scramClient struct {
+	*scram.ClientConversation
+	hashGenerator scram.HashGeneratorFcn
+}
+
+func (c *scramClient) Begin(userName, password, authzID string) error {
+	client, err := c.hashGenerator.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	c.ClientConversation = client.NewConversation()
+	return nil
+}
+
+// Run consumes flow records from the ring buffer and publishes them to Kafka.
+// It blocks until ctx is cancelled or the consumer signals shutdown.
+func (e *KafkaExporter) Run(ctx context.Context, buf ringbuffer.BroadcastBuffer[*flowpb.Flow]) {
+	// The consumer is created first, so that the records received while connecting to the
+	// brokers are buffered.
+	consumer := buf.NewConsumer(ringbuffer.WithMaxConsumeDeadline(consumeDeadline))
+	producer := e.connect(ctx)
+	if producer == nil {
+		return
+	}
+	defer func() {
+		if err := producer.Close(); err != nil {
+			klog.ErrorS(err, "Error when closing Kafka producer")
+		}
+	}()
+
+	records := make([]*flowpb.Flow, consumeMultipleBatchSize)
+	messages := make([]*sarama.ProducerMessage, 0, consumeMultipleBatchSize)
+	for {
+		n, _, shutdown := consumer.ConsumeMultiple(records)
+		messages = messages[:0]
+		for _, record := range records[:n] {
+			msg, err := e.buildMessage(record)
+			if err != nil {
+				klog.ErrorS(err, "Error when serializing record for Kafka")
+				continue
+			}
+			messages = append(messages, msg)
+		}
+		if len(messages) > 0 {
+			if err := producer.SendMessages(messages); err != nil {
+				var producerErrs sarama.ProducerErrors
+				if errors.As(err, &producerErrs) {
+					klog.ErrorS(producerErrs[0].Err, "Error when publishing records to Kafka", "topic", e.config.Topic, "failed", len(producerErrs), "total", len(messages))
+				} else {
+					klog.ErrorS(err, "Error when publishing records to Kafka", "topic", e.config.Topic)
+				}
+			}
+		}
+		if shutdown || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// connect creates the Kafka producer, retrying with backoff until it succeeds. It returns nil
+// if ctx is cancelled first.
+func (e *KafkaExporter) connect(ctx context.Context) sarama.SyncProducer {
+	backoff := newInitBackoff()
+	for {
+		producer, err := newKafkaProducer(e.config.Brokers, e.saramaConfig)
+		if err == nil {
+			klog.InfoS("Connected to Kafka brokers", "brokers", e.config.Brokers)
+			return producer
+		}
+		retryAfter := backoff.Step()
+		klog.ErrorS(err, "Error when connecting to Kafka brokers", "brokers", e.config.Brokers, "retryAfter", retryAfter)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retryAfter):
+		}
+	}
+}
+
+func (e *KafkaExporter) buildMessage(record *flowpb.Flow) (*sarama.ProducerMessage, error) {
+	var value []byte
+	var err error
+	if e.config.RecordFormat == "JSON" {
+		value, err = protojson.Marshal(record)
+	} else {
+		value, err = proto.Marshal(record)
+	}
+	if err != nil {
+		return nil, err
+	}
+	msg := &sarama.ProducerMessage{
+		Topic: e.config.Topic,
+		Value: sarama.ByteEncoder(value),
+	}
+	// Messages without a key are distributed randomly across partitions.
+	if key := e.messageKey(record); key != "" {
+		msg.Key = sarama.StringEncoder(key)
+	}
+	return msg, nil
+}
+
+func (e *KafkaExporter) messageKey(record *flowpb.Flow) string {
+	if e.config.PartitionKey == "Namespace" {
+		if namespace := record.GetK8S().GetSourcePodNamespace(); namespace != "" {
+			return namespace
+		}
+		return record.GetK8S().GetDestinationPodNamespace()
+	}
+	return fmt.Sprintf("%s-%s-%d",
+		net.JoinHostPort(net.IP(record.GetIp().GetSource()).String(), fmt.Sprint(record.GetTransport().GetSourcePort())),
+		net.JoinHostPort(net.IP(record.GetIp().GetDestination()).String(), fmt.Sprint(record.GetTransport().GetDestinationPort())),
+		record.GetTransport().GetProtocolNumber())
+}
@@ -0,0 +1,305 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	flowpb "antrea.io/antrea/v2/pkg/apis/flow/v1alpha1"
+	flowaggregatorconfig "antrea.io/antrea/v2/pkg/config/flowaggregator"
+	"antrea.io/antrea/v2/pkg/flowaggregator/options"
+	"antrea.io/antrea/v2/pkg/flowaggregator/ringbuffer"
+	flowaggregatortesting "antrea.io/antrea/v2/pkg/flowaggregator/testing"
+)
+
+func newTestKafkaOptions(brokers ...string) *options.Options {
+	opt := &options.Options{
+		Config: &flowaggregatorconfig.FlowAggregatorConfig{
+			Kafka: flowaggregatorconfig.KafkaConfig{
+				Enable:  true,
+				Brokers: brokers,
+			},
+		},
+	}
+	flowaggregatorconfig.SetConfigDefaults(opt.Config)
+	return opt
+}
+
+func TestKafkaExporter_buildMessage(t *testing.T) {
+	testCases := []struct {
+		name         string
+		isIPv4       bool
+		recordFormat string
+		partitionKey string
+		expectedKey  string
+	}{
+		{
+			name:         "protobuf with flow key",
+			isIPv4:       true,
+			recordFormat: "Protobuf",
+			partitionKey: "FlowKey",
+			expectedKey:  "10.10.0.79:44752-10.10.0.80:5201-6",
+		},
+		{
+			name:         "protobuf with IPv6 flow key",
+			isIPv4:       false,
+			recordFormat: "Protobuf",
+			partitionKey: "FlowKey",
+			expectedKey:  "[2001:0:3238:dfe1:63::fefb]:44752-[2001:0:3238:dfe1:63::fefc]:5201-6",
+		},
+		{
+			name:         "json with namespace",
+			isIPv4:       true,
+			recordFormat: "JSON",
+			partitionKey: "Namespace",
+			expectedKey:  "antrea-test",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := newTestKafkaOptions("127.0.0.1:9092")
+			opt.Config.Kafka.RecordFormat = tc.recordFormat
+			opt.Config.Kafka.PartitionKey = tc.partitionKey
+			exp, err := NewKafkaExporter(opt)
+			require.NoError(t, err)
+
+			record := flowaggregatortesting.PrepareTestFlowRecord(tc.isIPv4)
+			msg, err := exp.buildMessage(record)
+			require.NoError(t, err)
+			assert.Equal(t, flowaggregatorconfig.DefaultKafkaTopic, msg.Topic)
+			assert.Equal(t, sarama.StringEncoder(tc.expectedKey), msg.Key)
+
+			value, err := msg.Value.Encode()
+			require.NoError(t, err)
+			decoded := &flowpb.Flow{}
+			if tc.recordFormat == "JSON" {
+				require.NoError(t, protojson.Unmarshal(value, decoded))
+			} else {
+				require.NoError(t, proto.Unmarshal(value, decoded))
+			}
+			assert.True(t, proto.Equal(record, decoded))
+		})
+	}
+
+	t.Run("namespace of destination Pod", func(t *testing.T) {
+		opt := newTestKafkaOptions("127.0.0.1:9092")
+		opt.Config.Kafka.PartitionKey = "Namespace"
+		exp, err := NewKafkaExporter(opt)
+		require.NoError(t, err)
+		record := flowaggregatortesting.PrepareTestFlowRecord(true)
+		record.K8S.SourcePodNamespace = ""
+		msg, err := exp.buildMessage(record)
+		require.NoError(t, err)
+		assert.Equal(t, sarama.StringEncoder("antrea-test-b"), msg.Key)
+		// Without any Namespace, the message has no key.
+		record.K8S.DestinationPodNamespace = ""
+		msg, err = exp.buildMessage(record)
+		require.NoError(t, err)
+		assert.Nil(t, msg.Key)
+	})
+}
+
+func TestBuildSaramaConfig(t *testing.T) {
+	t.Run("compression", func(t *testing.T) {
+		config := newTestKafkaOptions("127.0.0.1:9092").Config.Kafka
+		config.Compression = "zstd"
+		saramaConfig, err := buildSaramaConfig(config)
+		require.NoError(t, err)
+		assert.Equal(t, sarama.CompressionZSTD, saramaConfig.Producer.Compression)
+		assert.False(t, saramaConfig.Net.TLS.Enable)
+		assert.False(t, saramaConfig.Net.SASL.Enable)
+	})
+	t.Run("SASL SCRAM", func(t *testing.T) {
+		t.Setenv("KAFKA_USERNAME", "antrea")
+		t.Setenv("KAFKA_PASSWORD", "password")
+		config := newTestKafkaOptions("127.0.0.1:9092").Config.Kafka
+		config.SASL.Enable = true
+		config.SASL.Mechanism = "SCRAM-SHA-512"
+		saramaConfig, err := buildSaramaConfig(config)
+		require.NoError(t, err)
+		assert.True(t, saramaConfig.Net.SASL.Enable)
+		assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), saramaConfig.Net.SASL.Mechanism)
+		assert.Equal(t, "antrea", saramaConfig.Net.SASL.User)
+		assert.Equal(t, "password", saramaConfig.Net.SASL.Password)
+		client := saramaConfig.Net.SASL.SCRAMClientGeneratorFunc()
+		require.NoError(t, client.Begin("antrea", "password", ""))
+		firstMessage, err := client.Step("")
+		require.NoError(t, err)
+		assert.Contains(t, firstMessage, "n=antrea")
+		assert.False(t, client.Done())
+	})
+	t.Run("SASL without username", func(t *testing.T) {
+		t.Setenv("KAFKA_USERNAME", "")
+		config := newTestKafkaOptions("127.0.0.1:9092").Config.Kafka
+		config.SASL.Enable = true
+		_, err := buildSaramaConfig(config)
+		assert.ErrorContains(t, err, "KAFKA_USERNAME")
+	})
+	t.Run("mTLS", func(t *testing.T) {
+		caCertPEM, _ := generateLocalhostCert(t, false)
+		clientCertPEM, clientKeyPEM := generateLocalhostCert(t, true)
+		defaultFS = afero.NewMemMapFs()
+		t.Cleanup(func() { defaultFS = afero.NewOsFs() })
+		require.NoError(t, afero.WriteFile(defaultFS, filepath.Join(kafkaCertDir, "ca.crt"), caCertPEM, 0644))
+		require.NoError(t, afero.WriteFile(defaultFS, filepath.Join(kafkaCertDir, "tls.crt"), clientCertPEM, 0644))
+		require.NoError(t, afero.WriteFile(defaultFS, filepath.Join(kafkaCertDir, "tls.key"), clientKeyPEM, 0644))
+		config := newTestKafkaOptions("127.0.0.1:9093").Config.Kafka
+		config.TLS = flowaggregatorconfig.KafkaTLSConfig{
+			Enable:           true,
+			CASecretName:     "kafka-ca",
+			ClientSecretName: "kafka-client",
+			ServerName:       "kafka.example.com",
+		}
+		saramaConfig, err := buildSaramaConfig(config)
+		require.NoError(t, err)
+		require.True(t, saramaConfig.Net.TLS.Enable)
+		tlsConfig := saramaConfig.Net.TLS.Config
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.Len(t, tlsConfig.Certificates, 1)
+		assert.Equal(t, "kafka.example.com", tlsConfig.ServerName)
+	})
+	t.Run("missing CA", func(t *testing.T) {
+		defaultFS = afero.NewMemMapFs()
+		t.Cleanup(func() { defaultFS = afero.NewOsFs() })
+		config := newTestKafkaOptions("127.0.0.1:9093").Config.Kafka
+		config.TLS = flowaggregatorconfig.KafkaTLSConfig{
+			Enable:       true,
+			CASecretName: "kafka-ca",
+		}
+		_, err := buildSaramaConfig(config)
+		assert.ErrorContains(t, err, `ensure Secret "kafka-ca" exists in this Namespace`)
+	})
+}
+
+func TestKafkaExporter_Run(t *testing.T) {
+	record := flowaggregatortesting.PrepareTestFlowRecord(true)
+	producer := mocks.NewSyncProducer(t, nil)
+	failedCh := make(chan struct{})
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndFail(func(msg *sarama.ProducerMessage) error {
+		close(failedCh)
+		return nil
+	}, sarama.ErrNotLeaderForPartition)
+	sentCh := make(chan struct{})
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		defer close(sentCh)
+		value, err := msg.Value.Encode()
+		if err != nil {
+			return err
+		}
+		decoded := &flowpb.Flow{}
+		if err := proto.Unmarshal(value, decoded); err != nil {
+			return err
+		}
+		assert.True(t, proto.Equal(record, decoded))
+		return nil
+	})
+
+	var attempts atomic.Int32
+	newKafkaProducerSaved := newKafkaProducer
+	t.Cleanup(func() { newKafkaProducer = newKafkaProducerSaved })
+	newKafkaProducer = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		assert.Equal(t, []string{"127.0.0.1:9092"}, brokers)
+		// The first connection attempt fails, to check that the exporter retries.
+		if attempts.Add(1) == 1 {
+			return nil, sarama.ErrOutOfBrokers
+		}
+		return producer, nil
+	}
+
+	exp, err := NewKafkaExporter(newTestKafkaOptions("127.0.0.1:9092"))
+	require.NoError(t, err)
+	buf := ringbuffer.NewBroadcastBuffer[*flowpb.Flow](8)
+	defer buf.Shutdown()
+	ctx, cancel := context.WithCancel(context.Background())
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		exp.Run(ctx, buf)
+	}()
+	// The consumer is created before connecting to the brokers, so the records produced once
+	// the producer has been created are guaranteed to be consumed.
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.EqualValues(c, 2, attempts.Load())
+	}, 5*time.Second, 100*time.Millisecond)
+	waitFor := func(ch <-chan struct{}) {
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "Record was not published")
+		}
+	}
+	buf.Produce(record)
+	waitFor(failedCh)
+	// A failure to publish a record does not prevent the next records from being published.
+	buf.Produce(record)
+	waitFor(sentCh)
+	// The mock producer reports an error for the expectations which have not been consumed
+	// when it is closed by Run.
+	cancel()
+	<-doneCh
+}
+
+func TestKafkaExporter_MockBroker(t *testing.T) {
+	const topic = "flows"
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockProduceResponse(t),
+	})
+
+	opt := newTestKafkaOptions(broker.Addr())
+	opt.Config.Kafka.Topic = topic
+	exp, err := NewKafkaExporter(opt)
+	require.NoError(t, err)
+	buf := ringbuffer.NewBroadcastBuffer[*flowpb.Flow](8)
+	defer buf.Shutdown()
+	ctx, cancel := context.WithCancel(context.Background())
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		exp.Run(ctx, buf)
+	}()
+	defer func() {
+		cancel()
+		<-doneCh
+	}()
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		// Records are produced until the consumer of the exporter has been created.
+		buf.Produce(flowaggregatortesting.PrepareTestFlowRecord(true))
+		var produceRequests int
+		for _, rr := range broker.History() {
+			if _, ok := rr.Request.(*sarama.ProduceRequest); ok {
+				produceRequests++
+			}
+		}
+		assert.Positive(c, produceRequests)
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,708 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"bytes"
+	gzipio "compress/gzip"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	flowpb "antrea.io/antrea/v2/pkg/apis/flow/v1alpha1"
+	flowaggregatorconfig "antrea.io/antrea/v2/pkg/config/flowaggregator"
+	"antrea.io/antrea/v2/pkg/flowaggregator/flowlogger"
+	"antrea.io/antrea/v2/pkg/flowaggregator/options"
+	"antrea.io/antrea/v2/pkg/flowaggregator/ringbuffer"
+	"antrea.io/antrea/v2/pkg/util/env"
+	"antrea.io/antrea/v2/pkg/util/ip"
+	"antrea.io/antrea/v2/pkg/version"
+)
+
+const (
+	otlpCertDir       = "/etc/flow-aggregator/certs/otlp"
+	otlpServiceName   = "antrea-flow-aggregator"
+	otlpScopeName     = "antrea.io/antrea/flow-aggregator"
+	otlpFlowEventName = "antrea.flow"
+	// otlpHTTPContentType is the content type for the binary Protobuf encoding of OTLP/HTTP.
+	otlpHTTPContentType = "application/x-protobuf"
+	// otlpHTTPMaxResponseSize bounds the size of the responses read from OTLP/HTTP receivers.
+	otlpHTTPMaxResponseSize = 1 << 20
+	// otlpHeadersEnvVar is the standard OpenTelemetry environment variable for the headers of
+	// OTLP export requests, which can be populated from a Secret to provide credentials.
+	otlpHeadersEnvVar = "OTEL_EXPORTER_OTLP_HEADERS"
+	// The counters of a workload pair are no longer exported when they have not been updated
+	// for that many export intervals.
+	otlpMetricsStaleIntervals = 10
+)
+
+type OTLPExporter struct {
+	config                flowaggregatorconfig.OTLPConfig
+	headers               map[string]string
+	tlsConfig             *tls.Config
+	timeout               time.Duration
+	metricsExportInterval time.Duration
+	resource              *resourcepb.Resource
+	scope                 *commonpb.InstrumentationScope
+	// metrics is nil when metrics are disabled.
+	metrics *otlpWorkloadPairMetrics
+	clock   clock.WithTicker
+}
+
+func NewOTLPExporter(clusterUUID uuid.UUID, opt *options.Options) (*OTLPExporter, error) {
+	config := opt.Config.OTLP
+	klog.InfoS("OTLP configuration", "endpoint", config.Endpoint, "protocol", config.Protocol, "compress", *config.Compress, "timeout", opt.OTLPTimeout,
+		"tls", config.TLS.Enable, "metrics", config.Metrics.Enable, "metricsExportInterval", opt.OTLPMetricsExportInterval)
+	headers, err := parseOTLPHeaders(os.Getenv(otlpHeadersEnvVar))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s environment variable: %w", otlpHeadersEnvVar, err)
+	}
+	// Headers from the configuration take precedence.
+	for k, v := range config.Headers {
+		headers[k] = v
+	}
+	var tlsConfig *tls.Config
+	if config.TLS.Enable {
+		tlsConfig = &tls.Config{
+			ServerName:         config.TLS.ServerName,
+			InsecureSkipVerify: config.TLS.InsecureSkipVerify,
+			// config.TLS.MinVersion has already been validated during FA config validation.
+			MinVersion: options.TLSVersionOrDie(config.TLS.MinVersion),
+		}
+		if err := loadTLSCertificates(tlsConfig, otlpCertDir, config.TLS.CASecretName, config.TLS.ClientSecretName); err != nil {
+			return nil, err
+		}
+	}
+	e := &OTLPExporter{
+		config:                config,
+		headers:               headers,
+		tlsConfig:             tlsConfig,
+		timeout:               opt.OTLPTimeout,
+		metricsExportInterval: opt.OTLPMetricsExportInterval,
+		resource:              newOTLPResource(clusterUUID),
+		scope: &commonpb.InstrumentationScope{
+			Name:    otlpScopeName,
+			Version: version.GetFullVersion(),
+		},
+		clock: clock.RealClock{},
+	}
+	if config.Metrics.Enable {
+		e.metrics = newOTLPWorkloadPairMetrics()
+	}
+	return e, nil
+}
+
+// parseOTLPHeaders parses headers provided as a list of comma-separated key=value pairs, with
+// percent-encoded values, as specified for the OTEL_EXPORTER_OTLP_HEADERS environment variable.
+func parseOTLPHeaders(value string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, found := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !found || k == "" {
+			return nil, fmt.Errorf("invalid header %q", pair)
+		}
+		v, err := url.PathUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for header %q: %w", k, err)
+		}
+		headers[k] = v
+	}
+	return headers, nil
+}
+
+func newOTLPResource(clusterUUID uuid.UUID) *resourcepb.Resource {
+	attributes := []*commonpb.KeyValue{
+		otlpStringAttribute("service.name", otlpServiceName),
+		otlpStringAttribute("service.version", version.GetFullVersion()),
+		otlpStringAttribute("k8s.cluster.uid", clusterUUID.String()),
+	}
+	attributes = appendOTLPStringAttribute(attributes, "k8s.namespace.name", env.GetPodNamespace())
+	attributes = appendOTLPStringAttribute(attributes, "k8s.pod.name", env.GetPodName())
+	return &resourcepb.Resource{Attributes: attributes}
+}
+
+// Run consumes flow records from the ring buffer and exports them as OTLP log records. When
+// metrics are enabled, it also exports the counters derived from the flow records periodically.
+// It blocks until ctx is cancelled or the consumer signals shutdown.
+func (e *OTLPExporter) Run(ctx context.Context, buf ringbuffer.BroadcastBuffer[*flowpb.Flow]) {
+	consumer := buf.NewConsumer(ringbuffer.WithMaxConsumeDeadline(consumeDeadline))
+	client, err := newOTLPClient(e.config, e.headers, e.tlsConfig)
+	if err != nil {
+		klog.ErrorS(err, "Error when creating OTLP client")
+		return
+	}
+	defer func() {
+		if err := client.close(); err != nil {
+			klog.ErrorS(err, "Error when closing OTLP client")
+		}
+	}()
+
+	// metricsCh stays nil when metrics are disabled.
+	var metricsCh <-chan time.Time
+	if e.metrics != nil {
+		ticker := e.clock.NewTicker(e.metricsExportInterval)
+		defer ticker.Stop()
+		metricsCh = ticker.C()
+	}
+
+	records := make([]*flowpb.Flow, consumeMultipleBatchSize)
+	for {
+		n, _, shutdown := consumer.ConsumeMultiple(records)
+		if n > 0 {
+			e.exportLogs(client, records[:n])
+		}
+		select {
+		case <-metricsCh:
+			e.exportMetrics(client)
+		default:
+		}
+		if shutdown || ctx.Err() != nil {
+			if e.metrics != nil {
+				e.exportMetrics(client)
+			}
+			return
+		}
+	}
+}
+
+// exportLogs exports the records as a single request. The request is not bound to the context
+// passed to Run, so that the records consumed before the exporter is stopped are not dropped.
+func (e *OTLPExporter) exportLogs(client otlpClient, records []*flowpb.Flow) {
+	now := e.clock.Now()
+	logRecords := make([]*logspb.LogRecord, 0, len(records))
+	for _, record := range records {
+		logRecord, err := e.buildLogRecord(record, now)
+		if err != nil {
+			klog.ErrorS(err, "Error when converting record to OTLP log record")
+			continue
+		}
+		logRecords = append(logRecords, logRecord)
+		if e.metrics != nil {
+			e.metrics.update(record, now)
+		}
+	}
+	if len(logRecords) == 0 {
+		return
+	}
+	req := &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: e.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      e.scope,
+				LogRecords: logRecords,
+			}},
+		}},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	resp, err := client.exportLogs(ctx, req)
+	if err != nil {
+		klog.ErrorS(err, "Error when exporting log records to OTLP receiver", "endpoint", e.config.Endpoint, "records", len(logRecords))
+		return
+	}
+	if partialSuccess := resp.GetPartialSuccess(); partialSuccess.GetRejectedLogRecords() > 0 {
+		klog.ErrorS(nil, "OTLP receiver rejected some log records", "endpoint", e.config.Endpoint, "rejected", partialSuccess.GetRejectedLogRecords(),
+			"total", len(logRecords), "message", partialSuccess.GetErrorMessage())
+	}
+}
+
+func (e *OTLPExporter) exportMetrics(client otlpClient) {
+	metrics := e.metrics.collect(e.clock.Now(), otlpMetricsStaleIntervals*e.metricsExportInterval)
+	if len(metrics) == 0 {
+		return
+	}
+	req := &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: e.resource,
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Scope:   e.scope,
+				Metrics: metrics,
+			}},
+		}},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	resp, err := client.exportMetrics(ctx, req)
+	if err != nil {
+		klog.ErrorS(err, "Error when exporting metrics to OTLP receiver", "endpoint", e.config.Endpoint)
+		return
+	}
+	if partialSuccess := resp.GetPartialSuccess(); partialSuccess.GetRejectedDataPoints() > 0 {
+		klog.ErrorS(nil, "OTLP receiver rejected some data points", "endpoint", e.config.Endpoint, "rejected", partialSuccess.GetRejectedDataPoints(),
+			"message", partialSuccess.GetErrorMessage())
+	}
+}
+
+// buildLogRecord converts a flow record to an OTLP log record. The body of the log record is
+// the JSON encoding of the flow record, and the attributes follow the OpenTelemetry semantic
+// conventions where possible. As a flow involves 2 endpoints, the Kubernetes attributes (e.g.,
+// k8s.pod.name) are prefixed with "source." or "destination.".
+func (e *OTLPExporter) buildLogRecord(record *flowpb.Flow, observedTime time.Time) (*logspb.LogRecord, error) {
+	body, err := protojson.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	logRecord := &logspb.LogRecord{
+		ObservedTimeUnixNano: uint64(observedTime.UnixNano()),
+		SeverityNumber:       logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+		SeverityText:         "INFO",
+		EventName:            otlpFlowEventName,
+		Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: string(body)}},
+		Attributes:           otlpFlowAttributes(record),
+	}
+	if record.EndTs != nil {
+		logRecord.TimeUnixNano = uint64(record.EndTs.AsTime().UnixNano())
+	}
+	return logRecord, nil
+}
+
+func otlpFlowAttributes(record *flowpb.Flow) []*commonpb.KeyValue {
+	k8s := record.GetK8S()
+	var attributes []*commonpb.KeyValue
+	add := func(key, value string) {
+		attributes = appendOTLPStringAttribute(attributes, key, value)
+	}
+	addInt := func(key string, value uint64) {
+		attributes = append(attributes, otlpIntAttribute(key, int64(value)))
+	}
+
+	switch record.GetIp().GetVersion() {
+	case flowpb.IPVersion_IP_VERSION_4:
+		add("network.type", "ipv4")
+	case flowpb.IPVersion_IP_VERSION_6:
+		add("network.type", "ipv6")
+	}
+	protocolNumber := uint8(record.GetTransport().GetProtocolNumber())
+	add("network.transport", strings.ToLower(ip.IPProtocolNumberToString(protocolNumber, strconv.Itoa(int(protocolNumber)))))
+	add("source.address", otlpIPString(record.GetIp().GetSource()))
+	addInt("source.port", uint64(record.GetTransport().GetSourcePort()))
+	add("destination.address", otlpIPString(record.GetIp().GetDestination()))
+	addInt("destination.port", uint64(record.GetTransport().GetDestinationPort()))
+
+	add("source.k8s.namespace.name", k8s.GetSourcePodNamespace())
+	add("source.k8s.pod.name", k8s.GetSourcePodName())
+	add("source.k8s.pod.uid", k8s.GetSourcePodUid())
+	add("source.k8s.node.name", k8s.GetSourceNodeName())
+	add("source.k8s.node.uid", k8s.GetSourceNodeUid())
+	add("destination.k8s.namespace.name", k8s.GetDestinationPodNamespace())
+	add("destination.k8s.pod.name", k8s.GetDestinationPodName())
+	add("destination.k8s.pod.uid", k8s.GetDestinationPodUid())
+	add("destination.k8s.node.name", k8s.GetDestinationNodeName())
+	add("destination.k8s.node.uid", k8s.GetDestinationNodeUid())
+	attributes = appendOTLPWorkloadAttributes(attributes, "source", k8s.GetSourcePodOwnerKind(), k8s.GetSourcePodOwnerName())
+	attributes = appendOTLPWorkloadAttributes(attributes, "destination", k8s.GetDestinationPodOwnerKind(), k8s.GetDestinationPodOwnerName())
+
+	add("antrea.flow.type", otlpFlowType(k8s.GetFlowType()))
+	add("antrea.destination.service.address", otlpIPString(k8s.GetDestinationClusterIp()))
+	add("antrea.destination.service.port_name", k8s.GetDestinationServicePortName())
+	add("antrea.destination.fqdn", k8s.GetDestinationFqdn())
+	add("antrea.ingress_network_policy.type", flowlogger.PrettyPrintPolicyType(uint8(k8s.GetIngressNetworkPolicyType())))
+	add("antrea.ingress_network_policy.namespace", k8s.GetIngressNetworkPolicyNamespace())
+	add("antrea.ingress_network_policy.name", k8s.GetIngressNetworkPolicyName())
+	add("antrea.ingress_network_policy.rule_name", k8s.GetIngressNetworkPolicyRuleName())
+	add("antrea.ingress_network_policy.rule_action", flowlogger.PrettyPrintRuleAction(uint8(k8s.GetIngressNetworkPolicyRuleAction())))
+	add("antrea.egress_network_policy.type", flowlogger.PrettyPrintPolicyType(uint8(k8s.GetEgressNetworkPolicyType())))
+	add("antrea.egress_network_policy.namespace", k8s.GetEgressNetworkPolicyNamespace())
+	add("antrea.egress_network_policy.name", k8s.GetEgressNetworkPolicyName())
+	add("antrea.egress_network_policy.rule_name", k8s.GetEgressNetworkPolicyRuleName())
+	add("antrea.egress_network_policy.rule_action", flowlogger.PrettyPrintRuleAction(uint8(k8s.GetEgressNetworkPolicyRuleAction())))
+	add("antrea.egress.name", k8s.GetEgressName())
+	add("antrea.egress.address", otlpIPString(k8s.GetEgressIp()))
+	add("antrea.egress.k8s.node.name", k8s.GetEgressNodeName())
+	add("antrea.tcp.state", record.GetTransport().GetTCP().GetStateName())
+
+	addInt("antrea.flow.packet_total_count", record.GetStats().GetPacketTotalCount())
+	addInt("antrea.flow.packet_delta_count", record.GetStats().GetPacketDeltaCount())
+	addInt("antrea.flow.octet_total_count", record.GetStats().GetOctetTotalCount())
+	addInt("antrea.flow.octet_delta_count", record.GetStats().GetOctetDeltaCount())
+	addInt("antrea.flow.reverse_packet_total_count", record.GetReverseStats().GetPacketTotalCount())
+	addInt("antrea.flow.reverse_packet_delta_count", record.GetReverseStats().GetPacketDeltaCount())
+	addInt("antrea.flow.reverse_octet_total_count", record.GetReverseStats().GetOctetTotalCount())
+	addInt("antrea.flow.reverse_octet_delta_count", record.GetReverseStats().GetOctetDeltaCount())
+	return attributes
+}
+
+func otlpFlowType(flowType flowpb.FlowType) string {
+	switch flowType {
+	case flowpb.FlowType_FLOW_TYPE_INTRA_NODE:
+		return "IntraNode"
+	case flowpb.FlowType_FLOW_TYPE_INTER_NODE:
+		return "InterNode"
+	case flowpb.FlowType_FLOW_TYPE_TO_EXTERNAL:
+		return "ToExternal"
+	case flowpb.FlowType_FLOW_TYPE_FROM_EXTERNAL:
+		return "FromExternal"
+	default:
+		return ""
+	}
+}
+
+func otlpIPString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return net.IP(b).String()
+}
+
+func otlpStringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func otlpIntAttribute(key string, value int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: value}}}
+}
+
+// otlpWorkloadNameKeys maps workload kinds to the corresponding OpenTelemetry semantic
+// conventions attributes.
+var otlpWorkloadNameKeys = map[string]string{
+	"Pod":         "k8s.pod.name",
+	"Deployment":  "k8s.deployment.name",
+	"ReplicaSet":  "k8s.replicaset.name",
+	"StatefulSet": "k8s.statefulset.name",
+	"DaemonSet":   "k8s.daemonset.name",
+	"Job":         "k8s.job.name",
+	"CronJob":     "k8s.cronjob.name",
+}
+
+// appendOTLPWorkloadAttributes appends the kind and name of a workload, prefixed with "source."
+// or "destination.". For well-known kinds, the name is also set with the corresponding semantic
+// conventions attribute (e.g., k8s.deployment.name).
+func appendOTLPWorkloadAttributes(attributes []*commonpb.KeyValue, prefix, kind, name string) []*commonpb.KeyValue {
+	if kind == "" || name == "" {
+		return attributes
+	}
+	attributes = append(attributes,
+		otlpStringAttribute("antrea."+prefix+".workload.kind", kind),
+		otlpStringAttribute("antrea."+prefix+".workload.name", name))
+	if key, ok := otlpWorkloadNameKeys[kind]; ok {
+		attributes = append(attributes, otlpStringAttribute(prefix+"."+key, name))
+	}
+	return attributes
+}
+
+// appendOTLPStringAttribute appends the attribute only if value is not empty.
+func appendOTLPStringAttribute(attributes []*commonpb.KeyValue, key, value string) []*commonpb.KeyValue {
+	if value == "" {
+		return attributes
+	}
+	return append(attributes, otlpStringAttribute(key, value))
+}
+
+// otlpWorkload identifies the workload at one end of a flow: the owner of the Pod (e.g., a
+// Deployment) if available, or the Pod itself. Endpoints which are not Pods (e.g., external IPs)
+// are all represented by the zero value.
+type otlpWorkload struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+func newOTLPWorkload(namespace, podName, ownerKind, ownerName string) otlpWorkload {
+	if podName == "" {
+		return otlpWorkload{}
+	}
+	if ownerKind == "" || ownerName == "" {
+		return otlpWorkload{namespace: namespace, kind: "Pod", name: podName}
+	}
+	return otlpWorkload{namespace: namespace, kind: ownerKind, name: ownerName}
+}
+
+type otlpWorkloadPair struct {
+	source      otlpWorkload
+	destination otlpWorkload
+}
+
+type otlpWorkloadPairCounters struct {
+	octets         uint64
+	packets        uint64
+	reverseOctets  uint64
+	reversePackets uint64
+	startTime      time.Time
+	updateTime     time.Time
+}
+
+// otlpWorkloadPairMetrics derives cumulative byte and packet counters for each pair of source
+// and destination workloads from the delta counts of flow records. It is not thread-safe.
+type otlpWorkloadPairMetrics struct {
+	counters map[otlpWorkloadPair]*otlpWorkloadPairCounters
+}
+
+func newOTLPWorkloadPairMetrics() *otlpWorkloadPairMetrics {
+	return &otlpWorkloadPairMetrics{
+		counters: make(map[otlpWorkloadPair]*otlpWorkloadPairCounters),
+	}
+}
+
+func (m *otlpWorkloadPairMetrics) update(record *flowpb.Flow, now time.Time) {
+	k8s := record.GetK8S()
+	pair := otlpWorkloadPair{
+		source:      newOTLPWorkload(k8s.GetSourcePodNamespace(), k8s.GetSourcePodName(), k8s.GetSourcePodOwnerKind(), k8s.GetSourcePodOwnerName()),
+		destination: newOTLPWorkload(k8s.GetDestinationPodNamespace(), k8s.GetDestinationPodName(), k8s.GetDestinationPodOwnerKind(), k8s.GetDestinationPodOwnerName()),
+	}
+	counters, ok := m.counters[pair]
+	if !ok {
+		counters = &otlpWorkloadPairCounters{startTime: now}
+		m.counters[pair] = counters
+	}
+	counters.octets += record.GetStats().GetOctetDeltaCount()
+	counters.packets += record.GetStats().GetPacketDeltaCount()
+	counters.reverseOctets += record.GetReverseStats().GetOctetDeltaCount()
+	counters.reversePackets += record.GetReverseStats().GetPacketDeltaCount()
+	counters.updateTime = now
+}
+
+// collect returns the metrics for all the workload pairs, then stops tracking the pairs which
+// have not been updated for longer than staleTimeout. If such a pair is updated again later,
+// its counters restart from 0, with a new start time.
+func (m *otlpWorkloadPairMetrics) collect(now time.Time, staleTimeout time.Duration) []*metricspb.Metric {
+	if len(m.counters) == 0 {
+		return nil
+	}
+	octetsDataPoints := make([]*metricspb.NumberDataPoint, 0, 2*len(m.counters))
+	packetsDataPoints := make([]*metricspb.NumberDataPoint, 0, 2*len(m.counters))
+	for pair, counters := range m.counters {
+		transmitAttributes := pair.attributes("transmit")
+		receiveAttributes := pair.attributes("receive")
+		octetsDataPoints = append(octetsDataPoints,
+			otlpCounterDataPoint(transmitAttributes, counters.octets, counters.startTime, now),
+			otlpCounterDataPoint(receiveAttributes, counters.reverseOctets, counters.startTime, now))
+		packetsDataPoints = append(packetsDataPoints,
+			otlpCounterDataPoint(transmitAttributes, counters.packets, counters.startTime, now),
+			otlpCounterDataPoint(receiveAttributes, counters.reversePackets, counters.startTime, now))
+		if now.Sub(counters.updateTime) > staleTimeout {
+			delete(m.counters, pair)
+		}
+	}
+	return []*metricspb.Metric{
+		otlpCounterMetric("antrea.flow.octets", "By",
+			"Number of bytes exchanged between workloads, from the source to the destination (transmit) and from the destination to the source (receive).",
+			octetsDataPoints),
+		otlpCounterMetric("antrea.flow.packets", "{packet}",
+			"Number of packets exchanged between workloads, from the source to the destination (transmit) and from the destination to the source (receive).",
+			packetsDataPoints),
+	}
+}
+
+func (p *otlpWorkloadPair) attributes(direction string) []*commonpb.KeyValue {
+	var attributes []*commonpb.KeyValue
+	attributes = appendOTLPStringAttribute(attributes, "source.k8s.namespace.name", p.source.namespace)
+	attributes = appendOTLPWorkloadAttributes(attributes, "source", p.source.kind, p.source.name)
+	attributes = appendOTLPStringAttribute(attributes, "destination.k8s.namespace.name", p.destination.namespace)
+	attributes = appendOTLPWorkloadAttributes(attributes, "destination", p.destination.kind, p.destination.name)
+	return append(attributes, otlpStringAttribute("network.io.direction", direction))
+}
+
+func otlpCounterDataPoint(attributes []*commonpb.KeyValue, value uint64, startTime, now time.Time) *metricspb.NumberDataPoint {
+	return &metricspb.NumberDataPoint{
+		Attributes:        attributes,
+		StartTimeUnixNano: uint64(startTime.UnixNano()),
+		TimeUnixNano:      uint64(now.UnixNano()),
+		Value:             &metricspb.NumberDataPoint_AsInt{AsInt: int64(value)},
+	}
+}
+
+func otlpCounterMetric(name, unit, description string, dataPoints []*metricspb.NumberDataPoint) *metricspb.Metric {
+	return &metricspb.Metric{
+		Name:        name,
+		Unit:        unit,
+		Description: description,
+		Data: &metricspb.Metric_Sum{
+			Sum: &metricspb.Sum{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				IsMonotonic:            true,
+				DataPoints:             dataPoints,
+			},
+		},
+	}
+}
+
+// otlpClient sends export requests to an OTLP receiver.
+type otlpClient interface {
+	exportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error)
+	exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error)
+	close() error
+}
+
+// tlsConfig is nil when TLS is disabled.
+func newOTLPClient(config flowaggregatorconfig.OTLPConfig, headers map[string]string, tlsConfig *tls.Config) (otlpClient, error) {
+	if config.Protocol == "HTTP" {
+		return newOTLPHTTPClient(config, headers, tlsConfig), nil
+	}
+	return newOTLPGRPCClient(config, headers, tlsConfig)
+}
+
+type otlpGRPCClient struct {
+	conn          *grpc.ClientConn
+	logsClient    collogspb.LogsServiceClient
+	metricsClient colmetricspb.MetricsServiceClient
+	metadata      metadata.MD
+	callOptions   []grpc.CallOption
+}
+
+func newOTLPGRPCClient(config flowaggregatorconfig.OTLPConfig, headers map[string]string, tlsConfig *tls.Config) (*otlpGRPCClient, error) {
+	transportCredentials := insecure.NewCredentials()
+	if tlsConfig != nil {
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+	// The connection is established lazily, and re-established as needed.
+	conn, err := grpc.NewClient(config.Endpoint, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, err
+	}
+	var callOptions []grpc.CallOption
+	if *config.Compress {
+		callOptions = append(callOptions, grpc.UseCompressor(gzip.Name))
+	}
+	return &otlpGRPCClient{
+		conn:          conn,
+		logsClient:    collogspb.NewLogsServiceClient(conn),
+		metricsClient: colmetricspb.NewMetricsServiceClient(conn),
+		metadata:      metadata.New(headers),
+		callOptions:   callOptions,
+	}, nil
+}
+
+func (c *otlpGRPCClient) exportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	return c.logsClient.Export(metadata.NewOutgoingContext(ctx, c.metadata), req, c.callOptions...)
+}
+
+func (c *otlpGRPCClient) exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	return c.metricsClient.Export(metadata.NewOutgoingContext(ctx, c.metadata), req, c.callOptions...)
+}
+
+func (c *otlpGRPCClient) close() error {
+	return c.conn.Close()
+}
+
+// otlpHTTPClient implements OTLP/HTTP with the binary Protobuf encoding.
+type otlpHTTPClient struct {
+	client   *http.Client
+	baseURL  string
+	headers  map[string]string
+	compress bool
+}
+
+func newOTLPHTTPClient(config flowaggregatorconfig.OTLPConfig, headers map[string]string, tlsConfig *tls.Config) *otlpHTTPClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	scheme := "http"
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+		scheme = "https"
+	}
+	return &otlpHTTPClient{
+		client:   &http.Client{Transport: transport},
+		baseURL:  (&url.URL{Scheme: scheme, Host: config.Endpoint}).String(),
+		headers:  headers,
+		compress: *config.Compress,
+	}
+}
+
+func (c *otlpHTTPClient) exportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	resp := &collogspb.ExportLogsServiceResponse{}
+	if err := c.export(ctx, "/v1/logs", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *otlpHTTPClient) exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	resp := &colmetricspb.ExportMetricsServiceResponse{}
+	if err := c.export(ctx, "/v1/metrics", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *otlpHTTPClient) close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+func (c *otlpHTTPClient) export(ctx context.Context, path string, req, resp proto.Message) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if c.compress {
+		var b bytes.Buffer
+		gzipWriter := gzipio.NewWriter(&b)
+		if _, err := gzipWriter.Write(body); err != nil {
+			return err
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return err
+		}
+		body = b.Bytes()
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range c.headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Content-Type", otlpHTTPContentType)
+	if c.compress {
+		httpReq.Header.Set("Content-Encoding", "gzip")
+	}
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, otlpHTTPMaxResponseSize))
+	if err != nil {
+		return fmt.Errorf("error when reading response: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %q from OTLP receiver", httpResp.Status)
+	}
+	// The response may be empty, which is equivalent to a successful export.
+	if httpResp.Header.Get("Content-Type") == otlpHTTPContentType {
+		if err := proto.Unmarshal(respBody, resp); err != nil {
+			return fmt.Errorf("error when decoding response: %w", err)
+		}
+	}
+	return nil
+}
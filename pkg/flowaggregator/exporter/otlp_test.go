@@ -0,0 +1,438 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	flowpb "antrea.io/antrea/v2/pkg/apis/flow/v1alpha1"
+	flowaggregatorconfig "antrea.io/antrea/v2/pkg/config/flowaggregator"
+	"antrea.io/antrea/v2/pkg/flowaggregator/options"
+	"antrea.io/antrea/v2/pkg/flowaggregator/ringbuffer"
+	flowaggregatortesting "antrea.io/antrea/v2/pkg/flowaggregator/testing"
+)
+
+func newTestOTLPOptions(endpoint, protocol string) *options.Options {
+	opt := &options.Options{
+		Config: &flowaggregatorconfig.FlowAggregatorConfig{
+			OTLP: flowaggregatorconfig.OTLPConfig{
+				Enable:   true,
+				Endpoint: endpoint,
+				Protocol: protocol,
+			},
+		},
+		OTLPTimeout:               5 * time.Second,
+		OTLPMetricsExportInterval: 10 * time.Second,
+	}
+	flowaggregatorconfig.SetConfigDefaults(opt.Config)
+	return opt
+}
+
+// otlpAttributesToMap converts attributes to a map, to make assertions easier.
+func otlpAttributesToMap(attributes []*commonpb.KeyValue) map[string]interface{} {
+	m := make(map[string]interface{})
+	for _, kv := range attributes {
+		switch v := kv.Value.Value.(type) {
+		case *commonpb.AnyValue_StringValue:
+			m[kv.Key] = v.StringValue
+		case *commonpb.AnyValue_IntValue:
+			m[kv.Key] = v.IntValue
+		}
+	}
+	return m
+}
+
+func TestParseOTLPHeaders(t *testing.T) {
+	testCases := []struct {
+		name            string
+		value           string
+		expectedHeaders map[string]string
+		expectedErr     string
+	}{
+		{
+			name:            "empty",
+			value:           "",
+			expectedHeaders: map[string]string{},
+		},
+		{
+			name:  "multiple headers",
+			value: "Authorization=Bearer%20token, X-Tenant = antrea ,",
+			expectedHeaders: map[string]string{
+				"Authorization": "Bearer token",
+				"X-Tenant":      "antrea",
+			},
+		},
+		{
+			name:        "missing value",
+			value:       "Authorization",
+			expectedErr: "invalid header",
+		},
+		{
+			name:        "invalid encoding",
+			value:       "Authorization=%zz",
+			expectedErr: "invalid value for header",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			headers, err := parseOTLPHeaders(tc.value)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHeaders, headers)
+		})
+	}
+}
+
+func TestOTLPExporter_buildLogRecord(t *testing.T) {
+	for _, isIPv4 := range []bool{true, false} {
+		record := flowaggregatortesting.PrepareTestFlowRecord(isIPv4)
+		exp, err := NewOTLPExporter(uuid.New(), newTestOTLPOptions("127.0.0.1:4317", "gRPC"))
+		require.NoError(t, err)
+		observedTime := time.Unix(1637706980, 0)
+		logRecord, err := exp.buildLogRecord(record, observedTime)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(time.Unix(1637706973, 0).UnixNano()), logRecord.TimeUnixNano)
+		assert.Equal(t, uint64(observedTime.UnixNano()), logRecord.ObservedTimeUnixNano)
+		assert.Equal(t, otlpFlowEventName, logRecord.EventName)
+		decoded := &flowpb.Flow{}
+		require.NoError(t, protojson.Unmarshal([]byte(logRecord.Body.GetStringValue()), decoded))
+		assert.True(t, proto.Equal(record, decoded))
+
+		attributes := otlpAttributesToMap(logRecord.Attributes)
+		if isIPv4 {
+			assert.Equal(t, "ipv4", attributes["network.type"])
+			assert.Equal(t, "10.10.0.79", attributes["source.address"])
+			assert.Equal(t, "10.10.0.80", attributes["destination.address"])
+		} else {
+			assert.Equal(t, "ipv6", attributes["network.type"])
+			assert.Equal(t, "2001:0:3238:dfe1:63::fefb", attributes["source.address"])
+			assert.Equal(t, "2001:0:3238:dfe1:63::fefc", attributes["destination.address"])
+		}
+		assert.Equal(t, "tcp", attributes["network.transport"])
+		assert.Equal(t, int64(44752), attributes["source.port"])
+		assert.Equal(t, int64(5201), attributes["destination.port"])
+		assert.Equal(t, "antrea-test", attributes["source.k8s.namespace.name"])
+		assert.Equal(t, "perftest-a", attributes["source.k8s.pod.name"])
+		assert.Equal(t, "k8s-node-control-plane", attributes["source.k8s.node.name"])
+		assert.Equal(t, "antrea-test-b", attributes["destination.k8s.namespace.name"])
+		assert.Equal(t, "perftest-b", attributes["destination.k8s.pod.name"])
+		assert.Equal(t, "k8s-node-control-plane-b", attributes["destination.k8s.node.name"])
+		assert.Equal(t, "InterNode", attributes["antrea.flow.type"])
+		assert.Equal(t, "perftest", attributes["antrea.destination.service.port_name"])
+		assert.Equal(t, "perftest.example.com", attributes["antrea.destination.fqdn"])
+		assert.Equal(t, "Deployment", attributes["antrea.source.workload.kind"])
+		assert.Equal(t, "perftest-client", attributes["antrea.source.workload.name"])
+		assert.Equal(t, "perftest-client", attributes["source.k8s.deployment.name"])
+		assert.Equal(t, "StatefulSet", attributes["antrea.destination.workload.kind"])
+		assert.Equal(t, "perftest-server", attributes["antrea.destination.workload.name"])
+		assert.Equal(t, "perftest-server", attributes["destination.k8s.statefulset.name"])
+		assert.Equal(t, "K8sNetworkPolicy", attributes["antrea.ingress_network_policy.type"])
+		assert.Equal(t, "Drop", attributes["antrea.ingress_network_policy.rule_action"])
+		assert.Equal(t, "AntreaClusterNetworkPolicy", attributes["antrea.egress_network_policy.type"])
+		assert.Equal(t, "Allow", attributes["antrea.egress_network_policy.rule_action"])
+		assert.Equal(t, "test-egress", attributes["antrea.egress.name"])
+		assert.Equal(t, "TIME_WAIT", attributes["antrea.tcp.state"])
+		assert.Equal(t, int64(8982624938), attributes["antrea.flow.octet_delta_count"])
+		assert.Equal(t, int64(136211), attributes["antrea.flow.reverse_packet_delta_count"])
+		// Empty values are omitted.
+		assert.NotContains(t, attributes, "source.k8s.pod.uid")
+	}
+}
+
+func TestOTLPWorkloadPairMetrics(t *testing.T) {
+	startTime := time.Unix(1637706980, 0)
+	m := newOTLPWorkloadPairMetrics()
+	record := flowaggregatortesting.PrepareTestFlowRecord(true)
+	externalRecord := flowaggregatortesting.PrepareTestFlowRecord(true)
+	externalRecord.K8S.DestinationPodNamespace = ""
+	externalRecord.K8S.DestinationPodName = ""
+	externalRecord.K8S.DestinationPodOwnerKind = ""
+	externalRecord.K8S.DestinationPodOwnerName = ""
+	m.update(record, startTime)
+	m.update(record, startTime.Add(time.Second))
+	m.update(externalRecord, startTime)
+
+	getValues := func(metrics []*metricspb.Metric) map[string]map[string]int64 {
+		values := make(map[string]map[string]int64)
+		for _, metric := range metrics {
+			values[metric.Name] = make(map[string]int64)
+			assert.True(t, metric.GetSum().IsMonotonic)
+			assert.Equal(t, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, metric.GetSum().AggregationTemporality)
+			for _, dp := range metric.GetSum().DataPoints {
+				assert.Equal(t, uint64(startTime.UnixNano()), dp.StartTimeUnixNano)
+				attributes := otlpAttributesToMap(dp.Attributes)
+				// Pods are grouped by owner workload, which is missing for external destinations.
+				assert.Equal(t, "perftest-client", attributes["source.k8s.deployment.name"])
+				workloadName, _ := attributes["antrea.destination.workload.name"].(string)
+				key := workloadName + "/" + attributes["network.io.direction"].(string)
+				values[metric.Name][key] = dp.GetAsInt()
+			}
+		}
+		return values
+	}
+
+	metrics := m.collect(startTime.Add(10*time.Second), 30*time.Second)
+	assert.Equal(t, map[string]map[string]int64{
+		"antrea.flow.octets": {
+			"perftest-server/transmit": 2 * 8982624938,
+			"perftest-server/receive":  2 * 7083284,
+			"/transmit":                8982624938,
+			"/receive":                 7083284,
+		},
+		"antrea.flow.packets": {
+			"perftest-server/transmit": 2 * 241333,
+			"perftest-server/receive":  2 * 136211,
+			"/transmit":                241333,
+			"/receive":                 136211,
+		},
+	}, getValues(metrics))
+
+	// Stale pairs are exported one last time before being removed.
+	m.update(record, startTime.Add(20*time.Second))
+	metrics = m.collect(startTime.Add(40*time.Second), 30*time.Second)
+	values := getValues(metrics)
+	assert.Len(t, values["antrea.flow.octets"], 4)
+	assert.Equal(t, int64(3*8982624938), values["antrea.flow.octets"]["perftest-server/transmit"])
+	assert.Len(t, m.counters, 1)
+}
+
+type testOTLPLogsServer struct {
+	collogspb.UnimplementedLogsServiceServer
+	requestsCh chan *collogspb.ExportLogsServiceRequest
+	metadataCh chan metadata.MD
+}
+
+func (s *testOTLPLogsServer) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.metadataCh <- md
+	s.requestsCh <- req
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+type testOTLPMetricsServer struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	requestsCh chan *colmetricspb.ExportMetricsServiceRequest
+}
+
+func (s *testOTLPMetricsServer) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	s.requestsCh <- req
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func runOTLPExporter(t *testing.T, exp *OTLPExporter) (ringbuffer.BroadcastBuffer[*flowpb.Flow], context.CancelFunc) {
+	buf := ringbuffer.NewBroadcastBuffer[*flowpb.Flow](8)
+	ctx, cancel := context.WithCancel(context.Background())
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		exp.Run(ctx, buf)
+	}()
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cancel()
+			<-doneCh
+			buf.Shutdown()
+		})
+	}
+	t.Cleanup(stop)
+	return buf, stop
+}
+
+func TestOTLPExporter_RunGRPC(t *testing.T) {
+	logsServer := &testOTLPLogsServer{
+		requestsCh: make(chan *collogspb.ExportLogsServiceRequest, 100),
+		metadataCh: make(chan metadata.MD, 100),
+	}
+	metricsServer := &testOTLPMetricsServer{
+		requestsCh: make(chan *colmetricspb.ExportMetricsServiceRequest, 100),
+	}
+	server := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(server, logsServer)
+	colmetricspb.RegisterMetricsServiceServer(server, metricsServer)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	t.Setenv(otlpHeadersEnvVar, "Authorization=Bearer%20token,X-Tenant=default")
+	opt := newTestOTLPOptions(listener.Addr().String(), "gRPC")
+	opt.Config.OTLP.Headers = map[string]string{"X-Tenant": "antrea"}
+	opt.Config.OTLP.Metrics.Enable = true
+	clusterUUID := uuid.New()
+	exp, err := NewOTLPExporter(clusterUUID, opt)
+	require.NoError(t, err)
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	exp.clock = fakeClock
+	buf, stop := runOTLPExporter(t, exp)
+
+	record := flowaggregatortesting.PrepareTestFlowRecord(true)
+	var req *collogspb.ExportLogsServiceRequest
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		// Records are produced until the consumer of the exporter has been created.
+		buf.Produce(record)
+		select {
+		case req = <-logsServer.requestsCh:
+		default:
+		}
+		assert.NotNil(c, req)
+	}, 5*time.Second, 100*time.Millisecond)
+	md := <-logsServer.metadataCh
+	assert.Equal(t, []string{"Bearer token"}, md.Get("authorization"))
+	assert.Equal(t, []string{"antrea"}, md.Get("x-tenant"))
+	require.Len(t, req.ResourceLogs, 1)
+	resourceAttributes := otlpAttributesToMap(req.ResourceLogs[0].Resource.Attributes)
+	assert.Equal(t, otlpServiceName, resourceAttributes["service.name"])
+	assert.Equal(t, clusterUUID.String(), resourceAttributes["k8s.cluster.uid"])
+	require.Len(t, req.ResourceLogs[0].ScopeLogs, 1)
+	assert.Equal(t, otlpScopeName, req.ResourceLogs[0].ScopeLogs[0].Scope.Name)
+	require.NotEmpty(t, req.ResourceLogs[0].ScopeLogs[0].LogRecords)
+	assert.Equal(t, "perftest-a", otlpAttributesToMap(req.ResourceLogs[0].ScopeLogs[0].LogRecords[0].Attributes)["source.k8s.pod.name"])
+
+	// The metrics are exported periodically, and one last time when the exporter is stopped.
+	require.Eventually(t, func() bool {
+		return fakeClock.HasWaiters()
+	}, 5*time.Second, 10*time.Millisecond)
+	fakeClock.Step(opt.OTLPMetricsExportInterval)
+	checkMetrics := func() {
+		select {
+		case req := <-metricsServer.requestsCh:
+			require.Len(t, req.ResourceMetrics, 1)
+			require.Len(t, req.ResourceMetrics[0].ScopeMetrics, 1)
+			metrics := req.ResourceMetrics[0].ScopeMetrics[0].Metrics
+			require.Len(t, metrics, 2)
+			assert.Equal(t, "antrea.flow.octets", metrics[0].Name)
+			assert.Equal(t, "antrea.flow.packets", metrics[1].Name)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "Metrics were not exported")
+		}
+	}
+	checkMetrics()
+	stop()
+	checkMetrics()
+}
+
+func TestOTLPExporter_RunHTTP(t *testing.T) {
+	certPEM, keyPEM := generateLocalhostCert(t, false)
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	defaultFS = afero.NewMemMapFs()
+	t.Cleanup(func() { defaultFS = afero.NewOsFs() })
+	require.NoError(t, afero.WriteFile(defaultFS, filepath.Join(otlpCertDir, "ca.crt"), certPEM, 0644))
+
+	requestsCh := make(chan *collogspb.ExportLogsServiceRequest, 100)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		reader, err := gzip.NewReader(r.Body)
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, err := io.ReadAll(reader)
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		req := &collogspb.ExportLogsServiceRequest{}
+		if !assert.NoError(t, proto.Unmarshal(body, req)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requestsCh <- req
+		// A partial success does not prevent the next records from being exported.
+		resp, _ := proto.Marshal(&collogspb.ExportLogsServiceResponse{
+			PartialSuccess: &collogspb.ExportLogsPartialSuccess{
+				RejectedLogRecords: 1,
+				ErrorMessage:       "rejected",
+			},
+		})
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.Write(resp)
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{serverCert}}
+	server.StartTLS()
+	defer server.Close()
+
+	opt := newTestOTLPOptions(server.Listener.Addr().String(), "HTTP")
+	opt.Config.OTLP.TLS = flowaggregatorconfig.OTLPTLSConfig{
+		Enable:       true,
+		CASecretName: "otlp-ca",
+	}
+	exp, err := NewOTLPExporter(uuid.New(), opt)
+	require.NoError(t, err)
+	buf, stop := runOTLPExporter(t, exp)
+	defer stop()
+
+	record := flowaggregatortesting.PrepareTestFlowRecord(false)
+	var req *collogspb.ExportLogsServiceRequest
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		buf.Produce(record)
+		select {
+		case req = <-requestsCh:
+		default:
+		}
+		assert.NotNil(c, req)
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Len(t, req.ResourceLogs, 1)
+	require.Len(t, req.ResourceLogs[0].ScopeLogs, 1)
+	logRecords := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.NotEmpty(t, logRecords)
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_INFO, logRecords[0].SeverityNumber)
+	assert.Equal(t, "2001:0:3238:dfe1:63::fefb", otlpAttributesToMap(logRecords[0].Attributes)["source.address"])
+}
+
+func TestOTLPHTTPClient_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	opt := newTestOTLPOptions(server.Listener.Addr().String(), "HTTP")
+	client := newOTLPHTTPClient(opt.Config.OTLP, nil, nil)
+	defer client.close()
+	_, err := client.exportMetrics(context.Background(), &colmetricspb.ExportMetricsServiceRequest{})
+	assert.ErrorContains(t, err, "503 Service Unavailable")
+}
@@ -29,6 +29,8 @@ import (
 type S3Exporter struct {
 	s3Input         *s3uploader.S3Input
 	s3UploadProcess *s3uploader.S3UploadProcess
+	// spooler is nil if the spool is disabled.
+	spooler *recordSpooler
 }
 
 func buildS3Input(opt *options.Options) s3uploader.S3Input {
@@ -45,9 +47,17 @@ func NewS3Exporter(clusterUUID uuid.UUID, opt *options.Options) (*S3Exporter, er
 	if err != nil {
 		return nil, err
 	}
+	var spooler *recordSpooler
+	if opt.Config.S3Uploader.Spool.Enable {
+		spooler, err = newRecordSpooler("s3", s3UploadProcess, &opt.Config.S3Uploader.Spool, opt.S3SpoolMaxSize)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &S3Exporter{
 		s3Input:         &s3Input,
 		s3UploadProcess: s3UploadProcess,
+		spooler:         spooler,
 	}, nil
 }
 
@@ -56,14 +66,25 @@ func NewS3Exporter(clusterUUID uuid.UUID, opt *options.Options) (*S3Exporter, er
 func (e *S3Exporter) Run(ctx context.Context, buf ringbuffer.BroadcastBuffer[*flowpb.Flow]) {
 	consumer := buf.NewConsumer(ringbuffer.WithMaxConsumeDeadline(consumeDeadline))
 	e.s3UploadProcess.Start()
-	defer e.s3UploadProcess.Stop()
+	defer func() {
+		e.s3UploadProcess.Stop()
+		// The spooler must be closed after the export process has been stopped, so that
+		// the records which could not be written are spooled.
+		if e.spooler != nil {
+			e.spooler.close()
+		}
+	}()
 
 	records := make([]*flowpb.Flow, consumeMultipleBatchSize)
 	for {
 		n, _, shutdown := consumer.ConsumeMultiple(records)
-		for _, record := range records[:n] {
-			if err := e.s3UploadProcess.CacheRecord(record); err != nil {
-				klog.ErrorS(err, "Error when caching record for S3")
+		if e.spooler != nil {
+			e.spooler.cacheRecords(records[:n])
+		} else {
+			for _, record := range records[:n] {
+				if err := e.s3UploadProcess.CacheRecord(record); err != nil {
+					klog.ErrorS(err, "Error when caching record for S3")
+				}
 			}
 		}
 		if shutdown || ctx.Err() != nil {
@@ -16,6 +16,7 @@ package exporter
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -57,3 +58,36 @@ func TestS3_NewS3Exporter(t *testing.T) {
 	assert.Equal(t, "defaultBucketPrefix", exporter.s3UploadProcess.GetBucketPrefix())
 	assert.Equal(t, "us-west-2", exporter.s3UploadProcess.GetRegion())
 }
+
+func TestS3_NewS3ExporterWithSpool(t *testing.T) {
+	GetS3BucketRegionSaved := s3uploader.GetS3BucketRegion
+	s3uploader.GetS3BucketRegion = func(ctx context.Context, bucket string, regionHint string) (string, error) {
+		return "us-west-2", nil
+	}
+	defer func() {
+		s3uploader.GetS3BucketRegion = GetS3BucketRegionSaved
+	}()
+	spoolBaseDir = t.TempDir()
+	compress := true
+	opt := &options.Options{
+		Config: &flowaggregator.FlowAggregatorConfig{
+			S3Uploader: flowaggregator.S3UploaderConfig{
+				BucketName:   "defaultBucketName",
+				RecordFormat: "CSV",
+				Compress:     &compress,
+				Spool: flowaggregator.SpoolConfig{
+					Enable:         true,
+					MaxSize:        "1Mi",
+					OverflowPolicy: "DropNewest",
+				},
+			},
+		},
+		S3UploadInterval: 8 * time.Second,
+		S3SpoolMaxSize:   1 << 20,
+	}
+	exporter, err := NewS3Exporter(uuid.New(), opt)
+	require.NoError(t, err)
+	require.NotNil(t, exporter.spooler)
+	defer exporter.spooler.close()
+	assert.DirExists(t, filepath.Join(spoolBaseDir, "s3"))
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+
+	flowpb "antrea.io/antrea/v2/pkg/apis/flow/v1alpha1"
+	flowaggregatorconfig "antrea.io/antrea/v2/pkg/config/flowaggregator"
+	"antrea.io/antrea/v2/pkg/flowaggregator/metrics"
+	"antrea.io/antrea/v2/pkg/flowaggregator/spool"
+)
+
+// spoolReplayBatchSize is the maximum number of records read from a spool at once.
+const spoolReplayBatchSize = 1000
+
+// spoolBaseDir is the directory under which each exporter stores its spool. It should be
+// backed by a PersistentVolume. It is a variable so that it can be overridden in tests.
+var spoolBaseDir = "/var/lib/flow-aggregator/spool"
+
+// spoolSink is implemented by the export processes which can be used with a spool.
+type spoolSink interface {
+	CacheRecord(record *flowpb.Flow) error
+	// Healthy returns false if the last attempt to write records to the destination failed.
+	Healthy() bool
+	// AvailableCapacity returns the number of records which can be cached without causing
+	// older records, which have not been written yet, to be dropped.
+	AvailableCapacity() int
+	// FlushedRecordCount returns how many of the records cached so far are no longer
+	// pending, because they have been written to the destination or dropped. Records are
+	// flushed in the order in which they are cached.
+	FlushedRecordCount() uint64
+}
+
+// pendingBatch is a batch of records which have been cached in the export process, but
+// have not been flushed yet.
+type pendingBatch struct {
+	// start and end are the sequence numbers of the first record of the batch and of the
+	// record following the batch, counting all the records cached in the export process.
+	start uint64
+	end   uint64
+	// records are the records of the batch, unless they were replayed from the spool.
+	records []*flowpb.Flow
+	// spooled is the number of records of the batch which were replayed from the spool,
+	// and which must be acknowledged once the batch has been flushed.
+	spooled int
+}
+
+// recordSpooler sits between the ring buffer consumer of an exporter and its export
+// process. Records are appended to a disk spool while the export process is unhealthy or
+// does not have enough capacity, and they are replayed in order once it recovers. As long
+// as the spool is not empty, new records are appended to it as well, to preserve ordering.
+// Replayed records are only acknowledged, and removed from the spool, once the export
+// process has flushed them.
+type recordSpooler struct {
+	name     string
+	spool    *spool.Spool
+	sink     spoolSink
+	buf      []*flowpb.Flow
+	spooling bool
+	// cached is the number of records cached in the export process so far.
+	cached uint64
+	// pending are the batches of records which have not been flushed yet, in order.
+	pending []pendingBatch
+}
+
+func newRecordSpooler(name string, sink spoolSink, config *flowaggregatorconfig.SpoolConfig, maxSize int64) (*recordSpooler, error) {
+	dir := filepath.Join(spoolBaseDir, name)
+	s, err := spool.Open(dir, maxSize, spool.OverflowPolicy(config.OverflowPolicy))
+	if err != nil {
+		return nil, fmt.Errorf("error when opening spool: %w", err)
+	}
+	klog.InfoS("Opened spool", "exporter", name, "dir", dir, "maxSize", maxSize, "overflowPolicy", config.OverflowPolicy, "records", s.Len())
+	spooler := &recordSpooler{
+		name:  name,
+		spool: s,
+		sink:  sink,
+		buf:   make([]*flowpb.Flow, spoolReplayBatchSize),
+	}
+	spooler.updateMetrics()
+	return spooler, nil
+}
+
+// cacheRecords replays spooled records if possible, then hands records to the export
+// process, or appends them to the spool.
+func (s *recordSpooler) cacheRecords(records []*flowpb.Flow) {
+	s.ack()
+	s.replay()
+	defer s.updateMetrics()
+	if s.spool.Len() == 0 && s.sink.Healthy() && s.sink.AvailableCapacity() >= len(records) {
+		if s.spooling {
+			klog.InfoS("All spooled records have been replayed", "exporter", s.name)
+			s.spooling = false
+		}
+		s.cacheInSink(records, false)
+		return
+	}
+	if len(records) == 0 {
+		return
+	}
+	if !s.spooling {
+		klog.InfoS("Spooling records to disk", "exporter", s.name, "healthy", s.sink.Healthy())
+		s.spooling = true
+	}
+	dropped, err := s.spool.Append(records)
+	if dropped > 0 {
+		klog.V(2).InfoS("Spool is full, dropped records", "exporter", s.name, "count", dropped)
+		metrics.SpoolDroppedRecordCount.WithLabelValues(s.name).Add(float64(dropped))
+	}
+	if err != nil {
+		// Some records may have been appended already, but duplicates are preferable to
+		// losing records.
+		klog.ErrorS(err, "Error when appending records to spool, caching them in memory instead", "exporter", s.name)
+		s.cacheInSink(records, false)
+	}
+}
+
+// replay hands spooled records to the export process, as long as it is healthy and has
+// enough capacity. Records which have been replayed but not acknowledged yet are not
+// replayed again.
+func (s *recordSpooler) replay() {
+	for s.spool.Len() > 0 && s.sink.Healthy() {
+		count := min(s.sink.AvailableCapacity(), len(s.buf))
+		if count == 0 {
+			return
+		}
+		n, dropped, err := s.spool.Peek(s.buf[:count])
+		if dropped > 0 {
+			metrics.SpoolDroppedRecordCount.WithLabelValues(s.name).Add(float64(dropped))
+		}
+		s.cacheInSink(s.buf[:n], true)
+		clear(s.buf[:n])
+		metrics.SpoolReplayedRecordCount.WithLabelValues(s.name).Add(float64(n))
+		if err != nil {
+			klog.ErrorS(err, "Error when reading records from spool", "exporter", s.name)
+			return
+		}
+		if n == 0 {
+			return
+		}
+	}
+}
+
+func (s *recordSpooler) cacheInSink(records []*flowpb.Flow, replayed bool) {
+	if len(records) == 0 {
+		return
+	}
+	batch := pendingBatch{start: s.cached}
+	for _, record := range records {
+		if err := s.sink.CacheRecord(record); err != nil {
+			klog.ErrorS(err, "Error when caching record", "exporter", s.name)
+			continue
+		}
+		s.cached++
+		if !replayed {
+			batch.records = append(batch.records, record)
+		}
+	}
+	batch.end = s.cached
+	if replayed {
+		batch.spooled = len(records)
+	}
+	s.pending = append(s.pending, batch)
+}
+
+// ack acknowledges the replayed records which have been flushed by the export process,
+// and forgets about the other flushed records.
+func (s *recordSpooler) ack() {
+	flushed := s.sink.FlushedRecordCount()
+	for len(s.pending) > 0 {
+		batch := &s.pending[0]
+		if flushed < batch.end {
+			if batch.spooled == 0 && flushed > batch.start {
+				batch.records = batch.records[flushed-batch.start:]
+				batch.start = flushed
+			}
+			return
+		}
+		if batch.spooled > 0 {
+			if err := s.spool.Ack(batch.spooled); err != nil {
+				klog.ErrorS(err, "Error when acknowledging spooled records", "exporter", s.name)
+			}
+		}
+		s.pending = s.pending[1:]
+	}
+}
+
+func (s *recordSpooler) updateMetrics() {
+	metrics.SpoolRecordCount.WithLabelValues(s.name).Set(float64(s.spool.Len()))
+	metrics.SpoolSizeBytes.WithLabelValues(s.name).Set(float64(s.spool.Size()))
+}
+
+// close closes the spool. It must be called after the export process has been stopped.
+// Records which were cached in the export process without going through the spool, and
+// which have not been flushed, are appended to the spool. Together with the records which
+// are still spooled, they will be replayed by the next instance of the exporter.
+func (s *recordSpooler) close() {
+	s.ack()
+	var records []*flowpb.Flow
+	for _, batch := range s.pending {
+		records = append(records, batch.records...)
+	}
+	s.pending = nil
+	if len(records) > 0 {
+		klog.InfoS("Spooling records which have not been written", "exporter", s.name, "count", len(records))
+		dropped, err := s.spool.Append(records)
+		if dropped > 0 {
+			metrics.SpoolDroppedRecordCount.WithLabelValues(s.name).Add(float64(dropped))
+		}
+		if err != nil {
+			klog.ErrorS(err, "Error when appending records to spool", "exporter", s.name)
+		}
+	}
+	if err := s.spool.Close(); err != nil {
+		klog.ErrorS(err, "Error when closing spool", "exporter", s.name)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	flowpb "antrea.io/antrea/v2/pkg/apis/flow/v1alpha1"
+	flowaggregatorconfig "antrea.io/antrea/v2/pkg/config/flowaggregator"
+)
+
+type fakeSpoolSink struct {
+	records  []*flowpb.Flow
+	healthy  bool
+	capacity int
+	flushed  uint64
+}
+
+func (s *fakeSpoolSink) CacheRecord(record *flowpb.Flow) error {
+	s.records = append(s.records, record)
+	s.capacity--
+	return nil
+}
+
+func (s *fakeSpoolSink) Healthy() bool {
+	return s.healthy
+}
+
+func (s *fakeSpoolSink) AvailableCapacity() int {
+	return max(s.capacity, 0)
+}
+
+func (s *fakeSpoolSink) FlushedRecordCount() uint64 {
+	return s.flushed
+}
+
+// flush marks all the cached records as flushed.
+func (s *fakeSpoolSink) flush() {
+	s.flushed = uint64(len(s.records))
+}
+
+func newSpooledRecords(start, count int) []*flowpb.Flow {
+	records := make([]*flowpb.Flow, count)
+	for i := range records {
+		records[i] = &flowpb.Flow{
+			Transport: &flowpb.Transport{
+				ProtocolNumber:  6,
+				SourcePort:      uint32(start + i),
+				DestinationPort: 80,
+			},
+		}
+	}
+	return records
+}
+
+func spooledSourcePorts(records []*flowpb.Flow) []uint32 {
+	ports := make([]uint32, len(records))
+	for i, record := range records {
+		ports[i] = record.Transport.SourcePort
+	}
+	return ports
+}
+
+func newTestRecordSpooler(t *testing.T, sink spoolSink) *recordSpooler {
+	spooler, err := newRecordSpooler("test", sink, &flowaggregatorconfig.SpoolConfig{
+		Enable:         true,
+		OverflowPolicy: "DropOldest",
+	}, 1<<20)
+	require.NoError(t, err)
+	return spooler
+}
+
+func TestRecordSpooler(t *testing.T) {
+	spoolBaseDir = t.TempDir()
+	sink := &fakeSpoolSink{healthy: true, capacity: 100}
+	spooler := newTestRecordSpooler(t, sink)
+	defer spooler.close()
+
+	// Records are handed to a healthy sink directly.
+	spooler.cacheRecords(newSpooledRecords(0, 10))
+	assert.Len(t, sink.records, 10)
+	assert.Zero(t, spooler.spool.Len())
+
+	// Records are spooled while the sink is unhealthy.
+	sink.healthy = false
+	spooler.cacheRecords(newSpooledRecords(10, 10))
+	spooler.cacheRecords(nil)
+	assert.Len(t, sink.records, 10)
+	assert.EqualValues(t, 10, spooler.spool.Len())
+
+	// Records are spooled while the sink does not have enough capacity.
+	sink.healthy = true
+	sink.capacity = 5
+	spooler.cacheRecords(newSpooledRecords(20, 10))
+	// 5 records have been replayed, but they remain in the spool until they are flushed.
+	assert.Len(t, sink.records, 15)
+	assert.EqualValues(t, 20, spooler.spool.Len())
+	spooler.cacheRecords(nil)
+	assert.Len(t, sink.records, 15)
+	assert.EqualValues(t, 20, spooler.spool.Len())
+
+	// Once the sink recovers, spooled records are replayed before new records.
+	sink.capacity = 100
+	sink.flush()
+	spooler.cacheRecords(newSpooledRecords(30, 10))
+	assert.Len(t, sink.records, 30)
+	assert.EqualValues(t, 25, spooler.spool.Len())
+	sink.flush()
+	spooler.cacheRecords(nil)
+	assert.Len(t, sink.records, 40)
+	assert.EqualValues(t, 10, spooler.spool.Len())
+	sink.flush()
+	spooler.cacheRecords(nil)
+	assert.Zero(t, spooler.spool.Len())
+	assert.Equal(t, spooledSourcePorts(newSpooledRecords(0, 40)), spooledSourcePorts(sink.records))
+
+	// Records are handed to the sink directly again.
+	spooler.cacheRecords(newSpooledRecords(40, 10))
+	assert.Len(t, sink.records, 50)
+	assert.Zero(t, spooler.spool.Len())
+}
+
+func TestRecordSpoolerReopen(t *testing.T) {
+	spoolBaseDir = t.TempDir()
+	sink := &fakeSpoolSink{healthy: false}
+	spooler := newTestRecordSpooler(t, sink)
+	spooler.cacheRecords(newSpooledRecords(0, 10))
+	spooler.close()
+	assert.Empty(t, sink.records)
+
+	// Records spooled by a previous instance are replayed.
+	sink = &fakeSpoolSink{healthy: true, capacity: 100}
+	spooler = newTestRecordSpooler(t, sink)
+	defer spooler.close()
+	assert.EqualValues(t, 10, spooler.spool.Len())
+	spooler.cacheRecords(newSpooledRecords(10, 1))
+	sink.flush()
+	spooler.cacheRecords(nil)
+	assert.Equal(t, spooledSourcePorts(newSpooledRecords(0, 11)), spooledSourcePorts(sink.records))
+}
+
+func TestRecordSpoolerClose(t *testing.T) {
+	spoolBaseDir = t.TempDir()
+	sink := &fakeSpoolSink{healthy: true, capacity: 100}
+	spooler := newTestRecordSpooler(t, sink)
+	spooler.cacheRecords(newSpooledRecords(0, 10))
+	sink.healthy = false
+	spooler.cacheRecords(newSpooledRecords(10, 5))
+	sink.healthy = true
+	spooler.cacheRecords(nil)
+	assert.Len(t, sink.records, 15)
+	// Only some of the records handed to the sink directly have been flushed, and none
+	// of the replayed records.
+	sink.flushed = 4
+	spooler.close()
+
+	// Records which have not been flushed are replayed by the next instance.
+	sink = &fakeSpoolSink{healthy: true, capacity: 100}
+	spooler = newTestRecordSpooler(t, sink)
+	defer spooler.close()
+	assert.EqualValues(t, 11, spooler.spool.Len())
+	spooler.cacheRecords(nil)
+	expectedPorts := append(spooledSourcePorts(newSpooledRecords(10, 5)), spooledSourcePorts(newSpooledRecords(4, 6))...)
+	assert.Equal(t, expectedPorts, spooledSourcePorts(sink.records))
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// loadTLSCertificates sets the RootCAs and client Certificates of tlsConfig, using the
+// ca.crt, tls.crt and tls.key files projected in certDir from the provided Secrets. A Secret
+// name may be empty, in which case the corresponding files are ignored.
+func loadTLSCertificates(tlsConfig *tls.Config, certDir, caSecretName, clientSecretName string) error {
+	if caSecretName != "" {
+		caPath := filepath.Join(certDir, "ca.crt")
+		caBytes, err := afero.ReadFile(defaultFS, caPath)
+		if err != nil {
+			return fmt.Errorf("error when reading CA cert %q, ensure Secret %q exists in this Namespace and has the 'ca.crt' key: %w", caPath, caSecretName, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return fmt.Errorf("no valid CA certificate found in %q", caPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if clientSecretName != "" {
+		certPath := filepath.Join(certDir, "tls.crt")
+		certBytes, err := afero.ReadFile(defaultFS, certPath)
+		if err != nil {
+			return fmt.Errorf("error when reading client cert %q, ensure Secret %q exists in this Namespace and has the 'tls.crt' key: %w", certPath, clientSecretName, err)
+		}
+		keyPath := filepath.Join(certDir, "tls.key")
+		keyBytes, err := afero.ReadFile(defaultFS, keyPath)
+		if err != nil {
+			return fmt.Errorf("error when reading client key %q, ensure Secret %q exists in this Namespace and has the 'tls.key' key: %w", keyPath, clientSecretName, err)
+		}
+		cert, err := tls.X509KeyPair(certBytes, keyBytes)
+		if err != nil {
+			return fmt.Errorf("error when loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return nil
+}
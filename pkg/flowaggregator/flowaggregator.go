@@ -62,6 +62,12 @@ var (
 	newLogExporter = func(opt *options.Options) (exporter.Runner, error) {
 		return exporter.NewLogExporter(opt)
 	}
+	newKafkaExporter = func(opt *options.Options) (exporter.Runner, error) {
+		return exporter.NewKafkaExporter(opt)
+	}
+	newOTLPExporter = func(clusterUUID uuid.UUID, opt *options.Options) (exporter.Runner, error) {
+		return exporter.NewOTLPExporter(clusterUUID, opt)
+	}
 
 	newCertificateProvider = func(k8sClient kubernetes.Interface, addr string) *certificate.Provider {
 		return certificate.NewProvider(k8sClient, addr)
@@ -123,6 +129,8 @@ type flowAggregator struct {
 	clickHouseHandle    *exporterHandle
 	s3Handle            *exporterHandle
 	logHandle           *exporterHandle
+	kafkaHandle         *exporterHandle
+	otlpHandle          *exporterHandle
 	exportersMutex      sync.Mutex
 	certificateProvider *certificate.Provider
 }
@@ -407,6 +415,24 @@ func (fa *flowAggregator) initExporters() {
 			klog.InfoS("Started log exporter")
 		}
 	}
+	if opt.Config.Kafka.Enable {
+		exp, err := newKafkaExporter(opt)
+		if err != nil {
+			klog.ErrorS(err, "Error when creating Kafka export process")
+		} else {
+			fa.kafkaHandle = fa.launchExporter(exp)
+			klog.InfoS("Started Kafka exporter")
+		}
+	}
+	if opt.Config.OTLP.Enable {
+		exp, err := newOTLPExporter(fa.clusterUUID, opt)
+		if err != nil {
+			klog.ErrorS(err, "Error when creating OTLP export process")
+		} else {
+			fa.otlpHandle = fa.launchExporter(exp)
+			klog.InfoS("Started OTLP exporter")
+		}
+	}
 }
 
 // stopAllExporters stops all running exporter goroutines.
@@ -429,6 +455,14 @@ func (fa *flowAggregator) stopAllExporters() {
 		fa.logHandle.stop()
 		fa.logHandle = nil
 	}
+	if fa.kafkaHandle != nil {
+		fa.kafkaHandle.stop()
+		fa.kafkaHandle = nil
+	}
+	if fa.otlpHandle != nil {
+		fa.otlpHandle.stop()
+		fa.otlpHandle = nil
+	}
 }
 
 // flowExportLoop reads records from recordCh, enriches them, and produces them
@@ -477,6 +511,7 @@ func (fa *flowAggregator) proxyRecord(record *flowpb.Flow) {
 		fa.fillEgressNodeUID(record, startTime)
 	}
 	fa.fillPodLabels(sourceAddress, destinationAddress, record, startTime)
+	fa.fillPodOwners(sourceAddress, destinationAddress, record, startTime)
 	fa.produceRecord(record)
 }
 
@@ -569,6 +604,7 @@ func (fa *flowAggregator) sendAggregatedRecord(key intermediate.FlowKey, record
 	// Even if fa.includePodLabels is false, we still need to add an empty IE to match the template.
 	if !fa.aggregationProcess.AreExternalFieldsFilled(*record) {
 		fa.fillPodLabels(key.SourceAddress, key.DestinationAddress, record.Record, startTime)
+		fa.fillPodOwners(key.SourceAddress, key.DestinationAddress, record.Record, startTime)
 		if fa.includeK8sUIDs {
 			fa.fillServiceUID(record.Record, startTime)
 			fa.fillEgressNodeUID(record.Record, startTime)
@@ -664,6 +700,21 @@ func (fa *flowAggregator) fillPodLabels(sourceAddress, destinationAddress string
 	}
 }
 
+// fillPodOwners fills the kind and name of the workloads (e.g., Deployment) owning the source and
+// destination Pods.
+func (fa *flowAggregator) fillPodOwners(sourceAddress, destinationAddress string, record *flowpb.Flow, startTime time.Time) {
+	if record.K8S.SourcePodName != "" {
+		if pod, exist := fa.podStore.GetPodByIPAndTime(sourceAddress, startTime); exist {
+			record.K8S.SourcePodOwnerKind, record.K8S.SourcePodOwnerName = objectstore.GetPodOwnerWorkload(pod)
+		}
+	}
+	if record.K8S.DestinationPodName != "" {
+		if pod, exist := fa.podStore.GetPodByIPAndTime(destinationAddress, startTime); exist {
+			record.K8S.DestinationPodOwnerKind, record.K8S.DestinationPodOwnerName = objectstore.GetPodOwnerWorkload(pod)
+		}
+	}
+}
+
 func (fa *flowAggregator) fillServiceUID(record *flowpb.Flow, startTime time.Time) {
 	if record.K8S.DestinationServicePortName == "" {
 		return
@@ -738,6 +789,8 @@ func (fa *flowAggregator) GetRecordMetrics() querier.Metrics {
 	metrics.WithS3Exporter = fa.s3Handle != nil
 	metrics.WithLogExporter = fa.logHandle != nil
 	metrics.WithIPFIXExporter = fa.ipfixHandle != nil
+	metrics.WithKafkaExporter = fa.kafkaHandle != nil
+	metrics.WithOTLPExporter = fa.otlpHandle != nil
 	return metrics
 }
 
@@ -894,6 +947,52 @@ func (fa *flowAggregator) updateFlowAggregator(opt *options.Options) {
 		klog.InfoS("Disabled FlowLogger")
 	}
 
+	// Kafka exporter: stop-and-replace
+	if opt.Config.Kafka.Enable {
+		if fa.kafkaHandle != nil {
+			klog.InfoS("Replacing Kafka exporter")
+			fa.kafkaHandle.stop()
+			fa.kafkaHandle = nil
+		} else {
+			klog.InfoS("Enabling Kafka")
+		}
+		exp, err := newKafkaExporter(opt)
+		if err != nil {
+			klog.ErrorS(err, "Error when creating Kafka export process")
+		} else {
+			fa.kafkaHandle = fa.launchExporter(exp)
+			klog.InfoS("Started Kafka exporter")
+		}
+	} else if fa.kafkaHandle != nil {
+		klog.InfoS("Disabling Kafka")
+		fa.kafkaHandle.stop()
+		fa.kafkaHandle = nil
+		klog.InfoS("Disabled Kafka")
+	}
+
+	// OTLP exporter: stop-and-replace
+	if opt.Config.OTLP.Enable {
+		if fa.otlpHandle != nil {
+			klog.InfoS("Replacing OTLP exporter")
+			fa.otlpHandle.stop()
+			fa.otlpHandle = nil
+		} else {
+			klog.InfoS("Enabling OTLP")
+		}
+		exp, err := newOTLPExporter(fa.clusterUUID, opt)
+		if err != nil {
+			klog.ErrorS(err, "Error when creating OTLP export process")
+		} else {
+			fa.otlpHandle = fa.launchExporter(exp)
+			klog.InfoS("Started OTLP exporter")
+		}
+	} else if fa.otlpHandle != nil {
+		klog.InfoS("Disabling OTLP")
+		fa.otlpHandle.stop()
+		fa.otlpHandle = nil
+		klog.InfoS("Disabled OTLP")
+	}
+
 	if opt.Config.RecordContents.PodLabels != fa.includePodLabels {
 		fa.includePodLabels = opt.Config.RecordContents.PodLabels
 		klog.InfoS("Updated recordContents.podLabels configuration", "value", fa.includePodLabels)
@@ -84,12 +84,18 @@ func TestFlowAggregator_sendAggregatedRecord(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "default",
 			Name:      "podA",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "daemonSetA", Controller: ptr.To(true)},
+			},
 		},
 	}
 	podB := &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "default",
 			Name:      "podB",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "statefulSetB", Controller: ptr.To(true)},
+			},
 		},
 	}
 
@@ -175,10 +181,14 @@ func TestFlowAggregator_sendAggregatedRecord(t *testing.T) {
 			mockAggregationProcess.EXPECT().AreCorrelatedFieldsFilled(*flowRecord).Return(false)
 			mockAggregationProcess.EXPECT().SetCorrelatedFieldsFilled(flowRecord, true)
 			mockAggregationProcess.EXPECT().AreExternalFieldsFilled(*flowRecord).Return(false)
+			// The Pods are looked up to fill the owners, and also to fill the labels if
+			// includePodLabels is true.
+			numPodLookups := 1
 			if tc.includePodLabels {
-				mockPodStore.EXPECT().GetPodByIPAndTime(tc.flowKey.SourceAddress, startTime).Return(podA, true)
-				mockPodStore.EXPECT().GetPodByIPAndTime(tc.flowKey.DestinationAddress, startTime).Return(podB, true)
+				numPodLookups = 2
 			}
+			mockPodStore.EXPECT().GetPodByIPAndTime(tc.flowKey.SourceAddress, startTime).Return(podA, true).Times(numPodLookups)
+			mockPodStore.EXPECT().GetPodByIPAndTime(tc.flowKey.DestinationAddress, startTime).Return(podB, true).Times(numPodLookups)
 			mockAggregationProcess.EXPECT().SetExternalFieldsFilled(flowRecord, true)
 
 			err := fa.sendAggregatedRecord(tc.flowKey, flowRecord)
@@ -187,6 +197,10 @@ func TestFlowAggregator_sendAggregatedRecord(t *testing.T) {
 				assert.NotNil(t, record.K8S.SourcePodLabels)
 				assert.NotNil(t, record.K8S.DestinationPodLabels)
 			}
+			assert.Equal(t, "DaemonSet", record.K8S.SourcePodOwnerKind)
+			assert.Equal(t, "daemonSetA", record.K8S.SourcePodOwnerName)
+			assert.Equal(t, "StatefulSet", record.K8S.DestinationPodOwnerKind)
+			assert.Equal(t, "statefulSetB", record.K8S.DestinationPodOwnerName)
 		})
 	}
 }
@@ -196,12 +210,18 @@ func TestFlowAggregator_proxyRecord(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "default",
 			Name:      "podA",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "daemonSetA", Controller: ptr.To(true)},
+			},
 		},
 	}
 	podB := &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "default",
 			Name:      "podB",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "statefulSetB", Controller: ptr.To(true)},
+			},
 		},
 	}
 
@@ -286,16 +306,22 @@ func TestFlowAggregator_proxyRecord(t *testing.T) {
 			record.Ip.Source = netip.MustParseAddr(sourceAddress).AsSlice()
 			record.Ip.Destination = netip.MustParseAddr(destinationAddress).AsSlice()
 
+			numPodLookups := 1
 			if tc.includePodLabels {
-				mockPodStore.EXPECT().GetPodByIPAndTime(sourceAddress, startTime).Return(podA, true)
-				mockPodStore.EXPECT().GetPodByIPAndTime(destinationAddress, startTime).Return(podB, true)
+				numPodLookups = 2
 			}
+			mockPodStore.EXPECT().GetPodByIPAndTime(sourceAddress, startTime).Return(podA, true).Times(numPodLookups)
+			mockPodStore.EXPECT().GetPodByIPAndTime(destinationAddress, startTime).Return(podB, true).Times(numPodLookups)
 
 			fa.proxyRecord(record)
 			if tc.includePodLabels {
 				assert.NotNil(t, record.K8S.SourcePodLabels)
 				assert.NotNil(t, record.K8S.DestinationPodLabels)
 			}
+			assert.Equal(t, "DaemonSet", record.K8S.SourcePodOwnerKind)
+			assert.Equal(t, "daemonSetA", record.K8S.SourcePodOwnerName)
+			assert.Equal(t, "StatefulSet", record.K8S.DestinationPodOwnerKind)
+			assert.Equal(t, "statefulSetB", record.K8S.DestinationPodOwnerName)
 		})
 	}
 }
@@ -390,21 +416,29 @@ func mockExporters(t *testing.T, ctrl *gomock.Controller, expectedClusterUUID *u
 	*exportertesting.MockRunner,
 	*exportertesting.MockRunner,
 	*exportertesting.MockRunner,
+	*exportertesting.MockRunner,
+	*exportertesting.MockRunner,
 ) {
 	mockIPFIXExporter := exportertesting.NewMockRunner(ctrl)
 	mockClickHouseExporter := exportertesting.NewMockRunner(ctrl)
 	mockS3Exporter := exportertesting.NewMockRunner(ctrl)
 	mockLogExporter := exportertesting.NewMockRunner(ctrl)
+	mockKafkaExporter := exportertesting.NewMockRunner(ctrl)
+	mockOTLPExporter := exportertesting.NewMockRunner(ctrl)
 
 	newIPFIXExporterSaved := newIPFIXExporter
 	newClickHouseExporterSaved := newClickHouseExporter
 	newS3ExporterSaved := newS3Exporter
 	newLogExporterSaved := newLogExporter
+	newKafkaExporterSaved := newKafkaExporter
+	newOTLPExporterSaved := newOTLPExporter
 	t.Cleanup(func() {
 		newIPFIXExporter = newIPFIXExporterSaved
 		newClickHouseExporter = newClickHouseExporterSaved
 		newS3Exporter = newS3ExporterSaved
 		newLogExporter = newLogExporterSaved
+		newKafkaExporter = newKafkaExporterSaved
+		newOTLPExporter = newOTLPExporterSaved
 	})
 	newIPFIXExporter = func(clusterUUID uuid.UUID, clusterID string, opts *options.Options, registry ipfix.IPFIXRegistry) exporter.Runner {
 		if expectedClusterUUID != nil {
@@ -430,14 +464,23 @@ func mockExporters(t *testing.T, ctrl *gomock.Controller, expectedClusterUUID *u
 	newLogExporter = func(opt *options.Options) (exporter.Runner, error) {
 		return mockLogExporter, nil
 	}
+	newKafkaExporter = func(opt *options.Options) (exporter.Runner, error) {
+		return mockKafkaExporter, nil
+	}
+	newOTLPExporter = func(clusterUUID uuid.UUID, opt *options.Options) (exporter.Runner, error) {
+		if expectedClusterUUID != nil {
+			assert.Equal(t, *expectedClusterUUID, clusterUUID)
+		}
+		return mockOTLPExporter, nil
+	}
 
-	return mockIPFIXExporter, mockClickHouseExporter, mockS3Exporter, mockLogExporter
+	return mockIPFIXExporter, mockClickHouseExporter, mockS3Exporter, mockLogExporter, mockKafkaExporter, mockOTLPExporter
 }
 
 func TestFlowAggregator_updateFlowAggregator(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
-	mockIPFIXExporter, mockClickHouseExporter, mockS3Exporter, mockLogExporter := mockExporters(t, ctrl, nil, nil)
+	mockIPFIXExporter, mockClickHouseExporter, mockS3Exporter, mockLogExporter, mockKafkaExporter, mockOTLPExporter := mockExporters(t, ctrl, nil, nil)
 
 	// All mock exporters' Run methods should block until context cancellation.
 	blockingRun := func(ctx context.Context, buf ringbuffer.BroadcastBuffer[*flowpb.Flow]) {
@@ -447,6 +490,8 @@ func TestFlowAggregator_updateFlowAggregator(t *testing.T) {
 	mockClickHouseExporter.EXPECT().Run(gomock.Any(), gomock.Any()).Do(blockingRun).AnyTimes()
 	mockS3Exporter.EXPECT().Run(gomock.Any(), gomock.Any()).Do(blockingRun).AnyTimes()
 	mockLogExporter.EXPECT().Run(gomock.Any(), gomock.Any()).Do(blockingRun).AnyTimes()
+	mockKafkaExporter.EXPECT().Run(gomock.Any(), gomock.Any()).Do(blockingRun).AnyTimes()
+	mockOTLPExporter.EXPECT().Run(gomock.Any(), gomock.Any()).Do(blockingRun).AnyTimes()
 
 	newFA := func() *flowAggregator {
 		buf := ringbuffer.NewBroadcastBuffer[*flowpb.Flow](8)
@@ -595,6 +640,76 @@ func TestFlowAggregator_updateFlowAggregator(t *testing.T) {
 		fa.updateFlowAggregator(opt)
 		assert.Nil(t, fa.logHandle)
 	})
+	t.Run("enableKafka", func(t *testing.T) {
+		fa := newFA()
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				Kafka: flowaggregatorconfig.KafkaConfig{
+					Enable:  true,
+					Brokers: []string{"kafka.kafka.svc:9092"},
+				},
+			},
+		}
+		fa.updateFlowAggregator(opt)
+		assert.NotNil(t, fa.kafkaHandle)
+	})
+	t.Run("disableKafka", func(t *testing.T) {
+		fa := newFA()
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				Kafka: flowaggregatorconfig.KafkaConfig{
+					Enable:  true,
+					Brokers: []string{"kafka.kafka.svc:9092"},
+				},
+			},
+		}
+		fa.updateFlowAggregator(opt)
+		require.NotNil(t, fa.kafkaHandle)
+		opt = &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				Kafka: flowaggregatorconfig.KafkaConfig{
+					Enable: false,
+				},
+			},
+		}
+		fa.updateFlowAggregator(opt)
+		assert.Nil(t, fa.kafkaHandle)
+	})
+	t.Run("enableOTLP", func(t *testing.T) {
+		fa := newFA()
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				OTLP: flowaggregatorconfig.OTLPConfig{
+					Enable:   true,
+					Endpoint: "otel-collector.otel.svc:4317",
+				},
+			},
+		}
+		fa.updateFlowAggregator(opt)
+		assert.NotNil(t, fa.otlpHandle)
+	})
+	t.Run("disableOTLP", func(t *testing.T) {
+		fa := newFA()
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				OTLP: flowaggregatorconfig.OTLPConfig{
+					Enable:   true,
+					Endpoint: "otel-collector.otel.svc:4317",
+				},
+			},
+		}
+		fa.updateFlowAggregator(opt)
+		require.NotNil(t, fa.otlpHandle)
+		opt = &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				OTLP: flowaggregatorconfig.OTLPConfig{
+					Enable: false,
+				},
+			},
+		}
+		fa.updateFlowAggregator(opt)
+		assert.Nil(t, fa.otlpHandle)
+	})
 	t.Run("replaceIPFIX", func(t *testing.T) {
 		fa := newFA()
 		opt := &options.Options{
@@ -666,7 +781,7 @@ func TestFlowAggregator_Run(t *testing.T) {
 	clusterID := clusterUUID.String()
 	// This will validate that the correct UUID / ID is provided by the
 	// FlowAggregator when instantiating exporters.
-	mockIPFIXExporter, mockClickHouseExporter, mockS3Exporter, mockLogExporter := mockExporters(t, ctrl, &clusterUUID, &clusterID)
+	mockIPFIXExporter, mockClickHouseExporter, mockS3Exporter, mockLogExporter, _, _ := mockExporters(t, ctrl, &clusterUUID, &clusterID)
 	mockCollector := collectortesting.NewMockInterface(ctrl)
 	mockAggregationProcess := intermediatetesting.NewMockAggregationProcess(ctrl)
 	mockAggregationProcess.EXPECT().ForAllExpiredFlowRecordsDo(gomock.Any()).AnyTimes()
@@ -946,6 +1061,8 @@ func TestFlowAggregator_GetRecordMetrics(t *testing.T) {
 		WithS3Exporter:         true,
 		WithLogExporter:        true,
 		WithIPFIXExporter:      true,
+		WithKafkaExporter:      true,
+		WithOTLPExporter:       true,
 	}
 
 	fa := &flowAggregator{
@@ -955,6 +1072,8 @@ func TestFlowAggregator_GetRecordMetrics(t *testing.T) {
 		s3Handle:           &exporterHandle{},
 		logHandle:          &exporterHandle{},
 		ipfixHandle:        &exporterHandle{},
+		kafkaHandle:        &exporterHandle{},
+		otlpHandle:         &exporterHandle{},
 	}
 	fa.numRecordsExported.Store(10)
 	fa.numRecordsDropped.Store(1)
@@ -1101,6 +1220,41 @@ func TestFlowAggregator_fillK8sMetadata(t *testing.T) {
 	assert.Equal(t, "destinationNode", record.K8S.DestinationNodeName)
 }
 
+func TestFlowAggregator_fillPodOwners(t *testing.T) {
+	srcPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "web-5d8f7c9b4-x2x7k",
+			Labels:    map[string]string{"pod-template-hash": "5d8f7c9b4"},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-5d8f7c9b4", Controller: ptr.To(true)},
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockPodStore := objectstoretest.NewMockPodStore(ctrl)
+	fa := &flowAggregator{
+		podStore: mockPodStore,
+	}
+
+	// The destination is not a Pod, so only the source Pod is looked up.
+	record := &flowpb.Flow{
+		K8S: &flowpb.Kubernetes{
+			FlowType:           flowpb.FlowType_FLOW_TYPE_TO_EXTERNAL,
+			SourcePodNamespace: "default",
+			SourcePodName:      "web-5d8f7c9b4-x2x7k",
+		},
+	}
+	mockPodStore.EXPECT().GetPodByIPAndTime("192.168.1.2", gomock.Any()).Return(srcPod, true)
+
+	fa.fillPodOwners("192.168.1.2", "8.8.8.8", record, time.Now())
+	assert.Equal(t, "Deployment", record.K8S.SourcePodOwnerKind)
+	assert.Equal(t, "web", record.K8S.SourcePodOwnerName)
+	assert.Empty(t, record.K8S.DestinationPodOwnerKind)
+	assert.Empty(t, record.K8S.DestinationPodOwnerName)
+}
+
 func TestNewFlowAggregator(t *testing.T) {
 	wd, err := os.Getwd()
 	require.NoError(t, err)
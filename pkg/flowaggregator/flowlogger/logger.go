@@ -117,6 +117,11 @@ func (fl *FlowLogger) WriteRecord(r *flowrecord.FlowRecord, prettyPrint bool) er
 		r.EgressName,
 		r.EgressIP,
 		r.EgressNodeName,
+		r.SourcePodOwnerKind,
+		r.SourcePodOwnerName,
+		r.DestinationPodOwnerKind,
+		r.DestinationPodOwnerName,
+		r.DestinationFQDN,
 	}
 
 	str := strings.Join(fields, ",")
@@ -74,6 +74,11 @@ type FlowRecord struct {
 	EgressName                           string
 	EgressIP                             string
 	EgressNodeName                       string
+	SourcePodOwnerKind                   string
+	SourcePodOwnerName                   string
+	DestinationPodOwnerKind              string
+	DestinationPodOwnerName              string
+	DestinationFQDN                      string
 }
 
 // GetFlowRecord converts flowpb.Flow to FlowRecord.
@@ -170,5 +175,10 @@ func GetFlowRecord(record *flowpb.Flow) (*FlowRecord, error) {
 		EgressName:                           record.K8S.EgressName,
 		EgressIP:                             ipAddressAsString(record.K8S.EgressIp),
 		EgressNodeName:                       record.K8S.EgressNodeName,
+		SourcePodOwnerKind:                   record.K8S.SourcePodOwnerKind,
+		SourcePodOwnerName:                   record.K8S.SourcePodOwnerName,
+		DestinationPodOwnerKind:              record.K8S.DestinationPodOwnerKind,
+		DestinationPodOwnerName:              record.K8S.DestinationPodOwnerName,
+		DestinationFQDN:                      record.K8S.DestinationFqdn,
 	}, nil
 }
@@ -75,6 +75,11 @@ func TestGetFlowRecord(t *testing.T) {
 		assert.Equal(t, uint64(12381345), flowRecord.ReverseThroughputFromSourceNode)
 		assert.Equal(t, uint64(12381346), flowRecord.ReverseThroughputFromDestinationNode)
 		assert.Equal(t, "test-egress", flowRecord.EgressName)
+		assert.Equal(t, "Deployment", flowRecord.SourcePodOwnerKind)
+		assert.Equal(t, "perftest-client", flowRecord.SourcePodOwnerName)
+		assert.Equal(t, "StatefulSet", flowRecord.DestinationPodOwnerKind)
+		assert.Equal(t, "perftest-server", flowRecord.DestinationPodOwnerName)
+		assert.Equal(t, "perftest.example.com", flowRecord.DestinationFQDN)
 
 		if isIPv4 {
 			assert.Equal(t, "10.10.0.79", flowRecord.SourceIP)
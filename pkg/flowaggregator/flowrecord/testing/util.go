@@ -73,5 +73,10 @@ func PrepareTestFlowRecord() *flowrecord.FlowRecord {
 		EgressName:                           "test-egress",
 		EgressIP:                             "172.18.0.1",
 		EgressNodeName:                       "test-egress-node",
+		SourcePodOwnerKind:                   "Deployment",
+		SourcePodOwnerName:                   "perftest-client",
+		DestinationPodOwnerKind:              "StatefulSet",
+		DestinationPodOwnerName:              "perftest-server",
+		DestinationFQDN:                      "perftest.example.com",
 	}
 }
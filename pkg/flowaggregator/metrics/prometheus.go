@@ -0,0 +1,93 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	metricNamespaceAntrea         = "antrea"
+	metricSubsystemFlowAggregator = "flow_aggregator"
+)
+
+var (
+	SpoolRecordCount = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemFlowAggregator,
+			Name:           "spool_record_count",
+			Help:           "Number of flow records stored in the disk spool of an exporter, waiting to be replayed.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"exporter"},
+	)
+
+	SpoolSizeBytes = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemFlowAggregator,
+			Name:           "spool_size_bytes",
+			Help:           "Disk space used by the spool of an exporter.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"exporter"},
+	)
+
+	SpoolReplayedRecordCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemFlowAggregator,
+			Name:           "spool_replayed_record_count",
+			Help:           "Number of flow records replayed from the disk spool of an exporter.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"exporter"},
+	)
+
+	SpoolDroppedRecordCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemFlowAggregator,
+			Name:           "spool_dropped_record_count",
+			Help:           "Number of flow records dropped by the disk spool of an exporter, because the spool was full or because records could not be read back.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"exporter"},
+	)
+)
+
+func InitializePrometheusMetrics() {
+	klog.Info("Initializing prometheus metrics")
+
+	InitializeSpoolMetrics()
+}
+
+func InitializeSpoolMetrics() {
+	if err := legacyregistry.Register(SpoolRecordCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_flow_aggregator_spool_record_count")
+	}
+	if err := legacyregistry.Register(SpoolSizeBytes); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_flow_aggregator_spool_size_bytes")
+	}
+	if err := legacyregistry.Register(SpoolReplayedRecordCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_flow_aggregator_spool_replayed_record_count")
+	}
+	if err := legacyregistry.Register(SpoolDroppedRecordCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_flow_aggregator_spool_dropped_record_count")
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"net"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
 
 	flowaggregatorconfig "antrea.io/antrea/v2/pkg/config/flowaggregator"
@@ -45,8 +46,16 @@ type Options struct {
 	TemplateRefreshTimeout time.Duration
 	// clickHouseCommitInterval flow records batch commit interval to clickhouse in the flow aggregator
 	ClickHouseCommitInterval time.Duration
+	// Maximum disk space used by the spool of the ClickHouse exporter, in bytes
+	ClickHouseSpoolMaxSize int64
 	// Flow records batch upload interval from flow aggregator to S3 bucket
 	S3UploadInterval time.Duration
+	// Maximum disk space used by the spool of the S3 exporter, in bytes
+	S3SpoolMaxSize int64
+	// Timeout for each request to the OTLP receiver
+	OTLPTimeout time.Duration
+	// Interval between exports of the OTLP metrics derived from flow records
+	OTLPMetricsExportInterval time.Duration
 }
 
 func LoadConfig(configBytes []byte) (*Options, error) {
@@ -62,7 +71,13 @@ func LoadConfig(configBytes []byte) (*Options, error) {
 	if opt.Config.S3Uploader.Enable && opt.Config.S3Uploader.BucketName == "" {
 		return nil, fmt.Errorf("s3Uploader enabled without specifying bucket name")
 	}
-	if !opt.Config.FlowCollector.Enable && !opt.Config.ClickHouse.Enable && !opt.Config.S3Uploader.Enable && !opt.Config.FlowLogger.Enable {
+	if opt.Config.Kafka.Enable && len(opt.Config.Kafka.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka enabled without providing brokers")
+	}
+	if opt.Config.OTLP.Enable && opt.Config.OTLP.Endpoint == "" {
+		return nil, fmt.Errorf("otlp enabled without providing endpoint")
+	}
+	if !opt.Config.FlowCollector.Enable && !opt.Config.ClickHouse.Enable && !opt.Config.S3Uploader.Enable && !opt.Config.FlowLogger.Enable && !opt.Config.Kafka.Enable && !opt.Config.OTLP.Enable {
 		klog.InfoS("No collector / sink has been configured, so no flow data will be exported")
 	}
 	// Validate common parameters
@@ -71,7 +86,7 @@ func LoadConfig(configBytes []byte) (*Options, error) {
 	}
 	opt.AggregatorMode = opt.Config.Mode
 	if opt.AggregatorMode == flowaggregatorconfig.AggregatorModeProxy {
-		if opt.Config.ClickHouse.Enable || opt.Config.S3Uploader.Enable || opt.Config.FlowLogger.Enable {
+		if opt.Config.ClickHouse.Enable || opt.Config.S3Uploader.Enable || opt.Config.FlowLogger.Enable || opt.Config.Kafka.Enable || opt.Config.OTLP.Enable {
 			return nil, fmt.Errorf("only flow collector is supported in Proxy mode")
 		}
 	}
@@ -146,6 +161,12 @@ func LoadConfig(configBytes []byte) (*Options, error) {
 			return nil, fmt.Errorf("commitInterval %s is too small: shortest supported interval is %v",
 				opt.Config.ClickHouse.CommitInterval, flowaggregatorconfig.MinClickHouseCommitInterval)
 		}
+		if opt.Config.ClickHouse.Spool.Enable {
+			opt.ClickHouseSpoolMaxSize, err = validateSpoolConfig(&opt.Config.ClickHouse.Spool)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	// Validate S3Uploader specific parameters
 	if opt.Config.S3Uploader.Enable {
@@ -160,6 +181,12 @@ func LoadConfig(configBytes []byte) (*Options, error) {
 			return nil, fmt.Errorf("uploadInterval %s is too small: shortest supported interval is %v",
 				opt.Config.S3Uploader.UploadInterval, flowaggregatorconfig.MinS3CommitInterval)
 		}
+		if opt.Config.S3Uploader.Spool.Enable {
+			opt.S3SpoolMaxSize, err = validateSpoolConfig(&opt.Config.S3Uploader.Spool)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	// Validate FlowLogger specific parameters
 	if opt.Config.FlowLogger.Enable {
@@ -167,5 +194,79 @@ func LoadConfig(configBytes []byte) (*Options, error) {
 			return nil, fmt.Errorf("record format %s is not supported", opt.Config.FlowLogger.RecordFormat)
 		}
 	}
+	// Validate Kafka specific parameters
+	if opt.Config.Kafka.Enable {
+		if opt.Config.Kafka.RecordFormat != "Protobuf" && opt.Config.Kafka.RecordFormat != "JSON" {
+			return nil, fmt.Errorf("record format %s is not supported", opt.Config.Kafka.RecordFormat)
+		}
+		if opt.Config.Kafka.PartitionKey != "FlowKey" && opt.Config.Kafka.PartitionKey != "Namespace" {
+			return nil, fmt.Errorf("partition key %s is not supported", opt.Config.Kafka.PartitionKey)
+		}
+		switch opt.Config.Kafka.Compression {
+		case "none", "gzip", "snappy", "lz4", "zstd":
+		default:
+			return nil, fmt.Errorf("compression %s is not supported", opt.Config.Kafka.Compression)
+		}
+		if opt.Config.Kafka.TLS.Enable {
+			if _, err := TLSVersion(opt.Config.Kafka.TLS.MinVersion); err != nil {
+				return nil, err
+			}
+		}
+		if opt.Config.Kafka.SASL.Enable {
+			switch opt.Config.Kafka.SASL.Mechanism {
+			case "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512":
+			default:
+				return nil, fmt.Errorf("SASL mechanism %s is not supported", opt.Config.Kafka.SASL.Mechanism)
+			}
+		}
+	}
+	// Validate OTLP specific parameters
+	if opt.Config.OTLP.Enable {
+		if opt.Config.OTLP.Protocol != "gRPC" && opt.Config.OTLP.Protocol != "HTTP" {
+			return nil, fmt.Errorf("OTLP protocol %s is not supported", opt.Config.OTLP.Protocol)
+		}
+		if _, _, err := net.SplitHostPort(opt.Config.OTLP.Endpoint); err != nil {
+			return nil, fmt.Errorf("endpoint %s is not valid: %w", opt.Config.OTLP.Endpoint, err)
+		}
+		opt.OTLPTimeout, err = time.ParseDuration(opt.Config.OTLP.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		if opt.OTLPTimeout <= 0 {
+			return nil, fmt.Errorf("timeout must be a positive duration")
+		}
+		if opt.Config.OTLP.TLS.Enable {
+			if _, err := TLSVersion(opt.Config.OTLP.TLS.MinVersion); err != nil {
+				return nil, err
+			}
+		}
+		if opt.Config.OTLP.Metrics.Enable {
+			opt.OTLPMetricsExportInterval, err = time.ParseDuration(opt.Config.OTLP.Metrics.ExportInterval)
+			if err != nil {
+				return nil, err
+			}
+			if opt.OTLPMetricsExportInterval < flowaggregatorconfig.MinOTLPMetricsExportInterval {
+				return nil, fmt.Errorf("exportInterval %s is too small: shortest supported interval is %v",
+					opt.Config.OTLP.Metrics.ExportInterval, flowaggregatorconfig.MinOTLPMetricsExportInterval)
+			}
+		}
+	}
 	return &opt, nil
 }
+
+// validateSpoolConfig validates the configuration of an exporter's spool, and returns its
+// maximum size in bytes.
+func validateSpoolConfig(spoolConfig *flowaggregatorconfig.SpoolConfig) (int64, error) {
+	maxSize, err := resource.ParseQuantity(spoolConfig.MaxSize)
+	if err != nil {
+		return 0, fmt.Errorf("spool maxSize %s is not valid: %w", spoolConfig.MaxSize, err)
+	}
+	if maxSize.Value() < flowaggregatorconfig.MinSpoolMaxSize {
+		return 0, fmt.Errorf("spool maxSize %s is too small: smallest supported size is %s",
+			spoolConfig.MaxSize, resource.NewQuantity(flowaggregatorconfig.MinSpoolMaxSize, resource.BinarySI))
+	}
+	if spoolConfig.OverflowPolicy != "DropOldest" && spoolConfig.OverflowPolicy != "DropNewest" {
+		return 0, fmt.Errorf("spool overflow policy %s is not supported", spoolConfig.OverflowPolicy)
+	}
+	return maxSize.Value(), nil
+}
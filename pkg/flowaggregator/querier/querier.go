@@ -28,6 +28,8 @@ type Metrics struct {
 	WithS3Exporter         bool
 	WithLogExporter        bool
 	WithIPFIXExporter      bool
+	WithKafkaExporter      bool
+	WithOTLPExporter       bool
 }
 
 type FlowAggregatorQuerier interface {
@@ -22,6 +22,7 @@ import (
 	"io"
 	"math/rand/v2"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -83,6 +84,16 @@ type S3UploadProcess struct {
 	// s3UploaderAPI wraps the call made by awsS3Uploader
 	s3UploaderAPI S3UploaderAPI
 	clusterUUID   string
+	// numBuffersToUpload is the length of buffersToUpload, which can be read without
+	// synchronizing with the upload goroutine.
+	numBuffersToUpload atomic.Int32
+	// uploadFailed is set when the last batch upload failed. Uploads without any buffer
+	// do not update it.
+	uploadFailed atomic.Bool
+	// cachedRecords is the number of records cached so far. It is protected by queueMutex.
+	cachedRecords uint64
+	// flushedRecords is the number of cached records which have been uploaded or dropped.
+	flushedRecords atomic.Uint64
 }
 
 type S3Input struct {
@@ -219,6 +230,7 @@ func (p *S3UploadProcess) CacheRecord(record *flowpb.Flow) error {
 	p.queueMutex.Lock()
 	defer p.queueMutex.Unlock()
 	p.writeRecordToBuffer(r)
+	p.cachedRecords++
 	// If the number of pending records in the buffer reaches maxRecordPerFile,
 	// add the buffer to bufferQueue.
 	if int32(p.cachedRecordCount) == p.maxRecordPerFile {
@@ -227,6 +239,27 @@ func (p *S3UploadProcess) CacheRecord(record *flowpb.Flow) error {
 	return nil
 }
 
+// Healthy returns false if the last attempt to upload cached records to S3 failed.
+func (p *S3UploadProcess) Healthy() bool {
+	return !p.uploadFailed.Load()
+}
+
+// AvailableCapacity returns the number of records which can still be cached before the
+// oldest buffers pending upload start being dropped.
+func (p *S3UploadProcess) AvailableCapacity() int {
+	p.queueMutex.Lock()
+	defer p.queueMutex.Unlock()
+	// The current buffer is queued for upload as well at the next upload.
+	freeBuffers := maxNumBuffersPendingUpload - len(p.bufferQueue) - int(p.numBuffersToUpload.Load())
+	return max(freeBuffers*int(p.maxRecordPerFile)-int(p.cachedRecordCount), 0)
+}
+
+// FlushedRecordCount returns the number of cached records which are no longer pending
+// upload, because they have been uploaded or dropped.
+func (p *S3UploadProcess) FlushedRecordCount() uint64 {
+	return p.flushedRecords.Load()
+}
+
 func (p *S3UploadProcess) Start() {
 	p.startExportProcess()
 }
@@ -277,24 +310,30 @@ func (p *S3UploadProcess) flowRecordPeriodicCommit() {
 			}
 			ctx, cancelFn := context.WithTimeout(ctx, bufferFlushTimeout)
 			defer cancelFn()
-			err := p.batchUploadAll(ctx)
+			_, err := p.batchUploadAll(ctx)
 			if err != nil {
 				klog.ErrorS(err, "Error when doing batchUploadAll on stop")
 			}
 			return
 		case <-p.uploadTicker.C:
-			err := p.batchUploadAll(ctx)
+			uploaded, err := p.batchUploadAll(ctx)
 			if err != nil {
 				klog.ErrorS(err, "Error when doing batchUploadAll on triggered timer")
 			}
+			// An upload without any buffer does not tell whether S3 can be reached.
+			if err != nil || uploaded > 0 {
+				p.uploadFailed.Store(err != nil)
+			}
 		}
 	}
 }
 
 // batchUploadAll uploads all buffers cached in bufferQueue and previous fail-
-// to-upload buffers stored in buffersToUpload. Returns error encountered
-// during upload if any.
-func (p *S3UploadProcess) batchUploadAll(ctx context.Context) error {
+// to-upload buffers stored in buffersToUpload. Returns the number of buffers
+// uploaded, and error encountered during upload if any.
+func (p *S3UploadProcess) batchUploadAll(ctx context.Context) (int, error) {
+	// All the records cached so far are flushed once buffersToUpload are uploaded.
+	var cachedRecords uint64
 	func() {
 		p.queueMutex.Lock()
 		defer p.queueMutex.Unlock()
@@ -310,6 +349,8 @@ func (p *S3UploadProcess) batchUploadAll(ctx context.Context) error {
 			}
 		}
 		p.bufferQueue = p.bufferQueue[:0]
+		p.numBuffersToUpload.Store(int32(len(p.buffersToUpload)))
+		cachedRecords = p.cachedRecords
 	}()
 
 	uploaded := 0
@@ -318,12 +359,15 @@ func (p *S3UploadProcess) batchUploadAll(ctx context.Context) error {
 		err := p.uploadFile(ctx, reader)
 		if err != nil {
 			p.buffersToUpload = p.buffersToUpload[uploaded:]
-			return err
+			p.numBuffersToUpload.Store(int32(len(p.buffersToUpload)))
+			return uploaded, err
 		}
 		uploaded += 1
 	}
 	p.buffersToUpload = p.buffersToUpload[:0]
-	return nil
+	p.numBuffersToUpload.Store(0)
+	p.flushedRecords.Store(cachedRecords)
+	return uploaded, nil
 }
 
 func (p *S3UploadProcess) writeRecordToBuffer(record *flowrecord.FlowRecord) {
@@ -487,4 +531,14 @@ func writeRecord(w io.Writer, r *flowrecord.FlowRecord, clusterUUID string) {
 	io.WriteString(w, r.EgressIP)
 	io.WriteString(w, ",")
 	io.WriteString(w, r.EgressNodeName)
+	io.WriteString(w, ",")
+	io.WriteString(w, r.SourcePodOwnerKind)
+	io.WriteString(w, ",")
+	io.WriteString(w, r.SourcePodOwnerName)
+	io.WriteString(w, ",")
+	io.WriteString(w, r.DestinationPodOwnerKind)
+	io.WriteString(w, ",")
+	io.WriteString(w, r.DestinationPodOwnerName)
+	io.WriteString(w, ",")
+	io.WriteString(w, r.DestinationFQDN)
 }
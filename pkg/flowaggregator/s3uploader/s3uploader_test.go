@@ -34,8 +34,8 @@ import (
 var (
 	timestampStr    = fmt.Sprint(time.Now().Unix())
 	fakeClusterUUID = uuid.New().String()
-	recordStrIPv4   = "1637706961,1637706973,1637706974,1637706975,3,10.10.0.79,10.10.0.80,44752,5201,6,823188,30472817041,241333,8982624938,471111,24500996,136211,7083284,perftest-a,antrea-test,k8s-node-control-plane,perftest-b,antrea-test-b,k8s-node-control-plane-b,10.10.1.10,5202,perftest,test-flow-aggregator-networkpolicy-ingress-allow,antrea-test-ns,test-flow-aggregator-networkpolicy-rule,2,1,test-flow-aggregator-networkpolicy-egress-allow,antrea-test-ns-e,test-flow-aggregator-networkpolicy-rule-e,1,3,TIME_WAIT,2,'{\"antrea-e2e\":\"perftest-a\",\"app\":\"iperf\"}','{\"antrea-e2e\":\"perftest-b\",\"app\":\"iperf\"}',15902813472,12381344,15902813473,15902813474,12381345,12381346," + fakeClusterUUID + "," + timestampStr + ",test-egress,172.18.0.1,test-egress-node"
-	recordStrIPv6   = "1637706961,1637706973,1637706974,1637706975,3,2001:0:3238:dfe1:63::fefb,2001:0:3238:dfe1:63::fefc,44752,5201,6,823188,30472817041,241333,8982624938,471111,24500996,136211,7083284,perftest-a,antrea-test,k8s-node-control-plane,perftest-b,antrea-test-b,k8s-node-control-plane-b,2001:0:3238:dfe1:64::a,5202,perftest,test-flow-aggregator-networkpolicy-ingress-allow,antrea-test-ns,test-flow-aggregator-networkpolicy-rule,2,1,test-flow-aggregator-networkpolicy-egress-allow,antrea-test-ns-e,test-flow-aggregator-networkpolicy-rule-e,1,3,TIME_WAIT,2,'{\"antrea-e2e\":\"perftest-a\",\"app\":\"iperf\"}','{\"antrea-e2e\":\"perftest-b\",\"app\":\"iperf\"}',15902813472,12381344,15902813473,15902813474,12381345,12381346," + fakeClusterUUID + "," + timestampStr + ",test-egress,2001:0:3238:dfe1::ac12:1,test-egress-node"
+	recordStrIPv4   = "1637706961,1637706973,1637706974,1637706975,3,10.10.0.79,10.10.0.80,44752,5201,6,823188,30472817041,241333,8982624938,471111,24500996,136211,7083284,perftest-a,antrea-test,k8s-node-control-plane,perftest-b,antrea-test-b,k8s-node-control-plane-b,10.10.1.10,5202,perftest,test-flow-aggregator-networkpolicy-ingress-allow,antrea-test-ns,test-flow-aggregator-networkpolicy-rule,2,1,test-flow-aggregator-networkpolicy-egress-allow,antrea-test-ns-e,test-flow-aggregator-networkpolicy-rule-e,1,3,TIME_WAIT,2,'{\"antrea-e2e\":\"perftest-a\",\"app\":\"iperf\"}','{\"antrea-e2e\":\"perftest-b\",\"app\":\"iperf\"}',15902813472,12381344,15902813473,15902813474,12381345,12381346," + fakeClusterUUID + "," + timestampStr + ",test-egress,172.18.0.1,test-egress-node,Deployment,perftest-client,StatefulSet,perftest-server,perftest.example.com"
+	recordStrIPv6   = "1637706961,1637706973,1637706974,1637706975,3,2001:0:3238:dfe1:63::fefb,2001:0:3238:dfe1:63::fefc,44752,5201,6,823188,30472817041,241333,8982624938,471111,24500996,136211,7083284,perftest-a,antrea-test,k8s-node-control-plane,perftest-b,antrea-test-b,k8s-node-control-plane-b,2001:0:3238:dfe1:64::a,5202,perftest,test-flow-aggregator-networkpolicy-ingress-allow,antrea-test-ns,test-flow-aggregator-networkpolicy-rule,2,1,test-flow-aggregator-networkpolicy-egress-allow,antrea-test-ns-e,test-flow-aggregator-networkpolicy-rule-e,1,3,TIME_WAIT,2,'{\"antrea-e2e\":\"perftest-a\",\"app\":\"iperf\"}','{\"antrea-e2e\":\"perftest-b\",\"app\":\"iperf\"}',15902813472,12381344,15902813473,15902813474,12381345,12381346," + fakeClusterUUID + "," + timestampStr + ",test-egress,2001:0:3238:dfe1::ac12:1,test-egress-node,Deployment,perftest-client,StatefulSet,perftest-server,perftest.example.com"
 )
 
 func TestUpdateS3Uploader(t *testing.T) {
@@ -109,12 +109,14 @@ func TestBatchUploadAll(t *testing.T) {
 	s3UploadProc.CacheRecord(record)
 	assert.EqualValues(t, 1, s3UploadProc.cachedRecordCount)
 
-	err := s3UploadProc.batchUploadAll(t.Context())
+	uploaded, err := s3UploadProc.batchUploadAll(t.Context())
 	assert.NoError(t, err)
+	assert.Equal(t, 1, uploaded)
 	assert.Equal(t, 0, len(s3UploadProc.bufferQueue))
 	assert.Equal(t, 0, len(s3UploadProc.buffersToUpload))
 	assert.Equal(t, "", s3UploadProc.currentBuffer.String())
 	assert.EqualValues(t, 0, s3UploadProc.cachedRecordCount)
+	assert.EqualValues(t, 1, s3UploadProc.FlushedRecordCount())
 }
 
 func TestBatchUploadAllPartialSuccess(t *testing.T) {
@@ -138,10 +140,13 @@ func TestBatchUploadAllPartialSuccess(t *testing.T) {
 	record = flowaggregatortesting.PrepareTestFlowRecord(false)
 	s3UploadProc.CacheRecord(record)
 
-	err := s3UploadProc.batchUploadAll(t.Context())
+	uploaded, err := s3UploadProc.batchUploadAll(t.Context())
+	assert.Equal(t, 1, uploaded)
 	assert.Equal(t, 0, len(s3UploadProc.bufferQueue))
 	assert.Equal(t, 1, len(s3UploadProc.buffersToUpload))
 	assert.EqualError(t, err, "error when uploading file to S3: random error")
+	// Records are only flushed once all the pending buffers have been uploaded.
+	assert.Zero(t, s3UploadProc.FlushedRecordCount())
 }
 
 func TestBatchUploadAllError(t *testing.T) {
@@ -164,7 +169,7 @@ func TestBatchUploadAllError(t *testing.T) {
 	s3UploadProc.CacheRecord(record)
 	assert.EqualValues(t, 1, s3UploadProc.cachedRecordCount)
 
-	err := s3UploadProc.batchUploadAll(t.Context())
+	_, err := s3UploadProc.batchUploadAll(t.Context())
 	assert.Equal(t, 1, len(s3UploadProc.buffersToUpload))
 	assert.Equal(t, 0, len(s3UploadProc.bufferQueue))
 	assert.Equal(t, "", s3UploadProc.currentBuffer.String())
@@ -173,6 +178,64 @@ func TestBatchUploadAllError(t *testing.T) {
 	assert.Contains(t, err.Error(), expectedErrMsg)
 }
 
+func TestAvailableCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockS3Uploader := s3uploadertesting.NewMockS3UploaderAPI(ctrl)
+	mockS3Uploader.EXPECT().Upload(gomock.Any(), gomock.Any(), nil).Return(nil, fmt.Errorf("random error"))
+	s3UploadProc := S3UploadProcess{
+		compress:         false,
+		maxRecordPerFile: 2,
+		currentBuffer:    &bytes.Buffer{},
+		bufferQueue:      make([]*bytes.Buffer, 0),
+		buffersToUpload:  make([]*bytes.Buffer, 0, maxNumBuffersPendingUpload),
+		s3UploaderAPI:    mockS3Uploader,
+		clusterUUID:      fakeClusterUUID,
+	}
+	assert.Equal(t, 10, s3UploadProc.AvailableCapacity())
+	record := flowaggregatortesting.PrepareTestFlowRecord(true)
+	s3UploadProc.CacheRecord(record)
+	assert.Equal(t, 9, s3UploadProc.AvailableCapacity())
+	s3UploadProc.CacheRecord(record)
+	s3UploadProc.CacheRecord(record)
+	assert.Equal(t, 7, s3UploadProc.AvailableCapacity())
+
+	// Buffers which failed to upload still count against the capacity.
+	_, err := s3UploadProc.batchUploadAll(t.Context())
+	assert.Error(t, err)
+	assert.Equal(t, 2, len(s3UploadProc.buffersToUpload))
+	assert.Equal(t, 6, s3UploadProc.AvailableCapacity())
+}
+
+func TestHealthy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockS3Uploader := s3uploadertesting.NewMockS3UploaderAPI(ctrl)
+	gomock.InOrder(
+		mockS3Uploader.EXPECT().Upload(gomock.Any(), gomock.Any(), nil).Return(nil, fmt.Errorf("random error")),
+		mockS3Uploader.EXPECT().Upload(gomock.Any(), gomock.Any(), nil).Return(nil, nil),
+	)
+	s3UploadProc := S3UploadProcess{
+		compress:         false,
+		maxRecordPerFile: 10,
+		uploadInterval:   100 * time.Millisecond,
+		currentBuffer:    &bytes.Buffer{},
+		bufferQueue:      make([]*bytes.Buffer, 0),
+		buffersToUpload:  make([]*bytes.Buffer, 0, maxNumBuffersPendingUpload),
+		s3UploaderAPI:    mockS3Uploader,
+		clusterUUID:      fakeClusterUUID,
+	}
+	assert.True(t, s3UploadProc.Healthy())
+	record := flowaggregatortesting.PrepareTestFlowRecord(true)
+	s3UploadProc.CacheRecord(record)
+
+	s3UploadProc.startExportProcess()
+	defer s3UploadProc.stopExportProcess(false)
+	assert.Eventually(t, func() bool {
+		return !s3UploadProc.Healthy()
+	}, 1*time.Second, 10*time.Millisecond)
+	// The failed upload is retried at the next interval.
+	assert.Eventually(t, s3UploadProc.Healthy, 1*time.Second, 10*time.Millisecond)
+}
+
 func TestFlowRecordPeriodicCommit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockS3Uploader := s3uploadertesting.NewMockS3UploaderAPI(ctrl)
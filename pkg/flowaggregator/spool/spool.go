@@ -0,0 +1,603 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spool implements a durable FIFO queue of flow records, used by
+// exporters to keep records on disk while their sink is unavailable.
+//
+// Records are appended to a sequence of segment files in the spool directory.
+// Each record is stored as a frame made of a 4-byte length, a 4-byte CRC32C
+// checksum of the payload and the Protobuf encoding of the record. Records
+// are returned by Peek, and only removed from the spool once they are
+// acknowledged with Ack, after they have been written to the destination. The
+// position of the oldest unacknowledged record is persisted in a cursor file,
+// so that records which have already been acknowledged are not replayed after
+// a restart. Delivery is at-least-once: records which have been peeked but not
+// acknowledged before a restart are returned again.
+package spool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+
+	flowpb "antrea.io/antrea/v2/pkg/apis/flow/v1alpha1"
+)
+
+type OverflowPolicy string
+
+const (
+	// OverflowPolicyDropOldest discards the oldest spooled records, one segment at a time,
+	// to make room for new records.
+	OverflowPolicyDropOldest OverflowPolicy = "DropOldest"
+	// OverflowPolicyDropNewest discards new records until enough room is available.
+	OverflowPolicyDropNewest OverflowPolicy = "DropNewest"
+)
+
+const (
+	segmentFileSuffix = ".seg"
+	cursorFileName    = "cursor"
+	frameHeaderSize   = 8
+	// maxSegmentSize is the maximum size of a segment file. Segments are rotated once
+	// they reach this size, and are removed once all their records have been read.
+	maxSegmentSize = 16 << 20
+	// minSegmentsPerSpool ensures that segments are small enough compared to the maximum
+	// size of the spool, as OverflowPolicyDropOldest discards a whole segment at a time.
+	minSegmentsPerSpool = 8
+	// maxRecordSize bounds the size of a frame read from disk, so that a corrupted length
+	// cannot cause a huge allocation.
+	maxRecordSize = 1 << 20
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+var errCorruptedRecord = errors.New("corrupted record")
+
+type segment struct {
+	id uint64
+	// size is the number of bytes in the segment file.
+	size int64
+	// records is the number of unacknowledged records in the segment.
+	records int64
+	// peeked is the number of records in the segment which have been returned by Peek,
+	// but have not been acknowledged yet.
+	peeked int64
+}
+
+// peekedRecord is a record which has been returned by Peek, but has not been
+// acknowledged yet.
+type peekedRecord struct {
+	segmentID uint64
+	size      int64
+}
+
+// Spool is a durable FIFO queue of flow records, bounded in size. It is safe for
+// concurrent use, but records are expected to be appended, peeked and acknowledged
+// by a single goroutine.
+type Spool struct {
+	dir            string
+	maxSize        int64
+	segmentSize    int64
+	overflowPolicy OverflowPolicy
+
+	mutex sync.Mutex
+	// segments are ordered from oldest to newest. The last segment is the one being
+	// written to. There is always at least one segment.
+	segments []*segment
+	writer   *os.File
+	bufw     *bufio.Writer
+	reader   *os.File
+	bufr     *bufio.Reader
+	// readOffset is the offset of the oldest unacknowledged record in the first segment.
+	readOffset int64
+	// peekID and peekOffset are the segment and the offset of the next record returned
+	// by Peek. The reader is opened at this position.
+	peekID     uint64
+	peekOffset int64
+	// peeked are the records returned by Peek which have not been acknowledged yet, in
+	// order.
+	peeked []peekedRecord
+	// nextID is the ID of the next segment to create.
+	nextID     uint64
+	numRecords int64
+	size       int64
+	buf        []byte
+}
+
+// Open opens the spool stored in dir, creating the directory if needed. Records left
+// over by a previous instance are preserved, and will be read before new records. A
+// truncated record at the end of a segment, e.g. because of a crash during a write, is
+// discarded.
+func Open(dir string, maxSize int64, overflowPolicy OverflowPolicy) (*Spool, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("invalid max size %d for spool", maxSize)
+	}
+	if overflowPolicy != OverflowPolicyDropOldest && overflowPolicy != OverflowPolicyDropNewest {
+		return nil, fmt.Errorf("invalid overflow policy %q for spool", overflowPolicy)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error when creating spool directory: %w", err)
+	}
+	s := &Spool{
+		dir:            dir,
+		maxSize:        maxSize,
+		segmentSize:    max(min(maxSize/minSegmentsPerSpool, maxSegmentSize), 1),
+		overflowPolicy: overflowPolicy,
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if err := s.newSegment(); err != nil {
+		return nil, err
+	}
+	s.peekID = s.segments[0].id
+	s.peekOffset = s.readOffset
+	return s, nil
+}
+
+func (s *Spool) segmentPath(id uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%016x%s", id, segmentFileSuffix))
+}
+
+// load scans the existing segments and restores the read position from the cursor file.
+func (s *Spool) load() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("error when reading spool directory: %w", err)
+	}
+	var ids []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentFileSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, segmentFileSuffix), 16, 64)
+		if err != nil {
+			klog.InfoS("Ignoring unexpected file in spool directory", "dir", s.dir, "file", name)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	cursorID, cursorOffset, err := s.readCursor()
+	if err != nil {
+		return err
+	}
+	// New segments always come after the one referenced by the cursor, so that a stale
+	// offset is never applied to them.
+	s.nextID = cursorID + 1
+	if len(ids) > 0 {
+		s.nextID = max(s.nextID, ids[len(ids)-1]+1)
+	}
+	for _, id := range ids {
+		if id < cursorID {
+			if err := os.Remove(s.segmentPath(id)); err != nil {
+				return fmt.Errorf("error when removing spool segment: %w", err)
+			}
+			continue
+		}
+		var offset int64
+		if id == cursorID {
+			offset = cursorOffset
+		}
+		seg, err := s.scanSegment(id, offset)
+		if err != nil {
+			return err
+		}
+		if seg.records == 0 {
+			if err := os.Remove(s.segmentPath(id)); err != nil {
+				return fmt.Errorf("error when removing spool segment: %w", err)
+			}
+			continue
+		}
+		if len(s.segments) == 0 {
+			s.readOffset = offset
+		}
+		s.segments = append(s.segments, seg)
+		s.numRecords += seg.records
+		s.size += seg.size
+	}
+	if s.numRecords > 0 {
+		klog.InfoS("Loaded spooled records", "dir", s.dir, "records", s.numRecords, "bytes", s.size)
+	}
+	return nil
+}
+
+// scanSegment counts the records in a segment file, starting at offset. The file is
+// truncated after the last valid record.
+func (s *Spool) scanSegment(id uint64, offset int64) (*segment, error) {
+	path := s.segmentPath(id)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error when opening spool segment: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("error when reading spool segment: %w", err)
+	}
+	seg := &segment{id: id, size: info.Size()}
+	if offset > seg.size {
+		offset = seg.size
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error when reading spool segment: %w", err)
+	}
+	r := bufio.NewReader(f)
+	validSize := offset
+	for {
+		frameSize, err := readFrame(r, &s.buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			klog.ErrorS(err, "Discarding the end of spool segment", "segment", path, "offset", validSize)
+			break
+		}
+		validSize += frameSize
+		seg.records++
+	}
+	if validSize < seg.size {
+		if err := os.Truncate(path, validSize); err != nil {
+			return nil, fmt.Errorf("error when truncating spool segment: %w", err)
+		}
+		seg.size = validSize
+	}
+	return seg, nil
+}
+
+func (s *Spool) readCursor() (uint64, int64, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, cursorFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("error when reading spool cursor: %w", err)
+	}
+	if len(data) != 16 {
+		klog.InfoS("Ignoring invalid spool cursor", "dir", s.dir)
+		return 0, 0, nil
+	}
+	return binary.BigEndian.Uint64(data[:8]), int64(binary.BigEndian.Uint64(data[8:])), nil
+}
+
+func (s *Spool) writeCursor() error {
+	data := make([]byte, 16)
+	binary.BigEndian.PutUint64(data[:8], s.segments[0].id)
+	binary.BigEndian.PutUint64(data[8:], uint64(s.readOffset))
+	path := filepath.Join(s.dir, cursorFileName)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("error when writing spool cursor: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("error when writing spool cursor: %w", err)
+	}
+	return nil
+}
+
+// newSegment closes the segment being written to, if any, and creates a new one.
+func (s *Spool) newSegment() error {
+	id := s.nextID
+	if s.writer != nil {
+		if err := s.closeWriter(); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(s.segmentPath(id), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("error when creating spool segment: %w", err)
+	}
+	s.writer = f
+	if s.bufw == nil {
+		s.bufw = bufio.NewWriter(f)
+	} else {
+		s.bufw.Reset(f)
+	}
+	s.segments = append(s.segments, &segment{id: id})
+	s.nextID++
+	return nil
+}
+
+func (s *Spool) closeWriter() error {
+	err := s.bufw.Flush()
+	if err == nil {
+		err = s.writer.Sync()
+	}
+	if closeErr := s.writer.Close(); err == nil {
+		err = closeErr
+	}
+	s.writer = nil
+	if err != nil {
+		return fmt.Errorf("error when closing spool segment: %w", err)
+	}
+	return nil
+}
+
+func (s *Spool) closeReader() {
+	if s.reader != nil {
+		s.reader.Close()
+		s.reader = nil
+	}
+}
+
+// removeOldestSegment removes the first segment, and returns the number of
+// unacknowledged records it contained which had not been peeked yet. If it is the
+// segment being written to, a new segment is created first.
+func (s *Spool) removeOldestSegment() (int64, error) {
+	if len(s.segments) == 1 {
+		if err := s.newSegment(); err != nil {
+			return 0, err
+		}
+	}
+	seg := s.segments[0]
+	s.closeReader()
+	if err := os.Remove(s.segmentPath(seg.id)); err != nil {
+		return 0, fmt.Errorf("error when removing spool segment: %w", err)
+	}
+	s.segments = s.segments[1:]
+	s.readOffset = 0
+	if s.peekID == seg.id {
+		s.peekID = s.segments[0].id
+		s.peekOffset = 0
+	}
+	s.numRecords -= seg.records
+	s.size -= seg.size
+	return seg.records - seg.peeked, nil
+}
+
+// compact removes the oldest segments which no longer have unacknowledged records, and
+// reclaims all the disk space as soon as the spool is empty.
+func (s *Spool) compact() error {
+	for (len(s.segments) > 1 && s.segments[0].records == 0) || (s.numRecords == 0 && s.segments[0].size > 0) {
+		if _, err := s.removeOldestSegment(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Append adds records to the spool, and returns the number of records which were
+// dropped according to the overflow policy. The records are flushed to disk before
+// Append returns.
+func (s *Spool) Append(records []*flowpb.Flow) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var dropped int64
+	for _, record := range records {
+		data, err := proto.Marshal(record)
+		if err != nil {
+			return dropped, fmt.Errorf("error when encoding record: %w", err)
+		}
+		frameSize := int64(frameHeaderSize + len(data))
+		if frameSize > s.maxSize || len(data) > maxRecordSize {
+			dropped++
+			continue
+		}
+		if s.size+frameSize > s.maxSize {
+			if s.overflowPolicy == OverflowPolicyDropNewest {
+				dropped++
+				continue
+			}
+			for s.size+frameSize > s.maxSize {
+				n, err := s.removeOldestSegment()
+				if err != nil {
+					return dropped, err
+				}
+				dropped += n
+			}
+		}
+		tail := s.segments[len(s.segments)-1]
+		if tail.size > 0 && tail.size+frameSize > s.segmentSize {
+			if err := s.newSegment(); err != nil {
+				return dropped, err
+			}
+			tail = s.segments[len(s.segments)-1]
+		}
+		var header [frameHeaderSize]byte
+		binary.BigEndian.PutUint32(header[:4], uint32(len(data)))
+		binary.BigEndian.PutUint32(header[4:], crc32.Checksum(data, crc32cTable))
+		if _, err := s.bufw.Write(header[:]); err != nil {
+			return dropped, fmt.Errorf("error when writing to spool segment: %w", err)
+		}
+		if _, err := s.bufw.Write(data); err != nil {
+			return dropped, fmt.Errorf("error when writing to spool segment: %w", err)
+		}
+		tail.size += frameSize
+		tail.records++
+		s.size += frameSize
+		s.numRecords++
+	}
+	if err := s.bufw.Flush(); err != nil {
+		return dropped, fmt.Errorf("error when writing to spool segment: %w", err)
+	}
+	if err := s.writer.Sync(); err != nil {
+		return dropped, fmt.Errorf("error when syncing spool segment: %w", err)
+	}
+	return dropped, nil
+}
+
+// Peek stores in out up to len(out) records from the spool, in the order in which
+// they were appended, starting after the records returned by previous calls which
+// have not been acknowledged yet. Records remain in the spool until they are
+// acknowledged with Ack. It returns the number of records stored in out, and the
+// number of records which were dropped because they could not be decoded.
+func (s *Spool) Peek(out []*flowpb.Flow) (int, int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	n := 0
+	var dropped int64
+	for n < len(out) {
+		i := slices.IndexFunc(s.segments, func(seg *segment) bool { return seg.id == s.peekID })
+		seg := s.segments[i]
+		if seg.peeked == seg.records {
+			if i == len(s.segments)-1 {
+				break
+			}
+			s.closeReader()
+			s.peekID = s.segments[i+1].id
+			s.peekOffset = 0
+			continue
+		}
+		if s.reader == nil {
+			f, err := os.Open(s.segmentPath(seg.id))
+			if err != nil {
+				return n, dropped, fmt.Errorf("error when opening spool segment: %w", err)
+			}
+			if _, err := f.Seek(s.peekOffset, io.SeekStart); err != nil {
+				f.Close()
+				return n, dropped, fmt.Errorf("error when reading spool segment: %w", err)
+			}
+			s.reader = f
+			if s.bufr == nil {
+				s.bufr = bufio.NewReader(f)
+			} else {
+				s.bufr.Reset(f)
+			}
+		}
+		frameSize, err := readFrame(s.bufr, &s.buf)
+		var record *flowpb.Flow
+		if err == nil {
+			record = &flowpb.Flow{}
+			err = proto.Unmarshal(s.buf, record)
+		}
+		if err != nil {
+			// The rest of the segment cannot be trusted.
+			remaining := seg.records - seg.peeked
+			klog.ErrorS(err, "Discarding the end of spool segment", "segment", s.segmentPath(seg.id), "offset", s.peekOffset, "records", remaining)
+			dropped += remaining
+			s.numRecords -= remaining
+			seg.records -= remaining
+			if i == len(s.segments)-1 {
+				// New records must not be appended after the corrupted data.
+				if err := s.newSegment(); err != nil {
+					return n, dropped, err
+				}
+			}
+			continue
+		}
+		s.peekOffset += frameSize
+		seg.peeked++
+		s.peeked = append(s.peeked, peekedRecord{segmentID: seg.id, size: frameSize})
+		out[n] = record
+		n++
+	}
+	if dropped > 0 {
+		if err := s.compact(); err != nil {
+			return n, dropped, err
+		}
+		if err := s.writeCursor(); err != nil {
+			return n, dropped, err
+		}
+	}
+	return n, dropped, nil
+}
+
+// Ack removes the n oldest records returned by Peek from the spool. It should be called
+// once these records have been written to the destination.
+func (s *Spool) Ack(n int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if n > len(s.peeked) {
+		return fmt.Errorf("cannot acknowledge %d records, only %d records are pending acknowledgement", n, len(s.peeked))
+	}
+	for _, record := range s.peeked[:n] {
+		// The segment may have been dropped already, according to the overflow policy.
+		if record.segmentID < s.segments[0].id {
+			continue
+		}
+		// All the records of the previous segments have been acknowledged.
+		for s.segments[0].id != record.segmentID {
+			if _, err := s.removeOldestSegment(); err != nil {
+				return err
+			}
+		}
+		seg := s.segments[0]
+		seg.records--
+		seg.peeked--
+		s.numRecords--
+		s.readOffset += record.size
+	}
+	s.peeked = slices.Delete(s.peeked, 0, n)
+	if err := s.compact(); err != nil {
+		return err
+	}
+	return s.writeCursor()
+}
+
+// readFrame reads a single frame, and stores its payload in buf. It returns io.EOF
+// if there is no more data to read.
+func readFrame(r *bufio.Reader, buf *[]byte) (int64, error) {
+	var header [frameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.EOF {
+			return 0, io.EOF
+		}
+		return 0, fmt.Errorf("%w: %v", errCorruptedRecord, err)
+	}
+	length := binary.BigEndian.Uint32(header[:4])
+	if length > maxRecordSize {
+		return 0, fmt.Errorf("%w: invalid length %d", errCorruptedRecord, length)
+	}
+	if cap(*buf) < int(length) {
+		*buf = make([]byte, length)
+	}
+	*buf = (*buf)[:length]
+	if _, err := io.ReadFull(r, *buf); err != nil {
+		return 0, fmt.Errorf("%w: %v", errCorruptedRecord, err)
+	}
+	if crc32.Checksum(*buf, crc32cTable) != binary.BigEndian.Uint32(header[4:]) {
+		return 0, fmt.Errorf("%w: checksum mismatch", errCorruptedRecord)
+	}
+	return int64(frameHeaderSize) + int64(length), nil
+}
+
+// Len returns the number of records in the spool, including the records which have
+// been peeked but not acknowledged yet.
+func (s *Spool) Len() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.numRecords
+}
+
+// Size returns the disk space used by the spool, in bytes.
+func (s *Spool) Size() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.size
+}
+
+// Close flushes pending writes and closes the spool files. Spooled records are
+// preserved, and can be read after opening the spool again.
+func (s *Spool) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closeReader()
+	if s.writer == nil {
+		return nil
+	}
+	return s.closeWriter()
+}
@@ -0,0 +1,282 @@
+// Copyright 2026 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	flowpb "antrea.io/antrea/v2/pkg/apis/flow/v1alpha1"
+)
+
+// basePort is added to the source port of the records returned by newRecords, so that
+// all the records have the same size.
+const basePort = 1000
+
+func newRecords(start, count int) []*flowpb.Flow {
+	records := make([]*flowpb.Flow, count)
+	for i := range records {
+		records[i] = &flowpb.Flow{
+			Id: "flow",
+			Ip: &flowpb.IP{
+				Source:      []byte{10, 0, 0, 1},
+				Destination: []byte{10, 0, 0, 2},
+			},
+			Transport: &flowpb.Transport{
+				ProtocolNumber:  6,
+				SourcePort:      uint32(basePort + start + i),
+				DestinationPort: 80,
+			},
+		}
+	}
+	return records
+}
+
+// frameSize returns the size of the frame used to store each record returned by newRecords.
+func frameSize(t *testing.T) int64 {
+	data, err := proto.Marshal(newRecords(0, 1)[0])
+	require.NoError(t, err)
+	return int64(frameHeaderSize + len(data))
+}
+
+// readAll peeks and acknowledges all the records in the spool.
+func readAll(t *testing.T, s *Spool) []*flowpb.Flow {
+	var records []*flowpb.Flow
+	out := make([]*flowpb.Flow, 3)
+	for {
+		n, dropped, err := s.Peek(out)
+		require.NoError(t, err)
+		assert.Zero(t, dropped)
+		if n == 0 {
+			return records
+		}
+		require.NoError(t, s.Ack(n))
+		records = append(records, out[:n]...)
+	}
+}
+
+func sourcePorts(records []*flowpb.Flow) []uint32 {
+	ports := make([]uint32, len(records))
+	for i, record := range records {
+		ports[i] = record.Transport.SourcePort - basePort
+	}
+	return ports
+}
+
+func portRange(start, count int) []uint32 {
+	ports := make([]uint32, count)
+	for i := range ports {
+		ports[i] = uint32(start + i)
+	}
+	return ports
+}
+
+func countSegments(t *testing.T, dir string) int {
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+segmentFileSuffix))
+	require.NoError(t, err)
+	return len(matches)
+}
+
+func TestOpenInvalidArguments(t *testing.T) {
+	_, err := Open(t.TempDir(), 0, OverflowPolicyDropOldest)
+	assert.ErrorContains(t, err, "invalid max size")
+	_, err = Open(t.TempDir(), 1024, OverflowPolicy("Block"))
+	assert.ErrorContains(t, err, "invalid overflow policy")
+}
+
+func TestAppendAndPeek(t *testing.T) {
+	dir := t.TempDir()
+	// Small segments, so that records span multiple segments.
+	s, err := Open(dir, 64*frameSize(t), OverflowPolicyDropOldest)
+	require.NoError(t, err)
+	defer s.Close()
+
+	dropped, err := s.Append(newRecords(0, 20))
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+	assert.EqualValues(t, 20, s.Len())
+	assert.Equal(t, 20*frameSize(t), s.Size())
+	assert.Greater(t, countSegments(t, dir), 1)
+
+	out := make([]*flowpb.Flow, 5)
+	n, dropped, err := s.Peek(out)
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+	require.Equal(t, 5, n)
+	assert.Equal(t, portRange(0, 5), sourcePorts(out))
+	assert.True(t, proto.Equal(newRecords(0, 1)[0], out[0]))
+	// Peeked records remain in the spool until they are acknowledged.
+	assert.EqualValues(t, 20, s.Len())
+	assert.ErrorContains(t, s.Ack(6), "only 5 records are pending acknowledgement")
+	require.NoError(t, s.Ack(5))
+	assert.EqualValues(t, 15, s.Len())
+
+	// Records appended while reading are read in order.
+	_, err = s.Append(newRecords(20, 5))
+	require.NoError(t, err)
+	assert.Equal(t, portRange(5, 20), sourcePorts(readAll(t, s)))
+	assert.Zero(t, s.Len())
+	// All the disk space is reclaimed once the spool is empty.
+	assert.Zero(t, s.Size())
+	assert.Equal(t, 1, countSegments(t, dir))
+}
+
+func TestReopen(t *testing.T) {
+	dir := t.TempDir()
+	maxSize := 64 * frameSize(t)
+	s, err := Open(dir, maxSize, OverflowPolicyDropOldest)
+	require.NoError(t, err)
+	_, err = s.Append(newRecords(0, 20))
+	require.NoError(t, err)
+	out := make([]*flowpb.Flow, 15)
+	n, _, err := s.Peek(out)
+	require.NoError(t, err)
+	require.Equal(t, 15, n)
+	require.NoError(t, s.Ack(12))
+	require.NoError(t, s.Close())
+
+	// Records which have been peeked but not acknowledged are read again.
+	s, err = Open(dir, maxSize, OverflowPolicyDropOldest)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, s.Len())
+	n, _, err = s.Peek(out[:2])
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	assert.Equal(t, portRange(12, 2), sourcePorts(out[:2]))
+	_, err = s.Append(newRecords(20, 2))
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	// Records which have already been acknowledged are not read again.
+	s, err = Open(dir, maxSize, OverflowPolicyDropOldest)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.EqualValues(t, 10, s.Len())
+	assert.Equal(t, portRange(12, 10), sourcePorts(readAll(t, s)))
+}
+
+func TestReopenTruncatedSegment(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, 1<<20, OverflowPolicyDropOldest)
+	require.NoError(t, err)
+	_, err = s.Append(newRecords(0, 3))
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	// Simulate a crash in the middle of a write.
+	path := s.segmentPath(s.segments[0].id)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-3))
+
+	s, err = Open(dir, 1<<20, OverflowPolicyDropOldest)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.EqualValues(t, 2, s.Len())
+	assert.Equal(t, 2*frameSize(t), s.Size())
+	_, err = s.Append(newRecords(3, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{0, 1, 3}, sourcePorts(readAll(t, s)))
+}
+
+func TestReadCorruptedRecord(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, 64*frameSize(t), OverflowPolicyDropOldest)
+	require.NoError(t, err)
+	defer s.Close()
+	// With a max size of 64 records, each segment holds 8 records.
+	_, err = s.Append(newRecords(0, 12))
+	require.NoError(t, err)
+
+	// Corrupt the payload of the second record.
+	f, err := os.OpenFile(s.segmentPath(s.segments[0].id), os.O_RDWR, 0)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{0xff}, frameSize(t)+frameHeaderSize)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	out := make([]*flowpb.Flow, 20)
+	n, dropped, err := s.Peek(out)
+	require.NoError(t, err)
+	// The rest of the first segment is discarded.
+	assert.EqualValues(t, 7, dropped)
+	assert.Equal(t, append([]uint32{0}, portRange(8, 4)...), sourcePorts(out[:n]))
+	assert.EqualValues(t, 5, s.Len())
+	require.NoError(t, s.Ack(n))
+	assert.Zero(t, s.Len())
+	assert.Zero(t, s.Size())
+}
+
+func TestOverflowPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy          OverflowPolicy
+		expectedDropped int64
+		expectedPorts   []uint32
+	}{
+		{
+			policy: OverflowPolicyDropOldest,
+			// The first segment (8 records) is discarded to make room for the new records.
+			expectedDropped: 8,
+			expectedPorts:   portRange(8, 62),
+		},
+		{
+			policy:          OverflowPolicyDropNewest,
+			expectedDropped: 6,
+			expectedPorts:   portRange(0, 64),
+		},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			s, err := Open(t.TempDir(), 64*frameSize(t), tc.policy)
+			require.NoError(t, err)
+			defer s.Close()
+			dropped, err := s.Append(newRecords(0, 60))
+			require.NoError(t, err)
+			assert.Zero(t, dropped)
+			dropped, err = s.Append(newRecords(60, 10))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDropped, dropped)
+			assert.LessOrEqual(t, s.Size(), 64*frameSize(t))
+			assert.Equal(t, tc.expectedPorts, sourcePorts(readAll(t, s)))
+		})
+	}
+}
+
+func TestOverflowPeekedRecords(t *testing.T) {
+	s, err := Open(t.TempDir(), 64*frameSize(t), OverflowPolicyDropOldest)
+	require.NoError(t, err)
+	defer s.Close()
+	_, err = s.Append(newRecords(0, 60))
+	require.NoError(t, err)
+	out := make([]*flowpb.Flow, 10)
+	n, _, err := s.Peek(out)
+	require.NoError(t, err)
+	require.Equal(t, 10, n)
+
+	// The first segment is discarded, but its records have all been peeked already.
+	dropped, err := s.Append(newRecords(60, 10))
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+	assert.EqualValues(t, 62, s.Len())
+	// The discarded records can still be acknowledged.
+	require.NoError(t, s.Ack(10))
+	assert.EqualValues(t, 60, s.Len())
+	assert.Equal(t, portRange(10, 60), sourcePorts(readAll(t, s)))
+	assert.Zero(t, s.Len())
+}
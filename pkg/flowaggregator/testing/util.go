@@ -96,6 +96,11 @@ func PrepareTestFlowRecord(isIPv4 bool) *flowpb.Flow {
 			EgressName:                     "test-egress",
 			EgressIp:                       egressIP.AsSlice(),
 			EgressNodeName:                 "test-egress-node",
+			SourcePodOwnerKind:             "Deployment",
+			SourcePodOwnerName:             "perftest-client",
+			DestinationPodOwnerKind:        "StatefulSet",
+			DestinationPodOwnerName:        "perftest-server",
+			DestinationFqdn:                "perftest.example.com",
 		},
 		Stats: &flowpb.Stats{
 			PacketTotalCount: 823188,
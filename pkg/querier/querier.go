@@ -16,6 +16,8 @@ package querier
 
 import (
 	"context"
+	"io"
+	"net/netip"
 	"regexp"
 
 	v1 "k8s.io/api/core/v1"
@@ -29,6 +31,7 @@ import (
 	"antrea.io/antrea/v2/pkg/agent/multicast"
 	"antrea.io/antrea/v2/pkg/agent/types"
 	cpv1beta "antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+	crdv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
 	"antrea.io/antrea/v2/pkg/util/env"
 	"antrea.io/antrea/v2/pkg/version"
 )
@@ -42,6 +45,7 @@ type NetworkPolicyInfoQuerier interface {
 type AgentNetworkPolicyInfoQuerier interface {
 	NetworkPolicyInfoQuerier
 	GetControllerConnectionStatus() bool
+	GetL7EngineHealth() error
 	GetNetworkPolicies(npFilter *NetworkPolicyQueryFilter) []cpv1beta.NetworkPolicy
 	GetAddressGroups() []cpv1beta.AddressGroup
 	GetAppliedToGroups() []cpv1beta.AppliedToGroup
@@ -49,6 +53,9 @@ type AgentNetworkPolicyInfoQuerier interface {
 	GetNetworkPolicyByRuleFlowID(ruleFlowID uint32) *cpv1beta.NetworkPolicyReference
 	GetRuleByFlowID(ruleFlowID uint32) *types.PolicyRule
 	GetFQDNCache(fqdnFilter *FQDNCacheFilter) []types.DnsCacheEntry
+	// GetFQDNForIP returns the FQDN which resolved to the given IP address, according to the
+	// DNS responses tracked for FQDN NetworkPolicy rules, or an empty string if there is none.
+	GetFQDNForIP(ip netip.Addr) string
 }
 
 type AgentMulticastInfoQuerier interface {
@@ -159,4 +166,25 @@ type AgentBGPPolicyInfoQuerier interface {
 	GetBGPPeerStatus(ctx context.Context) ([]bgp.PeerStatus, error)
 	// GetBGPRoutes returns the advertised BGP routes.
 	GetBGPRoutes(ctx context.Context) (map[bgp.Route]bgpcontroller.RouteMetadata, error)
+	// GetBGPPolicyStatus returns the status of the effective BGP Policy applied on the Node, which is reported in
+	// AntreaAgentInfo, or nil if there is no effective BGP Policy.
+	GetBGPPolicyStatus() *crdv1beta1.BGPPolicyInfo
+}
+
+type AgentNodeLatencyMonitorQuerier interface {
+	// GetDegradedPeerNodes returns a map from the names of the peer Nodes whose connection exceeds the
+	// thresholds of the NodeLatencyMonitor to the reasons, and whether any threshold is configured.
+	GetDegradedPeerNodes() (map[string]string, bool)
+}
+
+type AgentConnectivityProbeQuerier interface {
+	// GetConnectivityProbeInfos returns the results of the ConnectivityProbes sent from the Node, which are reported
+	// in AntreaAgentInfo, sorted by ConnectivityProbe name.
+	GetConnectivityProbeInfos() []crdv1beta1.ConnectivityProbeInfo
+}
+
+type AgentPacketCaptureQuerier interface {
+	// GetPacketCaptureFile returns the file storing the packets captured by the PacketCapture with the given name on
+	// the Node. The caller is responsible for closing it.
+	GetPacketCaptureFile(name string) (io.ReadSeekCloser, error)
 }
@@ -14,11 +14,11 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: antrea.io/antrea/v2/pkg/querier (interfaces: AgentNetworkPolicyInfoQuerier,AgentMulticastInfoQuerier,EgressQuerier,AgentBGPPolicyInfoQuerier)
+// Source: antrea.io/antrea/v2/pkg/querier (interfaces: AgentNetworkPolicyInfoQuerier,AgentMulticastInfoQuerier,EgressQuerier,AgentBGPPolicyInfoQuerier,AgentPacketCaptureQuerier,AgentNodeLatencyMonitorQuerier,AgentConnectivityProbeQuerier)
 //
 // Generated by this command:
 //
-//	mockgen -copyright_file hack/boilerplate/license_header.raw.txt -destination pkg/querier/testing/mock_querier.go -package testing antrea.io/antrea/v2/pkg/querier AgentNetworkPolicyInfoQuerier,AgentMulticastInfoQuerier,EgressQuerier,AgentBGPPolicyInfoQuerier
+//	mockgen -copyright_file hack/boilerplate/license_header.raw.txt -destination pkg/querier/testing/mock_querier.go -package testing antrea.io/antrea/v2/pkg/querier AgentNetworkPolicyInfoQuerier,AgentMulticastInfoQuerier,EgressQuerier,AgentBGPPolicyInfoQuerier,AgentPacketCaptureQuerier,AgentNodeLatencyMonitorQuerier,AgentConnectivityProbeQuerier
 //
 
 // Package testing is a generated GoMock package.
@@ -26,6 +26,8 @@ package testing
 
 import (
 	context "context"
+	io "io"
+	netip "net/netip"
 	reflect "reflect"
 
 	bgp "antrea.io/antrea/v2/pkg/agent/bgp"
@@ -34,6 +36,7 @@ import (
 	multicast "antrea.io/antrea/v2/pkg/agent/multicast"
 	types "antrea.io/antrea/v2/pkg/agent/types"
 	v1beta2 "antrea.io/antrea/v2/pkg/apis/controlplane/v1beta2"
+	v1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
 	querier "antrea.io/antrea/v2/pkg/querier"
 	gomock "go.uber.org/mock/gomock"
 	types0 "k8s.io/apimachinery/pkg/types"
@@ -161,6 +164,34 @@ func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetFQDNCache(fqdnFilter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFQDNCache", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetFQDNCache), fqdnFilter)
 }
 
+// GetFQDNForIP mocks base method.
+func (m *MockAgentNetworkPolicyInfoQuerier) GetFQDNForIP(ip netip.Addr) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFQDNForIP", ip)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetFQDNForIP indicates an expected call of GetFQDNForIP.
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetFQDNForIP(ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFQDNForIP", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetFQDNForIP), ip)
+}
+
+// GetL7EngineHealth mocks base method.
+func (m *MockAgentNetworkPolicyInfoQuerier) GetL7EngineHealth() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetL7EngineHealth")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetL7EngineHealth indicates an expected call of GetL7EngineHealth.
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetL7EngineHealth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetL7EngineHealth", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetL7EngineHealth))
+}
+
 // GetNetworkPolicies mocks base method.
 func (m *MockAgentNetworkPolicyInfoQuerier) GetNetworkPolicies(npFilter *querier.NetworkPolicyQueryFilter) []v1beta2.NetworkPolicy {
 	m.ctrl.T.Helper()
@@ -405,6 +436,20 @@ func (mr *MockAgentBGPPolicyInfoQuerierMockRecorder) GetBGPPolicyInfo() *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBGPPolicyInfo", reflect.TypeOf((*MockAgentBGPPolicyInfoQuerier)(nil).GetBGPPolicyInfo))
 }
 
+// GetBGPPolicyStatus mocks base method.
+func (m *MockAgentBGPPolicyInfoQuerier) GetBGPPolicyStatus() *v1beta1.BGPPolicyInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBGPPolicyStatus")
+	ret0, _ := ret[0].(*v1beta1.BGPPolicyInfo)
+	return ret0
+}
+
+// GetBGPPolicyStatus indicates an expected call of GetBGPPolicyStatus.
+func (mr *MockAgentBGPPolicyInfoQuerierMockRecorder) GetBGPPolicyStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBGPPolicyStatus", reflect.TypeOf((*MockAgentBGPPolicyInfoQuerier)(nil).GetBGPPolicyStatus))
+}
+
 // GetBGPRoutes mocks base method.
 func (m *MockAgentBGPPolicyInfoQuerier) GetBGPRoutes(ctx context.Context) (map[bgp.Route]bgp0.RouteMetadata, error) {
 	m.ctrl.T.Helper()
@@ -419,3 +464,119 @@ func (mr *MockAgentBGPPolicyInfoQuerierMockRecorder) GetBGPRoutes(ctx any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBGPRoutes", reflect.TypeOf((*MockAgentBGPPolicyInfoQuerier)(nil).GetBGPRoutes), ctx)
 }
+
+// MockAgentPacketCaptureQuerier is a mock of AgentPacketCaptureQuerier interface.
+type MockAgentPacketCaptureQuerier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentPacketCaptureQuerierMockRecorder
+	isgomock struct{}
+}
+
+// MockAgentPacketCaptureQuerierMockRecorder is the mock recorder for MockAgentPacketCaptureQuerier.
+type MockAgentPacketCaptureQuerierMockRecorder struct {
+	mock *MockAgentPacketCaptureQuerier
+}
+
+// NewMockAgentPacketCaptureQuerier creates a new mock instance.
+func NewMockAgentPacketCaptureQuerier(ctrl *gomock.Controller) *MockAgentPacketCaptureQuerier {
+	mock := &MockAgentPacketCaptureQuerier{ctrl: ctrl}
+	mock.recorder = &MockAgentPacketCaptureQuerierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentPacketCaptureQuerier) EXPECT() *MockAgentPacketCaptureQuerierMockRecorder {
+	return m.recorder
+}
+
+// GetPacketCaptureFile mocks base method.
+func (m *MockAgentPacketCaptureQuerier) GetPacketCaptureFile(name string) (io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPacketCaptureFile", name)
+	ret0, _ := ret[0].(io.ReadSeekCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPacketCaptureFile indicates an expected call of GetPacketCaptureFile.
+func (mr *MockAgentPacketCaptureQuerierMockRecorder) GetPacketCaptureFile(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPacketCaptureFile", reflect.TypeOf((*MockAgentPacketCaptureQuerier)(nil).GetPacketCaptureFile), name)
+}
+
+// MockAgentNodeLatencyMonitorQuerier is a mock of AgentNodeLatencyMonitorQuerier interface.
+type MockAgentNodeLatencyMonitorQuerier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentNodeLatencyMonitorQuerierMockRecorder
+	isgomock struct{}
+}
+
+// MockAgentNodeLatencyMonitorQuerierMockRecorder is the mock recorder for MockAgentNodeLatencyMonitorQuerier.
+type MockAgentNodeLatencyMonitorQuerierMockRecorder struct {
+	mock *MockAgentNodeLatencyMonitorQuerier
+}
+
+// NewMockAgentNodeLatencyMonitorQuerier creates a new mock instance.
+func NewMockAgentNodeLatencyMonitorQuerier(ctrl *gomock.Controller) *MockAgentNodeLatencyMonitorQuerier {
+	mock := &MockAgentNodeLatencyMonitorQuerier{ctrl: ctrl}
+	mock.recorder = &MockAgentNodeLatencyMonitorQuerierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentNodeLatencyMonitorQuerier) EXPECT() *MockAgentNodeLatencyMonitorQuerierMockRecorder {
+	return m.recorder
+}
+
+// GetDegradedPeerNodes mocks base method.
+func (m *MockAgentNodeLatencyMonitorQuerier) GetDegradedPeerNodes() (map[string]string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDegradedPeerNodes")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetDegradedPeerNodes indicates an expected call of GetDegradedPeerNodes.
+func (mr *MockAgentNodeLatencyMonitorQuerierMockRecorder) GetDegradedPeerNodes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDegradedPeerNodes", reflect.TypeOf((*MockAgentNodeLatencyMonitorQuerier)(nil).GetDegradedPeerNodes))
+}
+
+// MockAgentConnectivityProbeQuerier is a mock of AgentConnectivityProbeQuerier interface.
+type MockAgentConnectivityProbeQuerier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentConnectivityProbeQuerierMockRecorder
+	isgomock struct{}
+}
+
+// MockAgentConnectivityProbeQuerierMockRecorder is the mock recorder for MockAgentConnectivityProbeQuerier.
+type MockAgentConnectivityProbeQuerierMockRecorder struct {
+	mock *MockAgentConnectivityProbeQuerier
+}
+
+// NewMockAgentConnectivityProbeQuerier creates a new mock instance.
+func NewMockAgentConnectivityProbeQuerier(ctrl *gomock.Controller) *MockAgentConnectivityProbeQuerier {
+	mock := &MockAgentConnectivityProbeQuerier{ctrl: ctrl}
+	mock.recorder = &MockAgentConnectivityProbeQuerierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentConnectivityProbeQuerier) EXPECT() *MockAgentConnectivityProbeQuerierMockRecorder {
+	return m.recorder
+}
+
+// GetConnectivityProbeInfos mocks base method.
+func (m *MockAgentConnectivityProbeQuerier) GetConnectivityProbeInfos() []v1beta1.ConnectivityProbeInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectivityProbeInfos")
+	ret0, _ := ret[0].([]v1beta1.ConnectivityProbeInfo)
+	return ret0
+}
+
+// GetConnectivityProbeInfos indicates an expected call of GetConnectivityProbeInfos.
+func (mr *MockAgentConnectivityProbeQuerierMockRecorder) GetConnectivityProbeInfos() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectivityProbeInfos", reflect.TypeOf((*MockAgentConnectivityProbeQuerier)(nil).GetConnectivityProbeInfos))
+}
@@ -28,11 +28,16 @@ import (
 
 	"github.com/spf13/afero"
 	"go.yaml.in/yaml/v2"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/utils/exec"
 
+	"antrea.io/antrea/v2/pkg/agent/apis"
+	"antrea.io/antrea/v2/pkg/agent/interfacestore"
 	agentquerier "antrea.io/antrea/v2/pkg/agent/querier"
 	"antrea.io/antrea/v2/pkg/agent/util/ipset"
 	clusterinformationv1beta1 "antrea.io/antrea/v2/pkg/apis/crd/v1beta1"
+	"antrea.io/antrea/v2/pkg/features"
 	"antrea.io/antrea/v2/pkg/ovs/ovsctl"
 	"antrea.io/antrea/v2/pkg/querier"
 	"antrea.io/antrea/v2/pkg/util/logdir"
@@ -115,6 +120,16 @@ func dumpAntctlGet(fs afero.Fs, executor exec.Interface, name, basedir string) e
 	return writeFile(fs, filepath.Join(basedir, name), name, output)
 }
 
+// dumpFeatureGates writes the status of the given feature gates, so that the feature gates of the Antrea components
+// can be compared when analyzing bundles.
+func dumpFeatureGates(fs afero.Fs, basedir string, gates sets.Set[featuregate.Feature]) error {
+	status := make(map[string]bool, len(gates))
+	for gate := range gates {
+		status[string(gate)] = features.DefaultFeatureGate.Enabled(gate)
+	}
+	return writeYAMLFile(fs, filepath.Join(basedir, "featuregates"), "featuregates", status)
+}
+
 func dumpNetworkPolicyResources(fs afero.Fs, executor exec.Interface, basedir string) error {
 	if err := dumpAntctlGet(fs, executor, "networkpolicies", basedir); err != nil {
 		return err
@@ -267,7 +282,10 @@ type controllerDumper struct {
 }
 
 func (d *controllerDumper) DumpControllerInfo(basedir string) error {
-	return dumpAntctlGet(d.fs, d.executor, "controllerinfo", basedir)
+	if err := dumpAntctlGet(d.fs, d.executor, "controllerinfo", basedir); err != nil {
+		return err
+	}
+	return dumpFeatureGates(d.fs, basedir, features.ControllerGates)
 }
 
 func (d *controllerDumper) DumpNetworkPolicyResources(basedir string) error {
@@ -310,7 +328,10 @@ type agentDumper struct {
 func (d *agentDumper) DumpAgentInfo(basedir string) error {
 	ai := new(clusterinformationv1beta1.AntreaAgentInfo)
 	d.aq.GetAgentInfo(ai, false)
-	return writeYAMLFile(d.fs, filepath.Join(basedir, "agentinfo"), "agentinfo", ai)
+	if err := writeYAMLFile(d.fs, filepath.Join(basedir, "agentinfo"), "agentinfo", ai); err != nil {
+		return err
+	}
+	return dumpFeatureGates(d.fs, basedir, features.AgentGates)
 }
 
 func (d *agentDumper) DumpNetworkPolicyResources(basedir string) error {
@@ -359,7 +380,34 @@ func (d *agentDumper) DumpOVSPorts(basedir string) error {
 	for idx := range portsDesc {
 		portData[idx] = strings.Join(portsDesc[idx], "\n")
 	}
-	return writeFile(d.fs, filepath.Join(basedir, "ovsports"), "ports", []byte(strings.Join(portData, "\n")))
+	if err := writeFile(d.fs, filepath.Join(basedir, "ovsports"), "ports", []byte(strings.Join(portData, "\n"))); err != nil {
+		return err
+	}
+	return d.dumpPodInterfaces(basedir)
+}
+
+// dumpPodInterfaces writes the Pod interfaces of the interface store, which are required to map the OVS ports and
+// flows to the Pods.
+func (d *agentDumper) dumpPodInterfaces(basedir string) error {
+	var podInterfaces []apis.PodInterfaceResponse
+	for _, iface := range d.aq.GetInterfaceStore().GetInterfacesByType(interfacestore.ContainerInterface) {
+		podInterface := apis.PodInterfaceResponse{
+			PodName:       iface.PodName,
+			PodNamespace:  iface.PodNamespace,
+			InterfaceName: iface.InterfaceName,
+			MAC:           iface.MAC.String(),
+			ContainerID:   iface.ContainerID,
+		}
+		for _, ip := range iface.IPs {
+			podInterface.IPs = append(podInterface.IPs, ip.String())
+		}
+		if iface.OVSPortConfig != nil {
+			podInterface.PortUUID = iface.PortUUID
+			podInterface.OFPort = iface.OFPort
+		}
+		podInterfaces = append(podInterfaces, podInterface)
+	}
+	return writeYAMLFile(d.fs, filepath.Join(basedir, "podinterfaces"), "podinterfaces", podInterfaces)
 }
 
 func NewAgentDumper(fs afero.Fs, executor exec.Interface, ovsCtlClient ovsctl.OVSCtlClient, aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, since string, v4Enabled, v6Enabled bool) AgentDumper {
@@ -0,0 +1,150 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package support
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// flowAggregatorLabelSelector selects the Pods and the ConfigMap of the flow-aggregator.
+const flowAggregatorLabelSelector = "app=flow-aggregator"
+
+// FlowAggregatorDumper is the interface for dumping runtime information of the flow-aggregator. Unlike the other
+// dumpers, it collects the information through the Kubernetes API, so that it can be used outside of the
+// flow-aggregator Pods, which may have several replicas.
+type FlowAggregatorDumper interface {
+	// DumpLog should create files that contains the container logs of all the
+	// flow-aggregator Pods under the basedir.
+	DumpLog(basedir string) error
+	// DumpFlowAggregatorInfo should create files that contains the flow-aggregator
+	// Pods and ConfigMap under the basedir.
+	DumpFlowAggregatorInfo(basedir string) error
+}
+
+type flowAggregatorDumper struct {
+	ctx        context.Context
+	fs         afero.Fs
+	kubeClient kubernetes.Interface
+	namespace  string
+	since      string
+}
+
+func (d *flowAggregatorDumper) listPods() ([]corev1.Pod, error) {
+	pods, err := d.kubeClient.CoreV1().Pods(d.namespace).List(d.ctx, metav1.ListOptions{LabelSelector: flowAggregatorLabelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing flow-aggregator Pods: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no flow-aggregator Pod found in Namespace %s", d.namespace)
+	}
+	return pods.Items, nil
+}
+
+func (d *flowAggregatorDumper) DumpLog(basedir string) error {
+	pods, err := d.listPods()
+	if err != nil {
+		return err
+	}
+	var sinceTime *metav1.Time
+	if timeFilter := timestampFilter(d.since); timeFilter != nil {
+		sinceTime = &metav1.Time{Time: *timeFilter}
+	}
+	for _, pod := range pods {
+		logDir := filepath.Join(basedir, "logs", "flow-aggregator", pod.Name)
+		if err := d.fs.MkdirAll(logDir, 0755); err != nil {
+			return fmt.Errorf("error when creating log dir: %w", err)
+		}
+		restartCounts := make(map[string]int32)
+		for _, status := range pod.Status.ContainerStatuses {
+			restartCounts[status.Name] = status.RestartCount
+		}
+		for _, container := range pod.Spec.Containers {
+			if err := d.dumpContainerLog(pod.Name, container.Name, false, sinceTime, filepath.Join(logDir, container.Name+".log")); err != nil {
+				return err
+			}
+			// The logs of the previous instance of a container usually explain why it was restarted.
+			if restartCounts[container.Name] > 0 {
+				if err := d.dumpContainerLog(pod.Name, container.Name, true, sinceTime, filepath.Join(logDir, container.Name+".previous.log")); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (d *flowAggregatorDumper) dumpContainerLog(podName, containerName string, previous bool, sinceTime *metav1.Time, filePath string) error {
+	stream, err := d.kubeClient.CoreV1().Pods(d.namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: containerName,
+		Previous:  previous,
+		SinceTime: sinceTime,
+	}).Stream(d.ctx)
+	if err != nil {
+		return fmt.Errorf("error when getting logs of container %s in Pod %s: %w", containerName, podName, err)
+	}
+	defer stream.Close()
+	f, err := d.fs.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error when creating file %s: %w", filePath, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, stream); err != nil {
+		return fmt.Errorf("error when writing logs of container %s in Pod %s: %w", containerName, podName, err)
+	}
+	return nil
+}
+
+func (d *flowAggregatorDumper) DumpFlowAggregatorInfo(basedir string) error {
+	pods, err := d.listPods()
+	if err != nil {
+		return err
+	}
+	if err := d.writeObjectsFile(filepath.Join(basedir, "pods"), "pods", pods); err != nil {
+		return err
+	}
+	configMaps, err := d.kubeClient.CoreV1().ConfigMaps(d.namespace).List(d.ctx, metav1.ListOptions{LabelSelector: flowAggregatorLabelSelector})
+	if err != nil {
+		return fmt.Errorf("error when listing flow-aggregator ConfigMaps: %w", err)
+	}
+	return d.writeObjectsFile(filepath.Join(basedir, "configmaps"), "configmaps", configMaps.Items)
+}
+
+// writeObjectsFile writes Kubernetes objects in YAML format, with the same field names as kubectl.
+func (d *flowAggregatorDumper) writeObjectsFile(filePath string, resource string, objects interface{}) error {
+	data, err := yaml.Marshal(objects)
+	if err != nil {
+		return fmt.Errorf("error when marshalling %s: %w", resource, err)
+	}
+	return writeFile(d.fs, filePath, resource, data)
+}
+
+func NewFlowAggregatorDumper(ctx context.Context, fs afero.Fs, kubeClient kubernetes.Interface, namespace string, since string) FlowAggregatorDumper {
+	return &flowAggregatorDumper{
+		ctx:        ctx,
+		fs:         fs,
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		since:      since,
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package support
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var (
+	flowAggregatorPod = &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "flow-aggregator-1",
+			Namespace: "flow-aggregator",
+			Labels:    map[string]string{"app": "flow-aggregator"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "flow-aggregator"}},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "flow-aggregator", RestartCount: 1}},
+		},
+	}
+	flowAggregatorConfigMap = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "flow-aggregator-configmap",
+			Namespace: "flow-aggregator",
+			Labels:    map[string]string{"app": "flow-aggregator"},
+		},
+		Data: map[string]string{"flow-aggregator.conf": "mode: Aggregate"},
+	}
+)
+
+func TestFlowAggregatorDumpLog(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dumper := NewFlowAggregatorDumper(context.Background(), fs, fake.NewClientset(flowAggregatorPod), "flow-aggregator", "1h")
+	require.NoError(t, dumper.DumpLog(baseDir))
+
+	logDir := filepath.Join(baseDir, "logs", "flow-aggregator", "flow-aggregator-1")
+	for _, name := range []string{"flow-aggregator.log", "flow-aggregator.previous.log"} {
+		data, err := afero.ReadFile(fs, filepath.Join(logDir, name))
+		require.NoError(t, err)
+		// The fake clientset always returns "fake logs".
+		assert.Equal(t, "fake logs", string(data))
+	}
+}
+
+func TestFlowAggregatorDumpInfo(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dumper := NewFlowAggregatorDumper(context.Background(), fs, fake.NewClientset(flowAggregatorPod, flowAggregatorConfigMap), "flow-aggregator", "")
+	require.NoError(t, dumper.DumpFlowAggregatorInfo(baseDir))
+
+	data, err := afero.ReadFile(fs, filepath.Join(baseDir, "pods"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "name: flow-aggregator-1")
+	assert.Contains(t, string(data), "restartCount: 1")
+	data, err = afero.ReadFile(fs, filepath.Join(baseDir, "configmaps"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "flow-aggregator.conf: 'mode: Aggregate'")
+}
+
+func TestFlowAggregatorDumpNoPod(t *testing.T) {
+	dumper := NewFlowAggregatorDumper(context.Background(), afero.NewMemMapFs(), fake.NewClientset(), "flow-aggregator", "")
+	assert.EqualError(t, dumper.DumpLog(baseDir), "no flow-aggregator Pod found in Namespace flow-aggregator")
+	assert.EqualError(t, dumper.DumpFlowAggregatorInfo(baseDir), "no flow-aggregator Pod found in Namespace flow-aggregator")
+}
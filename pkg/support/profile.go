@@ -0,0 +1,120 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package support
+
+import (
+	"fmt"
+)
+
+// Profile defines which information is collected in a support bundle.
+type Profile string
+
+const (
+	// ProfileAll collects all the available information.
+	ProfileAll Profile = "All"
+	// ProfileLogsOnly only collects the logs.
+	ProfileLogsOnly Profile = "LogsOnly"
+	// ProfileDatapathOnly only collects the OVS flows, groups and ports, and the host network configuration.
+	ProfileDatapathOnly Profile = "DatapathOnly"
+	// ProfilePolicyOnly only collects the NetworkPolicy resources.
+	ProfilePolicyOnly Profile = "PolicyOnly"
+)
+
+// AgentDumpFuncs returns the functions of the AgentDumper which must be called to collect a bundle with the given
+// profile. An empty profile is equivalent to ProfileAll. The AgentInfo is collected with all the profiles, as it is
+// required to interpret the other files of a bundle.
+func AgentDumpFuncs(d AgentDumper, profile Profile) ([]func(basedir string) error, error) {
+	switch profile {
+	case "", ProfileAll:
+		return []func(string) error{
+			d.DumpLog,
+			d.DumpHostNetworkInfo,
+			d.DumpFlows,
+			d.DumpGroups,
+			d.DumpNetworkPolicyResources,
+			d.DumpAgentInfo,
+			d.DumpHeapPprof,
+			d.DumpGoroutinePprof,
+			d.DumpOVSPorts,
+		}, nil
+	case ProfileLogsOnly:
+		return []func(string) error{
+			d.DumpLog,
+			d.DumpAgentInfo,
+		}, nil
+	case ProfileDatapathOnly:
+		return []func(string) error{
+			d.DumpHostNetworkInfo,
+			d.DumpFlows,
+			d.DumpGroups,
+			d.DumpOVSPorts,
+			d.DumpAgentInfo,
+		}, nil
+	case ProfilePolicyOnly:
+		return []func(string) error{
+			d.DumpNetworkPolicyResources,
+			d.DumpAgentInfo,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported support bundle profile %q", profile)
+}
+
+// ControllerDumpFuncs returns the functions of the ControllerDumper which must be called to collect a bundle with the
+// given profile. The controller has no datapath, so only the ControllerInfo is collected with ProfileDatapathOnly.
+func ControllerDumpFuncs(d ControllerDumper, profile Profile) ([]func(basedir string) error, error) {
+	switch profile {
+	case "", ProfileAll:
+		return []func(string) error{
+			d.DumpLog,
+			d.DumpNetworkPolicyResources,
+			d.DumpControllerInfo,
+			d.DumpHeapPprof,
+			d.DumpGoroutinePprof,
+		}, nil
+	case ProfileLogsOnly:
+		return []func(string) error{
+			d.DumpLog,
+			d.DumpControllerInfo,
+		}, nil
+	case ProfileDatapathOnly:
+		return []func(string) error{
+			d.DumpControllerInfo,
+		}, nil
+	case ProfilePolicyOnly:
+		return []func(string) error{
+			d.DumpNetworkPolicyResources,
+			d.DumpControllerInfo,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported support bundle profile %q", profile)
+}
+
+// FlowAggregatorDumpFuncs returns the functions of the FlowAggregatorDumper which must be called to collect a bundle
+// with the given profile. The flow-aggregator has neither datapath nor NetworkPolicy resources, so only its Pods and
+// ConfigMap are collected with ProfileDatapathOnly and ProfilePolicyOnly.
+func FlowAggregatorDumpFuncs(d FlowAggregatorDumper, profile Profile) ([]func(basedir string) error, error) {
+	switch profile {
+	case "", ProfileAll, ProfileLogsOnly:
+		return []func(string) error{
+			d.DumpLog,
+			d.DumpFlowAggregatorInfo,
+		}, nil
+	case ProfileDatapathOnly, ProfilePolicyOnly:
+		return []func(string) error{
+			d.DumpFlowAggregatorInfo,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported support bundle profile %q", profile)
+}
@@ -0,0 +1,314 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package support
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	// The pseudonyms of IP addresses are allocated from ranges which are not used by actual workloads, so that they
+	// cannot be mistaken for addresses which were not redacted.
+	redactedIPv4Prefix = netip.MustParsePrefix("240.0.0.0/4")
+	redactedIPv6Prefix = netip.MustParsePrefix("2001:db8::/32")
+
+	// nonRedactedNames are well-known names which do not reveal anything about a cluster. They are also common words,
+	// which appear in many places unrelated to Kubernetes resources, e.g. "default" in the output of "ip route".
+	nonRedactedNames = sets.New[string]("default", "kube-system", "kube-public", "kube-node-lease", "true", "false")
+)
+
+const (
+	// binaryDetectionLength is the number of bytes inspected to tell whether a file is a binary file, which cannot be
+	// redacted, e.g. a pprof profile.
+	binaryDetectionLength = 8000
+	// nameHashLength is the number of hexadecimal digits of the keyed hash included in the pseudonyms of names.
+	nameHashLength = 10
+)
+
+// Redactor pseudonymizes the IP addresses, the names of resources and the label values in the files of a support
+// bundle. Pseudonyms are derived from a keyed hash (HMAC-SHA256) of the redacted values: a given value is always
+// replaced with the same pseudonym by all the Redactors created with the same key, regardless of the order in which
+// values are registered or encountered. The relationships between objects can therefore still be followed in the
+// redacted bundles of all the components, while the original values cannot be recovered without the key.
+//
+// IP addresses are detected automatically, while the names and the label values to redact must be registered with
+// AddNames and AddLabels, before the files are redacted. A Redactor is not safe for concurrent use.
+type Redactor struct {
+	key []byte
+	// names maps the registered names and label values to their pseudonyms.
+	names map[string]string
+	// nameKinds maps the registered names and label values to the kind used as the prefix of their pseudonyms.
+	nameKinds map[string]string
+	ips       map[netip.Addr]netip.Addr
+	// usedIPs stores the pseudonyms already allocated to IP addresses, to detect hash collisions.
+	usedIPs sets.Set[netip.Addr]
+}
+
+// NewRedactor returns a Redactor which derives pseudonyms from key. The same key should be used for all the bundles
+// of a given collection, so that they can be correlated.
+func NewRedactor(key []byte) *Redactor {
+	return &Redactor{
+		key:       key,
+		names:     make(map[string]string),
+		nameKinds: make(map[string]string),
+		ips:       make(map[netip.Addr]netip.Addr),
+		usedIPs:   sets.New[netip.Addr](),
+	}
+}
+
+// hash returns the HMAC-SHA256 of the given parts with the key of the Redactor.
+func (r *Redactor) hash(parts ...string) []byte {
+	mac := hmac.New(sha256.New, r.key)
+	for _, part := range parts {
+		// Include the length of each part, so that different parts cannot produce the same input.
+		fmt.Fprintf(mac, "%d:%s", len(part), part)
+	}
+	return mac.Sum(nil)
+}
+
+// AddNames registers names to redact. kind is used as the prefix of the pseudonyms, e.g. "pod" generates pseudonyms
+// like "pod-3f2a9c01de". When a name is registered with different kinds, the first kind in lexicographic order is
+// used, so that the pseudonym does not depend on the registration order.
+func (r *Redactor) AddNames(kind string, names ...string) {
+	for _, name := range names {
+		if !isRedactableName(name) {
+			continue
+		}
+		if existingKind, ok := r.nameKinds[name]; ok && existingKind <= kind {
+			continue
+		}
+		r.nameKinds[name] = kind
+		r.names[name] = fmt.Sprintf("%s-%s", kind, hex.EncodeToString(r.hash("name", name))[:nameHashLength])
+	}
+}
+
+// AddLabels registers the values of the given labels to redact. Label keys are not redacted, as they usually denote
+// the purpose of a label rather than identify an object.
+func (r *Redactor) AddLabels(labels map[string]string) {
+	// Sort the keys so that the pseudonyms do not depend on the iteration order of the map.
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		r.AddNames("label", labels[key])
+	}
+}
+
+// isRedactableName returns whether a name can be redacted without replacing unrelated words. Names which contain
+// no letters are skipped, as they could match numbers or parts of IP addresses.
+func isRedactableName(name string) bool {
+	if len(name) < 2 || nonRedactedNames.Has(name) {
+		return false
+	}
+	return strings.ContainsFunc(name, func(c rune) bool {
+		return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	})
+}
+
+// Redact returns a copy of data in which all the registered names and the IP addresses are replaced with their
+// pseudonyms.
+func (r *Redactor) Redact(data []byte) []byte {
+	return r.redactIPs(r.redactNames(data))
+}
+
+func isNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.'
+}
+
+func isIPChar(c byte) bool {
+	return c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F' || c >= '0' && c <= '9' || c == ':' || c == '.'
+}
+
+// replaceTokens calls replace for all the maximal sequences of bytes of data for which isTokenChar returns true, and
+// returns a copy of data in which each sequence is substituted with the result of replace.
+func replaceTokens(data []byte, isTokenChar func(byte) bool, replace func([]byte) []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); {
+		if !isTokenChar(data[i]) {
+			out = append(out, data[i])
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(data) && isTokenChar(data[j]) {
+			j++
+		}
+		out = append(out, replace(data[i:j])...)
+		i = j
+	}
+	return out
+}
+
+func (r *Redactor) redactNames(data []byte) []byte {
+	if len(r.names) == 0 {
+		return data
+	}
+	return replaceTokens(data, isNameChar, func(token []byte) []byte {
+		if pseudonym, ok := r.names[string(token)]; ok {
+			return []byte(pseudonym)
+		}
+		// A name can be part of a dot-separated token, e.g. a DNS name or a name at the end of a sentence.
+		if !bytes.Contains(token, []byte(".")) {
+			return token
+		}
+		parts := bytes.Split(token, []byte("."))
+		for i, part := range parts {
+			if pseudonym, ok := r.names[string(part)]; ok {
+				parts[i] = []byte(pseudonym)
+			}
+		}
+		return bytes.Join(parts, []byte("."))
+	})
+}
+
+func (r *Redactor) redactIPs(data []byte) []byte {
+	return replaceTokens(data, isIPChar, func(token []byte) []byte {
+		if pseudonym, ok := r.redactIP(string(token)); ok {
+			return []byte(pseudonym)
+		}
+		// The token may end with the punctuation of a sentence, e.g. "10.10.0.1.".
+		if trimmed := bytes.TrimRight(token, ".:"); len(trimmed) < len(token) {
+			if pseudonym, ok := r.redactIP(string(trimmed)); ok {
+				return append([]byte(pseudonym), token[len(trimmed):]...)
+			}
+		}
+		// The token may be made of IPv4 addresses separated by colons, e.g. "10.10.0.1:8080". IPv6 addresses are
+		// enclosed in brackets when followed by a port, and are therefore not affected.
+		if !bytes.Contains(token, []byte(".")) || !bytes.Contains(token, []byte(":")) {
+			return token
+		}
+		parts := bytes.Split(token, []byte(":"))
+		for i, part := range parts {
+			if pseudonym, ok := r.redactIP(string(part)); ok {
+				parts[i] = []byte(pseudonym)
+			}
+		}
+		return bytes.Join(parts, []byte(":"))
+	})
+}
+
+// redactIP returns the pseudonym of s if it is an IP address which must be redacted.
+func (r *Redactor) redactIP(s string) (string, bool) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil || !isRedactableIP(addr) {
+		return "", false
+	}
+	pseudonym, ok := r.ips[addr]
+	if !ok {
+		pseudonym = r.ipPseudonym(addr)
+		r.ips[addr] = pseudonym
+		r.usedIPs.Insert(pseudonym)
+	}
+	return pseudonym.String(), true
+}
+
+// ipPseudonym derives the pseudonym of addr from its keyed hash, by replacing the prefix of the hash with the range
+// reserved for pseudonyms. In the unlikely event of a collision with the pseudonym of another address, the hash is
+// computed again with a counter.
+func (r *Redactor) ipPseudonym(addr netip.Addr) netip.Addr {
+	prefix := redactedIPv6Prefix
+	if addr.Is4() {
+		prefix = redactedIPv4Prefix
+	}
+	prefixBytes := prefix.Addr().AsSlice()
+	for i := 0; ; i++ {
+		hash := r.hash("ip", addr.String(), strconv.Itoa(i))
+		b := hash[:len(prefixBytes)]
+		for bit := 0; bit < prefix.Bits(); bit++ {
+			mask := byte(0x80) >> (bit % 8)
+			b[bit/8] = b[bit/8]&^mask | prefixBytes[bit/8]&mask
+		}
+		pseudonym, _ := netip.AddrFromSlice(b)
+		if !r.usedIPs.Has(pseudonym) && !isMask(pseudonym) {
+			return pseudonym
+		}
+	}
+}
+
+// isRedactableIP returns whether an IP address may identify a workload or a host. Well-known addresses, e.g. the
+// IPv4 link-local addresses used by Antrea for its virtual IPs, and network masks are kept in the redacted bundle.
+func isRedactableIP(addr netip.Addr) bool {
+	if addr.IsUnspecified() || addr.IsLoopback() || addr.IsMulticast() || addr.Is4() && addr.IsLinkLocalUnicast() {
+		return false
+	}
+	return !isMask(addr)
+}
+
+// isMask returns whether the bits of addr are a sequence of ones followed by a sequence of zeros.
+func isMask(addr netip.Addr) bool {
+	zero := false
+	for _, b := range addr.AsSlice() {
+		for i := 7; i >= 0; i-- {
+			bit := b&(1<<i) != 0
+			if bit && zero {
+				return false
+			}
+			zero = !bit
+		}
+	}
+	return true
+}
+
+// isBinary returns whether data looks like the content of a binary file, using the same heuristic as Git.
+func isBinary(data []byte) bool {
+	return bytes.IndexByte(data[:min(len(data), binaryDetectionLength)], 0) >= 0
+}
+
+// RedactDir redacts the content of all the text files under dir, as well as their names, e.g. log files whose names
+// include the hostname. Binary files are left unchanged.
+func (r *Redactor) RedactDir(fs afero.Fs, dir string) error {
+	var filePaths []string
+	if err := afero.Walk(fs, dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			filePaths = append(filePaths, filePath)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error when listing files to redact: %w", err)
+	}
+	for _, filePath := range filePaths {
+		data, err := afero.ReadFile(fs, filePath)
+		if err != nil {
+			return fmt.Errorf("error when reading file %s to redact: %w", filePath, err)
+		}
+		if isBinary(data) {
+			continue
+		}
+		redactedPath := filepath.Join(filepath.Dir(filePath), string(r.Redact([]byte(filepath.Base(filePath)))))
+		if err := writeFile(fs, redactedPath, filepath.Base(redactedPath), r.Redact(data)); err != nil {
+			return err
+		}
+		if redactedPath != filePath {
+			if err := fs.Remove(filePath); err != nil {
+				return fmt.Errorf("error when removing file %s: %w", filePath, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package support
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRedactionKey = []byte("test-key")
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name:     "log line",
+			data:     `I0817 06:55:10.804384       1 pod_configuration.go:270] "Installed Pod" pod="frontend/web-1" IP="10.10.1.5"`,
+			expected: `I0817 06:55:10.804384       1 pod_configuration.go:270] "Installed Pod" pod="namespace-2f6e7a2f50/pod-a5d55614e7" IP="249.152.181.176"`,
+		},
+		{
+			name:     "OVS flow",
+			data:     "table=IngressRule, priority=200,ip,nw_src=10.10.1.5,nw_dst=fd00:10:10::5 actions=goto_table:IngressMetric",
+			expected: "table=IngressRule, priority=200,ip,nw_src=249.152.181.176,nw_dst=2001:db8:6e93:f17a:b66d:ff1e:9757:af72 actions=goto_table:IngressMetric",
+		},
+		{
+			name:     "route",
+			data:     "default via 192.168.77.1 dev eth0\n10.10.1.0/24 dev antrea-gw0 proto kernel scope link src 10.10.1.1\n",
+			expected: "default via 253.100.130.237 dev eth0\n253.182.2.163/24 dev antrea-gw0 proto kernel scope link src 255.90.1.159\n",
+		},
+		{
+			name:     "addresses with ports and punctuation",
+			data:     "Connection to 10.10.1.5:8080 failed. Retrying with 192.168.77.1.",
+			expected: "Connection to 249.152.181.176:8080 failed. Retrying with 253.100.130.237.",
+		},
+		{
+			name:     "names in DNS names and file names",
+			data:     "web-1.frontend.svc.cluster.local antrea-agent.k8s-node-1.root.log.INFO",
+			expected: "pod-a5d55614e7.namespace-2f6e7a2f50.svc.cluster.local antrea-agent.node-271c1f510c.root.log.INFO",
+		},
+		{
+			name:     "labels",
+			data:     "labels: map[app:web tier:backend]",
+			expected: "labels: map[app:label-9d6c806df6 tier:label-39d462dabe]",
+		},
+		{
+			name:     "unchanged",
+			data:     "2021-06-02T16:18:52.285Z|00004|reconnect|INFO|unix:/var/run/openvswitch/db.sock: connected to 127.0.0.1, mask 255.255.255.0, MAC aa:bb:cc:dd:ee:ff, virtual IP 169.254.0.253, in kube-system, version 1.2.3",
+			expected: "2021-06-02T16:18:52.285Z|00004|reconnect|INFO|unix:/var/run/openvswitch/db.sock: connected to 127.0.0.1, mask 255.255.255.0, MAC aa:bb:cc:dd:ee:ff, virtual IP 169.254.0.253, in kube-system, version 1.2.3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRedactor(testRedactionKey)
+			r.AddNames("namespace", "frontend", "kube-system")
+			r.AddNames("pod", "web-1", "x")
+			r.AddNames("node", "k8s-node-1")
+			r.AddLabels(map[string]string{"tier": "backend", "app": "web", "enabled": "true"})
+			assert.Equal(t, tt.expected, string(r.Redact([]byte(tt.data))))
+		})
+	}
+}
+
+func TestRedactorRegistrationOrder(t *testing.T) {
+	data := []byte("pod frontend/web-1 on k8s-node-1 (app=web) has IPs 10.10.1.5 and fd00:10:10::5, gateway 10.10.1.1")
+
+	r1 := NewRedactor(testRedactionKey)
+	r1.AddNames("namespace", "frontend", "web")
+	r1.AddNames("pod", "web-1", "frontend")
+	r1.AddNames("node", "k8s-node-1")
+	r1.AddLabels(map[string]string{"app": "web"})
+	r1.Redact([]byte("10.10.1.1 10.10.1.5"))
+
+	// The same values are registered and encountered in a different order.
+	r2 := NewRedactor(testRedactionKey)
+	r2.AddLabels(map[string]string{"app": "web"})
+	r2.AddNames("node", "k8s-node-1")
+	r2.AddNames("pod", "frontend", "web-1")
+	r2.AddNames("namespace", "web", "frontend")
+	r2.Redact([]byte("fd00:10:10::5 10.10.1.5"))
+
+	redacted := r1.Redact(data)
+	assert.Equal(t, "pod namespace-2f6e7a2f50/pod-a5d55614e7 on node-271c1f510c (app=label-9d6c806df6) has IPs 249.152.181.176 and 2001:db8:6e93:f17a:b66d:ff1e:9757:af72, gateway 255.90.1.159", string(redacted))
+	assert.Equal(t, string(redacted), string(r2.Redact(data)))
+
+	// Pseudonyms cannot be correlated without the key.
+	r3 := NewRedactor([]byte("other-key"))
+	r3.AddNames("namespace", "frontend")
+	assert.NotEqual(t, string(r1.Redact([]byte("frontend"))), string(r3.Redact([]byte("frontend"))))
+}
+
+func TestRedactDir(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	logPath := filepath.Join(baseDir, "logs", "agent", "antrea-agent.k8s-node-1.root.log.INFO.20260101-000000.1")
+	require.NoError(t, afero.WriteFile(fs, logPath, []byte(`"Installed Pod" pod="frontend/web-1" IP="10.10.1.5"`), 0644))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(baseDir, "addressgroups"), []byte("- pod:\n    name: web-1\n    namespace: frontend\n  ips:\n  - 10.10.1.5\n"), 0644))
+	binaryData := []byte{0x1f, 0x8b, 0x08, 0x00, '1', '0', '.', '1', '0', '.', '1', '.', '5'}
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(baseDir, "memprofile"), binaryData, 0644))
+
+	r := NewRedactor(testRedactionKey)
+	r.AddNames("namespace", "frontend")
+	r.AddNames("pod", "web-1")
+	r.AddNames("node", "k8s-node-1")
+	require.NoError(t, r.RedactDir(fs, baseDir))
+
+	exists, err := afero.Exists(fs, logPath)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	data, err := afero.ReadFile(fs, filepath.Join(baseDir, "logs", "agent", "antrea-agent.node-271c1f510c.root.log.INFO.20260101-000000.1"))
+	require.NoError(t, err)
+	assert.Equal(t, `"Installed Pod" pod="namespace-2f6e7a2f50/pod-a5d55614e7" IP="249.152.181.176"`, string(data))
+	data, err = afero.ReadFile(fs, filepath.Join(baseDir, "addressgroups"))
+	require.NoError(t, err)
+	assert.Equal(t, "- pod:\n    name: pod-a5d55614e7\n    namespace: namespace-2f6e7a2f50\n  ips:\n  - 249.152.181.176\n", string(data))
+	data, err = afero.ReadFile(fs, filepath.Join(baseDir, "memprofile"))
+	require.NoError(t, err)
+	assert.Equal(t, binaryData, data)
+}
@@ -24,10 +24,12 @@ import (
 )
 
 const (
-	SecretKeyWithAPIKey      = "apikey"
-	SecretKeyWithBearerToken = "token"
-	SecretKeyWithUsername    = "username"
-	SecretKeyWithPassword    = "password"
+	SecretKeyWithAPIKey          = "apikey"
+	SecretKeyWithBearerToken     = "token"
+	SecretKeyWithUsername        = "username"
+	SecretKeyWithPassword        = "password"
+	SecretKeyWithAccessKeyID     = "accessKeyId"
+	SecretKeyWithSecretAccessKey = "secretAccessKey"
 )
 
 // AuthType defines the authentication type to access a file server.
@@ -37,6 +39,7 @@ const (
 	APIKeyType              AuthType = "APIKey"
 	BearerTokenType         AuthType = "BearerToken"
 	BasicAuthenticationType AuthType = "BasicAuthentication"
+	AccessKeyType           AuthType = "AccessKey"
 )
 
 type BasicAuthentication struct {
@@ -44,11 +47,18 @@ type BasicAuthentication struct {
 	Password string
 }
 
+// AccessKey is the access key used to sign the requests sent to an S3 service.
+type AccessKey struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
 type AuthConfiguration struct {
 	AuthType            AuthType
 	BearerToken         string
 	APIKey              string
 	BasicAuthentication *BasicAuthentication
+	AccessKey           *AccessKey
 }
 
 // GetAuthConfigurationFromSecret returns the authentication from a Secret.
@@ -103,6 +113,22 @@ func GetAuthConfigurationFromSecret(ctx context.Context, authType AuthType, secr
 				Password: password,
 			},
 		}, nil
+	case AccessKeyType:
+		accessKeyID, err := parseAuthValue(secret.Data, SecretKeyWithAccessKeyID)
+		if err != nil {
+			return nil, err
+		}
+		secretAccessKey, err := parseAuthValue(secret.Data, SecretKeyWithSecretAccessKey)
+		if err != nil {
+			return nil, err
+		}
+		return &AuthConfiguration{
+			AuthType: AccessKeyType,
+			AccessKey: &AccessKey{
+				AccessKeyID:     accessKeyID,
+				SecretAccessKey: secretAccessKey,
+			},
+		}, nil
 	}
 	return nil, fmt.Errorf("unsupported authentication type %s", authType)
 }
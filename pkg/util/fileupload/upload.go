@@ -0,0 +1,119 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileupload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	"antrea.io/antrea/v2/pkg/util/auth"
+	"antrea.io/antrea/v2/pkg/util/httpupload"
+	"antrea.io/antrea/v2/pkg/util/s3upload"
+	"antrea.io/antrea/v2/pkg/util/sftp"
+)
+
+type Protocol string
+
+const (
+	SFTPProtocol  Protocol = "sftp"
+	S3Protocol    Protocol = "s3"
+	HTTPProtocol  Protocol = "http"
+	HTTPSProtocol Protocol = "https"
+)
+
+// GetProtocol returns the protocol used to upload files to the file server with the given URL.
+func GetProtocol(fileServerURL string) (Protocol, error) {
+	parsedURL, err := url.Parse(fileServerURL)
+	// The sftp protocol is used if the URL has no scheme, e.g. 10.92.23.154:22/path, which url.Parse fails to parse.
+	if err != nil || parsedURL.Scheme == "" {
+		return SFTPProtocol, nil
+	}
+	protocol := Protocol(parsedURL.Scheme)
+	switch protocol {
+	case SFTPProtocol, S3Protocol, HTTPProtocol, HTTPSProtocol:
+		return protocol, nil
+	}
+	return "", fmt.Errorf("unsupported protocol %s", protocol)
+}
+
+// FileServer specifies the file server to which files are uploaded.
+type FileServer struct {
+	// URL is the URL of the file server. Its scheme determines the protocol used to upload files.
+	URL string
+	// HostPublicKey is the only host public key accepted when connecting to an SFTP server. If empty,
+	// any host key is accepted.
+	HostPublicKey []byte
+	// S3Endpoint is the URL of an S3-compatible service. If empty, AWS S3 is used.
+	S3Endpoint string
+	// S3Region is the region of the S3 bucket.
+	S3Region string
+}
+
+// Uploader uploads files to a file server with the protocol given by the scheme of its URL.
+type Uploader struct {
+	SFTPUploader sftp.Uploader
+	S3Uploader   s3upload.Uploader
+	HTTPUploader httpupload.Uploader
+}
+
+func NewUploader() *Uploader {
+	return &Uploader{
+		SFTPUploader: sftp.NewUploader(),
+		S3Uploader:   s3upload.NewUploader(),
+		HTTPUploader: httpupload.NewUploader(),
+	}
+}
+
+// Upload uploads a file to the file server with the given name. serverAuth must contain a username and a password
+// for the sftp protocol, and an access key for the s3 protocol. For the http and https protocols, it can be nil, or
+// contain a bearer token, an API key or a username and a password.
+func (u *Uploader) Upload(ctx context.Context, fileServer *FileServer, serverAuth *auth.AuthConfiguration, fileName string, file io.ReadSeeker) error {
+	protocol, err := GetProtocol(fileServer.URL)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("error when setting offset of the file: %w", err)
+	}
+	switch protocol {
+	case S3Protocol:
+		if serverAuth == nil || serverAuth.AccessKey == nil {
+			return fmt.Errorf("access key is required to upload files to S3")
+		}
+		cfg := &s3upload.Config{
+			Endpoint:        fileServer.S3Endpoint,
+			Region:          fileServer.S3Region,
+			AccessKeyID:     serverAuth.AccessKey.AccessKeyID,
+			SecretAccessKey: serverAuth.AccessKey.SecretAccessKey,
+		}
+		return u.S3Uploader.Upload(ctx, fileServer.URL, fileName, cfg, file)
+	case HTTPProtocol, HTTPSProtocol:
+		return u.HTTPUploader.Upload(ctx, fileServer.URL, fileName, serverAuth, file)
+	}
+	if serverAuth == nil || serverAuth.BasicAuthentication == nil {
+		return fmt.Errorf("username and password are required to upload files to an SFTP server")
+	}
+	cfg, err := sftp.GetSSHClientConfig(
+		serverAuth.BasicAuthentication.Username,
+		serverAuth.BasicAuthentication.Password,
+		fileServer.HostPublicKey,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate SSH client config: %w", err)
+	}
+	return u.SFTPUploader.Upload(fileServer.URL, fileName, cfg, file)
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileupload
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"antrea.io/antrea/v2/pkg/util/auth"
+	"antrea.io/antrea/v2/pkg/util/s3upload"
+)
+
+type uploadCall struct {
+	protocol   Protocol
+	url        string
+	fileName   string
+	user       string
+	s3Config   *s3upload.Config
+	serverAuth *auth.AuthConfiguration
+	data       string
+}
+
+type fakeUploader struct {
+	calls []uploadCall
+}
+
+func (f *fakeUploader) record(call uploadCall, file io.Reader) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	call.data = string(data)
+	f.calls = append(f.calls, call)
+	return nil
+}
+
+type fakeSFTPUploader struct{ *fakeUploader }
+
+func (f fakeSFTPUploader) Upload(url string, fileName string, config *ssh.ClientConfig, file io.Reader) error {
+	return f.record(uploadCall{protocol: SFTPProtocol, url: url, fileName: fileName, user: config.User}, file)
+}
+
+type fakeS3Uploader struct{ *fakeUploader }
+
+func (f fakeS3Uploader) Upload(ctx context.Context, url string, fileName string, config *s3upload.Config, file io.ReadSeeker) error {
+	return f.record(uploadCall{protocol: S3Protocol, url: url, fileName: fileName, s3Config: config}, file)
+}
+
+type fakeHTTPUploader struct{ *fakeUploader }
+
+func (f fakeHTTPUploader) Upload(ctx context.Context, url string, fileName string, serverAuth *auth.AuthConfiguration, file io.ReadSeeker) error {
+	return f.record(uploadCall{protocol: HTTPSProtocol, url: url, fileName: fileName, serverAuth: serverAuth}, file)
+}
+
+func TestGetProtocol(t *testing.T) {
+	for _, tc := range []struct {
+		url              string
+		expectedProtocol Protocol
+		expectedErr      string
+	}{
+		{url: "sftp://10.0.0.1:22/upload", expectedProtocol: SFTPProtocol},
+		{url: "10.0.0.1:22/upload", expectedProtocol: SFTPProtocol},
+		{url: "s3://bucket/prefix", expectedProtocol: S3Protocol},
+		{url: "http://10.0.0.1/upload", expectedProtocol: HTTPProtocol},
+		{url: "https://api.example.com:8443/v1/supportbundles/", expectedProtocol: HTTPSProtocol},
+		{url: "ftp://10.0.0.1/upload", expectedErr: "unsupported protocol ftp"},
+	} {
+		t.Run(tc.url, func(t *testing.T) {
+			protocol, err := GetProtocol(tc.url)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedProtocol, protocol)
+		})
+	}
+}
+
+func TestUpload(t *testing.T) {
+	basicAuth := &auth.AuthConfiguration{
+		AuthType:            auth.BasicAuthenticationType,
+		BasicAuthentication: &auth.BasicAuthentication{Username: "user", Password: "pass"},
+	}
+	accessKeyAuth := &auth.AuthConfiguration{
+		AuthType:  auth.AccessKeyType,
+		AccessKey: &auth.AccessKey{AccessKeyID: "id", SecretAccessKey: "secret"},
+	}
+	apiKeyAuth := &auth.AuthConfiguration{
+		AuthType: auth.APIKeyType,
+		APIKey:   "key",
+	}
+	for _, tc := range []struct {
+		name         string
+		fileServer   FileServer
+		serverAuth   *auth.AuthConfiguration
+		expectedCall *uploadCall
+		expectedErr  string
+	}{
+		{
+			name:         "sftp",
+			fileServer:   FileServer{URL: "sftp://10.0.0.1:22/upload"},
+			serverAuth:   basicAuth,
+			expectedCall: &uploadCall{protocol: SFTPProtocol, url: "sftp://10.0.0.1:22/upload", fileName: "bundle.tar.gz", user: "user", data: "bundle"},
+		},
+		{
+			name:        "sftp without username",
+			fileServer:  FileServer{URL: "10.0.0.1:22/upload"},
+			serverAuth:  apiKeyAuth,
+			expectedErr: "username and password are required to upload files to an SFTP server",
+		},
+		{
+			name:        "sftp with invalid host key",
+			fileServer:  FileServer{URL: "sftp://10.0.0.1:22/upload", HostPublicKey: []byte("abc")},
+			serverAuth:  basicAuth,
+			expectedErr: "failed to generate SSH client config: invalid host public key",
+		},
+		{
+			name:       "s3",
+			fileServer: FileServer{URL: "s3://bucket/prefix", S3Endpoint: "http://minio:9000", S3Region: "us-west-2"},
+			serverAuth: accessKeyAuth,
+			expectedCall: &uploadCall{protocol: S3Protocol, url: "s3://bucket/prefix", fileName: "bundle.tar.gz", data: "bundle", s3Config: &s3upload.Config{
+				Endpoint:        "http://minio:9000",
+				Region:          "us-west-2",
+				AccessKeyID:     "id",
+				SecretAccessKey: "secret",
+			}},
+		},
+		{
+			name:        "s3 without access key",
+			fileServer:  FileServer{URL: "s3://bucket/prefix"},
+			serverAuth:  basicAuth,
+			expectedErr: "access key is required to upload files to S3",
+		},
+		{
+			name:         "https",
+			fileServer:   FileServer{URL: "https://api.example.com/v1/supportbundles/"},
+			serverAuth:   apiKeyAuth,
+			expectedCall: &uploadCall{protocol: HTTPSProtocol, url: "https://api.example.com/v1/supportbundles/", fileName: "bundle.tar.gz", serverAuth: apiKeyAuth, data: "bundle"},
+		},
+		{
+			name:        "unsupported protocol",
+			fileServer:  FileServer{URL: "ftp://10.0.0.1/upload"},
+			serverAuth:  basicAuth,
+			expectedErr: "unsupported protocol ftp",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeUploader{}
+			uploader := &Uploader{
+				SFTPUploader: fakeSFTPUploader{fake},
+				S3Uploader:   fakeS3Uploader{fake},
+				HTTPUploader: fakeHTTPUploader{fake},
+			}
+			file := strings.NewReader("bundle")
+			// Move the offset to check that the whole file is uploaded.
+			_, err := file.Seek(0, io.SeekEnd)
+			require.NoError(t, err)
+			err = uploader.Upload(context.Background(), &tc.fileServer, tc.serverAuth, "bundle.tar.gz", file)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.Empty(t, fake.calls)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []uploadCall{*tc.expectedCall}, fake.calls)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpupload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/v2/pkg/util/auth"
+)
+
+const (
+	uploadToFileServerMaxRetries = 5
+	uploadToFileServerRetryDelay = 5 * time.Second
+	uploadTimeout                = 5 * time.Minute
+	// apiKeyHeader is the header in which the API key is sent to the file server.
+	apiKeyHeader = "X-API-Key"
+)
+
+// ParseHTTPUploadUrl parses a URL with format http(s)://host[:port][/path].
+func ParseHTTPUploadUrl(uploadUrl string) (*url.URL, error) {
+	parsedURL, err := url.Parse(uploadUrl)
+	if err != nil {
+		return nil, err
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return nil, fmt.Errorf("not http or https protocol")
+	}
+	if parsedURL.Host == "" {
+		return nil, fmt.Errorf("missing host")
+	}
+	return parsedURL, nil
+}
+
+type Uploader interface {
+	// Upload uploads a file with a PUT request to the target URL, with the file name appended to its path.
+	// serverAuth can be nil, or contain a bearer token, an API key or a username and password for basic authentication.
+	Upload(ctx context.Context, url string, fileName string, serverAuth *auth.AuthConfiguration, file io.ReadSeeker) error
+}
+
+type httpUploader struct {
+	client     *http.Client
+	retryDelay time.Duration
+}
+
+func NewUploader() Uploader {
+	return &httpUploader{
+		client:     &http.Client{Timeout: uploadTimeout},
+		retryDelay: uploadToFileServerRetryDelay,
+	}
+}
+
+func (uploader *httpUploader) Upload(ctx context.Context, url string, fileName string, serverAuth *auth.AuthConfiguration, file io.ReadSeeker) error {
+	parsedURL, err := ParseHTTPUploadUrl(url)
+	if err != nil {
+		return err
+	}
+	targetURL := parsedURL.JoinPath(fileName).String()
+	if serverAuth != nil {
+		switch serverAuth.AuthType {
+		case auth.BearerTokenType, auth.APIKeyType, auth.BasicAuthenticationType:
+		default:
+			return fmt.Errorf("unsupported authentication type %s", serverAuth.AuthType)
+		}
+	}
+
+	retries := 0
+	var uploadErr error
+	for {
+		if uploadErr = uploader.upload(ctx, targetURL, serverAuth, file); uploadErr == nil {
+			return nil
+		}
+		retries++
+		if retries >= uploadToFileServerMaxRetries {
+			return fmt.Errorf("failed to upload file after %d attempts: %w", uploadToFileServerMaxRetries, uploadErr)
+		}
+		klog.ErrorS(uploadErr, "Failed to upload file after retries", "retries", retries)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(uploader.retryDelay):
+		}
+	}
+}
+
+func (uploader *httpUploader) upload(ctx context.Context, targetURL string, serverAuth *auth.AuthConfiguration, file io.ReadSeeker) error {
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("error when getting the size of the file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("error when setting offset of the file: %w", err)
+	}
+	// The body is wrapped so that the file is not closed by the client.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, targetURL, io.NopCloser(file))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if serverAuth != nil {
+		switch serverAuth.AuthType {
+		case auth.BearerTokenType:
+			req.Header.Set("Authorization", "Bearer "+serverAuth.BearerToken)
+		case auth.APIKeyType:
+			req.Header.Set(apiKeyHeader, serverAuth.APIKey)
+		case auth.BasicAuthenticationType:
+			req.SetBasicAuth(serverAuth.BasicAuthentication.Username, serverAuth.BasicAuthentication.Password)
+		}
+	}
+	resp, err := uploader.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error when sending request to the file server: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("file server returned status %s: %s", resp.Status, body)
+	}
+	klog.InfoS("Successfully uploaded file to URL", "url", targetURL)
+	return nil
+}
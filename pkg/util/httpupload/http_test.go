@@ -0,0 +1,152 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpupload
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"antrea.io/antrea/v2/pkg/util/auth"
+)
+
+func TestParseHTTPUploadUrl(t *testing.T) {
+	cases := []struct {
+		url           string
+		expectedError string
+	}{
+		{
+			url: "https://api.example.com:8443/v1/packets/",
+		},
+		{
+			url: "http://10.0.0.1/upload",
+		},
+		{
+			url:           "http:///upload",
+			expectedError: "missing host",
+		},
+		{
+			url:           "sftp://127.0.0.1:22/path",
+			expectedError: "not http or https protocol",
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := ParseHTTPUploadUrl(tc.url)
+		if tc.expectedError == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, tc.expectedError)
+		}
+	}
+}
+
+func TestUpload(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		serverAuth            *auth.AuthConfiguration
+		statusCodes           []int
+		expectedAuthorization string
+		expectedAPIKey        string
+		expectedRequests      int
+		expectedErr           string
+	}{
+		{
+			name:             "no authentication",
+			statusCodes:      []int{http.StatusCreated},
+			expectedRequests: 1,
+		},
+		{
+			name: "bearer token",
+			serverAuth: &auth.AuthConfiguration{
+				AuthType:    auth.BearerTokenType,
+				BearerToken: "token",
+			},
+			statusCodes:           []int{http.StatusOK},
+			expectedAuthorization: "Bearer token",
+			expectedRequests:      1,
+		},
+		{
+			name: "API key",
+			serverAuth: &auth.AuthConfiguration{
+				AuthType: auth.APIKeyType,
+				APIKey:   "key",
+			},
+			statusCodes:      []int{http.StatusOK},
+			expectedAPIKey:   "key",
+			expectedRequests: 1,
+		},
+		{
+			name: "basic authentication",
+			serverAuth: &auth.AuthConfiguration{
+				AuthType:            auth.BasicAuthenticationType,
+				BasicAuthentication: &auth.BasicAuthentication{Username: "user", Password: "pass"},
+			},
+			statusCodes:           []int{http.StatusOK},
+			expectedAuthorization: "Basic dXNlcjpwYXNz",
+			expectedRequests:      1,
+		},
+		{
+			name:             "retry",
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedRequests: 2,
+		},
+		{
+			name:             "failure",
+			statusCodes:      []int{http.StatusForbidden},
+			expectedRequests: uploadToFileServerMaxRetries,
+			expectedErr:      "failed to upload file after 5 attempts: file server returned status 403 Forbidden",
+		},
+		{
+			name: "unsupported authentication",
+			serverAuth: &auth.AuthConfiguration{
+				AuthType:  auth.AccessKeyType,
+				AccessKey: &auth.AccessKey{AccessKeyID: "id", SecretAccessKey: "secret"},
+			},
+			expectedErr: "unsupported authentication type AccessKey",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/v1/packets/pc.pcapng", r.URL.Path)
+				assert.Equal(t, tc.expectedAuthorization, r.Header.Get("Authorization"))
+				assert.Equal(t, tc.expectedAPIKey, r.Header.Get(apiKeyHeader))
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, "packets", string(body))
+				w.WriteHeader(tc.statusCodes[min(requests, len(tc.statusCodes))-1])
+			}))
+			defer server.Close()
+
+			uploader := &httpUploader{client: server.Client()}
+			err := uploader.Upload(context.Background(), server.URL+"/v1/packets/", "pc.pcapng", tc.serverAuth, strings.NewReader("packets"))
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
@@ -16,9 +16,12 @@ package objectstore
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -63,6 +66,26 @@ func (s *podStore) GetPodByIPAndTime(ip string, startTime time.Time) (*corev1.Po
 	return s.GetObjectByIndexAndTime(podIPIndex, ip, startTime)
 }
 
+// GetPodOwnerWorkload returns the kind and name of the workload which owns the Pod (e.g.,
+// Deployment, StatefulSet, DaemonSet), based on the controller reference of the Pod. Empty
+// strings are returned for Pods without a controller. The ReplicaSets created by a Deployment
+// are named after the Deployment and the pod-template-hash label of their Pods, so the
+// Deployment can be resolved without having to watch ReplicaSets.
+func GetPodOwnerWorkload(pod *corev1.Pod) (kind string, name string) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "", ""
+	}
+	if owner.Kind == "ReplicaSet" && owner.APIVersion == appsv1.SchemeGroupVersion.String() {
+		if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" {
+			if deploymentName, ok := strings.CutSuffix(owner.Name, "-"+hash); ok && deploymentName != "" {
+				return "Deployment", deploymentName
+			}
+		}
+	}
+	return owner.Kind, owner.Name
+}
+
 func podIPIndexFunc(obj interface{}) ([]string, error) {
 	pod, ok := obj.(*corev1.Pod)
 	if !ok {
@@ -189,6 +189,85 @@ func Test_podIPIndexFunc(t *testing.T) {
 	}
 }
 
+func TestGetPodOwnerWorkload(t *testing.T) {
+	newPod := func(labels map[string]string, ownerReferences ...metav1.OwnerReference) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "pod",
+				Namespace:       "ns",
+				Labels:          labels,
+				OwnerReferences: ownerReferences,
+			},
+		}
+	}
+	newOwnerReference := func(apiVersion, kind, name string, controller bool) metav1.OwnerReference {
+		return metav1.OwnerReference{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Name:       name,
+			Controller: &controller,
+		}
+	}
+	tests := []struct {
+		name         string
+		pod          *corev1.Pod
+		expectedKind string
+		expectedName string
+	}{
+		{
+			name: "no owner",
+			pod:  newPod(nil),
+		},
+		{
+			name: "owner is not a controller",
+			pod:  newPod(nil, newOwnerReference("apps/v1", "StatefulSet", "web", false)),
+		},
+		{
+			name:         "Deployment",
+			pod:          newPod(map[string]string{"pod-template-hash": "5d8f7c9b4"}, newOwnerReference("apps/v1", "ReplicaSet", "web-5d8f7c9b4", true)),
+			expectedKind: "Deployment",
+			expectedName: "web",
+		},
+		{
+			name:         "ReplicaSet without pod-template-hash",
+			pod:          newPod(nil, newOwnerReference("apps/v1", "ReplicaSet", "web-5d8f7c9b4", true)),
+			expectedKind: "ReplicaSet",
+			expectedName: "web-5d8f7c9b4",
+		},
+		{
+			name:         "ReplicaSet not named after pod-template-hash",
+			pod:          newPod(map[string]string{"pod-template-hash": "5d8f7c9b4"}, newOwnerReference("apps/v1", "ReplicaSet", "web", true)),
+			expectedKind: "ReplicaSet",
+			expectedName: "web",
+		},
+		{
+			name:         "StatefulSet",
+			pod:          newPod(nil, newOwnerReference("apps/v1", "StatefulSet", "db", true)),
+			expectedKind: "StatefulSet",
+			expectedName: "db",
+		},
+		{
+			name:         "DaemonSet",
+			pod:          newPod(nil, newOwnerReference("apps/v1", "DaemonSet", "agent", true)),
+			expectedKind: "DaemonSet",
+			expectedName: "agent",
+		},
+		{
+			name:         "Job",
+			pod:          newPod(nil, newOwnerReference("batch/v1", "Job", "backup-29145600", true)),
+			expectedKind: "Job",
+			expectedName: "backup-29145600",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, name := GetPodOwnerWorkload(tt.pod)
+			assert.Equal(t, tt.expectedKind, kind)
+			assert.Equal(t, tt.expectedName, name)
+		})
+	}
+}
+
 func Test_noHostNetworkPod(t *testing.T) {
 	k8sClient := fake.NewSimpleClientset(hostNetworkPod, testPod1)
 	podInformer := getPodInformer(k8sClient)
@@ -0,0 +1,105 @@
+// Copyright 2026 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3upload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"k8s.io/klog/v2"
+)
+
+const defaultRegion = "us-east-1"
+
+// Config contains the information required to access an S3 service.
+type Config struct {
+	// Endpoint is the URL of an S3-compatible service. If empty, AWS S3 is used.
+	Endpoint string
+	// Region is the region of the bucket. If empty, us-east-1 is used.
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// ParseS3UploadUrl parses a URL with format s3://bucket[/prefix], and returns the bucket and the prefix.
+func ParseS3UploadUrl(uploadUrl string) (string, string, error) {
+	parsedURL, err := url.Parse(uploadUrl)
+	if err != nil {
+		return "", "", err
+	}
+	if parsedURL.Scheme != "s3" {
+		return "", "", fmt.Errorf("not s3 protocol")
+	}
+	if parsedURL.Host == "" {
+		return "", "", fmt.Errorf("missing bucket name")
+	}
+	return parsedURL.Host, strings.Trim(parsedURL.Path, "/"), nil
+}
+
+type Uploader interface {
+	// Upload uploads a file to the target S3 bucket, using the file name as the key under the prefix of the URL.
+	Upload(ctx context.Context, url string, fileName string, config *Config, file io.ReadSeeker) error
+}
+
+type s3Uploader struct {
+}
+
+func NewUploader() Uploader {
+	return &s3Uploader{}
+}
+
+func (uploader *s3Uploader) Upload(ctx context.Context, url string, fileName string, config *Config, file io.ReadSeeker) error {
+	// url should be like: s3://bucket/prefix
+	bucket, prefix, err := ParseS3UploadUrl(url)
+	if err != nil {
+		return err
+	}
+	key := path.Join(prefix, fileName)
+	// The SDK retries the request if it fails with a retryable error.
+	if _, err := newClient(config).PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   file,
+	}); err != nil {
+		return fmt.Errorf("error when uploading file to bucket %s: %w", bucket, err)
+	}
+	klog.InfoS("Successfully uploaded file to S3 bucket", "bucket", bucket, "key", key)
+	return nil
+}
+
+func newClient(config *Config) *s3.Client {
+	region := config.Region
+	if region == "" {
+		region = defaultRegion
+	}
+	awsCfg := aws.Config{
+		Region:      region,
+		Credentials: credentials.NewStaticCredentialsProvider(config.AccessKeyID, config.SecretAccessKey, ""),
+	}
+	return s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if config.Endpoint != "" {
+			o.BaseEndpoint = aws.String(config.Endpoint)
+			// S3-compatible services usually don't support virtual-hosted-style addressing.
+			o.UsePathStyle = true
+		}
+	})
+}
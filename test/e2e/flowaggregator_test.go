@@ -2022,4 +2022,9 @@ type ClickHouseFullRow struct {
 	EgressName                           string    `json:"egressName"`
 	EgressIP                             string    `json:"egressIP"`
 	EgressNodeName                       string    `json:"egressNodeName"`
+	SourcePodOwnerKind                   string    `json:"sourcePodOwnerKind"`
+	SourcePodOwnerName                   string    `json:"sourcePodOwnerName"`
+	DestinationPodOwnerKind              string    `json:"destinationPodOwnerKind"`
+	DestinationPodOwnerName              string    `json:"destinationPodOwnerName"`
+	DestinationFQDN                      string    `json:"destinationFQDN"`
 }
@@ -27,6 +27,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	"antrea.io/antrea/v2/pkg/agent/bgp"
+	"antrea.io/antrea/v2/pkg/agent/bgp/bfd"
 	"antrea.io/antrea/v2/pkg/agent/bgp/gobgp"
 	"antrea.io/antrea/v2/pkg/apis/crd/v1alpha1"
 )
@@ -248,3 +249,334 @@ func TestGoBGPLifecycle(t *testing.T) {
 	require.NoError(t, server2.Stop(ctx))
 	t.Log("Stopped all BGP servers")
 }
+
+func TestGoBGPRoutePolicies(t *testing.T) {
+	asn1 := int32(61180)
+	asn2 := int32(62180)
+	listenPort1 := int32(1180)
+	listenPort2 := int32(2180)
+	server1 := gobgp.NewGoBGPServer(&bgp.GlobalConfig{
+		ASN:             uint32(asn1),
+		RouterID:        "127.0.0.1",
+		ListenPort:      listenPort1,
+		ListenAddresses: []string{"127.0.0.1"},
+	})
+	server2 := gobgp.NewGoBGPServer(&bgp.GlobalConfig{
+		ASN:             uint32(asn2),
+		RouterID:        "127.0.0.2",
+		ListenPort:      listenPort2,
+		ListenAddresses: []string{"127.0.0.2"},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, server1.Start(ctx))
+	defer server1.Stop(ctx)
+	require.NoError(t, server2.Start(ctx))
+	defer server2.Stop(ctx)
+
+	// Server1 only advertises the routes within 1.1.0.0/16 to server2, and adds communities to them.
+	server2PeerConfigForServer1 := bgp.PeerConfig{
+		BGPPeer: &v1alpha1.BGPPeer{
+			Address: "127.0.0.2",
+			Port:    &listenPort2,
+			ASN:     asn2,
+		},
+		LocalAddress:   "127.0.0.1",
+		ConnectionMode: bgp.ConnectionModePassive,
+		ExportRoutePolicy: &bgp.RoutePolicy{
+			Rules: []bgp.RoutePolicyRule{
+				{
+					Prefixes: []bgp.PrefixMatch{{Prefix: "1.1.0.0/16", OrLonger: true}},
+					Attributes: &bgp.PathAttributes{
+						Communities:      []string{"61180:100", "no-export"},
+						LargeCommunities: []string{"61180:1:2"},
+						MED:              ptr.To[uint32](50),
+					},
+				},
+			},
+			DefaultAction: bgp.RouteActionReject,
+		},
+	}
+	server1PeerConfigForServer2 := bgp.PeerConfig{
+		BGPPeer: &v1alpha1.BGPPeer{
+			Address: "127.0.0.1",
+			Port:    &listenPort1,
+			ASN:     asn1,
+		},
+		LocalAddress:   "127.0.0.2",
+		ConnectionMode: bgp.ConnectionModeActive,
+	}
+	require.NoError(t, server1.AddPeer(ctx, server2PeerConfigForServer1))
+	require.NoError(t, server2.AddPeer(ctx, server1PeerConfigForServer2))
+
+	server1Routes := []bgp.Route{
+		{Prefix: "1.1.1.0/24"},
+		{Prefix: "1.1.2.0/24"},
+		{Prefix: "1.2.1.0/24"},
+	}
+	require.NoError(t, server1.AdvertiseRoutes(ctx, server1Routes))
+
+	getRoutesFn := func(server bgp.Interface, routeType bgp.RouteType, peerAddress string) []bgp.Route {
+		routes, err := server.GetRoutes(ctx, routeType, peerAddress)
+		if err != nil {
+			return nil
+		}
+		return routes
+	}
+
+	t.Log("Verifying the routes advertised by BGP server1 with the export policy")
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		expected := []bgp.Route{{Prefix: "1.1.1.0/24"}, {Prefix: "1.1.2.0/24"}}
+		assert.ElementsMatch(t, expected, getRoutesFn(server1, bgp.RouteAdvertised, "127.0.0.2"))
+		assert.ElementsMatch(t, expected, getRoutesFn(server2, bgp.RouteReceived, "127.0.0.1"))
+	}, 30*time.Second, time.Second)
+
+	t.Log("Updating the export policy of BGP server1 to reject 1.1.1.0/24 only")
+	server2PeerConfigForServer1.ExportRoutePolicy = &bgp.RoutePolicy{
+		Rules: []bgp.RoutePolicyRule{
+			{
+				Prefixes: []bgp.PrefixMatch{{Prefix: "1.1.1.0/24"}},
+				Action:   bgp.RouteActionReject,
+			},
+		},
+	}
+	require.NoError(t, server1.UpdatePeer(ctx, server2PeerConfigForServer1))
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		expected := []bgp.Route{{Prefix: "1.1.2.0/24"}, {Prefix: "1.2.1.0/24"}}
+		assert.ElementsMatch(t, expected, getRoutesFn(server1, bgp.RouteAdvertised, "127.0.0.2"))
+		assert.ElementsMatch(t, expected, getRoutesFn(server2, bgp.RouteReceived, "127.0.0.1"))
+	}, 30*time.Second, time.Second)
+
+	t.Log("Removing the export policy of BGP server1")
+	server2PeerConfigForServer1.ExportRoutePolicy = nil
+	require.NoError(t, server1.UpdatePeer(ctx, server2PeerConfigForServer1))
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		assert.ElementsMatch(t, server1Routes, getRoutesFn(server2, bgp.RouteReceived, "127.0.0.1"))
+	}, 30*time.Second, time.Second)
+}
+
+func TestGoBGPReceivedRoutes(t *testing.T) {
+	asn1 := int32(61181)
+	asn2 := int32(62181)
+	listenPort1 := int32(1181)
+	listenPort2 := int32(2181)
+	server1 := gobgp.NewGoBGPServer(&bgp.GlobalConfig{
+		ASN:             uint32(asn1),
+		RouterID:        "127.0.0.1",
+		ListenPort:      listenPort1,
+		ListenAddresses: []string{"127.0.0.1"},
+	})
+	server2 := gobgp.NewGoBGPServer(&bgp.GlobalConfig{
+		ASN:             uint32(asn2),
+		RouterID:        "127.0.0.2",
+		ListenPort:      listenPort2,
+		ListenAddresses: []string{"127.0.0.2"},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, server1.Start(ctx))
+	defer server1.Stop(ctx)
+	require.NoError(t, server2.Start(ctx))
+	defer server2.Stop(ctx)
+
+	server2PeerConfigForServer1 := bgp.PeerConfig{
+		BGPPeer: &v1alpha1.BGPPeer{
+			Address: "127.0.0.2",
+			Port:    &listenPort2,
+			ASN:     asn2,
+		},
+		LocalAddress:   "127.0.0.1",
+		ConnectionMode: bgp.ConnectionModePassive,
+	}
+	// Server2 rejects the routes within 1.2.0.0/16 received from server1.
+	server1PeerConfigForServer2 := bgp.PeerConfig{
+		BGPPeer: &v1alpha1.BGPPeer{
+			Address: "127.0.0.1",
+			Port:    &listenPort1,
+			ASN:     asn1,
+		},
+		LocalAddress:   "127.0.0.2",
+		ConnectionMode: bgp.ConnectionModeActive,
+		ImportRoutePolicy: &bgp.RoutePolicy{
+			Rules: []bgp.RoutePolicyRule{
+				{
+					Prefixes: []bgp.PrefixMatch{{Prefix: "1.2.0.0/16", OrLonger: true}},
+					Action:   bgp.RouteActionReject,
+				},
+			},
+		},
+	}
+	require.NoError(t, server1.AddPeer(ctx, server2PeerConfigForServer1))
+	require.NoError(t, server2.AddPeer(ctx, server1PeerConfigForServer2))
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	updateCh := make(chan struct{}, 1)
+	require.NoError(t, server2.WatchReceivedRoutes(watchCtx, func() {
+		select {
+		case updateCh <- struct{}{}:
+		default:
+		}
+	}))
+	waitForUpdate := func() {
+		select {
+		case <-updateCh:
+		case <-time.After(30 * time.Second):
+			require.Fail(t, "Received routes were not updated")
+		}
+	}
+
+	// The routes originated by server2 should not be considered received routes.
+	require.NoError(t, server2.AdvertiseRoutes(ctx, []bgp.Route{{Prefix: "2.2.2.0/24"}}))
+	require.NoError(t, server1.AdvertiseRoutes(ctx, []bgp.Route{
+		{Prefix: "1.1.1.0/24"},
+		{Prefix: "1.1.2.0/24"},
+		{Prefix: "1.2.1.0/24"},
+	}))
+
+	getReceivedRoutesFn := func() []bgp.ReceivedRoute {
+		routes, err := server2.GetReceivedRoutes(ctx)
+		if err != nil {
+			return nil
+		}
+		return routes
+	}
+
+	t.Log("Verifying the routes received by BGP server2 with the import policy")
+	waitForUpdate()
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		expected := []bgp.ReceivedRoute{
+			{Prefix: "1.1.1.0/24", NextHop: "127.0.0.1", PeerAddress: "127.0.0.1"},
+			{Prefix: "1.1.2.0/24", NextHop: "127.0.0.1", PeerAddress: "127.0.0.1"},
+		}
+		assert.ElementsMatch(t, expected, getReceivedRoutesFn())
+	}, 30*time.Second, time.Second)
+
+	t.Log("Withdrawing a route from BGP server1")
+	// Drain any pending notification before withdrawing the route.
+	select {
+	case <-updateCh:
+	default:
+	}
+	require.NoError(t, server1.WithdrawRoutes(ctx, []bgp.Route{{Prefix: "1.1.1.0/24"}}))
+	waitForUpdate()
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		expected := []bgp.ReceivedRoute{
+			{Prefix: "1.1.2.0/24", NextHop: "127.0.0.1", PeerAddress: "127.0.0.1"},
+		}
+		assert.ElementsMatch(t, expected, getReceivedRoutesFn())
+	}, 30*time.Second, time.Second)
+
+	t.Log("Removing BGP server1 from the peers of BGP server2")
+	require.NoError(t, server2.RemovePeer(ctx, server1PeerConfigForServer2))
+	waitForUpdate()
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		assert.Empty(t, getReceivedRoutesFn())
+	}, 30*time.Second, time.Second)
+}
+
+func TestGoBGPBFD(t *testing.T) {
+	asn1 := int32(61182)
+	asn2 := int32(62182)
+	listenPort1 := int32(1182)
+	listenPort2 := int32(2182)
+	bfdListenPort := int32(13784)
+	server1 := gobgp.NewGoBGPServer(&bgp.GlobalConfig{
+		ASN:             uint32(asn1),
+		RouterID:        "127.0.0.1",
+		ListenPort:      listenPort1,
+		ListenAddresses: []string{"127.0.0.1"},
+	})
+	server2 := gobgp.NewGoBGPServer(&bgp.GlobalConfig{
+		ASN:             uint32(asn2),
+		RouterID:        "127.0.0.2",
+		ListenPort:      listenPort2,
+		ListenAddresses: []string{"127.0.0.2"},
+		BFDListenPort:   bfdListenPort,
+	})
+
+	ctx := context.Background()
+	require.NoError(t, server1.Start(ctx))
+	defer server1.Stop(ctx)
+	require.NoError(t, server2.Start(ctx))
+	defer server2.Stop(ctx)
+
+	// The BFD session of server1 is run by a standalone BFD server, so that the path between the BGP servers can be
+	// failed by stopping it, without affecting the BGP session.
+	bfdConfig := &bgp.BFDConfig{
+		MinTxInterval: 50 * time.Millisecond,
+		MinRxInterval: 50 * time.Millisecond,
+		Multiplier:    3,
+	}
+	bfdServer1 := bfd.NewServer([]string{"127.0.0.1"}, int(bfdListenPort), func(bfd.StateChange) {})
+	defer bfdServer1.Stop()
+	require.NoError(t, bfdServer1.AddSession("127.0.0.2", "127.0.0.1", bfd.SessionConfig{
+		MinTxInterval: bfdConfig.MinTxInterval,
+		MinRxInterval: bfdConfig.MinRxInterval,
+		Multiplier:    bfdConfig.Multiplier,
+	}))
+
+	server2PeerConfigForServer1 := bgp.PeerConfig{
+		BGPPeer: &v1alpha1.BGPPeer{
+			Address: "127.0.0.2",
+			Port:    &listenPort2,
+			ASN:     asn2,
+		},
+		LocalAddress:   "127.0.0.1",
+		ConnectionMode: bgp.ConnectionModePassive,
+	}
+	server1PeerConfigForServer2 := bgp.PeerConfig{
+		BGPPeer: &v1alpha1.BGPPeer{
+			Address: "127.0.0.1",
+			Port:    &listenPort1,
+			ASN:     asn1,
+		},
+		LocalAddress:   "127.0.0.2",
+		ConnectionMode: bgp.ConnectionModeActive,
+		BFDConfig:      bfdConfig,
+	}
+	require.NoError(t, server1.AddPeer(ctx, server2PeerConfigForServer1))
+	require.NoError(t, server2.AddPeer(ctx, server1PeerConfigForServer2))
+	require.NoError(t, server1.AdvertiseRoutes(ctx, []bgp.Route{{Prefix: "1.1.1.0/24"}}))
+
+	getPeerFn := func() *bgp.PeerStatus {
+		peers, err := server2.GetPeers(ctx)
+		if err != nil || len(peers) != 1 {
+			return nil
+		}
+		return &peers[0]
+	}
+
+	t.Log("Verifying that the BGP session and the BFD session of BGP server2 are up")
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		peer := getPeerFn()
+		if !assert.NotNil(t, peer) {
+			return
+		}
+		assert.Equal(t, bgp.SessionEstablished, peer.SessionState)
+		assert.Equal(t, "Up", peer.BFDSessionState)
+		assert.Equal(t, 1, peer.ReceivedRoutes)
+	}, 30*time.Second, 100*time.Millisecond)
+	state, _ := bfdServer1.GetSessionState("127.0.0.2")
+	assert.Equal(t, bfd.StateUp, state)
+
+	t.Log("Failing the path between the BGP servers and verifying that the BGP session is reset")
+	bfdServer1.Stop()
+	// The failure should be detected by BFD long before the hold timer expires.
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		peer := getPeerFn()
+		if !assert.NotNil(t, peer) {
+			return
+		}
+		assert.Equal(t, "Down", peer.BFDSessionState)
+		assert.NotEqual(t, bgp.SessionEstablished, peer.SessionState)
+		assert.Zero(t, peer.ReceivedRoutes)
+	}, 5*time.Second, 100*time.Millisecond)
+
+	t.Log("Disabling BFD for BGP server1")
+	server1PeerConfigForServer2.BFDConfig = nil
+	require.NoError(t, server2.UpdatePeer(ctx, server1PeerConfigForServer2))
+	peer := getPeerFn()
+	require.NotNil(t, peer)
+	assert.Empty(t, peer.BFDSessionState)
+}